@@ -58,6 +58,26 @@
 //   - types.FileWithBytes <-> protocol.FileWithBytes (base64 encoding)
 //   - types.FileWithURI <-> protocol.FileWithURI
 //
+// # Signed A2A
+//
+// Plain A2A has no sender authentication. Clients can sign their JSON-RPC
+// requests with the agent's Ed25519 key using RFC 9421 HTTP message
+// signatures, and servers can require them:
+//
+//	client, err := a2a.NewClient(peerURL, a2a.WithSigningKey(key, "did:sage:local:alice#key-1"))
+//
+//	server, err := a2a.NewServer(&a2a.ServerConfig{
+//	    ...
+//	    Middleware: a2a.VerifySignatures(httpsig.StaticKeys(peerKeys), &httpsig.VerifierConfig{
+//	        Guard: replay.NewMemory(0),
+//	    }),
+//	})
+//
+// Senders' keys are resolved from a static key map or through a SAGE
+// DIDResolver (DIDResolver.HTTPKeyResolver). Without a replay Guard, a
+// captured request verifies again until its signature ages out. Message
+// handlers get the verified sender's DID from Sender(ctx).
+//
 // # Limitations
 //
 //   - ReceiveMessage() is not supported (A2A uses request-response model)
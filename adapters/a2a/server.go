@@ -19,8 +19,15 @@ package a2a
 
 import (
 	"context"
+	"crypto/tls"
+	"net/http"
+	"sync"
 
 	"github.com/sage-x-project/sage-adk/core/agent"
+	"github.com/sage-x-project/sage-adk/core/authn"
+	"github.com/sage-x-project/sage-adk/core/authz"
+	"github.com/sage-x-project/sage-adk/core/task"
+	"github.com/sage-x-project/sage-adk/pkg/mtls"
 	"github.com/sage-x-project/sage-adk/pkg/types"
 	a2aprotocol "trpc.group/trpc-go/trpc-a2a-go/protocol"
 	a2aserver "trpc.group/trpc-go/trpc-a2a-go/server"
@@ -31,8 +38,14 @@ import (
 //
 // It integrates with the agent's message handler to process incoming requests.
 type Server struct {
-	server  *a2aserver.A2AServer
-	handler agent.MessageHandler
+	server     *a2aserver.A2AServer
+	handler    agent.MessageHandler
+	middleware func(http.Handler) http.Handler
+	authn      *authn.Authenticator
+	tlsConfig  *tls.Config
+	tasks      task.Store
+	mu         sync.Mutex
+	httpServer *http.Server
 }
 
 // ServerConfig configures the A2A server.
@@ -48,6 +61,30 @@ type ServerConfig struct {
 
 	// MessageHandler processes incoming messages
 	MessageHandler agent.MessageHandler
+
+	// Middleware, if set, wraps the server's HTTP handler, e.g. to require
+	// RFC 9421 HTTP message signatures with httpsig.Verifier.Middleware
+	Middleware func(http.Handler) http.Handler
+
+	// Authenticator, if set, requires an API key or bearer token on every
+	// request except health checks and agent card discovery. The
+	// principal is available to handlers through authn.PrincipalFromContext.
+	Authenticator *authn.Authenticator
+
+	// Authorizer, if set, authorizes every inbound message against its
+	// policy before the message handler runs. Denied messages are
+	// rejected without creating a task.
+	Authorizer *authz.Authorizer
+
+	// TLS, if set, serves HTTPS, optionally requiring client certificates.
+	// The verified client certificate is available to handlers through
+	// mtls.PeerFromContext.
+	TLS *mtls.Config
+
+	// Tasks records every processed message as a task. Share it with the
+	// gRPC server's ServerConfig.Tasks so that tasks can be looked up,
+	// canceled and followed over gRPC. Defaults to an in-memory store.
+	Tasks task.Store
 }
 
 // NewServer creates a new A2A server.
@@ -72,8 +109,13 @@ func NewServer(config *ServerConfig) (*Server, error) {
 		Version:     "0.1.0",
 	}
 
+	tasks := config.Tasks
+	if tasks == nil {
+		tasks = task.NewMemoryStore(nil)
+	}
+
 	// Create task manager with the message handler
-	taskMgr := newTaskManager(config.MessageHandler)
+	taskMgr := newTaskManager(config.MessageHandler, tasks, config.Authorizer)
 
 	// Create A2A server
 	a2aServer, err := a2aserver.NewA2AServer(agentCard, taskMgr)
@@ -81,28 +123,87 @@ func NewServer(config *ServerConfig) (*Server, error) {
 		return nil, err
 	}
 
+	var tlsConfig *tls.Config
+	if config.TLS.Enabled() {
+		tlsConfig, err = config.TLS.ServerTLS()
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	return &Server{
-		server:  a2aServer,
-		handler: config.MessageHandler,
+		server:     a2aServer,
+		handler:    config.MessageHandler,
+		middleware: config.Middleware,
+		authn:      config.Authenticator,
+		tlsConfig:  tlsConfig,
+		tasks:      tasks,
 	}, nil
 }
 
+// Tasks returns the store recording the server's tasks.
+func (s *Server) Tasks() task.Store {
+	return s.tasks
+}
+
 // Start starts the HTTP server on the given address.
 //
 // This is a blocking call that returns when the server stops.
 func (s *Server) Start(addr string) error {
-	return s.server.Start(addr)
+	if s.middleware == nil && s.authn == nil && s.tlsConfig == nil {
+		return s.server.Start(addr)
+	}
+
+	handler := s.server.Handler()
+	if s.middleware != nil {
+		handler = s.middleware(handler)
+	}
+	if s.authn != nil {
+		handler = s.authn.Middleware(handler)
+	}
+	if s.tlsConfig != nil {
+		handler = mtls.Middleware(handler)
+	}
+
+	s.mu.Lock()
+	s.httpServer = &http.Server{
+		Addr:      addr,
+		Handler:   handler,
+		TLSConfig: s.tlsConfig,
+	}
+	httpServer := s.httpServer
+	s.mu.Unlock()
+
+	var err error
+	if s.tlsConfig != nil {
+		err = httpServer.ListenAndServeTLS("", "")
+	} else {
+		err = httpServer.ListenAndServe()
+	}
+	if err != nil && err != http.ErrServerClosed {
+		return err
+	}
+	return nil
 }
 
 // Stop gracefully stops the HTTP server.
 func (s *Server) Stop(ctx context.Context) error {
+	s.mu.Lock()
+	httpServer := s.httpServer
+	s.mu.Unlock()
+
+	if httpServer != nil {
+		return httpServer.Shutdown(ctx)
+	}
 	return s.server.Stop(ctx)
 }
 
 // messageProcessor implements the taskmanager.MessageProcessor interface
 // to integrate with the agent's message handler.
 type messageProcessor struct {
-	handler agent.MessageHandler
+	handler    agent.MessageHandler
+	tasks      task.Store
+	authorizer *authz.Authorizer
 }
 
 // ProcessMessage processes an incoming message.
@@ -115,22 +216,42 @@ func (p *messageProcessor) ProcessMessage(
 	// Convert A2A message to sage-adk message
 	sdkMsg := convertA2AMessageToSDK(&msg)
 
-	// For now, we create a simple messageContext wrapper
-	// TODO: Implement proper MessageContext that supports Reply() and other methods
-	msgCtx := &simpleMessageContext{
-		message: sdkMsg,
+	// Authorize the message before it becomes a task; the handler's
+	// context carries the authorized caller for tool calls
+	if p.authorizer != nil {
+		var err error
+		if ctx, err = p.authorizer.AuthorizeMessage(ctx, sdkMsg); err != nil {
+			return nil, err
+		}
 	}
 
-	// Call the agent's message handler
-	if err := p.handler(ctx, msgCtx); err != nil {
+	// Record the message as a task, so that it can be looked up and
+	// canceled while the agent's message handler runs
+	_, reply, err := task.Run(ctx, p.tasks, sdkMsg, func(ctx context.Context, m *types.Message) (*types.Message, error) {
+		// For now, we create a simple messageContext wrapper
+		// TODO: Implement proper MessageContext that supports Reply() and other methods
+		msgCtx := &simpleMessageContext{
+			message: m,
+		}
+
+		// Call the agent's message handler
+		if err := p.handler(ctx, msgCtx); err != nil {
+			return nil, err
+		}
+
+		// For now, return a simple response message
+		// In a real implementation, this would be the agent's actual response
+		return types.NewMessage(types.MessageRoleAgent, []types.Part{}), nil
+	})
+	if err != nil {
 		return nil, err
 	}
 
-	// For now, return a simple response message
-	// In a real implementation, this would be the agent's actual response
 	response := &a2aprotocol.Message{
-		Role:  a2aprotocol.MessageRoleAgent,
-		Parts: []a2aprotocol.Part{},
+		Role:      a2aprotocol.MessageRoleAgent,
+		Parts:     []a2aprotocol.Part{},
+		TaskID:    reply.TaskID,
+		ContextID: reply.ContextID,
 	}
 
 	return &taskmanager.MessageProcessingResult{
@@ -185,16 +306,21 @@ func convertA2AMessageToSDK(msg *a2aprotocol.Message) *types.Message {
 	}
 
 	return &types.Message{
-		Role:  types.MessageRole(msg.Role),
-		Parts: parts,
+		MessageID: msg.MessageID,
+		Role:      types.MessageRole(msg.Role),
+		Parts:     parts,
+		ContextID: msg.ContextID,
+		TaskID:    msg.TaskID,
 	}
 }
 
 // newTaskManager creates a new task manager.
-func newTaskManager(handler agent.MessageHandler) taskmanager.TaskManager {
+func newTaskManager(handler agent.MessageHandler, tasks task.Store, authorizer *authz.Authorizer) taskmanager.TaskManager {
 	// Create message processor
 	processor := &messageProcessor{
-		handler: handler,
+		handler:    handler,
+		tasks:      tasks,
+		authorizer: authorizer,
 	}
 
 	// Use the memory task manager from sage-a2a-go
@@ -22,6 +22,10 @@ import (
 	"testing"
 
 	"github.com/sage-x-project/sage-adk/core/agent"
+	"github.com/sage-x-project/sage-adk/core/task"
+	"github.com/sage-x-project/sage-adk/pkg/types"
+	a2aprotocol "trpc.group/trpc-go/trpc-a2a-go/protocol"
+	"trpc.group/trpc-go/trpc-a2a-go/taskmanager"
 )
 
 func TestNewServer(t *testing.T) {
@@ -76,8 +80,37 @@ func TestNewTaskManager(t *testing.T) {
 		return nil
 	}
 
-	tm := newTaskManager(handler)
+	tm := newTaskManager(handler, task.NewMemoryStore(nil), nil)
 	if tm == nil {
 		t.Fatal("newTaskManager() returned nil")
 	}
 }
+
+func TestMessageProcessor_RecordsTask(t *testing.T) {
+	store := task.NewMemoryStore(nil)
+	processor := &messageProcessor{
+		handler: func(ctx context.Context, msg agent.MessageContext) error {
+			return nil
+		},
+		tasks: store,
+	}
+
+	msg := a2aprotocol.NewMessage(a2aprotocol.MessageRoleUser, []a2aprotocol.Part{a2aprotocol.NewTextPart("hello")})
+	result, err := processor.ProcessMessage(context.Background(), msg, taskmanager.ProcessOptions{}, nil)
+	if err != nil {
+		t.Fatalf("ProcessMessage() error = %v", err)
+	}
+
+	reply, ok := result.Result.(*a2aprotocol.Message)
+	if !ok || reply.TaskID == nil {
+		t.Fatalf("ProcessMessage() result = %+v, want a reply with a task ID", result.Result)
+	}
+
+	recorded, err := store.Get(context.Background(), *reply.TaskID)
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	if recorded.Status.State != types.TaskStateCompleted {
+		t.Errorf("task state = %s, want completed", recorded.Status.State)
+	}
+}
@@ -0,0 +1,68 @@
+// Copyright (C) 2025 sage-x-project
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with this program. If not, see <https://www.gnu.org/licenses/>.
+
+// SPDX-License-Identifier: LGPL-3.0-or-later
+
+package a2a
+
+import (
+	"context"
+	"crypto/ed25519"
+	"net/http"
+
+	"github.com/sage-x-project/sage-adk/adapters/sage/httpsig"
+	a2aclient "trpc.group/trpc-go/trpc-a2a-go/client"
+)
+
+// WithSigningKey is a client option that signs every A2A JSON-RPC request
+// with an RFC 9421 HTTP message signature made with the agent's Ed25519
+// key. keyID identifies the key to the server, e.g.
+// "did:sage:local:alice#key-1". See WithSigner.
+func WithSigningKey(key ed25519.PrivateKey, keyID string) a2aclient.Option {
+	return WithSigner(httpsig.NewSigner(httpsig.StaticKey(key, keyID), nil), nil)
+}
+
+// WithSigner is a client option that signs every request with signer,
+// sending it through httpClient (a new client if nil). It replaces the A2A
+// client's HTTP client, so pass it before options that configure that
+// client, such as WithTimeout.
+func WithSigner(signer *httpsig.Signer, httpClient *http.Client) a2aclient.Option {
+	if httpClient == nil {
+		httpClient = &http.Client{}
+	}
+	signed := *httpClient
+	signed.Transport = signer.Transport(httpClient.Transport)
+	return a2aclient.WithHTTPClient(&signed)
+}
+
+// VerifySignatures returns server middleware (see ServerConfig.Middleware)
+// that rejects requests without a valid HTTP message signature with 401
+// Unauthorized. Signing keys are resolved with resolve, e.g.
+// httpsig.StaticKeys or a SAGE DIDResolver's HTTPKeyResolver. A nil config
+// uses the httpsig defaults, which have no replay guard; set
+// VerifierConfig.Guard to reject replayed requests. Message handlers learn
+// the verified sender from Sender.
+func VerifySignatures(resolve httpsig.KeyResolver, config *httpsig.VerifierConfig) func(http.Handler) http.Handler {
+	return httpsig.NewVerifier(resolve, config).Middleware
+}
+
+// Sender returns the DID that signed the request being handled, or "" if
+// the server does not verify signatures.
+func Sender(ctx context.Context) string {
+	if result := httpsig.FromContext(ctx); result != nil {
+		return result.DID()
+	}
+	return ""
+}
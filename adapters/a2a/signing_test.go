@@ -0,0 +1,69 @@
+// Copyright (C) 2025 sage-x-project
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with this program. If not, see <https://www.gnu.org/licenses/>.
+
+// SPDX-License-Identifier: LGPL-3.0-or-later
+
+package a2a
+
+import (
+	"crypto/ed25519"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/sage-x-project/sage-adk/adapters/sage/httpsig"
+)
+
+func TestVerifySignatures(t *testing.T) {
+	publicKey, privateKey, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		t.Fatalf("GenerateKey() error = %v", err)
+	}
+
+	var sender string
+	middleware := VerifySignatures(httpsig.StaticKeys(map[string]ed25519.PublicKey{
+		"did:sage:local:alice": publicKey,
+	}), nil)
+	server := httptest.NewServer(middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		sender = Sender(r.Context())
+	})))
+	defer server.Close()
+
+	body := `{"jsonrpc":"2.0","method":"message/send","id":1}`
+	signer := httpsig.NewSigner(httpsig.StaticKey(privateKey, "did:sage:local:alice#key-1"), nil)
+	client := &http.Client{Transport: signer.Transport(nil)}
+
+	resp, err := client.Post(server.URL, "application/json", strings.NewReader(body))
+	if err != nil {
+		t.Fatalf("Post() error = %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("signed request status = %d, want 200", resp.StatusCode)
+	}
+	if sender != "did:sage:local:alice" {
+		t.Errorf("Sender() = %q, want did:sage:local:alice", sender)
+	}
+
+	resp, err = http.Post(server.URL, "application/json", strings.NewReader(body))
+	if err != nil {
+		t.Fatalf("Post() error = %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusUnauthorized {
+		t.Errorf("unsigned request status = %d, want 401", resp.StatusCode)
+	}
+}
@@ -39,6 +39,7 @@ const (
 type AnthropicProvider struct {
 	apiKey     string
 	model      string
+	baseURL    string
 	httpClient *http.Client
 }
 
@@ -52,6 +53,10 @@ type AnthropicConfig struct {
 	// Default: "claude-3-sonnet-20240229"
 	Model string
 
+	// BaseURL is the API base URL (for proxies or compatible endpoints).
+	// Default: https://api.anthropic.com/v1
+	BaseURL string
+
 	// HTTPClient is the HTTP client to use (optional).
 	HTTPClient *http.Client
 }
@@ -104,10 +109,35 @@ func Anthropic(config ...*AnthropicConfig) Provider {
 	return &AnthropicProvider{
 		apiKey:     apiKey,
 		model:      model,
+		baseURL:    cfg.BaseURL,
 		httpClient: httpClient,
 	}
 }
 
+func init() {
+	RegisterFactory("anthropic", newAnthropicFromConfig,
+		ConfigField{Name: FieldAPIKey, Description: "Anthropic API key", Required: true, EnvVar: "ANTHROPIC_API_KEY"},
+		ConfigField{Name: FieldModel, Description: "Default model (default: claude-3-sonnet-20240229)", EnvVar: "ANTHROPIC_MODEL"},
+		ConfigField{Name: FieldBaseURL, Description: "API base URL for proxies or compatible endpoints"},
+		ConfigField{Name: FieldProxyURL, Description: "HTTP proxy URL"},
+	)
+}
+
+// newAnthropicFromConfig is the factory for the "anthropic" provider type.
+func newAnthropicFromConfig(cfg *ProviderConfig) (Provider, error) {
+	httpClient, err := cfg.HTTPClient()
+	if err != nil {
+		return nil, err
+	}
+
+	return Anthropic(&AnthropicConfig{
+		APIKey:     cfg.APIKey,
+		Model:      cfg.Model,
+		BaseURL:    cfg.BaseURL,
+		HTTPClient: httpClient,
+	}), nil
+}
+
 // Name returns the provider name.
 func (p *AnthropicProvider) Name() string {
 	return "anthropic"
@@ -156,7 +186,7 @@ func (p *AnthropicProvider) Stream(ctx context.Context, req *CompletionRequest,
 		return fmt.Errorf("failed to marshal request: %w", err)
 	}
 
-	httpReq, err := http.NewRequestWithContext(ctx, "POST", anthropicAPIURL, bytes.NewReader(reqBody))
+	httpReq, err := http.NewRequestWithContext(ctx, "POST", p.apiURL(), bytes.NewReader(reqBody))
 	if err != nil {
 		return fmt.Errorf("failed to create request: %w", err)
 	}
@@ -265,7 +295,7 @@ func (p *AnthropicProvider) CompleteWithTools(ctx context.Context, req *Completi
 	}
 
 	// Create HTTP request
-	httpReq, err := http.NewRequestWithContext(ctx, "POST", anthropicAPIURL, bytes.NewReader(reqBody))
+	httpReq, err := http.NewRequestWithContext(ctx, "POST", p.apiURL(), bytes.NewReader(reqBody))
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
@@ -347,6 +377,14 @@ func (p *AnthropicProvider) GetTokenLimit(model string) int {
 	return GetModelTokenLimit(model)
 }
 
+// apiURL returns the messages endpoint, honoring a configured base URL.
+func (p *AnthropicProvider) apiURL() string {
+	if p.baseURL == "" {
+		return anthropicAPIURL
+	}
+	return strings.TrimRight(p.baseURL, "/") + "/messages"
+}
+
 // buildAnthropicRequest converts our standard request to Anthropic format.
 func (p *AnthropicProvider) buildAnthropicRequest(req *CompletionRequest, stream bool) *anthropicRequest {
 	// Separate system message from conversation
@@ -406,7 +444,7 @@ func (p *AnthropicProvider) makeRequest(ctx context.Context, anthropicReq *anthr
 		return nil, fmt.Errorf("failed to marshal request: %w", err)
 	}
 
-	httpReq, err := http.NewRequestWithContext(ctx, "POST", anthropicAPIURL, bytes.NewReader(reqBody))
+	httpReq, err := http.NewRequestWithContext(ctx, "POST", p.apiURL(), bytes.NewReader(reqBody))
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
@@ -0,0 +1,124 @@
+// Copyright (C) 2025 sage-x-project
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with this program. If not, see <https://www.gnu.org/licenses/>.
+
+// SPDX-License-Identifier: LGPL-3.0-or-later
+
+package llm
+
+import (
+	"context"
+)
+
+// GenerationDefaults holds default generation parameters applied to
+// requests that leave them unset.
+type GenerationDefaults struct {
+	// MaxTokens is the default maximum number of tokens to generate.
+	MaxTokens int
+
+	// Temperature is the default sampling temperature.
+	Temperature float64
+
+	// TopP is the default nucleus sampling value.
+	TopP float64
+}
+
+// IsZero returns true if no default is set.
+func (d GenerationDefaults) IsZero() bool {
+	return d.MaxTokens == 0 && d.Temperature == 0 && d.TopP == 0
+}
+
+// apply returns a copy of req with unset parameters filled in.
+func (d GenerationDefaults) apply(req CompletionRequest) CompletionRequest {
+	if req.MaxTokens == 0 {
+		req.MaxTokens = d.MaxTokens
+	}
+	if req.Temperature == 0 {
+		req.Temperature = d.Temperature
+	}
+	if req.TopP == 0 {
+		req.TopP = d.TopP
+	}
+	return req
+}
+
+// WithDefaults wraps provider so that requests without MaxTokens,
+// Temperature or TopP use the given defaults. The wrapper implements
+// AdvancedProvider when provider does. Zero defaults return provider as is.
+func WithDefaults(provider Provider, defaults GenerationDefaults) Provider {
+	if provider == nil || defaults.IsZero() {
+		return provider
+	}
+
+	base := &defaultsProvider{Provider: provider, defaults: defaults}
+	if advanced, ok := provider.(AdvancedProvider); ok {
+		return &advancedDefaultsProvider{defaultsProvider: base, advanced: advanced}
+	}
+	return base
+}
+
+// defaultsProvider applies generation defaults to a Provider.
+type defaultsProvider struct {
+	Provider
+	defaults GenerationDefaults
+}
+
+// Complete applies the defaults and delegates to the wrapped provider.
+func (p *defaultsProvider) Complete(ctx context.Context, req *CompletionRequest) (*CompletionResponse, error) {
+	if req != nil {
+		r := p.defaults.apply(*req)
+		req = &r
+	}
+	return p.Provider.Complete(ctx, req)
+}
+
+// Stream applies the defaults and delegates to the wrapped provider.
+func (p *defaultsProvider) Stream(ctx context.Context, req *CompletionRequest, fn StreamFunc) error {
+	if req != nil {
+		r := p.defaults.apply(*req)
+		req = &r
+	}
+	return p.Provider.Stream(ctx, req, fn)
+}
+
+// advancedDefaultsProvider applies generation defaults to an AdvancedProvider.
+type advancedDefaultsProvider struct {
+	*defaultsProvider
+	advanced AdvancedProvider
+}
+
+// SupportsFunctionCalling delegates to the wrapped provider.
+func (p *advancedDefaultsProvider) SupportsFunctionCalling() bool {
+	return p.advanced.SupportsFunctionCalling()
+}
+
+// CompleteWithTools applies the defaults and delegates to the wrapped provider.
+func (p *advancedDefaultsProvider) CompleteWithTools(ctx context.Context, req *CompletionRequestWithTools) (*CompletionResponseWithTools, error) {
+	if req != nil {
+		r := *req
+		r.CompletionRequest = p.defaults.apply(req.CompletionRequest)
+		req = &r
+	}
+	return p.advanced.CompleteWithTools(ctx, req)
+}
+
+// CountTokens delegates to the wrapped provider.
+func (p *advancedDefaultsProvider) CountTokens(text string) int {
+	return p.advanced.CountTokens(text)
+}
+
+// GetTokenLimit delegates to the wrapped provider.
+func (p *advancedDefaultsProvider) GetTokenLimit(model string) int {
+	return p.advanced.GetTokenLimit(model)
+}
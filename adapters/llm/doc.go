@@ -94,6 +94,32 @@
 //	// Use default provider
 //	defaultProvider := registry.Default()
 //
+// # Provider Factories
+//
+// Providers register a factory by type name together with the configuration
+// fields they accept. NewProvider creates providers from a vendor-neutral
+// ProviderConfig, and NewProviderFromConfig resolves the named providers
+// declared in config.LLMConfig:
+//
+//	llm.RegisterFactory("ollama", newOllama,
+//	    llm.ConfigField{Name: llm.FieldBaseURL, Required: true})
+//
+//	provider, err := llm.NewProviderFromConfig(&cfg.LLM, "fast")
+//
+// # Schemas from Go Types
+//
+// SchemaFor reflects FunctionParameters from a struct using its json,
+// description, enum, required, min and max tags, and Validate checks
+// decoded arguments against a schema:
+//
+//	params, err := llm.SchemaFor[WeatherArgs]()
+//	tool := llm.NewTool(llm.NewFunction("get_weather", "Get the weather", params))
+//
+//	args, _ := call.Function.ParsedArguments()
+//	if err := params.Validate(args); err != nil {
+//	    // err is a *llm.ValidationError listing every problem
+//	}
+//
 // # Integration with Agent
 //
 //	agent, _ := agent.NewAgent("llm-agent").
@@ -162,6 +188,18 @@
 //	resp2, _ := provider.Complete(ctx, req)  // "Second response"
 //	resp3, _ := provider.Complete(ctx, req)  // "Third response"
 //
+// Rules script more complex behavior. They are matched against the request
+// in registration order before falling back to the pre-defined responses:
+//
+//	mock := llm.NewMockProvider("test", nil)
+//	mock.On(llm.MatchPromptContains("weather")).
+//	    RespondWithToolCall("get_weather", `{"city":"Seoul"}`).
+//	    Once()
+//	mock.On(llm.MatchAny()).Fail(errors.ErrLLMRateLimit).Times(2)
+//	mock.On(llm.MatchAny()).
+//	    Respond("It is sunny.").
+//	    StreamChunks(10*time.Millisecond, "It is ", "sunny.")
+//
 // # Future Enhancements
 //
 // Phase 2: Provider Implementations
@@ -0,0 +1,317 @@
+// Copyright (C) 2025 sage-x-project
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with this program. If not, see <https://www.gnu.org/licenses/>.
+
+// SPDX-License-Identifier: LGPL-3.0-or-later
+
+package llm
+
+import (
+	"net/http"
+	"net/url"
+	"os"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/sage-x-project/sage-adk/config"
+	"github.com/sage-x-project/sage-adk/pkg/errors"
+)
+
+// Configuration field names understood by NewProvider.
+const (
+	FieldAPIKey       = "api_key"
+	FieldModel        = "model"
+	FieldBaseURL      = "base_url"
+	FieldOrganization = "organization"
+	FieldProxyURL     = "proxy_url"
+)
+
+// ProviderConfig is the vendor-neutral configuration passed to provider factories.
+type ProviderConfig struct {
+	// Name is the name the provider was declared under.
+	Name string
+
+	// Type is the registered factory type (e.g., "openai").
+	// Default: Name
+	Type string
+
+	// APIKey is the provider API key.
+	APIKey string
+
+	// Model is the default model.
+	Model string
+
+	// BaseURL overrides the API endpoint.
+	BaseURL string
+
+	// Organization is the organization ID, for providers that support it.
+	Organization string
+
+	// ProxyURL routes requests through an HTTP proxy.
+	ProxyURL string
+
+	// MaxTokens is the default maximum number of tokens to generate.
+	MaxTokens int
+
+	// Temperature is the default sampling temperature.
+	Temperature float64
+
+	// TopP is the default nucleus sampling value.
+	TopP float64
+
+	// Timeout bounds each HTTP request to the provider.
+	Timeout time.Duration
+
+	// Options contains provider-specific settings.
+	Options map[string]string
+}
+
+// HTTPClient builds an HTTP client honoring Timeout and ProxyURL.
+// It returns nil when neither is set, so providers keep their default client.
+func (c *ProviderConfig) HTTPClient() (*http.Client, error) {
+	if c.Timeout <= 0 && c.ProxyURL == "" {
+		return nil, nil
+	}
+
+	client := &http.Client{Timeout: c.Timeout}
+	if c.ProxyURL != "" {
+		proxy, err := url.Parse(c.ProxyURL)
+		if err != nil {
+			return nil, errors.ErrInvalidFormat.
+				WithMessage("invalid proxy URL").
+				WithDetail("proxy_url", c.ProxyURL).
+				Wrap(err)
+		}
+		transport := http.DefaultTransport.(*http.Transport).Clone()
+		transport.Proxy = http.ProxyURL(proxy)
+		client.Transport = transport
+	}
+
+	return client, nil
+}
+
+// ConfigField describes a configuration field accepted by a provider factory.
+type ConfigField struct {
+	// Name is the field name (e.g., FieldAPIKey). Names other than the
+	// Field* constants refer to keys of ProviderConfig.Options.
+	Name string
+
+	// Description explains the field.
+	Description string
+
+	// Required marks fields that must be set after environment fallback.
+	Required bool
+
+	// EnvVar is read when the field is empty.
+	EnvVar string
+
+	// AltEnvVars are read, in order, when EnvVar is unset.
+	AltEnvVars []string
+}
+
+// ProviderFactory creates a provider from configuration.
+type ProviderFactory func(cfg *ProviderConfig) (Provider, error)
+
+type factoryEntry struct {
+	factory ProviderFactory
+	schema  []ConfigField
+}
+
+var (
+	factoriesMu sync.RWMutex
+	factories   = make(map[string]*factoryEntry)
+)
+
+// RegisterFactory registers a provider factory under providerType together
+// with the configuration fields it accepts. Registering an existing type
+// replaces it, which allows built-in providers to be overridden.
+//
+// Providers typically register themselves from an init function:
+//
+//	func init() {
+//	    llm.RegisterFactory("ollama", newOllama,
+//	        llm.ConfigField{Name: llm.FieldBaseURL, Required: true})
+//	}
+func RegisterFactory(providerType string, factory ProviderFactory, schema ...ConfigField) {
+	factoriesMu.Lock()
+	defer factoriesMu.Unlock()
+
+	factories[providerType] = &factoryEntry{
+		factory: factory,
+		schema:  schema,
+	}
+}
+
+// Factories returns the registered provider types in sorted order.
+func Factories() []string {
+	factoriesMu.RLock()
+	defer factoriesMu.RUnlock()
+
+	types := make([]string, 0, len(factories))
+	for t := range factories {
+		types = append(types, t)
+	}
+	sort.Strings(types)
+	return types
+}
+
+// FactorySchema returns the configuration fields accepted by providerType.
+func FactorySchema(providerType string) ([]ConfigField, error) {
+	factoriesMu.RLock()
+	defer factoriesMu.RUnlock()
+
+	entry, ok := factories[providerType]
+	if !ok {
+		return nil, errors.ErrNotFound.WithDetail("provider_type", providerType)
+	}
+
+	schema := make([]ConfigField, len(entry.schema))
+	copy(schema, entry.schema)
+	return schema, nil
+}
+
+// NewProvider creates a provider using the factory registered for cfg.Type.
+//
+// Empty fields fall back to the environment variables declared in the
+// factory schema, and required fields are checked before the factory runs.
+// When default generation parameters are set, the provider is wrapped with
+// WithDefaults.
+func NewProvider(cfg *ProviderConfig) (Provider, error) {
+	if cfg == nil {
+		return nil, errors.ErrInvalidInput.WithMessage("provider config is nil")
+	}
+
+	resolved := *cfg
+	if resolved.Type == "" {
+		resolved.Type = resolved.Name
+	}
+	if resolved.Name == "" {
+		resolved.Name = resolved.Type
+	}
+
+	factoriesMu.RLock()
+	entry, ok := factories[resolved.Type]
+	factoriesMu.RUnlock()
+	if !ok {
+		return nil, errors.ErrNotFound.
+			WithMessage("unknown LLM provider type").
+			WithDetail("provider_type", resolved.Type)
+	}
+
+	resolved.Options = make(map[string]string, len(cfg.Options))
+	for k, v := range cfg.Options {
+		resolved.Options[k] = v
+	}
+
+	for _, field := range entry.schema {
+		if resolved.lookup(field.Name) == "" {
+			for _, env := range append([]string{field.EnvVar}, field.AltEnvVars...) {
+				if v := os.Getenv(env); env != "" && v != "" {
+					resolved.set(field.Name, v)
+					break
+				}
+			}
+		}
+		if resolved.lookup(field.Name) == "" && field.Required {
+			err := errors.ErrMissingField.
+				WithDetail("provider", resolved.Name).
+				WithDetail("field", field.Name)
+			if field.EnvVar != "" {
+				err = err.WithDetail("env", field.EnvVar)
+			}
+			return nil, err
+		}
+	}
+
+	provider, err := entry.factory(&resolved)
+	if err != nil {
+		return nil, err
+	}
+
+	return WithDefaults(provider, GenerationDefaults{
+		MaxTokens:   resolved.MaxTokens,
+		Temperature: resolved.Temperature,
+		TopP:        resolved.TopP,
+	}), nil
+}
+
+// NewProviderFromConfig creates the provider declared under name in cfg.
+// An empty name selects cfg.Provider. See config.LLMConfig.Lookup.
+func NewProviderFromConfig(cfg *config.LLMConfig, name string) (Provider, error) {
+	if cfg == nil {
+		return nil, errors.ErrInvalidInput.WithMessage("LLM config is nil")
+	}
+
+	p, ok := cfg.Lookup(name)
+	if !ok {
+		return nil, errors.ErrNotFound.
+			WithMessage("LLM provider not declared").
+			WithDetail("provider", name)
+	}
+
+	if name == "" {
+		name = cfg.Provider
+	}
+
+	return NewProvider(&ProviderConfig{
+		Name:         name,
+		Type:         p.Type,
+		APIKey:       p.APIKey,
+		Model:        p.Model,
+		BaseURL:      p.BaseURL,
+		Organization: p.Organization,
+		ProxyURL:     p.ProxyURL,
+		MaxTokens:    p.MaxTokens,
+		Temperature:  p.Temperature,
+		TopP:         p.TopP,
+		Timeout:      p.Timeout,
+		Options:      p.Options,
+	})
+}
+
+// lookup returns the named configuration value.
+func (c *ProviderConfig) lookup(name string) string {
+	switch name {
+	case FieldAPIKey:
+		return c.APIKey
+	case FieldModel:
+		return c.Model
+	case FieldBaseURL:
+		return c.BaseURL
+	case FieldOrganization:
+		return c.Organization
+	case FieldProxyURL:
+		return c.ProxyURL
+	}
+	return c.Options[name]
+}
+
+// set stores the named configuration value.
+func (c *ProviderConfig) set(name, value string) {
+	switch name {
+	case FieldAPIKey:
+		c.APIKey = value
+	case FieldModel:
+		c.Model = value
+	case FieldBaseURL:
+		c.BaseURL = value
+	case FieldOrganization:
+		c.Organization = value
+	case FieldProxyURL:
+		c.ProxyURL = value
+	default:
+		c.Options[name] = value
+	}
+}
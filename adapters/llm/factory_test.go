@@ -0,0 +1,190 @@
+// Copyright (C) 2025 sage-x-project
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with this program. If not, see <https://www.gnu.org/licenses/>.
+
+// SPDX-License-Identifier: LGPL-3.0-or-later
+
+package llm
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/sage-x-project/sage-adk/config"
+	"github.com/sage-x-project/sage-adk/pkg/errors"
+)
+
+func TestFactories_BuiltinsRegistered(t *testing.T) {
+	registered := make(map[string]bool)
+	for _, name := range Factories() {
+		registered[name] = true
+	}
+
+	for _, name := range []string{"openai", "anthropic", "gemini"} {
+		if !registered[name] {
+			t.Errorf("factory %q not registered", name)
+		}
+
+		schema, err := FactorySchema(name)
+		if err != nil {
+			t.Fatalf("FactorySchema(%q) error = %v", name, err)
+		}
+		if len(schema) == 0 {
+			t.Errorf("FactorySchema(%q) is empty", name)
+		}
+	}
+}
+
+func TestNewProvider_UnknownType(t *testing.T) {
+	_, err := NewProvider(&ProviderConfig{Type: "nonexistent"})
+	if !errors.Is(err, errors.ErrNotFound) {
+		t.Errorf("NewProvider() error = %v, want not found", err)
+	}
+}
+
+func TestNewProvider_RequiredFieldAndEnvFallback(t *testing.T) {
+	var got *ProviderConfig
+	RegisterFactory("test-required", func(cfg *ProviderConfig) (Provider, error) {
+		got = cfg
+		return NewMockProvider(cfg.Name, nil), nil
+	},
+		ConfigField{Name: FieldAPIKey, Required: true, EnvVar: "TEST_FACTORY_API_KEY"},
+		ConfigField{Name: "region", EnvVar: "TEST_FACTORY_REGION"},
+	)
+
+	t.Setenv("TEST_FACTORY_API_KEY", "")
+	_, err := NewProvider(&ProviderConfig{Type: "test-required"})
+	if !errors.Is(err, errors.ErrMissingField) {
+		t.Fatalf("NewProvider() error = %v, want missing field", err)
+	}
+
+	t.Setenv("TEST_FACTORY_API_KEY", "env-key")
+	t.Setenv("TEST_FACTORY_REGION", "eu")
+	provider, err := NewProvider(&ProviderConfig{Name: "primary", Type: "test-required"})
+	if err != nil {
+		t.Fatalf("NewProvider() error = %v", err)
+	}
+	if provider.Name() != "primary" {
+		t.Errorf("Name() = %v, want primary", provider.Name())
+	}
+	if got.APIKey != "env-key" {
+		t.Errorf("APIKey = %v, want env-key", got.APIKey)
+	}
+	if got.Options["region"] != "eu" {
+		t.Errorf("Options[region] = %v, want eu", got.Options["region"])
+	}
+}
+
+func TestNewProvider_AppliesDefaults(t *testing.T) {
+	mock := NewMockProvider("mock", nil)
+	mock.On(nil).Respond("ok")
+	RegisterFactory("test-defaults", func(cfg *ProviderConfig) (Provider, error) {
+		return mock, nil
+	})
+
+	provider, err := NewProvider(&ProviderConfig{
+		Type:        "test-defaults",
+		MaxTokens:   256,
+		Temperature: 0.3,
+	})
+	if err != nil {
+		t.Fatalf("NewProvider() error = %v", err)
+	}
+
+	advanced, ok := provider.(AdvancedProvider)
+	if !ok {
+		t.Fatal("wrapped provider should remain an AdvancedProvider")
+	}
+
+	if _, err := advanced.CompleteWithTools(context.Background(), &CompletionRequestWithTools{
+		CompletionRequest: CompletionRequest{MaxTokens: 10},
+	}); err != nil {
+		t.Fatalf("CompleteWithTools() error = %v", err)
+	}
+
+	call := mock.Calls()[0]
+	if call.MaxTokens != 10 {
+		t.Errorf("MaxTokens = %d, want explicit 10", call.MaxTokens)
+	}
+	if call.Temperature != 0.3 {
+		t.Errorf("Temperature = %v, want default 0.3", call.Temperature)
+	}
+}
+
+func TestNewProvider_InvalidProxy(t *testing.T) {
+	_, err := NewProvider(&ProviderConfig{
+		Type:     "openai",
+		APIKey:   "sk-test",
+		ProxyURL: "://bad",
+	})
+	if !errors.Is(err, errors.ErrInvalidFormat) {
+		t.Errorf("NewProvider() error = %v, want invalid format", err)
+	}
+}
+
+func TestProviderConfig_HTTPClient(t *testing.T) {
+	cfg := &ProviderConfig{}
+	client, err := cfg.HTTPClient()
+	if err != nil || client != nil {
+		t.Errorf("HTTPClient() = %v, %v; want nil client", client, err)
+	}
+
+	cfg = &ProviderConfig{Timeout: 5 * time.Second, ProxyURL: "http://proxy:3128"}
+	client, err = cfg.HTTPClient()
+	if err != nil {
+		t.Fatalf("HTTPClient() error = %v", err)
+	}
+	if client.Timeout != 5*time.Second {
+		t.Errorf("Timeout = %v, want 5s", client.Timeout)
+	}
+	if client.Transport == nil {
+		t.Error("Transport should be set for proxy")
+	}
+}
+
+func TestNewProviderFromConfig_Named(t *testing.T) {
+	cfg := &config.LLMConfig{
+		Provider:  "fast",
+		MaxTokens: 1000,
+		Providers: map[string]config.LLMProviderConfig{
+			"fast":   {Type: "openai", APIKey: "sk-fast", Model: "gpt-4o-mini"},
+			"claude": {Type: "anthropic", APIKey: "sk-ant"},
+			"gemini": {APIKey: "AIza"},
+		},
+	}
+
+	tests := []struct {
+		name     string
+		wantName string
+	}{
+		{"", "openai"},
+		{"claude", "anthropic"},
+		{"gemini", "gemini"},
+	}
+
+	for _, tt := range tests {
+		provider, err := NewProviderFromConfig(cfg, tt.name)
+		if err != nil {
+			t.Fatalf("NewProviderFromConfig(%q) error = %v", tt.name, err)
+		}
+		if provider.Name() != tt.wantName {
+			t.Errorf("NewProviderFromConfig(%q).Name() = %v, want %v", tt.name, provider.Name(), tt.wantName)
+		}
+	}
+
+	if _, err := NewProviderFromConfig(cfg, "missing"); !errors.Is(err, errors.ErrNotFound) {
+		t.Errorf("NewProviderFromConfig(missing) error = %v, want not found", err)
+	}
+}
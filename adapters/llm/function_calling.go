@@ -53,12 +53,13 @@ type Function struct {
 }
 
 // FunctionParameters defines function parameters using JSON Schema.
+// It is shared with core/tools, where it is known as ParameterSchema.
 type FunctionParameters struct {
 	// Type is the schema type (usually "object").
 	Type string `json:"type"`
 
 	// Properties defines the parameter properties.
-	Properties map[string]*PropertySchema `json:"properties"`
+	Properties map[string]*PropertySchema `json:"properties,omitempty"`
 
 	// Required lists the required parameter names.
 	Required []string `json:"required,omitempty"`
@@ -67,19 +68,38 @@ type FunctionParameters struct {
 // PropertySchema defines a property schema.
 type PropertySchema struct {
 	// Type is the property type (string, number, boolean, etc.).
-	Type string `json:"type"`
+	// Empty means any type.
+	Type string `json:"type,omitempty"`
 
 	// Description describes the property.
 	Description string `json:"description,omitempty"`
 
-	// Enum lists allowed values (for string type).
-	Enum []string `json:"enum,omitempty"`
+	// Enum lists allowed values, of the property type.
+	Enum []interface{} `json:"enum,omitempty"`
+
+	// Default is the value used when the property is omitted.
+	Default interface{} `json:"default,omitempty"`
+
+	// Minimum and Maximum bound numeric values.
+	Minimum *float64 `json:"minimum,omitempty"`
+	Maximum *float64 `json:"maximum,omitempty"`
+
+	// MinLength and MaxLength bound string lengths.
+	MinLength *int `json:"minLength,omitempty"`
+	MaxLength *int `json:"maxLength,omitempty"`
+
+	// MinItems and MaxItems bound array lengths.
+	MinItems *int `json:"minItems,omitempty"`
+	MaxItems *int `json:"maxItems,omitempty"`
 
 	// Items defines array item schema (for array type).
 	Items *PropertySchema `json:"items,omitempty"`
 
 	// Properties defines nested object properties (for object type).
 	Properties map[string]*PropertySchema `json:"properties,omitempty"`
+
+	// Required lists required nested properties (for object type).
+	Required []string `json:"required,omitempty"`
 }
 
 // FunctionChoice controls function calling behavior.
@@ -199,10 +219,14 @@ func (fp *FunctionParameters) AddProperty(name, propType, description string, re
 
 // AddEnumProperty adds an enum property to the function parameters.
 func (fp *FunctionParameters) AddEnumProperty(name, description string, enum []string, required bool) *FunctionParameters {
+	values := make([]interface{}, len(enum))
+	for i, v := range enum {
+		values[i] = v
+	}
 	fp.Properties[name] = &PropertySchema{
 		Type:        "string",
 		Description: description,
-		Enum:        enum,
+		Enum:        values,
 	}
 	if required {
 		fp.Required = append(fp.Required, name)
@@ -38,6 +38,7 @@ const (
 type GeminiProvider struct {
 	apiKey     string
 	model      string
+	baseURL    string
 	httpClient *http.Client
 }
 
@@ -51,6 +52,10 @@ type GeminiConfig struct {
 	// Default: "gemini-pro"
 	Model string
 
+	// BaseURL is the models API base URL (for proxies or compatible endpoints).
+	// Default: https://generativelanguage.googleapis.com/v1beta/models
+	BaseURL string
+
 	// HTTPClient is the HTTP client to use (optional).
 	HTTPClient *http.Client
 }
@@ -106,10 +111,35 @@ func Gemini(config ...*GeminiConfig) Provider {
 	return &GeminiProvider{
 		apiKey:     apiKey,
 		model:      model,
+		baseURL:    cfg.BaseURL,
 		httpClient: httpClient,
 	}
 }
 
+func init() {
+	RegisterFactory("gemini", newGeminiFromConfig,
+		ConfigField{Name: FieldAPIKey, Description: "Google AI API key", Required: true, EnvVar: "GEMINI_API_KEY", AltEnvVars: []string{"GOOGLE_API_KEY"}},
+		ConfigField{Name: FieldModel, Description: "Default model (default: gemini-pro)", EnvVar: "GEMINI_MODEL"},
+		ConfigField{Name: FieldBaseURL, Description: "Models API base URL for proxies or compatible endpoints"},
+		ConfigField{Name: FieldProxyURL, Description: "HTTP proxy URL"},
+	)
+}
+
+// newGeminiFromConfig is the factory for the "gemini" provider type.
+func newGeminiFromConfig(cfg *ProviderConfig) (Provider, error) {
+	httpClient, err := cfg.HTTPClient()
+	if err != nil {
+		return nil, err
+	}
+
+	return Gemini(&GeminiConfig{
+		APIKey:     cfg.APIKey,
+		Model:      cfg.Model,
+		BaseURL:    cfg.BaseURL,
+		HTTPClient: httpClient,
+	}), nil
+}
+
 // Name returns the provider name.
 func (p *GeminiProvider) Name() string {
 	return "gemini"
@@ -159,7 +189,7 @@ func (p *GeminiProvider) Stream(ctx context.Context, req *CompletionRequest, fn
 	}
 
 	model := p.getModel(req)
-	url := fmt.Sprintf("%s/%s:streamGenerateContent?key=%s&alt=sse", geminiAPIURL, model, p.apiKey)
+	url := fmt.Sprintf("%s/%s:streamGenerateContent?key=%s&alt=sse", p.apiURL(), model, p.apiKey)
 
 	httpReq, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewReader(reqBody))
 	if err != nil {
@@ -265,7 +295,7 @@ func (p *GeminiProvider) CompleteWithTools(ctx context.Context, req *CompletionR
 
 	// Build URL
 	endpoint := fmt.Sprintf("/%s:generateContent", model)
-	url := geminiAPIURL + endpoint + "?key=" + p.apiKey
+	url := p.apiURL() + endpoint + "?key=" + p.apiKey
 
 	// Marshal request
 	reqBody, err := json.Marshal(geminiReq)
@@ -429,7 +459,7 @@ func (p *GeminiProvider) makeRequest(ctx context.Context, geminiReq *geminiReque
 		endpoint = "streamGenerateContent"
 	}
 
-	url := fmt.Sprintf("%s/%s:%s?key=%s", geminiAPIURL, model, endpoint, p.apiKey)
+	url := fmt.Sprintf("%s/%s:%s?key=%s", p.apiURL(), model, endpoint, p.apiKey)
 
 	httpReq, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewReader(reqBody))
 	if err != nil {
@@ -456,6 +486,14 @@ func (p *GeminiProvider) makeRequest(ctx context.Context, geminiReq *geminiReque
 	return body, nil
 }
 
+// apiURL returns the models endpoint, honoring a configured base URL.
+func (p *GeminiProvider) apiURL() string {
+	if p.baseURL == "" {
+		return geminiAPIURL
+	}
+	return strings.TrimRight(p.baseURL, "/")
+}
+
 // getModel returns the model to use for the request.
 func (p *GeminiProvider) getModel(req *CompletionRequest) string {
 	if req.Model != "" {
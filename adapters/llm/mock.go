@@ -19,17 +19,30 @@ package llm
 
 import (
 	"context"
+	"regexp"
+	"strings"
 	"sync"
+	"time"
+	"unicode"
 
 	"github.com/google/uuid"
 	"github.com/sage-x-project/sage-adk/pkg/errors"
 )
 
 // MockProvider is a mock LLM provider for testing.
+//
+// Without any rules it returns the pre-defined responses in order. Rules
+// registered with On are evaluated first, in registration order, and can
+// return tool calls, stream chunks, inject latency or fail with an error.
 type MockProvider struct {
 	name      string
 	responses []string
 	index     int
+	rules     []*MockRule
+	calls     []*CompletionRequestWithTools
+	usage     Usage
+	streaming bool
+	counter   TokenCounter
 	mu        sync.Mutex
 }
 
@@ -39,9 +52,216 @@ func NewMockProvider(name string, responses []string) *MockProvider {
 		name:      name,
 		responses: responses,
 		index:     0,
+		counter:   NewSimpleTokenCounter(),
+	}
+}
+
+// MockMatcher decides whether a rule applies to a request.
+type MockMatcher func(req *CompletionRequestWithTools) bool
+
+// MockRule is a scripted reaction of a MockProvider to matching requests.
+// Rules are configured through chained calls:
+//
+//	mock.On(llm.MatchPromptContains("weather")).
+//	    RespondWithToolCall("get_weather", `{"city":"Seoul"}`).
+//	    Times(1)
+type MockRule struct {
+	matcher      MockMatcher
+	content      string
+	toolCalls    []*ToolCall
+	chunks       []string
+	stream       bool
+	interval     time.Duration
+	latency      time.Duration
+	err          error
+	usage        *Usage
+	finishReason string
+	times        int
+	used         int
+}
+
+// On registers a new rule for requests accepted by matcher.
+// A nil matcher matches every request.
+func (m *MockProvider) On(matcher MockMatcher) *MockRule {
+	if matcher == nil {
+		matcher = MatchAny()
+	}
+
+	rule := &MockRule{matcher: matcher}
+
+	m.mu.Lock()
+	m.rules = append(m.rules, rule)
+	m.mu.Unlock()
+
+	return rule
+}
+
+// Respond sets the text content returned by the rule.
+func (r *MockRule) Respond(content string) *MockRule {
+	r.content = content
+	return r
+}
+
+// RespondWithToolCall adds a tool call to the rule's response.
+// It can be called multiple times to return several tool calls in one turn.
+// Every response carries its own copies of the calls, with fresh IDs.
+func (r *MockRule) RespondWithToolCall(name, arguments string) *MockRule {
+	r.toolCalls = append(r.toolCalls, &ToolCall{
+		Type: ToolTypeFunction,
+		Function: &FunctionCall{
+			Name:      name,
+			Arguments: arguments,
+		},
+	})
+	return r
+}
+
+// StreamChunks sets the chunks emitted by Stream, with interval between
+// consecutive chunks. Without explicit chunks the content is split into
+// words. Configuring chunks enables streaming on the provider.
+func (r *MockRule) StreamChunks(interval time.Duration, chunks ...string) *MockRule {
+	r.stream = true
+	r.interval = interval
+	r.chunks = chunks
+	return r
+}
+
+// WithLatency delays the response by d. Context cancellation during the
+// delay results in an LLM timeout error.
+func (r *MockRule) WithLatency(d time.Duration) *MockRule {
+	r.latency = d
+	return r
+}
+
+// Fail makes the rule return err, typically one of the LLM errors from
+// pkg/errors. For streams, configured chunks are emitted before the error.
+func (r *MockRule) Fail(err error) *MockRule {
+	r.err = err
+	return r
+}
+
+// WithUsage sets the reported token usage. By default usage is estimated
+// from the request messages and response content.
+func (r *MockRule) WithUsage(promptTokens, completionTokens int) *MockRule {
+	r.usage = &Usage{
+		PromptTokens:     promptTokens,
+		CompletionTokens: completionTokens,
+		TotalTokens:      promptTokens + completionTokens,
+	}
+	return r
+}
+
+// WithFinishReason overrides the reported finish reason.
+func (r *MockRule) WithFinishReason(reason string) *MockRule {
+	r.finishReason = reason
+	return r
+}
+
+// Times limits the rule to n matches. Once exhausted, later rules are
+// considered. Zero (the default) means unlimited.
+func (r *MockRule) Times(n int) *MockRule {
+	r.times = n
+	return r
+}
+
+// Once is shorthand for Times(1).
+func (r *MockRule) Once() *MockRule {
+	return r.Times(1)
+}
+
+// exhausted reports whether the rule has used all of its matches.
+func (r *MockRule) exhausted() bool {
+	return r.times > 0 && r.used >= r.times
+}
+
+// MatchAny matches every request.
+func MatchAny() MockMatcher {
+	return func(req *CompletionRequestWithTools) bool {
+		return true
+	}
+}
+
+// MatchPromptContains matches when the last user message contains substr.
+func MatchPromptContains(substr string) MockMatcher {
+	return func(req *CompletionRequestWithTools) bool {
+		return strings.Contains(lastUserMessage(req), substr)
 	}
 }
 
+// MatchPromptRegexp matches when the last user message matches pattern.
+// It panics if pattern does not compile.
+func MatchPromptRegexp(pattern string) MockMatcher {
+	re := regexp.MustCompile(pattern)
+	return func(req *CompletionRequestWithTools) bool {
+		return re.MatchString(lastUserMessage(req))
+	}
+}
+
+// MatchAnyMessageContains matches when any message contains substr.
+func MatchAnyMessageContains(substr string) MockMatcher {
+	return func(req *CompletionRequestWithTools) bool {
+		for _, msg := range req.Messages {
+			if strings.Contains(msg.Content, substr) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// MatchSystemContains matches when a system message contains substr.
+func MatchSystemContains(substr string) MockMatcher {
+	return func(req *CompletionRequestWithTools) bool {
+		for _, msg := range req.Messages {
+			if msg.Role == RoleSystem && strings.Contains(msg.Content, substr) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// MatchModel matches requests for the given model.
+func MatchModel(model string) MockMatcher {
+	return func(req *CompletionRequestWithTools) bool {
+		return req.Model == model
+	}
+}
+
+// MatchToolAvailable matches when a tool with the given name is offered.
+func MatchToolAvailable(name string) MockMatcher {
+	return func(req *CompletionRequestWithTools) bool {
+		for _, tool := range req.Tools {
+			if tool != nil && tool.Function != nil && tool.Function.Name == name {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// MatchAll matches when all matchers match.
+func MatchAll(matchers ...MockMatcher) MockMatcher {
+	return func(req *CompletionRequestWithTools) bool {
+		for _, match := range matchers {
+			if !match(req) {
+				return false
+			}
+		}
+		return true
+	}
+}
+
+// lastUserMessage returns the content of the most recent user message.
+func lastUserMessage(req *CompletionRequestWithTools) string {
+	for i := len(req.Messages) - 1; i >= 0; i-- {
+		if req.Messages[i].Role == RoleUser {
+			return req.Messages[i].Content
+		}
+	}
+	return ""
+}
+
 // Name returns the provider name.
 func (m *MockProvider) Name() string {
 	return m.name
@@ -49,37 +269,292 @@ func (m *MockProvider) Name() string {
 
 // Complete generates a mock completion response.
 func (m *MockProvider) Complete(ctx context.Context, req *CompletionRequest) (*CompletionResponse, error) {
+	if req == nil {
+		return nil, errors.ErrInvalidInput.WithMessage("completion request is nil")
+	}
+
+	resp, err := m.CompleteWithTools(ctx, &CompletionRequestWithTools{CompletionRequest: *req})
+	if err != nil {
+		return nil, err
+	}
+	return &resp.CompletionResponse, nil
+}
+
+// CompleteWithTools generates a mock completion that may contain tool calls.
+func (m *MockProvider) CompleteWithTools(ctx context.Context, req *CompletionRequestWithTools) (*CompletionResponseWithTools, error) {
+	if req == nil {
+		return nil, errors.ErrInvalidInput.WithMessage("completion request is nil")
+	}
+
+	rule, content, err := m.next(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if rule != nil {
+		if err := waitFor(ctx, rule.latency); err != nil {
+			return nil, err
+		}
+		if rule.err != nil {
+			return nil, rule.err
+		}
+	}
+
+	resp := m.buildResponse(req, rule, content)
+	m.recordUsage(resp.Usage)
+
+	return resp, nil
+}
+
+// Stream emits the scripted chunks of the matching rule.
+func (m *MockProvider) Stream(ctx context.Context, req *CompletionRequest, fn StreamFunc) error {
+	if !m.SupportsStreaming() {
+		return errors.ErrNotImplemented.WithMessage("streaming not enabled in mock provider")
+	}
+	if req == nil {
+		return errors.ErrInvalidInput.WithMessage("completion request is nil")
+	}
+	if fn == nil {
+		return errors.ErrInvalidInput.WithMessage("stream function is nil")
+	}
+
+	toolReq := &CompletionRequestWithTools{CompletionRequest: *req}
+	rule, content, err := m.next(toolReq)
+	if err != nil {
+		return err
+	}
+
+	var chunks []string
+	var interval time.Duration
+	if rule != nil {
+		if err := waitFor(ctx, rule.latency); err != nil {
+			return err
+		}
+		chunks, interval = rule.chunks, rule.interval
+		if rule.err != nil && len(chunks) == 0 {
+			return rule.err
+		}
+	}
+	if len(chunks) == 0 {
+		chunks = splitWords(content)
+	}
+
+	for i, chunk := range chunks {
+		if i > 0 {
+			if err := waitFor(ctx, interval); err != nil {
+				return err
+			}
+		}
+		if err := fn(chunk); err != nil {
+			return err
+		}
+	}
+
+	if rule != nil && rule.err != nil {
+		return rule.err
+	}
+
+	m.recordUsage(m.buildResponse(toolReq, rule, content).Usage)
+	return nil
+}
+
+// SupportsStreaming reports whether streaming has been enabled, either
+// explicitly with SetStreaming or by a rule configured with StreamChunks.
+func (m *MockProvider) SupportsStreaming() bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.streaming {
+		return true
+	}
+	for _, rule := range m.rules {
+		if rule.stream {
+			return true
+		}
+	}
+	return false
+}
+
+// SetStreaming enables or disables streaming for rules without explicit chunks.
+func (m *MockProvider) SetStreaming(enabled bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.streaming = enabled
+}
+
+// SupportsFunctionCalling returns true as the mock can script tool calls.
+func (m *MockProvider) SupportsFunctionCalling() bool {
+	return true
+}
+
+// CountTokens estimates the number of tokens in text.
+func (m *MockProvider) CountTokens(text string) int {
+	return m.counter.CountTokens(text)
+}
+
+// GetTokenLimit returns the maximum token limit for the model.
+func (m *MockProvider) GetTokenLimit(model string) int {
+	return GetModelTokenLimit(model)
+}
+
+// Calls returns the requests received so far, in order.
+func (m *MockProvider) Calls() []*CompletionRequestWithTools {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	calls := make([]*CompletionRequestWithTools, len(m.calls))
+	copy(calls, m.calls)
+	return calls
+}
+
+// CallCount returns the number of requests received so far.
+func (m *MockProvider) CallCount() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return len(m.calls)
+}
+
+// TotalUsage returns the accumulated token usage of successful responses.
+func (m *MockProvider) TotalUsage() Usage {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return m.usage
+}
+
+// next records the request and selects the matching rule. When no rule
+// matches, the next pre-defined response is returned as content.
+func (m *MockProvider) next(req *CompletionRequestWithTools) (*MockRule, string, error) {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
+	m.calls = append(m.calls, req)
+
+	for _, rule := range m.rules {
+		if rule.exhausted() || !rule.matcher(req) {
+			continue
+		}
+		rule.used++
+		return rule, rule.content, nil
+	}
+
 	if m.index >= len(m.responses) {
-		return nil, errors.ErrLLMInvalidResponse.WithMessage("no more mock responses available")
+		return nil, "", errors.ErrLLMInvalidResponse.WithMessage("no more mock responses available")
 	}
 
 	content := m.responses[m.index]
 	m.index++
 
-	response := &CompletionResponse{
-		ID:           "mock-" + uuid.New().String(),
-		Model:        req.Model,
-		Content:      content,
-		FinishReason: "stop",
-		Usage: &Usage{
-			PromptTokens:     100,
-			CompletionTokens: 50,
-			TotalTokens:      150,
+	return nil, content, nil
+}
+
+// buildResponse assembles the response for a request and its rule.
+func (m *MockProvider) buildResponse(req *CompletionRequestWithTools, rule *MockRule, content string) *CompletionResponseWithTools {
+	resp := &CompletionResponseWithTools{
+		CompletionResponse: CompletionResponse{
+			ID:           "mock-" + uuid.New().String(),
+			Model:        req.Model,
+			Content:      content,
+			FinishReason: "stop",
+			Usage: &Usage{
+				PromptTokens:     100,
+				CompletionTokens: 50,
+				TotalTokens:      150,
+			},
 		},
 	}
 
-	return response, nil
+	if rule == nil {
+		return resp
+	}
+
+	if len(rule.toolCalls) > 0 {
+		resp.ToolCalls = make([]*ToolCall, len(rule.toolCalls))
+		for i, call := range rule.toolCalls {
+			function := *call.Function
+			resp.ToolCalls[i] = &ToolCall{
+				ID:       newToolCallID(),
+				Type:     call.Type,
+				Function: &function,
+			}
+		}
+		resp.FinishReason = "tool_calls"
+	}
+	if rule.finishReason != "" {
+		resp.FinishReason = rule.finishReason
+	}
+
+	if rule.usage != nil {
+		usage := *rule.usage
+		resp.Usage = &usage
+	} else {
+		prompt := m.counter.CountMessagesTokens(req.Messages)
+		completion := m.counter.CountTokens(content)
+		resp.Usage = &Usage{
+			PromptTokens:     prompt,
+			CompletionTokens: completion,
+			TotalTokens:      prompt + completion,
+		}
+	}
+
+	return resp
 }
 
-// Stream is not implemented in Phase 1.
-func (m *MockProvider) Stream(ctx context.Context, req *CompletionRequest, fn StreamFunc) error {
-	return errors.ErrNotImplemented.WithMessage("streaming not implemented in mock provider")
+// newToolCallID returns a unique tool call ID in the style of OpenAI's.
+func newToolCallID() string {
+	return "call_" + strings.ReplaceAll(uuid.New().String(), "-", "")[:24]
 }
 
-// SupportsStreaming returns false for mock provider.
-func (m *MockProvider) SupportsStreaming() bool {
-	return false
+// recordUsage adds usage to the provider totals.
+func (m *MockProvider) recordUsage(usage *Usage) {
+	if usage == nil {
+		return
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.usage.PromptTokens += usage.PromptTokens
+	m.usage.CompletionTokens += usage.CompletionTokens
+	m.usage.TotalTokens += usage.TotalTokens
+}
+
+// waitFor sleeps for d unless the context is done first.
+func waitFor(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return errors.ErrLLMTimeout.Wrap(ctx.Err())
+	case <-timer.C:
+		return nil
+	}
+}
+
+// splitWords splits text into word chunks, keeping trailing whitespace
+// attached to each word so that the chunks concatenate to the original.
+func splitWords(text string) []string {
+	if text == "" {
+		return nil
+	}
+
+	var chunks []string
+	start := 0
+	inSpace := false
+	for i, r := range text {
+		space := unicode.IsSpace(r)
+		if inSpace && !space {
+			chunks = append(chunks, text[start:i])
+			start = i
+		}
+		inSpace = space
+	}
+	return append(chunks, text[start:])
 }
@@ -19,7 +19,11 @@ package llm
 
 import (
 	"context"
+	"strings"
 	"testing"
+	"time"
+
+	"github.com/sage-x-project/sage-adk/pkg/errors"
 )
 
 func TestNewMockProvider(t *testing.T) {
@@ -151,3 +155,219 @@ func TestMockProvider_Stream_NotImplemented(t *testing.T) {
 		t.Error("Stream() should return error (not implemented)")
 	}
 }
+
+func TestMockProvider_ImplementsAdvancedProvider(t *testing.T) {
+	var _ AdvancedProvider = NewMockProvider("test", nil)
+}
+
+func TestMockProvider_Rules_MatchPrompt(t *testing.T) {
+	provider := NewMockProvider("test", []string{"fallback"})
+	provider.On(MatchPromptContains("weather")).Respond("sunny")
+	provider.On(MatchPromptRegexp(`^hello`)).Respond("hi there")
+
+	tests := []struct {
+		prompt string
+		want   string
+	}{
+		{"what is the weather?", "sunny"},
+		{"hello bot", "hi there"},
+		{"something else", "fallback"},
+	}
+
+	for _, tt := range tests {
+		resp, err := provider.Complete(context.Background(), &CompletionRequest{
+			Messages: []Message{{Role: RoleUser, Content: tt.prompt}},
+		})
+		if err != nil {
+			t.Fatalf("Complete(%q) error = %v", tt.prompt, err)
+		}
+		if resp.Content != tt.want {
+			t.Errorf("Complete(%q) = %q, want %q", tt.prompt, resp.Content, tt.want)
+		}
+	}
+
+	if provider.CallCount() != 3 {
+		t.Errorf("CallCount() = %d, want 3", provider.CallCount())
+	}
+}
+
+func TestMockProvider_CompleteWithTools_ToolLoop(t *testing.T) {
+	provider := NewMockProvider("test", nil)
+	provider.On(MatchAll(MatchToolAvailable("get_weather"), MatchPromptContains("Seoul"))).
+		RespondWithToolCall("get_weather", `{"city":"Seoul"}`).
+		Once()
+	provider.On(MatchAny()).Respond("It is sunny in Seoul.")
+
+	req := &CompletionRequestWithTools{
+		CompletionRequest: CompletionRequest{
+			Messages: []Message{{Role: RoleUser, Content: "Weather in Seoul?"}},
+		},
+		Tools: []*Tool{NewTool(NewFunction("get_weather", "Get weather", NewFunctionParameters()))},
+	}
+
+	resp, err := provider.CompleteWithTools(context.Background(), req)
+	if err != nil {
+		t.Fatalf("CompleteWithTools() error = %v", err)
+	}
+	if len(resp.ToolCalls) != 1 {
+		t.Fatalf("ToolCalls = %d, want 1", len(resp.ToolCalls))
+	}
+	if resp.FinishReason != "tool_calls" {
+		t.Errorf("FinishReason = %v, want tool_calls", resp.FinishReason)
+	}
+	args, err := resp.ToolCalls[0].Function.ParsedArguments()
+	if err != nil {
+		t.Fatalf("ParsedArguments() error = %v", err)
+	}
+	if args["city"] != "Seoul" {
+		t.Errorf("city = %v, want Seoul", args["city"])
+	}
+
+	resp, err = provider.CompleteWithTools(context.Background(), req)
+	if err != nil {
+		t.Fatalf("second CompleteWithTools() error = %v", err)
+	}
+	if len(resp.ToolCalls) != 0 || resp.Content != "It is sunny in Seoul." {
+		t.Errorf("second response = %+v, want final answer", resp)
+	}
+}
+
+func TestMockProvider_ToolCallsPerResponse(t *testing.T) {
+	provider := NewMockProvider("test", nil)
+	provider.On(MatchAny()).RespondWithToolCall("get_weather", `{"city":"Seoul"}`)
+
+	req := &CompletionRequestWithTools{
+		CompletionRequest: CompletionRequest{
+			Messages: []Message{{Role: RoleUser, Content: "Weather?"}},
+		},
+	}
+
+	first, err := provider.CompleteWithTools(context.Background(), req)
+	if err != nil {
+		t.Fatalf("CompleteWithTools() error = %v", err)
+	}
+	first.ToolCalls[0].Function.Arguments = `{"city":"Busan"}`
+
+	second, err := provider.CompleteWithTools(context.Background(), req)
+	if err != nil {
+		t.Fatalf("second CompleteWithTools() error = %v", err)
+	}
+	if second.ToolCalls[0].ID == "" || second.ToolCalls[0].ID == first.ToolCalls[0].ID {
+		t.Errorf("tool call IDs = %q, %q, want distinct IDs", first.ToolCalls[0].ID, second.ToolCalls[0].ID)
+	}
+	if got := second.ToolCalls[0].Function.Arguments; got != `{"city":"Seoul"}` {
+		t.Errorf("second response arguments = %s, changed through the first response", got)
+	}
+}
+
+func TestMockProvider_Fail_ThenRecover(t *testing.T) {
+	provider := NewMockProvider("test", nil)
+	provider.On(nil).Fail(errors.ErrLLMRateLimit).Times(2)
+	provider.On(nil).Respond("ok")
+
+	req := &CompletionRequest{Messages: []Message{{Role: RoleUser, Content: "test"}}}
+
+	for i := 0; i < 2; i++ {
+		_, err := provider.Complete(context.Background(), req)
+		if !errors.Is(err, errors.ErrLLMRateLimit) {
+			t.Fatalf("call %d error = %v, want rate limit", i, err)
+		}
+	}
+
+	resp, err := provider.Complete(context.Background(), req)
+	if err != nil {
+		t.Fatalf("Complete() error = %v", err)
+	}
+	if resp.Content != "ok" {
+		t.Errorf("Content = %v, want ok", resp.Content)
+	}
+}
+
+func TestMockProvider_Latency_ContextCancelled(t *testing.T) {
+	provider := NewMockProvider("test", nil)
+	provider.On(nil).Respond("slow").WithLatency(time.Second)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	_, err := provider.Complete(ctx, &CompletionRequest{})
+	if !errors.Is(err, errors.ErrLLMTimeout) {
+		t.Errorf("error = %v, want LLM timeout", err)
+	}
+}
+
+func TestMockProvider_Stream_Chunks(t *testing.T) {
+	provider := NewMockProvider("test", nil)
+	provider.On(nil).StreamChunks(time.Millisecond, "Hello", ", ", "world")
+
+	if !provider.SupportsStreaming() {
+		t.Fatal("SupportsStreaming() should be true once a rule streams")
+	}
+
+	var chunks []string
+	err := provider.Stream(context.Background(), &CompletionRequest{}, func(chunk string) error {
+		chunks = append(chunks, chunk)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Stream() error = %v", err)
+	}
+	if strings.Join(chunks, "") != "Hello, world" || len(chunks) != 3 {
+		t.Errorf("chunks = %q", chunks)
+	}
+}
+
+func TestMockProvider_Stream_SplitsContentAndFailsMidStream(t *testing.T) {
+	provider := NewMockProvider("test", nil)
+	provider.SetStreaming(true)
+	provider.On(MatchPromptContains("fail")).StreamChunks(0, "partial").Fail(errors.ErrLLMConnection)
+	provider.On(nil).Respond("one two three")
+
+	var chunks []string
+	collect := func(chunk string) error {
+		chunks = append(chunks, chunk)
+		return nil
+	}
+
+	if err := provider.Stream(context.Background(), &CompletionRequest{}, collect); err != nil {
+		t.Fatalf("Stream() error = %v", err)
+	}
+	if len(chunks) != 3 || strings.Join(chunks, "") != "one two three" {
+		t.Errorf("chunks = %q", chunks)
+	}
+
+	chunks = nil
+	err := provider.Stream(context.Background(), &CompletionRequest{
+		Messages: []Message{{Role: RoleUser, Content: "please fail"}},
+	}, collect)
+	if !errors.Is(err, errors.ErrLLMConnection) {
+		t.Errorf("error = %v, want connection error", err)
+	}
+	if len(chunks) != 1 || chunks[0] != "partial" {
+		t.Errorf("chunks = %q, want [partial]", chunks)
+	}
+}
+
+func TestMockProvider_Usage(t *testing.T) {
+	provider := NewMockProvider("test", nil)
+	provider.On(MatchModel("big")).Respond("a").WithUsage(10, 5)
+	provider.On(nil).Respond("b")
+
+	if _, err := provider.Complete(context.Background(), &CompletionRequest{Model: "big"}); err != nil {
+		t.Fatalf("Complete() error = %v", err)
+	}
+	resp, err := provider.Complete(context.Background(), &CompletionRequest{
+		Messages: []Message{{Role: RoleUser, Content: "count these words"}},
+	})
+	if err != nil {
+		t.Fatalf("Complete() error = %v", err)
+	}
+	if resp.Usage.PromptTokens == 0 || resp.Usage.CompletionTokens == 0 {
+		t.Errorf("estimated usage = %+v, want non-zero", resp.Usage)
+	}
+
+	total := provider.TotalUsage()
+	if total.TotalTokens != 15+resp.Usage.TotalTokens {
+		t.Errorf("TotalUsage() = %+v", total)
+	}
+}
@@ -21,6 +21,7 @@ import (
 	"context"
 	"errors"
 	"io"
+	"net/http"
 	"os"
 
 	openai "github.com/sashabaranov/go-openai"
@@ -45,6 +46,12 @@ type OpenAIConfig struct {
 	// BaseURL is the API base URL (for custom endpoints).
 	// Default: https://api.openai.com/v1
 	BaseURL string
+
+	// Organization is the OpenAI organization ID (optional).
+	Organization string
+
+	// HTTPClient is the HTTP client to use (optional).
+	HTTPClient *http.Client
 }
 
 // OpenAI creates a new OpenAI provider with optional configuration.
@@ -91,6 +98,12 @@ func OpenAI(config ...*OpenAIConfig) Provider {
 	if cfg.BaseURL != "" {
 		clientConfig.BaseURL = cfg.BaseURL
 	}
+	if cfg.Organization != "" {
+		clientConfig.OrgID = cfg.Organization
+	}
+	if cfg.HTTPClient != nil {
+		clientConfig.HTTPClient = cfg.HTTPClient
+	}
 
 	client := openai.NewClientWithConfig(clientConfig)
 
@@ -100,6 +113,32 @@ func OpenAI(config ...*OpenAIConfig) Provider {
 	}
 }
 
+func init() {
+	RegisterFactory("openai", newOpenAIFromConfig,
+		ConfigField{Name: FieldAPIKey, Description: "OpenAI API key", Required: true, EnvVar: "OPENAI_API_KEY"},
+		ConfigField{Name: FieldModel, Description: "Default model (default: gpt-4)", EnvVar: "OPENAI_MODEL"},
+		ConfigField{Name: FieldBaseURL, Description: "API base URL for compatible endpoints"},
+		ConfigField{Name: FieldOrganization, Description: "OpenAI organization ID", EnvVar: "OPENAI_ORG_ID"},
+		ConfigField{Name: FieldProxyURL, Description: "HTTP proxy URL"},
+	)
+}
+
+// newOpenAIFromConfig is the factory for the "openai" provider type.
+func newOpenAIFromConfig(cfg *ProviderConfig) (Provider, error) {
+	httpClient, err := cfg.HTTPClient()
+	if err != nil {
+		return nil, err
+	}
+
+	return OpenAI(&OpenAIConfig{
+		APIKey:       cfg.APIKey,
+		Model:        cfg.Model,
+		BaseURL:      cfg.BaseURL,
+		Organization: cfg.Organization,
+		HTTPClient:   httpClient,
+	}), nil
+}
+
 // Name returns the provider name.
 func (p *OpenAIProvider) Name() string {
 	return "openai"
@@ -0,0 +1,477 @@
+// Copyright (C) 2025 sage-x-project
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with this program. If not, see <https://www.gnu.org/licenses/>.
+
+// SPDX-License-Identifier: LGPL-3.0-or-later
+
+package llm
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/sage-x-project/sage-adk/pkg/errors"
+)
+
+// SchemaFor reflects the function parameters of struct type T.
+//
+// Property names follow the json tag. Additional struct tags refine the schema:
+//
+//	type WeatherArgs struct {
+//	    City  string `json:"city" description:"City name" required:"true"`
+//	    Unit  string `json:"unit,omitempty" enum:"celsius,fahrenheit"`
+//	    Days  int    `json:"days,omitempty" min:"1" max:"7"`
+//	}
+//
+// min and max bound numbers, string lengths and array lengths.
+func SchemaFor[T any]() (*FunctionParameters, error) {
+	return SchemaFromType(reflect.TypeOf((*T)(nil)).Elem())
+}
+
+// SchemaFromType reflects the function parameters of a struct type.
+func SchemaFromType(t reflect.Type) (*FunctionParameters, error) {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return nil, errors.ErrInvalidInput.
+			WithMessage("parameter type must be a struct").
+			WithDetail("type", t.String())
+	}
+
+	prop, err := reflectStruct(t, map[reflect.Type]bool{})
+	if err != nil {
+		return nil, err
+	}
+
+	return &FunctionParameters{
+		Type:       "object",
+		Properties: prop.Properties,
+		Required:   prop.Required,
+	}, nil
+}
+
+// reflectStruct builds an object schema from struct fields.
+func reflectStruct(t reflect.Type, visiting map[reflect.Type]bool) (*PropertySchema, error) {
+	if visiting[t] {
+		return nil, errors.ErrInvalidInput.
+			WithMessage("recursive parameter types are not supported").
+			WithDetail("type", t.String())
+	}
+	visiting[t] = true
+	defer delete(visiting, t)
+
+	schema := &PropertySchema{
+		Type:       "object",
+		Properties: make(map[string]*PropertySchema),
+	}
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+
+		name, skip := jsonFieldName(field)
+		if skip {
+			continue
+		}
+
+		// Flatten embedded structs without an explicit json name.
+		if field.Anonymous && name == "" {
+			ft := field.Type
+			for ft.Kind() == reflect.Ptr {
+				ft = ft.Elem()
+			}
+			if ft.Kind() == reflect.Struct {
+				embedded, err := reflectStruct(ft, visiting)
+				if err != nil {
+					return nil, err
+				}
+				for k, v := range embedded.Properties {
+					schema.Properties[k] = v
+				}
+				schema.Required = append(schema.Required, embedded.Required...)
+				continue
+			}
+		}
+		if !field.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+
+		prop, err := reflectType(field.Type, visiting)
+		if err != nil {
+			return nil, err
+		}
+		if err := applyTags(prop, field); err != nil {
+			return nil, err
+		}
+		schema.Properties[name] = prop
+
+		if field.Tag.Get("required") == "true" {
+			schema.Required = append(schema.Required, name)
+		}
+	}
+
+	return schema, nil
+}
+
+// reflectType builds the schema of a single Go type.
+func reflectType(t reflect.Type, visiting map[reflect.Type]bool) (*PropertySchema, error) {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	switch t.Kind() {
+	case reflect.String:
+		return &PropertySchema{Type: "string"}, nil
+	case reflect.Bool:
+		return &PropertySchema{Type: "boolean"}, nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return &PropertySchema{Type: "integer"}, nil
+	case reflect.Float32, reflect.Float64:
+		return &PropertySchema{Type: "number"}, nil
+	case reflect.Slice, reflect.Array:
+		items, err := reflectType(t.Elem(), visiting)
+		if err != nil {
+			return nil, err
+		}
+		return &PropertySchema{Type: "array", Items: items}, nil
+	case reflect.Map:
+		return &PropertySchema{Type: "object"}, nil
+	case reflect.Struct:
+		if t.PkgPath() == "time" && t.Name() == "Time" {
+			return &PropertySchema{Type: "string"}, nil
+		}
+		return reflectStruct(t, visiting)
+	case reflect.Interface:
+		return &PropertySchema{}, nil
+	default:
+		return nil, errors.ErrInvalidInput.
+			WithMessage("unsupported parameter type").
+			WithDetail("type", t.String())
+	}
+}
+
+// applyTags applies description, enum and bound tags to a property.
+func applyTags(prop *PropertySchema, field reflect.StructField) error {
+	if desc := field.Tag.Get("description"); desc != "" {
+		prop.Description = desc
+	}
+
+	if enum := field.Tag.Get("enum"); enum != "" {
+		for _, raw := range strings.Split(enum, ",") {
+			v, err := parseEnumValue(prop.Type, strings.TrimSpace(raw))
+			if err != nil {
+				return errors.ErrInvalidFormat.
+					WithMessage("invalid enum tag").
+					WithDetail("field", field.Name).
+					Wrap(err)
+			}
+			prop.Enum = append(prop.Enum, v)
+		}
+	}
+
+	for _, tag := range []string{"min", "max"} {
+		raw := field.Tag.Get(tag)
+		if raw == "" {
+			continue
+		}
+		v, err := strconv.ParseFloat(raw, 64)
+		if err != nil {
+			return errors.ErrInvalidFormat.
+				WithMessage("invalid "+tag+" tag").
+				WithDetail("field", field.Name).
+				Wrap(err)
+		}
+		setBound(prop, tag == "min", v)
+	}
+
+	return nil
+}
+
+// parseEnumValue converts an enum tag value to the property type, so that
+// it matches the decoded JSON arguments.
+func parseEnumValue(typ, raw string) (interface{}, error) {
+	switch typ {
+	case "integer":
+		return strconv.ParseInt(raw, 10, 64)
+	case "number":
+		return strconv.ParseFloat(raw, 64)
+	case "boolean":
+		return strconv.ParseBool(raw)
+	default:
+		return raw, nil
+	}
+}
+
+// setBound stores a min or max bound in the field matching the property type.
+func setBound(prop *PropertySchema, isMin bool, v float64) {
+	n := int(v)
+	switch prop.Type {
+	case "string":
+		if isMin {
+			prop.MinLength = &n
+		} else {
+			prop.MaxLength = &n
+		}
+	case "array":
+		if isMin {
+			prop.MinItems = &n
+		} else {
+			prop.MaxItems = &n
+		}
+	default:
+		if isMin {
+			prop.Minimum = &v
+		} else {
+			prop.Maximum = &v
+		}
+	}
+}
+
+// jsonFieldName returns the json name of a field and whether it is skipped.
+func jsonFieldName(field reflect.StructField) (string, bool) {
+	tag := field.Tag.Get("json")
+	if tag == "-" {
+		return "", true
+	}
+	name, _, _ := strings.Cut(tag, ",")
+	return name, false
+}
+
+// ValidationError reports arguments that do not match a schema.
+// It unwraps to errors.ErrInvalidInput.
+type ValidationError struct {
+	// Problems lists each violation, prefixed with the property path.
+	Problems []string
+}
+
+// Error implements the error interface.
+func (e *ValidationError) Error() string {
+	return "invalid arguments: " + strings.Join(e.Problems, "; ")
+}
+
+// Unwrap returns errors.ErrInvalidInput.
+func (e *ValidationError) Unwrap() error {
+	return errors.ErrInvalidInput
+}
+
+// Validate checks arguments against the schema. They are normalized
+// through JSON first, so Go values such as []string, typed maps and
+// structs are checked as the JSON they encode to.
+// It returns a *ValidationError describing every violation, or nil.
+func (p *FunctionParameters) Validate(args map[string]interface{}) error {
+	if p == nil {
+		return nil
+	}
+
+	normalized, err := normalizeArgs(args)
+	if err != nil {
+		return &ValidationError{Problems: []string{"arguments are not valid JSON: " + err.Error()}}
+	}
+
+	var problems []string
+	validateObject("", p.Properties, p.Required, normalized, &problems)
+	if len(problems) == 0 {
+		return nil
+	}
+
+	sort.Strings(problems)
+	return &ValidationError{Problems: problems}
+}
+
+// normalizeArgs round-trips args through JSON, so that they only hold the
+// types encoding/json decodes to.
+func normalizeArgs(args map[string]interface{}) (map[string]interface{}, error) {
+	data, err := json.Marshal(args)
+	if err != nil {
+		return nil, err
+	}
+	var normalized map[string]interface{}
+	if err := json.Unmarshal(data, &normalized); err != nil {
+		return nil, err
+	}
+	return normalized, nil
+}
+
+// validateObject validates the properties of an object value.
+func validateObject(path string, props map[string]*PropertySchema, required []string, obj map[string]interface{}, problems *[]string) {
+	for _, name := range required {
+		if v, ok := obj[name]; !ok || v == nil {
+			*problems = append(*problems, joinPath(path, name)+": is required")
+		}
+	}
+
+	for name, value := range obj {
+		prop, ok := props[name]
+		if !ok || prop == nil || value == nil {
+			continue
+		}
+		validateValue(joinPath(path, name), prop, value, problems)
+	}
+}
+
+// validateValue validates a single value against its property schema.
+func validateValue(path string, prop *PropertySchema, value interface{}, problems *[]string) {
+	fail := func(format string, args ...interface{}) {
+		*problems = append(*problems, path+": "+fmt.Sprintf(format, args...))
+	}
+	checkEnum := func() {
+		if len(prop.Enum) > 0 && !enumContains(prop.Enum, value) {
+			fail("must be one of %s", formatEnum(prop.Enum))
+		}
+	}
+
+	switch prop.Type {
+	case "":
+		// Any type.
+	case "string":
+		s, ok := value.(string)
+		if !ok {
+			fail("expected string, got %s", jsonType(value))
+			return
+		}
+		checkEnum()
+		n := utf8.RuneCountInString(s)
+		if prop.MinLength != nil && n < *prop.MinLength {
+			fail("must be at least %d characters", *prop.MinLength)
+		}
+		if prop.MaxLength != nil && n > *prop.MaxLength {
+			fail("must be at most %d characters", *prop.MaxLength)
+		}
+	case "boolean":
+		if _, ok := value.(bool); !ok {
+			fail("expected boolean, got %s", jsonType(value))
+			return
+		}
+		checkEnum()
+	case "number", "integer":
+		f, ok := toFloat(value)
+		if !ok {
+			fail("expected %s, got %s", prop.Type, jsonType(value))
+			return
+		}
+		if prop.Type == "integer" && f != math.Trunc(f) {
+			fail("expected integer, got %v", f)
+		}
+		checkEnum()
+		if prop.Minimum != nil && f < *prop.Minimum {
+			fail("must be >= %v", *prop.Minimum)
+		}
+		if prop.Maximum != nil && f > *prop.Maximum {
+			fail("must be <= %v", *prop.Maximum)
+		}
+	case "array":
+		items, ok := value.([]interface{})
+		if !ok {
+			fail("expected array, got %s", jsonType(value))
+			return
+		}
+		if prop.MinItems != nil && len(items) < *prop.MinItems {
+			fail("must have at least %d items", *prop.MinItems)
+		}
+		if prop.MaxItems != nil && len(items) > *prop.MaxItems {
+			fail("must have at most %d items", *prop.MaxItems)
+		}
+		if prop.Items != nil {
+			for i, item := range items {
+				if item != nil {
+					validateValue(fmt.Sprintf("%s[%d]", path, i), prop.Items, item, problems)
+				}
+			}
+		}
+	case "object":
+		obj, ok := value.(map[string]interface{})
+		if !ok {
+			fail("expected object, got %s", jsonType(value))
+			return
+		}
+		validateObject(path, prop.Properties, prop.Required, obj, problems)
+	}
+}
+
+// toFloat converts a numeric value to float64.
+func toFloat(value interface{}) (float64, bool) {
+	v := reflect.ValueOf(value)
+	switch v.Kind() {
+	case reflect.Float32, reflect.Float64:
+		return v.Float(), true
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(v.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(v.Uint()), true
+	}
+	return 0, false
+}
+
+// jsonType names the JSON type of a decoded value.
+func jsonType(value interface{}) string {
+	switch value.(type) {
+	case string:
+		return "string"
+	case bool:
+		return "boolean"
+	case []interface{}:
+		return "array"
+	case map[string]interface{}:
+		return "object"
+	}
+	if _, ok := toFloat(value); ok {
+		return "number"
+	}
+	return fmt.Sprintf("%T", value)
+}
+
+// joinPath appends a property name to a path.
+func joinPath(path, name string) string {
+	if path == "" {
+		return name
+	}
+	return path + "." + name
+}
+
+// enumContains reports whether enum contains value. Numbers are compared
+// by value, whatever their Go type.
+func enumContains(enum []interface{}, value interface{}) bool {
+	f, isNumber := toFloat(value)
+	for _, v := range enum {
+		if isNumber {
+			if g, ok := toFloat(v); ok && g == f {
+				return true
+			}
+			continue
+		}
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// formatEnum lists enum values for a validation problem.
+func formatEnum(enum []interface{}) string {
+	values := make([]string, len(enum))
+	for i, v := range enum {
+		values[i] = fmt.Sprint(v)
+	}
+	return strings.Join(values, ", ")
+}
@@ -0,0 +1,269 @@
+// Copyright (C) 2025 sage-x-project
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with this program. If not, see <https://www.gnu.org/licenses/>.
+
+// SPDX-License-Identifier: LGPL-3.0-or-later
+
+package llm
+
+import (
+	"encoding/json"
+	stderrors "errors"
+	"strings"
+	"testing"
+
+	"github.com/sage-x-project/sage-adk/pkg/errors"
+)
+
+type schemaAddress struct {
+	City string `json:"city" required:"true"`
+	Zip  string `json:"zip,omitempty" min:"5" max:"5"`
+}
+
+type schemaBase struct {
+	ID string `json:"id" required:"true"`
+}
+
+type schemaArgs struct {
+	schemaBase
+	Query   string         `json:"query" description:"Search query" required:"true" min:"1"`
+	Unit    string         `json:"unit,omitempty" enum:"celsius, fahrenheit"`
+	Limit   int            `json:"limit,omitempty" min:"1" max:"10"`
+	Score   *float64       `json:"score,omitempty"`
+	Tags    []string       `json:"tags,omitempty" max:"3"`
+	Address *schemaAddress `json:"address,omitempty"`
+	Extra   interface{}    `json:"extra,omitempty"`
+	Labels  map[string]int `json:"labels,omitempty"`
+	Ignored string         `json:"-"`
+	hidden  string
+}
+
+type schemaTypedEnums struct {
+	Level  int     `json:"level,omitempty" enum:"1,2,3"`
+	Ratio  float64 `json:"ratio,omitempty" enum:"0.5, 1"`
+	Strict bool    `json:"strict,omitempty" enum:"true"`
+}
+
+type schemaNode struct {
+	Next *schemaNode `json:"next"`
+}
+
+func TestSchemaFor(t *testing.T) {
+	schema, err := SchemaFor[schemaArgs]()
+	if err != nil {
+		t.Fatalf("SchemaFor() error = %v", err)
+	}
+
+	if schema.Type != "object" {
+		t.Errorf("Type = %q, want object", schema.Type)
+	}
+	if len(schema.Properties) != 9 {
+		t.Errorf("len(Properties) = %d, want 9", len(schema.Properties))
+	}
+	if _, ok := schema.Properties["Ignored"]; ok {
+		t.Error("json:\"-\" field should be skipped")
+	}
+	if got := strings.Join(schema.Required, ","); got != "id,query" {
+		t.Errorf("Required = %q, want id,query", got)
+	}
+
+	query := schema.Properties["query"]
+	if query.Type != "string" || query.Description != "Search query" {
+		t.Errorf("query = %+v", query)
+	}
+	if query.MinLength == nil || *query.MinLength != 1 {
+		t.Errorf("query.MinLength = %v, want 1", query.MinLength)
+	}
+	if got := formatEnum(schema.Properties["unit"].Enum); got != "celsius, fahrenheit" {
+		t.Errorf("unit.Enum = %q", got)
+	}
+
+	limit := schema.Properties["limit"]
+	if limit.Type != "integer" || *limit.Minimum != 1 || *limit.Maximum != 10 {
+		t.Errorf("limit = %+v", limit)
+	}
+	if schema.Properties["score"].Type != "number" {
+		t.Errorf("score.Type = %q, want number", schema.Properties["score"].Type)
+	}
+
+	tags := schema.Properties["tags"]
+	if tags.Type != "array" || tags.Items.Type != "string" || *tags.MaxItems != 3 {
+		t.Errorf("tags = %+v", tags)
+	}
+
+	address := schema.Properties["address"]
+	if address.Type != "object" || address.Properties["city"].Type != "string" {
+		t.Errorf("address = %+v", address)
+	}
+	if len(address.Required) != 1 || address.Required[0] != "city" {
+		t.Errorf("address.Required = %v", address.Required)
+	}
+	if schema.Properties["extra"].Type != "" {
+		t.Errorf("extra.Type = %q, want any", schema.Properties["extra"].Type)
+	}
+
+	if _, err := json.Marshal(schema); err != nil {
+		t.Errorf("json.Marshal() error = %v", err)
+	}
+}
+
+func TestSchemaFor_Errors(t *testing.T) {
+	if _, err := SchemaFor[string](); !stderrors.Is(err, errors.ErrInvalidInput) {
+		t.Errorf("SchemaFor[string]() error = %v, want ErrInvalidInput", err)
+	}
+	if _, err := SchemaFor[schemaNode](); err == nil {
+		t.Error("SchemaFor() should reject recursive types")
+	}
+	if _, err := SchemaFor[struct {
+		C chan int `json:"c"`
+	}](); err == nil {
+		t.Error("SchemaFor() should reject channels")
+	}
+	if _, err := SchemaFor[struct {
+		N int `json:"n" min:"low"`
+	}](); err == nil {
+		t.Error("SchemaFor() should reject invalid bounds")
+	}
+	if _, err := SchemaFor[struct {
+		N int `json:"n" enum:"one"`
+	}](); err == nil {
+		t.Error("SchemaFor() should reject enum values of another type")
+	}
+}
+
+func TestFunctionParameters_Validate(t *testing.T) {
+	schema, err := SchemaFor[schemaArgs]()
+	if err != nil {
+		t.Fatalf("SchemaFor() error = %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		args    string
+		problem string
+	}{
+		{"valid", `{"id":"1","query":"go","unit":"celsius","limit":3,"tags":["a"],"address":{"city":"Seoul","zip":"04524"},"extra":[1]}`, ""},
+		{"missing required", `{"id":"1"}`, "query: is required"},
+		{"null required", `{"id":"1","query":null}`, "query: is required"},
+		{"wrong type", `{"id":"1","query":5}`, "query: expected string, got number"},
+		{"enum", `{"id":"1","query":"go","unit":"kelvin"}`, "unit: must be one of celsius, fahrenheit"},
+		{"min length", `{"id":"1","query":""}`, "query: must be at least 1 characters"},
+		{"not integer", `{"id":"1","query":"go","limit":2.5}`, "limit: expected integer, got 2.5"},
+		{"maximum", `{"id":"1","query":"go","limit":11}`, "limit: must be <= 10"},
+		{"max items", `{"id":"1","query":"go","tags":["a","b","c","d"]}`, "tags: must have at most 3 items"},
+		{"item type", `{"id":"1","query":"go","tags":["a",1]}`, "tags[1]: expected string, got number"},
+		{"nested required", `{"id":"1","query":"go","address":{}}`, "address.city: is required"},
+		{"nested bounds", `{"id":"1","query":"go","address":{"city":"Seoul","zip":"1"}}`, "address.zip: must be at least 5 characters"},
+		{"object type", `{"id":"1","query":"go","labels":[]}`, "labels: expected object, got array"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var args map[string]interface{}
+			if err := json.Unmarshal([]byte(tt.args), &args); err != nil {
+				t.Fatal(err)
+			}
+
+			err := schema.Validate(args)
+			if tt.problem == "" {
+				if err != nil {
+					t.Errorf("Validate() error = %v", err)
+				}
+				return
+			}
+
+			var verr *ValidationError
+			if !stderrors.As(err, &verr) {
+				t.Fatalf("Validate() error = %v, want *ValidationError", err)
+			}
+			if len(verr.Problems) != 1 || verr.Problems[0] != tt.problem {
+				t.Errorf("Problems = %q, want [%q]", verr.Problems, tt.problem)
+			}
+			if !stderrors.Is(err, errors.ErrInvalidInput) {
+				t.Error("ValidationError should match ErrInvalidInput")
+			}
+		})
+	}
+}
+
+func TestFunctionParameters_ValidateGoValues(t *testing.T) {
+	schema, _ := SchemaFor[schemaArgs]()
+
+	if err := schema.Validate(map[string]interface{}{"id": "1", "query": "go", "limit": 3}); err != nil {
+		t.Errorf("Validate() error = %v", err)
+	}
+
+	// Typed slices, maps and structs are checked as the JSON they encode to
+	if err := schema.Validate(map[string]interface{}{
+		"id":      "1",
+		"query":   "go",
+		"tags":    []string{"a", "b"},
+		"labels":  map[string]int{"x": 1},
+		"address": schemaAddress{City: "Seoul"},
+	}); err != nil {
+		t.Errorf("Validate() of Go values error = %v", err)
+	}
+	if err := schema.Validate(map[string]interface{}{"id": "1", "query": "go", "tags": []int{1}}); err == nil {
+		t.Error("Validate() accepted []int for an array of strings")
+	}
+	if err := schema.Validate(map[string]interface{}{"id": "1", "query": "go", "extra": make(chan int)}); err == nil {
+		t.Error("Validate() accepted arguments that are not JSON")
+	}
+
+	var nilSchema *FunctionParameters
+	if err := nilSchema.Validate(nil); err != nil {
+		t.Errorf("nil Validate() error = %v", err)
+	}
+}
+
+func TestFunctionParameters_ValidateTypedEnums(t *testing.T) {
+	schema, err := SchemaFor[schemaTypedEnums]()
+	if err != nil {
+		t.Fatalf("SchemaFor() error = %v", err)
+	}
+
+	data, _ := json.Marshal(schema.Properties["level"])
+	if got := string(data); !strings.Contains(got, `"enum":[1,2,3]`) {
+		t.Errorf("level schema = %s, want numeric enum", got)
+	}
+
+	tests := []struct {
+		name    string
+		args    map[string]interface{}
+		problem string
+	}{
+		{"valid JSON numbers", map[string]interface{}{"level": float64(2), "ratio": 0.5, "strict": true}, ""},
+		{"valid Go int", map[string]interface{}{"level": 3}, ""},
+		{"integer", map[string]interface{}{"level": 4}, "level: must be one of 1, 2, 3"},
+		{"number", map[string]interface{}{"ratio": 0.25}, "ratio: must be one of 0.5, 1"},
+		{"boolean", map[string]interface{}{"strict": false}, "strict: must be one of true"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := schema.Validate(tt.args)
+			if tt.problem == "" {
+				if err != nil {
+					t.Errorf("Validate() error = %v", err)
+				}
+				return
+			}
+
+			var verr *ValidationError
+			if !stderrors.As(err, &verr) || len(verr.Problems) != 1 || verr.Problems[0] != tt.problem {
+				t.Errorf("Validate() error = %v, want [%q]", err, tt.problem)
+			}
+		})
+	}
+}
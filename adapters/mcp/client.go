@@ -0,0 +1,469 @@
+// Copyright (C) 2025 sage-x-project
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with this program. If not, see <https://www.gnu.org/licenses/>.
+
+// SPDX-License-Identifier: LGPL-3.0-or-later
+
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/sage-x-project/sage-adk/pkg/errors"
+)
+
+// ClientConfig configures an MCP client.
+type ClientConfig struct {
+	// Name and Version identify the client to servers.
+	Name    string
+	Version string
+
+	// Timeout bounds each request, including initialization.
+	// Zero disables the timeout.
+	// Default: 30s
+	Timeout time.Duration
+
+	// MaxReconnects is the number of times a lost connection is
+	// re-established for a single request.
+	// Default: 3
+	MaxReconnects int
+
+	// ReconnectDelay is the delay before the first reconnect.
+	// It grows linearly with each further attempt.
+	// Default: 500ms
+	ReconnectDelay time.Duration
+
+	// OnToolsChanged is called when the server reports that its tool
+	// list changed.
+	OnToolsChanged func()
+}
+
+// DefaultClientConfig returns the default client configuration.
+func DefaultClientConfig() *ClientConfig {
+	return &ClientConfig{
+		Name:           "sage-adk",
+		Version:        "1.2.0",
+		Timeout:        30 * time.Second,
+		MaxReconnects:  3,
+		ReconnectDelay: 500 * time.Millisecond,
+	}
+}
+
+// Client is an MCP client. It connects lazily and reconnects when the
+// connection is lost. It is safe for concurrent use.
+//
+// Requests that may have reached the server are not repeated after a
+// connection loss unless they are idempotent (tools/list and ping), so a
+// tool is never called twice by the client.
+type Client struct {
+	transport Transport
+	config    *ClientConfig
+
+	// mu guards the fields below and is held while connecting.
+	mu     sync.Mutex
+	conn   *clientConn
+	info   *InitializeResult
+	closed bool
+
+	nextID atomic.Int64
+}
+
+// NewClient creates a client using transport.
+//
+// Example:
+//
+//	client := mcp.NewClient(mcp.NewStdioTransport("npx", "-y", "@modelcontextprotocol/server-everything"), nil)
+//	defer client.Close()
+//
+//	if err := client.RegisterTools(ctx, registry, "everything_"); err != nil {
+//	    log.Fatal(err)
+//	}
+func NewClient(transport Transport, config *ClientConfig) *Client {
+	if config == nil {
+		config = DefaultClientConfig()
+	}
+	return &Client{
+		transport: transport,
+		config:    config,
+	}
+}
+
+// Connect connects to the server and performs initialization.
+// Calling it is optional; requests connect on demand.
+func (c *Client) Connect(ctx context.Context) error {
+	_, err := c.connection(ctx)
+	return err
+}
+
+// ServerInfo returns the result of the last initialization,
+// or nil if the client never connected.
+func (c *Client) ServerInfo() *InitializeResult {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.info
+}
+
+// ListTools lists all tools offered by the server.
+func (c *Client) ListTools(ctx context.Context) ([]*Tool, error) {
+	var all []*Tool
+	params := &ListToolsParams{}
+
+	for {
+		var result ListToolsResult
+		if err := c.call(ctx, MethodToolsList, params, &result, true); err != nil {
+			return nil, err
+		}
+		all = append(all, result.Tools...)
+
+		if result.NextCursor == "" {
+			return all, nil
+		}
+		params = &ListToolsParams{Cursor: result.NextCursor}
+	}
+}
+
+// CallTool calls the named tool. A tool failure is reported in the
+// result's IsError field; errors are protocol or connection failures.
+func (c *Client) CallTool(ctx context.Context, name string, args map[string]interface{}) (*CallToolResult, error) {
+	var result CallToolResult
+	params := &CallToolParams{Name: name, Arguments: args}
+	if err := c.call(ctx, MethodToolsCall, params, &result, false); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// ListPrompts lists all prompts offered by the server.
+func (c *Client) ListPrompts(ctx context.Context) ([]*Prompt, error) {
+	var all []*Prompt
+	params := &ListPromptsParams{}
+
+	for {
+		var result ListPromptsResult
+		if err := c.call(ctx, MethodPromptsList, params, &result, true); err != nil {
+			return nil, err
+		}
+		all = append(all, result.Prompts...)
+
+		if result.NextCursor == "" {
+			return all, nil
+		}
+		params = &ListPromptsParams{Cursor: result.NextCursor}
+	}
+}
+
+// GetPrompt renders the named prompt with args.
+func (c *Client) GetPrompt(ctx context.Context, name string, args map[string]string) (*GetPromptResult, error) {
+	var result GetPromptResult
+	params := &GetPromptParams{Name: name, Arguments: args}
+	if err := c.call(ctx, MethodPromptsGet, params, &result, true); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// Ping checks that the server is responsive.
+func (c *Client) Ping(ctx context.Context) error {
+	return c.call(ctx, MethodPing, nil, nil, true)
+}
+
+// Close closes the connection. The client cannot be used afterwards.
+func (c *Client) Close() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.closed = true
+	if c.conn == nil {
+		return nil
+	}
+	err := c.conn.Close()
+	c.conn = nil
+	return err
+}
+
+// call sends a request, reconnecting when the connection is lost.
+func (c *Client) call(ctx context.Context, method string, params, result interface{}, idempotent bool) error {
+	var lastErr error
+
+	for attempt := 0; attempt <= c.config.MaxReconnects; attempt++ {
+		if attempt > 0 {
+			select {
+			case <-time.After(c.config.ReconnectDelay * time.Duration(attempt)):
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+
+		cc, err := c.connection(ctx)
+		if err != nil {
+			if !isConnectionError(err) {
+				return err
+			}
+			lastErr = err
+			continue
+		}
+
+		sent, err := cc.call(ctx, method, params, result)
+		if err == nil || !isConnectionError(err) {
+			return err
+		}
+
+		c.drop(cc)
+		if sent && !idempotent {
+			return err
+		}
+		lastErr = err
+	}
+
+	return lastErr
+}
+
+// connection returns the current connection, connecting if needed.
+func (c *Client) connection(ctx context.Context) (*clientConn, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.closed {
+		return nil, ErrConnectionClosed.WithMessage("client is closed")
+	}
+
+	if c.conn != nil {
+		select {
+		case <-c.conn.Done():
+			c.conn.Close()
+			c.conn = nil
+		default:
+			return c.conn, nil
+		}
+	}
+
+	conn, err := c.transport.Connect(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	cc := &clientConn{
+		Conn:    conn,
+		client:  c,
+		pending: make(map[string]chan *Message),
+	}
+	go cc.readLoop()
+
+	info, err := cc.initialize(ctx)
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+
+	c.conn = cc
+	c.info = info
+	return cc, nil
+}
+
+// drop discards a broken connection.
+func (c *Client) drop(cc *clientConn) {
+	c.mu.Lock()
+	if c.conn == cc {
+		c.conn = nil
+	}
+	c.mu.Unlock()
+
+	cc.Close()
+}
+
+// clientConn tracks the pending requests of one connection.
+type clientConn struct {
+	Conn
+	client *Client
+
+	mu      sync.Mutex
+	pending map[string]chan *Message
+}
+
+// initialize performs the MCP initialization handshake.
+func (cc *clientConn) initialize(ctx context.Context) (*InitializeResult, error) {
+	params := &InitializeParams{
+		ProtocolVersion: LatestProtocolVersion,
+		ClientInfo: Implementation{
+			Name:    cc.client.config.Name,
+			Version: cc.client.config.Version,
+		},
+	}
+
+	var result InitializeResult
+	if _, err := cc.call(ctx, MethodInitialize, params, &result); err != nil {
+		return nil, err
+	}
+
+	if !isSupportedVersion(result.ProtocolVersion) {
+		return nil, errors.ErrProtocolMismatch.
+			WithMessage("unsupported MCP protocol version").
+			WithDetail("version", result.ProtocolVersion)
+	}
+
+	notification, _ := NewNotification(MethodInitialized, nil)
+	if err := cc.Send(ctx, notification); err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// call sends a request and waits for its response. sent reports whether
+// the request may have reached the server.
+func (cc *clientConn) call(ctx context.Context, method string, params, result interface{}) (sent bool, err error) {
+	id := json.RawMessage(strconv.FormatInt(cc.client.nextID.Add(1), 10))
+	msg, err := NewRequest(id, method, params)
+	if err != nil {
+		return false, errors.ErrInvalidInput.WithMessage("failed to encode MCP request").Wrap(err)
+	}
+
+	reqCtx := ctx
+	if timeout := cc.client.config.Timeout; timeout > 0 {
+		var cancel context.CancelFunc
+		reqCtx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	ch := make(chan *Message, 1)
+	cc.mu.Lock()
+	cc.pending[string(id)] = ch
+	cc.mu.Unlock()
+
+	defer func() {
+		cc.mu.Lock()
+		delete(cc.pending, string(id))
+		cc.mu.Unlock()
+	}()
+
+	if err := cc.Send(reqCtx, msg); err != nil {
+		if reqCtx.Err() != nil {
+			return false, timeoutError(ctx, method)
+		}
+		return false, err
+	}
+
+	select {
+	case resp := <-ch:
+		if resp.Error != nil {
+			return true, resp.Error
+		}
+		if result != nil && len(resp.Result) > 0 {
+			if err := json.Unmarshal(resp.Result, result); err != nil {
+				return true, errors.ErrMessageParsing.
+					WithMessage("invalid MCP response").
+					WithDetail("method", method).
+					Wrap(err)
+			}
+		}
+		return true, nil
+	case <-cc.Done():
+		return true, ErrConnectionClosed
+	case <-reqCtx.Done():
+		cc.cancel(id)
+		return true, timeoutError(ctx, method)
+	}
+}
+
+// cancel tells the server to stop working on the request with id.
+func (cc *clientConn) cancel(id json.RawMessage) {
+	notification, err := NewNotification(MethodCancelled, &CancelledParams{
+		RequestID: id,
+		Reason:    "request cancelled by client",
+	})
+	if err != nil {
+		return
+	}
+
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		cc.Send(ctx, notification)
+	}()
+}
+
+// readLoop dispatches incoming messages until the connection ends.
+func (cc *clientConn) readLoop() {
+	for {
+		select {
+		case msg := <-cc.Messages():
+			cc.handle(msg)
+		case <-cc.Done():
+			return
+		}
+	}
+}
+
+func (cc *clientConn) handle(msg *Message) {
+	switch {
+	case msg.IsResponse():
+		cc.mu.Lock()
+		ch, ok := cc.pending[string(msg.ID)]
+		cc.mu.Unlock()
+		if ok {
+			select {
+			case ch <- msg:
+			default:
+			}
+		}
+
+	case msg.IsRequest():
+		var reply *Message
+		if msg.Method == MethodPing {
+			reply, _ = NewResponse(msg.ID, nil)
+		} else {
+			reply = NewErrorResponse(msg.ID, CodeMethodNotFound, "method not found: "+msg.Method)
+		}
+		go func() {
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+			cc.Send(ctx, reply)
+		}()
+
+	case msg.IsNotification():
+		if msg.Method == MethodToolsListChanged && cc.client.config.OnToolsChanged != nil {
+			go cc.client.config.OnToolsChanged()
+		}
+	}
+}
+
+// timeoutError reports a request that ran out of time, or the caller's
+// own context error if it was cancelled.
+func timeoutError(ctx context.Context, method string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return errors.ErrTimeout.
+		WithMessage("MCP request timed out").
+		WithDetail("method", method)
+}
+
+// isConnectionError returns true if err means the connection is unusable.
+func isConnectionError(err error) bool {
+	return errors.Is(err, ErrConnectionClosed) || errors.Is(err, errors.ErrConnectionRefused)
+}
+
+func isSupportedVersion(version string) bool {
+	for _, v := range SupportedProtocolVersions {
+		if v == version {
+			return true
+		}
+	}
+	return false
+}
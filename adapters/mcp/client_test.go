@@ -0,0 +1,335 @@
+// Copyright (C) 2025 sage-x-project
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with this program. If not, see <https://www.gnu.org/licenses/>.
+
+// SPDX-License-Identifier: LGPL-3.0-or-later
+
+package mcp
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/sage-x-project/sage-adk/core/tools"
+	"github.com/sage-x-project/sage-adk/pkg/errors"
+)
+
+// fakeServer is a minimal MCP server used to exercise the client.
+type fakeServer struct {
+	version string
+
+	mu        sync.Mutex
+	cancelled []string
+	notify    func(*Message)
+}
+
+func newFakeServer() *fakeServer {
+	return &fakeServer{version: LatestProtocolVersion}
+}
+
+var fakeTools = []*Tool{
+	{
+		Name:        "echo",
+		Description: "Echoes the text",
+		InputSchema: json.RawMessage(`{"type":"object","properties":{"text":{"type":"string"}},"required":["text"]}`),
+	},
+	{Name: "fail", Description: "Always fails", InputSchema: json.RawMessage(`{"type":"object"}`)},
+	{Name: "slow", InputSchema: json.RawMessage(`{"type":"object"}`)},
+	{Name: "odd", InputSchema: json.RawMessage(`{"type":"object","properties":{"x":{"type":["string","null"]}}}`)},
+}
+
+// handle returns the response to msg, or nil for notifications.
+func (s *fakeServer) handle(msg *Message) *Message {
+	if msg.IsNotification() {
+		if msg.Method == MethodCancelled {
+			var params CancelledParams
+			json.Unmarshal(msg.Params, &params)
+			s.mu.Lock()
+			s.cancelled = append(s.cancelled, string(params.RequestID))
+			s.mu.Unlock()
+		}
+		return nil
+	}
+
+	var result interface{}
+	switch msg.Method {
+	case MethodInitialize:
+		result = &InitializeResult{
+			ProtocolVersion: s.version,
+			Capabilities:    ServerCapabilities{Tools: &ToolsCapability{ListChanged: true}},
+			ServerInfo:      Implementation{Name: "fake", Version: "0.1.0"},
+		}
+	case MethodPing:
+	case MethodToolsList:
+		var params ListToolsParams
+		json.Unmarshal(msg.Params, &params)
+		if params.Cursor == "" {
+			result = &ListToolsResult{Tools: fakeTools[:2], NextCursor: "page2"}
+		} else {
+			result = &ListToolsResult{Tools: fakeTools[2:]}
+		}
+	case MethodToolsCall:
+		var params CallToolParams
+		json.Unmarshal(msg.Params, &params)
+		switch params.Name {
+		case "echo":
+			text, _ := params.Arguments["text"].(string)
+			result = &CallToolResult{Content: []Content{TextContent(text)}}
+		case "fail":
+			result = &CallToolResult{Content: []Content{TextContent("boom")}, IsError: true}
+		case "slow":
+			time.Sleep(200 * time.Millisecond)
+			result = &CallToolResult{Content: []Content{TextContent("late")}}
+		case "crash": // only used against the stdio helper process
+			os.Exit(3)
+		case "change":
+			if s.notify != nil {
+				n, _ := NewNotification(MethodToolsListChanged, nil)
+				s.notify(n)
+			}
+			result = &CallToolResult{Content: []Content{TextContent("changed")}}
+		default:
+			return NewErrorResponse(msg.ID, CodeInvalidParams, "unknown tool: "+params.Name)
+		}
+	default:
+		return NewErrorResponse(msg.ID, CodeMethodNotFound, "method not found")
+	}
+
+	resp, _ := NewResponse(msg.ID, result)
+	return resp
+}
+
+// serveStream serves newline-delimited messages until r is exhausted.
+func (s *fakeServer) serveStream(r io.Reader, w io.Writer) {
+	var writeMu sync.Mutex
+	write := func(msg *Message) {
+		data, _ := json.Marshal(msg)
+		writeMu.Lock()
+		w.Write(append(data, '\n'))
+		writeMu.Unlock()
+	}
+	s.notify = write
+
+	reader := bufio.NewReader(r)
+	for {
+		line, err := reader.ReadBytes('\n')
+		for _, msg := range decodeMessages(line) {
+			go func(msg *Message) {
+				if resp := s.handle(msg); resp != nil {
+					write(resp)
+				}
+			}(msg)
+		}
+		if err != nil {
+			return
+		}
+	}
+}
+
+func (s *fakeServer) cancelledIDs() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]string(nil), s.cancelled...)
+}
+
+// newPipeClient connects a client to server over in-memory pipes.
+func newPipeClient(t *testing.T, server *fakeServer, config *ClientConfig) *Client {
+	t.Helper()
+
+	clientR, serverW := io.Pipe()
+	serverR, clientW := io.Pipe()
+	go func() {
+		server.serveStream(serverR, serverW)
+		serverW.Close()
+	}()
+
+	client := NewClient(NewStreamTransport(clientR, clientW), config)
+	t.Cleanup(func() { client.Close() })
+	return client
+}
+
+func TestClient_Stream(t *testing.T) {
+	ctx := context.Background()
+	client := newPipeClient(t, newFakeServer(), nil)
+
+	if client.ServerInfo() != nil {
+		t.Error("ServerInfo() should be nil before connecting")
+	}
+	if err := client.Connect(ctx); err != nil {
+		t.Fatalf("Connect() error = %v", err)
+	}
+	if info := client.ServerInfo(); info.ServerInfo.Name != "fake" || info.Capabilities.Tools == nil {
+		t.Errorf("ServerInfo() = %+v", info)
+	}
+
+	list, err := client.ListTools(ctx)
+	if err != nil {
+		t.Fatalf("ListTools() error = %v", err)
+	}
+	if len(list) != len(fakeTools) {
+		t.Errorf("len(ListTools()) = %d, want %d", len(list), len(fakeTools))
+	}
+
+	result, err := client.CallTool(ctx, "echo", map[string]interface{}{"text": "hello"})
+	if err != nil {
+		t.Fatalf("CallTool() error = %v", err)
+	}
+	if result.IsError || result.Text() != "hello" {
+		t.Errorf("CallTool(echo) = %+v", result)
+	}
+
+	result, err = client.CallTool(ctx, "fail", nil)
+	if err != nil {
+		t.Fatalf("CallTool() error = %v", err)
+	}
+	if !result.IsError || result.Text() != "boom" {
+		t.Errorf("CallTool(fail) = %+v", result)
+	}
+
+	_, err = client.CallTool(ctx, "missing", nil)
+	var rpcErr *RPCError
+	if !errors.As(err, &rpcErr) || rpcErr.Code != CodeInvalidParams {
+		t.Errorf("CallTool(missing) error = %v, want RPCError", err)
+	}
+
+	if err := client.Ping(ctx); err != nil {
+		t.Errorf("Ping() error = %v", err)
+	}
+}
+
+func TestClient_Timeout(t *testing.T) {
+	server := newFakeServer()
+	config := DefaultClientConfig()
+	config.Timeout = 50 * time.Millisecond
+	client := newPipeClient(t, server, config)
+
+	_, err := client.CallTool(context.Background(), "slow", nil)
+	if !errors.Is(err, errors.ErrTimeout) {
+		t.Fatalf("CallTool(slow) error = %v, want ErrTimeout", err)
+	}
+
+	deadline := time.Now().Add(time.Second)
+	for len(server.cancelledIDs()) == 0 && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	if len(server.cancelledIDs()) != 1 {
+		t.Errorf("cancelled = %v, want one request", server.cancelledIDs())
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := client.CallTool(ctx, "echo", nil); err != context.Canceled {
+		t.Errorf("CallTool() with cancelled context error = %v, want context.Canceled", err)
+	}
+}
+
+func TestClient_ProtocolMismatch(t *testing.T) {
+	server := newFakeServer()
+	server.version = "1999-01-01"
+	client := newPipeClient(t, server, nil)
+
+	if err := client.Connect(context.Background()); !errors.Is(err, errors.ErrProtocolMismatch) {
+		t.Errorf("Connect() error = %v, want ErrProtocolMismatch", err)
+	}
+}
+
+func TestClient_ToolsChanged(t *testing.T) {
+	changed := make(chan struct{}, 1)
+	config := DefaultClientConfig()
+	config.OnToolsChanged = func() { changed <- struct{}{} }
+	client := newPipeClient(t, newFakeServer(), config)
+
+	if _, err := client.CallTool(context.Background(), "change", nil); err != nil {
+		t.Fatalf("CallTool() error = %v", err)
+	}
+
+	select {
+	case <-changed:
+	case <-time.After(time.Second):
+		t.Error("OnToolsChanged was not called")
+	}
+}
+
+func TestClient_Closed(t *testing.T) {
+	config := DefaultClientConfig()
+	config.MaxReconnects = 0
+	client := newPipeClient(t, newFakeServer(), config)
+
+	if err := client.Connect(context.Background()); err != nil {
+		t.Fatalf("Connect() error = %v", err)
+	}
+	client.Close()
+
+	if err := client.Ping(context.Background()); !errors.Is(err, ErrConnectionClosed) {
+		t.Errorf("Ping() after Close error = %v, want ErrConnectionClosed", err)
+	}
+}
+
+func TestClient_RegisterTools(t *testing.T) {
+	ctx := context.Background()
+	client := newPipeClient(t, newFakeServer(), nil)
+	registry := tools.NewRegistry()
+
+	if err := client.RegisterTools(ctx, registry, "fake_"); err != nil {
+		t.Fatalf("RegisterTools() error = %v", err)
+	}
+	if registry.Count() != len(fakeTools) {
+		t.Fatalf("Count() = %d, want %d", registry.Count(), len(fakeTools))
+	}
+
+	echo, err := registry.Get("fake_echo")
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	if echo.Description() != "Echoes the text" {
+		t.Errorf("Description() = %q", echo.Description())
+	}
+	if params := echo.Parameters(); params.Properties["text"].Type != "string" || params.Required[0] != "text" {
+		t.Errorf("Parameters() = %+v", params)
+	}
+
+	odd, _ := registry.Get("fake_odd")
+	if params := odd.Parameters(); params.Type != "object" || len(params.Properties) != 0 {
+		t.Errorf("unsupported schema should fall back to an open object, got %+v", params)
+	}
+
+	result, err := registry.Execute(ctx, "fake_echo", map[string]interface{}{"text": "hi"})
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	if !result.Success || result.Output != "hi" || result.Metadata["mcp_tool"] != "echo" {
+		t.Errorf("Execute(echo) = %+v", result)
+	}
+
+	result, err = registry.Execute(ctx, "fake_fail", nil)
+	if err != nil {
+		t.Fatalf("Execute(fail) error = %v", err)
+	}
+	if result.Success || result.Error != "boom" {
+		t.Errorf("Execute(fail) = %+v", result)
+	}
+
+	missing := NewRemoteTool(client, &Tool{Name: "missing"}, "")
+	result, err = missing.Execute(ctx, nil)
+	if err != nil || result.Success || !strings.Contains(result.Error, "unknown tool") {
+		t.Errorf("Execute(missing) = %+v, %v", result, err)
+	}
+}
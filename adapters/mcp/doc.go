@@ -0,0 +1,81 @@
+// Copyright (C) 2025 sage-x-project
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with this program. If not, see <https://www.gnu.org/licenses/>.
+
+// SPDX-License-Identifier: LGPL-3.0-or-later
+// Package mcp implements the Model Context Protocol (MCP).
+//
+// The client connects to MCP servers and exposes their tools as
+// core/tools.Tool, so agents can use tools hosted outside the process.
+// The server does the reverse, publishing an agent's tools and skills to
+// MCP hosts such as IDEs.
+//
+// # Transports
+//
+// Two transports are provided:
+//
+//   - StdioTransport runs the server as a subprocess and exchanges
+//     newline-delimited JSON-RPC messages over its standard input and output.
+//   - HTTPTransport speaks the streamable HTTP transport, accepting JSON and
+//     SSE responses and carrying the session ID between requests.
+//
+// StreamTransport connects to a server over an existing reader and writer,
+// which is convenient for in-process servers and tests.
+//
+// # Client
+//
+//	client := mcp.NewClient(mcp.NewStdioTransport("mcp-server-git", "--repository", "."), nil)
+//	defer client.Close()
+//
+//	// Register the server's tools as git_status, git_log, ...
+//	registry := tools.NewRegistry()
+//	if err := client.RegisterTools(ctx, registry, "git_"); err != nil {
+//	    log.Fatal(err)
+//	}
+//
+// The client connects and performs initialization on the first request.
+// Every request is bounded by ClientConfig.Timeout; timed-out requests are
+// cancelled on the server. When the connection is lost the client
+// reconnects up to MaxReconnects times. Tool calls that may have reached
+// the server are not repeated.
+//
+// Tool failures reported by the server become failed tools.Result values;
+// connection failures and timeouts are returned as errors.
+//
+// # Server
+//
+// The server publishes the tools of a core/tools.Registry with their
+// ParameterSchema and runs calls through a tools.Executor, so the access
+// policy, argument validation and approvals apply. Agent skills are
+// published as prompts:
+//
+//	server := mcp.NewServer(&mcp.ServerConfig{
+//	    Name:       "research-agent",
+//	    Tools:      registry,
+//	    Skills:     agent.Card().Skills,
+//	    Middleware: []func(http.Handler) http.Handler{obs.Middleware().Handler},
+//	})
+//
+//	// Over stdio, for hosts that launch the agent as a subprocess
+//	server.ServeStdio(ctx, os.Stdin, os.Stdout)
+//
+//	// Or over streamable HTTP at /mcp
+//	server.Start(":8080")
+//
+// The server is also an http.Handler, so it can be mounted next to other
+// endpoints; Handler applies the configured middleware and Authenticator.
+// TLS makes Start serve HTTPS, and HTTP sessions idle for longer than
+// SessionIdleTimeout end. `adk serve --mcp` selects the MCP transport from
+// the command line.
+package mcp
@@ -0,0 +1,286 @@
+// Copyright (C) 2025 sage-x-project
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with this program. If not, see <https://www.gnu.org/licenses/>.
+
+// SPDX-License-Identifier: LGPL-3.0-or-later
+
+package mcp
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"mime"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sage-x-project/sage-adk/pkg/errors"
+)
+
+// HeaderSessionID carries the MCP session ID over streamable HTTP.
+const HeaderSessionID = "Mcp-Session-Id"
+
+// HTTPTransport connects to an MCP server over the streamable HTTP transport.
+//
+// Each message is POSTed to URL. The server answers with a JSON body or an
+// SSE stream carrying the response; the session ID it assigns is sent back
+// on later requests. Each connection starts a new session.
+type HTTPTransport struct {
+	// URL is the MCP endpoint.
+	URL string
+
+	// Client performs HTTP requests.
+	// Default: http.DefaultClient
+	Client *http.Client
+
+	// Header is added to every request (e.g., Authorization).
+	Header http.Header
+}
+
+// NewHTTPTransport creates a transport for the endpoint at url.
+func NewHTTPTransport(url string) *HTTPTransport {
+	return &HTTPTransport{URL: url}
+}
+
+// Connect creates a connection. No request is made until the first message.
+func (t *HTTPTransport) Connect(ctx context.Context) (Conn, error) {
+	if t.URL == "" {
+		return nil, errors.ErrMissingField.WithDetail("field", "url")
+	}
+
+	client := t.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	connCtx, cancel := context.WithCancel(context.Background())
+	return &httpConn{
+		transport: t,
+		client:    client,
+		ctx:       connCtx,
+		cancel:    cancel,
+		messages:  make(chan *Message, 16),
+		done:      make(chan struct{}),
+	}, nil
+}
+
+// httpConn is a streamable HTTP session.
+type httpConn struct {
+	transport *HTTPTransport
+	client    *http.Client
+
+	ctx    context.Context
+	cancel context.CancelFunc
+
+	mu        sync.RWMutex
+	sessionID string
+
+	messages chan *Message
+	done     chan struct{}
+	once     sync.Once
+	streams  sync.WaitGroup
+}
+
+// Send POSTs msg and delivers any messages in the reply.
+func (c *httpConn) Send(ctx context.Context, msg *Message) error {
+	select {
+	case <-c.done:
+		return ErrConnectionClosed
+	default:
+	}
+
+	data, err := json.Marshal(msg)
+	if err != nil {
+		return errors.ErrInvalidInput.WithMessage("failed to encode MCP message").Wrap(err)
+	}
+
+	// The request lives until the caller gives up or the connection closes.
+	reqCtx, cancel := context.WithCancel(c.ctx)
+	stop := context.AfterFunc(ctx, cancel)
+
+	req, err := http.NewRequestWithContext(reqCtx, http.MethodPost, c.transport.URL, bytes.NewReader(data))
+	if err != nil {
+		stop()
+		cancel()
+		return errors.ErrInvalidInput.WithMessage("invalid MCP endpoint").Wrap(err)
+	}
+	c.setHeaders(req)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json, text/event-stream")
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		stop()
+		cancel()
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		return ErrConnectionClosed.Wrap(err)
+	}
+
+	if id := resp.Header.Get(HeaderSessionID); id != "" {
+		c.mu.Lock()
+		c.sessionID = id
+		c.mu.Unlock()
+	}
+
+	release := func() {
+		resp.Body.Close()
+		stop()
+		cancel()
+	}
+
+	if err := c.checkStatus(resp); err != nil {
+		release()
+		return err
+	}
+
+	mediaType, _, _ := mime.ParseMediaType(resp.Header.Get("Content-Type"))
+	switch {
+	case resp.StatusCode == http.StatusAccepted || resp.StatusCode == http.StatusNoContent:
+		release()
+	case mediaType == "text/event-stream":
+		c.streams.Add(1)
+		go func() {
+			defer c.streams.Done()
+			defer release()
+			c.readEvents(resp.Body)
+		}()
+	default:
+		body, err := io.ReadAll(resp.Body)
+		release()
+		if err != nil {
+			return ErrConnectionClosed.Wrap(err)
+		}
+		c.deliver(decodeMessages(body))
+	}
+
+	return nil
+}
+
+// checkStatus maps HTTP error statuses to errors.
+func (c *httpConn) checkStatus(resp *http.Response) error {
+	if resp.StatusCode < 300 {
+		return nil
+	}
+
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+
+	switch {
+	case resp.StatusCode == http.StatusNotFound && c.SessionID() != "":
+		c.shutdown()
+		return ErrSessionExpired
+	case resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden:
+		return errors.ErrUnauthorized.
+			WithDetail("status", resp.StatusCode).
+			WithDetail("body", string(body))
+	default:
+		return errors.ErrOperationFailed.
+			WithMessage("MCP server returned an error status").
+			WithDetail("status", resp.StatusCode).
+			WithDetail("body", string(body))
+	}
+}
+
+// readEvents delivers the messages carried by an SSE stream.
+func (c *httpConn) readEvents(r io.Reader) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+
+	var data []string
+	flush := func() {
+		if len(data) > 0 {
+			c.deliver(decodeMessages([]byte(strings.Join(data, "\n"))))
+			data = data[:0]
+		}
+	}
+
+	for scanner.Scan() {
+		line := scanner.Text()
+		switch {
+		case line == "":
+			flush()
+		case strings.HasPrefix(line, "data:"):
+			data = append(data, strings.TrimPrefix(strings.TrimPrefix(line, "data:"), " "))
+		}
+	}
+	flush()
+}
+
+func (c *httpConn) deliver(msgs []*Message) {
+	for _, msg := range msgs {
+		select {
+		case c.messages <- msg:
+		case <-c.done:
+			return
+		}
+	}
+}
+
+func (c *httpConn) setHeaders(req *http.Request) {
+	for k, v := range c.transport.Header {
+		req.Header[k] = v
+	}
+	if id := c.SessionID(); id != "" {
+		req.Header.Set(HeaderSessionID, id)
+	}
+}
+
+// SessionID returns the session ID assigned by the server.
+func (c *httpConn) SessionID() string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.sessionID
+}
+
+// Messages returns the received messages.
+func (c *httpConn) Messages() <-chan *Message {
+	return c.messages
+}
+
+// Done is closed when the session ends.
+func (c *httpConn) Done() <-chan struct{} {
+	return c.done
+}
+
+// Close terminates the session on the server and stops open streams.
+func (c *httpConn) Close() error {
+	c.shutdown()
+	c.cancel()
+	c.streams.Wait()
+
+	if c.SessionID() == "" {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	// Ending the session is best effort; the server expires it otherwise.
+	if req, err := http.NewRequestWithContext(ctx, http.MethodDelete, c.transport.URL, nil); err == nil {
+		c.setHeaders(req)
+		if resp, err := c.client.Do(req); err == nil {
+			resp.Body.Close()
+		}
+	}
+	return nil
+}
+
+func (c *httpConn) shutdown() {
+	c.once.Do(func() { close(c.done) })
+}
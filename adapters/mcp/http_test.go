@@ -0,0 +1,192 @@
+// Copyright (C) 2025 sage-x-project
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with this program. If not, see <https://www.gnu.org/licenses/>.
+
+// SPDX-License-Identifier: LGPL-3.0-or-later
+
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/sage-x-project/sage-adk/pkg/errors"
+)
+
+// fakeHTTPServer serves fakeServer over streamable HTTP.
+type fakeHTTPServer struct {
+	*fakeServer
+	sse bool
+
+	mu       sync.Mutex
+	sessions map[string]bool
+	next     int
+	deleted  int
+	headers  []http.Header
+}
+
+func newFakeHTTPServer(sse bool) *fakeHTTPServer {
+	return &fakeHTTPServer{
+		fakeServer: newFakeServer(),
+		sse:        sse,
+		sessions:   make(map[string]bool),
+	}
+}
+
+func (s *fakeHTTPServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	s.headers = append(s.headers, r.Header.Clone())
+	s.mu.Unlock()
+
+	session := r.Header.Get(HeaderSessionID)
+
+	if r.Method == http.MethodDelete {
+		s.mu.Lock()
+		delete(s.sessions, session)
+		s.deleted++
+		s.mu.Unlock()
+		return
+	}
+
+	body, _ := io.ReadAll(r.Body)
+	msgs := decodeMessages(body)
+	if len(msgs) != 1 {
+		http.Error(w, "bad request", http.StatusBadRequest)
+		return
+	}
+	msg := msgs[0]
+
+	s.mu.Lock()
+	if msg.Method == MethodInitialize {
+		s.next++
+		session = fmt.Sprintf("session-%d", s.next)
+		s.sessions[session] = true
+		w.Header().Set(HeaderSessionID, session)
+	} else if !s.sessions[session] {
+		s.mu.Unlock()
+		http.Error(w, "unknown session", http.StatusNotFound)
+		return
+	}
+	s.mu.Unlock()
+
+	resp := s.handle(msg)
+	if resp == nil {
+		w.WriteHeader(http.StatusAccepted)
+		return
+	}
+
+	data, _ := json.Marshal(resp)
+	if s.sse {
+		w.Header().Set("Content-Type", "text/event-stream")
+		fmt.Fprintf(w, ": keep-alive\n\nevent: message\ndata: %s\n\n", data)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(data)
+}
+
+func (s *fakeHTTPServer) expire() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.sessions = make(map[string]bool)
+}
+
+func TestHTTPTransport(t *testing.T) {
+	for _, sse := range []bool{false, true} {
+		t.Run(fmt.Sprintf("sse=%v", sse), func(t *testing.T) {
+			ctx := context.Background()
+			server := newFakeHTTPServer(sse)
+			ts := httptest.NewServer(server)
+			defer ts.Close()
+
+			transport := NewHTTPTransport(ts.URL)
+			transport.Header = http.Header{"Authorization": {"Bearer token"}}
+			client := NewClient(transport, nil)
+
+			list, err := client.ListTools(ctx)
+			if err != nil {
+				t.Fatalf("ListTools() error = %v", err)
+			}
+			if len(list) != len(fakeTools) {
+				t.Errorf("len(ListTools()) = %d, want %d", len(list), len(fakeTools))
+			}
+
+			result, err := client.CallTool(ctx, "echo", map[string]interface{}{"text": "http"})
+			if err != nil {
+				t.Fatalf("CallTool() error = %v", err)
+			}
+			if result.Text() != "http" {
+				t.Errorf("Text() = %q, want http", result.Text())
+			}
+
+			client.Close()
+
+			server.mu.Lock()
+			defer server.mu.Unlock()
+			if server.deleted != 1 {
+				t.Errorf("deleted = %d, want 1", server.deleted)
+			}
+			last := server.headers[len(server.headers)-1]
+			if last.Get(HeaderSessionID) != "session-1" || last.Get("Authorization") != "Bearer token" {
+				t.Errorf("headers = %v", last)
+			}
+		})
+	}
+}
+
+func TestHTTPTransport_SessionExpired(t *testing.T) {
+	ctx := context.Background()
+	server := newFakeHTTPServer(false)
+	ts := httptest.NewServer(server)
+	defer ts.Close()
+
+	config := DefaultClientConfig()
+	config.ReconnectDelay = 0
+	client := NewClient(NewHTTPTransport(ts.URL), config)
+	defer client.Close()
+
+	if err := client.Connect(ctx); err != nil {
+		t.Fatalf("Connect() error = %v", err)
+	}
+	server.expire()
+
+	if err := client.Ping(ctx); err != nil {
+		t.Fatalf("Ping() after expiry error = %v", err)
+	}
+	if server.next != 2 {
+		t.Errorf("sessions = %d, want a new session", server.next)
+	}
+}
+
+func TestHTTPTransport_Errors(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "denied", http.StatusUnauthorized)
+	}))
+	defer ts.Close()
+
+	client := NewClient(NewHTTPTransport(ts.URL), nil)
+	if err := client.Connect(context.Background()); !errors.Is(err, errors.ErrUnauthorized) {
+		t.Errorf("Connect() error = %v, want ErrUnauthorized", err)
+	}
+
+	if _, err := NewHTTPTransport("").Connect(context.Background()); !errors.Is(err, errors.ErrMissingField) {
+		t.Errorf("Connect() error = %v, want ErrMissingField", err)
+	}
+}
@@ -0,0 +1,336 @@
+// Copyright (C) 2025 sage-x-project
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with this program. If not, see <https://www.gnu.org/licenses/>.
+
+// SPDX-License-Identifier: LGPL-3.0-or-later
+
+package mcp
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// Protocol versions.
+const (
+	// JSONRPCVersion is the JSON-RPC version used by MCP.
+	JSONRPCVersion = "2.0"
+
+	// LatestProtocolVersion is the MCP revision requested by the client.
+	LatestProtocolVersion = "2025-03-26"
+)
+
+// SupportedProtocolVersions lists the MCP revisions this package speaks.
+var SupportedProtocolVersions = []string{LatestProtocolVersion, "2024-11-05"}
+
+// MCP methods.
+const (
+	MethodInitialize       = "initialize"
+	MethodInitialized      = "notifications/initialized"
+	MethodPing             = "ping"
+	MethodCancelled        = "notifications/cancelled"
+	MethodToolsList        = "tools/list"
+	MethodToolsCall        = "tools/call"
+	MethodToolsListChanged = "notifications/tools/list_changed"
+	MethodPromptsList      = "prompts/list"
+	MethodPromptsGet       = "prompts/get"
+)
+
+// JSON-RPC error codes.
+const (
+	CodeParseError     = -32700
+	CodeInvalidRequest = -32600
+	CodeMethodNotFound = -32601
+	CodeInvalidParams  = -32602
+	CodeInternalError  = -32603
+)
+
+// Message is a JSON-RPC 2.0 request, notification or response.
+type Message struct {
+	// JSONRPC is always "2.0".
+	JSONRPC string `json:"jsonrpc"`
+
+	// ID identifies requests and their responses. Notifications have none.
+	ID json.RawMessage `json:"id,omitempty"`
+
+	// Method is the method of a request or notification.
+	Method string `json:"method,omitempty"`
+
+	// Params holds the method parameters.
+	Params json.RawMessage `json:"params,omitempty"`
+
+	// Result holds the result of a successful response.
+	Result json.RawMessage `json:"result,omitempty"`
+
+	// Error holds the error of a failed response.
+	Error *RPCError `json:"error,omitempty"`
+}
+
+// IsRequest returns true if the message is a request.
+func (m *Message) IsRequest() bool {
+	return m.Method != "" && len(m.ID) > 0
+}
+
+// IsNotification returns true if the message is a notification.
+func (m *Message) IsNotification() bool {
+	return m.Method != "" && len(m.ID) == 0
+}
+
+// IsResponse returns true if the message is a response.
+func (m *Message) IsResponse() bool {
+	return m.Method == "" && len(m.ID) > 0
+}
+
+// NewRequest creates a request message.
+func NewRequest(id json.RawMessage, method string, params interface{}) (*Message, error) {
+	msg := &Message{JSONRPC: JSONRPCVersion, ID: id, Method: method}
+	if params != nil {
+		data, err := json.Marshal(params)
+		if err != nil {
+			return nil, err
+		}
+		msg.Params = data
+	}
+	return msg, nil
+}
+
+// NewNotification creates a notification message.
+func NewNotification(method string, params interface{}) (*Message, error) {
+	return NewRequest(nil, method, params)
+}
+
+// NewResponse creates a successful response to the request with id.
+func NewResponse(id json.RawMessage, result interface{}) (*Message, error) {
+	data := json.RawMessage("{}")
+	if result != nil {
+		var err error
+		if data, err = json.Marshal(result); err != nil {
+			return nil, err
+		}
+	}
+	return &Message{JSONRPC: JSONRPCVersion, ID: id, Result: data}, nil
+}
+
+// NewErrorResponse creates a failed response to the request with id.
+func NewErrorResponse(id json.RawMessage, code int, message string) *Message {
+	if len(id) == 0 {
+		id = json.RawMessage("null")
+	}
+	return &Message{
+		JSONRPC: JSONRPCVersion,
+		ID:      id,
+		Error:   &RPCError{Code: code, Message: message},
+	}
+}
+
+// RPCError is a JSON-RPC error object returned by the peer.
+type RPCError struct {
+	Code    int             `json:"code"`
+	Message string          `json:"message"`
+	Data    json.RawMessage `json:"data,omitempty"`
+}
+
+// Error implements the error interface.
+func (e *RPCError) Error() string {
+	return fmt.Sprintf("mcp: rpc error %d: %s", e.Code, e.Message)
+}
+
+// Implementation identifies an MCP client or server.
+type Implementation struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+}
+
+// ClientCapabilities are the capabilities announced by the client.
+type ClientCapabilities struct {
+	Experimental map[string]interface{} `json:"experimental,omitempty"`
+}
+
+// ServerCapabilities are the capabilities announced by the server.
+type ServerCapabilities struct {
+	Tools        *ToolsCapability       `json:"tools,omitempty"`
+	Prompts      *PromptsCapability     `json:"prompts,omitempty"`
+	Experimental map[string]interface{} `json:"experimental,omitempty"`
+}
+
+// ToolsCapability describes tool support.
+type ToolsCapability struct {
+	// ListChanged is true if the server notifies about tool list changes.
+	ListChanged bool `json:"listChanged,omitempty"`
+}
+
+// PromptsCapability describes prompt support.
+type PromptsCapability struct {
+	// ListChanged is true if the server notifies about prompt list changes.
+	ListChanged bool `json:"listChanged,omitempty"`
+}
+
+// InitializeParams are the parameters of the initialize request.
+type InitializeParams struct {
+	ProtocolVersion string             `json:"protocolVersion"`
+	Capabilities    ClientCapabilities `json:"capabilities"`
+	ClientInfo      Implementation     `json:"clientInfo"`
+}
+
+// InitializeResult is the result of the initialize request.
+type InitializeResult struct {
+	ProtocolVersion string             `json:"protocolVersion"`
+	Capabilities    ServerCapabilities `json:"capabilities"`
+	ServerInfo      Implementation     `json:"serverInfo"`
+	Instructions    string             `json:"instructions,omitempty"`
+}
+
+// Tool describes a tool offered by a server.
+type Tool struct {
+	// Name is the unique tool name.
+	Name string `json:"name"`
+
+	// Description describes what the tool does.
+	Description string `json:"description,omitempty"`
+
+	// InputSchema is the JSON Schema of the tool arguments.
+	InputSchema json.RawMessage `json:"inputSchema"`
+}
+
+// ListToolsParams are the parameters of the tools/list request.
+type ListToolsParams struct {
+	Cursor string `json:"cursor,omitempty"`
+}
+
+// ListToolsResult is the result of the tools/list request.
+type ListToolsResult struct {
+	Tools      []*Tool `json:"tools"`
+	NextCursor string  `json:"nextCursor,omitempty"`
+}
+
+// CallToolParams are the parameters of the tools/call request.
+type CallToolParams struct {
+	Name      string                 `json:"name"`
+	Arguments map[string]interface{} `json:"arguments,omitempty"`
+}
+
+// Content types.
+const (
+	ContentTypeText     = "text"
+	ContentTypeImage    = "image"
+	ContentTypeAudio    = "audio"
+	ContentTypeResource = "resource"
+)
+
+// Content is a piece of tool output.
+type Content struct {
+	// Type is the content type (e.g., ContentTypeText).
+	Type string `json:"type"`
+
+	// Text is the text of text content.
+	Text string `json:"text,omitempty"`
+
+	// Data is base64-encoded image or audio data.
+	Data string `json:"data,omitempty"`
+
+	// MimeType is the MIME type of Data.
+	MimeType string `json:"mimeType,omitempty"`
+
+	// Resource is an embedded resource.
+	Resource map[string]interface{} `json:"resource,omitempty"`
+}
+
+// TextContent creates text content.
+func TextContent(text string) Content {
+	return Content{Type: ContentTypeText, Text: text}
+}
+
+// CallToolResult is the result of the tools/call request.
+type CallToolResult struct {
+	// Content is the unstructured tool output.
+	Content []Content `json:"content"`
+
+	// StructuredContent is the structured tool output, if any.
+	StructuredContent interface{} `json:"structuredContent,omitempty"`
+
+	// IsError is true if the tool failed. The error is described in Content.
+	IsError bool `json:"isError,omitempty"`
+}
+
+// Text returns the text parts of the result joined by newlines.
+func (r *CallToolResult) Text() string {
+	var parts []string
+	for _, c := range r.Content {
+		if c.Type == ContentTypeText {
+			parts = append(parts, c.Text)
+		}
+	}
+	return strings.Join(parts, "\n")
+}
+
+// Prompt describes a prompt template offered by a server.
+type Prompt struct {
+	// Name is the unique prompt name.
+	Name string `json:"name"`
+
+	// Description describes what the prompt is for.
+	Description string `json:"description,omitempty"`
+
+	// Arguments lists the arguments the prompt accepts.
+	Arguments []PromptArgument `json:"arguments,omitempty"`
+}
+
+// PromptArgument describes a prompt argument.
+type PromptArgument struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	Required    bool   `json:"required,omitempty"`
+}
+
+// ListPromptsParams are the parameters of the prompts/list request.
+type ListPromptsParams struct {
+	Cursor string `json:"cursor,omitempty"`
+}
+
+// ListPromptsResult is the result of the prompts/list request.
+type ListPromptsResult struct {
+	Prompts    []*Prompt `json:"prompts"`
+	NextCursor string    `json:"nextCursor,omitempty"`
+}
+
+// GetPromptParams are the parameters of the prompts/get request.
+type GetPromptParams struct {
+	Name      string            `json:"name"`
+	Arguments map[string]string `json:"arguments,omitempty"`
+}
+
+// Prompt message roles.
+const (
+	RoleUser      = "user"
+	RoleAssistant = "assistant"
+)
+
+// PromptMessage is a message of a rendered prompt.
+type PromptMessage struct {
+	Role    string  `json:"role"`
+	Content Content `json:"content"`
+}
+
+// GetPromptResult is the result of the prompts/get request.
+type GetPromptResult struct {
+	Description string          `json:"description,omitempty"`
+	Messages    []PromptMessage `json:"messages"`
+}
+
+// CancelledParams are the parameters of the cancelled notification.
+type CancelledParams struct {
+	RequestID json.RawMessage `json:"requestId"`
+	Reason    string          `json:"reason,omitempty"`
+}
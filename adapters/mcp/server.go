@@ -0,0 +1,696 @@
+// Copyright (C) 2025 sage-x-project
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with this program. If not, see <https://www.gnu.org/licenses/>.
+
+// SPDX-License-Identifier: LGPL-3.0-or-later
+
+package mcp
+
+import (
+	"bufio"
+	"context"
+	"crypto/sha256"
+	"crypto/tls"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/sage-x-project/sage-adk/adapters/llm"
+	"github.com/sage-x-project/sage-adk/core/authn"
+	"github.com/sage-x-project/sage-adk/core/tools"
+	"github.com/sage-x-project/sage-adk/pkg/errors"
+	"github.com/sage-x-project/sage-adk/pkg/mtls"
+	"github.com/sage-x-project/sage-adk/pkg/types"
+)
+
+// DefaultSessionIdleTimeout is how long an unused HTTP session is kept.
+const DefaultSessionIdleTimeout = 30 * time.Minute
+
+// PromptFunc renders a prompt from its arguments.
+type PromptFunc func(ctx context.Context, args map[string]string) (*GetPromptResult, error)
+
+// ServerPrompt is a prompt served by a Server.
+type ServerPrompt struct {
+	Prompt
+
+	// Render renders the prompt.
+	Render PromptFunc
+}
+
+// SkillPrompt exposes an agent skill as a prompt. The prompt takes an
+// optional "input" argument with the request for the agent.
+func SkillPrompt(skill types.AgentSkill) *ServerPrompt {
+	name := skill.ID
+	if name == "" {
+		name = skill.Name
+	}
+
+	return &ServerPrompt{
+		Prompt: Prompt{
+			Name:        name,
+			Description: skill.Description,
+			Arguments: []PromptArgument{
+				{Name: "input", Description: "The request for the agent"},
+			},
+		},
+		Render: func(ctx context.Context, args map[string]string) (*GetPromptResult, error) {
+			text := fmt.Sprintf("Use the %s skill: %s", skill.Name, skill.Description)
+			if input := args["input"]; input != "" {
+				text += "\n\n" + input
+			}
+			return &GetPromptResult{
+				Description: skill.Description,
+				Messages: []PromptMessage{
+					{Role: RoleUser, Content: TextContent(text)},
+				},
+			}, nil
+		},
+	}
+}
+
+// ServerConfig configures an MCP server.
+type ServerConfig struct {
+	// Name and Version identify the server to clients.
+	Name    string
+	Version string
+
+	// Instructions tell clients how to use the server.
+	Instructions string
+
+	// Tools are published as MCP tools.
+	Tools *tools.Registry
+
+	// Executor runs tool calls, applying its access policy, argument
+	// validation and approvals. Calls of tools needing approval wait for
+	// a decision (see tools.ApprovalHandler) and succeed when repeated in
+	// the same session. Default: an executor over Tools without an
+	// approval store, which refuses such tools.
+	Executor *tools.Executor
+
+	// Skills are published as MCP prompts. See SkillPrompt.
+	Skills []types.AgentSkill
+
+	// Prompts are additional prompts to publish.
+	Prompts []*ServerPrompt
+
+	// Path is the HTTP endpoint served by Start.
+	// Default: "/mcp"
+	Path string
+
+	// Middleware wraps the HTTP handler served by Start, outermost first.
+	// It is the same kind of middleware used by the other HTTP transports,
+	// e.g. observability.Middleware.Handler or authentication.
+	Middleware []func(http.Handler) http.Handler
+
+	// Authenticator, if set, requires an API key or bearer token on every
+	// HTTP request. The principal is available to tools through
+	// authn.PrincipalFromContext.
+	Authenticator *authn.Authenticator
+
+	// TLS, if set, makes Start serve HTTPS, optionally requiring client
+	// certificates.
+	TLS *mtls.Config
+
+	// SessionIdleTimeout ends HTTP sessions unused for this long.
+	// Default: DefaultSessionIdleTimeout
+	SessionIdleTimeout time.Duration
+}
+
+// Server is an MCP server publishing tools and prompts.
+//
+// It serves over stdio with ServeStdio, over streamable HTTP as an
+// http.Handler, or standalone with Start and Stop.
+type Server struct {
+	config   *ServerConfig
+	prompts  map[string]*ServerPrompt
+	executor *tools.Executor
+
+	mu       sync.Mutex
+	sessions map[string]time.Time // last use
+
+	inflight map[string]context.CancelFunc
+	http     *http.Server
+}
+
+// NewServer creates a server.
+//
+// Example:
+//
+//	registry := tools.NewRegistry()
+//	tools.RegisterBuiltinTools(registry)
+//
+//	server := mcp.NewServer(&mcp.ServerConfig{
+//	    Name:  "calculator-agent",
+//	    Tools: registry,
+//	})
+//	server.ServeStdio(ctx, os.Stdin, os.Stdout)
+func NewServer(config *ServerConfig) *Server {
+	if config == nil {
+		config = &ServerConfig{}
+	}
+	if config.Name == "" {
+		config.Name = "sage-adk"
+	}
+	if config.Path == "" {
+		config.Path = "/mcp"
+	}
+	if config.SessionIdleTimeout <= 0 {
+		config.SessionIdleTimeout = DefaultSessionIdleTimeout
+	}
+
+	executor := config.Executor
+	if executor == nil && config.Tools != nil {
+		executor = tools.NewExecutor(config.Tools, nil)
+	}
+
+	prompts := make(map[string]*ServerPrompt)
+	for _, skill := range config.Skills {
+		p := SkillPrompt(skill)
+		prompts[p.Name] = p
+	}
+	for _, p := range config.Prompts {
+		prompts[p.Name] = p
+	}
+
+	return &Server{
+		config:   config,
+		prompts:  prompts,
+		executor: executor,
+		sessions: make(map[string]time.Time),
+		inflight: make(map[string]context.CancelFunc),
+	}
+}
+
+// Handle processes a single message of session and returns the response,
+// or nil if msg needs none.
+func (s *Server) Handle(ctx context.Context, session string, msg *Message) *Message {
+	switch {
+	case msg.IsNotification():
+		if msg.Method == MethodCancelled {
+			var params CancelledParams
+			if json.Unmarshal(msg.Params, &params) == nil {
+				s.cancel(session, params.RequestID)
+			}
+		}
+		return nil
+	case !msg.IsRequest():
+		return nil
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	key := session + "/" + string(msg.ID)
+	s.mu.Lock()
+	s.inflight[key] = cancel
+	s.mu.Unlock()
+
+	defer func() {
+		s.mu.Lock()
+		delete(s.inflight, key)
+		s.mu.Unlock()
+		cancel()
+	}()
+
+	result, err := s.dispatch(ctx, session, msg)
+	if err != nil {
+		if rpcErr, ok := err.(*RPCError); ok {
+			return NewErrorResponse(msg.ID, rpcErr.Code, rpcErr.Message)
+		}
+		return NewErrorResponse(msg.ID, CodeInternalError, err.Error())
+	}
+
+	resp, err := NewResponse(msg.ID, result)
+	if err != nil {
+		return NewErrorResponse(msg.ID, CodeInternalError, "failed to encode result")
+	}
+	return resp
+}
+
+func (s *Server) dispatch(ctx context.Context, session string, msg *Message) (interface{}, error) {
+	switch msg.Method {
+	case MethodInitialize:
+		var params InitializeParams
+		if err := decodeParams(msg, &params); err != nil {
+			return nil, err
+		}
+		return s.initialize(&params), nil
+
+	case MethodPing:
+		return nil, nil
+
+	case MethodToolsList:
+		return s.listTools()
+
+	case MethodToolsCall:
+		var params CallToolParams
+		if err := decodeParams(msg, &params); err != nil {
+			return nil, err
+		}
+		return s.callTool(ctx, session, &params)
+
+	case MethodPromptsList:
+		return s.listPrompts(), nil
+
+	case MethodPromptsGet:
+		var params GetPromptParams
+		if err := decodeParams(msg, &params); err != nil {
+			return nil, err
+		}
+		return s.getPrompt(ctx, &params)
+	}
+
+	return nil, &RPCError{Code: CodeMethodNotFound, Message: "method not found: " + msg.Method}
+}
+
+func (s *Server) initialize(params *InitializeParams) *InitializeResult {
+	version := LatestProtocolVersion
+	if isSupportedVersion(params.ProtocolVersion) {
+		version = params.ProtocolVersion
+	}
+
+	result := &InitializeResult{
+		ProtocolVersion: version,
+		ServerInfo: Implementation{
+			Name:    s.config.Name,
+			Version: s.config.Version,
+		},
+		Instructions: s.config.Instructions,
+	}
+	if s.config.Tools != nil {
+		result.Capabilities.Tools = &ToolsCapability{}
+	}
+	if len(s.prompts) > 0 {
+		result.Capabilities.Prompts = &PromptsCapability{}
+	}
+	return result
+}
+
+func (s *Server) listTools() (*ListToolsResult, error) {
+	result := &ListToolsResult{Tools: []*Tool{}}
+	if s.config.Tools == nil {
+		return result, nil
+	}
+
+	for _, tool := range s.config.Tools.List() {
+		schema := tool.Parameters()
+		if schema == nil {
+			schema = &tools.ParameterSchema{Type: "object"}
+		}
+		data, err := json.Marshal(schema)
+		if err != nil {
+			return nil, err
+		}
+
+		result.Tools = append(result.Tools, &Tool{
+			Name:        tool.Name(),
+			Description: tool.Description(),
+			InputSchema: data,
+		})
+	}
+
+	sort.Slice(result.Tools, func(i, j int) bool {
+		return result.Tools[i].Name < result.Tools[j].Name
+	})
+	return result, nil
+}
+
+// callTool runs a tool through the executor. Tool failures are reported
+// in the result so the calling model can see them; unknown tools are
+// protocol errors.
+func (s *Server) callTool(ctx context.Context, session string, params *CallToolParams) (*CallToolResult, error) {
+	if s.config.Tools == nil || !s.config.Tools.Has(params.Name) {
+		return nil, &RPCError{Code: CodeInvalidParams, Message: "unknown tool: " + params.Name}
+	}
+
+	args := params.Arguments
+	if args == nil {
+		args = map[string]interface{}{}
+	}
+	data, err := json.Marshal(args)
+	if err != nil {
+		return nil, &RPCError{Code: CodeInvalidParams, Message: "invalid arguments: " + err.Error()}
+	}
+
+	// The same call in the same session maps to the same approval, so it
+	// runs once approved
+	digest := sha256.Sum256(append([]byte(params.Name+"\x00"), data...))
+	call := &llm.ToolCall{
+		ID:       hex.EncodeToString(digest[:16]),
+		Type:     llm.ToolTypeFunction,
+		Function: &llm.FunctionCall{Name: params.Name, Arguments: string(data)},
+	}
+
+	res := s.executor.Execute(tools.WithTaskID(ctx, session), call)
+	switch {
+	case res.Status == tools.StatusPendingApproval:
+		return &CallToolResult{Content: []Content{TextContent("tool call awaits approval: " + res.Approval.ID)}, IsError: true}, nil
+	case res.Err != nil:
+		return &CallToolResult{Content: []Content{TextContent(res.Err.Error())}, IsError: true}, nil
+	}
+
+	result := res.Result
+	if !result.Success {
+		return &CallToolResult{Content: []Content{TextContent(result.Error)}, IsError: true}, nil
+	}
+
+	if text, ok := result.Output.(string); ok {
+		return &CallToolResult{Content: []Content{TextContent(text)}}, nil
+	}
+
+	data, err = json.Marshal(result.Output)
+	if err != nil {
+		return &CallToolResult{Content: []Content{TextContent(err.Error())}, IsError: true}, nil
+	}
+
+	out := &CallToolResult{Content: []Content{TextContent(string(data))}}
+	if len(data) > 0 && data[0] == '{' {
+		out.StructuredContent = json.RawMessage(data)
+	}
+	return out, nil
+}
+
+func (s *Server) listPrompts() *ListPromptsResult {
+	result := &ListPromptsResult{Prompts: []*Prompt{}}
+	for _, p := range s.prompts {
+		prompt := p.Prompt
+		result.Prompts = append(result.Prompts, &prompt)
+	}
+
+	sort.Slice(result.Prompts, func(i, j int) bool {
+		return result.Prompts[i].Name < result.Prompts[j].Name
+	})
+	return result
+}
+
+func (s *Server) getPrompt(ctx context.Context, params *GetPromptParams) (*GetPromptResult, error) {
+	p, ok := s.prompts[params.Name]
+	if !ok || p.Render == nil {
+		return nil, &RPCError{Code: CodeInvalidParams, Message: "unknown prompt: " + params.Name}
+	}
+
+	for _, arg := range p.Arguments {
+		if arg.Required && params.Arguments[arg.Name] == "" {
+			return nil, &RPCError{Code: CodeInvalidParams, Message: "missing prompt argument: " + arg.Name}
+		}
+	}
+
+	return p.Render(ctx, params.Arguments)
+}
+
+// cancel stops the in-flight request id of session.
+func (s *Server) cancel(session string, id json.RawMessage) {
+	s.mu.Lock()
+	cancel, ok := s.inflight[session+"/"+string(id)]
+	s.mu.Unlock()
+
+	if ok {
+		cancel()
+	}
+}
+
+// ServeStdio serves a single client over newline-delimited JSON on r and w
+// until r is exhausted or ctx is cancelled.
+func (s *Server) ServeStdio(ctx context.Context, r io.Reader, w io.Writer) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	session := uuid.NewString()
+	var (
+		writeMu sync.Mutex
+		wg      sync.WaitGroup
+	)
+	write := func(msg *Message) {
+		data, err := json.Marshal(msg)
+		if err != nil {
+			return
+		}
+		writeMu.Lock()
+		w.Write(append(data, '\n'))
+		writeMu.Unlock()
+	}
+
+	lines := make(chan []byte)
+	readErr := make(chan error, 1)
+	go func() {
+		reader := bufio.NewReader(r)
+		for {
+			line, err := reader.ReadBytes('\n')
+			if len(line) > 0 {
+				select {
+				case lines <- line:
+				case <-ctx.Done():
+					return
+				}
+			}
+			if err != nil {
+				if err == io.EOF {
+					err = nil
+				}
+				readErr <- err
+				return
+			}
+		}
+	}()
+
+	defer wg.Wait()
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case err := <-readErr:
+			return err
+		case line := <-lines:
+			msgs := decodeMessages(line)
+			if msgs == nil {
+				write(NewErrorResponse(nil, CodeParseError, "invalid JSON-RPC message"))
+				continue
+			}
+			for _, msg := range msgs {
+				wg.Add(1)
+				go func(msg *Message) {
+					defer wg.Done()
+					if resp := s.Handle(ctx, session, msg); resp != nil {
+						write(resp)
+					}
+				}(msg)
+			}
+		}
+	}
+}
+
+// ServeHTTP serves the streamable HTTP transport.
+//
+// A session ID is issued on initialization and required on later requests.
+// Responses are returned as JSON; server-initiated streams are not used.
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodPost:
+		s.servePost(w, r)
+	case http.MethodDelete:
+		session := r.Header.Get(HeaderSessionID)
+		known := s.useSession(session)
+		s.mu.Lock()
+		delete(s.sessions, session)
+		s.mu.Unlock()
+		if !known {
+			http.Error(w, "unknown session", http.StatusNotFound)
+		}
+	default:
+		w.Header().Set("Allow", "POST, DELETE")
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+func (s *Server) servePost(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(io.LimitReader(r.Body, 16*1024*1024))
+	if err != nil {
+		http.Error(w, "failed to read request", http.StatusBadRequest)
+		return
+	}
+
+	msgs := decodeMessages(body)
+	if msgs == nil {
+		writeJSON(w, http.StatusBadRequest, NewErrorResponse(nil, CodeParseError, "invalid JSON-RPC message"))
+		return
+	}
+
+	session := r.Header.Get(HeaderSessionID)
+	if isInitialize(msgs) {
+		session = s.openSession()
+		w.Header().Set(HeaderSessionID, session)
+	} else {
+		switch {
+		case session == "":
+			http.Error(w, "missing "+HeaderSessionID+" header", http.StatusBadRequest)
+			return
+		case !s.useSession(session):
+			http.Error(w, "unknown session", http.StatusNotFound)
+			return
+		}
+	}
+
+	var responses []*Message
+	for _, msg := range msgs {
+		if resp := s.Handle(r.Context(), session, msg); resp != nil {
+			responses = append(responses, resp)
+		}
+	}
+
+	switch {
+	case len(responses) == 0:
+		w.WriteHeader(http.StatusAccepted)
+	case len(responses) == 1 && body[0] != '[':
+		writeJSON(w, http.StatusOK, responses[0])
+	default:
+		writeJSON(w, http.StatusOK, responses)
+	}
+}
+
+// openSession starts an HTTP session, first ending the sessions that have
+// been idle for longer than SessionIdleTimeout.
+func (s *Server) openSession() string {
+	session := uuid.NewString()
+	now := time.Now()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for id, lastUsed := range s.sessions {
+		if now.Sub(lastUsed) > s.config.SessionIdleTimeout {
+			delete(s.sessions, id)
+		}
+	}
+	s.sessions[session] = now
+	return session
+}
+
+// useSession reports whether session is open and not idle for too long,
+// and records its use.
+func (s *Server) useSession(session string) bool {
+	now := time.Now()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	lastUsed, ok := s.sessions[session]
+	if !ok {
+		return false
+	}
+	if now.Sub(lastUsed) > s.config.SessionIdleTimeout {
+		delete(s.sessions, session)
+		return false
+	}
+	s.sessions[session] = now
+	return true
+}
+
+// Handler returns the HTTP handler wrapped with the configured middleware
+// and authentication.
+func (s *Server) Handler() http.Handler {
+	var handler http.Handler = s
+	for i := len(s.config.Middleware) - 1; i >= 0; i-- {
+		handler = s.config.Middleware[i](handler)
+	}
+	if s.config.Authenticator != nil {
+		handler = s.config.Authenticator.Middleware(handler)
+	}
+	return handler
+}
+
+// Start serves the HTTP transport at the configured path on addr.
+//
+// This is a blocking call that returns when the server stops.
+func (s *Server) Start(addr string) error {
+	var tlsConfig *tls.Config
+	if s.config.TLS.Enabled() {
+		var err error
+		tlsConfig, err = s.config.TLS.ServerTLS()
+		if err != nil {
+			return err
+		}
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle(s.config.Path, s.Handler())
+
+	var handler http.Handler = mux
+	if tlsConfig != nil {
+		handler = mtls.Middleware(handler)
+	}
+
+	srv := &http.Server{
+		Addr:              addr,
+		Handler:           handler,
+		TLSConfig:         tlsConfig,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	s.mu.Lock()
+	s.http = srv
+	s.mu.Unlock()
+
+	var err error
+	if tlsConfig != nil {
+		err = srv.ListenAndServeTLS("", "")
+	} else {
+		err = srv.ListenAndServe()
+	}
+	if err != nil && err != http.ErrServerClosed {
+		return errors.ErrNetworkUnavailable.WithMessage("MCP server failed").Wrap(err)
+	}
+	return nil
+}
+
+// Stop gracefully stops the HTTP server started by Start.
+func (s *Server) Stop(ctx context.Context) error {
+	s.mu.Lock()
+	srv := s.http
+	s.mu.Unlock()
+
+	if srv == nil {
+		return nil
+	}
+	return srv.Shutdown(ctx)
+}
+
+func decodeParams(msg *Message, v interface{}) error {
+	if len(msg.Params) == 0 {
+		return nil
+	}
+	if err := json.Unmarshal(msg.Params, v); err != nil {
+		return &RPCError{Code: CodeInvalidParams, Message: "invalid params: " + err.Error()}
+	}
+	return nil
+}
+
+func isInitialize(msgs []*Message) bool {
+	for _, msg := range msgs {
+		if msg.Method == MethodInitialize {
+			return true
+		}
+	}
+	return false
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
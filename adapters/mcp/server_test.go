@@ -0,0 +1,395 @@
+// Copyright (C) 2025 sage-x-project
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with this program. If not, see <https://www.gnu.org/licenses/>.
+
+// SPDX-License-Identifier: LGPL-3.0-or-later
+
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sage-x-project/sage-adk/core/tools"
+	"github.com/sage-x-project/sage-adk/pkg/errors"
+	"github.com/sage-x-project/sage-adk/pkg/types"
+	"github.com/sage-x-project/sage-adk/storage"
+)
+
+type addInput struct {
+	A float64 `json:"a" required:"true"`
+	B float64 `json:"b" required:"true"`
+}
+
+type addOutput struct {
+	Sum float64 `json:"sum"`
+}
+
+func newTestServer(t *testing.T, middleware ...func(http.Handler) http.Handler) *Server {
+	t.Helper()
+
+	registry := tools.NewRegistry()
+	registry.Register(tools.EchoTool())
+	registry.Register(tools.MustTypedTool("add", "Adds two numbers",
+		func(ctx context.Context, in addInput) (addOutput, error) {
+			return addOutput{Sum: in.A + in.B}, nil
+		}))
+	registry.Register(tools.NewFunctionTool("wait", "Waits for cancellation", nil,
+		func(ctx context.Context, params map[string]interface{}) (*tools.Result, error) {
+			<-ctx.Done()
+			return nil, ctx.Err()
+		}))
+
+	return NewServer(&ServerConfig{
+		Name:    "test-agent",
+		Version: "1.0.0",
+		Tools:   registry,
+		Skills: []types.AgentSkill{
+			{ID: "summarize", Name: "Summarize", Description: "Summarizes text"},
+		},
+		Middleware: middleware,
+	})
+}
+
+// newStdioPair connects a client to server.ServeStdio over pipes.
+func newStdioPair(t *testing.T, server *Server) *Client {
+	t.Helper()
+
+	clientR, serverW := io.Pipe()
+	serverR, clientW := io.Pipe()
+	go func() {
+		server.ServeStdio(context.Background(), serverR, serverW)
+		serverW.Close()
+	}()
+
+	client := NewClient(NewStreamTransport(clientR, clientW), nil)
+	t.Cleanup(func() { client.Close() })
+	return client
+}
+
+func TestServer_Stdio(t *testing.T) {
+	ctx := context.Background()
+	client := newStdioPair(t, newTestServer(t))
+
+	if err := client.Connect(ctx); err != nil {
+		t.Fatalf("Connect() error = %v", err)
+	}
+	info := client.ServerInfo()
+	if info.ServerInfo.Name != "test-agent" || info.Capabilities.Tools == nil || info.Capabilities.Prompts == nil {
+		t.Errorf("ServerInfo() = %+v", info)
+	}
+
+	list, err := client.ListTools(ctx)
+	if err != nil {
+		t.Fatalf("ListTools() error = %v", err)
+	}
+	if len(list) != 3 || list[0].Name != "add" {
+		t.Fatalf("ListTools() = %v", list)
+	}
+	var schema tools.ParameterSchema
+	if err := json.Unmarshal(list[0].InputSchema, &schema); err != nil {
+		t.Fatalf("InputSchema: %v", err)
+	}
+	if schema.Properties["a"].Type != "number" || len(schema.Required) != 2 {
+		t.Errorf("InputSchema = %s", list[0].InputSchema)
+	}
+
+	result, err := client.CallTool(ctx, "add", map[string]interface{}{"a": 2, "b": 3})
+	if err != nil {
+		t.Fatalf("CallTool() error = %v", err)
+	}
+	if result.IsError || result.Text() != `{"sum":5}` || result.StructuredContent == nil {
+		t.Errorf("CallTool(add) = %+v", result)
+	}
+
+	result, err = client.CallTool(ctx, "add", map[string]interface{}{"a": "two"})
+	if err != nil {
+		t.Fatalf("CallTool() error = %v", err)
+	}
+	if !result.IsError || !strings.Contains(result.Text(), "b: is required") {
+		t.Errorf("CallTool(add) with invalid arguments = %+v", result)
+	}
+
+	result, err = client.CallTool(ctx, "echo", map[string]interface{}{"message": "hi"})
+	if err != nil || result.IsError || result.Text() != "hi" {
+		t.Errorf("CallTool(echo) = %+v, %v", result, err)
+	}
+
+	_, err = client.CallTool(ctx, "missing", nil)
+	var rpcErr *RPCError
+	if !errors.As(err, &rpcErr) || rpcErr.Code != CodeInvalidParams {
+		t.Errorf("CallTool(missing) error = %v, want invalid params", err)
+	}
+}
+
+func TestServer_Prompts(t *testing.T) {
+	ctx := context.Background()
+	client := newStdioPair(t, newTestServer(t))
+
+	prompts, err := client.ListPrompts(ctx)
+	if err != nil {
+		t.Fatalf("ListPrompts() error = %v", err)
+	}
+	if len(prompts) != 1 || prompts[0].Name != "summarize" || prompts[0].Description != "Summarizes text" {
+		t.Fatalf("ListPrompts() = %+v", prompts)
+	}
+
+	prompt, err := client.GetPrompt(ctx, "summarize", map[string]string{"input": "long text"})
+	if err != nil {
+		t.Fatalf("GetPrompt() error = %v", err)
+	}
+	if len(prompt.Messages) != 1 || prompt.Messages[0].Role != RoleUser ||
+		!strings.HasSuffix(prompt.Messages[0].Content.Text, "long text") {
+		t.Errorf("GetPrompt() = %+v", prompt)
+	}
+
+	if _, err := client.GetPrompt(ctx, "missing", nil); err == nil {
+		t.Error("GetPrompt(missing) should fail")
+	}
+}
+
+func TestServer_Cancel(t *testing.T) {
+	config := DefaultClientConfig()
+	config.Timeout = 50 * time.Millisecond
+	server := newTestServer(t)
+
+	clientR, serverW := io.Pipe()
+	serverR, clientW := io.Pipe()
+	go server.ServeStdio(context.Background(), serverR, serverW)
+
+	client := NewClient(NewStreamTransport(clientR, clientW), config)
+	defer client.Close()
+
+	if _, err := client.CallTool(context.Background(), "wait", nil); !errors.Is(err, errors.ErrTimeout) {
+		t.Fatalf("CallTool(wait) error = %v, want ErrTimeout", err)
+	}
+
+	deadline := time.Now().Add(time.Second)
+	for time.Now().Before(deadline) {
+		server.mu.Lock()
+		n := len(server.inflight)
+		server.mu.Unlock()
+		if n == 0 {
+			return
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Error("cancelled request is still in flight")
+}
+
+func TestServer_HTTP(t *testing.T) {
+	ctx := context.Background()
+	auth := func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Header.Get("Authorization") != "Bearer secret" {
+				http.Error(w, "unauthorized", http.StatusUnauthorized)
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+
+	ts := httptest.NewServer(newTestServer(t, auth).Handler())
+	defer ts.Close()
+
+	if err := NewClient(NewHTTPTransport(ts.URL), nil).Connect(ctx); !errors.Is(err, errors.ErrUnauthorized) {
+		t.Errorf("Connect() without credentials error = %v, want ErrUnauthorized", err)
+	}
+
+	transport := NewHTTPTransport(ts.URL)
+	transport.Header = http.Header{"Authorization": {"Bearer secret"}}
+	client := NewClient(transport, nil)
+	defer client.Close()
+
+	result, err := client.CallTool(ctx, "add", map[string]interface{}{"a": 1, "b": 1})
+	if err != nil {
+		t.Fatalf("CallTool() error = %v", err)
+	}
+	if result.Text() != `{"sum":2}` {
+		t.Errorf("Text() = %q", result.Text())
+	}
+}
+
+func TestServer_HTTPSessions(t *testing.T) {
+	server := newTestServer(t)
+	ts := httptest.NewServer(server)
+	defer ts.Close()
+
+	post := func(session, body string) *http.Response {
+		req, _ := http.NewRequest(http.MethodPost, ts.URL, strings.NewReader(body))
+		if session != "" {
+			req.Header.Set(HeaderSessionID, session)
+		}
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close()
+		return resp
+	}
+
+	resp := post("", `{"jsonrpc":"2.0","id":1,"method":"initialize","params":{"protocolVersion":"2024-11-05"}}`)
+	session := resp.Header.Get(HeaderSessionID)
+	if resp.StatusCode != http.StatusOK || session == "" {
+		t.Fatalf("initialize: status %d, session %q", resp.StatusCode, session)
+	}
+
+	if resp := post(session, `{"jsonrpc":"2.0","method":"notifications/initialized"}`); resp.StatusCode != http.StatusAccepted {
+		t.Errorf("notification status = %d, want 202", resp.StatusCode)
+	}
+	if resp := post("", `{"jsonrpc":"2.0","id":2,"method":"ping"}`); resp.StatusCode != http.StatusBadRequest {
+		t.Errorf("missing session status = %d, want 400", resp.StatusCode)
+	}
+	if resp := post("unknown", `{"jsonrpc":"2.0","id":2,"method":"ping"}`); resp.StatusCode != http.StatusNotFound {
+		t.Errorf("unknown session status = %d, want 404", resp.StatusCode)
+	}
+	if resp := post(session, `not json`); resp.StatusCode != http.StatusBadRequest {
+		t.Errorf("invalid body status = %d, want 400", resp.StatusCode)
+	}
+
+	req, _ := http.NewRequest(http.MethodDelete, ts.URL, nil)
+	req.Header.Set(HeaderSessionID, session)
+	del, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	del.Body.Close()
+	if del.StatusCode != http.StatusOK {
+		t.Errorf("DELETE status = %d, want 200", del.StatusCode)
+	}
+	if resp := post(session, `{"jsonrpc":"2.0","id":3,"method":"ping"}`); resp.StatusCode != http.StatusNotFound {
+		t.Errorf("deleted session status = %d, want 404", resp.StatusCode)
+	}
+
+	get, err := http.Get(ts.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	get.Body.Close()
+	if get.StatusCode != http.StatusMethodNotAllowed {
+		t.Errorf("GET status = %d, want 405", get.StatusCode)
+	}
+}
+
+func TestServer_Approval(t *testing.T) {
+	ctx := context.Background()
+
+	registry := tools.NewRegistry()
+	registry.Register(tools.RequireApproval(tools.EchoTool()))
+
+	// Without an approval store the tool is refused
+	client := newStdioPair(t, NewServer(&ServerConfig{Name: "test-agent", Tools: registry}))
+	result, err := client.CallTool(ctx, "echo", map[string]interface{}{"message": "hi"})
+	if err != nil {
+		t.Fatalf("CallTool() error = %v", err)
+	}
+	if !result.IsError || !strings.Contains(result.Text(), "approval") {
+		t.Errorf("CallTool() = %+v, want approval error", result)
+	}
+	client.Close()
+
+	approvals := tools.NewApprovalStore(storage.NewMemoryStorage())
+	server := NewServer(&ServerConfig{
+		Name:     "test-agent",
+		Tools:    registry,
+		Executor: tools.NewExecutor(registry, &tools.ExecutorConfig{Approvals: approvals}),
+	})
+	client = newStdioPair(t, server)
+	defer client.Close()
+
+	result, err = client.CallTool(ctx, "echo", map[string]interface{}{"message": "hi"})
+	if err != nil {
+		t.Fatalf("CallTool() error = %v", err)
+	}
+	if !result.IsError || !strings.Contains(result.Text(), "awaits approval") {
+		t.Fatalf("CallTool() = %+v, want pending approval", result)
+	}
+
+	id := strings.TrimPrefix(result.Text(), "tool call awaits approval: ")
+	if _, err := approvals.Approve(ctx, id, "ok"); err != nil {
+		t.Fatalf("Approve() error = %v", err)
+	}
+
+	// The approved call runs when repeated
+	result, err = client.CallTool(ctx, "echo", map[string]interface{}{"message": "hi"})
+	if err != nil {
+		t.Fatalf("CallTool() error = %v", err)
+	}
+	if result.IsError || result.Text() != "hi" {
+		t.Errorf("CallTool() = %+v, want hi", result)
+	}
+}
+
+func TestServer_HTTPSessionExpiry(t *testing.T) {
+	server := NewServer(&ServerConfig{Name: "test-agent", SessionIdleTimeout: 20 * time.Millisecond})
+	ts := httptest.NewServer(server)
+	defer ts.Close()
+
+	post := func(session, body string) *http.Response {
+		req, _ := http.NewRequest(http.MethodPost, ts.URL, strings.NewReader(body))
+		if session != "" {
+			req.Header.Set(HeaderSessionID, session)
+		}
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close()
+		return resp
+	}
+	initialize := `{"jsonrpc":"2.0","id":1,"method":"initialize","params":{"protocolVersion":"2024-11-05"}}`
+
+	session := post("", initialize).Header.Get(HeaderSessionID)
+	time.Sleep(40 * time.Millisecond)
+
+	if resp := post(session, `{"jsonrpc":"2.0","id":2,"method":"ping"}`); resp.StatusCode != http.StatusNotFound {
+		t.Errorf("idle session status = %d, want 404", resp.StatusCode)
+	}
+
+	// Idle sessions are swept when new ones open
+	stale := post("", initialize).Header.Get(HeaderSessionID)
+	time.Sleep(40 * time.Millisecond)
+	post("", initialize)
+
+	server.mu.Lock()
+	_, kept := server.sessions[stale]
+	server.mu.Unlock()
+	if kept {
+		t.Error("idle session not swept")
+	}
+}
+
+func TestServer_StartStop(t *testing.T) {
+	server := newTestServer(t)
+	done := make(chan error, 1)
+	go func() { done <- server.Start("127.0.0.1:0") }()
+
+	for started := false; !started; time.Sleep(5 * time.Millisecond) {
+		server.mu.Lock()
+		started = server.http != nil
+		server.mu.Unlock()
+	}
+	if err := server.Stop(context.Background()); err != nil {
+		t.Fatalf("Stop() error = %v", err)
+	}
+	if err := <-done; err != nil {
+		t.Errorf("Start() error = %v", err)
+	}
+}
@@ -0,0 +1,134 @@
+// Copyright (C) 2025 sage-x-project
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with this program. If not, see <https://www.gnu.org/licenses/>.
+
+// SPDX-License-Identifier: LGPL-3.0-or-later
+
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/sage-x-project/sage-adk/core/tools"
+	"github.com/sage-x-project/sage-adk/pkg/errors"
+)
+
+// RemoteTool exposes a tool of an MCP server as a tools.Tool.
+type RemoteTool struct {
+	client     *Client
+	tool       *Tool
+	name       string
+	parameters *tools.ParameterSchema
+}
+
+// NewRemoteTool wraps tool, registering it as prefix + tool.Name.
+func NewRemoteTool(client *Client, tool *Tool, prefix string) *RemoteTool {
+	return &RemoteTool{
+		client:     client,
+		tool:       tool,
+		name:       prefix + tool.Name,
+		parameters: decodeSchema(tool.InputSchema),
+	}
+}
+
+// Name returns the tool name, including the prefix.
+func (t *RemoteTool) Name() string {
+	return t.name
+}
+
+// Description returns the tool description.
+func (t *RemoteTool) Description() string {
+	return t.tool.Description
+}
+
+// Parameters returns the tool's input schema.
+func (t *RemoteTool) Parameters() *tools.ParameterSchema {
+	return t.parameters
+}
+
+// Execute calls the tool on the server.
+//
+// Tool failures and JSON-RPC errors such as invalid arguments are returned
+// as failed results so the model can react; connection failures and
+// timeouts are returned as errors.
+func (t *RemoteTool) Execute(ctx context.Context, params map[string]interface{}) (*tools.Result, error) {
+	result, err := t.client.CallTool(ctx, t.tool.Name, params)
+	if err != nil {
+		var rpcErr *RPCError
+		if errors.As(err, &rpcErr) {
+			return tools.ErrorResultWithMessage(rpcErr.Message), nil
+		}
+		return nil, err
+	}
+
+	if result.IsError {
+		return tools.ErrorResultWithMessage(result.Text()), nil
+	}
+
+	var output interface{} = result.Text()
+	if result.StructuredContent != nil {
+		output = result.StructuredContent
+	}
+
+	res := tools.SuccessResult(output)
+	res.Metadata = map[string]interface{}{
+		"mcp_tool":    t.tool.Name,
+		"mcp_content": result.Content,
+	}
+	return res, nil
+}
+
+// Tools lists the server's tools as tools.Tool, with names prefixed by prefix.
+func (c *Client) Tools(ctx context.Context, prefix string) ([]tools.Tool, error) {
+	remote, err := c.ListTools(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]tools.Tool, len(remote))
+	for i, tool := range remote {
+		result[i] = NewRemoteTool(c, tool, prefix)
+	}
+	return result, nil
+}
+
+// RegisterTools registers the server's tools in registry, with names
+// prefixed by prefix to avoid clashes between servers.
+func (c *Client) RegisterTools(ctx context.Context, registry *tools.Registry, prefix string) error {
+	remote, err := c.Tools(ctx, prefix)
+	if err != nil {
+		return err
+	}
+
+	for _, tool := range remote {
+		if err := registry.Register(tool); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// decodeSchema converts a JSON Schema to a parameter schema. Schemas using
+// constructs it cannot represent fall back to an unconstrained object.
+func decodeSchema(raw json.RawMessage) *tools.ParameterSchema {
+	var schema tools.ParameterSchema
+	if len(raw) == 0 || json.Unmarshal(raw, &schema) != nil {
+		return &tools.ParameterSchema{Type: "object"}
+	}
+	if schema.Type == "" {
+		schema.Type = "object"
+	}
+	return &schema
+}
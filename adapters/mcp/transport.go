@@ -0,0 +1,304 @@
+// Copyright (C) 2025 sage-x-project
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with this program. If not, see <https://www.gnu.org/licenses/>.
+
+// SPDX-License-Identifier: LGPL-3.0-or-later
+
+package mcp
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"os/exec"
+	"sync"
+	"time"
+
+	"github.com/sage-x-project/sage-adk/pkg/errors"
+)
+
+var (
+	// ErrConnectionClosed indicates the connection to the peer was lost or closed.
+	ErrConnectionClosed = errors.ErrNetworkUnavailable.WithMessage("MCP connection closed")
+
+	// ErrSessionExpired indicates the server no longer knows the session.
+	ErrSessionExpired = errors.ErrNetworkUnavailable.WithMessage("MCP session expired")
+)
+
+// Transport opens connections to an MCP peer.
+//
+// Connect is called again to reconnect after a connection is lost.
+type Transport interface {
+	Connect(ctx context.Context) (Conn, error)
+}
+
+// Conn is a connection carrying JSON-RPC messages.
+type Conn interface {
+	// Send writes a message to the peer.
+	Send(ctx context.Context, msg *Message) error
+
+	// Messages delivers messages received from the peer.
+	Messages() <-chan *Message
+
+	// Done is closed when the connection ends.
+	Done() <-chan struct{}
+
+	// Close closes the connection.
+	Close() error
+}
+
+// streamConn exchanges newline-delimited JSON messages over a byte stream.
+type streamConn struct {
+	w        io.WriteCloser
+	writeMu  sync.Mutex
+	messages chan *Message
+	done     chan struct{}
+	once     sync.Once
+	onClose  func() error
+}
+
+// newStreamConn starts reading messages from r.
+func newStreamConn(r io.Reader, w io.WriteCloser, onClose func() error) *streamConn {
+	c := &streamConn{
+		w:        w,
+		messages: make(chan *Message, 16),
+		done:     make(chan struct{}),
+		onClose:  onClose,
+	}
+	go c.readLoop(r)
+	return c
+}
+
+func (c *streamConn) readLoop(r io.Reader) {
+	defer c.shutdown()
+
+	reader := bufio.NewReader(r)
+	for {
+		line, err := reader.ReadBytes('\n')
+		if len(line) > 0 {
+			c.dispatch(line)
+		}
+		if err != nil {
+			return
+		}
+	}
+}
+
+// dispatch decodes a line holding a message or a batch of messages.
+func (c *streamConn) dispatch(line []byte) {
+	for _, msg := range decodeMessages(line) {
+		select {
+		case c.messages <- msg:
+		case <-c.done:
+			return
+		}
+	}
+}
+
+// Send writes msg followed by a newline.
+func (c *streamConn) Send(ctx context.Context, msg *Message) error {
+	data, err := json.Marshal(msg)
+	if err != nil {
+		return errors.ErrInvalidInput.WithMessage("failed to encode MCP message").Wrap(err)
+	}
+
+	select {
+	case <-c.done:
+		return ErrConnectionClosed
+	case <-ctx.Done():
+		return ctx.Err()
+	default:
+	}
+
+	c.writeMu.Lock()
+	defer c.writeMu.Unlock()
+
+	if _, err := c.w.Write(append(data, '\n')); err != nil {
+		c.shutdown()
+		return ErrConnectionClosed.Wrap(err)
+	}
+	return nil
+}
+
+// Messages returns the received messages.
+func (c *streamConn) Messages() <-chan *Message {
+	return c.messages
+}
+
+// Done is closed when the stream ends.
+func (c *streamConn) Done() <-chan struct{} {
+	return c.done
+}
+
+// Close closes the write side and releases the stream.
+func (c *streamConn) Close() error {
+	c.shutdown()
+
+	c.writeMu.Lock()
+	err := c.w.Close()
+	c.writeMu.Unlock()
+
+	if c.onClose != nil {
+		if closeErr := c.onClose(); closeErr != nil {
+			err = closeErr
+		}
+	}
+	return err
+}
+
+func (c *streamConn) shutdown() {
+	c.once.Do(func() { close(c.done) })
+}
+
+// decodeMessages decodes a single message or a batch. Invalid input is dropped.
+func decodeMessages(data []byte) []*Message {
+	data = bytes.TrimSpace(data)
+	if len(data) == 0 {
+		return nil
+	}
+
+	if data[0] == '[' {
+		var batch []*Message
+		if err := json.Unmarshal(data, &batch); err != nil {
+			return nil
+		}
+		return batch
+	}
+
+	var msg Message
+	if err := json.Unmarshal(data, &msg); err != nil {
+		return nil
+	}
+	return []*Message{&msg}
+}
+
+// StreamTransport is a Transport over an existing reader and writer,
+// such as a pipe to an in-process server. It connects only once.
+type StreamTransport struct {
+	mu   sync.Mutex
+	r    io.Reader
+	w    io.WriteCloser
+	used bool
+}
+
+// NewStreamTransport creates a transport over r and w.
+func NewStreamTransport(r io.Reader, w io.WriteCloser) *StreamTransport {
+	return &StreamTransport{r: r, w: w}
+}
+
+// Connect returns the connection over the stream.
+// Subsequent calls fail with ErrConnectionClosed.
+func (t *StreamTransport) Connect(ctx context.Context) (Conn, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.used {
+		return nil, ErrConnectionClosed.WithMessage("stream transport cannot reconnect")
+	}
+	t.used = true
+
+	var onClose func() error
+	if closer, ok := t.r.(io.Closer); ok {
+		onClose = closer.Close
+	}
+	return newStreamConn(t.r, t.w, onClose), nil
+}
+
+// StdioTransport runs an MCP server as a subprocess and talks to it over
+// its standard input and output. Each connection starts a new process.
+type StdioTransport struct {
+	// Command is the server executable.
+	Command string
+
+	// Args are the command arguments.
+	Args []string
+
+	// Env is the process environment. Nil inherits the current environment.
+	Env []string
+
+	// Dir is the working directory.
+	Dir string
+
+	// Stderr receives the server's standard error. Nil discards it.
+	Stderr io.Writer
+
+	// ShutdownTimeout bounds the wait for the process to exit after its
+	// input is closed before it is killed.
+	// Default: 5s
+	ShutdownTimeout time.Duration
+}
+
+// NewStdioTransport creates a transport running command with args.
+func NewStdioTransport(command string, args ...string) *StdioTransport {
+	return &StdioTransport{
+		Command: command,
+		Args:    args,
+	}
+}
+
+// Connect starts the server process.
+func (t *StdioTransport) Connect(ctx context.Context) (Conn, error) {
+	if t.Command == "" {
+		return nil, errors.ErrMissingField.WithDetail("field", "command")
+	}
+
+	cmd := exec.Command(t.Command, t.Args...)
+	cmd.Env = t.Env
+	cmd.Dir = t.Dir
+	cmd.Stderr = t.Stderr
+
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return nil, errors.ErrOperationFailed.WithMessage("failed to open stdin").Wrap(err)
+	}
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, errors.ErrOperationFailed.WithMessage("failed to open stdout").Wrap(err)
+	}
+
+	if err := cmd.Start(); err != nil {
+		return nil, errors.ErrConnectionRefused.
+			WithMessage("failed to start MCP server").
+			WithDetail("command", t.Command).
+			Wrap(err)
+	}
+
+	timeout := t.ShutdownTimeout
+	if timeout <= 0 {
+		timeout = 5 * time.Second
+	}
+
+	var waitOnce sync.Once
+	wait := func() error {
+		waitOnce.Do(func() {
+			exited := make(chan struct{})
+			go func() {
+				cmd.Wait()
+				close(exited)
+			}()
+
+			select {
+			case <-exited:
+			case <-time.After(timeout):
+				cmd.Process.Kill()
+				<-exited
+			}
+		})
+		return nil
+	}
+
+	return newStreamConn(stdout, stdin, wait), nil
+}
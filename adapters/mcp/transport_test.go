@@ -0,0 +1,114 @@
+// Copyright (C) 2025 sage-x-project
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with this program. If not, see <https://www.gnu.org/licenses/>.
+
+// SPDX-License-Identifier: LGPL-3.0-or-later
+
+package mcp
+
+import (
+	"context"
+	"io"
+	"os"
+	"testing"
+
+	"github.com/sage-x-project/sage-adk/pkg/errors"
+)
+
+// TestHelperFakeServer is run as a subprocess by the stdio tests.
+func TestHelperFakeServer(t *testing.T) {
+	if os.Getenv("MCP_FAKE_SERVER") != "1" {
+		t.Skip("helper process")
+	}
+	newFakeServer().serveStream(os.Stdin, os.Stdout)
+	os.Exit(0)
+}
+
+func newStdioTransport() *StdioTransport {
+	transport := NewStdioTransport(os.Args[0], "-test.run=^TestHelperFakeServer$")
+	transport.Env = append(os.Environ(), "MCP_FAKE_SERVER=1")
+	return transport
+}
+
+func TestStdioTransport(t *testing.T) {
+	ctx := context.Background()
+	client := NewClient(newStdioTransport(), nil)
+	defer client.Close()
+
+	list, err := client.ListTools(ctx)
+	if err != nil {
+		t.Fatalf("ListTools() error = %v", err)
+	}
+	if len(list) != len(fakeTools) {
+		t.Errorf("len(ListTools()) = %d, want %d", len(list), len(fakeTools))
+	}
+
+	result, err := client.CallTool(ctx, "echo", map[string]interface{}{"text": "stdio"})
+	if err != nil {
+		t.Fatalf("CallTool() error = %v", err)
+	}
+	if result.Text() != "stdio" {
+		t.Errorf("Text() = %q, want stdio", result.Text())
+	}
+}
+
+func TestStdioTransport_Reconnect(t *testing.T) {
+	ctx := context.Background()
+	client := NewClient(newStdioTransport(), nil)
+	defer client.Close()
+
+	if err := client.Connect(ctx); err != nil {
+		t.Fatalf("Connect() error = %v", err)
+	}
+
+	// The server exits while handling the call; the call is not repeated.
+	if _, err := client.CallTool(ctx, "crash", nil); !errors.Is(err, ErrConnectionClosed) {
+		t.Fatalf("CallTool(crash) error = %v, want ErrConnectionClosed", err)
+	}
+
+	// The next request starts a new server.
+	if err := client.Ping(ctx); err != nil {
+		t.Fatalf("Ping() after server exit error = %v", err)
+	}
+}
+
+func TestStdioTransport_InvalidCommand(t *testing.T) {
+	config := DefaultClientConfig()
+	config.MaxReconnects = 1
+	config.ReconnectDelay = 0
+	client := NewClient(NewStdioTransport("/nonexistent/mcp-server"), config)
+
+	if err := client.Connect(context.Background()); !errors.Is(err, errors.ErrConnectionRefused) {
+		t.Errorf("Connect() error = %v, want ErrConnectionRefused", err)
+	}
+
+	if _, err := NewStdioTransport("").Connect(context.Background()); !errors.Is(err, errors.ErrMissingField) {
+		t.Errorf("Connect() error = %v, want ErrMissingField", err)
+	}
+}
+
+func TestStreamTransport_ConnectOnce(t *testing.T) {
+	r, w := io.Pipe()
+	transport := NewStreamTransport(r, w)
+
+	conn, err := transport.Connect(context.Background())
+	if err != nil {
+		t.Fatalf("Connect() error = %v", err)
+	}
+	defer conn.Close()
+
+	if _, err := transport.Connect(context.Background()); !errors.Is(err, ErrConnectionClosed) {
+		t.Errorf("second Connect() error = %v, want ErrConnectionClosed", err)
+	}
+}
@@ -23,12 +23,21 @@ import (
 	"crypto/rand"
 	"encoding/base64"
 	"fmt"
+	"net/http"
 	"sync"
 	"time"
 
+	"github.com/sage-x-project/sage-adk/adapters/sage/httpsig"
+	"github.com/sage-x-project/sage-adk/adapters/sage/keyring"
+	"github.com/sage-x-project/sage-adk/adapters/sage/keystore"
+	"github.com/sage-x-project/sage-adk/adapters/sage/registry"
+	"github.com/sage-x-project/sage-adk/adapters/sage/replay"
+	"github.com/sage-x-project/sage-adk/adapters/sage/sessionstore"
 	"github.com/sage-x-project/sage-adk/config"
+	"github.com/sage-x-project/sage-adk/core/authn"
 	"github.com/sage-x-project/sage-adk/core/protocol"
 	"github.com/sage-x-project/sage-adk/pkg/errors"
+	"github.com/sage-x-project/sage-adk/pkg/mtls"
 	"github.com/sage-x-project/sage-adk/pkg/types"
 	"github.com/sage-x-project/sage/core"
 	sagecrypto "github.com/sage-x-project/sage/crypto"
@@ -61,19 +70,32 @@ func init() {
 
 // Adapter implements the ProtocolAdapter interface for SAGE protocol.
 type Adapter struct {
-	core            *core.Core
-	config          *config.SAGEConfig
-	agentDID        string
-	signingManager  *SigningManager
-	nonceCache      *NonceCache
-	didResolver     *DIDResolver
-	keyManager      *KeyManager
-	privateKey      ed25519.PrivateKey
-	networkClient   *NetworkClient
-	remoteEndpoint  string // Remote agent endpoint for message transmission
-	mu              sync.RWMutex
+	core           *core.Core
+	config         *config.SAGEConfig
+	agentDID       string
+	signingManager *SigningManager
+	replayGuard    replay.Guard
+	maxClockSkew   time.Duration
+	didResolver    *DIDResolver
+	keyManager     *KeyManager
+	privateKey     ed25519.PrivateKey
+	keyRing        *keyring.Ring
+	networkClient  *NetworkClient
+	remoteEndpoint string // Remote agent endpoint for message transmission
+	remoteDID      string // Remote agent DID for encrypted sessions
+	transport      *TransportManager
+	sessionStore   sessionstore.Store
+	resolveKey     KeyResolverFunc
+	inbox          chan *types.Message
+	handler        MessageHandlerFunc
+	streamHandler  StreamHandlerFunc
+	mu             sync.RWMutex
 }
 
+// DefaultInboxSize is the number of verified inbound messages buffered for
+// ReceiveMessage when no message handler is set.
+const DefaultInboxSize = 100
+
 // NewAdapter creates a new SAGE protocol adapter.
 func NewAdapter(cfg *config.SAGEConfig) (*Adapter, error) {
 	if cfg == nil {
@@ -94,47 +116,90 @@ func NewAdapter(cfg *config.SAGEConfig) (*Adapter, error) {
 	// Initialize signing manager for RFC 9421
 	signingManager := NewSigningManager()
 
-	// Initialize nonce cache for replay protection (10000 max nonces)
-	nonceCache := NewNonceCache(10000)
+	// Initialize replay protection; a shared store catches replays routed
+	// to another replica
+	maxClockSkew := cfg.MaxClockSkew
+	if maxClockSkew <= 0 {
+		maxClockSkew = replay.DefaultMaxClockSkew
+	}
+	replayGuard, err := replay.Open(cfg.NonceStore, replay.Window(maxClockSkew))
+	if err != nil {
+		return nil, errors.ErrConfigurationError.
+			WithMessage("failed to open nonce store").
+			WithDetail("error", err.Error())
+	}
 
 	// Initialize DID resolver (optional - allows working without blockchain)
 	var didResolver *DIDResolver
-	// Note: DID resolver initialization requires blockchain connectivity
-	// Skip for now - will be initialized when needed
+	// Note: on-chain resolution requires blockchain connectivity and is
+	// skipped; a local registry can stand in for it
+	if cfg.Registry != "" {
+		reg, err := registry.Open(cfg.Registry)
+		if err != nil {
+			return nil, errors.ErrConfigurationError.
+				WithMessage("failed to open DID registry").
+				WithDetail("registry", cfg.Registry).
+				WithDetail("error", err.Error())
+		}
+		didResolver = NewRegistryDIDResolver(reg)
+	}
+
+	// did:key and did:web need no registration and are always resolvable
+	didResolver = NewMethodDIDResolver(didResolver, nil)
 
 	// Initialize key manager
 	keyManager := NewKeyManager()
 
-	// Load private key if path is provided
+	// Load private key if path is provided. A missing, unreadable,
+	// undecryptable or non-Ed25519 key is a configuration error: an agent
+	// configured to sign must not start unable to.
 	var privateKey ed25519.PrivateKey
+	passphrase := keystore.DefaultPassphrase(cfg.PassphraseFile)
 	if cfg.PrivateKeyPath != "" {
-		keyPair, err := keyManager.LoadFromFile(cfg.PrivateKeyPath)
+		key, err := keyManager.LoadPrivateKey(cfg.PrivateKeyPath, passphrase)
 		if err != nil {
-			// Private key is optional - log warning but continue
-			// This allows the adapter to work without signing capability
-		} else {
-			// Extract Ed25519 private key
-			privateKey, err = keyManager.ExtractEd25519PrivateKey(keyPair)
-			if err != nil {
-				// Key type mismatch - log warning but continue
-			}
+			return nil, errors.ErrConfigurationError.
+				WithMessage("failed to load private key").
+				WithDetail("path", cfg.PrivateKeyPath).
+				WithDetail("error", err.Error())
 		}
+		privateKey = key
+	}
+
+	// A key ring replaces the single private key and enables rotation
+	var keyRing *keyring.Ring
+	if cfg.KeyRingPath != "" {
+		ring, activeKey, err := loadKeyRing(cfg.KeyRingPath, cfg.DID, passphrase)
+		if err != nil {
+			return nil, err
+		}
+		keyRing, privateKey = ring, activeKey
 	}
 
 	// Initialize network client
 	networkClient := NewNetworkClient(nil)
 
-	return &Adapter{
+	adapter := &Adapter{
 		core:           sageCore,
 		config:         cfg,
 		agentDID:       cfg.DID,
 		signingManager: signingManager,
-		nonceCache:     nonceCache,
+		replayGuard:    replayGuard,
+		maxClockSkew:   maxClockSkew,
 		didResolver:    didResolver,
 		keyManager:     keyManager,
 		privateKey:     privateKey,
+		keyRing:        keyRing,
 		networkClient:  networkClient,
-	}, nil
+		inbox:          make(chan *types.Message, DefaultInboxSize),
+	}
+
+	// Bind outgoing HTTP requests to the agent's key
+	if privateKey != nil {
+		networkClient.SetHTTPSigner(adapter.HTTPSigner())
+	}
+
+	return adapter, nil
 }
 
 // Name returns the adapter name.
@@ -142,6 +207,36 @@ func (a *Adapter) Name() string {
 	return "sage"
 }
 
+// SetDIDResolver sets the resolver used to find the public keys of peers.
+func (a *Adapter) SetDIDResolver(resolver *DIDResolver) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.didResolver = resolver
+}
+
+// GetAgentCapabilities returns the capabilities agentDID advertises,
+// looked up with the adapter's DID resolver. It lets the adapter serve as
+// an authz.CapabilityResolver.
+func (a *Adapter) GetAgentCapabilities(ctx context.Context, agentDID string) (map[string]interface{}, error) {
+	a.mu.RLock()
+	resolver := a.didResolver
+	a.mu.RUnlock()
+
+	if resolver == nil {
+		return nil, errors.ErrConfigurationError.
+			WithMessage("no DID resolver configured")
+	}
+	return resolver.GetAgentCapabilities(ctx, agentDID)
+}
+
+// SetReplayGuard replaces the guard used to reject replayed nonces, for
+// example to share a Redis client with the rest of the application.
+func (a *Adapter) SetReplayGuard(guard replay.Guard) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.replayGuard = guard
+}
+
 // SetRemoteEndpoint sets the remote agent endpoint for message transmission.
 func (a *Adapter) SetRemoteEndpoint(endpoint string) {
 	a.mu.Lock()
@@ -156,8 +251,80 @@ func (a *Adapter) GetRemoteEndpoint() string {
 	return a.remoteEndpoint
 }
 
+// SetRemoteDID sets the DID of the remote agent. With encryption enabled,
+// messages to it are sent over an encrypted session.
+func (a *Adapter) SetRemoteDID(did string) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.remoteDID = did
+}
+
+// EnableEncryption enables encrypted sessions. Outgoing messages to the
+// remote DID run the SAGE handshake on first contact and are then sent
+// encrypted; servers created by NewServer accept encrypted sessions.
+// resolveKey resolves peer keys by key ID; if nil, the DID resolver is
+// used. A private key is required.
+func (a *Adapter) EnableEncryption(resolveKey KeyResolverFunc) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if a.privateKey == nil {
+		return errors.ErrConfigurationError.
+			WithMessage("encryption requires a private key").
+			WithDetail("field", "PrivateKeyPath")
+	}
+
+	if resolveKey == nil && a.didResolver != nil {
+		resolveKey = a.didResolver.KeyResolver(context.Background())
+	}
+	if resolveKey == nil {
+		return errors.ErrConfigurationError.
+			WithMessage("encryption requires a key resolver or DID resolver")
+	}
+
+	a.transport = NewTransportManager(a.agentDID, a.privateKey, a.transportConfig())
+	a.transport.SetSigningKeyFunc(a.signingKey)
+	if a.sessionStore != nil {
+		a.transport.SetSessionStore(a.sessionStore)
+	}
+	a.resolveKey = resolveKey
+	a.networkClient.EnableEncryption(a.transport, resolveKey)
+	return nil
+}
+
+// transportConfig returns the transport configuration for encrypted
+// sessions, with the rekeying limits from the SAGE configuration.
+func (a *Adapter) transportConfig() *TransportConfig {
+	transportConfig := DefaultTransportConfig()
+	if a.config.RekeyMessages > 0 {
+		transportConfig.Rekey.Messages = a.config.RekeyMessages
+	}
+	if a.config.RekeyBytes > 0 {
+		transportConfig.Rekey.Bytes = a.config.RekeyBytes
+	}
+	if a.config.RekeyInterval > 0 {
+		transportConfig.Rekey.Interval = a.config.RekeyInterval
+	}
+	return transportConfig
+}
+
+// SetSessionStore persists encrypted sessions in store, so that they are
+// resumed rather than re-established after this agent or its peer restarts.
+// See the sessionstore package.
+func (a *Adapter) SetSessionStore(store sessionstore.Store) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	a.sessionStore = store
+	if a.transport != nil {
+		a.transport.SetSessionStore(store)
+	}
+}
+
 // SendMessage sends a message using the SAGE protocol.
 // Adds security metadata, signs the message, and prepares it for transmission.
+// With encryption enabled and a remote DID set, it is sent over an
+// encrypted session.
 func (a *Adapter) SendMessage(ctx context.Context, msg *types.Message) error {
 	a.mu.Lock()
 	defer a.mu.Unlock()
@@ -192,6 +359,14 @@ func (a *Adapter) SendMessage(ctx context.Context, msg *types.Message) error {
 		return nil
 	}
 
+	if a.transport != nil && a.remoteDID != "" {
+		endpoint, remoteDID := a.remoteEndpoint, a.remoteDID
+		a.mu.Unlock()
+		_, err := a.networkClient.SendEncrypted(ctx, endpoint, remoteDID, msg)
+		a.mu.Lock()
+		return err
+	}
+
 	// Unlock before network call to avoid blocking
 	a.mu.Unlock()
 	err := a.networkClient.SendMessage(ctx, a.remoteEndpoint, msg)
@@ -207,29 +382,225 @@ func (a *Adapter) SendMessage(ctx context.Context, msg *types.Message) error {
 	return nil
 }
 
-// ReceiveMessage receives a message using the SAGE protocol.
-// Receives message from transport layer and validates security.
-func (a *Adapter) ReceiveMessage(ctx context.Context) (*types.Message, error) {
+// SetMessageHandler sets the handler that receives verified inbound
+// messages. Without a handler, messages are queued for ReceiveMessage.
+func (a *Adapter) SetMessageHandler(handler MessageHandlerFunc) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.handler = handler
+}
+
+// SetStreamHandler sets the handler that answers verified inbound
+// streaming requests.
+func (a *Adapter) SetStreamHandler(handler StreamHandlerFunc) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.streamHandler = handler
+}
+
+// HandleMessage is the receive path for inbound messages. It verifies the
+// message (signature, nonce, timestamp) and delivers it to the message
+// handler, or queues it for ReceiveMessage if no handler is set.
+func (a *Adapter) HandleMessage(ctx context.Context, msg *types.Message) (*types.Message, error) {
+	if err := a.Verify(ctx, msg); err != nil {
+		return nil, err
+	}
+
 	a.mu.RLock()
-	defer a.mu.RUnlock()
+	handler := a.handler
+	a.mu.RUnlock()
+
+	if handler != nil {
+		return handler(ctx, msg)
+	}
+
+	select {
+	case a.inbox <- msg:
+		return nil, nil
+	default:
+		return nil, errors.ErrRateLimitExceeded.
+			WithMessage("SAGE inbox is full").
+			WithDetail("size", cap(a.inbox))
+	}
+}
+
+// HandleStream is the receive path for inbound streaming requests. It
+// verifies the message and passes it to the stream handler.
+func (a *Adapter) HandleStream(ctx context.Context, msg *types.Message, emit protocol.StreamFunc) error {
+	if err := a.Verify(ctx, msg); err != nil {
+		return err
+	}
+
+	a.mu.RLock()
+	handler := a.streamHandler
+	a.mu.RUnlock()
+
+	if handler == nil {
+		return errors.ErrNotImplemented.WithMessage("no SAGE stream handler configured")
+	}
+	return handler(ctx, msg, emit)
+}
+
+// NewServer creates a NetworkServer whose /sage/message and /sage/stream
+// endpoints feed this adapter's receive path. Request bodies are bounded by
+// sage.max_body_bytes. Streamed chunks are signed with the adapter's key
+// when one is loaded. With encryption enabled, the server also accepts
+// encrypted sessions.
+func (a *Adapter) NewServer(addr string) *NetworkServer {
+	server := NewNetworkServer(addr, a.HandleMessage)
+	server.SetStreamHandler(a.HandleStream)
+	server.SetMaxBodyBytes(a.config.MaxBodyBytes)
+
+	a.mu.RLock()
+	if a.privateKey != nil {
+		server.SetStreamSigningKeyFunc(a.signingKey)
+	}
+	if a.transport != nil {
+		server.EnableEncryption(a.transport, a.resolveKey)
+	}
+	a.mu.RUnlock()
+
+	if a.config.RequireHTTPSignatures {
+		server.SetHTTPVerifier(a.HTTPVerifier())
+	}
 
-	// 1. Receive message from transport layer
-	// TODO: Implement transport layer
-	// For now, return not implemented error
-	return nil, errors.ErrNotImplemented.
-		WithMessage("SAGE transport layer not implemented")
+	return server
+}
 
-	// When transport is implemented, the flow will be:
-	// 1. msg := transport.Receive()
-	// 2. if err := a.Verify(ctx, msg); err != nil { return nil, err }
-	// 3. return msg, nil
+// HTTPSigner returns a signer adding RFC 9421 HTTP message signatures with
+// the adapter's key to outgoing requests. It covers the components and
+// headers set in sage.http_signature_components and
+// sage.http_signature_headers, and the body through Content-Digest.
+func (a *Adapter) HTTPSigner() *httpsig.Signer {
+	return httpsig.NewSigner(httpsig.KeyFunc(a.signingKey), &httpsig.SignerConfig{
+		Components:   a.httpSignatureComponents(),
+		Headers:      a.config.HTTPSignatureHeaders,
+		MaxBodyBytes: a.config.MaxBodyBytes,
+	})
+}
+
+// HTTPVerifier returns a verifier for the signatures HTTPSigner adds. Keys
+// are resolved with the adapter's DID resolver, and nonces are checked
+// against its replay guard. Use its Middleware to protect other HTTP
+// servers, such as the A2A server.
+func (a *Adapter) HTTPVerifier() *httpsig.Verifier {
+	a.mu.RLock()
+	resolver := a.didResolver
+	guard := a.replayGuard
+	a.mu.RUnlock()
+
+	components := append(a.httpSignatureComponents(), a.config.HTTPSignatureHeaders...)
+	resolveKey := httpsig.StaticKeys(nil)
+	if resolver != nil {
+		resolveKey = resolver.HTTPKeyResolver()
+	}
+	return httpsig.NewVerifier(resolveKey, &httpsig.VerifierConfig{
+		Components:   components,
+		MaxAge:       a.maxClockSkew,
+		Guard:        guard,
+		MaxBodyBytes: a.config.MaxBodyBytes,
+	})
+}
+
+// httpSignatureComponents returns the components HTTP signatures cover.
+func (a *Adapter) httpSignatureComponents() []string {
+	components := []string{httpsig.ComponentMethod, httpsig.ComponentTargetURI, httpsig.ComponentAuthority}
+	if len(a.config.HTTPSignatureComponents) > 0 {
+		components = append([]string{}, a.config.HTTPSignatureComponents...)
+	}
+	return append(components, httpsig.ComponentContentDigest)
+}
+
+// AgentServer runs the adapter's network server with the Start(addr) and
+// Stop(ctx) methods expected of agent servers.
+type AgentServer struct {
+	adapter *Adapter
+	tls     *mtls.Config
+	authn   *authn.Authenticator
+	mu      sync.Mutex
+	server  *NetworkServer
+}
+
+// NewAgentServer creates an agent server for the adapter.
+func NewAgentServer(adapter *Adapter) *AgentServer {
+	return &AgentServer{adapter: adapter}
+}
+
+// SetTLS serves HTTPS with the given settings. Call it before Start.
+func (s *AgentServer) SetTLS(config *mtls.Config) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.tls = config
+}
+
+// SetAuthenticator requires an API key or bearer token on the SAGE
+// routes. Call it before Start.
+func (s *AgentServer) SetAuthenticator(authenticator *authn.Authenticator) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.authn = authenticator
+}
+
+// Start serves the adapter on addr. It blocks until the server stops.
+func (s *AgentServer) Start(addr string) error {
+	s.mu.Lock()
+	s.server = s.adapter.NewServer(addr)
+	server := s.server
+	tlsConfig := s.tls
+	if s.authn != nil {
+		server.SetAuthenticator(s.authn)
+	}
+	s.mu.Unlock()
+
+	if tlsConfig.Enabled() {
+		if err := server.EnableTLS(tlsConfig); err != nil {
+			return err
+		}
+	}
+
+	if err := server.Start(); err != nil && err != http.ErrServerClosed {
+		return err
+	}
+	return nil
+}
+
+// Stop gracefully stops the server.
+func (s *AgentServer) Stop(ctx context.Context) error {
+	s.mu.Lock()
+	server := s.server
+	s.mu.Unlock()
+
+	if server == nil {
+		return nil
+	}
+	return server.Stop(ctx)
+}
+
+// ReceiveMessage returns the next verified inbound message queued by
+// HandleMessage. It blocks until a message arrives or ctx is done.
+func (a *Adapter) ReceiveMessage(ctx context.Context) (*types.Message, error) {
+	select {
+	case msg := <-a.inbox:
+		return msg, nil
+	case <-ctx.Done():
+		return nil, errors.ErrTimeout.
+			WithMessage("no SAGE message received").
+			WithDetail("error", ctx.Err().Error())
+	}
 }
 
 // Verify verifies a message according to SAGE protocol.
 // Performs complete security validation including signature, nonce, and timestamp verification.
+// Unsigned messages are rejected with ErrSignatureInvalid.
 func (a *Adapter) Verify(ctx context.Context, msg *types.Message) error {
+	// Resolving keys and checking nonces may go over the network; do not
+	// hold the lock meanwhile, or a waiting ReloadKeyRing blocks readers
 	a.mu.RLock()
-	defer a.mu.RUnlock()
+	resolver := a.didResolver
+	guard := a.replayGuard
+	a.mu.RUnlock()
 
 	// 1. Validate security metadata presence
 	if msg.Security == nil {
@@ -251,75 +622,83 @@ func (a *Adapter) Verify(ctx context.Context, msg *types.Message) error {
 	}
 
 	// 4. Verify timestamp (prevent messages that are too old or too far in future)
-	maxClockSkew := 5 * time.Minute // 5 minutes tolerance
-	if err := a.signingManager.ValidateTimestamp(msg.Security.Timestamp, maxClockSkew); err != nil {
+	if err := a.signingManager.ValidateTimestamp(msg.Security.Timestamp, a.maxClockSkew); err != nil {
 		return errors.ErrInvalidValue.
 			WithMessage("timestamp validation failed").
 			WithDetail("error", err.Error())
 	}
 
-	// 5. Verify nonce (prevent replay attacks)
-	if err := a.nonceCache.Check(msg.Security.Nonce); err != nil {
-		return errors.ErrInvalidValue.
-			WithMessage("nonce validation failed - possible replay attack").
-			WithDetail("nonce", msg.Security.Nonce).
-			WithDetail("error", err.Error())
+	// 5. Verify signature. Unsigned messages are rejected: the signature
+	// binds the message to the DID it claims to be from. Forged messages
+	// are rejected before their nonce is recorded.
+	if resolver == nil {
+		return errors.ErrOperationFailed.
+			WithMessage("cannot verify signature: DID resolver not available")
+	}
+	if err := verifyMessageSignature(a.signingManager, msg, resolver.KeyResolver(ctx)); err != nil {
+		return err
 	}
 
-	// 6. Verify signature if present
-	if msg.Security.Signature != nil {
-		// Resolve DID to get public key
-		if a.didResolver == nil {
-			return errors.ErrOperationFailed.
-				WithMessage("cannot verify signature: DID resolver not available")
-		}
-
-		publicKey, err := a.didResolver.ResolvePublicKey(ctx, msg.Security.AgentDID)
-		if err != nil {
-			return errors.ErrOperationFailed.
-				WithMessage("failed to resolve public key for DID").
-				WithDetail("did", msg.Security.AgentDID).
-				WithDetail("error", err.Error())
-		}
-
-		// Type assert to ed25519.PublicKey
-		ed25519PubKey, ok := publicKey.(ed25519.PublicKey)
-		if !ok {
-			return errors.ErrOperationFailed.
-				WithMessage("public key is not Ed25519 type").
-				WithDetail("did", msg.Security.AgentDID)
-		}
-
-		// Verify signature using legacy method
-		// TODO: Update to use RFC 9421 when message format supports it
-		signatureEnvelope := &SignatureEnvelope{
-			Algorithm: string(msg.Security.Signature.Algorithm),
-			KeyID:     msg.Security.Signature.KeyID,
-			Value:     base64Encode(msg.Security.Signature.Signature),
-		}
-
-		if err := a.signingManager.VerifySignature(msg, signatureEnvelope, ed25519PubKey); err != nil {
-			return errors.ErrSignatureInvalid.
-				WithMessage("signature verification failed").
-				WithDetail("error", err.Error())
-		}
+	// 6. Verify nonce (prevent replay attacks)
+	// A replay fails with ErrReplayDetected; an unreachable shared nonce
+	// store fails closed
+	if err := guard.Check(ctx, msg.Security.Nonce); err != nil {
+		return err
 	}
 
 	return nil
 }
 
-// SupportsStreaming returns false as SAGE streaming is not implemented.
+// SupportsStreaming returns true; SAGE streams responses as signed,
+// hash-chained chunks.
 func (a *Adapter) SupportsStreaming() bool {
-	return false
+	return true
 }
 
-// Stream sends a message and streams the response through the callback.
-// Phase 1: Not implemented.
+// Stream sends the message from the context (see protocol.WithStreamMessage)
+// to the remote agent's streaming endpoint and passes each verified response
+// chunk to fn. Chunk signatures are verified when a DID resolver is
+// available; the digest chain is always verified.
 func (a *Adapter) Stream(ctx context.Context, fn protocol.StreamFunc) error {
-	a.mu.RLock()
-	defer a.mu.RUnlock()
+	msg, ok := protocol.StreamMessageFromContext(ctx)
+	if !ok {
+		return errors.ErrInvalidInput.WithMessage("no message to stream in context")
+	}
+	if fn == nil {
+		return errors.ErrInvalidInput.WithMessage("stream callback is nil")
+	}
+
+	if err := msg.Validate(); err != nil {
+		return errors.ErrInvalidInput.
+			WithMessage("message validation failed").
+			WithDetail("error", err.Error())
+	}
+
+	a.mu.Lock()
+	if err := a.addSecurityMetadata(msg); err != nil {
+		a.mu.Unlock()
+		return err
+	}
+	if err := a.signMessage(msg); err != nil {
+		a.mu.Unlock()
+		return errors.ErrOperationFailed.
+			WithMessage("failed to sign message").
+			WithDetail("error", err.Error())
+	}
+	endpoint := a.remoteEndpoint
+	resolver := a.didResolver
+	a.mu.Unlock()
+
+	if endpoint == "" {
+		return errors.ErrInvalidInput.WithMessage("remote endpoint not configured")
+	}
 
-	return errors.ErrNotImplemented.WithMessage("SAGE streaming not implemented")
+	var resolveKey KeyResolverFunc
+	if resolver != nil {
+		resolveKey = resolver.KeyResolver(ctx)
+	}
+
+	return a.networkClient.StreamMessage(ctx, endpointFor(endpoint, RouteStream), msg, resolveKey, fn)
 }
 
 // Helper functions
@@ -353,18 +732,24 @@ func (a *Adapter) signMessage(msg *types.Message) error {
 		return errors.ErrInvalidInput.WithMessage("security metadata is missing")
 	}
 
-	// Check if we have a private key
-	if a.privateKey == nil {
-		// No private key available - skip signing
-		// This is not an error, signing is optional
+	// Pick the signing key: the key ring's active key, or the loaded key
+	privateKey, keyID, err := a.signingKeyLocked()
+	if err != nil {
+		return err
+	}
+	if privateKey == nil {
+		// No private key available - skip signing. The message can be
+		// prepared, but SAGE receivers reject it unsigned.
 		return nil
 	}
 
-	// Generate key ID from agent DID
-	keyID := a.agentDID + "#key-1"
+	return signMessageWith(a.signingManager, msg, privateKey, keyID)
+}
 
-	// Sign the message using the signing manager
-	signatureEnvelope, err := a.signingManager.SignMessage(msg, a.privateKey, keyID)
+// signMessageWith signs msg, which carries security metadata, with
+// privateKey and records the signature in the metadata.
+func signMessageWith(sm *SigningManager, msg *types.Message, privateKey ed25519.PrivateKey, keyID string) error {
+	signatureEnvelope, err := sm.SignMessage(msg, privateKey, keyID)
 	if err != nil {
 		return errors.ErrOperationFailed.
 			WithMessage("failed to sign message").
@@ -390,6 +775,43 @@ func (a *Adapter) signMessage(msg *types.Message) error {
 	return nil
 }
 
+// verifyMessageSignature checks that msg is signed with a key of the DID
+// in its security metadata. Unsigned messages fail with
+// ErrSignatureInvalid.
+func verifyMessageSignature(sm *SigningManager, msg *types.Message, resolveKey KeyResolverFunc) error {
+	if msg.Security == nil || msg.Security.Signature == nil {
+		return errors.ErrSignatureInvalid.
+			WithMessage("message is not signed")
+	}
+
+	// The key ID selects among the sender's versioned keys, and must
+	// belong to the DID the message claims to be from
+	did := msg.Security.AgentDID
+	keyID := msg.Security.Signature.KeyID
+	if keyID == "" {
+		keyID = did
+	}
+	publicKey, err := peerKey(resolveKey, did, keyID, did)
+	if err != nil {
+		return err
+	}
+
+	// Verify signature using legacy method
+	// TODO: Update to use RFC 9421 when message format supports it
+	signatureEnvelope := &SignatureEnvelope{
+		Algorithm: string(msg.Security.Signature.Algorithm),
+		KeyID:     msg.Security.Signature.KeyID,
+		Value:     base64Encode(msg.Security.Signature.Signature),
+	}
+
+	if err := sm.VerifySignature(msg, signatureEnvelope, publicKey); err != nil {
+		return errors.ErrSignatureInvalid.
+			WithMessage("signature verification failed").
+			WithDetail("error", err.Error())
+	}
+	return nil
+}
+
 // generateSecureNonce generates a cryptographically secure nonce
 func generateSecureNonce() (string, error) {
 	// Use combination of timestamp and random bytes
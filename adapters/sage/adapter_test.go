@@ -20,10 +20,16 @@ package sage
 import (
 	"context"
 	"crypto/ed25519"
+	"crypto/rand"
+	"path/filepath"
 	"testing"
 	"time"
 
+	"github.com/sage-x-project/sage-adk/adapters/sage/didmethod"
+	"github.com/sage-x-project/sage-adk/adapters/sage/replay"
 	"github.com/sage-x-project/sage-adk/config"
+	"github.com/sage-x-project/sage-adk/core/protocol"
+	"github.com/sage-x-project/sage-adk/pkg/errors"
 	"github.com/sage-x-project/sage-adk/pkg/types"
 )
 
@@ -61,6 +67,19 @@ func TestNewAdapter_MissingDID(t *testing.T) {
 	}
 }
 
+func TestNewAdapter_MissingKeyFile(t *testing.T) {
+	cfg := &config.SAGEConfig{
+		Enabled:        true,
+		Network:        "ethereum",
+		DID:            "did:sage:eth:0x1234567890abcdef",
+		PrivateKeyPath: filepath.Join(t.TempDir(), "missing.pem"),
+	}
+
+	if _, err := NewAdapter(cfg); !errors.Is(err, errors.ErrConfigurationError) {
+		t.Errorf("NewAdapter() error = %v, want ErrConfigurationError", err)
+	}
+}
+
 func TestAdapter_Name(t *testing.T) {
 	cfg := &config.SAGEConfig{
 		Enabled: true,
@@ -83,9 +102,8 @@ func TestAdapter_SupportsStreaming(t *testing.T) {
 
 	adapter, _ := NewAdapter(cfg)
 
-	// Streaming not supported in Phase 1
-	if adapter.SupportsStreaming() {
-		t.Error("SupportsStreaming() should return false in Phase 1")
+	if !adapter.SupportsStreaming() {
+		t.Error("SupportsStreaming() should return true")
 	}
 }
 
@@ -114,7 +132,7 @@ func TestAdapter_SendMessage_WithoutEndpoint(t *testing.T) {
 	}
 }
 
-func TestAdapter_ReceiveMessage_NotImplemented(t *testing.T) {
+func TestAdapter_ReceiveMessage_Timeout(t *testing.T) {
 	cfg := &config.SAGEConfig{
 		Enabled: true,
 		Network: "ethereum",
@@ -123,10 +141,66 @@ func TestAdapter_ReceiveMessage_NotImplemented(t *testing.T) {
 
 	adapter, _ := NewAdapter(cfg)
 
-	// ReceiveMessage not implemented in Phase 1
-	_, err := adapter.ReceiveMessage(context.Background())
-	if err == nil {
-		t.Error("ReceiveMessage() should return error (not implemented)")
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	// No message arrives before the deadline
+	_, err := adapter.ReceiveMessage(ctx)
+	if !errors.Is(err, errors.ErrTimeout) {
+		t.Errorf("ReceiveMessage() error = %v, want ErrTimeout", err)
+	}
+}
+
+// newSigningAdapter returns an adapter signing as a did:key DID, which
+// every adapter can resolve.
+func newSigningAdapter(t *testing.T) *Adapter {
+	t.Helper()
+
+	publicKey, privateKey, _ := ed25519.GenerateKey(rand.Reader)
+	adapter, err := NewAdapter(&config.SAGEConfig{Network: "ethereum", DID: didmethod.KeyDID(publicKey)})
+	if err != nil {
+		t.Fatalf("NewAdapter() error = %v", err)
+	}
+	adapter.privateKey = privateKey
+	return adapter
+}
+
+func TestAdapter_HandleMessage_QueuesVerifiedMessages(t *testing.T) {
+	cfg := &config.SAGEConfig{
+		Enabled: true,
+		Network: "ethereum",
+		DID:     "did:sage:eth:0x1234567890abcdef",
+	}
+
+	sender := newSigningAdapter(t)
+	receiver, _ := NewAdapter(cfg)
+
+	msg := types.NewMessage(
+		types.MessageRoleUser,
+		[]types.Part{types.NewTextPart("hello")},
+	)
+	if err := sender.SendMessage(context.Background(), msg); err != nil {
+		t.Fatalf("SendMessage() error = %v", err)
+	}
+
+	if _, err := receiver.HandleMessage(context.Background(), msg); err != nil {
+		t.Fatalf("HandleMessage() error = %v", err)
+	}
+
+	got, err := receiver.ReceiveMessage(context.Background())
+	if err != nil || got.MessageID != msg.MessageID {
+		t.Fatalf("ReceiveMessage() = %v, %v", got, err)
+	}
+
+	// The same message again is a replay
+	if _, err := receiver.HandleMessage(context.Background(), msg); err == nil {
+		t.Error("HandleMessage() should reject a replayed nonce")
+	}
+
+	// Messages without SAGE metadata never reach the agent
+	plain := types.NewMessage(types.MessageRoleUser, []types.Part{types.NewTextPart("x")})
+	if _, err := receiver.HandleMessage(context.Background(), plain); err == nil {
+		t.Error("HandleMessage() should reject unverified messages")
 	}
 }
 
@@ -150,7 +224,26 @@ func TestAdapter_Verify_MissingSecurityMetadata(t *testing.T) {
 	}
 }
 
-func TestAdapter_Stream_NotImplemented(t *testing.T) {
+func TestAdapter_EnableEncryption_RequiresPrivateKey(t *testing.T) {
+	cfg := &config.SAGEConfig{
+		Enabled: true,
+		Network: "ethereum",
+		DID:     "did:sage:eth:0x1234567890abcdef",
+	}
+
+	adapter, _ := NewAdapter(cfg)
+
+	resolveKey := func(keyID string) (ed25519.PublicKey, error) {
+		return nil, nil
+	}
+
+	err := adapter.EnableEncryption(resolveKey)
+	if !errors.Is(err, errors.ErrConfigurationError) {
+		t.Errorf("EnableEncryption() error = %v, want ErrConfigurationError", err)
+	}
+}
+
+func TestAdapter_Stream_NoMessage(t *testing.T) {
 	cfg := &config.SAGEConfig{
 		Enabled: true,
 		Network: "ethereum",
@@ -163,10 +256,10 @@ func TestAdapter_Stream_NotImplemented(t *testing.T) {
 		return nil
 	}
 
-	// Streaming not implemented in Phase 1
+	// Stream needs the message in the context
 	err := adapter.Stream(context.Background(), fn)
 	if err == nil {
-		t.Error("Stream() should return error (not implemented)")
+		t.Error("Stream() should return error without a message")
 	}
 }
 
@@ -296,7 +389,47 @@ func TestAdapter_Verify_ReplayAttack(t *testing.T) {
 	}
 }
 
-func TestAdapter_Verify_ValidSecurityMetadata(t *testing.T) {
+func TestAdapter_Verify_SharedReplayGuard(t *testing.T) {
+	cfg := &config.SAGEConfig{
+		Enabled: true,
+		Network: "ethereum",
+		DID:     "did:sage:eth:0x1234567890abcdef",
+	}
+
+	// Two replicas behind a load balancer share one guard
+	guard := replay.NewMemory(replay.Window(replay.DefaultMaxClockSkew))
+	replicaA, _ := NewAdapter(cfg)
+	replicaB, _ := NewAdapter(cfg)
+	replicaA.SetReplayGuard(guard)
+	replicaB.SetReplayGuard(guard)
+
+	msg := types.NewMessage(types.MessageRoleUser, []types.Part{types.NewTextPart("test")})
+	if err := newSigningAdapter(t).SendMessage(context.Background(), msg); err != nil {
+		t.Fatalf("SendMessage() error = %v", err)
+	}
+
+	if err := replicaA.Verify(context.Background(), msg); err != nil {
+		t.Fatalf("Verify() error = %v", err)
+	}
+	if err := replicaB.Verify(context.Background(), msg); !errors.Is(err, errors.ErrReplayDetected) {
+		t.Errorf("Verify() on other replica error = %v, want ErrReplayDetected", err)
+	}
+}
+
+func TestNewAdapter_NonceStore(t *testing.T) {
+	cfg := &config.SAGEConfig{
+		Enabled:    true,
+		Network:    "ethereum",
+		DID:        "did:sage:eth:0x1234567890abcdef",
+		NonceStore: "memcached://localhost",
+	}
+
+	if _, err := NewAdapter(cfg); !errors.Is(err, errors.ErrConfigurationError) {
+		t.Errorf("NewAdapter() unsupported nonce store error = %v, want ErrConfigurationError", err)
+	}
+}
+
+func TestAdapter_Verify_UnsignedMessage(t *testing.T) {
 	cfg := &config.SAGEConfig{
 		Enabled: true,
 		Network: "ethereum",
@@ -309,18 +442,42 @@ func TestAdapter_Verify_ValidSecurityMetadata(t *testing.T) {
 		[]types.Part{types.NewTextPart("test")},
 	)
 
-	// Set valid security metadata (no signature)
+	// Valid security metadata without a signature could claim any DID
 	msg.Security = &types.SecurityMetadata{
 		Mode:      types.ProtocolModeSAGE,
 		AgentDID:  "did:sage:eth:0xABC",
 		Nonce:     "test_nonce_valid",
 		Timestamp: time.Now(),
-		Signature: nil, // No signature to verify
+		Signature: nil,
 	}
 
 	err := adapter.Verify(context.Background(), msg)
-	if err != nil {
-		t.Errorf("Verify() should succeed with valid security metadata: %v", err)
+	if !errors.Is(err, errors.ErrSignatureInvalid) {
+		t.Errorf("Verify() error = %v, want ErrSignatureInvalid", err)
+	}
+}
+
+func TestAdapter_HandleStream_RejectsUnsignedMessages(t *testing.T) {
+	cfg := &config.SAGEConfig{
+		Enabled: true,
+		Network: "ethereum",
+		DID:     "did:sage:eth:0x1234567890abcdef",
+	}
+
+	adapter, _ := NewAdapter(cfg)
+	adapter.SetStreamHandler(func(ctx context.Context, msg *types.Message, emit protocol.StreamFunc) error {
+		t.Error("stream handler called for an unsigned message")
+		return nil
+	})
+
+	// An adapter without a key sends unsigned messages
+	unsigned, _ := NewAdapter(&config.SAGEConfig{Network: "ethereum", DID: "did:sage:eth:0xABC"})
+	msg := types.NewMessage(types.MessageRoleUser, []types.Part{types.NewTextPart("test")})
+	unsigned.SendMessage(context.Background(), msg)
+
+	emit := func(chunk string) error { return nil }
+	if err := adapter.HandleStream(context.Background(), msg, emit); !errors.Is(err, errors.ErrSignatureInvalid) {
+		t.Errorf("HandleStream() error = %v, want ErrSignatureInvalid", err)
 	}
 }
 
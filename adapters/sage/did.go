@@ -19,8 +19,12 @@ package sage
 
 import (
 	"context"
+	"crypto/ecdh"
+	"crypto/ed25519"
 	"fmt"
 
+	"github.com/sage-x-project/sage-adk/adapters/sage/didmethod"
+	"github.com/sage-x-project/sage-adk/adapters/sage/httpsig"
 	"github.com/sage-x-project/sage/did"
 	"github.com/sage-x-project/sage/did/ethereum"
 )
@@ -217,6 +221,97 @@ func (r *DIDResolver) ResolvePublicKey(ctx context.Context, agentDID string) (in
 	return publicKey, nil
 }
 
+// ResolveKEMKey resolves a DID to the X25519 key that data encrypted for
+// the agent is sealed to. DIDs that publish no key agreement key use the
+// key derived from their Ed25519 public key.
+func (r *DIDResolver) ResolveKEMKey(ctx context.Context, agentDID string) (*ecdh.PublicKey, error) {
+	if agentDID == "" {
+		return nil, fmt.Errorf("DID cannot be empty")
+	}
+
+	if kemKey, err := r.resolver.ResolveKEMKey(ctx, did.AgentDID(agentDID)); err == nil {
+		if key, err := x25519Key(kemKey); err == nil {
+			return key, nil
+		}
+	}
+
+	publicKey, err := r.ResolvePublicKey(ctx, agentDID)
+	if err != nil {
+		return nil, err
+	}
+	key, err := x25519Key(publicKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve key agreement key for DID %s: %w", agentDID, err)
+	}
+	return key, nil
+}
+
+// x25519Key converts a resolved key to an X25519 public key.
+func x25519Key(key interface{}) (*ecdh.PublicKey, error) {
+	switch key := key.(type) {
+	case *ecdh.PublicKey:
+		if key.Curve() == ecdh.X25519() {
+			return key, nil
+		}
+	case ed25519.PublicKey:
+		return didmethod.X25519PublicKey(key)
+	case []byte:
+		return ecdh.X25519().NewPublicKey(key)
+	}
+	return nil, fmt.Errorf("unsupported key agreement key type %T", key)
+}
+
+// keyIDResolver is implemented by resolvers that publish several versioned
+// keys per DID and select one by key ID ("did#key-2").
+type keyIDResolver interface {
+	ResolveKeyID(ctx context.Context, keyID string) (interface{}, error)
+}
+
+// ResolveKeyID resolves the public key a signature's key ID refers to.
+// Resolvers that keep a single key per DID return that key for any key ID
+// of the DID.
+func (r *DIDResolver) ResolveKeyID(ctx context.Context, keyID string) (interface{}, error) {
+	if keyID == "" {
+		return nil, fmt.Errorf("key ID cannot be empty")
+	}
+
+	resolver, ok := r.resolver.(keyIDResolver)
+	if !ok {
+		return r.ResolvePublicKey(ctx, didFromKeyID(keyID))
+	}
+
+	publicKey, err := resolver.ResolveKeyID(ctx, keyID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve public key for key ID %s: %w", keyID, err)
+	}
+
+	return publicKey, nil
+}
+
+// KeyResolver returns a resolver for the Ed25519 keys that message,
+// handshake and stream signatures name by key ID ("did#fragment").
+func (r *DIDResolver) KeyResolver(ctx context.Context) KeyResolverFunc {
+	return func(keyID string) (ed25519.PublicKey, error) {
+		publicKey, err := r.ResolveKeyID(ctx, keyID)
+		if err != nil {
+			return nil, err
+		}
+
+		key, ok := publicKey.(ed25519.PublicKey)
+		if !ok {
+			return nil, fmt.Errorf("public key of %s is not Ed25519", keyID)
+		}
+		return key, nil
+	}
+}
+
+// HTTPKeyResolver returns a resolver for the key IDs of HTTP message
+// signatures (see the httpsig package), so that requests signed by any DID
+// this resolver knows can be verified.
+func (r *DIDResolver) HTTPKeyResolver() httpsig.KeyResolver {
+	return httpsig.KeyResolver(r.KeyResolver(context.Background()))
+}
+
 // VerifyMetadata verifies that the provided metadata matches the on-chain data.
 func (r *DIDResolver) VerifyMetadata(ctx context.Context, agentDID string, metadata *did.AgentMetadata) (*did.VerificationResult, error) {
 	if agentDID == "" {
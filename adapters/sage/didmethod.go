@@ -0,0 +1,229 @@
+// Copyright (C) 2025 sage-x-project
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with this program. If not, see <https://www.gnu.org/licenses/>.
+
+// SPDX-License-Identifier: LGPL-3.0-or-later
+
+package sage
+
+import (
+	"context"
+	"crypto/ed25519"
+	"fmt"
+	"time"
+
+	"github.com/sage-x-project/sage-adk/adapters/sage/didmethod"
+	"github.com/sage-x-project/sage-adk/pkg/errors"
+	"github.com/sage-x-project/sage/did"
+)
+
+// ServiceTypeSAGE is the DID document service type of a SAGE agent
+// endpoint.
+const ServiceTypeSAGE = "SAGEAgent"
+
+// methodResolver resolves did:key and did:web identifiers and passes every
+// other DID to the next resolver, if any.
+type methodResolver struct {
+	web  *didmethod.WebResolver
+	next did.Resolver
+}
+
+// NewMethodDIDResolver creates a DID resolver that understands did:key and
+// did:web in addition to the DIDs of next, which may be nil. A nil web
+// resolver uses didmethod.NewWebResolver defaults.
+func NewMethodDIDResolver(next *DIDResolver, web *didmethod.WebResolver) *DIDResolver {
+	if web == nil {
+		web = didmethod.NewWebResolver(nil, 0)
+	}
+
+	resolver := &methodResolver{web: web}
+	var cfg *Config
+	if next != nil {
+		resolver.next, cfg = next.resolver, next.config
+	}
+	return &DIDResolver{resolver: resolver, config: cfg}
+}
+
+// document resolves a did:key or did:web identifier.
+func (r *methodResolver) document(ctx context.Context, agentDID did.AgentDID) (*didmethod.Document, error) {
+	var (
+		doc *didmethod.Document
+		err error
+	)
+	switch didmethod.Method(string(agentDID)) {
+	case didmethod.MethodKey:
+		doc, err = didmethod.ResolveKey(string(agentDID))
+	case didmethod.MethodWeb:
+		doc, err = r.web.Resolve(ctx, string(agentDID))
+	}
+	if errors.Is(err, errors.ErrDIDNotFound) {
+		return nil, fmt.Errorf("%w: %s", did.ErrDIDNotFound, agentDID)
+	}
+	return doc, err
+}
+
+// delegate returns the next resolver for DIDs of other methods.
+func (r *methodResolver) delegate(agentDID did.AgentDID) (did.Resolver, error) {
+	if r.next == nil {
+		return nil, fmt.Errorf("%w: no resolver for DID method of %s", did.ErrDIDNotFound, agentDID)
+	}
+	return r.next, nil
+}
+
+func (r *methodResolver) Resolve(ctx context.Context, agentDID did.AgentDID) (*did.AgentMetadata, error) {
+	if !didmethod.Supported(string(agentDID)) {
+		next, err := r.delegate(agentDID)
+		if err != nil {
+			return nil, err
+		}
+		return next.Resolve(ctx, agentDID)
+	}
+
+	doc, err := r.document(ctx, agentDID)
+	if err != nil {
+		return nil, err
+	}
+	return documentMetadata(doc), nil
+}
+
+func (r *methodResolver) ResolvePublicKey(ctx context.Context, agentDID did.AgentDID) (interface{}, error) {
+	if !didmethod.Supported(string(agentDID)) {
+		next, err := r.delegate(agentDID)
+		if err != nil {
+			return nil, err
+		}
+		return next.ResolvePublicKey(ctx, agentDID)
+	}
+
+	doc, err := r.document(ctx, agentDID)
+	if err != nil {
+		return nil, err
+	}
+	return doc.PublicKey()
+}
+
+// ResolveKeyID selects a verification method of a did:key or did:web
+// document by key ID. did:key has a single key, which any key ID of the
+// DID refers to.
+func (r *methodResolver) ResolveKeyID(ctx context.Context, keyID string) (interface{}, error) {
+	agentDID := did.AgentDID(didFromKeyID(keyID))
+	if !didmethod.Supported(string(agentDID)) {
+		next, err := r.delegate(agentDID)
+		if err != nil {
+			return nil, err
+		}
+		if resolver, ok := next.(keyIDResolver); ok {
+			return resolver.ResolveKeyID(ctx, keyID)
+		}
+		return next.ResolvePublicKey(ctx, agentDID)
+	}
+
+	doc, err := r.document(ctx, agentDID)
+	if err != nil {
+		return nil, err
+	}
+	if keyID == string(agentDID) || didmethod.Method(keyID) == didmethod.MethodKey {
+		return doc.PublicKey()
+	}
+
+	method, err := doc.Method(keyID)
+	if err != nil {
+		return nil, err
+	}
+	return method.Ed25519PublicKey()
+}
+
+func (r *methodResolver) ResolveKEMKey(ctx context.Context, agentDID did.AgentDID) (interface{}, error) {
+	if !didmethod.Supported(string(agentDID)) {
+		next, err := r.delegate(agentDID)
+		if err != nil {
+			return nil, err
+		}
+		return next.ResolveKEMKey(ctx, agentDID)
+	}
+
+	doc, err := r.document(ctx, agentDID)
+	if err != nil {
+		return nil, err
+	}
+	return doc.KeyAgreementKey()
+}
+
+func (r *methodResolver) VerifyMetadata(ctx context.Context, agentDID did.AgentDID, metadata *did.AgentMetadata) (*did.VerificationResult, error) {
+	if !didmethod.Supported(string(agentDID)) {
+		next, err := r.delegate(agentDID)
+		if err != nil {
+			return nil, err
+		}
+		return next.VerifyMetadata(ctx, agentDID, metadata)
+	}
+
+	doc, err := r.document(ctx, agentDID)
+	if err != nil {
+		return nil, err
+	}
+	resolved := documentMetadata(doc)
+
+	result := &did.VerificationResult{
+		Agent:      resolved,
+		VerifiedAt: time.Now(),
+	}
+
+	publicKey, _ := metadata.PublicKey.(ed25519.PublicKey)
+	resolvedKey, _ := resolved.PublicKey.(ed25519.PublicKey)
+	switch {
+	case resolvedKey == nil:
+		result.Error = "DID document has no verification key"
+	case !publicKey.Equal(resolvedKey):
+		result.Error = "public key mismatch"
+	case metadata.Endpoint != "" && metadata.Endpoint != resolved.Endpoint:
+		result.Error = "endpoint mismatch"
+	}
+	result.Valid = result.Error == ""
+
+	return result, nil
+}
+
+// ListAgentsByOwner and Search only cover DIDs of the next resolver:
+// did:key and did:web identifiers are not enumerable.
+func (r *methodResolver) ListAgentsByOwner(ctx context.Context, ownerAddress string) ([]*did.AgentMetadata, error) {
+	if r.next == nil {
+		return []*did.AgentMetadata{}, nil
+	}
+	return r.next.ListAgentsByOwner(ctx, ownerAddress)
+}
+
+func (r *methodResolver) Search(ctx context.Context, criteria did.SearchCriteria) ([]*did.AgentMetadata, error) {
+	if r.next == nil {
+		return []*did.AgentMetadata{}, nil
+	}
+	return r.next.Search(ctx, criteria)
+}
+
+// documentMetadata converts a DID document to DID metadata. Documents carry
+// no activation state, so resolvable DIDs are active.
+func documentMetadata(doc *didmethod.Document) *did.AgentMetadata {
+	metadata := &did.AgentMetadata{
+		DID:      did.AgentDID(doc.ID),
+		Endpoint: doc.ServiceEndpoint(ServiceTypeSAGE),
+		IsActive: true,
+	}
+	if metadata.Endpoint == "" {
+		metadata.Endpoint = doc.ServiceEndpoint()
+	}
+	if publicKey, err := doc.PublicKey(); err == nil {
+		metadata.PublicKey = publicKey
+	}
+	return metadata
+}
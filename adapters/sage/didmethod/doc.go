@@ -0,0 +1,45 @@
+// Copyright (C) 2025 sage-x-project
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with this program. If not, see <https://www.gnu.org/licenses/>.
+
+// SPDX-License-Identifier: LGPL-3.0-or-later
+// Package didmethod resolves did:key and did:web identifiers to DID
+// documents.
+//
+// Agents without an on-chain registration can still take part in signed
+// SAGE exchanges by identifying themselves with one of these methods.
+//
+// # did:key
+//
+// A did:key is self-certifying: the identifier is the multibase-encoded
+// public key, so resolution is local. Ed25519 keys ("did:key:z6Mk...")
+// verify signatures, and their X25519 equivalent is derived for key
+// agreement; X25519 keys ("did:key:z6LS...") are for key agreement only.
+//
+//	did := didmethod.KeyDID(publicKey)
+//	doc, err := didmethod.ResolveKey(did)
+//	key, err := doc.PublicKey()
+//
+// # did:web
+//
+// A did:web names an HTTPS location whose did.json holds the document
+// (see WebURL). WebResolver fetches and caches documents, following
+// redirects only to HTTPS URLs on the same host:
+//
+//	resolver := didmethod.NewWebResolver(nil, 10*time.Minute)
+//	doc, err := resolver.Resolve(ctx, "did:web:agents.example.com:bob")
+//
+// The SAGE adapter resolves both methods automatically; see
+// sage.NewMethodDIDResolver.
+package didmethod
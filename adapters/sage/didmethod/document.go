@@ -0,0 +1,282 @@
+// Copyright (C) 2025 sage-x-project
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with this program. If not, see <https://www.gnu.org/licenses/>.
+
+// SPDX-License-Identifier: LGPL-3.0-or-later
+
+package didmethod
+
+import (
+	"crypto/ecdh"
+	"crypto/ed25519"
+	"encoding/base64"
+	"encoding/json"
+	"strings"
+
+	"github.com/sage-x-project/sage-adk/pkg/errors"
+)
+
+// Verification method types.
+const (
+	TypeEd25519VerificationKey2018 = "Ed25519VerificationKey2018"
+	TypeEd25519VerificationKey2020 = "Ed25519VerificationKey2020"
+	TypeX25519KeyAgreementKey2019  = "X25519KeyAgreementKey2019"
+	TypeX25519KeyAgreementKey2020  = "X25519KeyAgreementKey2020"
+	TypeMultikey                   = "Multikey"
+	TypeJSONWebKey2020             = "JsonWebKey2020"
+)
+
+// Document is a DID document, as defined by DID Core.
+//
+// Only the properties needed to find keys and endpoints are modeled.
+type Document struct {
+	Context            interface{}          `json:"@context,omitempty"`
+	ID                 string               `json:"id"`
+	AlsoKnownAs        []string             `json:"alsoKnownAs,omitempty"`
+	VerificationMethod []VerificationMethod `json:"verificationMethod,omitempty"`
+	Authentication     []Reference          `json:"authentication,omitempty"`
+	AssertionMethod    []Reference          `json:"assertionMethod,omitempty"`
+	KeyAgreement       []Reference          `json:"keyAgreement,omitempty"`
+	Service            []Service            `json:"service,omitempty"`
+}
+
+// VerificationMethod is a public key in a DID document.
+type VerificationMethod struct {
+	ID                 string `json:"id"`
+	Type               string `json:"type"`
+	Controller         string `json:"controller,omitempty"`
+	PublicKeyMultibase string `json:"publicKeyMultibase,omitempty"`
+	PublicKeyBase58    string `json:"publicKeyBase58,omitempty"`
+	PublicKeyJwk       *JWK   `json:"publicKeyJwk,omitempty"`
+}
+
+// JWK is an OKP JSON Web Key.
+type JWK struct {
+	Kty string `json:"kty"`
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+}
+
+// Reference is a verification relationship entry: either the ID of a
+// verification method or an embedded one.
+type Reference struct {
+	ID       string
+	Embedded *VerificationMethod
+}
+
+// UnmarshalJSON accepts a string ID or an embedded verification method.
+func (r *Reference) UnmarshalJSON(data []byte) error {
+	if len(data) > 0 && data[0] == '"' {
+		return json.Unmarshal(data, &r.ID)
+	}
+
+	var method VerificationMethod
+	if err := json.Unmarshal(data, &method); err != nil {
+		return err
+	}
+	r.ID, r.Embedded = method.ID, &method
+	return nil
+}
+
+// MarshalJSON writes the embedded method, or the ID if there is none.
+func (r Reference) MarshalJSON() ([]byte, error) {
+	if r.Embedded != nil {
+		return json.Marshal(r.Embedded)
+	}
+	return json.Marshal(r.ID)
+}
+
+// Service is a service endpoint in a DID document.
+type Service struct {
+	ID              string      `json:"id"`
+	Type            string      `json:"type"`
+	ServiceEndpoint interface{} `json:"serviceEndpoint"`
+}
+
+// Endpoint returns the service endpoint if it is a single URL.
+func (s *Service) Endpoint() string {
+	endpoint, _ := s.ServiceEndpoint.(string)
+	return endpoint
+}
+
+// Method returns the verification method with the given ID, which may be
+// relative to the document ("#key-1").
+func (d *Document) Method(id string) (*VerificationMethod, error) {
+	id = d.absolute(id)
+	for i := range d.VerificationMethod {
+		if d.absolute(d.VerificationMethod[i].ID) == id {
+			return &d.VerificationMethod[i], nil
+		}
+	}
+	for _, refs := range [][]Reference{d.Authentication, d.AssertionMethod, d.KeyAgreement} {
+		for _, ref := range refs {
+			if ref.Embedded != nil && d.absolute(ref.ID) == id {
+				return ref.Embedded, nil
+			}
+		}
+	}
+	return nil, errors.ErrNotFound.
+		WithMessage("verification method not found").
+		WithDetail("id", id)
+}
+
+// PublicKey returns the Ed25519 key used to verify the subject's
+// signatures: the first one referenced for authentication or assertion,
+// or else the first Ed25519 verification method.
+func (d *Document) PublicKey() (ed25519.PublicKey, error) {
+	for _, method := range d.related(d.Authentication, d.AssertionMethod) {
+		if key, err := method.Ed25519PublicKey(); err == nil {
+			return key, nil
+		}
+	}
+	for i := range d.VerificationMethod {
+		if key, err := d.VerificationMethod[i].Ed25519PublicKey(); err == nil {
+			return key, nil
+		}
+	}
+	return nil, errors.ErrNotFound.
+		WithMessage("DID document has no Ed25519 verification key").
+		WithDetail("did", d.ID)
+}
+
+// KeyAgreementKey returns the first X25519 key agreement key.
+func (d *Document) KeyAgreementKey() (*ecdh.PublicKey, error) {
+	for _, method := range d.related(d.KeyAgreement) {
+		if key, err := method.X25519PublicKey(); err == nil {
+			return key, nil
+		}
+	}
+	return nil, errors.ErrNotFound.
+		WithMessage("DID document has no X25519 key agreement key").
+		WithDetail("did", d.ID)
+}
+
+// ServiceEndpoint returns the endpoint of the first service of one of the
+// given types, or of the first service if no types are given.
+func (d *Document) ServiceEndpoint(types ...string) string {
+	for i := range d.Service {
+		if len(types) == 0 {
+			return d.Service[i].Endpoint()
+		}
+		for _, t := range types {
+			if d.Service[i].Type == t {
+				return d.Service[i].Endpoint()
+			}
+		}
+	}
+	return ""
+}
+
+// related resolves verification relationship references to methods,
+// skipping dangling references.
+func (d *Document) related(relationships ...[]Reference) []*VerificationMethod {
+	var methods []*VerificationMethod
+	for _, refs := range relationships {
+		for _, ref := range refs {
+			if ref.Embedded != nil {
+				methods = append(methods, ref.Embedded)
+			} else if method, err := d.Method(ref.ID); err == nil {
+				methods = append(methods, method)
+			}
+		}
+	}
+	return methods
+}
+
+// absolute resolves a fragment-only ID against the document ID.
+func (d *Document) absolute(id string) string {
+	if strings.HasPrefix(id, "#") {
+		return d.ID + id
+	}
+	return id
+}
+
+// Ed25519PublicKey decodes the method's key as an Ed25519 public key.
+func (m *VerificationMethod) Ed25519PublicKey() (ed25519.PublicKey, error) {
+	key, err := m.rawKey(ed25519Codec, "Ed25519", TypeEd25519VerificationKey2018, TypeEd25519VerificationKey2020)
+	if err != nil {
+		return nil, err
+	}
+	return ed25519.PublicKey(key), nil
+}
+
+// X25519PublicKey decodes the method's key as an X25519 public key.
+func (m *VerificationMethod) X25519PublicKey() (*ecdh.PublicKey, error) {
+	key, err := m.rawKey(x25519Codec, "X25519", TypeX25519KeyAgreementKey2019, TypeX25519KeyAgreementKey2020)
+	if err != nil {
+		return nil, err
+	}
+	return ecdh.X25519().NewPublicKey(key)
+}
+
+// rawKey extracts the 32-byte key of the given curve from whichever key
+// property the method uses.
+func (m *VerificationMethod) rawKey(codec []byte, curve string, types ...string) ([]byte, error) {
+	mismatch := errors.ErrInvalidFormat.
+		WithMessage("verification method is not an "+curve+" key").
+		WithDetail("id", m.ID).
+		WithDetail("type", m.Type)
+
+	switch {
+	case m.PublicKeyMultibase != "":
+		prefix, key, err := decodeMulticodecKey(m.PublicKeyMultibase)
+		if err != nil {
+			return nil, err
+		}
+		if string(prefix) != string(codec) {
+			return nil, mismatch
+		}
+		return key, nil
+
+	case m.PublicKeyBase58 != "":
+		if !contains(types, m.Type) {
+			return nil, mismatch
+		}
+		key, err := decodeBase58(m.PublicKeyBase58)
+		if err != nil {
+			return nil, err
+		}
+		if len(key) != 32 {
+			return nil, errors.ErrInvalidFormat.
+				WithMessage("unexpected key length").
+				WithDetail("id", m.ID)
+		}
+		return key, nil
+
+	case m.PublicKeyJwk != nil:
+		if m.PublicKeyJwk.Kty != "OKP" || m.PublicKeyJwk.Crv != curve {
+			return nil, mismatch
+		}
+		key, err := base64.RawURLEncoding.DecodeString(m.PublicKeyJwk.X)
+		if err != nil || len(key) != 32 {
+			return nil, errors.ErrInvalidFormat.
+				WithMessage("invalid JWK x coordinate").
+				WithDetail("id", m.ID)
+		}
+		return key, nil
+	}
+
+	return nil, errors.ErrInvalidFormat.
+		WithMessage("verification method has no supported key property").
+		WithDetail("id", m.ID)
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
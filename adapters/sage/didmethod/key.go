@@ -0,0 +1,160 @@
+// Copyright (C) 2025 sage-x-project
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with this program. If not, see <https://www.gnu.org/licenses/>.
+
+// SPDX-License-Identifier: LGPL-3.0-or-later
+
+package didmethod
+
+import (
+	"crypto/ecdh"
+	"crypto/ed25519"
+	"crypto/sha512"
+	"strings"
+
+	"github.com/sage-x-project/sage-adk/pkg/errors"
+)
+
+// Supported DID methods.
+const (
+	MethodKey = "key"
+	MethodWeb = "web"
+)
+
+// Method returns the method name of a DID ("key" for "did:key:..."), or an
+// empty string if did is not a DID.
+func Method(did string) string {
+	parts := strings.SplitN(did, ":", 3)
+	if len(parts) != 3 || parts[0] != "did" || parts[2] == "" {
+		return ""
+	}
+	return parts[1]
+}
+
+// Supported returns true if did uses a method this package resolves.
+func Supported(did string) bool {
+	switch Method(did) {
+	case MethodKey, MethodWeb:
+		return true
+	}
+	return false
+}
+
+// KeyDID returns the did:key identifier of an Ed25519 public key.
+func KeyDID(publicKey ed25519.PublicKey) string {
+	return "did:key:" + encodeMultibase(append(append([]byte{}, ed25519Codec...), publicKey...))
+}
+
+// X25519KeyDID returns the did:key identifier of an X25519 public key.
+// Such a DID can be used for key agreement only.
+func X25519KeyDID(publicKey *ecdh.PublicKey) string {
+	return "did:key:" + encodeMultibase(append(append([]byte{}, x25519Codec...), publicKey.Bytes()...))
+}
+
+// X25519PublicKey returns the X25519 key agreement key derived from an
+// Ed25519 public key, the key a did:key document publishes for it.
+func X25519PublicKey(publicKey ed25519.PublicKey) (*ecdh.PublicKey, error) {
+	key, err := ed25519ToX25519(publicKey)
+	if err != nil {
+		return nil, err
+	}
+	return ecdh.X25519().NewPublicKey(key)
+}
+
+// X25519PrivateKey returns the X25519 private key matching
+// X25519PublicKey of the key's public half, so an agent can open what was
+// encrypted for its Ed25519 identity.
+func X25519PrivateKey(privateKey ed25519.PrivateKey) (*ecdh.PrivateKey, error) {
+	if len(privateKey) != ed25519.PrivateKeySize {
+		return nil, errors.ErrInvalidFormat.WithMessage("invalid Ed25519 private key length")
+	}
+
+	// The Ed25519 scalar is the first half of the hashed seed; X25519
+	// clamps it the same way.
+	digest := sha512.Sum512(privateKey.Seed())
+	return ecdh.X25519().NewPrivateKey(digest[:32])
+}
+
+// ResolveKey expands a did:key identifier into its DID document.
+//
+// did:key is self-certifying: the document is derived from the key encoded
+// in the identifier, so resolution needs no network access. An Ed25519 key
+// yields a verification method for authentication and assertion plus the
+// derived X25519 key for key agreement; an X25519 key yields only the key
+// agreement method.
+func ResolveKey(did string) (*Document, error) {
+	if Method(did) != MethodKey {
+		return nil, errors.ErrInvalidFormat.
+			WithMessage("not a did:key identifier").
+			WithDetail("did", did)
+	}
+
+	id := strings.TrimPrefix(did, "did:key:")
+	codec, key, err := decodeMulticodecKey(id)
+	if err != nil {
+		return nil, errors.ErrInvalidFormat.
+			WithMessage("invalid did:key identifier").
+			WithDetail("did", did).
+			WithDetail("error", err.Error())
+	}
+
+	doc := &Document{
+		Context: []string{
+			"https://www.w3.org/ns/did/v1",
+			"https://w3id.org/security/suites/ed25519-2020/v1",
+			"https://w3id.org/security/suites/x25519-2020/v1",
+		},
+		ID: did,
+	}
+
+	switch string(codec) {
+	case string(ed25519Codec):
+		method := VerificationMethod{
+			ID:                 did + "#" + id,
+			Type:               TypeEd25519VerificationKey2020,
+			Controller:         did,
+			PublicKeyMultibase: id,
+		}
+		doc.VerificationMethod = []VerificationMethod{method}
+		doc.Authentication = []Reference{{ID: method.ID}}
+		doc.AssertionMethod = []Reference{{ID: method.ID}}
+
+		if agreementKey, err := ed25519ToX25519(key); err == nil {
+			multibase := encodeMultibase(append(append([]byte{}, x25519Codec...), agreementKey...))
+			doc.KeyAgreement = []Reference{{ID: did + "#" + multibase, Embedded: &VerificationMethod{
+				ID:                 did + "#" + multibase,
+				Type:               TypeX25519KeyAgreementKey2020,
+				Controller:         did,
+				PublicKeyMultibase: multibase,
+			}}}
+		}
+
+	case string(x25519Codec):
+		method := VerificationMethod{
+			ID:                 did + "#" + id,
+			Type:               TypeX25519KeyAgreementKey2020,
+			Controller:         did,
+			PublicKeyMultibase: id,
+		}
+		doc.VerificationMethod = []VerificationMethod{method}
+		doc.KeyAgreement = []Reference{{ID: method.ID}}
+
+	default:
+		return nil, errors.ErrInvalidFormat.
+			WithMessage("unsupported did:key key type").
+			WithDetail("did", did)
+	}
+
+	return doc, nil
+}
@@ -0,0 +1,157 @@
+// Copyright (C) 2025 sage-x-project
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with this program. If not, see <https://www.gnu.org/licenses/>.
+
+// SPDX-License-Identifier: LGPL-3.0-or-later
+
+package didmethod
+
+import (
+	"crypto/ecdh"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestResolveKey_Vector(t *testing.T) {
+	// Example from the did:key method specification.
+	const did = "did:key:z6MkiTBz1ymuepAQ4HEHYSF1H8quG5GLVVQR3djdX3mDooWp"
+
+	doc, err := ResolveKey(did)
+	if err != nil {
+		t.Fatalf("ResolveKey() error = %v", err)
+	}
+
+	if got := doc.VerificationMethod[0].ID; got != did+"#z6MkiTBz1ymuepAQ4HEHYSF1H8quG5GLVVQR3djdX3mDooWp" {
+		t.Errorf("verification method id = %s", got)
+	}
+	if got := doc.KeyAgreement[0].ID; got != did+"#z6LShs9GGnqk85isEBzzshkuVWrVKsRp24GnDuHk8QWkARMW" {
+		t.Errorf("key agreement id = %s", got)
+	}
+
+	key, err := doc.PublicKey()
+	if err != nil {
+		t.Fatalf("PublicKey() error = %v", err)
+	}
+	if KeyDID(key) != did {
+		t.Errorf("KeyDID() = %s, want %s", KeyDID(key), did)
+	}
+}
+
+func TestResolveKey_Ed25519(t *testing.T) {
+	publicKey, privateKey, _ := ed25519.GenerateKey(rand.Reader)
+	did := KeyDID(publicKey)
+	if !strings.HasPrefix(did, "did:key:z6Mk") {
+		t.Fatalf("KeyDID() = %s, want did:key:z6Mk prefix", did)
+	}
+
+	doc, err := ResolveKey(did)
+	if err != nil {
+		t.Fatalf("ResolveKey() error = %v", err)
+	}
+
+	key, err := doc.PublicKey()
+	if err != nil || !key.Equal(publicKey) {
+		t.Fatalf("PublicKey() = %x, %v", key, err)
+	}
+
+	// The key agreement key belongs to the same secret as the signing key.
+	agreementPrivate, err := X25519PrivateKey(privateKey)
+	if err != nil {
+		t.Fatalf("X25519PrivateKey() error = %v", err)
+	}
+	agreementKey, err := doc.KeyAgreementKey()
+	if err != nil {
+		t.Fatalf("KeyAgreementKey() error = %v", err)
+	}
+	if !agreementKey.Equal(agreementPrivate.PublicKey()) {
+		t.Error("KeyAgreementKey() does not match the Ed25519 secret")
+	}
+	if derived, err := X25519PublicKey(publicKey); err != nil || !derived.Equal(agreementKey) {
+		t.Errorf("X25519PublicKey() does not match KeyAgreementKey(), error = %v", err)
+	}
+}
+
+func TestResolveKey_X25519(t *testing.T) {
+	private, _ := ecdh.X25519().GenerateKey(rand.Reader)
+	did := X25519KeyDID(private.PublicKey())
+	if !strings.HasPrefix(did, "did:key:z6LS") {
+		t.Fatalf("X25519KeyDID() = %s, want did:key:z6LS prefix", did)
+	}
+
+	doc, err := ResolveKey(did)
+	if err != nil {
+		t.Fatalf("ResolveKey() error = %v", err)
+	}
+	if _, err := doc.PublicKey(); err == nil {
+		t.Error("PublicKey() of an X25519 did:key should fail")
+	}
+	key, err := doc.KeyAgreementKey()
+	if err != nil || !key.Equal(private.PublicKey()) {
+		t.Errorf("KeyAgreementKey() = %v, %v", key, err)
+	}
+}
+
+func TestResolveKey_Invalid(t *testing.T) {
+	for _, did := range []string{
+		"did:web:example.com",
+		"did:key:",
+		"did:key:mAbc",
+		"did:key:z0OIl",
+		"did:key:z6Mk",
+	} {
+		if _, err := ResolveKey(did); err == nil {
+			t.Errorf("ResolveKey(%q) should fail", did)
+		}
+	}
+}
+
+func TestDocument_JSON(t *testing.T) {
+	publicKey, _, _ := ed25519.GenerateKey(rand.Reader)
+	doc, _ := ResolveKey(KeyDID(publicKey))
+
+	data, err := json.Marshal(doc)
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+
+	var decoded Document
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	if decoded.KeyAgreement[0].Embedded == nil || decoded.Authentication[0].Embedded != nil {
+		t.Error("references should round-trip as embedded methods and IDs")
+	}
+	key, err := decoded.PublicKey()
+	if err != nil || !key.Equal(publicKey) {
+		t.Errorf("PublicKey() after round trip = %x, %v", key, err)
+	}
+}
+
+func TestMethod(t *testing.T) {
+	tests := map[string]string{
+		"did:key:z6Mk":         MethodKey,
+		"did:web:example.com":  MethodWeb,
+		"did:sage:eth:0xabc":   "sage",
+		"did:key":              "",
+		"https://example.com/": "",
+	}
+	for did, want := range tests {
+		if got := Method(did); got != want {
+			t.Errorf("Method(%q) = %q, want %q", did, got, want)
+		}
+	}
+}
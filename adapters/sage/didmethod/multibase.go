@@ -0,0 +1,146 @@
+// Copyright (C) 2025 sage-x-project
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with this program. If not, see <https://www.gnu.org/licenses/>.
+
+// SPDX-License-Identifier: LGPL-3.0-or-later
+
+package didmethod
+
+import (
+	"crypto/ed25519"
+	"math/big"
+	"strings"
+
+	"github.com/sage-x-project/sage-adk/pkg/errors"
+)
+
+// Multicodec prefixes of the public key types did:key supports, as unsigned
+// varints.
+var (
+	ed25519Codec = []byte{0xed, 0x01}
+	x25519Codec  = []byte{0xec, 0x01}
+)
+
+const base58Alphabet = "123456789ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnopqrstuvwxyz"
+
+// encodeMultibase encodes data as a base58btc multibase string ("z...").
+func encodeMultibase(data []byte) string {
+	n := new(big.Int).SetBytes(data)
+	radix := big.NewInt(58)
+	mod := new(big.Int)
+
+	var out []byte
+	for n.Sign() > 0 {
+		n.DivMod(n, radix, mod)
+		out = append(out, base58Alphabet[mod.Int64()])
+	}
+	for _, b := range data {
+		if b != 0 {
+			break
+		}
+		out = append(out, base58Alphabet[0])
+	}
+
+	for i, j := 0, len(out)-1; i < j; i, j = i+1, j-1 {
+		out[i], out[j] = out[j], out[i]
+	}
+	return "z" + string(out)
+}
+
+// decodeMultibase decodes a base58btc multibase string.
+func decodeMultibase(value string) ([]byte, error) {
+	if !strings.HasPrefix(value, "z") {
+		return nil, errors.ErrInvalidFormat.
+			WithMessage("only base58btc multibase values are supported").
+			WithDetail("value", value)
+	}
+	return decodeBase58(value[1:])
+}
+
+// decodeBase58 decodes a bitcoin-alphabet base58 string.
+func decodeBase58(value string) ([]byte, error) {
+	n := new(big.Int)
+	radix := big.NewInt(58)
+	for _, c := range value {
+		digit := strings.IndexRune(base58Alphabet, c)
+		if digit < 0 {
+			return nil, errors.ErrInvalidFormat.
+				WithMessage("invalid base58 character").
+				WithDetail("value", value)
+		}
+		n.Mul(n, radix)
+		n.Add(n, big.NewInt(int64(digit)))
+	}
+
+	zeros := 0
+	for zeros < len(value) && value[zeros] == base58Alphabet[0] {
+		zeros++
+	}
+	return append(make([]byte, zeros), n.Bytes()...), nil
+}
+
+// decodeMulticodecKey decodes a multibase, multicodec-prefixed public key
+// and returns its codec prefix and raw 32-byte key.
+func decodeMulticodecKey(value string) ([]byte, []byte, error) {
+	data, err := decodeMultibase(value)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(data) != 2+32 {
+		return nil, nil, errors.ErrInvalidFormat.
+			WithMessage("unexpected multicodec key length").
+			WithDetail("value", value)
+	}
+	return data[:2], data[2:], nil
+}
+
+// fieldPrime is the prime 2^255 - 19 of Curve25519's field.
+var fieldPrime = new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 255), big.NewInt(19))
+
+// ed25519ToX25519 converts an Ed25519 public key to the X25519 public key
+// of the same secret, using the birational map u = (1 + y) / (1 - y).
+func ed25519ToX25519(publicKey ed25519.PublicKey) ([]byte, error) {
+	if len(publicKey) != ed25519.PublicKeySize {
+		return nil, errors.ErrInvalidFormat.WithMessage("invalid Ed25519 public key length")
+	}
+
+	// The key is the little-endian y coordinate with the sign of x in the
+	// top bit.
+	le := make([]byte, 32)
+	copy(le, publicKey)
+	le[31] &= 0x7f
+	y := new(big.Int).SetBytes(reverse(le))
+
+	denominator := new(big.Int).Sub(big.NewInt(1), y)
+	denominator.Mod(denominator, fieldPrime)
+	if denominator.Sign() == 0 {
+		return nil, errors.ErrInvalidFormat.WithMessage("Ed25519 public key has no X25519 equivalent")
+	}
+
+	u := new(big.Int).Add(big.NewInt(1), y)
+	u.Mul(u, new(big.Int).ModInverse(denominator, fieldPrime))
+	u.Mod(u, fieldPrime)
+
+	out := make([]byte, 32)
+	u.FillBytes(out)
+	return reverse(out), nil
+}
+
+// reverse reverses b in place and returns it.
+func reverse(b []byte) []byte {
+	for i, j := 0, len(b)-1; i < j; i, j = i+1, j-1 {
+		b[i], b[j] = b[j], b[i]
+	}
+	return b
+}
@@ -0,0 +1,275 @@
+// Copyright (C) 2025 sage-x-project
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with this program. If not, see <https://www.gnu.org/licenses/>.
+
+// SPDX-License-Identifier: LGPL-3.0-or-later
+
+package didmethod
+
+import (
+	"container/list"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sage-x-project/sage-adk/pkg/errors"
+)
+
+const (
+	// DefaultWebCacheTTL is how long resolved did:web documents are cached.
+	DefaultWebCacheTTL = 5 * time.Minute
+
+	// DefaultWebCacheSize is how many resolved did:web documents are
+	// cached; the least recently used are dropped first.
+	DefaultWebCacheSize = 1024
+
+	// maxDocumentSize bounds the size of a fetched did.json.
+	maxDocumentSize = 1 << 20
+
+	// maxRedirects bounds the redirects followed to fetch a did.json.
+	maxRedirects = 10
+)
+
+// WebURL returns the HTTPS URL of the did.json document of a did:web
+// identifier.
+//
+//	did:web:example.com             → https://example.com/.well-known/did.json
+//	did:web:example.com:agents:bob  → https://example.com/agents/bob/did.json
+//	did:web:localhost%3A8443        → https://localhost:8443/.well-known/did.json
+func WebURL(did string) (string, error) {
+	if Method(did) != MethodWeb {
+		return "", errors.ErrInvalidFormat.
+			WithMessage("not a did:web identifier").
+			WithDetail("did", did)
+	}
+
+	segments := strings.Split(strings.TrimPrefix(did, "did:web:"), ":")
+	for i, segment := range segments {
+		decoded, err := url.PathUnescape(segment)
+		if err != nil || decoded == "" || strings.Contains(decoded, "/") {
+			return "", errors.ErrInvalidFormat.
+				WithMessage("invalid did:web identifier").
+				WithDetail("did", did)
+		}
+		segments[i] = decoded
+	}
+
+	host := segments[0]
+	path := "/.well-known"
+	if len(segments) > 1 {
+		path = "/" + strings.Join(segments[1:], "/")
+	}
+	return "https://" + host + path + "/did.json", nil
+}
+
+// WebResolver resolves did:web identifiers by fetching their did.json
+// over HTTPS. Documents are cached for a configurable time, up to
+// DefaultWebCacheSize of them.
+type WebResolver struct {
+	client *http.Client
+	ttl    time.Duration
+
+	mu      sync.Mutex
+	cache   map[string]*list.Element
+	order   *list.List // of *cachedDocument, most recently used first
+	maxSize int
+	now     func() time.Time
+}
+
+type cachedDocument struct {
+	did     string
+	doc     *Document
+	expires time.Time
+}
+
+// NewWebResolver creates a did:web resolver.
+//
+// A nil client uses a client with a 10 second timeout, and a non-positive
+// ttl uses DefaultWebCacheTTL. Redirects are only followed to HTTPS URLs
+// on the DID's own host, whatever the client's redirect policy.
+func NewWebResolver(client *http.Client, ttl time.Duration) *WebResolver {
+	if client == nil {
+		client = &http.Client{Timeout: 10 * time.Second}
+	}
+	if ttl <= 0 {
+		ttl = DefaultWebCacheTTL
+	}
+
+	// Copy the client so the caller's redirect policy is left alone
+	web := *client
+	web.CheckRedirect = checkWebRedirect
+
+	return &WebResolver{
+		client:  &web,
+		ttl:     ttl,
+		cache:   make(map[string]*list.Element),
+		order:   list.New(),
+		maxSize: DefaultWebCacheSize,
+		now:     time.Now,
+	}
+}
+
+// checkWebRedirect refuses redirects that would fetch a did.json over
+// plain HTTP or from another host than the DID names.
+func checkWebRedirect(req *http.Request, via []*http.Request) error {
+	if len(via) >= maxRedirects {
+		return errors.ErrAccessDenied.
+			WithMessage("too many did:web redirects").
+			WithDetail("url", req.URL.String())
+	}
+	if req.URL.Scheme != "https" {
+		return errors.ErrAccessDenied.
+			WithMessage("did:web redirect to a non-HTTPS URL").
+			WithDetail("url", req.URL.String())
+	}
+	if !strings.EqualFold(req.URL.Host, via[0].URL.Host) {
+		return errors.ErrAccessDenied.
+			WithMessage("did:web redirect to another host").
+			WithDetail("url", req.URL.String())
+	}
+	return nil
+}
+
+// Resolve returns the DID document of a did:web identifier, from the cache
+// if it has not expired.
+func (r *WebResolver) Resolve(ctx context.Context, did string) (*Document, error) {
+	if doc := r.cached(did); doc != nil {
+		return doc, nil
+	}
+
+	doc, err := r.fetch(ctx, did)
+	if err != nil {
+		return nil, err
+	}
+
+	r.store(did, doc)
+	return doc, nil
+}
+
+// Invalidate drops a cached document so the next Resolve fetches it again.
+func (r *WebResolver) Invalidate(did string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if elem, ok := r.cache[did]; ok {
+		r.removeLocked(elem)
+	}
+}
+
+// cached returns the unexpired cached document of did, or nil. Expired
+// documents are dropped.
+func (r *WebResolver) cached(did string) *Document {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	elem, ok := r.cache[did]
+	if !ok {
+		return nil
+	}
+	entry := elem.Value.(*cachedDocument)
+	if !r.now().Before(entry.expires) {
+		r.removeLocked(elem)
+		return nil
+	}
+	r.order.MoveToFront(elem)
+	return entry.doc
+}
+
+// store caches doc for did. When the cache is full, expired documents are
+// dropped, then the least recently used ones.
+func (r *WebResolver) store(did string, doc *Document) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := r.now()
+	if elem, ok := r.cache[did]; ok {
+		r.removeLocked(elem)
+	}
+	if len(r.cache) >= r.maxSize {
+		for elem := r.order.Back(); elem != nil; {
+			prev := elem.Prev()
+			if !now.Before(elem.Value.(*cachedDocument).expires) {
+				r.removeLocked(elem)
+			}
+			elem = prev
+		}
+	}
+	for len(r.cache) >= r.maxSize && r.order.Len() > 0 {
+		r.removeLocked(r.order.Back())
+	}
+
+	r.cache[did] = r.order.PushFront(&cachedDocument{did: did, doc: doc, expires: now.Add(r.ttl)})
+}
+
+// removeLocked drops a cache entry. r.mu must be held.
+func (r *WebResolver) removeLocked(elem *list.Element) {
+	r.order.Remove(elem)
+	delete(r.cache, elem.Value.(*cachedDocument).did)
+}
+
+// fetch downloads and validates the document of did.
+func (r *WebResolver) fetch(ctx context.Context, did string) (*Document, error) {
+	docURL, err := WebURL(did)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, docURL, nil)
+	if err != nil {
+		return nil, errors.ErrInvalidFormat.
+			WithMessage("invalid did:web identifier").
+			WithDetail("did", did).
+			WithDetail("error", err.Error())
+	}
+	req.Header.Set("Accept", "application/did+json, application/json")
+
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return nil, errors.ErrNetworkUnavailable.
+			WithMessage("failed to fetch DID document").
+			WithDetail("url", docURL).
+			WithDetail("error", err.Error())
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusNotFound || resp.StatusCode == http.StatusGone:
+		return nil, errors.ErrDIDNotFound.WithDetail("did", did)
+	case resp.StatusCode != http.StatusOK:
+		return nil, errors.ErrNetworkUnavailable.
+			WithMessage("failed to fetch DID document").
+			WithDetail("url", docURL).
+			WithDetail("status", resp.Status)
+	}
+
+	var doc Document
+	if err := json.NewDecoder(io.LimitReader(resp.Body, maxDocumentSize)).Decode(&doc); err != nil {
+		return nil, errors.ErrMessageParsing.
+			WithMessage("invalid DID document").
+			WithDetail("url", docURL).
+			WithDetail("error", err.Error())
+	}
+	if doc.ID != did {
+		return nil, errors.ErrInvalidFormat.
+			WithMessage("DID document id does not match the DID").
+			WithDetail("did", did).
+			WithDetail("id", doc.ID)
+	}
+
+	return &doc, nil
+}
@@ -0,0 +1,252 @@
+// Copyright (C) 2025 sage-x-project
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with this program. If not, see <https://www.gnu.org/licenses/>.
+
+// SPDX-License-Identifier: LGPL-3.0-or-later
+
+package didmethod
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/sage-x-project/sage-adk/pkg/errors"
+)
+
+func TestWebURL(t *testing.T) {
+	tests := []struct {
+		did     string
+		want    string
+		wantErr bool
+	}{
+		{did: "did:web:example.com", want: "https://example.com/.well-known/did.json"},
+		{did: "did:web:example.com:agents:bob", want: "https://example.com/agents/bob/did.json"},
+		{did: "did:web:localhost%3A8443", want: "https://localhost:8443/.well-known/did.json"},
+		{did: "did:key:z6Mk", wantErr: true},
+		{did: "did:web:example.com::bob", wantErr: true},
+		{did: "did:web:example.com:a%2Fb", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		got, err := WebURL(tt.did)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("WebURL(%q) error = %v, wantErr %v", tt.did, err, tt.wantErr)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("WebURL(%q) = %q, want %q", tt.did, got, tt.want)
+		}
+	}
+}
+
+// newWebServer serves a did.json for the did:web of the test server at
+// /agents/bob and returns the DID and request counter.
+func newWebServer(t *testing.T, publicKey ed25519.PublicKey) (*httptest.Server, string, *int32) {
+	t.Helper()
+
+	var requests int32
+	var did string
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		if r.URL.Path != "/agents/bob/did.json" {
+			http.NotFound(w, r)
+			return
+		}
+		json.NewEncoder(w).Encode(&Document{
+			ID: did,
+			VerificationMethod: []VerificationMethod{{
+				ID:         "#key-1",
+				Type:       TypeJSONWebKey2020,
+				Controller: did,
+				PublicKeyJwk: &JWK{
+					Kty: "OKP",
+					Crv: "Ed25519",
+					X:   base64.RawURLEncoding.EncodeToString(publicKey),
+				},
+			}},
+			AssertionMethod: []Reference{{ID: "#key-1"}},
+			Service: []Service{{
+				ID:              "#sage",
+				Type:            "SAGEAgent",
+				ServiceEndpoint: "https://bob.example.com/sage/message",
+			}},
+		})
+	}))
+	t.Cleanup(server.Close)
+
+	host := strings.TrimPrefix(server.URL, "https://")
+	did = "did:web:" + strings.ReplaceAll(host, ":", "%3A") + ":agents:bob"
+	return server, did, &requests
+}
+
+func TestWebResolver_Resolve(t *testing.T) {
+	publicKey, _, _ := ed25519.GenerateKey(rand.Reader)
+	server, did, requests := newWebServer(t, publicKey)
+
+	resolver := NewWebResolver(server.Client(), time.Minute)
+	now := time.Now()
+	resolver.now = func() time.Time { return now }
+
+	doc, err := resolver.Resolve(context.Background(), did)
+	if err != nil {
+		t.Fatalf("Resolve() error = %v", err)
+	}
+
+	key, err := doc.PublicKey()
+	if err != nil || !key.Equal(publicKey) {
+		t.Fatalf("PublicKey() = %x, %v", key, err)
+	}
+	if got := doc.ServiceEndpoint("SAGEAgent"); got != "https://bob.example.com/sage/message" {
+		t.Errorf("ServiceEndpoint() = %q", got)
+	}
+
+	// Cached until the TTL passes.
+	resolver.Resolve(context.Background(), did)
+	if got := atomic.LoadInt32(requests); got != 1 {
+		t.Errorf("requests = %d, want 1 while cached", got)
+	}
+
+	now = now.Add(2 * time.Minute)
+	resolver.Resolve(context.Background(), did)
+	if got := atomic.LoadInt32(requests); got != 2 {
+		t.Errorf("requests = %d, want 2 after expiry", got)
+	}
+
+	resolver.Invalidate(did)
+	resolver.Resolve(context.Background(), did)
+	if got := atomic.LoadInt32(requests); got != 3 {
+		t.Errorf("requests = %d, want 3 after Invalidate", got)
+	}
+}
+
+func TestWebResolver_Errors(t *testing.T) {
+	publicKey, _, _ := ed25519.GenerateKey(rand.Reader)
+	server, did, _ := newWebServer(t, publicKey)
+	resolver := NewWebResolver(server.Client(), 0)
+	ctx := context.Background()
+
+	// A document is only found at its DID's own location.
+	missing := strings.TrimSuffix(did, ":bob") + ":alice"
+	if _, err := resolver.Resolve(ctx, missing); !errors.Is(err, errors.ErrDIDNotFound) {
+		t.Errorf("Resolve() of missing document error = %v, want ErrDIDNotFound", err)
+	}
+
+	// The served document's id must match the DID it was fetched for.
+	mismatched := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(&Document{ID: "did:web:evil.example.com"})
+	}))
+	defer mismatched.Close()
+	evil := "did:web:" + strings.ReplaceAll(strings.TrimPrefix(mismatched.URL, "https://"), ":", "%3A")
+	if _, err := NewWebResolver(mismatched.Client(), 0).Resolve(ctx, evil); !errors.Is(err, errors.ErrInvalidFormat) {
+		t.Errorf("Resolve() of mismatched document error = %v, want ErrInvalidFormat", err)
+	}
+
+	// Documents are only fetched over verified TLS.
+	if _, err := NewWebResolver(nil, 0).Resolve(ctx, did); err == nil {
+		t.Error("Resolve() without trusting the test certificate should fail")
+	}
+}
+
+func TestWebResolver_Redirects(t *testing.T) {
+	publicKey, _, _ := ed25519.GenerateKey(rand.Reader)
+	target, _, _ := newWebServer(t, publicKey)
+
+	tests := []struct {
+		name     string
+		location func(r *http.Request) string
+	}{
+		{
+			name: "plain HTTP",
+			location: func(r *http.Request) string {
+				return "http://" + r.Host + "/agents/bob/did.json"
+			},
+		},
+		{
+			name: "another host",
+			location: func(r *http.Request) string {
+				return target.URL + "/agents/bob/did.json"
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				http.Redirect(w, r, tt.location(r), http.StatusFound)
+			}))
+			defer server.Close()
+
+			did := "did:web:" + strings.ReplaceAll(strings.TrimPrefix(server.URL, "https://"), ":", "%3A")
+			_, err := NewWebResolver(server.Client(), 0).Resolve(context.Background(), did)
+			if err == nil {
+				t.Fatal("Resolve() followed the redirect")
+			}
+			var e *errors.Error
+			if !errors.As(err, &e) || !strings.Contains(fmt.Sprint(e.Details["error"]), "redirect") {
+				t.Errorf("Resolve() error = %v, want a refused redirect", err)
+			}
+		})
+	}
+}
+
+func TestWebResolver_CacheBounds(t *testing.T) {
+	publicKey, _, _ := ed25519.GenerateKey(rand.Reader)
+	server, did, requests := newWebServer(t, publicKey)
+
+	resolver := NewWebResolver(server.Client(), time.Minute)
+	resolver.maxSize = 2
+	now := time.Now()
+	resolver.now = func() time.Time { return now }
+
+	doc := &Document{ID: "did:web:example.com"}
+	resolver.store("did:web:a.example.com", doc)
+	resolver.store("did:web:b.example.com", doc)
+	resolver.Resolve(context.Background(), did)
+
+	if got := len(resolver.cache); got != 2 {
+		t.Fatalf("cache size = %d, want 2", got)
+	}
+	if resolver.cached("did:web:a.example.com") != nil {
+		t.Error("least recently used document was not evicted")
+	}
+
+	resolver.Resolve(context.Background(), did)
+	if got := atomic.LoadInt32(requests); got != 1 {
+		t.Errorf("requests = %d, want 1 while cached", got)
+	}
+
+	// Expired documents are dropped rather than kept until evicted.
+	now = now.Add(2 * time.Minute)
+	if resolver.cached("did:web:b.example.com") != nil {
+		t.Error("expired document returned from the cache")
+	}
+	if got := len(resolver.cache); got != 1 {
+		t.Errorf("cache size = %d after an expired lookup, want 1", got)
+	}
+	resolver.store("did:web:c.example.com", doc)
+	resolver.store("did:web:d.example.com", doc)
+	if _, ok := resolver.cache[did]; ok {
+		t.Error("expired document kept when the cache filled")
+	}
+}
@@ -0,0 +1,156 @@
+// Copyright (C) 2025 sage-x-project
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with this program. If not, see <https://www.gnu.org/licenses/>.
+
+// SPDX-License-Identifier: LGPL-3.0-or-later
+
+package sage
+
+import (
+	"context"
+	"crypto/ecdh"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/sage-x-project/sage-adk/adapters/sage/didmethod"
+	"github.com/sage-x-project/sage-adk/adapters/sage/registry"
+	"github.com/sage-x-project/sage-adk/config"
+	"github.com/sage-x-project/sage-adk/pkg/types"
+	"github.com/sage-x-project/sage/did"
+)
+
+func TestMethodDIDResolver_Key(t *testing.T) {
+	ctx := context.Background()
+	publicKey, _, _ := ed25519.GenerateKey(rand.Reader)
+	agentDID := didmethod.KeyDID(publicKey)
+
+	resolver := NewMethodDIDResolver(nil, nil)
+
+	key, err := resolver.ResolvePublicKey(ctx, agentDID)
+	if err != nil {
+		t.Fatalf("ResolvePublicKey() error = %v", err)
+	}
+	if !publicKey.Equal(key) {
+		t.Error("ResolvePublicKey() returned a different key")
+	}
+
+	kemKey, err := resolver.resolver.ResolveKEMKey(ctx, did.AgentDID(agentDID))
+	if err != nil {
+		t.Fatalf("ResolveKEMKey() error = %v", err)
+	}
+	if _, ok := kemKey.(*ecdh.PublicKey); !ok {
+		t.Errorf("ResolveKEMKey() = %T, want *ecdh.PublicKey", kemKey)
+	}
+
+	if _, err := resolver.ResolvePublicKey(ctx, "did:sage:eth:0xabc"); !errors.Is(err, did.ErrDIDNotFound) {
+		t.Errorf("ResolvePublicKey() without a next resolver error = %v, want did.ErrDIDNotFound", err)
+	}
+}
+
+func TestMethodDIDResolver_Web(t *testing.T) {
+	ctx := context.Background()
+	publicKey, _, _ := ed25519.GenerateKey(rand.Reader)
+	keyDoc, _ := didmethod.ResolveKey(didmethod.KeyDID(publicKey))
+
+	var agentDID string
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(&didmethod.Document{
+			ID:                 agentDID,
+			VerificationMethod: keyDoc.VerificationMethod,
+			Authentication:     keyDoc.Authentication,
+			Service: []didmethod.Service{{
+				ID:              "#sage",
+				Type:            ServiceTypeSAGE,
+				ServiceEndpoint: "https://bob.example.com/sage/message",
+			}},
+		})
+	}))
+	defer server.Close()
+	agentDID = "did:web:" + strings.ReplaceAll(strings.TrimPrefix(server.URL, "https://"), ":", "%3A")
+
+	resolver := NewMethodDIDResolver(nil, didmethod.NewWebResolver(server.Client(), 0))
+
+	metadata, err := resolver.Resolve(ctx, agentDID)
+	if err != nil {
+		t.Fatalf("Resolve() error = %v", err)
+	}
+	if metadata.Endpoint != "https://bob.example.com/sage/message" || !metadata.IsActive {
+		t.Errorf("Resolve() = %+v", metadata)
+	}
+
+	result, err := resolver.VerifyMetadata(ctx, agentDID, metadata)
+	if err != nil || !result.Valid {
+		t.Errorf("VerifyMetadata() = %+v, %v", result, err)
+	}
+}
+
+func TestMethodDIDResolver_FallsBackToNext(t *testing.T) {
+	ctx := context.Background()
+	reg := registry.NewFileRegistry(filepath.Join(t.TempDir(), "registry.json"))
+	publicKey, _, _ := ed25519.GenerateKey(rand.Reader)
+	reg.Register(ctx, registry.NewRecord("did:sage:local:alice", publicKey))
+
+	resolver := NewMethodDIDResolver(NewRegistryDIDResolver(reg), nil)
+
+	key, err := resolver.ResolvePublicKey(ctx, "did:sage:local:alice")
+	if err != nil || !publicKey.Equal(key) {
+		t.Errorf("ResolvePublicKey() = %v, %v", key, err)
+	}
+
+	agents, err := resolver.Search(ctx, did.SearchCriteria{})
+	if err != nil || len(agents) != 1 {
+		t.Errorf("Search() = %v, %v", agents, err)
+	}
+}
+
+func TestAdapter_Verify_DIDKeySender(t *testing.T) {
+	ctx := context.Background()
+	publicKey, privateKey, _ := ed25519.GenerateKey(rand.Reader)
+
+	// Neither side is registered anywhere.
+	sender, err := NewAdapter(&config.SAGEConfig{Network: "ethereum", DID: didmethod.KeyDID(publicKey)})
+	if err != nil {
+		t.Fatalf("NewAdapter() error = %v", err)
+	}
+	sender.privateKey = privateKey
+
+	receiver, err := NewAdapter(&config.SAGEConfig{Network: "ethereum", DID: "did:sage:eth:0xbob"})
+	if err != nil {
+		t.Fatalf("NewAdapter() error = %v", err)
+	}
+
+	msg := types.NewMessage(types.MessageRoleUser, []types.Part{types.NewTextPart("hello")})
+	if err := sender.SendMessage(ctx, msg); err != nil {
+		t.Fatalf("SendMessage() error = %v", err)
+	}
+	if err := receiver.Verify(ctx, msg); err != nil {
+		t.Errorf("Verify() error = %v", err)
+	}
+
+	// The did:key names the key, so a different key cannot sign for it.
+	_, otherKey, _ := ed25519.GenerateKey(rand.Reader)
+	sender.privateKey = otherKey
+	forged := types.NewMessage(types.MessageRoleUser, []types.Part{types.NewTextPart("hello")})
+	sender.SendMessage(ctx, forged)
+	if err := receiver.Verify(ctx, forged); err == nil {
+		t.Error("Verify() should reject a signature by another key")
+	}
+}
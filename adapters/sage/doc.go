@@ -32,11 +32,11 @@
 //   - Configuration loading
 //   - Message verification (basic validation)
 //   - Protocol interface compliance
+//   - Message sending and receiving over HTTP
+//   - Streaming responses with per-chunk integrity
+//   - Encrypted sessions over the SAGE handshake
 //
 // Not Implemented (Future):
-//   - Message sending (requires transport layer)
-//   - Message receiving (requires transport layer)
-//   - Streaming support
 //   - Full signature verification
 //   - DID registration and management
 //
@@ -74,6 +74,182 @@
 //
 //	err = adapter.Verify(context.Background(), msg)
 //
+// # Receiving and Streaming
+//
+// Adapter.NewServer exposes the adapter's receive path over HTTP. Every
+// message posted to /sage/message is checked with Verify (signature, nonce,
+// timestamp) before it reaches the handler set with SetMessageHandler, or
+// the ReceiveMessage queue if no handler is set. Unsigned messages are
+// rejected, and a message received over an encrypted session must be
+// signed by the session's peer:
+//
+//	adapter.SetMessageHandler(agent.HandleMessage)
+//	server := adapter.NewServer(":18080")
+//	go server.Start()
+//
+// Requests to /sage/stream are answered by the handler set with
+// SetStreamHandler as newline-delimited StreamChunk frames. The chunks form
+// a BLAKE3 hash chain seeded from the request nonce and are signed with the
+// adapter's key, so dropped, reordered or altered chunks are detected. On
+// the client side Stream sends the message stored with
+// protocol.WithStreamMessage and verifies each chunk:
+//
+//	ctx = protocol.WithStreamMessage(ctx, msg)
+//	err := adapter.Stream(ctx, func(chunk string) error {
+//	    fmt.Print(chunk)
+//	    return nil
+//	})
+//
+// # Encrypted Sessions
+//
+// With EnableEncryption, messages to the DID set with SetRemoteDID travel as
+// encrypted ApplicationMessages. The first message to a peer runs the
+// four-phase handshake over /sage/handshake (invitation → request, then
+// response → complete); later messages are encrypted with keys derived from
+// the session (see Rekeying) and posted to /sage/secure. Expired sessions, and sessions the peer no longer knows
+// (it answers 428 Precondition Required), are re-established automatically.
+// Peers that do not serve encrypted sessions fail with ErrProtocolMismatch:
+//
+//	adapter.SetRemoteEndpoint("http://peer:18080/sage/message")
+//	adapter.SetRemoteDID("did:sage:eth:0xpeer")
+//	if err := adapter.EnableEncryption(resolveKey); err != nil {
+//	    log.Fatal(err)
+//	}
+//	err := adapter.SendMessage(ctx, msg)
+//
+// Handshake and session messages are signed; resolveKey maps their key IDs
+// ("did#key-1") to Ed25519 public keys, and a signature must come from the
+// DID the message claims to be from.
+//
+// # Local DID Registry
+//
+// Without a blockchain, set sage.registry to a JSON or YAML registry file
+// (or to the URL of a registry shared with "adk registry serve"). Public
+// keys are then resolved from the registry, so signatures can be verified
+// on a laptop or in CI:
+//
+//	sage:
+//	  network: "local"
+//	  did: "did:sage:local:alice"
+//	  registry: "./registry.yaml"
+//
+// See the registry package for registering, updating and deactivating DIDs.
+//
+// # did:key and did:web
+//
+// Partners without an on-chain registration can use a self-certifying
+// did:key ("did:key:z6Mk...", see didmethod.KeyDID) or a did:web whose
+// did.json is served over HTTPS. Both are resolved by every adapter, ahead
+// of the registry or chain, so their signed messages pass Verify. did:web
+// documents are cached; use NewMethodDIDResolver with a custom
+// didmethod.WebResolver to change the cache TTL or HTTP client.
+//
+// # Key Rotation
+//
+// Set sage.key_ring_path to sign with a rotating key ring (see the keyring
+// package) instead of a single key. Signatures carry versioned key IDs
+// ("did#key-2"), and verifiers resolve the key a key ID names, so the old
+// and new keys are both accepted while a rotation's overlap window lasts:
+//
+//	adk keys init --did did:sage:local:alice --registry registry.yaml
+//	adk keys rotate --overlap 24h --registry registry.yaml
+//
+// Call ReloadKeyRing on a running adapter to sign with the new active key.
+//
+// # Session Persistence
+//
+// Encrypted sessions live in memory and are lost on restart unless a
+// session store is set. The sessionstore package keeps them in a
+// storage.Storage backend, sealed with a key-encryption key:
+//
+//	kek, err := sessionstore.LoadOrCreateKEK("/var/lib/agent/session.kek")
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	store, err := sessionstore.NewStorageStore(redisStorage, kek)
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	adapter.SetSessionStore(store)
+//
+// After a restart, the first message to a peer resumes the stored session
+// in one round trip, with both sides proving they still hold the session
+// key. If the peer no longer knows the session, a full handshake runs.
+//
+// # Rekeying
+//
+// Each encrypted message is numbered and encrypted with its own key, derived
+// from per-direction chain keys (see the ratchet package). Messages must
+// arrive in order: a replayed or reordered message, or one of an earlier
+// session, is rejected with ErrReplayDetected. The chain keys are rekeyed after a number of messages,
+// bytes or time, with an X25519 exchange mixed in every few rekeys:
+//
+//	sage:
+//	  rekey_messages: 1000
+//	  rekey_bytes: 67108864
+//	  rekey_interval: 10m
+//
+// Peers whose keys diverge, e.g. after a lost message that carried a key
+// exchange, fail with ErrSessionOutOfSync and establish a new session.
+//
+// # Replay Protection
+//
+// Verify rejects a nonce it has seen within twice sage.max_clock_skew
+// (default 5m) with ErrReplayDetected. Nonces are remembered in-process by
+// default; replicas behind a load balancer should share a Redis store so a
+// replay routed to another replica is caught too:
+//
+//	sage:
+//	  nonce_store: "redis://redis:6379/0"
+//
+// See the replay package, or SetReplayGuard to supply a guard directly.
+//
+// # HTTP Message Signatures
+//
+// With a private key loaded, every HTTP request the adapter sends carries
+// RFC 9421 Signature-Input and Signature headers, and the body is covered
+// through an RFC 9530 Content-Digest header (see the httpsig package). Set
+// sage.require_http_signatures to make servers created by NewServer reject
+// requests whose signature is missing or does not match the request's
+// method, URL, host or body:
+//
+//	sage:
+//	  require_http_signatures: true
+//	  http_signature_components: ["@method", "@target-uri", "@authority"]
+//	  http_signature_headers: ["content-type"]
+//
+// HTTPVerifier returns the same check as middleware for other servers, such
+// as the A2A server (see a2a.ServerConfig.Middleware).
+//
+// # Sealed Parts
+//
+// Single message parts can be encrypted for one recipient, so they stay
+// confidential when the message travels over plain A2A, through
+// intermediaries, or into logs. SealPart replaces a text, file or data part
+// with a DataPart envelope that only the holder of the recipient DID's key
+// agreement key (or the key derived from its Ed25519 key) can open:
+//
+//	sealed, err := adapter.SealPart(ctx, types.NewTextPart(secret), "did:sage:ethereum:0xBob")
+//	msg := types.NewMessage(types.MessageRoleUser, []types.Part{types.NewTextPart("see attached"), sealed})
+//
+// The recipient opens its parts transparently with middleware; handlers see
+// the original parts, while middleware placed before it sees only the
+// envelopes:
+//
+//	chain := middleware.NewChain(auditLog.Middleware(), adapter.PartDecrypter().Middleware())
+//
+// # Encrypted Keys
+//
+// sage.private_key_path may point to a passphrase-encrypted keystore file
+// (see the keystore package) instead of a plain PEM or JWK key:
+//
+//	adk keys encrypt agent.pem --out agent.key.json
+//
+// The passphrase is read from SAGE_ADK_KEY_PASSPHRASE, then from
+// sage.passphrase_file, then prompted for on the terminal. A key file that
+// is missing, cannot be decrypted or is not an Ed25519 key makes NewAdapter
+// fail with ErrConfigurationError rather than running without signing.
+//
 // # Security Features
 //
 // SAGE provides enhanced security compared to A2A:
@@ -95,10 +271,7 @@
 //
 // # Limitations (Phase 1)
 //
-//   - SendMessage() returns ErrNotImplemented (no transport layer)
-//   - ReceiveMessage() returns ErrNotImplemented (no transport layer)
 //   - Verify() performs basic validation only (full verification in Phase 2)
-//   - Streaming not supported
 //   - DID management not included (assumes pre-registered DID)
 //
 // # Future Enhancements
@@ -109,13 +282,10 @@
 //   - DID resolution from blockchain
 //
 // Phase 3:
-//   - gRPC or HTTP transport layer
-//   - Message sending and receiving
-//   - Streaming support
+//   - gRPC transport layer
 //
 // Phase 4:
 //   - DID registration and lifecycle management
 //   - Key rotation
 //   - Multi-chain support
-//   - SAGE handshake protocol
 package sage
@@ -24,6 +24,7 @@ import (
 	"encoding/base64"
 	"time"
 
+	"github.com/sage-x-project/sage-adk/adapters/sage/ratchet"
 	"github.com/sage-x-project/sage-adk/pkg/errors"
 )
 
@@ -38,10 +39,18 @@ type HandshakeManager struct {
 	privateKey ed25519.PrivateKey
 	publicKey  ed25519.PublicKey
 
+	// signingKey, if set, provides the signing key in place of privateKey
+	signingKey SigningKeyFunc
+
 	// Configuration
 	config *TransportConfig
 }
 
+// SigningKeyFunc returns the private key to sign with and the key ID
+// ("did#key-2") signatures carry. It lets long-lived components follow key
+// rotations.
+type SigningKeyFunc func() (ed25519.PrivateKey, string, error)
+
 // NewHandshakeManager creates a new handshake manager.
 func NewHandshakeManager(
 	sessionManager *SessionManager,
@@ -62,6 +71,20 @@ func NewHandshakeManager(
 	}
 }
 
+// SetSigningKeyFunc makes handshake messages follow the key returned by fn
+// instead of the private key given at construction.
+func (hm *HandshakeManager) SetSigningKeyFunc(fn SigningKeyFunc) {
+	hm.signingKey = fn
+}
+
+// currentKey returns the key handshake messages are signed with.
+func (hm *HandshakeManager) currentKey() (ed25519.PrivateKey, string, error) {
+	if hm.signingKey != nil {
+		return hm.signingKey()
+	}
+	return hm.privateKey, hm.localDID + "#key-1", nil
+}
+
 // InitiateHandshake starts the handshake process (Phase 1: Invitation).
 // Alice calls this to initiate communication with Bob.
 func (hm *HandshakeManager) InitiateHandshake(ctx context.Context, remoteDID string) (*HandshakeInvitation, *Session, error) {
@@ -192,8 +215,11 @@ func (hm *HandshakeManager) ProcessInvitation(ctx context.Context, invitation *H
 	}
 
 	// Sign request
-	keyID := hm.localDID + "#key-1"
-	signature, err := hm.signingManager.SignMessage(request, hm.privateKey, keyID)
+	privateKey, keyID, err := hm.currentKey()
+	if err != nil {
+		return nil, nil, err
+	}
+	signature, err := hm.signingManager.SignMessage(request, privateKey, keyID)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -296,8 +322,11 @@ func (hm *HandshakeManager) ProcessRequest(ctx context.Context, request *Handsha
 	}
 
 	// Sign response
-	keyID := hm.localDID + "#key-1"
-	signature, err := hm.signingManager.SignMessage(response, hm.privateKey, keyID)
+	privateKey, keyID, err := hm.currentKey()
+	if err != nil {
+		return nil, err
+	}
+	signature, err := hm.signingManager.SignMessage(response, privateKey, keyID)
 	if err != nil {
 		return nil, err
 	}
@@ -375,15 +404,20 @@ func (hm *HandshakeManager) ProcessResponse(ctx context.Context, response *Hands
 	}
 
 	// Sign complete
-	keyID := hm.localDID + "#key-1"
-	signature, err := hm.signingManager.SignMessage(complete, hm.privateKey, keyID)
+	privateKey, keyID, err := hm.currentKey()
+	if err != nil {
+		return nil, err
+	}
+	signature, err := hm.signingManager.SignMessage(complete, privateKey, keyID)
 	if err != nil {
 		return nil, err
 	}
 	complete.Signature = *signature
 
 	// Activate Bob's session (Bob has the session key now)
-	session.Status = SessionActive
+	if err := activateSession(session); err != nil {
+		return nil, err
+	}
 	if err := hm.sessionManager.Update(session); err != nil {
 		return nil, err
 	}
@@ -421,7 +455,9 @@ func (hm *HandshakeManager) ProcessComplete(ctx context.Context, complete *Hands
 	}
 
 	// Activate session
-	session.Status = SessionActive
+	if err := activateSession(session); err != nil {
+		return err
+	}
 	if err := hm.sessionManager.Update(session); err != nil {
 		return err
 	}
@@ -540,3 +576,20 @@ func (hm *HandshakeManager) reconstructPrivateKey(keyBytes []byte) (*ecdh.Privat
 	}
 	return privKey, nil
 }
+
+// activateSession starts the message key schedule of an established session
+// and marks it active. The handshake secrets are discarded and the session
+// key is replaced by its resumption key, so the message keys cannot be
+// recomputed from what the session keeps.
+func activateSession(session *Session) error {
+	state, err := ratchet.New(session.SessionKey, session.LocalDID, session.RemoteDID, time.Now())
+	if err != nil {
+		return err
+	}
+	session.Ratchet = state
+	session.SessionKey = ratchet.ResumptionKey(session.SessionKey)
+	session.SharedSecret = nil
+	session.EphemeralKey = nil
+	session.Status = SessionActive
+	return nil
+}
@@ -0,0 +1,172 @@
+// Copyright (C) 2025 sage-x-project
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with this program. If not, see <https://www.gnu.org/licenses/>.
+
+// SPDX-License-Identifier: LGPL-3.0-or-later
+
+package httpsig
+
+import (
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/sage-x-project/sage-adk/pkg/errors"
+)
+
+// Derived components supported in covered component lists.
+const (
+	ComponentMethod        = "@method"
+	ComponentTargetURI     = "@target-uri"
+	ComponentAuthority     = "@authority"
+	ComponentScheme        = "@scheme"
+	ComponentRequestTarget = "@request-target"
+	ComponentPath          = "@path"
+	ComponentQuery         = "@query"
+
+	// ComponentContentDigest covers the body through its Content-Digest.
+	ComponentContentDigest = "content-digest"
+)
+
+// signatureBase builds the RFC 9421 signature base of r for the covered
+// components and signature parameters in params. scheme is the scheme the
+// request was sent with; if empty it is taken from r.
+func signatureBase(r *http.Request, params item, scheme string) (string, error) {
+	var b strings.Builder
+	seen := make(map[string]bool, len(params.list))
+	for _, component := range params.list {
+		if component.kind != kindString {
+			return "", errors.ErrSignatureInvalid.
+				WithMessage("covered component is not a string")
+		}
+		name := component.str
+		if len(component.params) > 0 {
+			return "", errors.ErrSignatureInvalid.
+				WithMessage("component parameters are not supported").
+				WithDetail("component", name)
+		}
+		if seen[name] {
+			return "", errors.ErrSignatureInvalid.
+				WithMessage("component is covered twice").
+				WithDetail("component", name)
+		}
+		seen[name] = true
+
+		value, err := componentValue(r, name, scheme)
+		if err != nil {
+			return "", err
+		}
+		b.WriteString(`"` + name + `": ` + value + "\n")
+	}
+
+	b.WriteString(`"@signature-params": ` + serializeItem(params))
+	return b.String(), nil
+}
+
+// componentValue returns the value of a derived component or header field
+// of r.
+func componentValue(r *http.Request, name, scheme string) (string, error) {
+	if scheme == "" {
+		scheme = requestScheme(r)
+	}
+
+	switch name {
+	case ComponentMethod:
+		return r.Method, nil
+	case ComponentTargetURI:
+		return scheme + "://" + authority(r, scheme) + r.URL.RequestURI(), nil
+	case ComponentAuthority:
+		return authority(r, scheme), nil
+	case ComponentScheme:
+		return scheme, nil
+	case ComponentRequestTarget:
+		return r.URL.RequestURI(), nil
+	case ComponentPath:
+		if path := r.URL.EscapedPath(); path != "" {
+			return path, nil
+		}
+		return "/", nil
+	case ComponentQuery:
+		return "?" + r.URL.RawQuery, nil
+	}
+
+	if strings.HasPrefix(name, "@") {
+		return "", errors.ErrSignatureInvalid.
+			WithMessage("unsupported derived component").
+			WithDetail("component", name)
+	}
+	if name != strings.ToLower(name) {
+		return "", errors.ErrSignatureInvalid.
+			WithMessage("header component names must be lowercase").
+			WithDetail("component", name)
+	}
+
+	values := r.Header.Values(name)
+	if len(values) == 0 {
+		// net/http keeps these out of the header map
+		switch {
+		case name == "host":
+			values = []string{requestHost(r)}
+		case name == "content-length" && r.ContentLength >= 0 && r.Body != nil && r.Body != http.NoBody:
+			values = []string{strconv.FormatInt(r.ContentLength, 10)}
+		default:
+			return "", errors.ErrSignatureInvalid.
+				WithMessage("covered header is missing").
+				WithDetail("component", name)
+		}
+	}
+
+	trimmed := make([]string, len(values))
+	for i, v := range values {
+		trimmed[i] = strings.TrimSpace(v)
+	}
+	return strings.Join(trimmed, ", "), nil
+}
+
+// requestScheme returns the scheme of an outgoing request, or of an
+// incoming one as seen by this server.
+func requestScheme(r *http.Request) string {
+	switch {
+	case r.URL.Scheme != "":
+		return strings.ToLower(r.URL.Scheme)
+	case r.TLS != nil:
+		return "https"
+	default:
+		return "http"
+	}
+}
+
+// requestHost returns the host the request is addressed to.
+func requestHost(r *http.Request) string {
+	if r.Host != "" {
+		return r.Host
+	}
+	return r.URL.Host
+}
+
+// authority returns the normalized @authority of r: lowercase, without the
+// scheme's default port.
+func authority(r *http.Request, scheme string) string {
+	host := strings.ToLower(requestHost(r))
+	if h, port, err := net.SplitHostPort(host); err == nil {
+		if (scheme == "http" && port == "80") || (scheme == "https" && port == "443") {
+			if strings.Contains(h, ":") {
+				return "[" + h + "]"
+			}
+			return h
+		}
+	}
+	return host
+}
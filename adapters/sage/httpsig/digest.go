@@ -0,0 +1,94 @@
+// Copyright (C) 2025 sage-x-project
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with this program. If not, see <https://www.gnu.org/licenses/>.
+
+// SPDX-License-Identifier: LGPL-3.0-or-later
+
+package httpsig
+
+import (
+	"crypto/sha256"
+	"crypto/sha512"
+	"crypto/subtle"
+	"hash"
+
+	"github.com/sage-x-project/sage-adk/pkg/errors"
+)
+
+// HeaderContentDigest is the RFC 9530 header carrying a digest of the body.
+const HeaderContentDigest = "Content-Digest"
+
+// digestAlgorithms are the Content-Digest algorithms accepted, strongest
+// first.
+var digestAlgorithms = []struct {
+	name string
+	new  func() hash.Hash
+}{
+	{"sha-512", sha512.New},
+	{"sha-256", sha256.New},
+}
+
+// ContentDigest returns the Content-Digest header value for body, using
+// SHA-256.
+func ContentDigest(body []byte) string {
+	sum := sha256.Sum256(body)
+	return serializeDictionary([]member{{name: "sha-256", value: bytesItem(sum[:])}})
+}
+
+// VerifyContentDigest checks body against a Content-Digest header value.
+// Every digest the header carries with a supported algorithm must match,
+// and at least one must be present; digests with unknown algorithms are
+// ignored.
+func VerifyContentDigest(header string, body []byte) error {
+	if header == "" {
+		return errors.ErrSignatureInvalid.
+			WithMessage("missing Content-Digest header")
+	}
+
+	digests, err := parseDictionary(header)
+	if err != nil {
+		return errors.ErrSignatureInvalid.
+			WithMessage("malformed Content-Digest header").
+			WithDetail("error", err.Error())
+	}
+
+	checked := 0
+	for _, algorithm := range digestAlgorithms {
+		digest, ok := lookup(digests, algorithm.name)
+		if !ok {
+			continue
+		}
+		if digest.kind != kindBytes {
+			return errors.ErrSignatureInvalid.
+				WithMessage("malformed Content-Digest header").
+				WithDetail("algorithm", algorithm.name)
+		}
+
+		h := algorithm.new()
+		h.Write(body)
+		if subtle.ConstantTimeCompare(h.Sum(nil), digest.bytes) != 1 {
+			return errors.ErrSignatureInvalid.
+				WithMessage("content digest does not match body").
+				WithDetail("algorithm", algorithm.name)
+		}
+		checked++
+	}
+
+	if checked == 0 {
+		return errors.ErrSignatureInvalid.
+			WithMessage("Content-Digest has no supported algorithm").
+			WithDetail("supported", "sha-256, sha-512")
+	}
+	return nil
+}
@@ -0,0 +1,101 @@
+// Copyright (C) 2025 sage-x-project
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with this program. If not, see <https://www.gnu.org/licenses/>.
+
+// SPDX-License-Identifier: LGPL-3.0-or-later
+
+package httpsig
+
+import (
+	"testing"
+
+	"github.com/sage-x-project/sage-adk/pkg/errors"
+)
+
+// rfc9530Body is the body of the RFC 9530 examples.
+const rfc9530Body = `{"hello": "world"}`
+
+func TestContentDigest(t *testing.T) {
+	// RFC 9530, Appendix B.1
+	want := "sha-256=:X48E9qOokqqrvdts8nOJRJN3OWDUoyWxBf7kbu9DBPE=:"
+	if got := ContentDigest([]byte(rfc9530Body)); got != want {
+		t.Errorf("ContentDigest() = %q, want %q", got, want)
+	}
+}
+
+func TestVerifyContentDigest(t *testing.T) {
+	tests := []struct {
+		name    string
+		header  string
+		body    string
+		wantErr bool
+	}{
+		{
+			name:   "sha-256",
+			header: "sha-256=:X48E9qOokqqrvdts8nOJRJN3OWDUoyWxBf7kbu9DBPE=:",
+			body:   rfc9530Body,
+		},
+		{
+			name:   "sha-512",
+			header: "sha-512=:WZDPaVn/7XgHaAy8pmojAkGWoRx2UFChF41A2svX+TaPm+AbwAgBWnrIiYllu7BNNyealdVLvRwEmTHWXvJwew==:",
+			body:   rfc9530Body,
+		},
+		{
+			name:   "unknown algorithm ignored",
+			header: "md5=:AAAA:, sha-256=:X48E9qOokqqrvdts8nOJRJN3OWDUoyWxBf7kbu9DBPE=:",
+			body:   rfc9530Body,
+		},
+		{
+			name:    "body changed",
+			header:  "sha-256=:X48E9qOokqqrvdts8nOJRJN3OWDUoyWxBf7kbu9DBPE=:",
+			body:    `{"hello": "there"}`,
+			wantErr: true,
+		},
+		{
+			name:    "one of two digests wrong",
+			header:  "sha-256=:X48E9qOokqqrvdts8nOJRJN3OWDUoyWxBf7kbu9DBPE=:, sha-512=:AAAA:",
+			body:    rfc9530Body,
+			wantErr: true,
+		},
+		{
+			name:    "only unsupported algorithms",
+			header:  "md5=:AAAA:",
+			body:    rfc9530Body,
+			wantErr: true,
+		},
+		{
+			name:    "missing",
+			body:    rfc9530Body,
+			wantErr: true,
+		},
+		{
+			name:    "malformed",
+			header:  "sha-256=X48E9qOokqqrvdts8nOJRJN3OWDUoyWxBf7kbu9DBPE=",
+			body:    rfc9530Body,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := VerifyContentDigest(tt.header, []byte(tt.body))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("VerifyContentDigest() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil && !errors.Is(err, errors.ErrSignatureInvalid) {
+				t.Errorf("VerifyContentDigest() error = %v, want ErrSignatureInvalid", err)
+			}
+		})
+	}
+}
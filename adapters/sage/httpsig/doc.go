@@ -0,0 +1,42 @@
+// Copyright (C) 2025 sage-x-project
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with this program. If not, see <https://www.gnu.org/licenses/>.
+
+// SPDX-License-Identifier: LGPL-3.0-or-later
+// Package httpsig signs and verifies HTTP requests with RFC 9421 HTTP
+// Message Signatures.
+//
+// A Signer covers derived components of the request it is about to send
+// (@method, @target-uri, @authority, ...) and selected header fields, and
+// adds the Signature-Input and Signature headers. The body is covered
+// through an RFC 9530 Content-Digest header, which the signer computes and
+// the verifier checks against the body it received:
+//
+//	signer := httpsig.NewSigner(httpsig.StaticKey(privateKey, "did:sage:local:alice#key-1"), nil)
+//	client := &http.Client{Transport: signer.Transport(nil)}
+//
+// A Verifier rebuilds the signature base from the request as it arrived, so
+// a signature made for a different method, URL, host or body does not
+// verify. Its Middleware rejects such requests with 401 Unauthorized and
+// stores the verified Result in the request context:
+//
+//	verifier := httpsig.NewVerifier(resolveKey, &httpsig.VerifierConfig{
+//	    MaxAge: 5 * time.Minute,
+//	    Guard:  replay.NewMemory(0),
+//	})
+//	http.ListenAndServe(":8080", verifier.Middleware(mux))
+//
+// Signatures use Ed25519 (alg "ed25519"). Key IDs are SAGE key IDs
+// ("did#key-1"), resolved to public keys by the verifier's resolver.
+package httpsig
@@ -0,0 +1,441 @@
+// Copyright (C) 2025 sage-x-project
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with this program. If not, see <https://www.gnu.org/licenses/>.
+
+// SPDX-License-Identifier: LGPL-3.0-or-later
+
+package httpsig
+
+import (
+	"encoding/base64"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// This file implements the subset of RFC 8941 Structured Field Values that
+// Signature-Input, Signature and Content-Digest use: dictionaries whose
+// members are inner lists or items, with parameters. Decimals are not
+// supported.
+
+type itemKind int
+
+const (
+	kindString itemKind = iota
+	kindToken
+	kindInteger
+	kindBytes
+	kindBoolean
+	kindInnerList
+)
+
+// item is a structured field item, or an inner list of items.
+type item struct {
+	kind    itemKind
+	str     string // string or token
+	integer int64
+	bytes   []byte
+	boolean bool
+	list    []item
+	params  []param
+}
+
+// param is a parameter of an item or inner list. Parameters keep their
+// order, so a parsed Signature-Input serializes back to the same text.
+type param struct {
+	name  string
+	value item
+}
+
+// member is a dictionary member.
+type member struct {
+	name  string
+	value item
+}
+
+func stringItem(s string) item { return item{kind: kindString, str: s} }
+
+func integerItem(n int64) item { return item{kind: kindInteger, integer: n} }
+
+func bytesItem(b []byte) item { return item{kind: kindBytes, bytes: b} }
+
+// lookup returns the dictionary member called name.
+func lookup(members []member, name string) (item, bool) {
+	for _, m := range members {
+		if m.name == name {
+			return m.value, true
+		}
+	}
+	return item{}, false
+}
+
+// lookupParam returns the parameter called name.
+func lookupParam(params []param, name string) (item, bool) {
+	for _, p := range params {
+		if p.name == name {
+			return p.value, true
+		}
+	}
+	return item{}, false
+}
+
+// parseDictionary parses a structured field dictionary. A repeated key
+// replaces the earlier member.
+func parseDictionary(s string) ([]member, error) {
+	p := &fieldParser{s: strings.Trim(s, " ")}
+	var members []member
+	for !p.done() {
+		name, err := p.key()
+		if err != nil {
+			return nil, err
+		}
+
+		value := item{kind: kindBoolean, boolean: true}
+		if p.peek() == '=' {
+			p.pos++
+			value, err = p.itemOrInnerList()
+		} else {
+			value.params, err = p.parameters()
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		replaced := false
+		for i := range members {
+			if members[i].name == name {
+				members[i].value = value
+				replaced = true
+			}
+		}
+		if !replaced {
+			members = append(members, member{name: name, value: value})
+		}
+
+		p.skipOWS()
+		if p.done() {
+			break
+		}
+		if p.next() != ',' {
+			return nil, p.errorf("expected ','")
+		}
+		p.skipOWS()
+		if p.done() {
+			return nil, p.errorf("trailing comma")
+		}
+	}
+	return members, nil
+}
+
+type fieldParser struct {
+	s   string
+	pos int
+}
+
+func (p *fieldParser) done() bool { return p.pos >= len(p.s) }
+
+func (p *fieldParser) peek() byte {
+	if p.done() {
+		return 0
+	}
+	return p.s[p.pos]
+}
+
+func (p *fieldParser) next() byte {
+	c := p.peek()
+	p.pos++
+	return c
+}
+
+func (p *fieldParser) skipOWS() {
+	for !p.done() && (p.s[p.pos] == ' ' || p.s[p.pos] == '\t') {
+		p.pos++
+	}
+}
+
+func (p *fieldParser) errorf(format string, args ...interface{}) error {
+	return fmt.Errorf("structured field: "+format+" at offset %d", append(args, p.pos)...)
+}
+
+func (p *fieldParser) key() (string, error) {
+	start := p.pos
+	if c := p.peek(); !isLCAlpha(c) && c != '*' {
+		return "", p.errorf("invalid key")
+	}
+	for !p.done() {
+		c := p.peek()
+		if !isLCAlpha(c) && !isDigit(c) && c != '_' && c != '-' && c != '.' && c != '*' {
+			break
+		}
+		p.pos++
+	}
+	return p.s[start:p.pos], nil
+}
+
+func (p *fieldParser) itemOrInnerList() (item, error) {
+	if p.peek() == '(' {
+		return p.innerList()
+	}
+	return p.item()
+}
+
+func (p *fieldParser) innerList() (item, error) {
+	if p.next() != '(' {
+		return item{}, p.errorf("expected '('")
+	}
+
+	list := item{kind: kindInnerList}
+	for !p.done() {
+		for p.peek() == ' ' {
+			p.pos++
+		}
+		if p.peek() == ')' {
+			p.pos++
+			params, err := p.parameters()
+			if err != nil {
+				return item{}, err
+			}
+			list.params = params
+			return list, nil
+		}
+
+		member, err := p.item()
+		if err != nil {
+			return item{}, err
+		}
+		list.list = append(list.list, member)
+
+		if c := p.peek(); c != ' ' && c != ')' {
+			return item{}, p.errorf("expected ' ' or ')' in inner list")
+		}
+	}
+	return item{}, p.errorf("unterminated inner list")
+}
+
+func (p *fieldParser) item() (item, error) {
+	value, err := p.bareItem()
+	if err != nil {
+		return item{}, err
+	}
+	value.params, err = p.parameters()
+	if err != nil {
+		return item{}, err
+	}
+	return value, nil
+}
+
+func (p *fieldParser) parameters() ([]param, error) {
+	var params []param
+	for p.peek() == ';' {
+		p.pos++
+		for p.peek() == ' ' {
+			p.pos++
+		}
+
+		name, err := p.key()
+		if err != nil {
+			return nil, err
+		}
+		value := item{kind: kindBoolean, boolean: true}
+		if p.peek() == '=' {
+			p.pos++
+			if value, err = p.bareItem(); err != nil {
+				return nil, err
+			}
+		}
+
+		replaced := false
+		for i := range params {
+			if params[i].name == name {
+				params[i].value = value
+				replaced = true
+			}
+		}
+		if !replaced {
+			params = append(params, param{name: name, value: value})
+		}
+	}
+	return params, nil
+}
+
+func (p *fieldParser) bareItem() (item, error) {
+	switch c := p.peek(); {
+	case c == '-' || isDigit(c):
+		return p.integer()
+	case c == '"':
+		return p.string()
+	case c == ':':
+		return p.byteSequence()
+	case c == '?':
+		return p.boolean()
+	case c == '*' || isAlpha(c):
+		return p.token()
+	default:
+		return item{}, p.errorf("invalid item")
+	}
+}
+
+func (p *fieldParser) integer() (item, error) {
+	start := p.pos
+	if p.peek() == '-' {
+		p.pos++
+	}
+	digits := p.pos
+	for isDigit(p.peek()) {
+		p.pos++
+	}
+	if p.pos == digits || p.pos-digits > 15 {
+		return item{}, p.errorf("invalid integer")
+	}
+	if p.peek() == '.' {
+		return item{}, p.errorf("decimals are not supported")
+	}
+
+	n, err := strconv.ParseInt(p.s[start:p.pos], 10, 64)
+	if err != nil {
+		return item{}, p.errorf("invalid integer")
+	}
+	return integerItem(n), nil
+}
+
+func (p *fieldParser) string() (item, error) {
+	p.pos++ // opening quote
+	var b strings.Builder
+	for !p.done() {
+		c := p.next()
+		switch {
+		case c == '\\':
+			if e := p.next(); e == '"' || e == '\\' {
+				b.WriteByte(e)
+			} else {
+				return item{}, p.errorf("invalid escape in string")
+			}
+		case c == '"':
+			return stringItem(b.String()), nil
+		case c < 0x20 || c > 0x7e:
+			return item{}, p.errorf("invalid character in string")
+		default:
+			b.WriteByte(c)
+		}
+	}
+	return item{}, p.errorf("unterminated string")
+}
+
+func (p *fieldParser) token() (item, error) {
+	start := p.pos
+	p.pos++
+	for !p.done() {
+		c := p.peek()
+		if !isTChar(c) && c != ':' && c != '/' {
+			break
+		}
+		p.pos++
+	}
+	return item{kind: kindToken, str: p.s[start:p.pos]}, nil
+}
+
+func (p *fieldParser) byteSequence() (item, error) {
+	p.pos++ // opening colon
+	end := strings.IndexByte(p.s[p.pos:], ':')
+	if end < 0 {
+		return item{}, p.errorf("unterminated byte sequence")
+	}
+
+	encoded := p.s[p.pos : p.pos+end]
+	decoded, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return item{}, p.errorf("invalid base64 in byte sequence")
+	}
+	p.pos += end + 1
+	return bytesItem(decoded), nil
+}
+
+func (p *fieldParser) boolean() (item, error) {
+	p.pos++ // question mark
+	switch p.next() {
+	case '1':
+		return item{kind: kindBoolean, boolean: true}, nil
+	case '0':
+		return item{kind: kindBoolean, boolean: false}, nil
+	default:
+		return item{}, p.errorf("invalid boolean")
+	}
+}
+
+// serializeDictionary serializes dictionary members.
+func serializeDictionary(members []member) string {
+	parts := make([]string, len(members))
+	for i, m := range members {
+		if m.value.kind == kindBoolean && m.value.boolean {
+			parts[i] = m.name + serializeParams(m.value.params)
+		} else {
+			parts[i] = m.name + "=" + serializeItem(m.value)
+		}
+	}
+	return strings.Join(parts, ", ")
+}
+
+// serializeItem serializes an item or inner list with its parameters.
+func serializeItem(v item) string {
+	if v.kind == kindInnerList {
+		members := make([]string, len(v.list))
+		for i, m := range v.list {
+			members[i] = serializeItem(m)
+		}
+		return "(" + strings.Join(members, " ") + ")" + serializeParams(v.params)
+	}
+	return serializeBareItem(v) + serializeParams(v.params)
+}
+
+func serializeParams(params []param) string {
+	var b strings.Builder
+	for _, p := range params {
+		b.WriteString(";" + p.name)
+		if p.value.kind != kindBoolean || !p.value.boolean {
+			b.WriteString("=" + serializeBareItem(p.value))
+		}
+	}
+	return b.String()
+}
+
+func serializeBareItem(v item) string {
+	switch v.kind {
+	case kindInteger:
+		return strconv.FormatInt(v.integer, 10)
+	case kindString:
+		return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(v.str) + `"`
+	case kindToken:
+		return v.str
+	case kindBytes:
+		return ":" + base64.StdEncoding.EncodeToString(v.bytes) + ":"
+	case kindBoolean:
+		if v.boolean {
+			return "?1"
+		}
+		return "?0"
+	default:
+		return ""
+	}
+}
+
+func isDigit(c byte) bool { return c >= '0' && c <= '9' }
+
+func isLCAlpha(c byte) bool { return c >= 'a' && c <= 'z' }
+
+func isAlpha(c byte) bool { return isLCAlpha(c) || (c >= 'A' && c <= 'Z') }
+
+// isTChar reports whether c is an RFC 9110 token character.
+func isTChar(c byte) bool {
+	return isAlpha(c) || isDigit(c) || strings.IndexByte("!#$%&'*+-.^_`|~", c) >= 0
+}
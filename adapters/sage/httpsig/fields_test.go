@@ -0,0 +1,85 @@
+// Copyright (C) 2025 sage-x-project
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with this program. If not, see <https://www.gnu.org/licenses/>.
+
+// SPDX-License-Identifier: LGPL-3.0-or-later
+
+package httpsig
+
+import "testing"
+
+func TestParseDictionary_RoundTrip(t *testing.T) {
+	tests := []string{
+		`sig1=("@method" "@target-uri" "content-digest");created=1618884473;keyid="did:sage:local:alice#key-1";alg="ed25519";nonce="abc"`,
+		`sig1=();created=1`,
+		`sig1=("@query-param";name="Pet" "date"), sig2=("@method");tag="sage-adk"`,
+		`sig1=:dGVzdA==:, sig2=:YW5vdGhlcg==:`,
+		`a=1, b=-42, c=token/x:y, d, e=?0;f`,
+		`a="quote \" and backslash \\"`,
+	}
+
+	for _, header := range tests {
+		members, err := parseDictionary(header)
+		if err != nil {
+			t.Errorf("parseDictionary(%q) error = %v", header, err)
+			continue
+		}
+		if got := serializeDictionary(members); got != header {
+			t.Errorf("serializeDictionary(parseDictionary(%q)) = %q", header, got)
+		}
+	}
+}
+
+func TestParseDictionary_Whitespace(t *testing.T) {
+	members, err := parseDictionary(`  sig1=("@method"  "@path");created=1 ,sig2=:dGVzdA==:  `)
+	if err != nil {
+		t.Fatalf("parseDictionary() error = %v", err)
+	}
+	want := `sig1=("@method" "@path");created=1, sig2=:dGVzdA==:`
+	if got := serializeDictionary(members); got != want {
+		t.Errorf("serializeDictionary() = %q, want %q", got, want)
+	}
+}
+
+func TestParseDictionary_DuplicateKey(t *testing.T) {
+	members, err := parseDictionary(`a=1, b=2, a=3`)
+	if err != nil {
+		t.Fatalf("parseDictionary() error = %v", err)
+	}
+	if got := serializeDictionary(members); got != `a=3, b=2` {
+		t.Errorf("serializeDictionary() = %q, want a=3, b=2", got)
+	}
+}
+
+func TestParseDictionary_Invalid(t *testing.T) {
+	tests := []string{
+		`Sig1=1`,
+		`sig1=("@method"`,
+		`sig1=("@method""@path")`,
+		`sig1="unterminated`,
+		`sig1=:not base64!:`,
+		`sig1=:dGVzdA==`,
+		`sig1=1.5`,
+		`sig1=?2`,
+		`sig1=1,`,
+		`sig1=1 sig2=2`,
+		`sig1=("\x01")`,
+	}
+
+	for _, header := range tests {
+		if _, err := parseDictionary(header); err == nil {
+			t.Errorf("parseDictionary(%q) succeeded, want error", header)
+		}
+	}
+}
@@ -0,0 +1,515 @@
+// Copyright (C) 2025 sage-x-project
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with this program. If not, see <https://www.gnu.org/licenses/>.
+
+// SPDX-License-Identifier: LGPL-3.0-or-later
+
+package httpsig
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/x509"
+	"encoding/base64"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sage-x-project/sage-adk/adapters/sage/replay"
+	"github.com/sage-x-project/sage-adk/pkg/errors"
+)
+
+const testKeyID = "did:sage:local:alice#key-1"
+
+func testKey(t *testing.T) (ed25519.PublicKey, ed25519.PrivateKey) {
+	t.Helper()
+	publicKey, privateKey, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		t.Fatalf("GenerateKey() error = %v", err)
+	}
+	return publicKey, privateKey
+}
+
+func resolverFor(keyID string, publicKey ed25519.PublicKey) KeyResolver {
+	return func(id string) (ed25519.PublicKey, error) {
+		if id != keyID {
+			return nil, errors.ErrNotFound.WithDetail("keyid", id)
+		}
+		return publicKey, nil
+	}
+}
+
+// roundTripFunc adapts a function to http.RoundTripper.
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(r *http.Request) (*http.Response, error) { return f(r) }
+
+// tamper returns a transport that changes signed requests before they are
+// sent.
+func tamper(fn func(*http.Request)) http.RoundTripper {
+	return roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		fn(r)
+		return http.DefaultTransport.RoundTrip(r)
+	})
+}
+
+// verifyingServer serves the verifier's middleware around a handler that
+// echoes the request body and records the verified result.
+func verifyingServer(t *testing.T, verifier *Verifier) (*httptest.Server, *[]*Result) {
+	t.Helper()
+
+	var results []*Result
+	server := httptest.NewServer(verifier.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		results = append(results, FromContext(r.Context()))
+		io.Copy(w, r.Body)
+	})))
+	t.Cleanup(server.Close)
+	return server, &results
+}
+
+func post(t *testing.T, client *http.Client, url, body string) (int, string) {
+	t.Helper()
+
+	req, err := http.NewRequest(http.MethodPost, url, strings.NewReader(body))
+	if err != nil {
+		t.Fatalf("NewRequest() error = %v", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		t.Fatalf("Do() error = %v", err)
+	}
+	defer resp.Body.Close()
+	respBody, _ := io.ReadAll(resp.Body)
+	return resp.StatusCode, string(respBody)
+}
+
+func TestVerifier_RFC9421Example(t *testing.T) {
+	// RFC 9421, Appendix B.2.6: Ed25519 signature with test-key-ed25519
+	der, _ := base64.StdEncoding.DecodeString("MCowBQYDK2VwAyEAJrQLj5P/89iXES9+vFgrIy29clF9CC/oPPsw3c5D0bs=")
+	key, err := x509.ParsePKIXPublicKey(der)
+	if err != nil {
+		t.Fatalf("ParsePKIXPublicKey() error = %v", err)
+	}
+
+	r := httptest.NewRequest(http.MethodPost, "http://example.com/foo?param=Value&Pet=dog", strings.NewReader(rfc9530Body))
+	r.Header.Set("Date", "Tue, 20 Apr 2021 02:07:55 GMT")
+	r.Header.Set("Content-Type", "application/json")
+	r.Header.Set("Content-Length", "18")
+	r.Header.Set(HeaderSignatureInput, `sig-b26=("date" "@method" "@path" "@authority" "content-type" "content-length");created=1618884473;keyid="test-key-ed25519"`)
+	r.Header.Set(HeaderSignature, `sig-b26=:wqcAqbmYJ2ji2glfAMaRy4gruYYnx2nEFN2HN6jrnDnQCK1u02Gb04v9EDgwUPiu4A0w6vuQv5lIp5WPpBKRCw==:`)
+
+	verifier := NewVerifier(resolverFor("test-key-ed25519", key.(ed25519.PublicKey)), &VerifierConfig{
+		Components: []string{ComponentMethod, ComponentPath, ComponentAuthority},
+	})
+	verifier.now = func() time.Time { return time.Unix(1618884473, 0) }
+
+	result, err := verifier.Verify(r)
+	if err != nil {
+		t.Fatalf("Verify() error = %v", err)
+	}
+	if result.Label != "sig-b26" || result.KeyID != "test-key-ed25519" {
+		t.Errorf("Verify() = %+v", result)
+	}
+
+	r.Header.Set("Date", "Tue, 20 Apr 2021 02:07:56 GMT")
+	if _, err := verifier.Verify(r); !errors.Is(err, errors.ErrSignatureInvalid) {
+		t.Errorf("Verify() with changed date error = %v, want ErrSignatureInvalid", err)
+	}
+}
+
+func TestSigner_Sign(t *testing.T) {
+	_, privateKey := testKey(t)
+	signer := NewSigner(StaticKey(privateKey, testKeyID), &SignerConfig{Tag: "sage-adk", Expires: time.Minute})
+	signer.now = func() time.Time { return time.Unix(1700000000, 0) }
+	signer.nonce = func() (string, error) { return "n-1", nil }
+
+	r, _ := http.NewRequest(http.MethodPost, "https://Agent.Example:443/sage/message?x=1", strings.NewReader(rfc9530Body))
+	if err := signer.Sign(r); err != nil {
+		t.Fatalf("Sign() error = %v", err)
+	}
+
+	wantInput := `sig1=("@method" "@target-uri" "@authority" "content-digest");created=1700000000;expires=1700000060;keyid="did:sage:local:alice#key-1";alg="ed25519";nonce="n-1";tag="sage-adk"`
+	if got := r.Header.Get(HeaderSignatureInput); got != wantInput {
+		t.Errorf("Signature-Input = %q, want %q", got, wantInput)
+	}
+	if got := r.Header.Get(HeaderContentDigest); got != ContentDigest([]byte(rfc9530Body)) {
+		t.Errorf("Content-Digest = %q", got)
+	}
+
+	members, _ := parseDictionary(r.Header.Get(HeaderSignatureInput))
+	base, err := signatureBase(r, members[0].value, "")
+	if err != nil {
+		t.Fatalf("signatureBase() error = %v", err)
+	}
+	wantBase := `"@method": POST
+"@target-uri": https://agent.example/sage/message?x=1
+"@authority": agent.example
+"content-digest": sha-256=:X48E9qOokqqrvdts8nOJRJN3OWDUoyWxBf7kbu9DBPE=:
+"@signature-params": ` + strings.TrimPrefix(wantInput, "sig1=")
+	if base != wantBase {
+		t.Errorf("signature base =\n%s\nwant\n%s", base, wantBase)
+	}
+
+	// The body is still there to be sent
+	body, _ := io.ReadAll(r.Body)
+	if string(body) != rfc9530Body {
+		t.Errorf("body after Sign() = %q", body)
+	}
+}
+
+func TestSigner_MissingHeader(t *testing.T) {
+	_, privateKey := testKey(t)
+	signer := NewSigner(StaticKey(privateKey, testKeyID), &SignerConfig{Headers: []string{"X-Request-ID"}})
+
+	r, _ := http.NewRequest(http.MethodGet, "http://agent.example/health", nil)
+	if err := signer.Sign(r); !errors.Is(err, errors.ErrSignatureInvalid) {
+		t.Errorf("Sign() without covered header error = %v, want ErrSignatureInvalid", err)
+	}
+
+	r.Header.Set("X-Request-ID", "req-1")
+	if err := signer.Sign(r); err != nil {
+		t.Fatalf("Sign() error = %v", err)
+	}
+	if !strings.Contains(r.Header.Get(HeaderSignatureInput), `"x-request-id"`) {
+		t.Errorf("Signature-Input %q does not cover x-request-id", r.Header.Get(HeaderSignatureInput))
+	}
+}
+
+func TestSigner_NoKey(t *testing.T) {
+	signer := NewSigner(StaticKey(nil, ""), nil)
+
+	r, _ := http.NewRequest(http.MethodGet, "http://agent.example/health", nil)
+	if err := signer.Sign(r); !errors.Is(err, errors.ErrConfigurationError) {
+		t.Errorf("Sign() error = %v, want ErrConfigurationError", err)
+	}
+}
+
+func TestMiddleware(t *testing.T) {
+	publicKey, privateKey := testKey(t)
+	verifier := NewVerifier(resolverFor(testKeyID, publicKey), &VerifierConfig{
+		Guard: replay.NewMemory(0),
+	})
+	server, results := verifyingServer(t, verifier)
+	signer := NewSigner(StaticKey(privateKey, testKeyID), &SignerConfig{
+		Headers: []string{"Content-Type"},
+	})
+
+	client := &http.Client{Transport: signer.Transport(nil)}
+	status, body := post(t, client, server.URL+"/sage/message?x=1", rfc9530Body)
+	if status != http.StatusOK {
+		t.Fatalf("status = %d (%s), want 200", status, body)
+	}
+	if body != rfc9530Body {
+		t.Errorf("handler read body %q, want %q", body, rfc9530Body)
+	}
+
+	if len(*results) != 1 || (*results)[0] == nil {
+		t.Fatalf("handler results = %v", *results)
+	}
+	result := (*results)[0]
+	if result.KeyID != testKeyID || result.Nonce == "" {
+		t.Errorf("result = %+v", result)
+	}
+	if want := append(append([]string{}, DefaultComponents...), "content-type"); strings.Join(result.Components, " ") != strings.Join(want, " ") {
+		t.Errorf("result components = %v, want %v", result.Components, want)
+	}
+
+	// Unsigned requests do not reach the handler
+	status, _ = post(t, http.DefaultClient, server.URL+"/sage/message", rfc9530Body)
+	if status != http.StatusUnauthorized {
+		t.Errorf("unsigned request status = %d, want 401", status)
+	}
+	if len(*results) != 1 {
+		t.Errorf("handler ran for an unsigned request")
+	}
+}
+
+func TestBodyLimit(t *testing.T) {
+	publicKey, privateKey := testKey(t)
+	verifier := NewVerifier(resolverFor(testKeyID, publicKey), &VerifierConfig{
+		MaxBodyBytes: 16,
+	})
+	server, results := verifyingServer(t, verifier)
+	signer := NewSigner(StaticKey(privateKey, testKeyID), nil)
+	client := &http.Client{Transport: signer.Transport(nil)}
+
+	status, body := post(t, client, server.URL+"/sage/message", `{"ok":true}`)
+	if status != http.StatusOK {
+		t.Fatalf("small body status = %d (%s), want 200", status, body)
+	}
+
+	status, _ = post(t, client, server.URL+"/sage/message", strings.Repeat("x", 17))
+	if status != http.StatusRequestEntityTooLarge {
+		t.Errorf("large body status = %d, want 413", status)
+	}
+	if len(*results) != 1 {
+		t.Errorf("handler ran for a request over the limit")
+	}
+
+	small := NewSigner(StaticKey(privateKey, testKeyID), &SignerConfig{MaxBodyBytes: 4})
+	r, _ := http.NewRequest(http.MethodPost, "http://agent.example/sage/message", strings.NewReader("too long"))
+	if err := small.Sign(r); err == nil {
+		t.Error("Sign() succeeded for a body over the limit")
+	}
+}
+
+func TestMiddleware_RejectsMismatch(t *testing.T) {
+	publicKey, privateKey := testKey(t)
+	_, otherKey := testKey(t)
+
+	tests := []struct {
+		name     string
+		signer   *SignerConfig
+		key      ed25519.PrivateKey
+		verifier *VerifierConfig
+		tamper   func(r *http.Request)
+	}{
+		{
+			name: "method changed",
+			tamper: func(r *http.Request) {
+				r.Method = http.MethodPut
+			},
+		},
+		{
+			name: "path changed",
+			tamper: func(r *http.Request) {
+				r.URL.Path = "/sage/secure"
+			},
+		},
+		{
+			name: "query changed",
+			tamper: func(r *http.Request) {
+				r.URL.RawQuery = "x=2"
+			},
+		},
+		{
+			name: "host changed",
+			tamper: func(r *http.Request) {
+				r.Host = "other.example"
+			},
+		},
+		{
+			name: "body changed",
+			tamper: func(r *http.Request) {
+				body := []byte(`{"hello": "there"}`)
+				r.Body = io.NopCloser(bytes.NewReader(body))
+				r.ContentLength = int64(len(body))
+			},
+		},
+		{
+			name: "body and digest changed",
+			tamper: func(r *http.Request) {
+				body := []byte(`{"hello": "there"}`)
+				r.Body = io.NopCloser(bytes.NewReader(body))
+				r.ContentLength = int64(len(body))
+				r.Header.Set(HeaderContentDigest, ContentDigest(body))
+			},
+		},
+		{
+			name:     "scheme differs",
+			verifier: &VerifierConfig{Scheme: "https"},
+		},
+		{
+			name:   "required component not covered",
+			signer: &SignerConfig{Components: []string{ComponentMethod, ComponentContentDigest}},
+		},
+		{
+			name: "unknown key",
+			key:  otherKey,
+		},
+		{
+			name:     "wrong tag",
+			signer:   &SignerConfig{Tag: "other"},
+			verifier: &VerifierConfig{Tag: "sage-adk"},
+		},
+		{
+			name: "signature removed",
+			tamper: func(r *http.Request) {
+				r.Header.Del(HeaderSignature)
+			},
+		},
+		{
+			name: "algorithm changed",
+			tamper: func(r *http.Request) {
+				input := r.Header.Get(HeaderSignatureInput)
+				r.Header.Set(HeaderSignatureInput, strings.Replace(input, `alg="ed25519"`, `alg="hmac-sha256"`, 1))
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			verifier := NewVerifier(resolverFor(testKeyID, publicKey), tt.verifier)
+			server, results := verifyingServer(t, verifier)
+
+			key := privateKey
+			if tt.key != nil {
+				key = tt.key
+			}
+			base := http.DefaultTransport
+			if tt.tamper != nil {
+				base = tamper(tt.tamper)
+			}
+			signer := NewSigner(StaticKey(key, testKeyID), tt.signer)
+			client := &http.Client{Transport: signer.Transport(base)}
+
+			status, body := post(t, client, server.URL+"/sage/message?x=1", rfc9530Body)
+			if status != http.StatusUnauthorized {
+				t.Errorf("status = %d (%s), want 401", status, body)
+			}
+			if len(*results) != 0 {
+				t.Errorf("handler ran for a rejected request")
+			}
+		})
+	}
+}
+
+func TestVerifier_CreatedAndExpires(t *testing.T) {
+	publicKey, privateKey := testKey(t)
+	created := time.Unix(1700000000, 0)
+
+	signer := NewSigner(StaticKey(privateKey, testKeyID), &SignerConfig{Expires: 30 * time.Second})
+	signer.now = func() time.Time { return created }
+
+	tests := []struct {
+		name    string
+		now     time.Time
+		wantErr bool
+	}{
+		{name: "fresh", now: created.Add(10 * time.Second)},
+		{name: "clock behind", now: created.Add(-time.Minute)},
+		{name: "expired", now: created.Add(30 * time.Second), wantErr: true},
+		{name: "too old", now: created.Add(-10 * time.Minute), wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodPost, "http://agent.example/sage/message", strings.NewReader(rfc9530Body))
+			if err := signer.Sign(r); err != nil {
+				t.Fatalf("Sign() error = %v", err)
+			}
+
+			verifier := NewVerifier(resolverFor(testKeyID, publicKey), nil)
+			verifier.now = func() time.Time { return tt.now }
+			_, err := verifier.Verify(r)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Verify() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestVerifier_Replay(t *testing.T) {
+	publicKey, privateKey := testKey(t)
+	signer := NewSigner(StaticKey(privateKey, testKeyID), nil)
+	verifier := NewVerifier(resolverFor(testKeyID, publicKey), &VerifierConfig{
+		Guard: replay.NewMemory(0),
+	})
+
+	r := httptest.NewRequest(http.MethodPost, "http://agent.example/sage/message", strings.NewReader(rfc9530Body))
+	if err := signer.Sign(r); err != nil {
+		t.Fatalf("Sign() error = %v", err)
+	}
+
+	if _, err := verifier.Verify(r); err != nil {
+		t.Fatalf("Verify() error = %v", err)
+	}
+	if _, err := verifier.Verify(r); !errors.Is(err, errors.ErrReplayDetected) {
+		t.Errorf("Verify() of replayed request error = %v, want ErrReplayDetected", err)
+	}
+
+	// A verifier with a guard requires nonces
+	r.Header.Set(HeaderSignatureInput, strings.Replace(r.Header.Get(HeaderSignatureInput), ";nonce=", ";x=", 1))
+	if _, err := verifier.Verify(r); !errors.Is(err, errors.ErrSignatureInvalid) {
+		t.Errorf("Verify() without nonce error = %v, want ErrSignatureInvalid", err)
+	}
+}
+
+func TestVerifier_MultipleSignatures(t *testing.T) {
+	publicKey, privateKey := testKey(t)
+	_, otherKey := testKey(t)
+
+	r := httptest.NewRequest(http.MethodPost, "http://agent.example/sage/message", strings.NewReader(rfc9530Body))
+	if err := NewSigner(StaticKey(privateKey, testKeyID), &SignerConfig{Label: "sage"}).Sign(r); err != nil {
+		t.Fatalf("Sign() error = %v", err)
+	}
+	input, signature := r.Header.Get(HeaderSignatureInput), r.Header.Get(HeaderSignature)
+
+	if err := NewSigner(StaticKey(otherKey, "did:sage:local:proxy#key-1"), nil).Sign(r); err != nil {
+		t.Fatalf("Sign() error = %v", err)
+	}
+	r.Header.Add(HeaderSignatureInput, input)
+	r.Header.Add(HeaderSignature, signature)
+
+	result, err := NewVerifier(resolverFor(testKeyID, publicKey), nil).Verify(r)
+	if err != nil {
+		t.Fatalf("Verify() error = %v", err)
+	}
+	if result.Label != "sage" {
+		t.Errorf("verified label = %q, want sage", result.Label)
+	}
+}
+
+func TestStaticKeys(t *testing.T) {
+	aliceKey, _ := testKey(t)
+	bobKey, _ := testKey(t)
+	resolve := StaticKeys(map[string]ed25519.PublicKey{
+		"did:sage:local:alice":     aliceKey,
+		"did:sage:local:bob#key-2": bobKey,
+	})
+
+	tests := []struct {
+		keyID   string
+		want    ed25519.PublicKey
+		wantErr bool
+	}{
+		{keyID: "did:sage:local:alice#key-1", want: aliceKey},
+		{keyID: "did:sage:local:alice", want: aliceKey},
+		{keyID: "did:sage:local:bob#key-2", want: bobKey},
+		{keyID: "did:sage:local:bob#key-1", wantErr: true},
+		{keyID: "did:sage:local:mallory#key-1", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		key, err := resolve(tt.keyID)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("resolve(%q) error = %v, wantErr %v", tt.keyID, err, tt.wantErr)
+			continue
+		}
+		if !tt.wantErr && !key.Equal(tt.want) {
+			t.Errorf("resolve(%q) returned another key", tt.keyID)
+		}
+	}
+}
+
+func TestResult_DID(t *testing.T) {
+	publicKey, privateKey := testKey(t)
+	verifier := NewVerifier(StaticKeys(map[string]ed25519.PublicKey{"did:sage:local:alice": publicKey}), nil)
+	server, results := verifyingServer(t, verifier)
+
+	signer := NewSigner(StaticKey(privateKey, testKeyID), nil)
+	client := &http.Client{Transport: signer.Transport(nil)}
+	if status, body := post(t, client, server.URL, rfc9530Body); status != http.StatusOK {
+		t.Fatalf("status = %d (%s), want 200", status, body)
+	}
+
+	if got := (*results)[0].DID(); got != "did:sage:local:alice" {
+		t.Errorf("DID() = %q, want did:sage:local:alice", got)
+	}
+}
@@ -0,0 +1,285 @@
+// Copyright (C) 2025 sage-x-project
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with this program. If not, see <https://www.gnu.org/licenses/>.
+
+// SPDX-License-Identifier: LGPL-3.0-or-later
+
+package httpsig
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/base64"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/sage-x-project/sage-adk/pkg/errors"
+)
+
+// Signature headers and parameters.
+const (
+	HeaderSignatureInput = "Signature-Input"
+	HeaderSignature      = "Signature"
+
+	// Algorithm is the RFC 9421 alg parameter of Ed25519 signatures.
+	Algorithm = "ed25519"
+
+	// DefaultLabel labels the signatures a Signer adds.
+	DefaultLabel = "sig1"
+
+	// DefaultMaxBodyBytes bounds the bodies read to compute or check
+	// Content-Digest.
+	DefaultMaxBodyBytes int64 = 10 << 20 // 10 MB
+)
+
+// DefaultComponents are the components signed and required by default: the
+// request's method, full target URI and authority, and its body through
+// Content-Digest.
+var DefaultComponents = []string{
+	ComponentMethod,
+	ComponentTargetURI,
+	ComponentAuthority,
+	ComponentContentDigest,
+}
+
+// KeyFunc returns the private key to sign with and its key ID. It is called
+// for every request, so a rotating key ring can be followed.
+type KeyFunc func() (ed25519.PrivateKey, string, error)
+
+// StaticKey returns a KeyFunc that always signs with key.
+func StaticKey(key ed25519.PrivateKey, keyID string) KeyFunc {
+	return func() (ed25519.PrivateKey, string, error) {
+		return key, keyID, nil
+	}
+}
+
+// SignerConfig configures a Signer.
+type SignerConfig struct {
+	// Components are the derived components and header fields to cover.
+	// Defaults to DefaultComponents.
+	Components []string
+
+	// Headers are additional header fields to cover, such as
+	// "content-type". Requests without one of them cannot be signed.
+	Headers []string
+
+	// Label names the signature in Signature-Input and Signature.
+	// Defaults to DefaultLabel.
+	Label string
+
+	// Tag is the application tag parameter, if any.
+	Tag string
+
+	// Expires sets an expires parameter this long after creation.
+	Expires time.Duration
+
+	// MaxBodyBytes bounds the bodies read for Content-Digest. Larger
+	// requests cannot be signed. Defaults to DefaultMaxBodyBytes.
+	MaxBodyBytes int64
+}
+
+// Signer adds RFC 9421 signatures to outgoing requests.
+type Signer struct {
+	key        KeyFunc
+	components []string
+	label      string
+	tag        string
+	expires    time.Duration
+	maxBody    int64
+	now        func() time.Time
+	nonce      func() (string, error)
+}
+
+// NewSigner creates a signer using the keys returned by key. A nil config
+// uses the defaults.
+func NewSigner(key KeyFunc, config *SignerConfig) *Signer {
+	if config == nil {
+		config = &SignerConfig{}
+	}
+
+	components := config.Components
+	if len(components) == 0 {
+		components = DefaultComponents
+	}
+	components = append(normalize(components), normalize(config.Headers)...)
+
+	label := config.Label
+	if label == "" {
+		label = DefaultLabel
+	}
+
+	return &Signer{
+		key:        key,
+		components: dedupe(components),
+		label:      label,
+		tag:        config.Tag,
+		expires:    config.Expires,
+		maxBody:    maxBodyBytes(config.MaxBodyBytes),
+		now:        time.Now,
+		nonce:      generateNonce,
+	}
+}
+
+// Sign signs r, replacing any Signature-Input and Signature headers. If
+// content-digest is covered, the body is read to set Content-Digest and
+// then restored.
+func (s *Signer) Sign(r *http.Request) error {
+	privateKey, keyID, err := s.key()
+	if err != nil {
+		return errors.ErrOperationFailed.
+			WithMessage("failed to get HTTP signing key").
+			WithDetail("error", err.Error())
+	}
+	if privateKey == nil {
+		return errors.ErrConfigurationError.
+			WithMessage("no HTTP signing key available")
+	}
+
+	for _, component := range s.components {
+		if component == ComponentContentDigest {
+			body, err := readBody(r, s.maxBody)
+			if err != nil {
+				return errors.ErrOperationFailed.
+					WithMessage("failed to read request body").
+					WithDetail("error", err.Error())
+			}
+			r.Header.Set(HeaderContentDigest, ContentDigest(body))
+		}
+	}
+
+	nonce, err := s.nonce()
+	if err != nil {
+		return errors.ErrOperationFailed.
+			WithMessage("failed to generate nonce").
+			WithDetail("error", err.Error())
+	}
+
+	created := s.now()
+	params := item{kind: kindInnerList}
+	for _, component := range s.components {
+		params.list = append(params.list, stringItem(component))
+	}
+	params.params = []param{
+		{name: "created", value: integerItem(created.Unix())},
+	}
+	if s.expires > 0 {
+		params.params = append(params.params,
+			param{name: "expires", value: integerItem(created.Add(s.expires).Unix())})
+	}
+	params.params = append(params.params,
+		param{name: "keyid", value: stringItem(keyID)},
+		param{name: "alg", value: stringItem(Algorithm)},
+		param{name: "nonce", value: stringItem(nonce)},
+	)
+	if s.tag != "" {
+		params.params = append(params.params, param{name: "tag", value: stringItem(s.tag)})
+	}
+
+	base, err := signatureBase(r, params, "")
+	if err != nil {
+		return err
+	}
+	signature := ed25519.Sign(privateKey, []byte(base))
+
+	r.Header.Set(HeaderSignatureInput, serializeDictionary([]member{{name: s.label, value: params}}))
+	r.Header.Set(HeaderSignature, serializeDictionary([]member{{name: s.label, value: bytesItem(signature)}}))
+	return nil
+}
+
+// Transport returns a RoundTripper that signs every request before passing
+// it to base (http.DefaultTransport if nil).
+func (s *Signer) Transport(base http.RoundTripper) http.RoundTripper {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return &signingTransport{signer: s, base: base}
+}
+
+type signingTransport struct {
+	signer *Signer
+	base   http.RoundTripper
+}
+
+// RoundTrip signs a copy of req and sends it.
+func (t *signingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	signed := req.Clone(req.Context())
+	if err := t.signer.Sign(signed); err != nil {
+		if req.Body != nil {
+			req.Body.Close()
+		}
+		return nil, err
+	}
+	return t.base.RoundTrip(signed)
+}
+
+// maxBodyBytes returns limit, or DefaultMaxBodyBytes if it is not set.
+func maxBodyBytes(limit int64) int64 {
+	if limit <= 0 {
+		return DefaultMaxBodyBytes
+	}
+	return limit
+}
+
+// readBody reads the body of r, up to limit bytes, and replaces it with an
+// unread copy. Longer bodies fail with an *http.MaxBytesError.
+func readBody(r *http.Request, limit int64) ([]byte, error) {
+	if r.Body == nil || r.Body == http.NoBody {
+		return nil, nil
+	}
+
+	body, err := io.ReadAll(http.MaxBytesReader(nil, r.Body, limit))
+	r.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	r.Body = io.NopCloser(bytes.NewReader(body))
+	r.GetBody = func() (io.ReadCloser, error) {
+		return io.NopCloser(bytes.NewReader(body)), nil
+	}
+	return body, nil
+}
+
+// normalize lowercases component names; header field names are
+// case-insensitive and always covered in lowercase.
+func normalize(components []string) []string {
+	normalized := make([]string, len(components))
+	for i, c := range components {
+		normalized[i] = strings.ToLower(strings.TrimSpace(c))
+	}
+	return normalized
+}
+
+func dedupe(components []string) []string {
+	seen := make(map[string]bool, len(components))
+	unique := components[:0]
+	for _, c := range components {
+		if c != "" && !seen[c] {
+			seen[c] = true
+			unique = append(unique, c)
+		}
+	}
+	return unique
+}
+
+func generateNonce() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
@@ -0,0 +1,380 @@
+// Copyright (C) 2025 sage-x-project
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with this program. If not, see <https://www.gnu.org/licenses/>.
+
+// SPDX-License-Identifier: LGPL-3.0-or-later
+
+package httpsig
+
+import (
+	"context"
+	"crypto/ed25519"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/sage-x-project/sage-adk/adapters/sage/replay"
+	"github.com/sage-x-project/sage-adk/pkg/errors"
+)
+
+// KeyResolver returns the Ed25519 public key a key ID names.
+type KeyResolver func(keyID string) (ed25519.PublicKey, error)
+
+// StaticKeys returns a KeyResolver for a fixed set of keys, indexed by key
+// ID ("did#key-1") or by DID. A DID entry matches every key ID of that DID.
+func StaticKeys(keys map[string]ed25519.PublicKey) KeyResolver {
+	known := make(map[string]ed25519.PublicKey, len(keys))
+	for id, key := range keys {
+		known[id] = key
+	}
+
+	return func(keyID string) (ed25519.PublicKey, error) {
+		if key, ok := known[keyID]; ok {
+			return key, nil
+		}
+		if key, ok := known[didOf(keyID)]; ok {
+			return key, nil
+		}
+		return nil, errors.ErrNotFound.
+			WithMessage("unknown signing key").
+			WithDetail("keyid", keyID)
+	}
+}
+
+// VerifierConfig configures a Verifier.
+type VerifierConfig struct {
+	// Components must all be covered by an accepted signature. Defaults to
+	// DefaultComponents.
+	Components []string
+
+	// MaxAge is how far the created parameter may be from the current
+	// time, in either direction. Defaults to replay.DefaultMaxClockSkew.
+	MaxAge time.Duration
+
+	// Guard, if set, requires a nonce parameter and rejects nonces used
+	// before. It should remember nonces for replay.Window(MaxAge).
+	Guard replay.Guard
+
+	// Tag, if set, only accepts signatures with this tag parameter.
+	Tag string
+
+	// Scheme overrides the scheme requests are assumed to arrive with, for
+	// servers behind a proxy that terminates TLS. By default it is https
+	// for TLS connections and http otherwise.
+	Scheme string
+
+	// MaxBodyBytes bounds the bodies read to check Content-Digest. Larger
+	// requests are rejected. Defaults to DefaultMaxBodyBytes.
+	MaxBodyBytes int64
+}
+
+// Result describes a verified signature.
+type Result struct {
+	// Label is the signature's label in Signature-Input.
+	Label string
+
+	// KeyID is the key the request was signed with.
+	KeyID string
+
+	// Components are the covered components.
+	Components []string
+
+	// Created is when the signature was made.
+	Created time.Time
+
+	// Nonce is the signature's nonce, if any.
+	Nonce string
+}
+
+// DID returns the DID of the signer: the key ID without its fragment.
+// Because the key was resolved from the key ID, the request was signed by
+// the holder of this DID's key.
+func (r *Result) DID() string {
+	return didOf(r.KeyID)
+}
+
+// Verifier verifies RFC 9421 signatures on incoming requests.
+type Verifier struct {
+	resolve    KeyResolver
+	components []string
+	maxAge     time.Duration
+	guard      replay.Guard
+	tag        string
+	scheme     string
+	maxBody    int64
+	now        func() time.Time
+}
+
+// NewVerifier creates a verifier resolving keys with resolve. A nil config
+// uses the defaults.
+func NewVerifier(resolve KeyResolver, config *VerifierConfig) *Verifier {
+	if config == nil {
+		config = &VerifierConfig{}
+	}
+
+	components := config.Components
+	if len(components) == 0 {
+		components = DefaultComponents
+	}
+
+	maxAge := config.MaxAge
+	if maxAge <= 0 {
+		maxAge = replay.DefaultMaxClockSkew
+	}
+
+	return &Verifier{
+		resolve:    resolve,
+		components: dedupe(normalize(components)),
+		maxAge:     maxAge,
+		guard:      config.Guard,
+		tag:        config.Tag,
+		scheme:     strings.ToLower(config.Scheme),
+		maxBody:    maxBodyBytes(config.MaxBodyBytes),
+		now:        time.Now,
+	}
+}
+
+// Verify checks the signatures on r. It succeeds if one signature (with the
+// configured tag, if any) covers the required components and verifies
+// against the request as received, including its body when content-digest
+// is covered. The body of r is restored for later handlers.
+func (v *Verifier) Verify(r *http.Request) (*Result, error) {
+	inputHeader := strings.Join(r.Header.Values(HeaderSignatureInput), ", ")
+	signatureHeader := strings.Join(r.Header.Values(HeaderSignature), ", ")
+	if inputHeader == "" || signatureHeader == "" {
+		return nil, errors.ErrSignatureInvalid.
+			WithMessage("request is not signed")
+	}
+
+	inputs, err := parseDictionary(inputHeader)
+	if err != nil {
+		return nil, errors.ErrSignatureInvalid.
+			WithMessage("malformed Signature-Input header").
+			WithDetail("error", err.Error())
+	}
+	signatures, err := parseDictionary(signatureHeader)
+	if err != nil {
+		return nil, errors.ErrSignatureInvalid.
+			WithMessage("malformed Signature header").
+			WithDetail("error", err.Error())
+	}
+
+	var firstErr error
+	for _, input := range inputs {
+		if input.value.kind != kindInnerList {
+			continue
+		}
+		if v.tag != "" {
+			if tag, ok := lookupParam(input.value.params, "tag"); !ok || tag.kind != kindString || tag.str != v.tag {
+				continue
+			}
+		}
+
+		signature, ok := lookup(signatures, input.name)
+		if !ok || signature.kind != kindBytes {
+			if firstErr == nil {
+				firstErr = errors.ErrSignatureInvalid.
+					WithMessage("missing signature for Signature-Input").
+					WithDetail("label", input.name)
+			}
+			continue
+		}
+
+		result, err := v.verify(r, input.name, input.value, signature.bytes)
+		if err == nil {
+			return result, nil
+		}
+		if firstErr == nil {
+			firstErr = err
+		}
+	}
+
+	if firstErr == nil {
+		firstErr = errors.ErrSignatureInvalid.
+			WithMessage("no acceptable signature").
+			WithDetail("tag", v.tag)
+	}
+	return nil, firstErr
+}
+
+// verify checks one signature.
+func (v *Verifier) verify(r *http.Request, label string, params item, signature []byte) (*Result, error) {
+	covered := make([]string, 0, len(params.list))
+	for _, component := range params.list {
+		covered = append(covered, component.str)
+	}
+	for _, required := range v.components {
+		if !contains(covered, required) {
+			return nil, errors.ErrSignatureInvalid.
+				WithMessage("signature does not cover a required component").
+				WithDetail("label", label).
+				WithDetail("component", required)
+		}
+	}
+
+	if alg, ok := lookupParam(params.params, "alg"); ok && (alg.kind != kindString || alg.str != Algorithm) {
+		return nil, errors.ErrSignatureInvalid.
+			WithMessage("unsupported signature algorithm").
+			WithDetail("label", label)
+	}
+
+	keyID, ok := lookupParam(params.params, "keyid")
+	if !ok || keyID.kind != kindString || keyID.str == "" {
+		return nil, errors.ErrSignatureInvalid.
+			WithMessage("signature has no keyid").
+			WithDetail("label", label)
+	}
+
+	now := v.now()
+	createdParam, ok := lookupParam(params.params, "created")
+	if !ok || createdParam.kind != kindInteger {
+		return nil, errors.ErrSignatureInvalid.
+			WithMessage("signature has no created time").
+			WithDetail("label", label)
+	}
+	created := time.Unix(createdParam.integer, 0)
+	if age := now.Sub(created); age > v.maxAge || age < -v.maxAge {
+		return nil, errors.ErrSignatureInvalid.
+			WithMessage("signature created time is out of range").
+			WithDetail("label", label).
+			WithDetail("created", created.UTC().Format(time.RFC3339))
+	}
+	if expires, ok := lookupParam(params.params, "expires"); ok {
+		if expires.kind != kindInteger || !now.Before(time.Unix(expires.integer, 0)) {
+			return nil, errors.ErrSignatureInvalid.
+				WithMessage("signature has expired").
+				WithDetail("label", label)
+		}
+	}
+
+	var nonce string
+	if n, ok := lookupParam(params.params, "nonce"); ok && n.kind == kindString {
+		nonce = n.str
+	}
+	if v.guard != nil && nonce == "" {
+		return nil, errors.ErrSignatureInvalid.
+			WithMessage("signature has no nonce").
+			WithDetail("label", label)
+	}
+
+	base, err := signatureBase(r, params, v.scheme)
+	if err != nil {
+		return nil, err
+	}
+
+	publicKey, err := v.resolve(keyID.str)
+	if err != nil {
+		return nil, errors.ErrSignatureInvalid.
+			WithMessage("failed to resolve signing key").
+			WithDetail("keyid", keyID.str).
+			WithDetail("error", err.Error())
+	}
+	if len(publicKey) != ed25519.PublicKeySize || !ed25519.Verify(publicKey, []byte(base), signature) {
+		return nil, errors.ErrSignatureInvalid.
+			WithMessage("HTTP signature does not match the request").
+			WithDetail("label", label).
+			WithDetail("keyid", keyID.str)
+	}
+
+	if contains(covered, ComponentContentDigest) {
+		body, err := readBody(r, v.maxBody)
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			return nil, errors.ErrOutOfRange.
+				WithMessage("request body too large").
+				WithDetail("limit", tooLarge.Limit)
+		}
+		if err != nil {
+			return nil, errors.ErrInvalidInput.
+				WithMessage("failed to read request body").
+				WithDetail("error", err.Error())
+		}
+		digest := strings.Join(r.Header.Values(HeaderContentDigest), ", ")
+		if err := VerifyContentDigest(digest, body); err != nil {
+			return nil, err
+		}
+	}
+
+	// Nonces are only recorded for valid signatures, so forged requests
+	// cannot use them up
+	if v.guard != nil {
+		if err := v.guard.Check(r.Context(), nonce); err != nil {
+			return nil, err
+		}
+	}
+
+	return &Result{
+		Label:      label,
+		KeyID:      keyID.str,
+		Components: covered,
+		Created:    created,
+		Nonce:      nonce,
+	}, nil
+}
+
+// Middleware verifies requests before passing them to next. Requests that
+// fail verification are answered with 401 Unauthorized, or 413 Request
+// Entity Too Large if their body exceeds the limit; the Result of a
+// verified request is available to next through FromContext.
+func (v *Verifier) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		result, err := v.Verify(r)
+		if errors.Is(err, errors.ErrOutOfRange) {
+			http.Error(w, err.Error(), http.StatusRequestEntityTooLarge)
+			return
+		}
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusUnauthorized)
+			return
+		}
+		next.ServeHTTP(w, r.WithContext(WithResult(r.Context(), result)))
+	})
+}
+
+type contextKey string
+
+const resultKey contextKey = "httpsig_result"
+
+// WithResult adds a verified signature to the context.
+func WithResult(ctx context.Context, result *Result) context.Context {
+	return context.WithValue(ctx, resultKey, result)
+}
+
+// FromContext returns the verified signature stored by Middleware, or nil.
+// Handlers behind the middleware use it to learn who sent a request.
+func FromContext(ctx context.Context) *Result {
+	if v := ctx.Value(resultKey); v != nil {
+		if result, ok := v.(*Result); ok {
+			return result
+		}
+	}
+	return nil
+}
+
+// didOf returns the DID part of a key ID.
+func didOf(keyID string) string {
+	if i := strings.Index(keyID, "#"); i >= 0 {
+		return keyID[:i]
+	}
+	return keyID
+}
+
+func contains(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,159 @@
+// Copyright (C) 2025 sage-x-project
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with this program. If not, see <https://www.gnu.org/licenses/>.
+
+// SPDX-License-Identifier: LGPL-3.0-or-later
+
+package sage
+
+import (
+	"context"
+	"crypto/ed25519"
+	"encoding/base64"
+	"time"
+
+	"github.com/sage-x-project/sage-adk/adapters/sage/keyring"
+	"github.com/sage-x-project/sage-adk/adapters/sage/keystore"
+	"github.com/sage-x-project/sage-adk/adapters/sage/registry"
+	"github.com/sage-x-project/sage-adk/pkg/errors"
+)
+
+// loadKeyRing loads the key ring at path, decrypting its private keys with
+// passphrase, and checks that it belongs to agentDID and has an active key.
+func loadKeyRing(path, agentDID string, passphrase keystore.PassphraseFunc) (*keyring.Ring, ed25519.PrivateKey, error) {
+	ring, err := keyring.Load(path, passphrase)
+	if err != nil {
+		return nil, nil, errors.ErrConfigurationError.
+			WithMessage("failed to load key ring").
+			WithDetail("path", path).
+			WithDetail("error", err.Error())
+	}
+
+	if ring.DID() != agentDID {
+		return nil, nil, errors.ErrConfigurationError.
+			WithMessage("key ring belongs to another DID").
+			WithDetail("path", path).
+			WithDetail("did", agentDID).
+			WithDetail("key_ring_did", ring.DID())
+	}
+
+	signer, err := ring.Signer(time.Now())
+	if err != nil {
+		return nil, nil, errors.ErrConfigurationError.
+			WithMessage("key ring has no active key").
+			WithDetail("path", path)
+	}
+
+	return ring, signer.PrivateKey, nil
+}
+
+// PublishKeys writes the keys of a ring that verifiers should accept to the
+// DID's registry record, registering the DID if needed. The active key
+// becomes the record's primary key.
+func PublishKeys(ctx context.Context, reg registry.Registry, ring *keyring.Ring) error {
+	record, err := reg.Lookup(ctx, ring.DID())
+	registered := err == nil
+	if !registered {
+		if !errors.Is(err, errors.ErrDIDNotFound) {
+			return err
+		}
+		record = &registry.Record{DID: ring.DID()}
+	}
+
+	record.Keys = nil
+	for _, key := range ring.Published(time.Now()) {
+		publicKey := base64.StdEncoding.EncodeToString(key.PublicKey)
+		record.Keys = append(record.Keys, registry.VerificationKey{
+			ID:        key.ID,
+			PublicKey: publicKey,
+			NotAfter:  key.NotAfter,
+		})
+		if key.Status == keyring.StatusActive {
+			record.PublicKey = publicKey
+		}
+	}
+
+	if registered {
+		return reg.Update(ctx, record)
+	}
+	return reg.Register(ctx, record)
+}
+
+// KeyRing returns the adapter's key ring, or nil if it signs with a single
+// key.
+func (a *Adapter) KeyRing() *keyring.Ring {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	return a.keyRing
+}
+
+// ReloadKeyRing re-reads the configured key ring, for example after
+// "adk keys rotate", with the passphrase of the configured passphrase
+// source. Messages, handshakes and streams signed afterwards use the new
+// active key.
+func (a *Adapter) ReloadKeyRing() error {
+	if a.config.KeyRingPath == "" {
+		return errors.ErrConfigurationError.
+			WithMessage("no key ring configured").
+			WithDetail("field", "KeyRingPath")
+	}
+
+	passphrase := keystore.DefaultPassphrase(a.config.PassphraseFile)
+	ring, privateKey, err := loadKeyRing(a.config.KeyRingPath, a.agentDID, passphrase)
+	if err != nil {
+		return err
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.keyRing = ring
+	a.privateKey = privateKey
+	return nil
+}
+
+// SigningKey returns the private key the adapter currently signs with and
+// its key ID, following key ring rotations. It fits audit.KeyFunc, so an
+// audit log can sign its checkpoints with the agent's key.
+func (a *Adapter) SigningKey() (ed25519.PrivateKey, string, error) {
+	key, keyID, err := a.signingKey()
+	if err != nil {
+		return nil, "", err
+	}
+	if key == nil {
+		return nil, "", errors.ErrConfigurationError.WithMessage("no private key loaded")
+	}
+	return key, keyID, nil
+}
+
+// signingKey returns the private key and key ID to sign with.
+func (a *Adapter) signingKey() (ed25519.PrivateKey, string, error) {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	return a.signingKeyLocked()
+}
+
+// signingKeyLocked is signingKey for callers holding a.mu. Without a key
+// ring, the loaded private key signs as "#key-1"; without any key, it
+// returns a nil key.
+func (a *Adapter) signingKeyLocked() (ed25519.PrivateKey, string, error) {
+	if a.keyRing == nil {
+		return a.privateKey, a.agentDID + "#key-1", nil
+	}
+
+	key, err := a.keyRing.Signer(time.Now())
+	if err != nil {
+		return nil, "", err
+	}
+	return key.PrivateKey, a.keyRing.KeyID(key), nil
+}
@@ -0,0 +1,45 @@
+// Copyright (C) 2025 sage-x-project
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with this program. If not, see <https://www.gnu.org/licenses/>.
+
+// SPDX-License-Identifier: LGPL-3.0-or-later
+// Package keyring manages rotating Ed25519 signing keys for a SAGE agent.
+//
+// A Ring holds versioned keys for one DID ("did#key-1", "did#key-2", ...)
+// in three roles:
+//
+//   - active: signs new messages
+//   - next: published ahead of time, becomes active at the next rotation
+//   - retired: a former active key that still verifies signatures until
+//     the end of its overlap window
+//
+// Rotating promotes the next key, retires the active one and generates a
+// new next key, so keys can be replaced without a flag day: verifiers
+// accept both the old and new key while the overlap lasts.
+//
+//	passphrase := keystore.DefaultPassphrase("/run/secrets/key-passphrase")
+//	ring, err := keyring.Load("keys.json", passphrase)
+//	key, err := ring.Signer(time.Now())
+//	keyID := ring.KeyID(key) // "did:sage:eth:0xabc#key-2"
+//
+//	ring.Rotate(time.Now(), keyring.DefaultOverlap)
+//	err = ring.Save("keys.json", passphrase, nil)
+//
+// Private keys are saved encrypted with the passphrase, like the single
+// keys of package keystore. A ring loaded with a nil passphrase holds the
+// public keys only.
+//
+// Published lists the keys to advertise for the DID, for example in a
+// local registry entry; "adk keys rotate" does this from the command line.
+package keyring
@@ -0,0 +1,496 @@
+// Copyright (C) 2025 sage-x-project
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with this program. If not, see <https://www.gnu.org/licenses/>.
+
+// SPDX-License-Identifier: LGPL-3.0-or-later
+
+package keyring
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sage-x-project/sage-adk/adapters/sage/keystore"
+	"github.com/sage-x-project/sage-adk/pkg/errors"
+)
+
+// DefaultOverlap is how long a retired key keeps verifying signatures
+// after a rotation.
+const DefaultOverlap = 24 * time.Hour
+
+// Status is the role of a key in a ring.
+type Status string
+
+const (
+	// StatusActive marks the key that signs new messages.
+	StatusActive Status = "active"
+
+	// StatusNext marks the key that becomes active at the next rotation.
+	// It is published ahead of time so verifiers know it before it is used.
+	StatusNext Status = "next"
+
+	// StatusRetired marks a former active key. It verifies signatures until
+	// NotAfter and is then pruned.
+	StatusRetired Status = "retired"
+)
+
+// Key is a versioned Ed25519 signing key.
+type Key struct {
+	// ID is the key ID fragment ("key-3").
+	ID string `json:"id"`
+
+	// Version increases with every generated key.
+	Version int `json:"version"`
+
+	// Status is the key's role in the ring.
+	Status Status `json:"status"`
+
+	// PublicKey and PrivateKey are the key pair. PrivateKey is omitted from
+	// published keys.
+	PublicKey  ed25519.PublicKey  `json:"public_key"`
+	PrivateKey ed25519.PrivateKey `json:"private_key,omitempty"`
+
+	// NotBefore and NotAfter bound when signatures by the key are accepted.
+	// A nil NotAfter means the key does not expire.
+	NotBefore time.Time  `json:"not_before"`
+	NotAfter  *time.Time `json:"not_after,omitempty"`
+
+	// sealed is the passphrase-encrypted private key as saved, so a ring
+	// loaded without its passphrase keeps its private keys when saved.
+	sealed *keystore.EncryptedKey
+}
+
+// ValidAt returns true if signatures by the key are accepted at t.
+func (k *Key) ValidAt(t time.Time) bool {
+	if t.Before(k.NotBefore) {
+		return false
+	}
+	return k.NotAfter == nil || t.Before(*k.NotAfter)
+}
+
+// Public returns a copy of the key without its private half.
+func (k *Key) Public() *Key {
+	public := *k
+	public.PrivateKey = nil
+	public.sealed = nil
+	return &public
+}
+
+// Ring holds the active, next and retired signing keys of a DID.
+//
+// A ring is safe for concurrent use.
+type Ring struct {
+	mu   sync.RWMutex
+	did  string
+	keys []*Key
+}
+
+// ringFile is the on-disk layout of a ring.
+type ringFile struct {
+	DID  string      `json:"did"`
+	Keys []*savedKey `json:"keys"`
+}
+
+// savedKey is a key as saved: its private half is encrypted with the
+// ring's passphrase. Rings saved without encryption carry the private key
+// in plain text, which Load still accepts.
+type savedKey struct {
+	*Key
+	EncryptedPrivateKey *keystore.EncryptedKey `json:"encrypted_private_key,omitempty"`
+}
+
+// New creates a ring for did with a freshly generated active key and next
+// key.
+func New(did string, now time.Time) (*Ring, error) {
+	if did == "" {
+		return nil, errors.ErrMissingField.WithDetail("field", "did")
+	}
+
+	r := &Ring{did: did}
+	for _, status := range []Status{StatusActive, StatusNext} {
+		key, err := r.generate(status, now)
+		if err != nil {
+			return nil, err
+		}
+		r.keys = append(r.keys, key)
+	}
+	return r, nil
+}
+
+// Load reads a ring from a file written by Save, decrypting its private
+// keys with the passphrase. With a nil passphrase only the public keys are
+// loaded; such a ring verifies but cannot sign, and saving it keeps the
+// encrypted private keys.
+func Load(path string, passphrase keystore.PassphraseFunc) (*Ring, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, errors.ErrStorageConnection.
+			WithMessage("failed to read key ring").
+			WithDetail("path", path).
+			WithDetail("error", err.Error())
+	}
+
+	var file ringFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, errors.ErrInvalidFormat.
+			WithMessage("failed to parse key ring").
+			WithDetail("path", path).
+			WithDetail("error", err.Error())
+	}
+
+	r := &Ring{did: file.DID}
+	var secret []byte
+	for _, saved := range file.Keys {
+		if saved == nil || saved.Key == nil {
+			return nil, errors.ErrInvalidFormat.
+				WithMessage("key ring has an empty key").
+				WithDetail("path", path)
+		}
+		key := saved.Key
+		if sealed := saved.EncryptedPrivateKey; sealed != nil {
+			if !key.PublicKey.Equal(ed25519.PublicKey(sealed.PublicKey)) {
+				return nil, errors.ErrInvalidFormat.
+					WithMessage("key ring private key does not match its public key").
+					WithDetail("id", key.ID)
+			}
+			key.sealed = sealed
+			if passphrase != nil {
+				if secret == nil {
+					if secret, err = passphrase(); err != nil {
+						return nil, err
+					}
+				}
+				if key.PrivateKey, err = sealed.Decrypt(secret); err != nil {
+					return nil, err
+				}
+			}
+		}
+		r.keys = append(r.keys, key)
+	}
+
+	if err := r.validate(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// Save writes the ring to a file readable only by its owner, replacing it
+// atomically. Private keys are encrypted with the passphrase, which is
+// only asked for if a key has not been encrypted yet; a nil opts uses
+// keystore.DefaultOptions.
+func (r *Ring) Save(path string, passphrase keystore.PassphraseFunc, opts *keystore.Options) error {
+	r.mu.Lock()
+	file, err := r.sealLocked(passphrase, opts)
+	r.mu.Unlock()
+	if err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(file, "", "  ")
+	if err != nil {
+		return errors.ErrOperationFailed.
+			WithMessage("failed to encode key ring").
+			WithDetail("error", err.Error())
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*")
+	if err == nil {
+		_, err = tmp.Write(data)
+		if closeErr := tmp.Close(); err == nil {
+			err = closeErr
+		}
+		if err == nil {
+			err = os.Rename(tmp.Name(), path)
+		}
+		if err != nil {
+			os.Remove(tmp.Name())
+		}
+	}
+	if err != nil {
+		return errors.ErrStorageConnection.
+			WithMessage("failed to write key ring").
+			WithDetail("path", path).
+			WithDetail("error", err.Error())
+	}
+	return nil
+}
+
+// sealLocked encrypts the private keys not encrypted yet and returns the
+// ring's on-disk form. Callers hold r.mu.
+func (r *Ring) sealLocked(passphrase keystore.PassphraseFunc, opts *keystore.Options) (*ringFile, error) {
+	file := &ringFile{DID: r.did}
+	var secret []byte
+	for _, key := range r.keys {
+		if key.sealed == nil && key.PrivateKey != nil {
+			if secret == nil {
+				if passphrase == nil {
+					return nil, errors.ErrInvalidInput.WithMessage("a passphrase is required to save private keys")
+				}
+				var err error
+				if secret, err = passphrase(); err != nil {
+					return nil, err
+				}
+			}
+			sealed, err := keystore.Encrypt(key.PrivateKey, secret, opts)
+			if err != nil {
+				return nil, err
+			}
+			key.sealed = sealed
+		}
+		file.Keys = append(file.Keys, &savedKey{Key: key.Public(), EncryptedPrivateKey: key.sealed})
+	}
+	return file, nil
+}
+
+// DID returns the DID the ring's keys belong to.
+func (r *Ring) DID() string {
+	return r.did
+}
+
+// KeyID returns the full key ID ("did#key-3") of a key.
+func (r *Ring) KeyID(key *Key) string {
+	return r.did + "#" + key.ID
+}
+
+// Signer returns the active key, which signs new messages.
+func (r *Ring) Signer(now time.Time) (*Key, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, key := range r.keys {
+		if key.Status == StatusActive && key.ValidAt(now) && key.PrivateKey != nil {
+			return key, nil
+		}
+	}
+	return nil, errors.ErrNotFound.
+		WithMessage("key ring has no usable active key").
+		WithDetail("did", r.did)
+}
+
+// Lookup returns the key with the given full or fragment-only key ID.
+func (r *Ring) Lookup(keyID string) (*Key, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.lookup(keyID)
+}
+
+func (r *Ring) lookup(keyID string) (*Key, error) {
+	fragment := keyID
+	if i := strings.Index(keyID, "#"); i >= 0 {
+		if keyID[:i] != r.did {
+			return nil, errors.ErrNotFound.
+				WithMessage("key ID belongs to another DID").
+				WithDetail("key_id", keyID)
+		}
+		fragment = keyID[i+1:]
+	}
+
+	for _, key := range r.keys {
+		if key.ID == fragment {
+			return key, nil
+		}
+	}
+	return nil, errors.ErrNotFound.
+		WithMessage("unknown key ID").
+		WithDetail("key_id", keyID)
+}
+
+// VerificationKey returns the public key for keyID if signatures by it are
+// accepted at now: the active and next keys, and retired keys within their
+// overlap window.
+func (r *Ring) VerificationKey(keyID string, now time.Time) (ed25519.PublicKey, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	key, err := r.lookup(keyID)
+	if err != nil {
+		return nil, err
+	}
+	if !key.ValidAt(now) {
+		return nil, errors.ErrSignatureInvalid.
+			WithMessage("signing key is outside its validity window").
+			WithDetail("key_id", keyID)
+	}
+	return key.PublicKey, nil
+}
+
+// Published returns public copies of the keys verifiers should accept at
+// now, ordered by version. They are what a DID document or registry entry
+// lists for the DID.
+func (r *Ring) Published(now time.Time) []*Key {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var keys []*Key
+	for _, key := range r.keys {
+		if key.ValidAt(now) {
+			keys = append(keys, key.Public())
+		}
+	}
+	return keys
+}
+
+// Keys returns public copies of all keys in the ring, ordered by version.
+func (r *Ring) Keys() []*Key {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	keys := make([]*Key, len(r.keys))
+	for i, key := range r.keys {
+		keys[i] = key.Public()
+	}
+	return keys
+}
+
+// Rotate promotes the next key to active and generates a new next key.
+//
+// The previously active key is retired but keeps verifying signatures for
+// overlap, so messages signed just before the rotation, and peers that
+// have not yet reloaded the DID's keys, are not rejected. Retired keys
+// whose overlap has ended are pruned. Rotate returns the new active key.
+func (r *Ring) Rotate(now time.Time, overlap time.Duration) (*Key, error) {
+	if overlap < 0 {
+		return nil, errors.ErrInvalidValue.
+			WithMessage("overlap cannot be negative").
+			WithDetail("overlap", overlap.String())
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var next *Key
+	for _, key := range r.keys {
+		switch key.Status {
+		case StatusActive:
+			notAfter := now.Add(overlap)
+			key.Status = StatusRetired
+			key.NotAfter = &notAfter
+		case StatusNext:
+			if next == nil {
+				next = key
+			}
+		}
+	}
+
+	if next == nil {
+		key, err := r.generate(StatusActive, now)
+		if err != nil {
+			return nil, err
+		}
+		r.keys = append(r.keys, key)
+		next = key
+	}
+	next.Status = StatusActive
+	if next.NotBefore.After(now) {
+		next.NotBefore = now
+	}
+
+	upcoming, err := r.generate(StatusNext, now)
+	if err != nil {
+		return nil, err
+	}
+	r.keys = append(r.keys, upcoming)
+
+	kept := r.keys[:0]
+	for _, key := range r.keys {
+		if key.Status == StatusRetired && !key.ValidAt(now) {
+			continue
+		}
+		kept = append(kept, key)
+	}
+	r.keys = kept
+
+	return next, nil
+}
+
+// generate creates a key with the next version number.
+func (r *Ring) generate(status Status, now time.Time) (*Key, error) {
+	publicKey, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, errors.ErrOperationFailed.
+			WithMessage("failed to generate key").
+			WithDetail("error", err.Error())
+	}
+
+	version := 1
+	for _, key := range r.keys {
+		if key.Version >= version {
+			version = key.Version + 1
+		}
+	}
+
+	return &Key{
+		ID:         fmt.Sprintf("key-%d", version),
+		Version:    version,
+		Status:     status,
+		PublicKey:  publicKey,
+		PrivateKey: privateKey,
+		NotBefore:  now,
+	}, nil
+}
+
+// validate checks a loaded ring and orders its keys by version.
+func (r *Ring) validate() error {
+	if r.did == "" {
+		return errors.ErrInvalidFormat.WithMessage("key ring has no DID")
+	}
+
+	seen := make(map[string]bool)
+	active := 0
+	for _, key := range r.keys {
+		if key.ID == "" || seen[key.ID] {
+			return errors.ErrInvalidFormat.
+				WithMessage("key ring has a missing or duplicate key ID").
+				WithDetail("id", key.ID)
+		}
+		seen[key.ID] = true
+
+		if len(key.PublicKey) != ed25519.PublicKeySize ||
+			(key.PrivateKey != nil && len(key.PrivateKey) != ed25519.PrivateKeySize) {
+			return errors.ErrInvalidFormat.
+				WithMessage("key ring has a malformed key").
+				WithDetail("id", key.ID)
+		}
+		if key.PrivateKey != nil && !key.PrivateKey.Public().(ed25519.PublicKey).Equal(key.PublicKey) {
+			return errors.ErrInvalidFormat.
+				WithMessage("key ring private key does not match its public key").
+				WithDetail("id", key.ID)
+		}
+
+		switch key.Status {
+		case StatusActive:
+			active++
+		case StatusNext, StatusRetired:
+		default:
+			return errors.ErrInvalidFormat.
+				WithMessage("key ring has an unknown key status").
+				WithDetail("id", key.ID).
+				WithDetail("status", string(key.Status))
+		}
+	}
+	if active > 1 {
+		return errors.ErrInvalidFormat.WithMessage("key ring has more than one active key")
+	}
+
+	sort.Slice(r.keys, func(i, j int) bool { return r.keys[i].Version < r.keys[j].Version })
+	return nil
+}
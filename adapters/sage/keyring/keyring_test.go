@@ -0,0 +1,211 @@
+// Copyright (C) 2025 sage-x-project
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with this program. If not, see <https://www.gnu.org/licenses/>.
+
+// SPDX-License-Identifier: LGPL-3.0-or-later
+
+package keyring
+
+import (
+	"bytes"
+	"encoding/base64"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/sage-x-project/sage-adk/adapters/sage/keystore"
+	"github.com/sage-x-project/sage-adk/pkg/errors"
+)
+
+const testDID = "did:sage:local:alice"
+
+var (
+	testPassphrase = keystore.StaticPassphrase([]byte("ring passphrase"))
+	testOptions    = &keystore.Options{KDF: keystore.KDFScrypt, ScryptN: 1 << 10, ScryptR: 8, ScryptP: 1}
+)
+
+func TestNew(t *testing.T) {
+	now := time.Now()
+	ring, err := New(testDID, now)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	signer, err := ring.Signer(now)
+	if err != nil {
+		t.Fatalf("Signer() error = %v", err)
+	}
+	if signer.ID != "key-1" || ring.KeyID(signer) != testDID+"#key-1" {
+		t.Errorf("Signer() = %s, want key-1", signer.ID)
+	}
+
+	keys := ring.Keys()
+	if len(keys) != 2 || keys[1].Status != StatusNext || keys[1].PrivateKey != nil {
+		t.Errorf("Keys() = %+v, want active and next public keys", keys)
+	}
+
+	if _, err := New("", now); err == nil {
+		t.Error("New() without DID should fail")
+	}
+}
+
+func TestRing_Rotate(t *testing.T) {
+	now := time.Now()
+	ring, _ := New(testDID, now)
+	old, _ := ring.Signer(now)
+
+	rotated := now.Add(time.Hour)
+	active, err := ring.Rotate(rotated, time.Hour)
+	if err != nil {
+		t.Fatalf("Rotate() error = %v", err)
+	}
+	if active.ID != "key-2" {
+		t.Errorf("Rotate() = %s, want key-2", active.ID)
+	}
+	if signer, _ := ring.Signer(rotated); signer.ID != "key-2" {
+		t.Errorf("Signer() after rotation = %s, want key-2", signer.ID)
+	}
+
+	// The old key verifies during the overlap window only.
+	if _, err := ring.VerificationKey(ring.KeyID(old), rotated.Add(30*time.Minute)); err != nil {
+		t.Errorf("VerificationKey() of retired key within overlap error = %v", err)
+	}
+	if _, err := ring.VerificationKey(old.ID, rotated.Add(2*time.Hour)); !errors.Is(err, errors.ErrSignatureInvalid) {
+		t.Errorf("VerificationKey() of retired key after overlap error = %v, want ErrSignatureInvalid", err)
+	}
+
+	// The new next key is published immediately.
+	if _, err := ring.VerificationKey("key-3", rotated); err != nil {
+		t.Errorf("VerificationKey() of next key error = %v", err)
+	}
+	if got := len(ring.Published(rotated)); got != 3 {
+		t.Errorf("Published() = %d keys, want 3", got)
+	}
+
+	// A second rotation after the overlap prunes the expired key.
+	if _, err := ring.Rotate(rotated.Add(2*time.Hour), 0); err != nil {
+		t.Fatalf("Rotate() error = %v", err)
+	}
+	if _, err := ring.Lookup("key-1"); !errors.Is(err, errors.ErrNotFound) {
+		t.Errorf("Lookup() of pruned key error = %v, want ErrNotFound", err)
+	}
+	if _, err := ring.Rotate(rotated, -time.Second); err == nil {
+		t.Error("Rotate() with negative overlap should fail")
+	}
+}
+
+func TestRing_Lookup(t *testing.T) {
+	ring, _ := New(testDID, time.Now())
+
+	if _, err := ring.Lookup(testDID + "#key-2"); err != nil {
+		t.Errorf("Lookup() by full key ID error = %v", err)
+	}
+	if _, err := ring.Lookup("did:sage:local:mallory#key-1"); err == nil {
+		t.Error("Lookup() of another DID's key ID should fail")
+	}
+	if _, err := ring.Lookup("key-9"); !errors.Is(err, errors.ErrNotFound) {
+		t.Errorf("Lookup() of unknown key error = %v, want ErrNotFound", err)
+	}
+}
+
+func TestRing_SaveLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "keys.json")
+	now := time.Now()
+	ring, _ := New(testDID, now)
+	ring.Rotate(now, DefaultOverlap)
+
+	if err := ring.Save(path, nil, testOptions); err == nil {
+		t.Error("Save() without passphrase should fail")
+	}
+	if err := ring.Save(path, testPassphrase, testOptions); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if perm := info.Mode().Perm(); perm != 0o600 {
+		t.Errorf("key ring mode = %o, want 600", perm)
+	}
+
+	// Private keys are only stored encrypted
+	data, _ := os.ReadFile(path)
+	original, _ := ring.Signer(now)
+	if bytes.Contains(data, []byte(base64.StdEncoding.EncodeToString(original.PrivateKey))) {
+		t.Error("Save() wrote a private key in plain text")
+	}
+	if _, err := Load(path, keystore.StaticPassphrase([]byte("wrong"))); err == nil {
+		t.Error("Load() with a wrong passphrase should fail")
+	}
+
+	loaded, err := Load(path, testPassphrase)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if loaded.DID() != testDID {
+		t.Errorf("DID() = %s", loaded.DID())
+	}
+	signer, err := loaded.Signer(now)
+	if err != nil || signer.ID != original.ID || !signer.PrivateKey.Equal(original.PrivateKey) {
+		t.Errorf("Signer() after Load() = %v, %v", signer, err)
+	}
+	if len(loaded.Keys()) != 3 {
+		t.Errorf("Keys() after Load() = %d, want 3", len(loaded.Keys()))
+	}
+
+	// Without the passphrase the ring verifies but cannot sign, and saving
+	// it keeps the encrypted keys
+	public, err := Load(path, nil)
+	if err != nil {
+		t.Fatalf("Load() without passphrase error = %v", err)
+	}
+	if _, err := public.Signer(now); err == nil {
+		t.Error("Signer() of a ring loaded without passphrase should fail")
+	}
+	if _, err := public.VerificationKey(testDID+"#"+original.ID, now); err != nil {
+		t.Errorf("VerificationKey() error = %v", err)
+	}
+	if err := public.Save(path, nil, testOptions); err != nil {
+		t.Fatalf("Save() of a public ring error = %v", err)
+	}
+	if reloaded, err := Load(path, testPassphrase); err != nil {
+		t.Errorf("Load() after saving the public ring error = %v", err)
+	} else if signer, err := reloaded.Signer(now); err != nil || !signer.PrivateKey.Equal(original.PrivateKey) {
+		t.Errorf("Signer() after saving the public ring = %v, %v", signer, err)
+	}
+}
+
+func TestLoad_Invalid(t *testing.T) {
+	dir := t.TempDir()
+	tests := map[string]string{
+		"not json":     "keys",
+		"no did":       `{"keys": []}`,
+		"bad status":   `{"did": "did:x:y", "keys": [{"id": "key-1", "status": "old", "public_key": "11qYAYKxCrfVS/7TyWQHOg7hcvPapiMlrwIaaPcHURo="}]}`,
+		"short key":    `{"did": "did:x:y", "keys": [{"id": "key-1", "status": "active", "public_key": "AAAA"}]}`,
+		"duplicate id": `{"did": "did:x:y", "keys": [{"id": "key-1", "status": "next", "public_key": "11qYAYKxCrfVS/7TyWQHOg7hcvPapiMlrwIaaPcHURo="}, {"id": "key-1", "status": "next", "public_key": "11qYAYKxCrfVS/7TyWQHOg7hcvPapiMlrwIaaPcHURo="}]}`,
+	}
+
+	for name, content := range tests {
+		path := filepath.Join(dir, "keys.json")
+		os.WriteFile(path, []byte(content), 0o600)
+		if _, err := Load(path, testPassphrase); err == nil {
+			t.Errorf("%s: Load() should fail", name)
+		}
+	}
+
+	if _, err := Load(filepath.Join(dir, "missing.json"), testPassphrase); err == nil {
+		t.Error("Load() of missing file should fail")
+	}
+}
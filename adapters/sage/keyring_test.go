@@ -0,0 +1,151 @@
+// Copyright (C) 2025 sage-x-project
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with this program. If not, see <https://www.gnu.org/licenses/>.
+
+// SPDX-License-Identifier: LGPL-3.0-or-later
+
+package sage
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/sage-x-project/sage-adk/adapters/sage/keyring"
+	"github.com/sage-x-project/sage-adk/adapters/sage/keystore"
+	"github.com/sage-x-project/sage-adk/adapters/sage/registry"
+	"github.com/sage-x-project/sage-adk/config"
+	"github.com/sage-x-project/sage-adk/pkg/types"
+)
+
+// testRingPassphrase encrypts test key rings; agents read it from
+// keystore.DefaultPassphraseEnv.
+const testRingPassphrase = "ring passphrase"
+
+// saveRing saves ring encrypted with testRingPassphrase and a cheap KDF.
+func saveRing(t *testing.T, ring *keyring.Ring, path string) {
+	t.Helper()
+
+	t.Setenv(keystore.DefaultPassphraseEnv, testRingPassphrase)
+	opts := &keystore.Options{KDF: keystore.KDFScrypt, ScryptN: 1 << 10, ScryptR: 8, ScryptP: 1}
+	if err := ring.Save(path, keystore.StaticPassphrase([]byte(testRingPassphrase)), opts); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+}
+
+func TestAdapter_KeyRotation(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	ringPath := filepath.Join(dir, "keys.json")
+	registryPath := filepath.Join(dir, "registry.json")
+	reg := registry.NewFileRegistry(registryPath)
+
+	ring, err := keyring.New("did:sage:local:alice", time.Now())
+	if err != nil {
+		t.Fatalf("keyring.New() error = %v", err)
+	}
+	saveRing(t, ring, ringPath)
+	if err := PublishKeys(ctx, reg, ring); err != nil {
+		t.Fatalf("PublishKeys() error = %v", err)
+	}
+
+	sender, err := NewAdapter(&config.SAGEConfig{Network: "local", DID: "did:sage:local:alice", KeyRingPath: ringPath})
+	if err != nil {
+		t.Fatalf("NewAdapter() error = %v", err)
+	}
+	receiver, err := NewAdapter(&config.SAGEConfig{Network: "local", DID: "did:sage:local:bob", Registry: registryPath})
+	if err != nil {
+		t.Fatalf("NewAdapter() error = %v", err)
+	}
+
+	before := types.NewMessage(types.MessageRoleUser, []types.Part{types.NewTextPart("before")})
+	sender.SendMessage(ctx, before)
+	if got := before.Security.Signature.KeyID; got != "did:sage:local:alice#key-1" {
+		t.Errorf("key ID before rotation = %s, want #key-1", got)
+	}
+
+	// Rotate, publish and reload: new messages use key-2
+	ring.Rotate(time.Now(), time.Hour)
+	saveRing(t, ring, ringPath)
+	if err := PublishKeys(ctx, reg, ring); err != nil {
+		t.Fatalf("PublishKeys() error = %v", err)
+	}
+	if err := sender.ReloadKeyRing(); err != nil {
+		t.Fatalf("ReloadKeyRing() error = %v", err)
+	}
+
+	after := types.NewMessage(types.MessageRoleUser, []types.Part{types.NewTextPart("after")})
+	sender.SendMessage(ctx, after)
+	if got := after.Security.Signature.KeyID; got != "did:sage:local:alice#key-2" {
+		t.Errorf("key ID after rotation = %s, want #key-2", got)
+	}
+
+	// Both verify while the old key is in its overlap window
+	if err := receiver.Verify(ctx, before); err != nil {
+		t.Errorf("Verify() of message signed before rotation error = %v", err)
+	}
+	if err := receiver.Verify(ctx, after); err != nil {
+		t.Errorf("Verify() of message signed after rotation error = %v", err)
+	}
+
+	// A key ID that does not match the signature fails
+	forged := types.NewMessage(types.MessageRoleUser, []types.Part{types.NewTextPart("forged")})
+	sender.SendMessage(ctx, forged)
+	forged.Security.Signature.KeyID = "did:sage:local:alice#key-3"
+	if err := receiver.Verify(ctx, forged); err == nil {
+		t.Error("Verify() should reject a signature under the wrong key ID")
+	}
+}
+
+func TestAdapter_Verify_KeyIDOfOtherDID(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	ringPath := filepath.Join(dir, "keys.json")
+
+	ring, _ := keyring.New("did:sage:local:alice", time.Now())
+	saveRing(t, ring, ringPath)
+	sender, err := NewAdapter(&config.SAGEConfig{Network: "local", DID: "did:sage:local:alice", KeyRingPath: ringPath})
+	if err != nil {
+		t.Fatalf("NewAdapter() error = %v", err)
+	}
+	receiver, _ := NewAdapter(&config.SAGEConfig{Network: "local", DID: "did:sage:local:bob"})
+
+	msg := types.NewMessage(types.MessageRoleUser, []types.Part{types.NewTextPart("hello")})
+	sender.SendMessage(ctx, msg)
+	msg.Security.Signature.KeyID = "did:sage:local:mallory#key-1"
+	if err := receiver.Verify(ctx, msg); err == nil {
+		t.Error("Verify() should reject a key ID of another DID")
+	}
+}
+
+func TestNewAdapter_KeyRingErrors(t *testing.T) {
+	dir := t.TempDir()
+	ringPath := filepath.Join(dir, "keys.json")
+	ring, _ := keyring.New("did:sage:local:alice", time.Now())
+	saveRing(t, ring, ringPath)
+
+	if _, err := NewAdapter(&config.SAGEConfig{Network: "local", DID: "did:sage:local:bob", KeyRingPath: ringPath}); err == nil {
+		t.Error("NewAdapter() should reject a key ring of another DID")
+	}
+	if _, err := NewAdapter(&config.SAGEConfig{Network: "local", DID: "did:sage:local:alice", KeyRingPath: filepath.Join(dir, "missing.json")}); err == nil {
+		t.Error("NewAdapter() should fail when the key ring is missing")
+	}
+
+	// The ring's private keys cannot be read without the passphrase
+	t.Setenv(keystore.DefaultPassphraseEnv, "wrong")
+	if _, err := NewAdapter(&config.SAGEConfig{Network: "local", DID: "did:sage:local:alice", KeyRingPath: ringPath}); err == nil {
+		t.Error("NewAdapter() should fail with a wrong key ring passphrase")
+	}
+}
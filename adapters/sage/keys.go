@@ -23,6 +23,8 @@ import (
 	"os"
 
 	"github.com/sage-x-project/sage/crypto"
+
+	"github.com/sage-x-project/sage-adk/adapters/sage/keystore"
 )
 
 // KeyManager provides a wrapper around sage crypto.Manager for key management.
@@ -118,6 +120,42 @@ func (km *KeyManager) SaveToFileWithFormat(keyPair crypto.KeyPair, path string,
 	return nil
 }
 
+// SaveEncrypted saves an Ed25519 private key encrypted with a passphrase.
+// A nil opts uses keystore.DefaultOptions.
+func (km *KeyManager) SaveEncrypted(privateKey ed25519.PrivateKey, path string, passphrase []byte, opts *keystore.Options) error {
+	return keystore.WriteFile(path, privateKey, passphrase, opts)
+}
+
+// LoadPrivateKey loads an Ed25519 private key from a PEM, JWK or encrypted
+// keystore file. passphrase is only called for encrypted files.
+func (km *KeyManager) LoadPrivateKey(path string, passphrase keystore.PassphraseFunc) (ed25519.PrivateKey, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read key file: %w", err)
+	}
+
+	if keystore.IsEncrypted(data) {
+		key, err := keystore.Parse(data)
+		if err != nil {
+			return nil, err
+		}
+		if passphrase == nil {
+			return nil, fmt.Errorf("key file is encrypted and no passphrase source is configured")
+		}
+		secret, err := passphrase()
+		if err != nil {
+			return nil, err
+		}
+		return key.Decrypt(secret)
+	}
+
+	keyPair, err := km.LoadFromFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return km.ExtractEd25519PrivateKey(keyPair)
+}
+
 // Store stores a key pair in the manager's storage backend.
 func (km *KeyManager) Store(keyPair crypto.KeyPair) error {
 	return km.manager.StoreKeyPair(keyPair)
@@ -24,6 +24,10 @@ import (
 	"testing"
 
 	"github.com/sage-x-project/sage/crypto"
+
+	"github.com/sage-x-project/sage-adk/adapters/sage/keystore"
+	"github.com/sage-x-project/sage-adk/config"
+	"github.com/sage-x-project/sage-adk/pkg/errors"
 )
 
 func TestKeyManager_Generate(t *testing.T) {
@@ -366,3 +370,71 @@ func TestKeyManager_RoundTrip(t *testing.T) {
 		t.Error("Round-trip: public keys do not match")
 	}
 }
+
+func TestKeyManager_LoadPrivateKey_Encrypted(t *testing.T) {
+	km := NewKeyManager()
+	keyPath := filepath.Join(t.TempDir(), "agent.key.json")
+	_, privateKey, _ := ed25519.GenerateKey(nil)
+	opts := &keystore.Options{KDF: keystore.KDFScrypt, ScryptN: 1 << 10, ScryptR: 8, ScryptP: 1}
+
+	if err := km.SaveEncrypted(privateKey, keyPath, []byte("secret"), opts); err != nil {
+		t.Fatalf("SaveEncrypted() error = %v", err)
+	}
+
+	loaded, err := km.LoadPrivateKey(keyPath, keystore.StaticPassphrase([]byte("secret")))
+	if err != nil {
+		t.Fatalf("LoadPrivateKey() error = %v", err)
+	}
+	if !loaded.Equal(privateKey) {
+		t.Error("LoadPrivateKey() returned a different key")
+	}
+
+	if _, err := km.LoadPrivateKey(keyPath, keystore.StaticPassphrase([]byte("wrong"))); !errors.Is(err, errors.ErrInvalidCredentials) {
+		t.Errorf("LoadPrivateKey() wrong passphrase error = %v", err)
+	}
+}
+
+func TestNewAdapter_EncryptedPrivateKey(t *testing.T) {
+	dir := t.TempDir()
+	keyPath := filepath.Join(dir, "agent.key.json")
+	passphraseFile := filepath.Join(dir, "passphrase")
+	_, privateKey, _ := ed25519.GenerateKey(nil)
+	opts := &keystore.Options{KDF: keystore.KDFScrypt, ScryptN: 1 << 10, ScryptR: 8, ScryptP: 1}
+
+	keystore.WriteFile(keyPath, privateKey, []byte("secret"), opts)
+	os.WriteFile(passphraseFile, []byte("secret\n"), 0600)
+	t.Setenv(keystore.DefaultPassphraseEnv, "")
+
+	cfg := &config.SAGEConfig{
+		Enabled:        true,
+		Network:        "local",
+		DID:            "did:sage:local:alice",
+		PrivateKeyPath: keyPath,
+		PassphraseFile: passphraseFile,
+	}
+	adapter, err := NewAdapter(cfg)
+	if err != nil {
+		t.Fatalf("NewAdapter() error = %v", err)
+	}
+	if !adapter.privateKey.Equal(privateKey) {
+		t.Error("NewAdapter() did not load the encrypted key")
+	}
+
+	// A wrong passphrase must not silently disable signing
+	os.WriteFile(passphraseFile, []byte("wrong"), 0600)
+	if _, err := NewAdapter(cfg); !errors.Is(err, errors.ErrConfigurationError) {
+		t.Errorf("NewAdapter() wrong passphrase error = %v, want ErrConfigurationError", err)
+	}
+
+	// Neither must a corrupt key file
+	os.WriteFile(keyPath, []byte("not a key"), 0600)
+	if _, err := NewAdapter(cfg); !errors.Is(err, errors.ErrConfigurationError) {
+		t.Errorf("NewAdapter() invalid key error = %v, want ErrConfigurationError", err)
+	}
+
+	// Nor a missing one
+	cfg.PrivateKeyPath = filepath.Join(dir, "missing.key")
+	if _, err := NewAdapter(cfg); !errors.Is(err, errors.ErrConfigurationError) {
+		t.Errorf("NewAdapter() missing key error = %v, want ErrConfigurationError", err)
+	}
+}
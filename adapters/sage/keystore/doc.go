@@ -0,0 +1,33 @@
+// Copyright (C) 2025 sage-x-project
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with this program. If not, see <https://www.gnu.org/licenses/>.
+
+// SPDX-License-Identifier: LGPL-3.0-or-later
+// Package keystore encrypts SAGE private keys at rest.
+//
+// A key is encrypted with XChaCha20-Poly1305 under a key derived from a
+// passphrase with argon2id (the default) or scrypt. The file is versioned
+// JSON; its header, including the public key and KDF parameters, is
+// authenticated, so tampering is detected on decryption:
+//
+//	err := keystore.WriteFile("agent.key.json", privateKey, passphrase, nil)
+//	key, err := keystore.ReadFile("agent.key.json", keystore.DefaultPassphrase(""))
+//
+// The passphrase comes from a PassphraseFunc: the SAGE_ADK_KEY_PASSPHRASE
+// environment variable, a file such as a mounted secret, or a terminal
+// prompt. A wrong passphrase fails with ErrInvalidCredentials.
+//
+// Keystore stores several keys by ID in a pluggable KeyStorage; DirStorage
+// and MemoryStorage are provided.
+package keystore
@@ -0,0 +1,353 @@
+// Copyright (C) 2025 sage-x-project
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with this program. If not, see <https://www.gnu.org/licenses/>.
+
+// SPDX-License-Identifier: LGPL-3.0-or-later
+
+package keystore
+
+import (
+	"bytes"
+	"crypto/cipher"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/json"
+	"os"
+
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/chacha20poly1305"
+	"golang.org/x/crypto/scrypt"
+
+	"github.com/sage-x-project/sage-adk/pkg/errors"
+)
+
+// Version is the current keystore format version.
+const Version = 1
+
+// Key derivation functions.
+const (
+	KDFScrypt   = "scrypt"
+	KDFArgon2id = "argon2id"
+)
+
+// CipherXChaCha20Poly1305 is the AEAD keys are encrypted with.
+const CipherXChaCha20Poly1305 = "xchacha20-poly1305"
+
+// KeyTypeEd25519 is the type of Ed25519 private keys.
+const KeyTypeEd25519 = "ed25519"
+
+// Upper bounds on KDF cost accepted when decrypting, so a crafted file
+// cannot exhaust memory or CPU.
+const (
+	maxScryptN     = 1 << 20
+	maxScryptR     = 32
+	maxScryptP     = 16
+	maxScryptMem   = 1 << 30 // bytes, 128·N·r
+	maxArgon2Time  = 16
+	maxArgon2Mem   = 1 << 20 // KiB
+	saltSize       = 16
+	derivedKeySize = chacha20poly1305.KeySize
+)
+
+// EncryptedKey is a passphrase-protected private key.
+//
+// Everything except the ciphertext is authenticated as additional data, so
+// tampering with the public key or KDF parameters is detected.
+type EncryptedKey struct {
+	Version    int       `json:"version"`
+	KeyType    string    `json:"key_type"`
+	PublicKey  []byte    `json:"public_key"`
+	KDF        KDFParams `json:"kdf"`
+	Cipher     string    `json:"cipher"`
+	Nonce      []byte    `json:"nonce"`
+	Ciphertext []byte    `json:"ciphertext,omitempty"`
+}
+
+// KDFParams are the parameters of the key derivation function.
+type KDFParams struct {
+	Name string `json:"name"`
+	Salt []byte `json:"salt"`
+
+	// scrypt cost parameters
+	N int `json:"n,omitempty"`
+	R int `json:"r,omitempty"`
+	P int `json:"p,omitempty"`
+
+	// argon2id cost parameters; Memory is in KiB
+	Time    uint32 `json:"time,omitempty"`
+	Memory  uint32 `json:"memory,omitempty"`
+	Threads uint8  `json:"threads,omitempty"`
+}
+
+// Options selects the key derivation function and its cost for new keys.
+type Options struct {
+	KDF string
+
+	ScryptN, ScryptR, ScryptP int
+
+	Argon2Time    uint32
+	Argon2Memory  uint32 // KiB
+	Argon2Threads uint8
+}
+
+// DefaultOptions returns argon2id with 3 passes over 64 MiB.
+func DefaultOptions() *Options {
+	return &Options{
+		KDF:           KDFArgon2id,
+		ScryptN:       1 << 15,
+		ScryptR:       8,
+		ScryptP:       1,
+		Argon2Time:    3,
+		Argon2Memory:  64 * 1024,
+		Argon2Threads: 4,
+	}
+}
+
+// Encrypt encrypts an Ed25519 private key with a passphrase. A nil opts
+// uses DefaultOptions.
+func Encrypt(privateKey ed25519.PrivateKey, passphrase []byte, opts *Options) (*EncryptedKey, error) {
+	if len(privateKey) != ed25519.PrivateKeySize {
+		return nil, errors.ErrInvalidInput.WithMessage("invalid Ed25519 private key")
+	}
+	if len(passphrase) == 0 {
+		return nil, errors.ErrInvalidInput.WithMessage("passphrase is empty")
+	}
+	if opts == nil {
+		opts = DefaultOptions()
+	}
+
+	salt := make([]byte, saltSize)
+	nonce := make([]byte, chacha20poly1305.NonceSizeX)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, errors.ErrOperationFailed.WithMessage("failed to generate salt")
+	}
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, errors.ErrOperationFailed.WithMessage("failed to generate nonce")
+	}
+
+	key := &EncryptedKey{
+		Version:   Version,
+		KeyType:   KeyTypeEd25519,
+		PublicKey: privateKey.Public().(ed25519.PublicKey),
+		Cipher:    CipherXChaCha20Poly1305,
+		Nonce:     nonce,
+	}
+	switch opts.KDF {
+	case KDFScrypt:
+		key.KDF = KDFParams{Name: KDFScrypt, Salt: salt, N: opts.ScryptN, R: opts.ScryptR, P: opts.ScryptP}
+	case KDFArgon2id, "":
+		key.KDF = KDFParams{Name: KDFArgon2id, Salt: salt, Time: opts.Argon2Time, Memory: opts.Argon2Memory, Threads: opts.Argon2Threads}
+	default:
+		return nil, errors.ErrInvalidValue.
+			WithMessage("unsupported key derivation function").
+			WithDetail("kdf", opts.KDF)
+	}
+
+	if err := key.validate(); err != nil {
+		return nil, errors.ErrInvalidValue.
+			WithMessage("invalid keystore options").
+			WithDetail("error", err.Error())
+	}
+
+	aead, err := key.aead(passphrase)
+	if err != nil {
+		return nil, err
+	}
+	additionalData, err := key.additionalData()
+	if err != nil {
+		return nil, err
+	}
+
+	key.Ciphertext = aead.Seal(nil, nonce, privateKey.Seed(), additionalData)
+	return key, nil
+}
+
+// Decrypt decrypts the private key. A wrong passphrase or a modified key
+// fails with ErrInvalidCredentials.
+func (k *EncryptedKey) Decrypt(passphrase []byte) (ed25519.PrivateKey, error) {
+	if err := k.validate(); err != nil {
+		return nil, err
+	}
+
+	aead, err := k.aead(passphrase)
+	if err != nil {
+		return nil, err
+	}
+	additionalData, err := k.additionalData()
+	if err != nil {
+		return nil, err
+	}
+
+	seed, err := aead.Open(nil, k.Nonce, k.Ciphertext, additionalData)
+	if err != nil {
+		return nil, errors.ErrInvalidCredentials.
+			WithMessage("failed to decrypt key: wrong passphrase or corrupted keystore")
+	}
+	if len(seed) != ed25519.SeedSize {
+		return nil, errors.ErrInvalidFormat.WithMessage("decrypted key has an invalid length")
+	}
+
+	privateKey := ed25519.NewKeyFromSeed(seed)
+	if !bytes.Equal(privateKey.Public().(ed25519.PublicKey), k.PublicKey) {
+		return nil, errors.ErrInvalidFormat.WithMessage("decrypted key does not match its public key")
+	}
+	return privateKey, nil
+}
+
+// Marshal encodes the key as JSON.
+func (k *EncryptedKey) Marshal() ([]byte, error) {
+	return json.MarshalIndent(k, "", "  ")
+}
+
+// Parse decodes and validates an encrypted key.
+func Parse(data []byte) (*EncryptedKey, error) {
+	var key EncryptedKey
+	if err := json.Unmarshal(data, &key); err != nil {
+		return nil, errors.ErrInvalidFormat.
+			WithMessage("invalid keystore").
+			WithDetail("error", err.Error())
+	}
+	if err := key.validate(); err != nil {
+		return nil, err
+	}
+	return &key, nil
+}
+
+// IsEncrypted returns true if data looks like an encrypted key, as
+// opposed to a plain PEM or JWK key.
+func IsEncrypted(data []byte) bool {
+	var probe struct {
+		Version    int             `json:"version"`
+		Ciphertext json.RawMessage `json:"ciphertext"`
+	}
+	return json.Unmarshal(data, &probe) == nil && probe.Version > 0 && probe.Ciphertext != nil
+}
+
+// WriteFile encrypts privateKey and writes it to path, readable only by its
+// owner.
+func WriteFile(path string, privateKey ed25519.PrivateKey, passphrase []byte, opts *Options) error {
+	key, err := Encrypt(privateKey, passphrase, opts)
+	if err != nil {
+		return err
+	}
+	data, err := key.Marshal()
+	if err != nil {
+		return errors.ErrOperationFailed.
+			WithMessage("failed to encode keystore").
+			WithDetail("error", err.Error())
+	}
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		return errors.ErrStorageConnection.
+			WithMessage("failed to write keystore").
+			WithDetail("path", path).
+			WithDetail("error", err.Error())
+	}
+	return nil
+}
+
+// ReadFile reads and decrypts the key at path, asking passphrase for the
+// passphrase.
+func ReadFile(path string, passphrase PassphraseFunc) (ed25519.PrivateKey, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	key, err := Parse(data)
+	if err != nil {
+		return nil, err
+	}
+	secret, err := passphrase()
+	if err != nil {
+		return nil, err
+	}
+	return key.Decrypt(secret)
+}
+
+// validate checks the format version and bounds the KDF cost.
+func (k *EncryptedKey) validate() error {
+	if k.Version != Version {
+		return errors.ErrInvalidFormat.
+			WithMessage("unsupported keystore version").
+			WithDetail("version", k.Version)
+	}
+	if k.KeyType != KeyTypeEd25519 || len(k.PublicKey) != ed25519.PublicKeySize {
+		return errors.ErrInvalidFormat.
+			WithMessage("unsupported keystore key type").
+			WithDetail("key_type", k.KeyType)
+	}
+	if k.Cipher != CipherXChaCha20Poly1305 || len(k.Nonce) != chacha20poly1305.NonceSizeX {
+		return errors.ErrInvalidFormat.
+			WithMessage("unsupported keystore cipher").
+			WithDetail("cipher", k.Cipher)
+	}
+	if len(k.KDF.Salt) < saltSize {
+		return errors.ErrInvalidFormat.WithMessage("keystore salt is too short")
+	}
+
+	switch k.KDF.Name {
+	case KDFScrypt:
+		if k.KDF.N <= 1 || k.KDF.N > maxScryptN || k.KDF.R <= 0 || k.KDF.R > maxScryptR ||
+			k.KDF.P <= 0 || k.KDF.P > maxScryptP || 128*k.KDF.N*k.KDF.R > maxScryptMem {
+			return errors.ErrInvalidFormat.WithMessage("keystore scrypt parameters out of range")
+		}
+	case KDFArgon2id:
+		if k.KDF.Time == 0 || k.KDF.Time > maxArgon2Time || k.KDF.Memory == 0 || k.KDF.Memory > maxArgon2Mem || k.KDF.Threads == 0 {
+			return errors.ErrInvalidFormat.WithMessage("keystore argon2id parameters out of range")
+		}
+	default:
+		return errors.ErrInvalidFormat.
+			WithMessage("unsupported key derivation function").
+			WithDetail("kdf", k.KDF.Name)
+	}
+	return nil
+}
+
+// aead derives the encryption key from the passphrase.
+func (k *EncryptedKey) aead(passphrase []byte) (cipher.AEAD, error) {
+	var derived []byte
+	switch k.KDF.Name {
+	case KDFScrypt:
+		var err error
+		derived, err = scrypt.Key(passphrase, k.KDF.Salt, k.KDF.N, k.KDF.R, k.KDF.P, derivedKeySize)
+		if err != nil {
+			return nil, errors.ErrInvalidValue.
+				WithMessage("invalid scrypt parameters").
+				WithDetail("error", err.Error())
+		}
+	case KDFArgon2id:
+		derived = argon2.IDKey(passphrase, k.KDF.Salt, k.KDF.Time, k.KDF.Memory, k.KDF.Threads, derivedKeySize)
+	}
+
+	aead, err := chacha20poly1305.NewX(derived)
+	if err != nil {
+		return nil, errors.ErrOperationFailed.
+			WithMessage("failed to create cipher").
+			WithDetail("error", err.Error())
+	}
+	return aead, nil
+}
+
+// additionalData is the authenticated header: the key without its
+// ciphertext.
+func (k *EncryptedKey) additionalData() ([]byte, error) {
+	header := *k
+	header.Ciphertext = nil
+	data, err := json.Marshal(&header)
+	if err != nil {
+		return nil, errors.ErrOperationFailed.
+			WithMessage("failed to encode keystore header").
+			WithDetail("error", err.Error())
+	}
+	return data, nil
+}
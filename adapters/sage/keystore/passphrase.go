@@ -0,0 +1,123 @@
+// Copyright (C) 2025 sage-x-project
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with this program. If not, see <https://www.gnu.org/licenses/>.
+
+// SPDX-License-Identifier: LGPL-3.0-or-later
+
+package keystore
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+
+	"github.com/sage-x-project/sage-adk/pkg/errors"
+)
+
+// DefaultPassphraseEnv is the environment variable DefaultPassphrase reads
+// the passphrase from.
+const DefaultPassphraseEnv = "SAGE_ADK_KEY_PASSPHRASE"
+
+// PassphraseFunc returns a keystore passphrase. It returns ErrNotFound if
+// its source has no passphrase, so sources can be chained with
+// FirstPassphrase.
+type PassphraseFunc func() ([]byte, error)
+
+// EnvPassphrase reads the passphrase from an environment variable.
+func EnvPassphrase(name string) PassphraseFunc {
+	return func() ([]byte, error) {
+		value, ok := os.LookupEnv(name)
+		if !ok || value == "" {
+			return nil, errors.ErrNotFound.
+				WithMessage("passphrase environment variable is not set").
+				WithDetail("env", name)
+		}
+		return []byte(value), nil
+	}
+}
+
+// FilePassphrase reads the passphrase from a file, such as a mounted
+// secret. A trailing newline is ignored.
+func FilePassphrase(path string) PassphraseFunc {
+	return func() ([]byte, error) {
+		data, err := os.ReadFile(path)
+		if os.IsNotExist(err) {
+			return nil, errors.ErrNotFound.
+				WithMessage("passphrase file does not exist").
+				WithDetail("path", path)
+		}
+		if err != nil {
+			return nil, errors.ErrStorageConnection.
+				WithMessage("failed to read passphrase file").
+				WithDetail("path", path).
+				WithDetail("error", err.Error())
+		}
+		return bytes.TrimRight(data, "\r\n"), nil
+	}
+}
+
+// PromptPassphrase asks for the passphrase on the terminal without echoing
+// it. It returns ErrNotFound when standard input is not a terminal.
+func PromptPassphrase(prompt string) PassphraseFunc {
+	return func() ([]byte, error) {
+		fd := int(os.Stdin.Fd())
+		if !isTerminal(fd) {
+			return nil, errors.ErrNotFound.WithMessage("standard input is not a terminal")
+		}
+
+		fmt.Fprint(os.Stderr, prompt)
+		passphrase, err := readPassword(fd)
+		fmt.Fprintln(os.Stderr)
+		if err != nil {
+			return nil, errors.ErrOperationFailed.
+				WithMessage("failed to read passphrase").
+				WithDetail("error", err.Error())
+		}
+		return passphrase, nil
+	}
+}
+
+// StaticPassphrase returns a fixed passphrase.
+func StaticPassphrase(passphrase []byte) PassphraseFunc {
+	return func() ([]byte, error) {
+		return passphrase, nil
+	}
+}
+
+// FirstPassphrase tries sources in order and returns the first passphrase
+// found. Errors other than ErrNotFound stop the search.
+func FirstPassphrase(sources ...PassphraseFunc) PassphraseFunc {
+	return func() ([]byte, error) {
+		for _, source := range sources {
+			passphrase, err := source()
+			if errors.Is(err, errors.ErrNotFound) {
+				continue
+			}
+			return passphrase, err
+		}
+		return nil, errors.ErrNotFound.
+			WithMessage("no keystore passphrase: set " + DefaultPassphraseEnv + ", configure a passphrase file or run interactively")
+	}
+}
+
+// DefaultPassphrase reads the passphrase from DefaultPassphraseEnv, then
+// from file if not empty, then from a terminal prompt.
+func DefaultPassphrase(file string) PassphraseFunc {
+	sources := []PassphraseFunc{EnvPassphrase(DefaultPassphraseEnv)}
+	if file != "" {
+		sources = append(sources, FilePassphrase(file))
+	}
+	sources = append(sources, PromptPassphrase("Key passphrase: "))
+	return FirstPassphrase(sources...)
+}
@@ -0,0 +1,293 @@
+// Copyright (C) 2025 sage-x-project
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with this program. If not, see <https://www.gnu.org/licenses/>.
+
+// SPDX-License-Identifier: LGPL-3.0-or-later
+
+package keystore
+
+import (
+	"context"
+	"crypto/ed25519"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/sage-x-project/sage-adk/pkg/errors"
+)
+
+// KeyStorage stores encrypted keys by ID. Implementations only ever see
+// ciphertext, so keys can be kept in a directory, a database or a secret
+// manager.
+type KeyStorage interface {
+	// Get returns the encrypted key stored under id, or ErrNotFound.
+	Get(ctx context.Context, id string) ([]byte, error)
+
+	// Put stores an encrypted key under id, replacing any existing key.
+	Put(ctx context.Context, id string, data []byte) error
+
+	// Delete removes the key stored under id, or returns ErrNotFound.
+	Delete(ctx context.Context, id string) error
+
+	// List returns the IDs of all stored keys in sorted order.
+	List(ctx context.Context) ([]string, error)
+}
+
+// Keystore encrypts keys with a passphrase and keeps them in a KeyStorage.
+type Keystore struct {
+	storage    KeyStorage
+	passphrase PassphraseFunc
+	opts       *Options
+}
+
+// New creates a keystore. A nil opts uses DefaultOptions.
+func New(storage KeyStorage, passphrase PassphraseFunc, opts *Options) (*Keystore, error) {
+	if storage == nil {
+		return nil, errors.ErrInvalidInput.WithMessage("key storage is required")
+	}
+	if passphrase == nil {
+		return nil, errors.ErrInvalidInput.WithMessage("passphrase source is required")
+	}
+	if opts == nil {
+		opts = DefaultOptions()
+	}
+	return &Keystore{storage: storage, passphrase: passphrase, opts: opts}, nil
+}
+
+// Save encrypts privateKey and stores it under id.
+func (s *Keystore) Save(ctx context.Context, id string, privateKey ed25519.PrivateKey) error {
+	if err := validateID(id); err != nil {
+		return err
+	}
+	secret, err := s.passphrase()
+	if err != nil {
+		return err
+	}
+	key, err := Encrypt(privateKey, secret, s.opts)
+	if err != nil {
+		return err
+	}
+	data, err := key.Marshal()
+	if err != nil {
+		return errors.ErrOperationFailed.
+			WithMessage("failed to encode keystore").
+			WithDetail("error", err.Error())
+	}
+	return s.storage.Put(ctx, id, data)
+}
+
+// Load returns the decrypted key stored under id.
+func (s *Keystore) Load(ctx context.Context, id string) (ed25519.PrivateKey, error) {
+	if err := validateID(id); err != nil {
+		return nil, err
+	}
+	data, err := s.storage.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	key, err := Parse(data)
+	if err != nil {
+		return nil, err
+	}
+	secret, err := s.passphrase()
+	if err != nil {
+		return nil, err
+	}
+	return key.Decrypt(secret)
+}
+
+// Delete removes the key stored under id.
+func (s *Keystore) Delete(ctx context.Context, id string) error {
+	if err := validateID(id); err != nil {
+		return err
+	}
+	return s.storage.Delete(ctx, id)
+}
+
+// List returns the IDs of the stored keys.
+func (s *Keystore) List(ctx context.Context) ([]string, error) {
+	return s.storage.List(ctx)
+}
+
+// DirStorage stores each key as <id>.json in a directory.
+type DirStorage struct {
+	dir string
+}
+
+// NewDirStorage creates a KeyStorage backed by dir, creating it with
+// owner-only permissions if needed.
+func NewDirStorage(dir string) (*DirStorage, error) {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, errors.ErrStorageConnection.
+			WithMessage("failed to create keystore directory").
+			WithDetail("dir", dir).
+			WithDetail("error", err.Error())
+	}
+	return &DirStorage{dir: dir}, nil
+}
+
+// Get implements KeyStorage.
+func (d *DirStorage) Get(ctx context.Context, id string) ([]byte, error) {
+	data, err := os.ReadFile(d.path(id))
+	if os.IsNotExist(err) {
+		return nil, errors.ErrNotFound.WithMessage("key not found").WithDetail("id", id)
+	}
+	if err != nil {
+		return nil, errors.ErrStorageConnection.
+			WithMessage("failed to read key").
+			WithDetail("id", id).
+			WithDetail("error", err.Error())
+	}
+	return data, nil
+}
+
+// Put implements KeyStorage. The file is replaced atomically.
+func (d *DirStorage) Put(ctx context.Context, id string, data []byte) error {
+	tmp, err := os.CreateTemp(d.dir, "."+id+".*")
+	if err != nil {
+		return errors.ErrStorageConnection.
+			WithMessage("failed to write key").
+			WithDetail("id", id).
+			WithDetail("error", err.Error())
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return errors.ErrStorageConnection.
+			WithMessage("failed to write key").
+			WithDetail("id", id).
+			WithDetail("error", err.Error())
+	}
+	if err := tmp.Close(); err != nil {
+		return errors.ErrStorageConnection.
+			WithMessage("failed to write key").
+			WithDetail("id", id).
+			WithDetail("error", err.Error())
+	}
+	if err := os.Rename(tmp.Name(), d.path(id)); err != nil {
+		return errors.ErrStorageConnection.
+			WithMessage("failed to write key").
+			WithDetail("id", id).
+			WithDetail("error", err.Error())
+	}
+	return nil
+}
+
+// Delete implements KeyStorage.
+func (d *DirStorage) Delete(ctx context.Context, id string) error {
+	err := os.Remove(d.path(id))
+	if os.IsNotExist(err) {
+		return errors.ErrNotFound.WithMessage("key not found").WithDetail("id", id)
+	}
+	if err != nil {
+		return errors.ErrStorageConnection.
+			WithMessage("failed to delete key").
+			WithDetail("id", id).
+			WithDetail("error", err.Error())
+	}
+	return nil
+}
+
+// List implements KeyStorage.
+func (d *DirStorage) List(ctx context.Context) ([]string, error) {
+	entries, err := os.ReadDir(d.dir)
+	if err != nil {
+		return nil, errors.ErrStorageConnection.
+			WithMessage("failed to list keys").
+			WithDetail("dir", d.dir).
+			WithDetail("error", err.Error())
+	}
+
+	ids := []string{}
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || strings.HasPrefix(name, ".") || !strings.HasSuffix(name, ".json") {
+			continue
+		}
+		ids = append(ids, strings.TrimSuffix(name, ".json"))
+	}
+	return ids, nil
+}
+
+func (d *DirStorage) path(id string) string {
+	return filepath.Join(d.dir, id+".json")
+}
+
+// MemoryStorage keeps encrypted keys in memory, for tests.
+type MemoryStorage struct {
+	mu   sync.RWMutex
+	keys map[string][]byte
+}
+
+// NewMemoryStorage creates an empty in-memory KeyStorage.
+func NewMemoryStorage() *MemoryStorage {
+	return &MemoryStorage{keys: make(map[string][]byte)}
+}
+
+// Get implements KeyStorage.
+func (m *MemoryStorage) Get(ctx context.Context, id string) ([]byte, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	data, ok := m.keys[id]
+	if !ok {
+		return nil, errors.ErrNotFound.WithMessage("key not found").WithDetail("id", id)
+	}
+	return append([]byte(nil), data...), nil
+}
+
+// Put implements KeyStorage.
+func (m *MemoryStorage) Put(ctx context.Context, id string, data []byte) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.keys[id] = append([]byte(nil), data...)
+	return nil
+}
+
+// Delete implements KeyStorage.
+func (m *MemoryStorage) Delete(ctx context.Context, id string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.keys[id]; !ok {
+		return errors.ErrNotFound.WithMessage("key not found").WithDetail("id", id)
+	}
+	delete(m.keys, id)
+	return nil
+}
+
+// List implements KeyStorage.
+func (m *MemoryStorage) List(ctx context.Context) ([]string, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	ids := make([]string, 0, len(m.keys))
+	for id := range m.keys {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids, nil
+}
+
+// validateID rejects IDs that could escape a storage directory.
+func validateID(id string) error {
+	if id == "" || strings.ContainsAny(id, `/\`) || strings.HasPrefix(id, ".") {
+		return errors.ErrInvalidInput.WithMessage("invalid key id").WithDetail("id", id)
+	}
+	return nil
+}
@@ -0,0 +1,31 @@
+// Copyright (C) 2025 sage-x-project
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with this program. If not, see <https://www.gnu.org/licenses/>.
+
+// SPDX-License-Identifier: LGPL-3.0-or-later
+//go:build !linux && !darwin && !freebsd && !netbsd && !openbsd && !dragonfly
+
+package keystore
+
+import "errors"
+
+// isTerminal reports false: passphrase prompts are not supported on this
+// platform, use the environment or a passphrase file instead.
+func isTerminal(fd int) bool {
+	return false
+}
+
+func readPassword(fd int) ([]byte, error) {
+	return nil, errors.New("passphrase prompt not supported on this platform")
+}
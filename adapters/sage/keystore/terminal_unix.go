@@ -0,0 +1,59 @@
+// Copyright (C) 2025 sage-x-project
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with this program. If not, see <https://www.gnu.org/licenses/>.
+
+// SPDX-License-Identifier: LGPL-3.0-or-later
+//go:build linux || darwin || freebsd || netbsd || openbsd || dragonfly
+
+package keystore
+
+import (
+	"golang.org/x/sys/unix"
+)
+
+// isTerminal returns true if fd is a terminal.
+func isTerminal(fd int) bool {
+	_, err := unix.IoctlGetTermios(fd, ioctlReadTermios)
+	return err == nil
+}
+
+// readPassword reads a line from the terminal fd with echo disabled.
+func readPassword(fd int) ([]byte, error) {
+	state, err := unix.IoctlGetTermios(fd, ioctlReadTermios)
+	if err != nil {
+		return nil, err
+	}
+
+	silent := *state
+	silent.Lflag &^= unix.ECHO
+	silent.Lflag |= unix.ICANON | unix.ISIG
+	silent.Iflag |= unix.ICRNL
+	if err := unix.IoctlSetTermios(fd, ioctlWriteTermios, &silent); err != nil {
+		return nil, err
+	}
+	defer unix.IoctlSetTermios(fd, ioctlWriteTermios, state)
+
+	var line []byte
+	buf := make([]byte, 1)
+	for {
+		n, err := unix.Read(fd, buf)
+		if err != nil {
+			return nil, err
+		}
+		if n == 0 || buf[0] == '\n' {
+			return line, nil
+		}
+		line = append(line, buf[0])
+	}
+}
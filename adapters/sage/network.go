@@ -20,20 +20,42 @@ package sage
 import (
 	"bytes"
 	"context"
+	"crypto/ed25519"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
+	"strings"
+	"sync"
 	"time"
 
+	"github.com/sage-x-project/sage-adk/adapters/sage/httpsig"
+	"github.com/sage-x-project/sage-adk/core/authn"
+	"github.com/sage-x-project/sage-adk/core/protocol"
 	"github.com/sage-x-project/sage-adk/pkg/errors"
+	"github.com/sage-x-project/sage-adk/pkg/mtls"
 	"github.com/sage-x-project/sage-adk/pkg/types"
 )
 
+// Server routes. Clients derive them from a peer's message endpoint.
+const (
+	RouteMessage   = "/sage/message"
+	RouteStream    = "/sage/stream"
+	RouteHandshake = "/sage/handshake"
+	RouteSecure    = "/sage/secure"
+)
+
 // NetworkClient handles HTTP communication for SAGE protocol.
 type NetworkClient struct {
 	httpClient *http.Client
 	timeout    time.Duration
+	signer     *httpsig.Signer
+
+	// Encrypted sessions (see EnableEncryption)
+	transport  *TransportManager
+	resolveKey KeyResolverFunc
+	peerLocks  map[string]*sync.Mutex
+	mu         sync.Mutex
 }
 
 // NetworkConfig configures the network client.
@@ -73,7 +95,8 @@ func NewNetworkClient(config *NetworkConfig) *NetworkClient {
 				MaxIdleConnsPerHost: 10,
 			},
 		},
-		timeout: config.Timeout,
+		timeout:   config.Timeout,
+		peerLocks: make(map[string]*sync.Mutex),
 	}
 }
 
@@ -115,6 +138,10 @@ func (nc *NetworkClient) SendMessage(ctx context.Context, endpoint string, msg *
 		req.Header.Set("X-SAGE-Timestamp", msg.Security.Timestamp.Format(time.RFC3339))
 	}
 
+	if err := nc.signRequest(req); err != nil {
+		return err
+	}
+
 	// Send request
 	resp, err := nc.httpClient.Do(req)
 	if err != nil {
@@ -137,6 +164,480 @@ func (nc *NetworkClient) SendMessage(ctx context.Context, endpoint string, msg *
 	return nil
 }
 
+// StreamMessage sends a message to a streaming endpoint and passes each
+// verified response chunk to fn. Chunk digests are always checked; chunk
+// signatures are checked when resolveKey is set. A stream that ends with an
+// error chunk returns that error.
+func (nc *NetworkClient) StreamMessage(ctx context.Context, endpoint string, msg *types.Message, resolveKey KeyResolverFunc, fn protocol.StreamFunc) error {
+	if endpoint == "" {
+		return errors.ErrInvalidInput.WithMessage("endpoint cannot be empty")
+	}
+
+	if msg == nil || msg.Security == nil {
+		return errors.ErrInvalidInput.WithMessage("message with security metadata is required")
+	}
+
+	messageBytes, err := json.Marshal(msg)
+	if err != nil {
+		return errors.ErrOperationFailed.
+			WithMessage("failed to marshal message").
+			WithDetail("error", err.Error())
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(messageBytes))
+	if err != nil {
+		return errors.ErrOperationFailed.
+			WithMessage("failed to create HTTP request").
+			WithDetail("error", err.Error())
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", ContentTypeStream)
+	req.Header.Set("User-Agent", "sage-adk/1.0")
+	req.Header.Set("X-SAGE-Protocol-Mode", string(msg.Security.Mode))
+	req.Header.Set("X-SAGE-Agent-DID", msg.Security.AgentDID)
+
+	if err := nc.signRequest(req); err != nil {
+		return err
+	}
+
+	// Streams may outlive the client timeout; the context bounds them instead.
+	client := *nc.httpClient
+	client.Timeout = 0
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return errors.ErrOperationFailed.
+			WithMessage("failed to send HTTP request").
+			WithDetail("endpoint", endpoint).
+			WithDetail("error", err.Error())
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		bodyBytes, _ := io.ReadAll(resp.Body)
+		return errors.ErrOperationFailed.
+			WithMessage("HTTP request failed").
+			WithDetail("status_code", fmt.Sprintf("%d", resp.StatusCode)).
+			WithDetail("response", string(bodyBytes))
+	}
+
+	reader := NewStreamReader(resp.Body, msg.MessageID, msg.Security.Nonce, resolveKey)
+	for {
+		chunk, err := reader.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		if chunk.Error != "" {
+			return errors.ErrOperationFailed.
+				WithMessage("stream failed: " + chunk.Error)
+		}
+		if chunk.Data != "" {
+			if err := fn(chunk.Data); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// SetHTTPSigner signs every request the client sends with an RFC 9421 HTTP
+// message signature (see the httpsig package). A nil signer stops signing.
+func (nc *NetworkClient) SetHTTPSigner(signer *httpsig.Signer) {
+	nc.mu.Lock()
+	defer nc.mu.Unlock()
+	nc.signer = signer
+}
+
+// signRequest adds an HTTP message signature to req if a signer is set.
+func (nc *NetworkClient) signRequest(req *http.Request) error {
+	nc.mu.Lock()
+	signer := nc.signer
+	nc.mu.Unlock()
+
+	if signer == nil {
+		return nil
+	}
+	return signer.Sign(req)
+}
+
+// EnableEncryption lets the client send messages over encrypted SAGE
+// sessions (see SendEncrypted). resolveKey resolves the Ed25519 keys peers
+// sign handshake and session messages with.
+func (nc *NetworkClient) EnableEncryption(transport *TransportManager, resolveKey KeyResolverFunc) {
+	nc.mu.Lock()
+	defer nc.mu.Unlock()
+	nc.transport = transport
+	nc.resolveKey = resolveKey
+}
+
+// encryption returns the transport and key resolver set by EnableEncryption.
+func (nc *NetworkClient) encryption() (*TransportManager, KeyResolverFunc, error) {
+	nc.mu.Lock()
+	defer nc.mu.Unlock()
+
+	if nc.transport == nil || nc.resolveKey == nil {
+		return nil, nil, errors.ErrConfigurationError.
+			WithMessage("encryption is not enabled on the SAGE network client")
+	}
+	return nc.transport, nc.resolveKey, nil
+}
+
+// peerLock returns the lock serializing handshakes and encrypted messages
+// with a remote DID.
+func (nc *NetworkClient) peerLock(remoteDID string) *sync.Mutex {
+	nc.mu.Lock()
+	defer nc.mu.Unlock()
+
+	lock, ok := nc.peerLocks[remoteDID]
+	if !ok {
+		lock = &sync.Mutex{}
+		nc.peerLocks[remoteDID] = lock
+	}
+	return lock
+}
+
+// Handshake establishes an encrypted session with remoteDID, served at
+// endpoint, unless an active session already exists.
+func (nc *NetworkClient) Handshake(ctx context.Context, endpoint, remoteDID string) error {
+	if endpoint == "" {
+		return errors.ErrInvalidInput.WithMessage("endpoint cannot be empty")
+	}
+	if remoteDID == "" {
+		return errors.ErrInvalidInput.WithMessage("remote DID cannot be empty")
+	}
+
+	transport, resolveKey, err := nc.encryption()
+	if err != nil {
+		return err
+	}
+	return nc.ensureSession(ctx, transport, resolveKey, endpoint, remoteDID)
+}
+
+// ensureSession runs the handshake with remoteDID if there is no active
+// session. A session restored from the session store is resumed instead,
+// falling back to the handshake if the peer no longer holds it.
+// Concurrent callers for the same peer share one handshake.
+func (nc *NetworkClient) ensureSession(ctx context.Context, transport *TransportManager, resolveKey KeyResolverFunc, endpoint, remoteDID string) error {
+	lock := nc.peerLock(remoteDID)
+	lock.Lock()
+	defer lock.Unlock()
+
+	return nc.establish(ctx, transport, resolveKey, endpoint, remoteDID)
+}
+
+// establish is ensureSession for callers holding the peer lock.
+func (nc *NetworkClient) establish(ctx context.Context, transport *TransportManager, resolveKey KeyResolverFunc, endpoint, remoteDID string) error {
+	if transport.HasSession(remoteDID) {
+		if !transport.NeedsResume(remoteDID) {
+			return nil
+		}
+		if err := nc.resume(ctx, transport, resolveKey, endpoint, remoteDID); err == nil {
+			return nil
+		}
+	}
+	return nc.handshake(ctx, transport, resolveKey, endpoint, remoteDID)
+}
+
+// resume proves to the peer that this side still holds the restored
+// session, and checks that the peer does too, in one round trip.
+func (nc *NetworkClient) resume(ctx context.Context, transport *TransportManager, resolveKey KeyResolverFunc, endpoint, remoteDID string) error {
+	resume, err := transport.ResumeSession(ctx, remoteDID)
+	if err != nil {
+		return err
+	}
+
+	var resumed SessionResumed
+	if err := nc.exchange(ctx, endpointFor(endpoint, RouteHandshake), PhaseResume, resume, PhaseResumed, &resumed); err != nil {
+		return err
+	}
+
+	peerPublicKey, err := peerKey(resolveKey, resumed.FromDID, resumed.Signature.KeyID, remoteDID)
+	if err != nil {
+		return err
+	}
+	return transport.CompleteResume(ctx, resume, &resumed, peerPublicKey)
+}
+
+// handshake runs the four handshake phases over two round trips to the
+// peer's handshake route: invitation → request, then response → complete.
+func (nc *NetworkClient) handshake(ctx context.Context, transport *TransportManager, resolveKey KeyResolverFunc, endpoint, remoteDID string) (err error) {
+	// Drop leftovers of an expired session or an interrupted handshake.
+	transport.Disconnect(ctx, remoteDID)
+	defer func() {
+		if err != nil {
+			transport.Disconnect(ctx, remoteDID)
+		}
+	}()
+
+	invitation, err := transport.Connect(ctx, remoteDID)
+	if err != nil {
+		return err
+	}
+
+	handshakeURL := endpointFor(endpoint, RouteHandshake)
+
+	var request HandshakeRequest
+	if err := nc.exchange(ctx, handshakeURL, PhaseInvitation, invitation, PhaseRequest, &request); err != nil {
+		return err
+	}
+
+	peerPublicKey, err := peerKey(resolveKey, request.FromDID, request.Signature.KeyID, remoteDID)
+	if err != nil {
+		return err
+	}
+
+	response, err := transport.HandleRequest(ctx, &request, peerPublicKey)
+	if err != nil {
+		return err
+	}
+
+	var complete HandshakeComplete
+	if err := nc.exchange(ctx, handshakeURL, PhaseResponse, response, PhaseComplete, &complete); err != nil {
+		return err
+	}
+
+	if complete.FromDID != remoteDID {
+		return errors.ErrUnauthorized.
+			WithMessage("handshake completed by an unexpected peer").
+			WithDetail("expected", remoteDID).
+			WithDetail("from", complete.FromDID)
+	}
+
+	return transport.HandleComplete(ctx, &complete, peerPublicKey)
+}
+
+// exchange posts one handshake message and decodes the peer's reply, which
+// must be of the expected phase.
+func (nc *NetworkClient) exchange(ctx context.Context, url string, phase HandshakePhase, payload interface{}, replyPhase HandshakePhase, reply interface{}) error {
+	envelope, err := WrapMessage(string(phase), payload)
+	if err != nil {
+		return err
+	}
+
+	resp, err := nc.post(ctx, url, envelope)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if err := checkSecureStatus(resp, url); err != nil {
+		return err
+	}
+
+	var replyEnvelope MessageEnvelope
+	if err := json.NewDecoder(resp.Body).Decode(&replyEnvelope); err != nil {
+		return errors.ErrMessageParsing.
+			WithMessage("failed to parse handshake reply").
+			WithDetail("error", err.Error())
+	}
+
+	if replyEnvelope.Type != string(replyPhase) {
+		return errors.ErrProtocolMismatch.
+			WithMessage("unexpected handshake phase in reply").
+			WithDetail("expected", string(replyPhase)).
+			WithDetail("got", replyEnvelope.Type)
+	}
+
+	return UnwrapMessage(&replyEnvelope, reply)
+}
+
+// SendEncrypted sends a message to remoteDID, served at endpoint, as an
+// encrypted ApplicationMessage. The handshake runs first when there is no
+// active session, including after the session expired; a session the peer
+// no longer knows is re-established once. It returns the peer's decrypted
+// reply, or nil if the peer accepted the message without one.
+//
+// Messages to one peer are sent one at a time, because the peer accepts a
+// session's messages only in sequence.
+//
+// Peers that do not serve encrypted sessions fail with ErrProtocolMismatch.
+func (nc *NetworkClient) SendEncrypted(ctx context.Context, endpoint, remoteDID string, msg *types.Message) (*types.Message, error) {
+	if msg == nil {
+		return nil, errors.ErrInvalidInput.WithMessage("message cannot be nil")
+	}
+	if endpoint == "" {
+		return nil, errors.ErrInvalidInput.WithMessage("endpoint cannot be empty")
+	}
+	if remoteDID == "" {
+		return nil, errors.ErrInvalidInput.WithMessage("remote DID cannot be empty")
+	}
+
+	transport, resolveKey, err := nc.encryption()
+	if err != nil {
+		return nil, err
+	}
+
+	lock := nc.peerLock(remoteDID)
+	lock.Lock()
+	defer lock.Unlock()
+
+	for attempt := 0; ; attempt++ {
+		if err := nc.establish(ctx, transport, resolveKey, endpoint, remoteDID); err != nil {
+			return nil, err
+		}
+
+		reply, rehandshake, err := nc.sendSealed(ctx, transport, resolveKey, endpoint, remoteDID, msg)
+		if rehandshake && attempt == 0 {
+			transport.Disconnect(ctx, remoteDID)
+			continue
+		}
+		return reply, err
+	}
+}
+
+// sendSealed encrypts msg for the active session and posts it to the peer's
+// secure route. rehandshake reports that the peer rejected the session.
+func (nc *NetworkClient) sendSealed(ctx context.Context, transport *TransportManager, resolveKey KeyResolverFunc, endpoint, remoteDID string, msg *types.Message) (reply *types.Message, rehandshake bool, err error) {
+	sealed, err := transport.SendMessage(ctx, remoteDID, msg)
+	if err != nil {
+		return nil, false, err
+	}
+
+	url := endpointFor(endpoint, RouteSecure)
+	resp, err := nc.post(ctx, url, sealed)
+	if err != nil {
+		return nil, false, err
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusAccepted:
+		return nil, false, nil
+	case http.StatusPreconditionRequired:
+		return nil, true, errors.ErrUnauthorized.
+			WithMessage("peer has no active session").
+			WithDetail("remote_did", remoteDID)
+	}
+	if err := checkSecureStatus(resp, url); err != nil {
+		return nil, false, err
+	}
+
+	var sealedReply ApplicationMessage
+	if err := json.NewDecoder(resp.Body).Decode(&sealedReply); err != nil {
+		return nil, false, errors.ErrMessageParsing.
+			WithMessage("failed to parse encrypted reply").
+			WithDetail("error", err.Error())
+	}
+
+	peerPublicKey, err := peerKey(resolveKey, sealedReply.FromDID, sealedReply.Signature.KeyID, remoteDID)
+	if err != nil {
+		return nil, false, err
+	}
+
+	payload, err := transport.OpenMessage(ctx, &sealedReply, peerPublicKey)
+	if err != nil {
+		if errors.Is(err, errors.ErrSessionOutOfSync) {
+			// The next message establishes a new session
+			transport.Disconnect(ctx, remoteDID)
+		}
+		return nil, false, err
+	}
+
+	var response types.Message
+	if err := json.Unmarshal(payload, &response); err != nil {
+		return nil, false, errors.ErrMessageParsing.
+			WithMessage("failed to parse decrypted reply").
+			WithDetail("error", err.Error())
+	}
+	return &response, false, nil
+}
+
+// post sends body as JSON to url. The caller closes the response body.
+func (nc *NetworkClient) post(ctx context.Context, url string, body interface{}) (*http.Response, error) {
+	bodyBytes, err := json.Marshal(body)
+	if err != nil {
+		return nil, errors.ErrOperationFailed.
+			WithMessage("failed to marshal message").
+			WithDetail("error", err.Error())
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(bodyBytes))
+	if err != nil {
+		return nil, errors.ErrOperationFailed.
+			WithMessage("failed to create HTTP request").
+			WithDetail("error", err.Error())
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "sage-adk/1.0")
+
+	if err := nc.signRequest(req); err != nil {
+		return nil, err
+	}
+
+	resp, err := nc.httpClient.Do(req)
+	if err != nil {
+		return nil, errors.ErrOperationFailed.
+			WithMessage("failed to send HTTP request").
+			WithDetail("endpoint", url).
+			WithDetail("error", err.Error())
+	}
+	return resp, nil
+}
+
+// checkSecureStatus turns a failed handshake or secure route response into
+// an error. Peers without the route, or with encryption disabled, are
+// reported as not supporting encrypted sessions.
+func checkSecureStatus(resp *http.Response, url string) error {
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return nil
+	case http.StatusNotFound, http.StatusMethodNotAllowed, http.StatusNotImplemented:
+		return errors.ErrProtocolMismatch.
+			WithMessage("peer does not support encrypted SAGE sessions").
+			WithDetail("endpoint", url).
+			WithDetail("status_code", fmt.Sprintf("%d", resp.StatusCode))
+	default:
+		bodyBytes, _ := io.ReadAll(resp.Body)
+		return errors.ErrOperationFailed.
+			WithMessage("HTTP request failed").
+			WithDetail("endpoint", url).
+			WithDetail("status_code", fmt.Sprintf("%d", resp.StatusCode)).
+			WithDetail("response", string(bodyBytes))
+	}
+}
+
+// peerKey resolves the key that signed a message claimed to come from
+// expectedDID. Messages whose sender or signing key belongs to another DID
+// are rejected.
+func peerKey(resolveKey KeyResolverFunc, fromDID, keyID, expectedDID string) (ed25519.PublicKey, error) {
+	if fromDID != expectedDID || didFromKeyID(keyID) != expectedDID {
+		return nil, errors.ErrUnauthorized.
+			WithMessage("message is not signed by the expected peer").
+			WithDetail("expected", expectedDID).
+			WithDetail("from", fromDID).
+			WithDetail("key_id", keyID)
+	}
+
+	publicKey, err := resolveKey(keyID)
+	if err != nil {
+		return nil, errors.ErrDIDNotFound.
+			WithMessage("failed to resolve peer key").
+			WithDetail("key_id", keyID).
+			WithDetail("error", err.Error())
+	}
+	return publicKey, nil
+}
+
+// didFromKeyID returns the DID part of a key ID ("did#fragment").
+func didFromKeyID(keyID string) string {
+	if i := strings.Index(keyID, "#"); i >= 0 {
+		return keyID[:i]
+	}
+	return keyID
+}
+
+// endpointFor derives the URL of a server route from a peer's message
+// endpoint or base URL.
+func endpointFor(endpoint, route string) string {
+	base := strings.TrimSuffix(endpoint, "/")
+	base = strings.TrimSuffix(base, RouteMessage)
+	return base + route
+}
+
 // ReceiveMessage receives a message from HTTP request body.
 func (nc *NetworkClient) ReceiveMessage(req *http.Request) (*types.Message, error) {
 	if req == nil {
@@ -172,20 +673,35 @@ func (nc *NetworkClient) Close() error {
 // MessageHandler is a function type for handling incoming messages.
 type MessageHandlerFunc func(ctx context.Context, msg *types.Message) (*types.Message, error)
 
+// StreamHandlerFunc handles an incoming message by emitting response chunks.
+type StreamHandlerFunc func(ctx context.Context, msg *types.Message, emit protocol.StreamFunc) error
+
 // NetworkServer provides HTTP server for receiving SAGE messages.
 type NetworkServer struct {
-	httpServer *http.Server
-	handler    MessageHandlerFunc
+	httpServer    *http.Server
+	handler       MessageHandlerFunc
+	streamHandler StreamHandlerFunc
+	signingKey    SigningKeyFunc
+	transport     *TransportManager
+	resolveKey    KeyResolverFunc
+	verifier      *httpsig.Verifier
+	authenticator *authn.Authenticator
+	maxBody       int64
+	mu            sync.RWMutex
 }
 
 // NewNetworkServer creates a new network server.
 func NewNetworkServer(addr string, handler MessageHandlerFunc) *NetworkServer {
 	ns := &NetworkServer{
 		handler: handler,
+		maxBody: httpsig.DefaultMaxBodyBytes,
 	}
 
 	mux := http.NewServeMux()
-	mux.HandleFunc("/sage/message", ns.handleMessage)
+	mux.Handle(RouteMessage, ns.verified(ns.handleMessage))
+	mux.Handle(RouteStream, ns.verified(ns.handleStream))
+	mux.Handle(RouteHandshake, ns.verified(ns.handleHandshake))
+	mux.Handle(RouteSecure, ns.verified(ns.handleSecure))
 	mux.HandleFunc("/health", ns.handleHealth)
 
 	ns.httpServer = &http.Server{
@@ -200,6 +716,73 @@ func NewNetworkServer(addr string, handler MessageHandlerFunc) *NetworkServer {
 	return ns
 }
 
+// SetHTTPVerifier requires RFC 9421 HTTP message signatures on the SAGE
+// routes. Requests whose signature is missing or does not match the
+// request's method, URL, host or body are rejected with 401 Unauthorized
+// before they are handled. /health stays unsigned.
+func (ns *NetworkServer) SetHTTPVerifier(verifier *httpsig.Verifier) {
+	ns.mu.Lock()
+	defer ns.mu.Unlock()
+	ns.verifier = verifier
+}
+
+// SetAuthenticator requires an API key or bearer token on the SAGE
+// routes. Unauthenticated requests are rejected with 401 Unauthorized;
+// /health stays open.
+func (ns *NetworkServer) SetAuthenticator(authenticator *authn.Authenticator) {
+	ns.mu.Lock()
+	defer ns.mu.Unlock()
+	ns.authenticator = authenticator
+}
+
+// SetMaxBodyBytes bounds the request bodies of the SAGE routes. Larger
+// requests are rejected with 413 Request Entity Too Large. Defaults to
+// httpsig.DefaultMaxBodyBytes; n <= 0 restores the default.
+func (ns *NetworkServer) SetMaxBodyBytes(n int64) {
+	ns.mu.Lock()
+	defer ns.mu.Unlock()
+	if n <= 0 {
+		n = httpsig.DefaultMaxBodyBytes
+	}
+	ns.maxBody = n
+}
+
+// verified wraps a route handler with the body limit set by
+// SetMaxBodyBytes, the authenticator set by SetAuthenticator and the
+// verifier set by SetHTTPVerifier.
+func (ns *NetworkServer) verified(handler http.HandlerFunc) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ns.mu.RLock()
+		verifier := ns.verifier
+		authenticator := ns.authenticator
+		maxBody := ns.maxBody
+		ns.mu.RUnlock()
+
+		r.Body = http.MaxBytesReader(w, r.Body, maxBody)
+
+		var h http.Handler = handler
+		if verifier != nil {
+			h = verifier.Middleware(h)
+		}
+		if authenticator != nil {
+			h = authenticator.Middleware(h)
+		}
+		h.ServeHTTP(w, r)
+	})
+}
+
+// bodyError answers a request whose body could not be read or parsed:
+// with 413 Request Entity Too Large if it exceeded the body limit, and
+// with 400 Bad Request and message otherwise.
+func bodyError(w http.ResponseWriter, err error, message string) {
+	var tooLarge *http.MaxBytesError
+	if errors.As(err, &tooLarge) {
+		http.Error(w, "Request body too large", http.StatusRequestEntityTooLarge)
+		return
+	}
+	http.Error(w, message, http.StatusBadRequest)
+}
+
 // handleMessage handles incoming SAGE messages.
 func (ns *NetworkServer) handleMessage(w http.ResponseWriter, r *http.Request) {
 	// Only accept POST requests
@@ -211,7 +794,7 @@ func (ns *NetworkServer) handleMessage(w http.ResponseWriter, r *http.Request) {
 	// Read and deserialize message
 	bodyBytes, err := io.ReadAll(r.Body)
 	if err != nil {
-		http.Error(w, "Failed to read request body", http.StatusBadRequest)
+		bodyError(w, err, "Failed to read request body")
 		return
 	}
 	defer r.Body.Close()
@@ -226,7 +809,7 @@ func (ns *NetworkServer) handleMessage(w http.ResponseWriter, r *http.Request) {
 	if ns.handler != nil {
 		response, err := ns.handler(r.Context(), &msg)
 		if err != nil {
-			http.Error(w, err.Error(), http.StatusInternalServerError)
+			http.Error(w, err.Error(), statusForError(err))
 			return
 		}
 
@@ -245,18 +828,347 @@ func (ns *NetworkServer) handleMessage(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// SetStreamHandler sets the handler for the /sage/stream endpoint.
+func (ns *NetworkServer) SetStreamHandler(handler StreamHandlerFunc) {
+	ns.mu.Lock()
+	defer ns.mu.Unlock()
+	ns.streamHandler = handler
+}
+
+// SetStreamSigner sets the key used to sign streamed response chunks.
+// Without it, chunks are protected by their digest chain only.
+func (ns *NetworkServer) SetStreamSigner(privateKey ed25519.PrivateKey, keyID string) {
+	ns.SetStreamSigningKeyFunc(func() (ed25519.PrivateKey, string, error) {
+		return privateKey, keyID, nil
+	})
+}
+
+// SetStreamSigningKeyFunc is like SetStreamSigner, but looks up the key for
+// each stream so that key rotations apply to a running server.
+func (ns *NetworkServer) SetStreamSigningKeyFunc(fn SigningKeyFunc) {
+	ns.mu.Lock()
+	defer ns.mu.Unlock()
+	ns.signingKey = fn
+}
+
+// handleStream handles incoming SAGE messages with a chunked response.
+func (ns *NetworkServer) handleStream(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	ns.mu.RLock()
+	handler, signingKeyFunc := ns.streamHandler, ns.signingKey
+	ns.mu.RUnlock()
+
+	if handler == nil {
+		http.Error(w, "Streaming not supported", http.StatusNotImplemented)
+		return
+	}
+
+	var (
+		signingKey ed25519.PrivateKey
+		keyID      string
+	)
+	if signingKeyFunc != nil {
+		var err error
+		if signingKey, keyID, err = signingKeyFunc(); err != nil {
+			http.Error(w, "signing key unavailable", http.StatusInternalServerError)
+			return
+		}
+	}
+
+	var msg types.Message
+	if err := json.NewDecoder(r.Body).Decode(&msg); err != nil {
+		bodyError(w, err, "Failed to parse message")
+		return
+	}
+	defer r.Body.Close()
+
+	if msg.Security == nil {
+		http.Error(w, "missing security metadata for SAGE protocol", http.StatusBadRequest)
+		return
+	}
+
+	// Errors before the first chunk are reported as HTTP errors; later
+	// errors end the stream with an error chunk.
+	var writer *StreamWriter
+	start := func() {
+		if writer == nil {
+			w.Header().Set("Content-Type", ContentTypeStream)
+			w.WriteHeader(http.StatusOK)
+			writer = NewStreamWriter(w, msg.MessageID, msg.Security.Nonce, signingKey, keyID)
+		}
+	}
+	emit := func(chunk string) error {
+		start()
+		return writer.Write(chunk)
+	}
+
+	err := handler(r.Context(), &msg, emit)
+	if writer == nil && err != nil {
+		http.Error(w, err.Error(), statusForError(err))
+		return
+	}
+	start()
+	writer.Close(err)
+}
+
+// EnableEncryption serves encrypted sessions on the handshake and secure
+// routes. Decrypted messages go to the server's message handler, and its
+// responses are returned encrypted. resolveKey resolves the Ed25519 keys
+// peers sign handshake and session messages with.
+func (ns *NetworkServer) EnableEncryption(transport *TransportManager, resolveKey KeyResolverFunc) {
+	ns.mu.Lock()
+	defer ns.mu.Unlock()
+	ns.transport = transport
+	ns.resolveKey = resolveKey
+}
+
+// encryption returns the transport and key resolver, or nil if encrypted
+// sessions are not enabled.
+func (ns *NetworkServer) encryption() (*TransportManager, KeyResolverFunc) {
+	ns.mu.RLock()
+	defer ns.mu.RUnlock()
+
+	if ns.transport == nil || ns.resolveKey == nil {
+		return nil, nil
+	}
+	return ns.transport, ns.resolveKey
+}
+
+// handleHandshake answers the initiator's handshake messages: an
+// invitation with a request, and a response with a complete message. A
+// resume request for a known session is answered with a resumed message.
+func (ns *NetworkServer) handleHandshake(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	transport, resolveKey := ns.encryption()
+	if transport == nil {
+		http.Error(w, "Encrypted sessions not supported", http.StatusNotImplemented)
+		return
+	}
+
+	var envelope MessageEnvelope
+	if err := json.NewDecoder(r.Body).Decode(&envelope); err != nil {
+		bodyError(w, err, "Failed to parse handshake message")
+		return
+	}
+	defer r.Body.Close()
+
+	var (
+		reply interface{}
+		phase HandshakePhase
+		err   error
+	)
+	switch HandshakePhase(envelope.Type) {
+	case PhaseInvitation:
+		var invitation HandshakeInvitation
+		if err := UnwrapMessage(&envelope, &invitation); err != nil {
+			http.Error(w, "Failed to parse handshake message", http.StatusBadRequest)
+			return
+		}
+		if invitation.ToDID != transport.LocalDID() {
+			http.Error(w, "Invitation is addressed to another agent", http.StatusBadRequest)
+			return
+		}
+		reply, err = transport.HandleInvitation(r.Context(), &invitation)
+		phase = PhaseRequest
+
+	case PhaseResponse:
+		var response HandshakeResponse
+		if err := UnwrapMessage(&envelope, &response); err != nil {
+			http.Error(w, "Failed to parse handshake message", http.StatusBadRequest)
+			return
+		}
+		var peerPublicKey ed25519.PublicKey
+		peerPublicKey, err = peerKey(resolveKey, response.FromDID, response.Signature.KeyID, response.FromDID)
+		if err == nil {
+			reply, err = transport.HandleResponse(r.Context(), &response, peerPublicKey)
+		}
+		phase = PhaseComplete
+
+	case PhaseResume:
+		var resume SessionResume
+		if err := UnwrapMessage(&envelope, &resume); err != nil {
+			http.Error(w, "Failed to parse handshake message", http.StatusBadRequest)
+			return
+		}
+		if resume.ToDID != transport.LocalDID() {
+			http.Error(w, "Resumption is addressed to another agent", http.StatusBadRequest)
+			return
+		}
+		var peerPublicKey ed25519.PublicKey
+		peerPublicKey, err = peerKey(resolveKey, resume.FromDID, resume.Signature.KeyID, resume.FromDID)
+		if err == nil {
+			reply, err = transport.HandleResume(r.Context(), &resume, peerPublicKey)
+		}
+		phase = PhaseResumed
+
+	default:
+		http.Error(w, "Unexpected handshake phase", http.StatusBadRequest)
+		return
+	}
+
+	if err != nil {
+		http.Error(w, err.Error(), statusForError(err))
+		return
+	}
+
+	replyEnvelope, err := WrapMessage(string(phase), reply)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(replyEnvelope)
+}
+
+// handleSecure handles encrypted application messages. Senders without an
+// active session get 428 Precondition Required and should handshake again.
+// The decrypted message must be signed by the session's peer.
+func (ns *NetworkServer) handleSecure(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	transport, resolveKey := ns.encryption()
+	if transport == nil {
+		http.Error(w, "Encrypted sessions not supported", http.StatusNotImplemented)
+		return
+	}
+
+	var sealed ApplicationMessage
+	if err := json.NewDecoder(r.Body).Decode(&sealed); err != nil {
+		bodyError(w, err, "Failed to parse message")
+		return
+	}
+	defer r.Body.Close()
+
+	if sealed.ToDID != transport.LocalDID() {
+		http.Error(w, "Message is addressed to another agent", http.StatusBadRequest)
+		return
+	}
+
+	if !transport.HasSession(sealed.FromDID) {
+		http.Error(w, "No active session; handshake required", http.StatusPreconditionRequired)
+		return
+	}
+
+	peerPublicKey, err := peerKey(resolveKey, sealed.FromDID, sealed.Signature.KeyID, sealed.FromDID)
+	if err != nil {
+		http.Error(w, err.Error(), statusForError(err))
+		return
+	}
+
+	payload, err := transport.OpenMessage(r.Context(), &sealed, peerPublicKey)
+	if errors.Is(err, errors.ErrSessionOutOfSync) {
+		transport.Disconnect(r.Context(), sealed.FromDID)
+		http.Error(w, "Session out of sync; handshake required", http.StatusPreconditionRequired)
+		return
+	}
+	if err != nil {
+		http.Error(w, err.Error(), statusForError(err))
+		return
+	}
+
+	var msg types.Message
+	if err := json.Unmarshal(payload, &msg); err != nil {
+		http.Error(w, "Failed to parse message", http.StatusBadRequest)
+		return
+	}
+
+	// The session authenticates the peer; the message inside must be
+	// signed by the same agent, so it cannot speak for another DID
+	if msg.Security == nil || msg.Security.AgentDID != sealed.FromDID {
+		http.Error(w, "Message is not from the session's peer", http.StatusUnauthorized)
+		return
+	}
+	if err := verifyMessageSignature(transport.signingManager, &msg, resolveKey); err != nil {
+		http.Error(w, err.Error(), statusForError(err))
+		return
+	}
+
+	if ns.handler == nil {
+		w.WriteHeader(http.StatusAccepted)
+		return
+	}
+
+	response, err := ns.handler(r.Context(), &msg)
+	if err != nil {
+		http.Error(w, err.Error(), statusForError(err))
+		return
+	}
+	if response == nil {
+		w.WriteHeader(http.StatusAccepted)
+		return
+	}
+
+	sealedResponse, err := transport.SendMessage(r.Context(), sealed.FromDID, response)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(sealedResponse)
+}
+
+// statusForError maps handler errors to HTTP status codes.
+func statusForError(err error) int {
+	switch {
+	case errors.Is(err, errors.ErrAccessDenied):
+		return http.StatusForbidden
+	case errors.IsCategory(err, errors.CategorySecurity),
+		errors.IsUnauthorized(err),
+		errors.Is(err, errors.ErrProtocolMismatch):
+		return http.StatusUnauthorized
+	case errors.IsInvalidInput(err):
+		return http.StatusBadRequest
+	case errors.IsNotFound(err):
+		return http.StatusNotFound
+	case errors.IsRateLimitExceeded(err):
+		return http.StatusTooManyRequests
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
 // handleHealth handles health check requests.
 func (ns *NetworkServer) handleHealth(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusOK)
 	json.NewEncoder(w).Encode(map[string]string{
-		"status": "healthy",
+		"status":  "healthy",
 		"service": "sage-adk",
 	})
 }
 
+// EnableTLS serves HTTPS with the given settings, optionally requiring
+// client certificates. The client certificate identity is available to
+// handlers through mtls.PeerFromContext. Call it before Start.
+func (ns *NetworkServer) EnableTLS(config *mtls.Config) error {
+	tlsConfig, err := config.ServerTLS()
+	if err != nil {
+		return err
+	}
+
+	ns.httpServer.TLSConfig = tlsConfig
+	ns.httpServer.Handler = mtls.Middleware(ns.httpServer.Handler)
+	return nil
+}
+
 // Start starts the HTTP server.
 func (ns *NetworkServer) Start() error {
+	if ns.httpServer.TLSConfig != nil {
+		return ns.httpServer.ListenAndServeTLS("", "")
+	}
 	return ns.httpServer.ListenAndServe()
 }
 
@@ -18,12 +18,24 @@
 package sage
 
 import (
+	"bytes"
 	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/json"
+	"fmt"
+	"io"
 	"net/http"
 	"net/http/httptest"
+	"sync/atomic"
 	"testing"
 	"time"
 
+	"github.com/sage-x-project/sage-adk/adapters/sage/httpsig"
+	"github.com/sage-x-project/sage-adk/adapters/sage/ratchet"
+	"github.com/sage-x-project/sage-adk/adapters/sage/sessionstore"
+	"github.com/sage-x-project/sage-adk/core/protocol"
+	"github.com/sage-x-project/sage-adk/pkg/errors"
 	"github.com/sage-x-project/sage-adk/pkg/types"
 )
 
@@ -153,6 +165,36 @@ func TestNetworkServer_HandleMessage(t *testing.T) {
 	}
 }
 
+func TestNetworkServer_MaxBodyBytes(t *testing.T) {
+	called := false
+	server := NewNetworkServer(":0", func(ctx context.Context, msg *types.Message) (*types.Message, error) {
+		called = true
+		return nil, nil
+	})
+	server.SetStreamHandler(func(ctx context.Context, msg *types.Message, emit protocol.StreamFunc) error {
+		called = true
+		return nil
+	})
+	keys := make(map[string]ed25519.PublicKey)
+	bob := newSecureTestPeer(t, "did:sage:bob", keys, nil)
+	server.EnableEncryption(bob.transport, testKeyResolver(keys))
+	server.SetMaxBodyBytes(64)
+
+	for _, route := range []string{RouteMessage, RouteStream, RouteHandshake, RouteSecure} {
+		body := bytes.Repeat([]byte(" "), 128)
+		req := httptest.NewRequest(http.MethodPost, route, bytes.NewReader(body))
+		w := httptest.NewRecorder()
+		server.httpServer.Handler.ServeHTTP(w, req)
+
+		if w.Code != http.StatusRequestEntityTooLarge {
+			t.Errorf("%s: status = %d, want 413", route, w.Code)
+		}
+	}
+	if called {
+		t.Error("handler ran for a request over the limit")
+	}
+}
+
 func TestNetworkServer_Health(t *testing.T) {
 	server := NewNetworkServer(":0", nil)
 
@@ -226,3 +268,410 @@ func TestNewNetworkClient_WithCustomConfig(t *testing.T) {
 		t.Errorf("Timeout = %v, want %v", client.timeout, config.Timeout)
 	}
 }
+
+// secureTestPeer is an agent identity with a transport manager.
+type secureTestPeer struct {
+	did       string
+	transport *TransportManager
+}
+
+func newSecureTestPeer(t *testing.T, did string, keys map[string]ed25519.PublicKey, config *TransportConfig) *secureTestPeer {
+	t.Helper()
+
+	publicKey, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("GenerateKey() error = %v", err)
+	}
+	keys[did] = publicKey
+
+	return &secureTestPeer{did: did, transport: NewTransportManager(did, privateKey, config)}
+}
+
+func testKeyResolver(keys map[string]ed25519.PublicKey) KeyResolverFunc {
+	return func(keyID string) (ed25519.PublicKey, error) {
+		key, ok := keys[didFromKeyID(keyID)]
+		if !ok {
+			return nil, fmt.Errorf("unknown key %s", keyID)
+		}
+		return key, nil
+	}
+}
+
+// newSecureTestServer serves bob with an echo handler and counts handshake
+// invitations.
+func newSecureTestServer(t *testing.T, bob *secureTestPeer, resolveKey KeyResolverFunc, invitations *int32) *httptest.Server {
+	t.Helper()
+
+	server := NewNetworkServer(":0", func(ctx context.Context, msg *types.Message) (*types.Message, error) {
+		text := msg.Parts[0].(*types.TextPart).Text
+		return types.NewMessage(types.MessageRoleAgent, []types.Part{types.NewTextPart("echo: " + text)}), nil
+	})
+	server.EnableEncryption(bob.transport, resolveKey)
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == RouteHandshake {
+			body, _ := io.ReadAll(r.Body)
+			var envelope MessageEnvelope
+			if json.Unmarshal(body, &envelope) == nil && envelope.Type == string(PhaseInvitation) {
+				atomic.AddInt32(invitations, 1)
+			}
+			r.Body = io.NopCloser(bytes.NewReader(body))
+		}
+		server.httpServer.Handler.ServeHTTP(w, r)
+	}))
+	t.Cleanup(ts.Close)
+	return ts
+}
+
+// signedTestMessage returns a text message signed as the local agent of
+// transport.
+func signedTestMessage(t *testing.T, transport *TransportManager, text string) *types.Message {
+	t.Helper()
+
+	privateKey, keyID, err := transport.handshakeManager.currentKey()
+	if err != nil {
+		t.Fatalf("currentKey() error = %v", err)
+	}
+
+	msg := types.NewMessage(types.MessageRoleUser, []types.Part{types.NewTextPart(text)})
+	msg.Security = &types.SecurityMetadata{
+		Mode:      types.ProtocolModeSAGE,
+		AgentDID:  transport.LocalDID(),
+		Nonce:     generateNonce(),
+		Timestamp: time.Now(),
+	}
+	if err := signMessageWith(transport.signingManager, msg, privateKey, keyID); err != nil {
+		t.Fatalf("signMessageWith() error = %v", err)
+	}
+	return msg
+}
+
+func sendEncryptedText(t *testing.T, client *NetworkClient, endpoint, remoteDID, text string) string {
+	t.Helper()
+
+	transport, _, err := client.encryption()
+	if err != nil {
+		t.Fatalf("encryption() error = %v", err)
+	}
+	msg := signedTestMessage(t, transport, text)
+	reply, err := client.SendEncrypted(context.Background(), endpoint, remoteDID, msg)
+	if err != nil {
+		t.Fatalf("SendEncrypted() error = %v", err)
+	}
+	if reply == nil || len(reply.Parts) != 1 {
+		t.Fatalf("SendEncrypted() reply = %+v", reply)
+	}
+	return reply.Parts[0].(*types.TextPart).Text
+}
+
+func TestNetworkClient_SendEncrypted(t *testing.T) {
+	keys := make(map[string]ed25519.PublicKey)
+	alice := newSecureTestPeer(t, "did:sage:alice", keys, nil)
+	bob := newSecureTestPeer(t, "did:sage:bob", keys, nil)
+
+	var invitations int32
+	ts := newSecureTestServer(t, bob, testKeyResolver(keys), &invitations)
+
+	client := NewNetworkClient(nil)
+	client.EnableEncryption(alice.transport, testKeyResolver(keys))
+
+	endpoint := ts.URL + RouteMessage
+	if got := sendEncryptedText(t, client, endpoint, bob.did, "hello"); got != "echo: hello" {
+		t.Errorf("reply = %q, want %q", got, "echo: hello")
+	}
+	if got := sendEncryptedText(t, client, endpoint, bob.did, "again"); got != "echo: again" {
+		t.Errorf("reply = %q, want %q", got, "echo: again")
+	}
+
+	if n := atomic.LoadInt32(&invitations); n != 1 {
+		t.Errorf("handshakes = %d, want 1 (session reused)", n)
+	}
+	if !alice.transport.HasSession(bob.did) || !bob.transport.HasSession(alice.did) {
+		t.Error("both peers should hold an active session")
+	}
+}
+
+func TestNetworkClient_SendEncrypted_Rehandshake(t *testing.T) {
+	keys := make(map[string]ed25519.PublicKey)
+	alice := newSecureTestPeer(t, "did:sage:alice", keys, &TransportConfig{
+		SessionTTL:   50 * time.Millisecond,
+		MaxClockSkew: time.Minute,
+	})
+	bob := newSecureTestPeer(t, "did:sage:bob", keys, nil)
+
+	var invitations int32
+	ts := newSecureTestServer(t, bob, testKeyResolver(keys), &invitations)
+
+	client := NewNetworkClient(nil)
+	client.EnableEncryption(alice.transport, testKeyResolver(keys))
+	endpoint := ts.URL
+
+	sendEncryptedText(t, client, endpoint, bob.did, "first")
+
+	// Expired sessions are re-established before sending.
+	time.Sleep(100 * time.Millisecond)
+	sendEncryptedText(t, client, endpoint, bob.did, "after expiry")
+	if n := atomic.LoadInt32(&invitations); n != 2 {
+		t.Errorf("handshakes after expiry = %d, want 2", n)
+	}
+
+	// Sessions the peer dropped, e.g. after a restart, are re-established.
+	if err := bob.transport.Disconnect(context.Background(), alice.did); err != nil {
+		t.Fatalf("Disconnect() error = %v", err)
+	}
+	sendEncryptedText(t, client, endpoint, bob.did, "after peer restart")
+	if n := atomic.LoadInt32(&invitations); n != 3 {
+		t.Errorf("handshakes after peer dropped session = %d, want 3", n)
+	}
+}
+
+func TestNetworkClient_SendEncrypted_ResumeAfterRestart(t *testing.T) {
+	keys := make(map[string]ed25519.PublicKey)
+	alice := newSecureTestPeer(t, "did:sage:alice", keys, nil)
+	bob := newSecureTestPeer(t, "did:sage:bob", keys, nil)
+	aliceStore, bobStore := sessionstore.NewMemory(), sessionstore.NewMemory()
+	alice.transport.SetSessionStore(aliceStore)
+	bob.transport.SetSessionStore(bobStore)
+
+	var invitations int32
+	ts := newSecureTestServer(t, bob, testKeyResolver(keys), &invitations)
+
+	client := NewNetworkClient(nil)
+	client.EnableEncryption(alice.transport, testKeyResolver(keys))
+
+	sendEncryptedText(t, client, ts.URL, bob.did, "first")
+	established, err := alice.transport.GetSession(bob.did)
+	if err != nil {
+		t.Fatalf("GetSession() error = %v", err)
+	}
+
+	// Both sides restart and resume the stored session without a handshake.
+	alice.transport.Close()
+	bob.transport.Close()
+	if reply := sendEncryptedText(t, client, ts.URL, bob.did, "after restart"); reply != "echo: after restart" {
+		t.Errorf("reply = %q", reply)
+	}
+	if n := atomic.LoadInt32(&invitations); n != 1 {
+		t.Errorf("handshakes after restart = %d, want 1", n)
+	}
+	resumed, err := alice.transport.GetSession(bob.did)
+	if err != nil {
+		t.Fatalf("GetSession() error = %v", err)
+	}
+	if resumed.ID != established.ID || resumed.Resumed {
+		t.Errorf("session after restart = %s (resumed %v), want confirmed %s", resumed.ID, resumed.Resumed, established.ID)
+	}
+
+	// A peer that lost its store no longer knows the session, so the
+	// client falls back to a full handshake.
+	alice.transport.Close()
+	bob.transport.Close()
+	bob.transport.SetSessionStore(sessionstore.NewMemory())
+	sendEncryptedText(t, client, ts.URL, bob.did, "after peer lost its sessions")
+	if n := atomic.LoadInt32(&invitations); n != 2 {
+		t.Errorf("handshakes after peer lost its sessions = %d, want 2", n)
+	}
+}
+
+func TestNetworkClient_SendEncrypted_OutOfSync(t *testing.T) {
+	keys := make(map[string]ed25519.PublicKey)
+	alice := newSecureTestPeer(t, "did:sage:alice", keys, nil)
+	bob := newSecureTestPeer(t, "did:sage:bob", keys, nil)
+
+	var invitations int32
+	ts := newSecureTestServer(t, bob, testKeyResolver(keys), &invitations)
+
+	client := NewNetworkClient(nil)
+	client.EnableEncryption(alice.transport, testKeyResolver(keys))
+
+	sendEncryptedText(t, client, ts.URL, bob.did, "first")
+
+	// The peer's message keys diverge, e.g. after a lost key exchange; the
+	// session is re-established.
+	session, err := bob.transport.GetSession(alice.did)
+	if err != nil {
+		t.Fatalf("GetSession() error = %v", err)
+	}
+	diverged, _ := ratchet.New(bytes.Repeat([]byte{1}, ratchet.KeySize), bob.did, alice.did, time.Now())
+	updated := *session
+	updated.Ratchet = diverged
+	if err := bob.transport.sessionManager.Update(&updated); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+
+	if reply := sendEncryptedText(t, client, ts.URL, bob.did, "after divergence"); reply != "echo: after divergence" {
+		t.Errorf("reply = %q", reply)
+	}
+	if n := atomic.LoadInt32(&invitations); n != 2 {
+		t.Errorf("handshakes = %d, want 2", n)
+	}
+}
+
+func TestNetworkClient_SendEncrypted_PeerWithoutEncryption(t *testing.T) {
+	keys := make(map[string]ed25519.PublicKey)
+	alice := newSecureTestPeer(t, "did:sage:alice", keys, nil)
+
+	server := NewNetworkServer(":0", nil)
+	ts := httptest.NewServer(server.httpServer.Handler)
+	defer ts.Close()
+
+	client := NewNetworkClient(nil)
+	client.EnableEncryption(alice.transport, testKeyResolver(keys))
+
+	msg := types.NewMessage(types.MessageRoleUser, []types.Part{types.NewTextPart("hi")})
+	_, err := client.SendEncrypted(context.Background(), ts.URL, "did:sage:bob", msg)
+	if !errors.Is(err, errors.ErrProtocolMismatch) {
+		t.Fatalf("SendEncrypted() error = %v, want ErrProtocolMismatch", err)
+	}
+	if alice.transport.HasSession("did:sage:bob") {
+		t.Error("failed handshake should not leave a session")
+	}
+
+	// A retry after the failure starts a fresh handshake.
+	_, err = client.SendEncrypted(context.Background(), ts.URL, "did:sage:bob", msg)
+	if !errors.Is(err, errors.ErrProtocolMismatch) {
+		t.Fatalf("second SendEncrypted() error = %v, want ErrProtocolMismatch", err)
+	}
+}
+
+func TestNetworkClient_SendEncrypted_NotEnabled(t *testing.T) {
+	client := NewNetworkClient(nil)
+
+	msg := types.NewMessage(types.MessageRoleUser, []types.Part{types.NewTextPart("hi")})
+	_, err := client.SendEncrypted(context.Background(), "http://localhost", "did:sage:bob", msg)
+	if !errors.Is(err, errors.ErrConfigurationError) {
+		t.Errorf("SendEncrypted() error = %v, want ErrConfigurationError", err)
+	}
+}
+
+func TestNetworkServer_Handshake_RejectsImpersonation(t *testing.T) {
+	keys := make(map[string]ed25519.PublicKey)
+	alice := newSecureTestPeer(t, "did:sage:alice", keys, nil)
+	bob := newSecureTestPeer(t, "did:sage:bob", keys, nil)
+
+	var invitations int32
+	ts := newSecureTestServer(t, bob, testKeyResolver(keys), &invitations)
+
+	// The impostor claims Alice's DID but signs with its own key.
+	_, impostorKey, _ := ed25519.GenerateKey(rand.Reader)
+	impostor := NewTransportManager(alice.did, impostorKey, nil)
+
+	client := NewNetworkClient(nil)
+	client.EnableEncryption(impostor, testKeyResolver(keys))
+
+	msg := types.NewMessage(types.MessageRoleUser, []types.Part{types.NewTextPart("hi")})
+	if _, err := client.SendEncrypted(context.Background(), ts.URL, bob.did, msg); err == nil {
+		t.Fatal("SendEncrypted() should fail when the initiator's signature does not match its DID")
+	}
+	if bob.transport.HasSession(alice.did) {
+		t.Error("server should not activate a session for an impersonated DID")
+	}
+}
+
+func TestNetworkServer_Secure_RequiresPeerSignature(t *testing.T) {
+	keys := make(map[string]ed25519.PublicKey)
+	alice := newSecureTestPeer(t, "did:sage:alice", keys, nil)
+	bob := newSecureTestPeer(t, "did:sage:bob", keys, nil)
+	carol := newSecureTestPeer(t, "did:sage:carol", keys, nil)
+
+	var handled int32
+	server := NewNetworkServer(":0", func(ctx context.Context, msg *types.Message) (*types.Message, error) {
+		atomic.AddInt32(&handled, 1)
+		return nil, nil
+	})
+	server.EnableEncryption(bob.transport, testKeyResolver(keys))
+	ts := httptest.NewServer(server.httpServer.Handler)
+	defer ts.Close()
+
+	client := NewNetworkClient(nil)
+	client.EnableEncryption(alice.transport, testKeyResolver(keys))
+	ctx := context.Background()
+
+	if _, err := client.SendEncrypted(ctx, ts.URL, bob.did, signedTestMessage(t, alice.transport, "hi")); err != nil {
+		t.Fatalf("SendEncrypted() error = %v", err)
+	}
+
+	// Inside Alice's session, unsigned messages and messages of another
+	// agent, even validly signed, are rejected
+	unsigned := types.NewMessage(types.MessageRoleUser, []types.Part{types.NewTextPart("hi")})
+	if _, err := client.SendEncrypted(ctx, ts.URL, bob.did, unsigned); err == nil {
+		t.Error("SendEncrypted() of an unsigned message should fail")
+	}
+	if _, err := client.SendEncrypted(ctx, ts.URL, bob.did, signedTestMessage(t, carol.transport, "hi")); err == nil {
+		t.Error("SendEncrypted() of another agent's message should fail")
+	}
+
+	if n := atomic.LoadInt32(&handled); n != 1 {
+		t.Errorf("handled messages = %d, want 1", n)
+	}
+	if !bob.transport.HasSession(alice.did) {
+		t.Error("rejected messages should not end the session")
+	}
+}
+
+func TestNetworkServer_HTTPSignatures(t *testing.T) {
+	keys := make(map[string]ed25519.PublicKey)
+	alice := newSecureTestPeer(t, "did:sage:alice", keys, nil)
+	bob := newSecureTestPeer(t, "did:sage:bob", keys, nil)
+
+	server := NewNetworkServer(":0", func(ctx context.Context, msg *types.Message) (*types.Message, error) {
+		if httpsig.FromContext(ctx) == nil {
+			t.Error("handler context has no verified HTTP signature")
+		}
+		text := msg.Parts[0].(*types.TextPart).Text
+		return types.NewMessage(types.MessageRoleAgent, []types.Part{types.NewTextPart("echo: " + text)}), nil
+	})
+	server.EnableEncryption(bob.transport, testKeyResolver(keys))
+	server.SetHTTPVerifier(httpsig.NewVerifier(httpsig.KeyResolver(testKeyResolver(keys)), nil))
+	ts := httptest.NewServer(server.httpServer.Handler)
+	t.Cleanup(ts.Close)
+
+	aliceKey, aliceKeyID, _ := alice.transport.handshakeManager.currentKey()
+	client := NewNetworkClient(nil)
+	client.SetHTTPSigner(httpsig.NewSigner(httpsig.StaticKey(aliceKey, aliceKeyID), nil))
+	client.EnableEncryption(alice.transport, testKeyResolver(keys))
+
+	// Plain and encrypted messages are signed on every route
+	msg := types.NewMessage(types.MessageRoleUser, []types.Part{types.NewTextPart("hi")})
+	if err := client.SendMessage(context.Background(), ts.URL+RouteMessage, msg); err != nil {
+		t.Fatalf("SendMessage() error = %v", err)
+	}
+	if got := sendEncryptedText(t, client, ts.URL+RouteMessage, bob.did, "hello"); got != "echo: hello" {
+		t.Errorf("reply = %q, want %q", got, "echo: hello")
+	}
+
+	// Unsigned requests are rejected
+	body, _ := json.Marshal(msg)
+	resp, err := http.Post(ts.URL+RouteMessage, "application/json", bytes.NewReader(body))
+	if err != nil {
+		t.Fatalf("POST %s error = %v", RouteMessage, err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusUnauthorized {
+		t.Errorf("unsigned POST status = %d, want 401", resp.StatusCode)
+	}
+
+	// Health checks stay open
+	resp, err = http.Get(ts.URL + "/health")
+	if err != nil {
+		t.Fatalf("GET /health error = %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("GET /health status = %d, want 200", resp.StatusCode)
+	}
+}
+
+func TestEndpointFor(t *testing.T) {
+	tests := map[string]string{
+		"http://peer:8080":               "http://peer:8080/sage/secure",
+		"http://peer:8080/":              "http://peer:8080/sage/secure",
+		"http://peer:8080/sage/message":  "http://peer:8080/sage/secure",
+		"http://peer:8080/sage/message/": "http://peer:8080/sage/secure",
+	}
+	for endpoint, want := range tests {
+		if got := endpointFor(endpoint, RouteSecure); got != want {
+			t.Errorf("endpointFor(%q) = %q, want %q", endpoint, got, want)
+		}
+	}
+}
@@ -0,0 +1,337 @@
+// Copyright (C) 2025 sage-x-project
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with this program. If not, see <https://www.gnu.org/licenses/>.
+
+// SPDX-License-Identifier: LGPL-3.0-or-later
+
+package sage
+
+import (
+	"context"
+	"crypto/ecdh"
+	"encoding/base64"
+	"encoding/json"
+
+	"github.com/sage-x-project/sage-adk/adapters/sage/didmethod"
+	"github.com/sage-x-project/sage-adk/core/middleware"
+	"github.com/sage-x-project/sage-adk/pkg/errors"
+	"github.com/sage-x-project/sage-adk/pkg/types"
+)
+
+// EncryptedPartType is the "type" of the data of a sealed part envelope.
+const EncryptedPartType = "sage.encrypted-part/v1"
+
+// EncryptedPart is the data of a DataPart that carries a sealed message
+// part. The whole original part, including its kind and metadata, is in
+// the ciphertext; only the recipient is visible to intermediaries.
+type EncryptedPart struct {
+	// Type is always EncryptedPartType.
+	Type string `json:"type"`
+
+	// Recipient is the DID or key ID the part is sealed for.
+	Recipient string `json:"recipient,omitempty"`
+
+	// Algorithm is the encryption scheme ("HPKE").
+	Algorithm string `json:"algorithm"`
+
+	// EphemeralKey is the sender's base64-encoded X25519 ephemeral key.
+	EphemeralKey string `json:"ephemeral_key"`
+
+	// Ciphertext and Nonce are base64-encoded.
+	Ciphertext string `json:"ciphertext"`
+	Nonce      string `json:"nonce"`
+}
+
+// ParseEncryptedPart returns the envelope of a sealed part. ok is false for
+// any other part.
+func ParseEncryptedPart(part types.Part) (envelope *EncryptedPart, ok bool) {
+	dataPart, isData := part.(*types.DataPart)
+	if !isData || dataPart.Data == nil {
+		return nil, false
+	}
+
+	// The data is an *EncryptedPart when sealed locally and a map once it
+	// has been through JSON.
+	if envelope, isEnvelope := dataPart.Data.(*EncryptedPart); isEnvelope {
+		return envelope, envelope.Type == EncryptedPartType
+	}
+	fields, isMap := dataPart.Data.(map[string]interface{})
+	if !isMap || fields["type"] != EncryptedPartType {
+		return nil, false
+	}
+	raw, err := json.Marshal(fields)
+	if err != nil {
+		return nil, false
+	}
+	envelope = &EncryptedPart{}
+	if err := json.Unmarshal(raw, envelope); err != nil {
+		return nil, false
+	}
+	return envelope, true
+}
+
+// SealPart encrypts a part for the holder of recipientKey and returns the
+// DataPart envelope that replaces it in a message. recipient names the
+// key's owner so that agents can tell which parts are theirs; it is not
+// encrypted.
+func (em *EncryptionManager) SealPart(part types.Part, recipient string, recipientKey *ecdh.PublicKey) (*types.DataPart, error) {
+	if part == nil {
+		return nil, errors.ErrInvalidInput.WithMessage("part is nil")
+	}
+	if recipientKey == nil {
+		return nil, errors.ErrInvalidInput.WithMessage("recipient key is nil")
+	}
+	if _, sealed := ParseEncryptedPart(part); sealed {
+		return nil, errors.ErrInvalidInput.WithMessage("part is already sealed")
+	}
+	if err := part.Validate(); err != nil {
+		return nil, errors.ErrInvalidInput.
+			WithMessage("invalid part").
+			WithDetail("error", err.Error())
+	}
+
+	payload, ephemeralPub, err := em.EncryptPayloadForPublicKey(part, recipientKey.Bytes())
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.DataPart{
+		Kind: string(types.PartKindData),
+		Data: &EncryptedPart{
+			Type:         EncryptedPartType,
+			Recipient:    recipient,
+			Algorithm:    payload.Algorithm,
+			EphemeralKey: base64.StdEncoding.EncodeToString(ephemeralPub),
+			Ciphertext:   payload.Ciphertext,
+			Nonce:        payload.Nonce,
+		},
+	}, nil
+}
+
+// OpenPart decrypts a sealed part envelope with the recipient's private
+// key and returns the original part.
+func (em *EncryptionManager) OpenPart(part types.Part, privateKey *ecdh.PrivateKey) (types.Part, error) {
+	envelope, ok := ParseEncryptedPart(part)
+	if !ok {
+		return nil, errors.ErrInvalidInput.WithMessage("part is not sealed")
+	}
+	if envelope.Algorithm != "HPKE" {
+		return nil, errors.ErrInvalidInput.
+			WithMessage("unsupported sealed part algorithm").
+			WithDetail("algorithm", envelope.Algorithm)
+	}
+
+	ephemeralPub, err := base64.StdEncoding.DecodeString(envelope.EphemeralKey)
+	if err != nil {
+		return nil, errors.ErrInvalidInput.
+			WithMessage("failed to decode ephemeral key").
+			WithDetail("error", err.Error())
+	}
+
+	var plaintext json.RawMessage
+	payload := &EncryptedPayload{
+		Algorithm:  envelope.Algorithm,
+		Ciphertext: envelope.Ciphertext,
+		Nonce:      envelope.Nonce,
+	}
+	if err := em.DecryptPayloadWithPrivateKey(payload, privateKey, ephemeralPub, &plaintext); err != nil {
+		return nil, err
+	}
+
+	opened, err := types.UnmarshalPart(plaintext)
+	if err != nil {
+		return nil, errors.ErrInvalidFormat.
+			WithMessage("sealed part is not a message part").
+			WithDetail("error", err.Error())
+	}
+	return opened, nil
+}
+
+// PartKeysFunc returns the X25519 private keys an agent opens sealed parts
+// with, the current key first.
+type PartKeysFunc func() ([]*ecdh.PrivateKey, error)
+
+// PartDecrypter opens the sealed parts of inbound messages that are
+// addressed to one agent. It is safe for concurrent use.
+type PartDecrypter struct {
+	recipient  string
+	keys       PartKeysFunc
+	encryption *EncryptionManager
+}
+
+// NewPartDecrypter creates a decrypter for the parts sealed for recipient,
+// a DID or key ID. Parts sealed for any key ID of the DID, and parts
+// without a recipient, are opened. An empty recipient opens every part the
+// keys can decrypt.
+func NewPartDecrypter(recipient string, keys PartKeysFunc) *PartDecrypter {
+	return &PartDecrypter{
+		recipient:  recipient,
+		keys:       keys,
+		encryption: NewEncryptionManager(),
+	}
+}
+
+// addressedTo reports whether parts sealed for recipient are the
+// decrypter's to open.
+func (d *PartDecrypter) addressedTo(recipient string) bool {
+	if d.recipient == "" || recipient == "" || recipient == d.recipient {
+		return true
+	}
+	return didFromKeyID(recipient) == didFromKeyID(d.recipient)
+}
+
+// Open returns a copy of msg in which the parts sealed for the decrypter's
+// recipient are replaced by their plaintext. Parts sealed for other agents
+// stay sealed. msg itself is not modified, so whatever logged or stored it
+// before keeps only the envelopes.
+func (d *PartDecrypter) Open(msg *types.Message) (*types.Message, error) {
+	if msg == nil {
+		return nil, nil
+	}
+
+	var keys []*ecdh.PrivateKey
+	var opened []types.Part
+	for i, part := range msg.Parts {
+		envelope, sealed := ParseEncryptedPart(part)
+		if !sealed || !d.addressedTo(envelope.Recipient) {
+			continue
+		}
+
+		if opened == nil {
+			var err error
+			if keys, err = d.keys(); err != nil {
+				return nil, err
+			}
+			opened = append([]types.Part(nil), msg.Parts...)
+		}
+
+		plain, err := d.openWith(part, keys)
+		if err != nil {
+			return nil, errors.ErrInvalidInput.
+				WithMessage("cannot open sealed part").
+				WithDetail("index", i).
+				WithDetail("recipient", envelope.Recipient).
+				WithDetail("error", err.Error())
+		}
+		opened[i] = plain
+	}
+
+	if opened == nil {
+		return msg, nil
+	}
+	copied := *msg
+	copied.Parts = opened
+	return &copied, nil
+}
+
+// openWith tries each key in turn, so parts sealed before a key rotation
+// still open.
+func (d *PartDecrypter) openWith(part types.Part, keys []*ecdh.PrivateKey) (types.Part, error) {
+	if len(keys) == 0 {
+		return nil, errors.ErrConfigurationError.WithMessage("no decryption key")
+	}
+
+	var lastErr error
+	for _, key := range keys {
+		plain, err := d.encryption.OpenPart(part, key)
+		if err == nil {
+			return plain, nil
+		}
+		lastErr = err
+	}
+	return nil, lastErr
+}
+
+// Middleware opens sealed parts before the message reaches the next
+// handler. Middleware placed before it, such as logging or auditing, only
+// sees the envelopes.
+func (d *PartDecrypter) Middleware() middleware.Middleware {
+	return func(next middleware.Handler) middleware.Handler {
+		return func(ctx context.Context, msg *types.Message) (*types.Message, error) {
+			opened, err := d.Open(msg)
+			if err != nil {
+				return nil, err
+			}
+			return next(ctx, opened)
+		}
+	}
+}
+
+// SealPart seals a part for the agent identified by recipientDID, using
+// the key agreement key its DID publishes (or the one derived from its
+// Ed25519 key). The envelope can travel through any A2A intermediary; only
+// the recipient can open it.
+func (a *Adapter) SealPart(ctx context.Context, part types.Part, recipientDID string) (*types.DataPart, error) {
+	a.mu.RLock()
+	resolver := a.didResolver
+	a.mu.RUnlock()
+
+	if resolver == nil {
+		return nil, errors.ErrConfigurationError.WithMessage("sealing parts requires a DID resolver")
+	}
+
+	recipientKey, err := resolver.ResolveKEMKey(ctx, recipientDID)
+	if err != nil {
+		return nil, errors.ErrOperationFailed.
+			WithMessage("failed to resolve key agreement key").
+			WithDetail("did", recipientDID).
+			WithDetail("error", err.Error())
+	}
+	return NewEncryptionManager().SealPart(part, recipientDID, recipientKey)
+}
+
+// PartDecrypter returns a decrypter for the parts sealed for this agent's
+// DID. It opens them with the X25519 keys derived from the agent's
+// Ed25519 keys, following key ring rotations.
+func (a *Adapter) PartDecrypter() *PartDecrypter {
+	return NewPartDecrypter(a.agentDID, a.partKeys)
+}
+
+// partKeys returns the X25519 keys matching the agent's Ed25519 keys: the
+// current signing key, then the other keys of the ring.
+func (a *Adapter) partKeys() ([]*ecdh.PrivateKey, error) {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	current, currentID, err := a.signingKeyLocked()
+	if err != nil {
+		return nil, err
+	}
+	if current == nil {
+		return nil, errors.ErrConfigurationError.WithMessage("no private key loaded")
+	}
+
+	key, err := didmethod.X25519PrivateKey(current)
+	if err != nil {
+		return nil, err
+	}
+	keys := []*ecdh.PrivateKey{key}
+
+	if a.keyRing == nil {
+		return keys, nil
+	}
+	for _, public := range a.keyRing.Keys() {
+		if a.keyRing.KeyID(public) == currentID {
+			continue
+		}
+		ringKey, err := a.keyRing.Lookup(public.ID)
+		if err != nil || ringKey.PrivateKey == nil {
+			continue
+		}
+		if key, err := didmethod.X25519PrivateKey(ringKey.PrivateKey); err == nil {
+			keys = append(keys, key)
+		}
+	}
+	return keys, nil
+}
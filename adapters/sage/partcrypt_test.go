@@ -0,0 +1,160 @@
+// Copyright (C) 2025 sage-x-project
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with this program. If not, see <https://www.gnu.org/licenses/>.
+
+// SPDX-License-Identifier: LGPL-3.0-or-later
+
+package sage
+
+import (
+	"context"
+	"crypto/ecdh"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/sage-x-project/sage-adk/adapters/sage/didmethod"
+	"github.com/sage-x-project/sage-adk/pkg/errors"
+	"github.com/sage-x-project/sage-adk/pkg/types"
+)
+
+// partKeyPair returns an agent's Ed25519 key as the X25519 pair parts are
+// sealed to.
+func partKeyPair(t *testing.T) (*ecdh.PublicKey, *ecdh.PrivateKey) {
+	t.Helper()
+	publicKey, privateKey, _ := ed25519.GenerateKey(rand.Reader)
+	sealTo, err := didmethod.X25519PublicKey(publicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	openWith, err := didmethod.X25519PrivateKey(privateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return sealTo, openWith
+}
+
+func TestEncryptionManager_SealOpenPart(t *testing.T) {
+	em := NewEncryptionManager()
+	publicKey, privateKey := partKeyPair(t)
+	_, otherKey := partKeyPair(t)
+
+	parts := []types.Part{
+		types.NewTextPart("account number 1234"),
+		types.NewFilePartWithBytes("statement.pdf", "application/pdf", []byte("%PDF-1.4")),
+		types.NewDataPart(map[string]interface{}{"ssn": "078-05-1120"}),
+	}
+
+	for _, part := range parts {
+		t.Run(part.GetKind(), func(t *testing.T) {
+			sealed, err := em.SealPart(part, "did:sage:bob", publicKey)
+			if err != nil {
+				t.Fatalf("SealPart() error = %v", err)
+			}
+
+			// The envelope survives JSON and reveals nothing of the part
+			raw, err := json.Marshal(sealed)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, secret := range []string{"1234", "statement", "078-05"} {
+				if strings.Contains(string(raw), secret) {
+					t.Fatalf("envelope leaks %q: %s", secret, raw)
+				}
+			}
+			transported, err := types.UnmarshalPart(raw)
+			if err != nil {
+				t.Fatal(err)
+			}
+			envelope, ok := ParseEncryptedPart(transported)
+			if !ok || envelope.Recipient != "did:sage:bob" {
+				t.Fatalf("ParseEncryptedPart() = %+v, %v", envelope, ok)
+			}
+
+			opened, err := em.OpenPart(transported, privateKey)
+			if err != nil {
+				t.Fatalf("OpenPart() error = %v", err)
+			}
+			want, _ := json.Marshal(part)
+			got, _ := json.Marshal(opened)
+			if string(got) != string(want) {
+				t.Errorf("OpenPart() = %s, want %s", got, want)
+			}
+
+			if _, err := em.OpenPart(transported, otherKey); err == nil {
+				t.Error("OpenPart() with another key succeeded")
+			}
+		})
+	}
+
+	if _, err := em.SealPart(types.NewTextPart(""), "did:sage:bob", publicKey); err == nil {
+		t.Error("SealPart() accepted an invalid part")
+	}
+	if _, err := em.OpenPart(types.NewTextPart("plain"), privateKey); err == nil {
+		t.Error("OpenPart() accepted a plain part")
+	}
+}
+
+func TestPartDecrypter_Middleware(t *testing.T) {
+	em := NewEncryptionManager()
+	bobPublic, bobPrivate := partKeyPair(t)
+	carolPublic, _ := partKeyPair(t)
+	_, rotatedPrivate := partKeyPair(t)
+
+	forBob, err := em.SealPart(types.NewTextPart("for bob"), "did:sage:bob#key-2", bobPublic)
+	if err != nil {
+		t.Fatal(err)
+	}
+	forCarol, err := em.SealPart(types.NewTextPart("for carol"), "did:sage:carol", carolPublic)
+	if err != nil {
+		t.Fatal(err)
+	}
+	msg := types.NewMessage(types.MessageRoleUser, []types.Part{types.NewTextPart("hello"), forBob, forCarol})
+
+	// Bob's current key comes first; the sealing key is an older one
+	decrypter := NewPartDecrypter("did:sage:bob", func() ([]*ecdh.PrivateKey, error) {
+		return []*ecdh.PrivateKey{rotatedPrivate, bobPrivate}, nil
+	})
+
+	var received *types.Message
+	handler := decrypter.Middleware()(func(ctx context.Context, msg *types.Message) (*types.Message, error) {
+		received = msg
+		return nil, nil
+	})
+	if _, err := handler(context.Background(), msg); err != nil {
+		t.Fatalf("handler error = %v", err)
+	}
+
+	if text, ok := received.Parts[1].(*types.TextPart); !ok || text.Text != "for bob" {
+		t.Errorf("part sealed for bob = %+v", received.Parts[1])
+	}
+	if _, sealed := ParseEncryptedPart(received.Parts[2]); !sealed {
+		t.Error("part sealed for carol was opened")
+	}
+	if _, sealed := ParseEncryptedPart(msg.Parts[1]); !sealed {
+		t.Error("Open() modified the original message")
+	}
+
+	// A part addressed to bob that his keys cannot open is rejected
+	forged, err := em.SealPart(types.NewTextPart("forged"), "did:sage:bob", carolPublic)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = handler(context.Background(), types.NewMessage(types.MessageRoleUser, []types.Part{forged}))
+	if !errors.Is(err, errors.ErrInvalidInput) {
+		t.Errorf("handler error = %v, want ErrInvalidInput", err)
+	}
+}
@@ -0,0 +1,40 @@
+// Copyright (C) 2025 sage-x-project
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with this program. If not, see <https://www.gnu.org/licenses/>.
+
+// SPDX-License-Identifier: LGPL-3.0-or-later
+
+// Package ratchet derives the message keys of a SAGE session.
+//
+// A session starts from the key agreed in the handshake. Each direction gets
+// its own chain key, and every message is encrypted with a key derived from
+// the chain key and the message's sequence number. The sender rekeys its
+// chain after a number of messages, bytes or time (see Config), starting a
+// new epoch: the next chain key is derived one-way from the current one, so
+// keys of past epochs cannot be recomputed from the session's state. Every
+// few rekeys a fresh X25519 exchange is mixed into the chain, so an attacker
+// who learned a chain key loses access once the exchange completes.
+//
+// Sequence numbers span the session and must strictly increase: a message
+// numbered at or below the last accepted one was replayed or reordered and
+// is rejected with errors.ErrReplayDetected. Gaps are allowed, so a lost
+// message does not stall the session, but a lost message that carried an
+// X25519 exchange leaves the peers with different keys. Receive fails with
+// errors.ErrSessionOutOfSync when it can tell, and decryption fails
+// otherwise; either way the session has to be established again.
+//
+// State is immutable from the caller's point of view: Send and Receive
+// return the next state, which the caller stores once the message has been
+// sealed or successfully opened.
+package ratchet
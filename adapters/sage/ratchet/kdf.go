@@ -0,0 +1,77 @@
+// Copyright (C) 2025 sage-x-project
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with this program. If not, see <https://www.gnu.org/licenses/>.
+
+// SPDX-License-Identifier: LGPL-3.0-or-later
+
+package ratchet
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"io"
+
+	"golang.org/x/crypto/hkdf"
+)
+
+// KeySize is the size of chain and message keys.
+const KeySize = 32
+
+// HKDF info labels. Changing any of them breaks compatibility with peers.
+const (
+	labelChain      = "sage-adk/ratchet/chain"
+	labelRekey      = "sage-adk/ratchet/rekey"
+	labelExchange   = "sage-adk/ratchet/exchange"
+	labelMessage    = "sage-adk/ratchet/message"
+	labelResumption = "sage-adk/ratchet/resumption"
+)
+
+// ResumptionKey derives the key that proves possession of a session when
+// it is resumed. It is independent of the message keys, so a session can
+// keep it without being able to recompute them.
+func ResumptionKey(sessionKey []byte) []byte {
+	return derive(sessionKey, nil, labelResumption)
+}
+
+// chainKey derives the initial chain key for messages from fromDID to toDID.
+func chainKey(sessionKey []byte, fromDID, toDID string) []byte {
+	return derive(sessionKey, nil, labelChain+"\x00"+fromDID+"\x00"+toDID)
+}
+
+// nextChainKey derives the chain key of the next epoch.
+func nextChainKey(chain []byte) []byte {
+	return derive(chain, nil, labelRekey)
+}
+
+// mixChainKey derives the chain key of the next epoch from an X25519
+// shared secret, salted with the current chain key.
+func mixChainKey(chain, shared []byte) []byte {
+	return derive(shared, chain, labelExchange)
+}
+
+// messageKey derives the key of the message with the given sequence number.
+func messageKey(chain []byte, sequence uint64) []byte {
+	var seq [8]byte
+	binary.BigEndian.PutUint64(seq[:], sequence)
+	return derive(chain, nil, labelMessage+string(seq[:]))
+}
+
+func derive(secret, salt []byte, info string) []byte {
+	key := make([]byte, KeySize)
+	// HKDF-SHA256 can expand far more than KeySize bytes, so this cannot fail
+	if _, err := io.ReadFull(hkdf.New(sha256.New, secret, salt, []byte(info)), key); err != nil {
+		panic("ratchet: " + err.Error())
+	}
+	return key
+}
@@ -0,0 +1,332 @@
+// Copyright (C) 2025 sage-x-project
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with this program. If not, see <https://www.gnu.org/licenses/>.
+
+// SPDX-License-Identifier: LGPL-3.0-or-later
+
+package ratchet
+
+import (
+	"bytes"
+	"crypto/ecdh"
+	"crypto/rand"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/sage-x-project/sage-adk/pkg/errors"
+)
+
+// MaxEpochSkip bounds how many epochs a receiver advances for one message.
+const MaxEpochSkip = 64
+
+// keepRatchetKeys is how many of its own announced X25519 keys a side keeps
+// for exchanges the peer starts against them.
+const keepRatchetKeys = 2
+
+// generateKey creates ratchet key pairs; tests replace it.
+var generateKey = func() (*ecdh.PrivateKey, error) {
+	return ecdh.X25519().GenerateKey(rand.Reader)
+}
+
+// Config controls when the sending chain is rekeyed. A zero limit disables
+// that trigger.
+type Config struct {
+	// Messages rekeys after this many messages in one epoch.
+	Messages int64
+
+	// Bytes rekeys before a message would take an epoch past this many
+	// plaintext bytes.
+	Bytes int64
+
+	// Interval rekeys once an epoch is this old.
+	Interval time.Duration
+
+	// ExchangeEvery mixes a fresh X25519 exchange into every n-th rekey.
+	// The other rekeys are symmetric.
+	ExchangeEvery int
+}
+
+// DefaultConfig returns the default rekeying limits.
+func DefaultConfig() Config {
+	return Config{
+		Messages:      1000,
+		Bytes:         64 << 20, // 64MB
+		Interval:      10 * time.Minute,
+		ExchangeEvery: 4,
+	}
+}
+
+// Header is sent in the clear with every message. It must be authenticated,
+// e.g. covered by the message signature.
+type Header struct {
+	// Sequence numbers the message within the session, starting at 1.
+	Sequence uint64 `json:"sequence"`
+
+	// Epoch is the sender's rekey count.
+	Epoch uint32 `json:"epoch,omitempty"`
+
+	// RatchetKey is the sender's X25519 public key announced in this
+	// epoch, and RatchetBase the receiver's key it was combined with, if
+	// the sender knew one. Every message of the epoch repeats them.
+	RatchetKey  []byte `json:"ratchet_key,omitempty"`
+	RatchetBase []byte `json:"ratchet_base,omitempty"`
+}
+
+// KeyPair is an X25519 key pair announced to the peer.
+type KeyPair struct {
+	Public  []byte `json:"public"`
+	Private []byte `json:"private"`
+}
+
+// State is the key schedule of one side of a session. It contains secret
+// keys and must be stored as carefully as the session key.
+type State struct {
+	// Sending chain
+	SendChain       []byte    `json:"send_chain"`
+	SendEpoch       uint32    `json:"send_epoch"`
+	SendSequence    uint64    `json:"send_sequence"`
+	SendRatchetKey  []byte    `json:"send_ratchet_key,omitempty"`
+	SendRatchetBase []byte    `json:"send_ratchet_base,omitempty"`
+	EpochMessages   int64     `json:"epoch_messages"`
+	EpochBytes      int64     `json:"epoch_bytes"`
+	EpochStarted    time.Time `json:"epoch_started"`
+
+	// Receiving chain
+	RecvChain    []byte `json:"recv_chain"`
+	RecvEpoch    uint32 `json:"recv_epoch"`
+	RecvSequence uint64 `json:"recv_sequence"`
+
+	// X25519 exchange: own announced keys, newest last, and the peer's
+	// latest announced key
+	RatchetKeys    []KeyPair `json:"ratchet_keys,omitempty"`
+	PeerRatchetKey []byte    `json:"peer_ratchet_key,omitempty"`
+}
+
+// New starts the key schedule of localDID's side of a session with
+// remoteDID. Both sides derive matching chains from the same session key.
+func New(sessionKey []byte, localDID, remoteDID string, now time.Time) (*State, error) {
+	if len(sessionKey) != KeySize {
+		return nil, errors.ErrInvalidInput.
+			WithMessage("invalid session key size").
+			WithDetail("expected", fmt.Sprintf("%d bytes", KeySize)).
+			WithDetail("got", fmt.Sprintf("%d bytes", len(sessionKey)))
+	}
+	if localDID == "" || remoteDID == "" || localDID == remoteDID {
+		return nil, errors.ErrInvalidInput.
+			WithMessage("a session needs two distinct DIDs").
+			WithDetail("local_did", localDID).
+			WithDetail("remote_did", remoteDID)
+	}
+
+	return &State{
+		SendChain:    chainKey(sessionKey, localDID, remoteDID),
+		EpochStarted: now,
+		RecvChain:    chainKey(sessionKey, remoteDID, localDID),
+	}, nil
+}
+
+// Send returns the header and key for the next message of size plaintext
+// bytes, rekeying first when config says so, and the state after sending.
+func (s *State) Send(config Config, size int, now time.Time) (Header, []byte, *State, error) {
+	next := s.Clone()
+	if next.dueForRekey(config, int64(size), now) {
+		if err := next.rekey(config, now); err != nil {
+			return Header{}, nil, nil, err
+		}
+	}
+
+	next.SendSequence++
+	next.EpochMessages++
+	next.EpochBytes += int64(size)
+
+	header := Header{
+		Sequence:    next.SendSequence,
+		Epoch:       next.SendEpoch,
+		RatchetKey:  next.SendRatchetKey,
+		RatchetBase: next.SendRatchetBase,
+	}
+	return header, messageKey(next.SendChain, next.SendSequence), next, nil
+}
+
+// Receive returns the key for a message with header h and the state after
+// receiving it. The caller keeps the old state if the message then fails to
+// decrypt.
+func (s *State) Receive(h Header) ([]byte, *State, error) {
+	if h.Sequence <= s.RecvSequence {
+		return nil, nil, errors.ErrReplayDetected.
+			WithMessage("message sequence number replayed or out of order").
+			WithDetail("sequence", h.Sequence).
+			WithDetail("last_sequence", s.RecvSequence)
+	}
+	if h.Epoch < s.RecvEpoch {
+		return nil, nil, errors.ErrReplayDetected.
+			WithMessage("message from a past epoch").
+			WithDetail("epoch", h.Epoch).
+			WithDetail("current_epoch", s.RecvEpoch)
+	}
+	if h.Epoch-s.RecvEpoch > MaxEpochSkip {
+		return nil, nil, errors.ErrSessionOutOfSync.
+			WithMessage("message is too many epochs ahead").
+			WithDetail("epoch", h.Epoch).
+			WithDetail("current_epoch", s.RecvEpoch)
+	}
+
+	next := s.Clone()
+	if h.Epoch > next.RecvEpoch {
+		// Epochs in between had no message here, so they are assumed to
+		// have been symmetric rekeys
+		for next.RecvEpoch+1 < h.Epoch {
+			next.RecvChain = nextChainKey(next.RecvChain)
+			next.RecvEpoch++
+		}
+		if err := next.advanceRecv(h); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	next.RecvSequence = h.Sequence
+	return messageKey(next.RecvChain, h.Sequence), next, nil
+}
+
+// dueForRekey reports whether the sending chain must be rekeyed before a
+// message of size bytes. Empty epochs are never rekeyed.
+func (s *State) dueForRekey(config Config, size int64, now time.Time) bool {
+	if s.EpochMessages == 0 {
+		return false
+	}
+	return (config.Messages > 0 && s.EpochMessages >= config.Messages) ||
+		(config.Bytes > 0 && s.EpochBytes+size > config.Bytes) ||
+		(config.Interval > 0 && now.Sub(s.EpochStarted) >= config.Interval)
+}
+
+// rekey starts the next sending epoch.
+func (s *State) rekey(config Config, now time.Time) error {
+	if s.SendEpoch == math.MaxUint32 {
+		return errors.ErrSessionOutOfSync.WithMessage("session ran out of epochs")
+	}
+	s.SendEpoch++
+	s.SendRatchetKey, s.SendRatchetBase = nil, nil
+	s.EpochMessages, s.EpochBytes = 0, 0
+	s.EpochStarted = now
+
+	if config.ExchangeEvery <= 0 || s.SendEpoch%uint32(config.ExchangeEvery) != 0 {
+		s.SendChain = nextChainKey(s.SendChain)
+		return nil
+	}
+
+	private, err := generateKey()
+	if err != nil {
+		return errors.ErrOperationFailed.
+			WithMessage("failed to generate ratchet key").
+			WithDetail("error", err.Error())
+	}
+	public := private.PublicKey().Bytes()
+
+	if s.PeerRatchetKey == nil {
+		// Nothing to exchange with yet; announce the key for the peer's
+		// next exchange
+		s.SendChain = nextChainKey(s.SendChain)
+	} else {
+		shared, err := exchange(private, s.PeerRatchetKey)
+		if err != nil {
+			return err
+		}
+		s.SendChain = mixChainKey(s.SendChain, shared)
+		s.SendRatchetBase = s.PeerRatchetKey
+	}
+	s.SendRatchetKey = public
+
+	if len(s.RatchetKeys) >= keepRatchetKeys {
+		s.RatchetKeys = s.RatchetKeys[len(s.RatchetKeys)-keepRatchetKeys+1:]
+	}
+	s.RatchetKeys = append(s.RatchetKeys, KeyPair{Public: public, Private: private.Bytes()})
+	return nil
+}
+
+// advanceRecv moves the receiving chain into the epoch started by h.
+func (s *State) advanceRecv(h Header) error {
+	switch {
+	case h.RatchetBase != nil:
+		if h.RatchetKey == nil {
+			return errors.ErrInvalidInput.WithMessage("ratchet base without ratchet key")
+		}
+		var own *KeyPair
+		for i := range s.RatchetKeys {
+			if bytes.Equal(s.RatchetKeys[i].Public, h.RatchetBase) {
+				own = &s.RatchetKeys[i]
+			}
+		}
+		if own == nil {
+			return errors.ErrSessionOutOfSync.
+				WithMessage("message was keyed with an unknown ratchet key").
+				WithDetail("epoch", h.Epoch)
+		}
+		private, err := ecdh.X25519().NewPrivateKey(own.Private)
+		if err != nil {
+			return errors.ErrOperationFailed.
+				WithMessage("invalid stored ratchet key").
+				WithDetail("error", err.Error())
+		}
+		shared, err := exchange(private, h.RatchetKey)
+		if err != nil {
+			return err
+		}
+		s.RecvChain = mixChainKey(s.RecvChain, shared)
+		s.PeerRatchetKey = h.RatchetKey
+
+	case h.RatchetKey != nil:
+		if _, err := ecdh.X25519().NewPublicKey(h.RatchetKey); err != nil {
+			return errors.ErrInvalidInput.
+				WithMessage("invalid ratchet key").
+				WithDetail("error", err.Error())
+		}
+		s.RecvChain = nextChainKey(s.RecvChain)
+		s.PeerRatchetKey = h.RatchetKey
+
+	default:
+		s.RecvChain = nextChainKey(s.RecvChain)
+	}
+
+	s.RecvEpoch = h.Epoch
+	return nil
+}
+
+// exchange computes the X25519 shared secret of private and peerPublic.
+func exchange(private *ecdh.PrivateKey, peerPublic []byte) ([]byte, error) {
+	public, err := ecdh.X25519().NewPublicKey(peerPublic)
+	if err != nil {
+		return nil, errors.ErrInvalidInput.
+			WithMessage("invalid ratchet key").
+			WithDetail("error", err.Error())
+	}
+	shared, err := private.ECDH(public)
+	if err != nil {
+		return nil, errors.ErrInvalidInput.
+			WithMessage("invalid ratchet key").
+			WithDetail("error", err.Error())
+	}
+	return shared, nil
+}
+
+// Clone returns a copy of the state. Keys are replaced rather than
+// modified in place, so the copy shares them. Clone of nil is nil.
+func (s *State) Clone() *State {
+	if s == nil {
+		return nil
+	}
+	c := *s
+	c.RatchetKeys = append([]KeyPair(nil), s.RatchetKeys...)
+	return &c
+}
@@ -0,0 +1,260 @@
+// Copyright (C) 2025 sage-x-project
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with this program. If not, see <https://www.gnu.org/licenses/>.
+
+// SPDX-License-Identifier: LGPL-3.0-or-later
+
+package ratchet
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/sage-x-project/sage-adk/pkg/errors"
+)
+
+const (
+	aliceDID = "did:sage:local:alice"
+	bobDID   = "did:sage:local:bob"
+)
+
+func newPair(t *testing.T, now time.Time) (alice, bob *State) {
+	t.Helper()
+
+	sessionKey := bytes.Repeat([]byte{9}, KeySize)
+	alice, err := New(sessionKey, aliceDID, bobDID, now)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	bob, err = New(sessionKey, bobDID, aliceDID, now)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	return alice, bob
+}
+
+// transfer sends one message from sender to receiver and checks that both
+// derive the same key.
+func transfer(t *testing.T, sender, receiver **State, config Config, size int, now time.Time) Header {
+	t.Helper()
+
+	header, key, next, err := (*sender).Send(config, size, now)
+	if err != nil {
+		t.Fatalf("Send() error = %v", err)
+	}
+	*sender = next
+
+	received, next, err := (*receiver).Receive(header)
+	if err != nil {
+		t.Fatalf("Receive(%+v) error = %v", header, err)
+	}
+	*receiver = next
+
+	if !bytes.Equal(key, received) {
+		t.Fatalf("receiver derived another key for %+v", header)
+	}
+	return header
+}
+
+func TestNew_Invalid(t *testing.T) {
+	now := time.Now()
+	if _, err := New(make([]byte, 16), aliceDID, bobDID, now); !errors.Is(err, errors.ErrInvalidInput) {
+		t.Errorf("short key: error = %v, want ErrInvalidInput", err)
+	}
+	if _, err := New(make([]byte, KeySize), aliceDID, aliceDID, now); !errors.Is(err, errors.ErrInvalidInput) {
+		t.Errorf("same DIDs: error = %v, want ErrInvalidInput", err)
+	}
+}
+
+func TestState_Directions(t *testing.T) {
+	now := time.Now()
+	alice, bob := newPair(t, now)
+
+	toBob, _, _, _ := alice.Send(Config{}, 1, now)
+	_, keyToBob, _, _ := alice.Send(Config{}, 1, now)
+	_, keyToAlice, _, _ := bob.Send(Config{}, 1, now)
+	if toBob.Sequence != 1 {
+		t.Errorf("first sequence = %d, want 1", toBob.Sequence)
+	}
+	if bytes.Equal(keyToBob, keyToAlice) {
+		t.Error("both directions use the same key")
+	}
+
+	for i := 0; i < 3; i++ {
+		transfer(t, &alice, &bob, Config{}, 10, now)
+		transfer(t, &bob, &alice, Config{}, 10, now)
+	}
+}
+
+func TestState_ReplayAndReordering(t *testing.T) {
+	now := time.Now()
+	alice, bob := newPair(t, now)
+
+	first, _, alice, _ := alice.Send(Config{}, 1, now)
+	second, _, alice, _ := alice.Send(Config{}, 1, now)
+
+	_, next, err := bob.Receive(second)
+	if err != nil {
+		t.Fatalf("Receive() error = %v", err)
+	}
+
+	if _, _, err := next.Receive(second); !errors.Is(err, errors.ErrReplayDetected) {
+		t.Errorf("replay: error = %v, want ErrReplayDetected", err)
+	}
+	if _, _, err := next.Receive(first); !errors.Is(err, errors.ErrReplayDetected) {
+		t.Errorf("reordered: error = %v, want ErrReplayDetected", err)
+	}
+
+	// The state a failed receive was called on is unchanged.
+	if _, _, err := bob.Receive(first); err != nil {
+		t.Errorf("Receive() on the previous state error = %v", err)
+	}
+}
+
+func TestState_RekeyTriggers(t *testing.T) {
+	start := time.Now()
+	tests := []struct {
+		name   string
+		config Config
+		size   int
+		step   time.Duration
+	}{
+		{"messages", Config{Messages: 3}, 10, 0},
+		{"bytes", Config{Bytes: 25}, 10, 0},
+		{"interval", Config{Interval: time.Minute}, 10, 25 * time.Second},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			alice, bob := newPair(t, start)
+			now := start
+
+			var epochs []uint32
+			for i := 0; i < 7; i++ {
+				epochs = append(epochs, transfer(t, &alice, &bob, tt.config, tt.size, now).Epoch)
+				now = now.Add(tt.step)
+			}
+
+			if epochs[0] != 0 || epochs[len(epochs)-1] == 0 {
+				t.Fatalf("epochs = %v, want rekeying", epochs)
+			}
+			for i := 1; i < len(epochs); i++ {
+				if epochs[i] < epochs[i-1] || epochs[i] > epochs[i-1]+1 {
+					t.Fatalf("epochs = %v, want steps of one", epochs)
+				}
+			}
+		})
+	}
+}
+
+func TestState_NoRekeyWhenDisabled(t *testing.T) {
+	now := time.Now()
+	alice, bob := newPair(t, now)
+	for i := 0; i < 50; i++ {
+		if h := transfer(t, &alice, &bob, Config{}, 1<<20, now.Add(time.Duration(i)*time.Hour)); h.Epoch != 0 {
+			t.Fatalf("epoch = %d with rekeying disabled", h.Epoch)
+		}
+	}
+}
+
+func TestState_Exchange(t *testing.T) {
+	now := time.Now()
+	alice, bob := newPair(t, now)
+	config := Config{Messages: 1, ExchangeEvery: 2}
+
+	var announced, exchanged bool
+	for i := 0; i < 12; i++ {
+		for _, h := range []Header{
+			transfer(t, &alice, &bob, config, 10, now),
+			transfer(t, &bob, &alice, config, 10, now),
+		} {
+			announced = announced || h.RatchetKey != nil
+			exchanged = exchanged || h.RatchetBase != nil
+		}
+	}
+	if !announced || !exchanged {
+		t.Errorf("announced = %v, exchanged = %v, want X25519 exchanges", announced, exchanged)
+	}
+	if len(alice.RatchetKeys) > keepRatchetKeys {
+		t.Errorf("kept %d ratchet keys, want at most %d", len(alice.RatchetKeys), keepRatchetKeys)
+	}
+}
+
+func TestState_LostMessages(t *testing.T) {
+	now := time.Now()
+	alice, bob := newPair(t, now)
+	config := Config{Messages: 1}
+
+	// Gaps in sequence numbers and skipped symmetric epochs are tolerated.
+	for i := 0; i < 3; i++ {
+		_, _, alice, _ = alice.Send(config, 10, now)
+	}
+	transfer(t, &alice, &bob, config, 10, now)
+
+	// A lost exchange cannot be recovered.
+	config.ExchangeEvery = 1
+	transfer(t, &bob, &alice, config, 10, now)
+	transfer(t, &bob, &alice, config, 10, now)
+	_, _, alice, _ = alice.Send(config, 10, now)
+	_, _, alice, _ = alice.Send(config, 10, now)
+	_, _, alice, _ = alice.Send(config, 10, now)
+	header, key, _, _ := alice.Send(config, 10, now)
+	received, _, err := bob.Receive(header)
+	if err == nil && bytes.Equal(received, key) {
+		t.Error("receiver should not derive the key after a lost exchange")
+	}
+
+	// Too many epochs ahead is out of sync.
+	header.Epoch = bob.RecvEpoch + MaxEpochSkip + 1
+	if _, _, err := bob.Receive(header); !errors.Is(err, errors.ErrSessionOutOfSync) {
+		t.Errorf("far epoch: error = %v, want ErrSessionOutOfSync", err)
+	}
+}
+
+func TestState_JSON(t *testing.T) {
+	now := time.Now()
+	alice, bob := newPair(t, now)
+	config := Config{Messages: 2, ExchangeEvery: 2}
+	for i := 0; i < 5; i++ {
+		transfer(t, &alice, &bob, config, 10, now)
+		transfer(t, &bob, &alice, config, 10, now)
+	}
+
+	// A stored and restored state continues the session.
+	data, err := json.Marshal(alice)
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+	var restored State
+	if err := json.Unmarshal(data, &restored); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	alice = &restored
+	for i := 0; i < 5; i++ {
+		transfer(t, &alice, &bob, config, 10, now)
+		transfer(t, &bob, &alice, config, 10, now)
+	}
+}
+
+func TestResumptionKey(t *testing.T) {
+	sessionKey := bytes.Repeat([]byte{9}, KeySize)
+	alice, _ := New(sessionKey, aliceDID, bobDID, time.Now())
+
+	key := ResumptionKey(sessionKey)
+	if len(key) != KeySize || bytes.Equal(key, sessionKey) || bytes.Equal(key, alice.SendChain) || bytes.Equal(key, alice.RecvChain) {
+		t.Error("resumption key should be independent of the session and chain keys")
+	}
+}
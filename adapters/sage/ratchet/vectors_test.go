@@ -0,0 +1,214 @@
+// Copyright (C) 2025 sage-x-project
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with this program. If not, see <https://www.gnu.org/licenses/>.
+
+// SPDX-License-Identifier: LGPL-3.0-or-later
+
+package ratchet
+
+import (
+	"bytes"
+	"crypto/ecdh"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"testing"
+	"time"
+)
+
+// hexBytes is a byte string written as hex in test vectors.
+type hexBytes []byte
+
+func (h hexBytes) MarshalJSON() ([]byte, error) {
+	return json.Marshal(hex.EncodeToString(h))
+}
+
+func (h *hexBytes) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	decoded, err := hex.DecodeString(s)
+	*h = decoded
+	return err
+}
+
+// testVectors are the known answers in testdata/vectors.json. They pin the
+// key schedule, so that other implementations can check against it and
+// changes that would break existing peers are caught.
+type testVectors struct {
+	Chain []struct {
+		SessionKey hexBytes `json:"session_key"`
+		FromDID    string   `json:"from_did"`
+		ToDID      string   `json:"to_did"`
+		ChainKey   hexBytes `json:"chain_key"`
+	} `json:"chain"`
+
+	Rekey []struct {
+		ChainKey     hexBytes `json:"chain_key"`
+		NextChainKey hexBytes `json:"next_chain_key"`
+	} `json:"rekey"`
+
+	Exchange []struct {
+		ChainKey      hexBytes `json:"chain_key"`
+		PrivateKey    hexBytes `json:"private_key"`
+		PeerPublicKey hexBytes `json:"peer_public_key"`
+		SharedSecret  hexBytes `json:"shared_secret"`
+		NextChainKey  hexBytes `json:"next_chain_key"`
+	} `json:"exchange"`
+
+	Message []struct {
+		ChainKey   hexBytes `json:"chain_key"`
+		Sequence   uint64   `json:"sequence"`
+		MessageKey hexBytes `json:"message_key"`
+	} `json:"message"`
+
+	Resumption []struct {
+		SessionKey    hexBytes `json:"session_key"`
+		ResumptionKey hexBytes `json:"resumption_key"`
+	} `json:"resumption"`
+
+	// Session is a conversation in which alice and bob send the messages
+	// in order, each of size MessageSize. Keys lists the X25519 private
+	// keys each side generates, in order.
+	Session struct {
+		SessionKey    hexBytes              `json:"session_key"`
+		AliceDID      string                `json:"alice_did"`
+		BobDID        string                `json:"bob_did"`
+		Messages      int64                 `json:"rekey_messages"`
+		ExchangeEvery int                   `json:"exchange_every"`
+		MessageSize   int                   `json:"message_size"`
+		Keys          map[string][]hexBytes `json:"keys"`
+		Transcript    []vectorMessage       `json:"transcript"`
+	} `json:"session"`
+}
+
+type vectorMessage struct {
+	From        string   `json:"from"`
+	Sequence    uint64   `json:"sequence"`
+	Epoch       uint32   `json:"epoch"`
+	RatchetKey  hexBytes `json:"ratchet_key,omitempty"`
+	RatchetBase hexBytes `json:"ratchet_base,omitempty"`
+	MessageKey  hexBytes `json:"message_key"`
+}
+
+func loadVectors(t *testing.T) *testVectors {
+	t.Helper()
+
+	data, err := os.ReadFile("testdata/vectors.json")
+	if err != nil {
+		t.Fatalf("ReadFile() error = %v", err)
+	}
+	var v testVectors
+	if err := json.Unmarshal(data, &v); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	return &v
+}
+
+func TestVectors_KeyDerivation(t *testing.T) {
+	v := loadVectors(t)
+
+	for i, c := range v.Chain {
+		if got := chainKey(c.SessionKey, c.FromDID, c.ToDID); !bytes.Equal(got, c.ChainKey) {
+			t.Errorf("chain[%d] = %x, want %x", i, got, c.ChainKey)
+		}
+	}
+	for i, c := range v.Rekey {
+		if got := nextChainKey(c.ChainKey); !bytes.Equal(got, c.NextChainKey) {
+			t.Errorf("rekey[%d] = %x, want %x", i, got, c.NextChainKey)
+		}
+	}
+	for i, c := range v.Exchange {
+		private, err := ecdh.X25519().NewPrivateKey(c.PrivateKey)
+		if err != nil {
+			t.Fatalf("exchange[%d]: NewPrivateKey() error = %v", i, err)
+		}
+		shared, err := exchange(private, c.PeerPublicKey)
+		if err != nil {
+			t.Fatalf("exchange[%d]: exchange() error = %v", i, err)
+		}
+		if !bytes.Equal(shared, c.SharedSecret) {
+			t.Errorf("exchange[%d] shared secret = %x, want %x", i, shared, c.SharedSecret)
+		}
+		if got := mixChainKey(c.ChainKey, shared); !bytes.Equal(got, c.NextChainKey) {
+			t.Errorf("exchange[%d] = %x, want %x", i, got, c.NextChainKey)
+		}
+	}
+	for i, c := range v.Message {
+		if got := messageKey(c.ChainKey, c.Sequence); !bytes.Equal(got, c.MessageKey) {
+			t.Errorf("message[%d] = %x, want %x", i, got, c.MessageKey)
+		}
+	}
+	for i, c := range v.Resumption {
+		if got := ResumptionKey(c.SessionKey); !bytes.Equal(got, c.ResumptionKey) {
+			t.Errorf("resumption[%d] = %x, want %x", i, got, c.ResumptionKey)
+		}
+	}
+}
+
+func TestVectors_Session(t *testing.T) {
+	s := loadVectors(t).Session
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	alice, err := New(s.SessionKey, s.AliceDID, s.BobDID, now)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	bob, err := New(s.SessionKey, s.BobDID, s.AliceDID, now)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	states := map[string]**State{"alice": &alice, "bob": &bob}
+	peers := map[string]string{"alice": "bob", "bob": "alice"}
+	config := Config{Messages: s.Messages, ExchangeEvery: s.ExchangeEvery}
+
+	keys := s.Keys
+	defer func(original func() (*ecdh.PrivateKey, error)) { generateKey = original }(generateKey)
+
+	for i, want := range s.Transcript {
+		sender, receiver := states[want.From], states[peers[want.From]]
+		generateKey = func() (*ecdh.PrivateKey, error) {
+			if len(keys[want.From]) == 0 {
+				t.Fatalf("message %d: %s generated more keys than listed", i, want.From)
+			}
+			key := keys[want.From][0]
+			keys[want.From] = keys[want.From][1:]
+			return ecdh.X25519().NewPrivateKey(key)
+		}
+
+		header, key, next, err := (*sender).Send(config, s.MessageSize, now)
+		if err != nil {
+			t.Fatalf("message %d: Send() error = %v", i, err)
+		}
+		*sender = next
+
+		if header.Sequence != want.Sequence || header.Epoch != want.Epoch ||
+			!bytes.Equal(header.RatchetKey, want.RatchetKey) || !bytes.Equal(header.RatchetBase, want.RatchetBase) {
+			t.Errorf("message %d: header = %+v, want %+v", i, header, want)
+		}
+		if !bytes.Equal(key, want.MessageKey) {
+			t.Errorf("message %d: key = %x, want %x", i, key, want.MessageKey)
+		}
+
+		received, next, err := (*receiver).Receive(header)
+		if err != nil {
+			t.Fatalf("message %d: Receive() error = %v", i, err)
+		}
+		*receiver = next
+		if !bytes.Equal(received, key) {
+			t.Errorf("message %d: receiver key = %x, sender key = %x", i, received, key)
+		}
+	}
+}
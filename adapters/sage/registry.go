@@ -0,0 +1,188 @@
+// Copyright (C) 2025 sage-x-project
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with this program. If not, see <https://www.gnu.org/licenses/>.
+
+// SPDX-License-Identifier: LGPL-3.0-or-later
+
+package sage
+
+import (
+	"context"
+	"crypto/ed25519"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/sage-x-project/sage-adk/adapters/sage/registry"
+	"github.com/sage-x-project/sage-adk/pkg/errors"
+	"github.com/sage-x-project/sage/did"
+)
+
+// registryResolver adapts a local DID registry to the did.Resolver
+// interface.
+type registryResolver struct {
+	registry registry.Registry
+}
+
+// NewRegistryDIDResolver creates a DID resolver backed by a local registry
+// instead of a blockchain, for development and tests.
+func NewRegistryDIDResolver(reg registry.Registry) *DIDResolver {
+	return &DIDResolver{resolver: &registryResolver{registry: reg}}
+}
+
+func (r *registryResolver) lookup(ctx context.Context, agentDID did.AgentDID) (*registry.Record, error) {
+	record, err := r.registry.Lookup(ctx, string(agentDID))
+	if errors.Is(err, errors.ErrDIDNotFound) {
+		return nil, fmt.Errorf("%w: %s", did.ErrDIDNotFound, agentDID)
+	}
+	return record, err
+}
+
+// activeRecord looks up a DID whose keys may be used.
+func (r *registryResolver) activeRecord(ctx context.Context, agentDID did.AgentDID) (*registry.Record, error) {
+	record, err := r.lookup(ctx, agentDID)
+	if err != nil {
+		return nil, err
+	}
+	if !record.IsActive() {
+		return nil, fmt.Errorf("agent DID %s is not active", agentDID)
+	}
+	return record, nil
+}
+
+func (r *registryResolver) Resolve(ctx context.Context, agentDID did.AgentDID) (*did.AgentMetadata, error) {
+	record, err := r.lookup(ctx, agentDID)
+	if err != nil {
+		return nil, err
+	}
+	return recordMetadata(record)
+}
+
+func (r *registryResolver) ResolvePublicKey(ctx context.Context, agentDID did.AgentDID) (interface{}, error) {
+	record, err := r.activeRecord(ctx, agentDID)
+	if err != nil {
+		return nil, err
+	}
+	return record.Ed25519PublicKey()
+}
+
+// ResolveKeyID selects among the record's versioned keys by key ID.
+func (r *registryResolver) ResolveKeyID(ctx context.Context, keyID string) (interface{}, error) {
+	record, err := r.activeRecord(ctx, did.AgentDID(didFromKeyID(keyID)))
+	if err != nil {
+		return nil, err
+	}
+	return record.VerificationKeyFor(keyID, time.Now())
+}
+
+func (r *registryResolver) ResolveKEMKey(ctx context.Context, agentDID did.AgentDID) (interface{}, error) {
+	record, err := r.activeRecord(ctx, agentDID)
+	if err != nil {
+		return nil, err
+	}
+	return record.X25519PublicKey()
+}
+
+func (r *registryResolver) VerifyMetadata(ctx context.Context, agentDID did.AgentDID, metadata *did.AgentMetadata) (*did.VerificationResult, error) {
+	record, err := r.lookup(ctx, agentDID)
+	if err != nil {
+		return nil, err
+	}
+	registered, err := recordMetadata(record)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &did.VerificationResult{
+		Valid:      true,
+		Agent:      registered,
+		VerifiedAt: time.Now(),
+	}
+
+	publicKey, _ := metadata.PublicKey.(ed25519.PublicKey)
+	switch {
+	case !registered.IsActive:
+		result.Error = "agent is deactivated"
+	case metadata.Name != registered.Name:
+		result.Error = "name mismatch"
+	case metadata.Endpoint != registered.Endpoint:
+		result.Error = "endpoint mismatch"
+	case !publicKey.Equal(registered.PublicKey.(ed25519.PublicKey)):
+		result.Error = "public key mismatch"
+	}
+	result.Valid = result.Error == ""
+
+	return result, nil
+}
+
+func (r *registryResolver) ListAgentsByOwner(ctx context.Context, ownerAddress string) ([]*did.AgentMetadata, error) {
+	return r.filter(ctx, func(record *registry.Record) bool {
+		return strings.EqualFold(record.Owner, ownerAddress)
+	}, 0)
+}
+
+func (r *registryResolver) Search(ctx context.Context, criteria did.SearchCriteria) ([]*did.AgentMetadata, error) {
+	name := strings.ToLower(criteria.Name)
+	return r.filter(ctx, func(record *registry.Record) bool {
+		if criteria.ActiveOnly && !record.IsActive() {
+			return false
+		}
+		return strings.Contains(strings.ToLower(record.Name), name)
+	}, criteria.Limit)
+}
+
+// filter returns the metadata of matching records, up to limit if positive.
+func (r *registryResolver) filter(ctx context.Context, match func(*registry.Record) bool, limit int) ([]*did.AgentMetadata, error) {
+	records, err := r.registry.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	agents := make([]*did.AgentMetadata, 0)
+	for _, record := range records {
+		if !match(record) {
+			continue
+		}
+		metadata, err := recordMetadata(record)
+		if err != nil {
+			return nil, err
+		}
+		agents = append(agents, metadata)
+		if limit > 0 && len(agents) == limit {
+			break
+		}
+	}
+	return agents, nil
+}
+
+// recordMetadata converts a registry record to DID metadata.
+func recordMetadata(record *registry.Record) (*did.AgentMetadata, error) {
+	publicKey, err := record.Ed25519PublicKey()
+	if err != nil {
+		return nil, err
+	}
+
+	return &did.AgentMetadata{
+		DID:          did.AgentDID(record.DID),
+		Name:         record.Name,
+		Description:  record.Description,
+		Endpoint:     record.Endpoint,
+		PublicKey:    publicKey,
+		Capabilities: record.Capabilities,
+		Owner:        record.Owner,
+		IsActive:     record.IsActive(),
+		CreatedAt:    record.CreatedAt,
+		UpdatedAt:    record.UpdatedAt,
+	}, nil
+}
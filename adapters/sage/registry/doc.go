@@ -0,0 +1,61 @@
+// Copyright (C) 2025 sage-x-project
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with this program. If not, see <https://www.gnu.org/licenses/>.
+
+// SPDX-License-Identifier: LGPL-3.0-or-later
+// Package registry provides an offline DID registry for development and
+// tests.
+//
+// SAGE agents normally resolve DIDs from an on-chain registry. A local
+// registry serves the same purpose without a blockchain, so SAGE mode can be
+// exercised end to end on a laptop or in CI. Records hold an agent's
+// Ed25519 verification key, an optional X25519 key agreement key and
+// descriptive metadata, and support register, update, deactivate and
+// lookup.
+//
+// # Backends
+//
+// NewFileRegistry keeps records in a JSON or YAML file, which can also be
+// written by hand:
+//
+//	agents:
+//	  - did: did:sage:local:alice
+//	    name: Alice
+//	    endpoint: http://localhost:18080/sage/message
+//	    public_key: 3q2+7w...base64...=
+//
+// NewStorageRegistry keeps records in any storage.Storage backend.
+//
+// # Sharing a Registry
+//
+// Handler serves a registry over HTTP and Client talks to it, so several
+// local agents can share one registry:
+//
+//	reg := registry.NewFileRegistry("registry.yaml")
+//	http.ListenAndServe(":18700", registry.Handler(reg, &registry.HandlerConfig{
+//		Token: os.Getenv("REGISTRY_TOKEN"),
+//	}))
+//
+//	client := registry.NewClient("http://localhost:18700", nil)
+//	client.SetToken(os.Getenv("REGISTRY_TOKEN"))
+//	err := client.Register(ctx, registry.NewRecord(did, publicKey))
+//
+// Lookups are public. Register, update and deactivate need the shared token
+// or credentials accepted by HandlerConfig.Authenticator; without either the
+// served registry is read-only.
+//
+// Open picks the right implementation for a file path or URL. The SAGE
+// adapter uses it for the sage.registry setting; see
+// sage.NewRegistryDIDResolver.
+package registry
@@ -0,0 +1,157 @@
+// Copyright (C) 2025 sage-x-project
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with this program. If not, see <https://www.gnu.org/licenses/>.
+
+// SPDX-License-Identifier: LGPL-3.0-or-later
+
+package registry
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/sage-x-project/sage-adk/pkg/errors"
+)
+
+// registryFile is the layout of a registry file.
+type registryFile struct {
+	Agents []*Record `json:"agents" yaml:"agents"`
+}
+
+// fileBackend keeps all records in one JSON or YAML file.
+type fileBackend struct {
+	path string
+	yaml bool
+}
+
+func newFileBackend(path string) *fileBackend {
+	ext := strings.ToLower(filepath.Ext(path))
+	return &fileBackend{path: path, yaml: ext == ".yaml" || ext == ".yml"}
+}
+
+func (b *fileBackend) get(ctx context.Context, did string) (*Record, error) {
+	records, err := b.read()
+	if err != nil {
+		return nil, err
+	}
+	for _, record := range records {
+		if record.DID == did {
+			return record, nil
+		}
+	}
+	return nil, notFound(did)
+}
+
+func (b *fileBackend) put(ctx context.Context, record *Record) error {
+	records, err := b.read()
+	if err != nil {
+		return err
+	}
+
+	replaced := false
+	for i, existing := range records {
+		if existing.DID == record.DID {
+			records[i] = record
+			replaced = true
+			break
+		}
+	}
+	if !replaced {
+		records = append(records, record)
+	}
+	return b.write(records)
+}
+
+func (b *fileBackend) list(ctx context.Context) ([]*Record, error) {
+	return b.read()
+}
+
+// read loads the records. A missing file is an empty registry.
+func (b *fileBackend) read() ([]*Record, error) {
+	data, err := os.ReadFile(b.path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errors.ErrStorageConnection.
+			WithMessage("failed to read registry file").
+			WithDetail("path", b.path).
+			WithDetail("error", err.Error())
+	}
+
+	var file registryFile
+	if b.yaml {
+		err = yaml.Unmarshal(data, &file)
+	} else if len(data) > 0 {
+		err = json.Unmarshal(data, &file)
+	}
+	if err != nil {
+		return nil, errors.ErrInvalidFormat.
+			WithMessage("failed to parse registry file").
+			WithDetail("path", b.path).
+			WithDetail("error", err.Error())
+	}
+	return file.Agents, nil
+}
+
+// write replaces the file atomically so concurrent readers never see a
+// partial registry.
+func (b *fileBackend) write(records []*Record) error {
+	sortRecords(records)
+	file := registryFile{Agents: records}
+
+	var (
+		data []byte
+		err  error
+	)
+	if b.yaml {
+		data, err = yaml.Marshal(&file)
+	} else {
+		data, err = json.MarshalIndent(&file, "", "  ")
+	}
+	if err != nil {
+		return errors.ErrOperationFailed.
+			WithMessage("failed to encode registry").
+			WithDetail("error", err.Error())
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(b.path), filepath.Base(b.path)+".*")
+	if err == nil {
+		_, err = tmp.Write(data)
+		if closeErr := tmp.Close(); err == nil {
+			err = closeErr
+		}
+		if err == nil {
+			err = os.Chmod(tmp.Name(), 0o644)
+		}
+		if err == nil {
+			err = os.Rename(tmp.Name(), b.path)
+		}
+		if err != nil {
+			os.Remove(tmp.Name())
+		}
+	}
+	if err != nil {
+		return errors.ErrStorageConnection.
+			WithMessage("failed to write registry file").
+			WithDetail("path", b.path).
+			WithDetail("error", err.Error())
+	}
+	return nil
+}
@@ -0,0 +1,394 @@
+// Copyright (C) 2025 sage-x-project
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with this program. If not, see <https://www.gnu.org/licenses/>.
+
+// SPDX-License-Identifier: LGPL-3.0-or-later
+
+package registry
+
+import (
+	"bytes"
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/sage-x-project/sage-adk/core/authn"
+	"github.com/sage-x-project/sage-adk/pkg/errors"
+)
+
+// DefaultMaxBodyBytes is the default request body limit of Handler.
+const DefaultMaxBodyBytes int64 = 1 << 20
+
+// HandlerConfig configures Handler.
+type HandlerConfig struct {
+	// Authenticator authenticates writes. Anonymous principals are not
+	// accepted, even if the authenticator is optional.
+	Authenticator *authn.Authenticator
+
+	// Token is a shared secret accepted for writes as a bearer token.
+	Token string
+
+	// MaxBodyBytes limits request bodies (default DefaultMaxBodyBytes).
+	MaxBodyBytes int64
+}
+
+// Handler serves a registry over HTTP so several local agents can share
+// one registry:
+//
+//	GET    /dids         list records
+//	POST   /dids         register a record
+//	GET    /dids/{did}   look up a record
+//	PUT    /dids/{did}   update a record
+//	DELETE /dids/{did}   deactivate a DID
+//
+// Reads are public. Writes need the shared token or credentials accepted
+// by the authenticator of config; with neither configured the registry is
+// read-only. Request bodies are limited to config.MaxBodyBytes.
+//
+// Errors are returned as {"code": ..., "error": ...} with the code of the
+// registry error, which Client turns back into the same error.
+func Handler(reg Registry, config *HandlerConfig) http.Handler {
+	h := &handler{registry: reg, maxBody: DefaultMaxBodyBytes}
+	if config != nil {
+		h.authenticator = config.Authenticator
+		h.token = config.Token
+		if config.MaxBodyBytes > 0 {
+			h.maxBody = config.MaxBodyBytes
+		}
+	}
+	return h
+}
+
+type handler struct {
+	registry      Registry
+	authenticator *authn.Authenticator
+	token         string
+	maxBody       int64
+}
+
+func (h *handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	path := strings.TrimSuffix(r.URL.Path, "/")
+
+	switch r.Method {
+	case http.MethodPost, http.MethodPut, http.MethodDelete:
+		if err := h.authenticate(r); err != nil {
+			w.Header().Set("WWW-Authenticate", `Bearer realm="sage-adk-registry"`)
+			writeError(w, err)
+			return
+		}
+		r.Body = http.MaxBytesReader(w, r.Body, h.maxBody)
+	}
+
+	if path == "/dids" {
+		switch r.Method {
+		case http.MethodGet:
+			records, err := h.registry.List(ctx)
+			if err != nil {
+				writeError(w, err)
+				return
+			}
+			if records == nil {
+				records = []*Record{}
+			}
+			writeJSON(w, http.StatusOK, records)
+
+		case http.MethodPost:
+			record, ok := readRecord(w, r)
+			if !ok {
+				return
+			}
+			if err := h.registry.Register(ctx, record); err != nil {
+				writeError(w, err)
+				return
+			}
+			h.writeRecord(w, r, http.StatusCreated, record.DID)
+
+		default:
+			w.Header().Set("Allow", "GET, POST")
+			writeError(w, errMethodNotAllowed)
+		}
+		return
+	}
+
+	did, ok := strings.CutPrefix(path, "/dids/")
+	if !ok || did == "" {
+		writeError(w, errors.ErrNotFound.WithDetail("path", r.URL.Path))
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		h.writeRecord(w, r, http.StatusOK, did)
+
+	case http.MethodPut:
+		record, ok := readRecord(w, r)
+		if !ok {
+			return
+		}
+		if record.DID == "" {
+			record.DID = did
+		}
+		if record.DID != did {
+			writeError(w, errors.ErrInvalidInput.WithMessage("DID in body does not match the path"))
+			return
+		}
+		if err := h.registry.Update(ctx, record); err != nil {
+			writeError(w, err)
+			return
+		}
+		h.writeRecord(w, r, http.StatusOK, did)
+
+	case http.MethodDelete:
+		if err := h.registry.Deactivate(ctx, did); err != nil {
+			writeError(w, err)
+			return
+		}
+		h.writeRecord(w, r, http.StatusOK, did)
+
+	default:
+		w.Header().Set("Allow", "GET, PUT, DELETE")
+		writeError(w, errMethodNotAllowed)
+	}
+}
+
+// authenticate checks the credentials of a write request.
+func (h *handler) authenticate(r *http.Request) error {
+	creds := authn.CredentialsFromRequest(r)
+	if h.token != "" && creds.Bearer != "" &&
+		subtle.ConstantTimeCompare([]byte(creds.Bearer), []byte(h.token)) == 1 {
+		return nil
+	}
+	if h.authenticator != nil {
+		principal, err := h.authenticator.Authenticate(r.Context(), creds)
+		if err != nil {
+			return err
+		}
+		if principal != nil {
+			return nil
+		}
+	}
+	if h.token == "" && h.authenticator == nil {
+		return errors.ErrUnauthorized.WithMessage("registry is read-only")
+	}
+	if creds.Empty() {
+		return errors.ErrUnauthorized.WithMessage("authentication required")
+	}
+	return errors.ErrInvalidCredentials.WithMessage("invalid registry token")
+}
+
+// writeRecord writes the stored record of did.
+func (h *handler) writeRecord(w http.ResponseWriter, r *http.Request, status int, did string) {
+	record, err := h.registry.Lookup(r.Context(), did)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, status, record)
+}
+
+func readRecord(w http.ResponseWriter, r *http.Request) (*Record, bool) {
+	var record Record
+	if err := json.NewDecoder(r.Body).Decode(&record); err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			writeError(w, errors.ErrOutOfRange.
+				WithMessage("record too large").
+				WithDetail("limit", fmt.Sprintf("%d", tooLarge.Limit)))
+			return nil, false
+		}
+		writeError(w, errors.ErrInvalidInput.
+			WithMessage("failed to parse record").
+			WithDetail("error", err.Error()))
+		return nil, false
+	}
+	return &record, true
+}
+
+var errMethodNotAllowed = errors.New(errors.CategoryValidation, "METHOD_NOT_ALLOWED", "method not allowed")
+
+// errorBody is the JSON body of an error response.
+type errorBody struct {
+	Code  string `json:"code"`
+	Error string `json:"error"`
+}
+
+// knownErrors are the registry errors carried over HTTP, with their status.
+var knownErrors = []struct {
+	err    *errors.Error
+	status int
+}{
+	{errors.ErrDIDNotFound, http.StatusNotFound},
+	{errors.ErrNotFound, http.StatusNotFound},
+	{errors.ErrAlreadyExists, http.StatusConflict},
+	{errors.ErrAgentInactive, http.StatusConflict},
+	{errors.ErrInvalidInput, http.StatusBadRequest},
+	{errors.ErrInvalidFormat, http.StatusBadRequest},
+	{errors.ErrOutOfRange, http.StatusRequestEntityTooLarge},
+	{errors.ErrUnauthorized, http.StatusUnauthorized},
+	{errors.ErrInvalidCredentials, http.StatusUnauthorized},
+	{errMethodNotAllowed, http.StatusMethodNotAllowed},
+}
+
+func writeError(w http.ResponseWriter, err error) {
+	body := errorBody{Code: errors.ErrInternal.Code, Error: err.Error()}
+	status := http.StatusInternalServerError
+
+	for _, known := range knownErrors {
+		if errors.Is(err, known.err) {
+			body.Code, status = known.err.Code, known.status
+			break
+		}
+	}
+	writeJSON(w, status, body)
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+// Client is a Registry served by Handler at a base URL.
+type Client struct {
+	baseURL    string
+	httpClient *http.Client
+	token      string
+}
+
+// NewClient creates a client for the registry served at baseURL. A nil
+// httpClient uses a client with a 10 second timeout.
+func NewClient(baseURL string, httpClient *http.Client) *Client {
+	if httpClient == nil {
+		httpClient = &http.Client{Timeout: 10 * time.Second}
+	}
+	return &Client{
+		baseURL:    strings.TrimSuffix(baseURL, "/"),
+		httpClient: httpClient,
+	}
+}
+
+// Register adds a new DID.
+func (c *Client) Register(ctx context.Context, record *Record) error {
+	return c.do(ctx, http.MethodPost, "/dids", record, nil)
+}
+
+// Update replaces the record of an active DID.
+func (c *Client) Update(ctx context.Context, record *Record) error {
+	return c.do(ctx, http.MethodPut, didPath(record.DID), record, nil)
+}
+
+// Deactivate marks a DID as deactivated.
+func (c *Client) Deactivate(ctx context.Context, did string) error {
+	return c.do(ctx, http.MethodDelete, didPath(did), nil, nil)
+}
+
+// Lookup returns the record of a DID.
+func (c *Client) Lookup(ctx context.Context, did string) (*Record, error) {
+	var record Record
+	if err := c.do(ctx, http.MethodGet, didPath(did), nil, &record); err != nil {
+		return nil, err
+	}
+	return &record, nil
+}
+
+// List returns all records ordered by DID.
+func (c *Client) List(ctx context.Context) ([]*Record, error) {
+	var records []*Record
+	if err := c.do(ctx, http.MethodGet, "/dids", nil, &records); err != nil {
+		return nil, err
+	}
+	return records, nil
+}
+
+// SetToken sets the shared token sent with writes.
+func (c *Client) SetToken(token string) {
+	c.token = token
+}
+
+func didPath(did string) string {
+	return "/dids/" + url.PathEscape(did)
+}
+
+// do sends a request and decodes the response into out, if set.
+func (c *Client) do(ctx context.Context, method, path string, body, out interface{}) error {
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return errors.ErrOperationFailed.
+				WithMessage("failed to encode request").
+				WithDetail("error", err.Error())
+		}
+		reader = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, c.baseURL+path, reader)
+	if err != nil {
+		return errors.ErrOperationFailed.
+			WithMessage("failed to create registry request").
+			WithDetail("error", err.Error())
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if c.token != "" && method != http.MethodGet {
+		req.Header.Set(authn.HeaderAuthorization, "Bearer "+c.token)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return errors.ErrNetworkUnavailable.
+			WithMessage("registry request failed").
+			WithDetail("url", c.baseURL).
+			WithDetail("error", err.Error())
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return responseError(resp)
+	}
+	if out == nil {
+		return nil
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return errors.ErrMessageParsing.
+			WithMessage("failed to parse registry response").
+			WithDetail("error", err.Error())
+	}
+	return nil
+}
+
+// responseError turns an error response back into the registry error.
+func responseError(resp *http.Response) error {
+	var body errorBody
+	data, _ := io.ReadAll(resp.Body)
+	json.Unmarshal(data, &body)
+
+	for _, known := range knownErrors {
+		if body.Code == known.err.Code {
+			return known.err.WithDetail("remote_error", body.Error)
+		}
+	}
+	return errors.ErrOperationFailed.
+		WithMessage("registry request failed").
+		WithDetail("status_code", fmt.Sprintf("%d", resp.StatusCode)).
+		WithDetail("response", string(data))
+}
@@ -0,0 +1,188 @@
+// Copyright (C) 2025 sage-x-project
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with this program. If not, see <https://www.gnu.org/licenses/>.
+
+// SPDX-License-Identifier: LGPL-3.0-or-later
+
+package registry
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/sage-x-project/sage-adk/core/authn"
+	"github.com/sage-x-project/sage-adk/pkg/errors"
+)
+
+func TestClient_SharesRegistry(t *testing.T) {
+	ctx := context.Background()
+	backing := NewFileRegistry(filepath.Join(t.TempDir(), "registry.yaml"))
+
+	server := httptest.NewServer(Handler(backing, &HandlerConfig{Token: "secret"}))
+	defer server.Close()
+
+	alice := NewClient(server.URL, nil)
+	alice.SetToken("secret")
+	bob := NewClient(server.URL+"/", nil)
+	bob.SetToken("secret")
+
+	record := testRecord(t, "did:web:example.com%3A8443")
+	if err := alice.Register(ctx, record); err != nil {
+		t.Fatalf("Register() error = %v", err)
+	}
+	if err := alice.Register(ctx, record); !errors.Is(err, errors.ErrAlreadyExists) {
+		t.Errorf("second Register() error = %v, want ErrAlreadyExists", err)
+	}
+
+	// Another agent sees the record.
+	got, err := bob.Lookup(ctx, record.DID)
+	if err != nil {
+		t.Fatalf("Lookup() error = %v", err)
+	}
+	if got.DID != record.DID || got.PublicKey != record.PublicKey {
+		t.Errorf("Lookup() = %+v", got)
+	}
+
+	got.Name = "Renamed"
+	if err := bob.Update(ctx, got); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+	if stored, _ := backing.Lookup(ctx, record.DID); stored.Name != "Renamed" {
+		t.Errorf("stored Name = %q, want Renamed", stored.Name)
+	}
+
+	if err := alice.Deactivate(ctx, record.DID); err != nil {
+		t.Fatalf("Deactivate() error = %v", err)
+	}
+	if err := bob.Update(ctx, got); !errors.Is(err, errors.ErrAgentInactive) {
+		t.Errorf("Update() of deactivated DID error = %v, want ErrAgentInactive", err)
+	}
+
+	records, err := bob.List(ctx)
+	if err != nil || len(records) != 1 || records[0].IsActive() {
+		t.Errorf("List() = %v, %v", records, err)
+	}
+
+	if _, err := bob.Lookup(ctx, "did:sage:local:nobody"); !errors.Is(err, errors.ErrDIDNotFound) {
+		t.Errorf("Lookup() of unknown DID error = %v, want ErrDIDNotFound", err)
+	}
+	if err := alice.Register(ctx, &Record{DID: "did:sage:local:bad"}); !errors.Is(err, errors.ErrInvalidInput) {
+		t.Errorf("Register() of invalid record error = %v, want ErrInvalidInput", err)
+	}
+}
+
+func TestHandler_Errors(t *testing.T) {
+	reg := NewFileRegistry(filepath.Join(t.TempDir(), "registry.json"))
+	server := httptest.NewServer(Handler(reg, &HandlerConfig{Token: "secret"}))
+	defer server.Close()
+
+	tests := []struct {
+		method string
+		path   string
+		want   int
+	}{
+		{http.MethodGet, "/dids", http.StatusOK},
+		{http.MethodPatch, "/dids", http.StatusMethodNotAllowed},
+		{http.MethodPost, "/dids/did:sage:local:alice", http.StatusMethodNotAllowed},
+		{http.MethodGet, "/other", http.StatusNotFound},
+		{http.MethodPost, "/dids", http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		req, _ := http.NewRequest(tt.method, server.URL+tt.path, nil)
+		req.Header.Set("Authorization", "Bearer secret")
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatalf("%s %s: %v", tt.method, tt.path, err)
+		}
+		resp.Body.Close()
+		if resp.StatusCode != tt.want {
+			t.Errorf("%s %s status = %d, want %d", tt.method, tt.path, resp.StatusCode, tt.want)
+		}
+	}
+}
+
+func TestHandler_WriteAuth(t *testing.T) {
+	ctx := context.Background()
+	authenticator, err := authn.NewAuthenticator(&authn.Config{
+		APIKeys:  []authn.APIKey{{ID: "ops", Key: "ops-secret"}},
+		Optional: true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		config  *HandlerConfig
+		token   string
+		wantErr *errors.Error
+	}{
+		{name: "read-only", config: nil, token: "secret", wantErr: errors.ErrUnauthorized},
+		{name: "shared token", config: &HandlerConfig{Token: "secret"}, token: "secret"},
+		{name: "wrong token", config: &HandlerConfig{Token: "secret"}, token: "guess", wantErr: errors.ErrInvalidCredentials},
+		{name: "no token", config: &HandlerConfig{Token: "secret"}, wantErr: errors.ErrUnauthorized},
+		{name: "API key", config: &HandlerConfig{Authenticator: authenticator}, token: "ops-secret"},
+		{name: "anonymous", config: &HandlerConfig{Authenticator: authenticator}, wantErr: errors.ErrUnauthorized},
+		{name: "unknown API key", config: &HandlerConfig{Authenticator: authenticator}, token: "guess", wantErr: errors.ErrInvalidCredentials},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(Handler(NewFileRegistry(filepath.Join(t.TempDir(), "registry.json")), tt.config))
+			defer server.Close()
+
+			client := NewClient(server.URL, nil)
+			client.SetToken(tt.token)
+			err := client.Register(ctx, testRecord(t, "did:sage:local:alice"))
+			if tt.wantErr == nil && err != nil {
+				t.Fatalf("Register() error = %v", err)
+			}
+			if tt.wantErr != nil && !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Register() error = %v, want %v", err, tt.wantErr)
+			}
+
+			// Reads stay public
+			if _, err := NewClient(server.URL, nil).List(ctx); err != nil {
+				t.Errorf("List() error = %v", err)
+			}
+		})
+	}
+}
+
+func TestHandler_MaxBodyBytes(t *testing.T) {
+	server := httptest.NewServer(Handler(
+		NewFileRegistry(filepath.Join(t.TempDir(), "registry.json")),
+		&HandlerConfig{Token: "secret", MaxBodyBytes: 1024},
+	))
+	defer server.Close()
+
+	client := NewClient(server.URL, nil)
+	client.SetToken("secret")
+
+	record := testRecord(t, "did:sage:local:alice")
+	record.Name = strings.Repeat("a", 2048)
+	if err := client.Register(context.Background(), record); !errors.Is(err, errors.ErrOutOfRange) {
+		t.Errorf("Register() of large record error = %v, want ErrOutOfRange", err)
+	}
+
+	record.Name = "Alice"
+	if err := client.Register(context.Background(), record); err != nil {
+		t.Errorf("Register() error = %v", err)
+	}
+}
@@ -0,0 +1,400 @@
+// Copyright (C) 2025 sage-x-project
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with this program. If not, see <https://www.gnu.org/licenses/>.
+
+// SPDX-License-Identifier: LGPL-3.0-or-later
+
+package registry
+
+import (
+	"context"
+	"crypto/ecdh"
+	"crypto/ed25519"
+	"encoding/base64"
+	"encoding/json"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sage-x-project/sage-adk/pkg/errors"
+	"github.com/sage-x-project/sage-adk/storage"
+)
+
+// Record is an agent entry in a local DID registry.
+type Record struct {
+	// DID is the agent's decentralized identifier.
+	DID string `json:"did" yaml:"did"`
+
+	// Name, Description and Endpoint describe the agent.
+	Name        string `json:"name,omitempty" yaml:"name,omitempty"`
+	Description string `json:"description,omitempty" yaml:"description,omitempty"`
+	Endpoint    string `json:"endpoint,omitempty" yaml:"endpoint,omitempty"`
+
+	// Owner identifies who controls the DID.
+	Owner string `json:"owner,omitempty" yaml:"owner,omitempty"`
+
+	// PublicKey is the base64-encoded Ed25519 verification key.
+	PublicKey string `json:"public_key" yaml:"public_key"`
+
+	// Keys optionally lists versioned verification keys, selected by the
+	// fragment of a signature's key ID. Without it, PublicKey verifies
+	// signatures under any key ID.
+	Keys []VerificationKey `json:"keys,omitempty" yaml:"keys,omitempty"`
+
+	// KEMKey is the optional base64-encoded X25519 key agreement key.
+	KEMKey string `json:"kem_key,omitempty" yaml:"kem_key,omitempty"`
+
+	// Capabilities are advertised agent capabilities.
+	Capabilities map[string]interface{} `json:"capabilities,omitempty" yaml:"capabilities,omitempty"`
+
+	// Deactivated marks a DID that may no longer sign messages.
+	Deactivated bool `json:"deactivated,omitempty" yaml:"deactivated,omitempty"`
+
+	// CreatedAt and UpdatedAt are set by the registry.
+	CreatedAt time.Time `json:"created_at" yaml:"created_at"`
+	UpdatedAt time.Time `json:"updated_at" yaml:"updated_at"`
+}
+
+// VerificationKey is a versioned verification key of a record.
+type VerificationKey struct {
+	// ID is the key ID fragment ("key-2").
+	ID string `json:"id" yaml:"id"`
+
+	// PublicKey is the base64-encoded Ed25519 public key.
+	PublicKey string `json:"public_key" yaml:"public_key"`
+
+	// NotAfter, if set, is when the key stops verifying signatures.
+	NotAfter *time.Time `json:"not_after,omitempty" yaml:"not_after,omitempty"`
+}
+
+// NewRecord creates a record for did with an Ed25519 verification key.
+func NewRecord(did string, publicKey ed25519.PublicKey) *Record {
+	return &Record{
+		DID:       did,
+		PublicKey: base64.StdEncoding.EncodeToString(publicKey),
+	}
+}
+
+// IsActive returns true if the DID has not been deactivated.
+func (r *Record) IsActive() bool {
+	return !r.Deactivated
+}
+
+// Validate checks that the record has a DID and well-formed keys.
+func (r *Record) Validate() error {
+	if !strings.HasPrefix(r.DID, "did:") {
+		return errors.ErrInvalidInput.
+			WithMessage("DID must start with \"did:\"").
+			WithDetail("did", r.DID)
+	}
+	if _, err := r.Ed25519PublicKey(); err != nil {
+		return err
+	}
+	for _, key := range r.Keys {
+		if key.ID == "" {
+			return errors.ErrInvalidInput.
+				WithMessage("verification key ID is empty").
+				WithDetail("did", r.DID)
+		}
+		if _, err := decodeKey(r.DID, "keys."+key.ID, key.PublicKey, ed25519.PublicKeySize); err != nil {
+			return err
+		}
+	}
+	if r.KEMKey != "" {
+		if _, err := r.X25519PublicKey(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// VerificationKeyFor returns the Ed25519 key that verifies signatures under
+// keyID ("did#key-2") at now. Records without versioned Keys return
+// PublicKey for any key ID.
+func (r *Record) VerificationKeyFor(keyID string, now time.Time) (ed25519.PublicKey, error) {
+	if len(r.Keys) == 0 {
+		return r.Ed25519PublicKey()
+	}
+
+	fragment := keyID
+	if i := strings.Index(keyID, "#"); i >= 0 {
+		fragment = keyID[i+1:]
+	}
+
+	for _, key := range r.Keys {
+		if key.ID != fragment {
+			continue
+		}
+		if key.NotAfter != nil && !now.Before(*key.NotAfter) {
+			return nil, errors.ErrSignatureInvalid.
+				WithMessage("verification key has expired").
+				WithDetail("key_id", keyID)
+		}
+		publicKey, err := decodeKey(r.DID, "keys."+key.ID, key.PublicKey, ed25519.PublicKeySize)
+		if err != nil {
+			return nil, err
+		}
+		return ed25519.PublicKey(publicKey), nil
+	}
+
+	return nil, errors.ErrNotFound.
+		WithMessage("unknown key ID").
+		WithDetail("key_id", keyID)
+}
+
+// Ed25519PublicKey decodes the verification key.
+func (r *Record) Ed25519PublicKey() (ed25519.PublicKey, error) {
+	key, err := decodeKey(r.DID, "public_key", r.PublicKey, ed25519.PublicKeySize)
+	if err != nil {
+		return nil, err
+	}
+	return ed25519.PublicKey(key), nil
+}
+
+// X25519PublicKey decodes the key agreement key.
+func (r *Record) X25519PublicKey() (*ecdh.PublicKey, error) {
+	if r.KEMKey == "" {
+		return nil, errors.ErrNotFound.
+			WithMessage("DID has no key agreement key").
+			WithDetail("did", r.DID)
+	}
+
+	key, err := decodeKey(r.DID, "kem_key", r.KEMKey, 32)
+	if err != nil {
+		return nil, err
+	}
+	return ecdh.X25519().NewPublicKey(key)
+}
+
+// decodeKey decodes a base64 key of the given size.
+func decodeKey(did, field, value string, size int) ([]byte, error) {
+	key, err := base64.StdEncoding.DecodeString(value)
+	if err != nil || len(key) != size {
+		return nil, errors.ErrInvalidInput.
+			WithMessage("invalid "+field).
+			WithDetail("did", did).
+			WithDetail("expected", "base64-encoded 32-byte key")
+	}
+	return key, nil
+}
+
+// Registry manages agent DIDs without a blockchain.
+type Registry interface {
+	// Register adds a new DID. It fails with ErrAlreadyExists if the DID
+	// is registered, even if deactivated.
+	Register(ctx context.Context, record *Record) error
+
+	// Update replaces the record of an active DID. It fails with
+	// ErrDIDNotFound or ErrAgentInactive.
+	Update(ctx context.Context, record *Record) error
+
+	// Deactivate marks a DID as deactivated. Deactivated DIDs still
+	// resolve, but their keys are no longer returned.
+	Deactivate(ctx context.Context, did string) error
+
+	// Lookup returns the record of a DID, or ErrDIDNotFound.
+	Lookup(ctx context.Context, did string) (*Record, error)
+
+	// List returns all records ordered by DID.
+	List(ctx context.Context) ([]*Record, error)
+}
+
+// backend persists the records of a LocalRegistry.
+type backend interface {
+	get(ctx context.Context, did string) (*Record, error)
+	put(ctx context.Context, record *Record) error
+	list(ctx context.Context) ([]*Record, error)
+}
+
+// LocalRegistry is a Registry kept in a file or a storage backend.
+type LocalRegistry struct {
+	backend backend
+	mu      sync.Mutex
+}
+
+// NewFileRegistry creates a registry kept in a JSON or YAML file (by
+// extension: .yaml and .yml are YAML, anything else is JSON). The file is
+// created on the first write and re-read on every operation, so edits by
+// other processes or by hand are picked up.
+func NewFileRegistry(path string) *LocalRegistry {
+	return &LocalRegistry{backend: newFileBackend(path)}
+}
+
+// DefaultNamespace is the storage namespace used by NewStorageRegistry.
+const DefaultNamespace = "did_registry"
+
+// NewStorageRegistry creates a registry kept in a storage backend.
+func NewStorageRegistry(store storage.Storage) *LocalRegistry {
+	return &LocalRegistry{backend: &storageBackend{storage: store, namespace: DefaultNamespace}}
+}
+
+// Open opens the registry at location: an http:// or https:// URL is a
+// registry served by Handler, anything else is a registry file.
+func Open(location string) (Registry, error) {
+	if location == "" {
+		return nil, errors.ErrInvalidInput.WithMessage("registry location is empty")
+	}
+	if strings.HasPrefix(location, "http://") || strings.HasPrefix(location, "https://") {
+		return NewClient(location, nil), nil
+	}
+	return NewFileRegistry(location), nil
+}
+
+// Register adds a new DID.
+func (r *LocalRegistry) Register(ctx context.Context, record *Record) error {
+	if err := record.Validate(); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, err := r.backend.get(ctx, record.DID); err == nil {
+		return errors.ErrAlreadyExists.
+			WithMessage("DID is already registered").
+			WithDetail("did", record.DID)
+	} else if !errors.Is(err, errors.ErrDIDNotFound) {
+		return err
+	}
+
+	stored := *record
+	stored.Deactivated = false
+	stored.CreatedAt = time.Now().UTC()
+	stored.UpdatedAt = stored.CreatedAt
+	return r.backend.put(ctx, &stored)
+}
+
+// Update replaces the record of an active DID, keeping its creation time.
+func (r *LocalRegistry) Update(ctx context.Context, record *Record) error {
+	if err := record.Validate(); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	existing, err := r.backend.get(ctx, record.DID)
+	if err != nil {
+		return err
+	}
+	if !existing.IsActive() {
+		return errors.ErrAgentInactive.
+			WithMessage("cannot update a deactivated DID").
+			WithDetail("did", record.DID)
+	}
+
+	stored := *record
+	stored.Deactivated = false
+	stored.CreatedAt = existing.CreatedAt
+	stored.UpdatedAt = time.Now().UTC()
+	return r.backend.put(ctx, &stored)
+}
+
+// Deactivate marks a DID as deactivated. Deactivating twice is a no-op.
+func (r *LocalRegistry) Deactivate(ctx context.Context, did string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	record, err := r.backend.get(ctx, did)
+	if err != nil {
+		return err
+	}
+	if record.Deactivated {
+		return nil
+	}
+
+	record.Deactivated = true
+	record.UpdatedAt = time.Now().UTC()
+	return r.backend.put(ctx, record)
+}
+
+// Lookup returns the record of a DID.
+func (r *LocalRegistry) Lookup(ctx context.Context, did string) (*Record, error) {
+	return r.backend.get(ctx, did)
+}
+
+// List returns all records ordered by DID.
+func (r *LocalRegistry) List(ctx context.Context) ([]*Record, error) {
+	records, err := r.backend.list(ctx)
+	if err != nil {
+		return nil, err
+	}
+	sortRecords(records)
+	return records, nil
+}
+
+func sortRecords(records []*Record) {
+	sort.Slice(records, func(i, j int) bool { return records[i].DID < records[j].DID })
+}
+
+func notFound(did string) error {
+	return errors.ErrDIDNotFound.WithDetail("did", did)
+}
+
+// storageBackend keeps records in a storage namespace keyed by DID.
+type storageBackend struct {
+	storage   storage.Storage
+	namespace string
+}
+
+func (b *storageBackend) get(ctx context.Context, did string) (*Record, error) {
+	item, err := b.storage.Get(ctx, b.namespace, did)
+	if err != nil {
+		if err == storage.ErrNotFound || errors.Is(err, errors.ErrNotFound) {
+			return nil, notFound(did)
+		}
+		return nil, err
+	}
+	return decodeRecord(item)
+}
+
+func (b *storageBackend) put(ctx context.Context, record *Record) error {
+	return b.storage.Store(ctx, b.namespace, record.DID, *record)
+}
+
+func (b *storageBackend) list(ctx context.Context) ([]*Record, error) {
+	items, err := b.storage.List(ctx, b.namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	records := make([]*Record, 0, len(items))
+	for _, item := range items {
+		record, err := decodeRecord(item)
+		if err != nil {
+			return nil, err
+		}
+		records = append(records, record)
+	}
+	return records, nil
+}
+
+// decodeRecord converts a stored item back into a Record. Backends that
+// serialize values return generic JSON data rather than the struct.
+func decodeRecord(item interface{}) (*Record, error) {
+	if r, ok := item.(Record); ok {
+		return &r, nil
+	}
+
+	data, err := json.Marshal(item)
+	if err != nil {
+		return nil, err
+	}
+	var record Record
+	if err := json.Unmarshal(data, &record); err != nil {
+		return nil, err
+	}
+	return &record, nil
+}
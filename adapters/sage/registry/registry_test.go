@@ -0,0 +1,278 @@
+// Copyright (C) 2025 sage-x-project
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with this program. If not, see <https://www.gnu.org/licenses/>.
+
+// SPDX-License-Identifier: LGPL-3.0-or-later
+
+package registry
+
+import (
+	"context"
+	"crypto/ecdh"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/base64"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sage-x-project/sage-adk/pkg/errors"
+	"github.com/sage-x-project/sage-adk/storage"
+)
+
+func testRecord(t *testing.T, did string) *Record {
+	t.Helper()
+
+	publicKey, _, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("GenerateKey() error = %v", err)
+	}
+	record := NewRecord(did, publicKey)
+	record.Name = "Test Agent"
+	return record
+}
+
+// testRegistries returns a registry for each backend.
+func testRegistries(t *testing.T) map[string]Registry {
+	dir := t.TempDir()
+	return map[string]Registry{
+		"json":    NewFileRegistry(filepath.Join(dir, "registry.json")),
+		"yaml":    NewFileRegistry(filepath.Join(dir, "registry.yaml")),
+		"storage": NewStorageRegistry(storage.NewMemoryStorage()),
+	}
+}
+
+func TestRegistry_Lifecycle(t *testing.T) {
+	ctx := context.Background()
+
+	for name, reg := range testRegistries(t) {
+		t.Run(name, func(t *testing.T) {
+			record := testRecord(t, "did:sage:local:alice")
+
+			if _, err := reg.Lookup(ctx, record.DID); !errors.Is(err, errors.ErrDIDNotFound) {
+				t.Fatalf("Lookup() before Register error = %v, want ErrDIDNotFound", err)
+			}
+
+			if err := reg.Register(ctx, record); err != nil {
+				t.Fatalf("Register() error = %v", err)
+			}
+			if err := reg.Register(ctx, record); !errors.Is(err, errors.ErrAlreadyExists) {
+				t.Errorf("second Register() error = %v, want ErrAlreadyExists", err)
+			}
+
+			got, err := reg.Lookup(ctx, record.DID)
+			if err != nil {
+				t.Fatalf("Lookup() error = %v", err)
+			}
+			if got.Name != "Test Agent" || got.PublicKey != record.PublicKey || !got.IsActive() {
+				t.Errorf("Lookup() = %+v", got)
+			}
+			if got.CreatedAt.IsZero() {
+				t.Error("CreatedAt should be set")
+			}
+
+			updated := *got
+			updated.Endpoint = "http://localhost:18080/sage/message"
+			if err := reg.Update(ctx, &updated); err != nil {
+				t.Fatalf("Update() error = %v", err)
+			}
+			got, _ = reg.Lookup(ctx, record.DID)
+			if got.Endpoint != updated.Endpoint {
+				t.Errorf("Endpoint = %q, want %q", got.Endpoint, updated.Endpoint)
+			}
+
+			if err := reg.Deactivate(ctx, record.DID); err != nil {
+				t.Fatalf("Deactivate() error = %v", err)
+			}
+			if err := reg.Deactivate(ctx, record.DID); err != nil {
+				t.Errorf("second Deactivate() error = %v", err)
+			}
+			got, _ = reg.Lookup(ctx, record.DID)
+			if got.IsActive() {
+				t.Error("record should be deactivated")
+			}
+			if err := reg.Update(ctx, &updated); !errors.Is(err, errors.ErrAgentInactive) {
+				t.Errorf("Update() of deactivated DID error = %v, want ErrAgentInactive", err)
+			}
+
+			if err := reg.Deactivate(ctx, "did:sage:local:nobody"); !errors.Is(err, errors.ErrDIDNotFound) {
+				t.Errorf("Deactivate() of unknown DID error = %v, want ErrDIDNotFound", err)
+			}
+			if err := reg.Update(ctx, testRecord(t, "did:sage:local:nobody")); !errors.Is(err, errors.ErrDIDNotFound) {
+				t.Errorf("Update() of unknown DID error = %v, want ErrDIDNotFound", err)
+			}
+		})
+	}
+}
+
+func TestRegistry_List(t *testing.T) {
+	ctx := context.Background()
+
+	for name, reg := range testRegistries(t) {
+		t.Run(name, func(t *testing.T) {
+			for _, did := range []string{"did:sage:local:carol", "did:sage:local:alice", "did:sage:local:bob"} {
+				if err := reg.Register(ctx, testRecord(t, did)); err != nil {
+					t.Fatalf("Register(%s) error = %v", did, err)
+				}
+			}
+
+			records, err := reg.List(ctx)
+			if err != nil {
+				t.Fatalf("List() error = %v", err)
+			}
+			var dids []string
+			for _, r := range records {
+				dids = append(dids, r.DID)
+			}
+			if got := strings.Join(dids, ","); got != "did:sage:local:alice,did:sage:local:bob,did:sage:local:carol" {
+				t.Errorf("List() = %s", got)
+			}
+		})
+	}
+}
+
+func TestRecord_Validate(t *testing.T) {
+	valid := testRecord(t, "did:sage:local:alice")
+
+	kemKey, _ := ecdh.X25519().GenerateKey(rand.Reader)
+	withKEM := *valid
+	withKEM.KEMKey = base64.StdEncoding.EncodeToString(kemKey.PublicKey().Bytes())
+
+	tests := []struct {
+		name    string
+		record  Record
+		wantErr bool
+	}{
+		{"valid", *valid, false},
+		{"valid with KEM key", withKEM, false},
+		{"missing did prefix", Record{DID: "alice", PublicKey: valid.PublicKey}, true},
+		{"missing key", Record{DID: valid.DID}, true},
+		{"short key", Record{DID: valid.DID, PublicKey: base64.StdEncoding.EncodeToString([]byte("short"))}, true},
+		{"bad KEM key", Record{DID: valid.DID, PublicKey: valid.PublicKey, KEMKey: "not base64!"}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.record.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+
+	key, err := withKEM.X25519PublicKey()
+	if err != nil || !key.Equal(kemKey.PublicKey()) {
+		t.Errorf("X25519PublicKey() = %v, %v", key, err)
+	}
+	if _, err := valid.X25519PublicKey(); err == nil {
+		t.Error("X25519PublicKey() without KEM key should fail")
+	}
+}
+
+func TestRecord_VerificationKeyFor(t *testing.T) {
+	record := testRecord(t, "did:sage:local:alice")
+	now := time.Now()
+
+	// Without versioned keys, the primary key verifies any key ID.
+	primary, _ := record.Ed25519PublicKey()
+	if key, err := record.VerificationKeyFor(record.DID+"#key-7", now); err != nil || !key.Equal(primary) {
+		t.Errorf("VerificationKeyFor() = %x, %v, want primary key", key, err)
+	}
+
+	retired, _, _ := ed25519.GenerateKey(rand.Reader)
+	expires := now.Add(time.Hour)
+	record.Keys = []VerificationKey{
+		{ID: "key-1", PublicKey: base64.StdEncoding.EncodeToString(retired), NotAfter: &expires},
+		{ID: "key-2", PublicKey: record.PublicKey},
+	}
+	if err := record.Validate(); err != nil {
+		t.Fatalf("Validate() error = %v", err)
+	}
+
+	if key, err := record.VerificationKeyFor(record.DID+"#key-1", now); err != nil || !key.Equal(retired) {
+		t.Errorf("VerificationKeyFor(key-1) = %x, %v, want retired key", key, err)
+	}
+	if _, err := record.VerificationKeyFor(record.DID+"#key-1", expires); !errors.Is(err, errors.ErrSignatureInvalid) {
+		t.Errorf("VerificationKeyFor(key-1) after expiry error = %v, want ErrSignatureInvalid", err)
+	}
+	if key, err := record.VerificationKeyFor("key-2", now); err != nil || !key.Equal(primary) {
+		t.Errorf("VerificationKeyFor(key-2) = %x, %v", key, err)
+	}
+	if _, err := record.VerificationKeyFor(record.DID+"#key-3", now); !errors.Is(err, errors.ErrNotFound) {
+		t.Errorf("VerificationKeyFor(key-3) error = %v, want ErrNotFound", err)
+	}
+
+	record.Keys = append(record.Keys, VerificationKey{ID: "key-3", PublicKey: "short"})
+	if err := record.Validate(); err == nil {
+		t.Error("Validate() should reject a malformed versioned key")
+	}
+}
+
+func TestFileRegistry_HandWrittenYAML(t *testing.T) {
+	publicKey, _, _ := ed25519.GenerateKey(rand.Reader)
+	path := filepath.Join(t.TempDir(), "registry.yml")
+
+	content := "agents:\n" +
+		"  - did: did:sage:local:alice\n" +
+		"    name: Alice\n" +
+		"    public_key: " + base64.StdEncoding.EncodeToString(publicKey) + "\n" +
+		"    capabilities:\n" +
+		"      chat: true\n"
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	record, err := NewFileRegistry(path).Lookup(context.Background(), "did:sage:local:alice")
+	if err != nil {
+		t.Fatalf("Lookup() error = %v", err)
+	}
+	if !record.IsActive() || record.Name != "Alice" || record.Capabilities["chat"] != true {
+		t.Errorf("Lookup() = %+v", record)
+	}
+
+	key, err := record.Ed25519PublicKey()
+	if err != nil || !key.Equal(publicKey) {
+		t.Errorf("Ed25519PublicKey() = %v, %v", key, err)
+	}
+}
+
+func TestFileRegistry_InvalidFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "registry.json")
+	if err := os.WriteFile(path, []byte("{not json"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err := NewFileRegistry(path).Lookup(context.Background(), "did:sage:local:alice")
+	if !errors.Is(err, errors.ErrInvalidFormat) {
+		t.Errorf("Lookup() error = %v, want ErrInvalidFormat", err)
+	}
+}
+
+func TestOpen(t *testing.T) {
+	if _, err := Open(""); err == nil {
+		t.Error("Open(\"\") should fail")
+	}
+
+	reg, err := Open("http://localhost:18700")
+	if _, ok := reg.(*Client); err != nil || !ok {
+		t.Errorf("Open(URL) = %T, %v, want *Client", reg, err)
+	}
+
+	reg, err = Open(filepath.Join(t.TempDir(), "registry.yaml"))
+	if _, ok := reg.(*LocalRegistry); err != nil || !ok {
+		t.Errorf("Open(path) = %T, %v, want *LocalRegistry", reg, err)
+	}
+}
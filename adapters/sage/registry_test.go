@@ -0,0 +1,129 @@
+// Copyright (C) 2025 sage-x-project
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with this program. If not, see <https://www.gnu.org/licenses/>.
+
+// SPDX-License-Identifier: LGPL-3.0-or-later
+
+package sage
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/sage-x-project/sage-adk/adapters/sage/registry"
+	"github.com/sage-x-project/sage-adk/config"
+	"github.com/sage-x-project/sage-adk/pkg/types"
+	"github.com/sage-x-project/sage/did"
+)
+
+func TestRegistryDIDResolver(t *testing.T) {
+	ctx := context.Background()
+	reg := registry.NewFileRegistry(filepath.Join(t.TempDir(), "registry.yaml"))
+
+	publicKey, _, _ := ed25519.GenerateKey(rand.Reader)
+	record := registry.NewRecord("did:sage:local:alice", publicKey)
+	record.Name = "Alice"
+	record.Owner = "0xOwner"
+	if err := reg.Register(ctx, record); err != nil {
+		t.Fatalf("Register() error = %v", err)
+	}
+
+	resolver := NewRegistryDIDResolver(reg)
+
+	key, err := resolver.ResolvePublicKey(ctx, record.DID)
+	if err != nil {
+		t.Fatalf("ResolvePublicKey() error = %v", err)
+	}
+	if !publicKey.Equal(key) {
+		t.Error("ResolvePublicKey() returned a different key")
+	}
+
+	agents, err := resolver.ListAgentsByOwner(ctx, "0xowner")
+	if err != nil || len(agents) != 1 || agents[0].Name != "Alice" {
+		t.Errorf("ListAgentsByOwner() = %v, %v", agents, err)
+	}
+
+	agents, err = resolver.Search(ctx, did.SearchCriteria{Name: "ali", ActiveOnly: true, Limit: 10})
+	if err != nil || len(agents) != 1 {
+		t.Errorf("Search() = %v, %v", agents, err)
+	}
+
+	metadata, _ := resolver.Resolve(ctx, record.DID)
+	result, err := resolver.VerifyMetadata(ctx, record.DID, metadata)
+	if err != nil || !result.Valid {
+		t.Errorf("VerifyMetadata() = %+v, %v", result, err)
+	}
+
+	if _, err := resolver.ResolvePublicKey(ctx, "did:sage:local:nobody"); !errors.Is(err, did.ErrDIDNotFound) {
+		t.Errorf("ResolvePublicKey() of unknown DID error = %v, want did.ErrDIDNotFound", err)
+	}
+
+	// Deactivated DIDs still resolve but no longer yield keys.
+	if err := reg.Deactivate(ctx, record.DID); err != nil {
+		t.Fatalf("Deactivate() error = %v", err)
+	}
+	active, err := resolver.IsActive(ctx, record.DID)
+	if err != nil || active {
+		t.Errorf("IsActive() = %v, %v, want false", active, err)
+	}
+	if _, err := resolver.ResolvePublicKey(ctx, record.DID); err == nil {
+		t.Error("ResolvePublicKey() of deactivated DID should fail")
+	}
+}
+
+func TestAdapter_Verify_WithLocalRegistry(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "registry.json")
+
+	publicKey, privateKey, _ := ed25519.GenerateKey(rand.Reader)
+	if err := registry.NewFileRegistry(path).Register(ctx, registry.NewRecord("did:sage:local:alice", publicKey)); err != nil {
+		t.Fatalf("Register() error = %v", err)
+	}
+
+	sender, err := NewAdapter(&config.SAGEConfig{Network: "local", DID: "did:sage:local:alice", Registry: path})
+	if err != nil {
+		t.Fatalf("NewAdapter() error = %v", err)
+	}
+	sender.privateKey = privateKey
+
+	receiver, err := NewAdapter(&config.SAGEConfig{Network: "local", DID: "did:sage:local:bob", Registry: path})
+	if err != nil {
+		t.Fatalf("NewAdapter() error = %v", err)
+	}
+
+	msg := types.NewMessage(types.MessageRoleUser, []types.Part{types.NewTextPart("hello")})
+	if err := sender.SendMessage(ctx, msg); err != nil {
+		t.Fatalf("SendMessage() error = %v", err)
+	}
+	if msg.Security.Signature == nil {
+		t.Fatal("message should be signed")
+	}
+
+	if err := receiver.Verify(ctx, msg); err != nil {
+		t.Fatalf("Verify() error = %v", err)
+	}
+
+	// A message signed by a key other than the registered one is rejected.
+	_, otherKey, _ := ed25519.GenerateKey(rand.Reader)
+	sender.privateKey = otherKey
+	forged := types.NewMessage(types.MessageRoleUser, []types.Part{types.NewTextPart("hello")})
+	sender.SendMessage(ctx, forged)
+	if err := receiver.Verify(ctx, forged); err == nil {
+		t.Error("Verify() should reject a signature from an unregistered key")
+	}
+}
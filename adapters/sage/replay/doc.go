@@ -0,0 +1,33 @@
+// Copyright (C) 2025 sage-x-project
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with this program. If not, see <https://www.gnu.org/licenses/>.
+
+// SPDX-License-Identifier: LGPL-3.0-or-later
+// Package replay protects signed SAGE messages against replay.
+//
+// Every signed message carries a nonce. A Guard remembers nonces for as long
+// as the message's timestamp is acceptable, and rejects a nonce seen twice
+// with errors.ErrReplayDetected.
+//
+// Memory keeps nonces in-process with time-based expiry. Redis claims each
+// nonce with SET NX and a TTL, so replicas behind a load balancer share one
+// view and a replay routed to a different replica is still rejected:
+//
+//	guard, err := replay.Open("redis://redis:6379/0", replay.Window(5*time.Minute))
+//	if err := guard.Check(ctx, msg.Security.Nonce); err != nil {
+//	    // reject the message
+//	}
+//
+// The SAGE adapter selects its guard with the sage.nonce_store setting.
+package replay
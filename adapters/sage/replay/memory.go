@@ -0,0 +1,90 @@
+// Copyright (C) 2025 sage-x-project
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with this program. If not, see <https://www.gnu.org/licenses/>.
+
+// SPDX-License-Identifier: LGPL-3.0-or-later
+
+package replay
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Memory is an in-process Guard. Nonces expire once the window has passed
+// since they were first seen, so memory use follows the message rate rather
+// than a fixed size, and a nonce is never forgotten early.
+//
+// Memory only protects a single process; use Redis behind a load balancer.
+type Memory struct {
+	mu        sync.Mutex
+	window    time.Duration
+	nonces    map[string]time.Time // nonce -> expiry
+	nextSweep time.Time
+	now       func() time.Time
+}
+
+// NewMemory creates an in-memory guard that remembers nonces for window.
+// A non-positive window uses Window(DefaultMaxClockSkew).
+func NewMemory(window time.Duration) *Memory {
+	if window <= 0 {
+		window = Window(DefaultMaxClockSkew)
+	}
+	return &Memory{
+		window: window,
+		nonces: make(map[string]time.Time),
+		now:    time.Now,
+	}
+}
+
+// Check implements Guard.
+func (m *Memory) Check(ctx context.Context, nonce string) error {
+	if err := validateNonce(nonce); err != nil {
+		return err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := m.now()
+	if !now.Before(m.nextSweep) {
+		m.sweep(now)
+	}
+
+	if expiry, ok := m.nonces[nonce]; ok && now.Before(expiry) {
+		return replayed(nonce)
+	}
+	m.nonces[nonce] = now.Add(m.window)
+	return nil
+}
+
+// Len returns the number of remembered nonces, including expired ones not
+// swept yet.
+func (m *Memory) Len() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return len(m.nonces)
+}
+
+// sweep drops expired nonces. It runs at most a few times per window so
+// Check stays O(1) on average.
+func (m *Memory) sweep(now time.Time) {
+	for nonce, expiry := range m.nonces {
+		if !now.Before(expiry) {
+			delete(m.nonces, nonce)
+		}
+	}
+	m.nextSweep = now.Add(m.window / 4)
+}
@@ -0,0 +1,94 @@
+// Copyright (C) 2025 sage-x-project
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with this program. If not, see <https://www.gnu.org/licenses/>.
+
+// SPDX-License-Identifier: LGPL-3.0-or-later
+
+package replay
+
+import (
+	"context"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+
+	"github.com/sage-x-project/sage-adk/pkg/errors"
+)
+
+// RedisConfig configures a Redis guard.
+type RedisConfig struct {
+	// KeyPrefix is prepended to nonces to form Redis keys.
+	KeyPrefix string
+
+	// Window is how long nonces are remembered.
+	Window time.Duration
+}
+
+// DefaultRedisConfig returns the default Redis guard configuration.
+func DefaultRedisConfig() *RedisConfig {
+	return &RedisConfig{
+		KeyPrefix: "sage:nonce:",
+		Window:    Window(DefaultMaxClockSkew),
+	}
+}
+
+// Redis is a Guard shared by every process using the same Redis, so a
+// replay is caught whichever replica it reaches. Each nonce is claimed with
+// SET NX and expires with the window.
+type Redis struct {
+	client redis.UniversalClient
+	config RedisConfig
+}
+
+// NewRedis creates a Redis guard. A nil config uses DefaultRedisConfig;
+// zero fields take their defaults.
+func NewRedis(client redis.UniversalClient, config *RedisConfig) *Redis {
+	defaults := DefaultRedisConfig()
+	if config == nil {
+		config = defaults
+	}
+
+	cfg := *config
+	if cfg.KeyPrefix == "" {
+		cfg.KeyPrefix = defaults.KeyPrefix
+	}
+	if cfg.Window <= 0 {
+		cfg.Window = defaults.Window
+	}
+
+	return &Redis{client: client, config: cfg}
+}
+
+// Check implements Guard.
+func (r *Redis) Check(ctx context.Context, nonce string) error {
+	if err := validateNonce(nonce); err != nil {
+		return err
+	}
+
+	claimed, err := r.client.SetNX(ctx, r.config.KeyPrefix+nonce, 1, r.config.Window).Result()
+	if err != nil {
+		return errors.ErrStorageConnection.
+			WithMessage("nonce store unavailable").
+			WithDetail("error", err.Error())
+	}
+	if !claimed {
+		return replayed(nonce)
+	}
+	return nil
+}
+
+// Close closes the Redis client.
+func (r *Redis) Close() error {
+	return r.client.Close()
+}
@@ -0,0 +1,68 @@
+// Copyright (C) 2025 sage-x-project
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with this program. If not, see <https://www.gnu.org/licenses/>.
+
+// SPDX-License-Identifier: LGPL-3.0-or-later
+//go:build integration
+
+package replay
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+
+	"github.com/sage-x-project/sage-adk/pkg/errors"
+)
+
+func setupRedis(t *testing.T) *redis.Client {
+	t.Helper()
+
+	client := redis.NewClient(&redis.Options{Addr: "localhost:6379"})
+	if err := client.Ping(context.Background()).Err(); err != nil {
+		t.Skipf("Redis not available: %v", err)
+	}
+	t.Cleanup(func() { client.Close() })
+	return client
+}
+
+func TestRedis_SharedAcrossReplicas(t *testing.T) {
+	client := setupRedis(t)
+	ctx := context.Background()
+	prefix := fmt.Sprintf("test:nonce:%d:", time.Now().UnixNano())
+
+	// Two replicas with their own guards over the same Redis
+	replicaA := NewRedis(client, &RedisConfig{KeyPrefix: prefix, Window: time.Second})
+	replicaB := NewRedis(client, &RedisConfig{KeyPrefix: prefix, Window: time.Second})
+
+	if err := replicaA.Check(ctx, "nonce-1"); err != nil {
+		t.Fatalf("Check() error = %v", err)
+	}
+	if err := replicaB.Check(ctx, "nonce-1"); !errors.Is(err, errors.ErrReplayDetected) {
+		t.Errorf("Check() on other replica error = %v, want ErrReplayDetected", err)
+	}
+
+	ttl := client.TTL(ctx, prefix+"nonce-1").Val()
+	if ttl <= 0 || ttl > time.Second {
+		t.Errorf("TTL = %v, want the window", ttl)
+	}
+
+	time.Sleep(1100 * time.Millisecond)
+	if err := replicaB.Check(ctx, "nonce-1"); err != nil {
+		t.Errorf("Check() after window error = %v", err)
+	}
+}
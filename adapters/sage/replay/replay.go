@@ -0,0 +1,94 @@
+// Copyright (C) 2025 sage-x-project
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with this program. If not, see <https://www.gnu.org/licenses/>.
+
+// SPDX-License-Identifier: LGPL-3.0-or-later
+
+package replay
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+
+	"github.com/sage-x-project/sage-adk/pkg/errors"
+)
+
+// DefaultMaxClockSkew is the clock skew SAGE accepts in message timestamps.
+const DefaultMaxClockSkew = 5 * time.Minute
+
+// Guard rejects nonces that were already used.
+//
+// Implementations must be safe for concurrent use. Callers should treat any
+// error as a rejection, so a guard whose backend is unreachable fails closed.
+type Guard interface {
+	// Check records nonce and returns ErrReplayDetected if it was recorded
+	// before and has not expired yet.
+	Check(ctx context.Context, nonce string) error
+}
+
+// Window returns how long nonces must be remembered when timestamps may be
+// off by up to maxClockSkew in either direction: a message stamped
+// maxClockSkew in the future stays acceptable for twice that long.
+func Window(maxClockSkew time.Duration) time.Duration {
+	return 2 * maxClockSkew
+}
+
+// Open creates the guard a store setting names: "" or "memory" for an
+// in-process guard, or a redis:// or rediss:// URL for a guard shared by
+// every replica using the same Redis.
+func Open(store string, window time.Duration) (Guard, error) {
+	switch {
+	case store == "" || store == "memory":
+		return NewMemory(window), nil
+
+	case strings.HasPrefix(store, "redis://") || strings.HasPrefix(store, "rediss://"):
+		opts, err := redis.ParseURL(store)
+		if err != nil {
+			return nil, errors.ErrInvalidValue.
+				WithMessage("invalid nonce store URL").
+				WithDetail("error", err.Error())
+		}
+
+		client := redis.NewClient(opts)
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := client.Ping(ctx).Err(); err != nil {
+			client.Close()
+			return nil, errors.ErrStorageConnection.
+				WithMessage("failed to connect to nonce store").
+				WithDetail("addr", opts.Addr).
+				WithDetail("error", err.Error())
+		}
+		return NewRedis(client, &RedisConfig{Window: window}), nil
+
+	default:
+		return nil, errors.ErrInvalidValue.
+			WithMessage("unsupported nonce store (use memory or a redis:// URL)").
+			WithDetail("store", store)
+	}
+}
+
+func replayed(nonce string) error {
+	return errors.ErrReplayDetected.WithDetail("nonce", nonce)
+}
+
+func validateNonce(nonce string) error {
+	if nonce == "" {
+		return errors.ErrMissingField.WithDetail("field", "nonce")
+	}
+	return nil
+}
@@ -0,0 +1,120 @@
+// Copyright (C) 2025 sage-x-project
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with this program. If not, see <https://www.gnu.org/licenses/>.
+
+// SPDX-License-Identifier: LGPL-3.0-or-later
+
+package replay
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/sage-x-project/sage-adk/pkg/errors"
+)
+
+func TestMemory_Check(t *testing.T) {
+	ctx := context.Background()
+	guard := NewMemory(time.Minute)
+
+	if err := guard.Check(ctx, "nonce-1"); err != nil {
+		t.Fatalf("Check() error = %v", err)
+	}
+	if err := guard.Check(ctx, "nonce-1"); !errors.Is(err, errors.ErrReplayDetected) {
+		t.Errorf("Check() replay error = %v, want ErrReplayDetected", err)
+	}
+	if err := guard.Check(ctx, "nonce-2"); err != nil {
+		t.Errorf("Check() other nonce error = %v", err)
+	}
+	if err := guard.Check(ctx, ""); !errors.Is(err, errors.ErrMissingField) {
+		t.Errorf("Check() empty nonce error = %v, want ErrMissingField", err)
+	}
+}
+
+func TestMemory_Expiry(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+	guard := NewMemory(time.Minute)
+	guard.now = func() time.Time { return now }
+
+	for i := 0; i < 1000; i++ {
+		guard.Check(ctx, fmt.Sprintf("nonce-%d", i))
+	}
+
+	// Nonces are never evicted while the window lasts, however many arrive
+	now = now.Add(59 * time.Second)
+	if err := guard.Check(ctx, "nonce-0"); !errors.Is(err, errors.ErrReplayDetected) {
+		t.Errorf("Check() within window error = %v, want ErrReplayDetected", err)
+	}
+
+	// Once the window has passed they are accepted again and swept
+	now = now.Add(time.Minute)
+	if err := guard.Check(ctx, "nonce-1"); err != nil {
+		t.Errorf("Check() after window error = %v", err)
+	}
+	if guard.Len() != 1 {
+		t.Errorf("Len() = %d, want expired nonces swept", guard.Len())
+	}
+}
+
+func TestMemory_Concurrent(t *testing.T) {
+	ctx := context.Background()
+	guard := NewMemory(time.Minute)
+
+	var accepted int32
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if guard.Check(ctx, "shared") == nil {
+				atomic.AddInt32(&accepted, 1)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if accepted != 1 {
+		t.Errorf("accepted %d times, want exactly once", accepted)
+	}
+}
+
+func TestOpen(t *testing.T) {
+	for _, store := range []string{"", "memory"} {
+		guard, err := Open(store, time.Minute)
+		if err != nil {
+			t.Fatalf("Open(%q) error = %v", store, err)
+		}
+		if _, ok := guard.(*Memory); !ok {
+			t.Errorf("Open(%q) = %T, want *Memory", store, guard)
+		}
+	}
+
+	if _, err := Open("memcached://localhost", time.Minute); !errors.Is(err, errors.ErrInvalidValue) {
+		t.Errorf("Open() unsupported store error = %v, want ErrInvalidValue", err)
+	}
+	if _, err := Open("redis://localhost:1/0", time.Minute); !errors.Is(err, errors.ErrStorageConnection) {
+		t.Errorf("Open() unreachable Redis error = %v, want ErrStorageConnection", err)
+	}
+}
+
+func TestWindow(t *testing.T) {
+	if got := Window(5 * time.Minute); got != 10*time.Minute {
+		t.Errorf("Window() = %v, want 10m", got)
+	}
+}
@@ -0,0 +1,200 @@
+// Copyright (C) 2025 sage-x-project
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with this program. If not, see <https://www.gnu.org/licenses/>.
+
+// SPDX-License-Identifier: LGPL-3.0-or-later
+
+package sage
+
+import (
+	"context"
+	"crypto/ed25519"
+	"time"
+
+	"github.com/sage-x-project/sage-adk/adapters/sage/sessionstore"
+	"github.com/sage-x-project/sage-adk/pkg/errors"
+)
+
+// SessionResume asks the peer to continue a session restored from a
+// session store. Each side names the session by its own ID, so the peer
+// finds its session by DID and Proof shows both hold the same session key.
+type SessionResume struct {
+	Phase     HandshakePhase    `json:"phase"`
+	FromDID   string            `json:"from_did"`
+	ToDID     string            `json:"to_did"`
+	Nonce     string            `json:"nonce"`
+	Proof     []byte            `json:"proof"`
+	Signature SignatureEnvelope `json:"signature"`
+	Timestamp time.Time         `json:"timestamp"`
+}
+
+// SessionResumed confirms a resumed session. Proof covers both nonces and
+// shows the peer holds the same session key.
+type SessionResumed struct {
+	Phase       HandshakePhase    `json:"phase"`
+	FromDID     string            `json:"from_did"`
+	ToDID       string            `json:"to_did"`
+	ResumeNonce string            `json:"resume_nonce"`
+	Nonce       string            `json:"nonce"`
+	Proof       []byte            `json:"proof"`
+	Signature   SignatureEnvelope `json:"signature"`
+	Timestamp   time.Time         `json:"timestamp"`
+}
+
+// SetSessionStore persists established sessions in store, so that they can
+// be resumed after either side restarts instead of handshaking again.
+func (tm *TransportManager) SetSessionStore(store sessionstore.Store) {
+	tm.sessionManager.SetStore(tm.localDID, store)
+}
+
+// NeedsResume reports whether the session with remoteDID was restored from
+// the session store and the peer has not confirmed it yet.
+func (tm *TransportManager) NeedsResume(remoteDID string) bool {
+	session, err := tm.sessionManager.GetByDID(remoteDID)
+	return err == nil && session.IsActive() && session.Resumed
+}
+
+// ResumeSession creates a request to continue the restored session with
+// remoteDID.
+func (tm *TransportManager) ResumeSession(ctx context.Context, remoteDID string) (*SessionResume, error) {
+	session, err := tm.sessionManager.GetByDID(remoteDID)
+	if err != nil {
+		return nil, err
+	}
+	if !session.IsActive() {
+		return nil, errors.ErrOperationFailed.
+			WithMessage("session is not active").
+			WithDetail("remote_did", remoteDID)
+	}
+
+	nonce, err := GenerateNonce()
+	if err != nil {
+		return nil, errors.ErrOperationFailed.
+			WithMessage("failed to generate nonce").
+			WithDetail("error", err.Error())
+	}
+
+	resume := &SessionResume{
+		Phase:     PhaseResume,
+		FromDID:   tm.localDID,
+		ToDID:     remoteDID,
+		Nonce:     nonce,
+		Proof:     sessionstore.Proof(session.SessionKey, sessionstore.LabelResume, tm.localDID, remoteDID, nonce),
+		Timestamp: time.Now(),
+	}
+	if err := tm.signResumption(resume, &resume.Signature); err != nil {
+		return nil, err
+	}
+	return resume, nil
+}
+
+// HandleResume verifies a peer's request to continue a session and answers
+// with proof that this side holds the session too. Unknown sessions fail
+// with ErrNotFound and sessions with another key with ErrSignatureInvalid,
+// so the peer handshakes again.
+func (tm *TransportManager) HandleResume(ctx context.Context, resume *SessionResume, peerPublicKey ed25519.PublicKey) (*SessionResumed, error) {
+	if err := tm.verifyResumption(resume, &resume.Signature, resume.Timestamp, peerPublicKey); err != nil {
+		return nil, err
+	}
+
+	if resume.ToDID != tm.localDID {
+		return nil, errors.ErrUnauthorized.
+			WithMessage("resumption is addressed to another agent").
+			WithDetail("to_did", resume.ToDID)
+	}
+	session, err := tm.sessionManager.GetByDID(resume.FromDID)
+	if err != nil || !session.IsActive() {
+		return nil, errors.ErrNotFound.
+			WithMessage("no session to resume").
+			WithDetail("remote_did", resume.FromDID)
+	}
+	if !sessionstore.VerifyProof(session.SessionKey, resume.Proof, sessionstore.LabelResume, resume.FromDID, resume.ToDID, resume.Nonce) {
+		return nil, errors.ErrSignatureInvalid.WithMessage("invalid session resumption proof")
+	}
+
+	nonce, err := GenerateNonce()
+	if err != nil {
+		return nil, errors.ErrOperationFailed.
+			WithMessage("failed to generate nonce").
+			WithDetail("error", err.Error())
+	}
+
+	resumed := &SessionResumed{
+		Phase:       PhaseResumed,
+		FromDID:     tm.localDID,
+		ToDID:       resume.FromDID,
+		ResumeNonce: resume.Nonce,
+		Nonce:       nonce,
+		Proof:       sessionstore.Proof(session.SessionKey, sessionstore.LabelResumed, tm.localDID, resume.FromDID, resume.Nonce, nonce),
+		Timestamp:   time.Now(),
+	}
+	if err := tm.signResumption(resumed, &resumed.Signature); err != nil {
+		return nil, err
+	}
+
+	session.Resumed = false
+	if err := tm.sessionManager.Update(session); err != nil {
+		return nil, err
+	}
+	return resumed, nil
+}
+
+// CompleteResume verifies the peer's confirmation of a resumed session,
+// after which the session is used as if it had never been interrupted.
+func (tm *TransportManager) CompleteResume(ctx context.Context, resume *SessionResume, resumed *SessionResumed, peerPublicKey ed25519.PublicKey) error {
+	if err := tm.verifyResumption(resumed, &resumed.Signature, resumed.Timestamp, peerPublicKey); err != nil {
+		return err
+	}
+
+	if resumed.FromDID != resume.ToDID || resumed.ToDID != tm.localDID || resumed.ResumeNonce != resume.Nonce {
+		return errors.ErrUnauthorized.
+			WithMessage("resumption confirmed for another session").
+			WithDetail("remote_did", resume.ToDID)
+	}
+	session, err := tm.sessionManager.GetByDID(resume.ToDID)
+	if err != nil {
+		return err
+	}
+	if !sessionstore.VerifyProof(session.SessionKey, resumed.Proof, sessionstore.LabelResumed, resumed.FromDID, resumed.ToDID, resume.Nonce, resumed.Nonce) {
+		return errors.ErrSignatureInvalid.WithMessage("invalid session resumption proof")
+	}
+
+	session.Resumed = false
+	return tm.sessionManager.Update(session)
+}
+
+// signResumption signs a resumption message into signature.
+func (tm *TransportManager) signResumption(message interface{}, signature *SignatureEnvelope) error {
+	privateKey, keyID, err := tm.handshakeManager.currentKey()
+	if err != nil {
+		return err
+	}
+	signed, err := tm.signingManager.SignMessage(message, privateKey, keyID)
+	if err != nil {
+		return err
+	}
+	*signature = *signed
+	return nil
+}
+
+// verifyResumption checks the signature and timestamp of a resumption
+// message.
+func (tm *TransportManager) verifyResumption(message interface{}, signature *SignatureEnvelope, timestamp time.Time, peerPublicKey ed25519.PublicKey) error {
+	if err := tm.signingManager.VerifySignature(message, signature, peerPublicKey); err != nil {
+		return errors.ErrSignatureInvalid.
+			WithMessage("resumption signature verification failed").
+			WithDetail("error", err.Error())
+	}
+	return tm.signingManager.ValidateTimestamp(timestamp, tm.config.MaxClockSkew)
+}
@@ -18,15 +18,20 @@
 package sage
 
 import (
+	"context"
 	"crypto/rand"
 	"encoding/hex"
 	"fmt"
 	"sync"
 	"time"
 
+	"github.com/sage-x-project/sage-adk/adapters/sage/sessionstore"
 	"github.com/sage-x-project/sage-adk/pkg/errors"
 )
 
+// sessionStoreTimeout bounds session store calls made on the message path.
+const sessionStoreTimeout = 5 * time.Second
+
 // SessionManager manages active SAGE sessions.
 type SessionManager struct {
 	// Session storage
@@ -38,6 +43,10 @@ type SessionManager struct {
 	sessionTTL      time.Duration
 	cleanupInterval time.Duration
 
+	// Persistence of established sessions (optional)
+	store    sessionstore.Store
+	localDID string
+
 	// Lifecycle
 	stopChan chan struct{}
 	wg       sync.WaitGroup
@@ -104,8 +113,21 @@ func (sm *SessionManager) Create(localDID, remoteDID string) (*Session, error) {
 	return session, nil
 }
 
-// Get retrieves a session by ID.
+// Get retrieves a session by ID. Sessions not in memory are restored from
+// the session store, if one is set.
 func (sm *SessionManager) Get(sessionID string) (*Session, error) {
+	session, err := sm.get(sessionID)
+	if errors.Is(err, errors.ErrNotFound) {
+		if restored := sm.restore(func(ctx context.Context, store sessionstore.Store) (*sessionstore.Record, error) {
+			return store.Load(ctx, sessionID)
+		}); restored != nil {
+			return restored, nil
+		}
+	}
+	return session, err
+}
+
+func (sm *SessionManager) get(sessionID string) (*Session, error) {
 	sm.mu.RLock()
 	defer sm.mu.RUnlock()
 
@@ -125,8 +147,21 @@ func (sm *SessionManager) Get(sessionID string) (*Session, error) {
 	return session, nil
 }
 
-// GetByDID retrieves a session by remote DID.
+// GetByDID retrieves a session by remote DID. Sessions not in memory are
+// restored from the session store, if one is set.
 func (sm *SessionManager) GetByDID(remoteDID string) (*Session, error) {
+	session, err := sm.getByDID(remoteDID)
+	if errors.Is(err, errors.ErrNotFound) {
+		if restored := sm.restore(func(ctx context.Context, store sessionstore.Store) (*sessionstore.Record, error) {
+			return store.LoadByDID(ctx, sm.localDID, remoteDID)
+		}); restored != nil {
+			return restored, nil
+		}
+	}
+	return session, err
+}
+
+func (sm *SessionManager) getByDID(remoteDID string) (*Session, error) {
 	sm.mu.RLock()
 	defer sm.mu.RUnlock()
 
@@ -153,17 +188,17 @@ func (sm *SessionManager) GetByDID(remoteDID string) (*Session, error) {
 	return session, nil
 }
 
-// Update updates an existing session.
+// Update updates an existing session. Active sessions are also saved to
+// the session store, if one is set.
 func (sm *SessionManager) Update(session *Session) error {
 	if session == nil {
 		return errors.ErrInvalidInput.WithMessage("session is nil")
 	}
 
 	sm.mu.Lock()
-	defer sm.mu.Unlock()
-
 	existing, exists := sm.sessions[session.ID]
 	if !exists {
+		sm.mu.Unlock()
 		return errors.ErrNotFound.
 			WithMessage("session not found").
 			WithDetail("session_id", session.ID)
@@ -173,16 +208,34 @@ func (sm *SessionManager) Update(session *Session) error {
 	*existing = *session
 	existing.UpdateActivity()
 
+	store := sm.store
+	var record *sessionstore.Record
+	if store != nil && existing.Status == SessionActive {
+		record = sessionRecord(existing)
+	}
+	sm.mu.Unlock()
+
+	if record == nil {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), sessionStoreTimeout)
+	defer cancel()
+	if err := store.Save(ctx, record); err != nil {
+		return errors.ErrStorageConnection.
+			WithMessage("failed to persist session").
+			WithDetail("session_id", record.ID).
+			WithDetail("error", err.Error())
+	}
 	return nil
 }
 
-// Delete removes a session.
+// Delete removes a session, including from the session store.
 func (sm *SessionManager) Delete(sessionID string) error {
 	sm.mu.Lock()
-	defer sm.mu.Unlock()
-
 	session, exists := sm.sessions[sessionID]
 	if !exists {
+		sm.mu.Unlock()
 		return errors.ErrNotFound.
 			WithMessage("session not found").
 			WithDetail("session_id", sessionID)
@@ -191,10 +244,115 @@ func (sm *SessionManager) Delete(sessionID string) error {
 	// Remove from indexes
 	delete(sm.sessions, sessionID)
 	delete(sm.didIndex, session.RemoteDID)
+	store := sm.store
+	sm.mu.Unlock()
 
+	if store == nil {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), sessionStoreTimeout)
+	defer cancel()
+	if err := store.Delete(ctx, sessionID); err != nil {
+		return errors.ErrStorageConnection.
+			WithMessage("failed to delete persisted session").
+			WithDetail("session_id", sessionID).
+			WithDetail("error", err.Error())
+	}
 	return nil
 }
 
+// SetStore persists the established sessions of localDID in store, so they
+// can be resumed after a restart. Sessions restored from the store are
+// marked Resumed.
+func (sm *SessionManager) SetStore(localDID string, store sessionstore.Store) {
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
+	sm.localDID = localDID
+	sm.store = store
+}
+
+// Forget drops all sessions from memory. Unlike Delete, it keeps persisted
+// sessions in the store, so they can be resumed by the next process.
+func (sm *SessionManager) Forget() {
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
+	sm.sessions = make(map[string]*Session)
+	sm.didIndex = make(map[string]string)
+}
+
+// restore loads a persisted session into memory with load. It returns nil
+// if there is no store or no live session in it for this agent. Sessions
+// saved without message key state cannot be continued and are ignored.
+func (sm *SessionManager) restore(load func(ctx context.Context, store sessionstore.Store) (*sessionstore.Record, error)) *Session {
+	sm.mu.RLock()
+	store, localDID := sm.store, sm.localDID
+	sm.mu.RUnlock()
+	if store == nil {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), sessionStoreTimeout)
+	defer cancel()
+	record, err := load(ctx, store)
+	if err != nil || record.LocalDID != localDID || record.Ratchet == nil {
+		return nil
+	}
+
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
+
+	// Another caller may have restored or re-established it meanwhile
+	if existingID, exists := sm.didIndex[record.RemoteDID]; exists {
+		if existing, ok := sm.sessions[existingID]; ok && existing.IsActive() {
+			if existing.ID != record.ID {
+				return nil
+			}
+			return existing
+		}
+	}
+
+	session := sessionFromRecord(record)
+	sm.sessions[session.ID] = session
+	sm.didIndex[session.RemoteDID] = session.ID
+	return session
+}
+
+// sessionRecord converts an established session to its persisted form.
+func sessionRecord(session *Session) *sessionstore.Record {
+	return &sessionstore.Record{
+		ID:               session.ID,
+		LocalDID:         session.LocalDID,
+		RemoteDID:        session.RemoteDID,
+		SessionKey:       append([]byte(nil), session.SessionKey...),
+		CreatedAt:        session.CreatedAt,
+		ExpiresAt:        session.ExpiresAt,
+		LastActive:       session.LastActive,
+		MessagesSent:     session.MessagesSent,
+		MessagesReceived: session.MessagesReceived,
+		Ratchet:          session.Ratchet.Clone(),
+	}
+}
+
+// sessionFromRecord restores an active session from its persisted form.
+func sessionFromRecord(record *sessionstore.Record) *Session {
+	return &Session{
+		ID:               record.ID,
+		LocalDID:         record.LocalDID,
+		RemoteDID:        record.RemoteDID,
+		SessionKey:       record.SessionKey,
+		CreatedAt:        record.CreatedAt,
+		ExpiresAt:        record.ExpiresAt,
+		LastActive:       record.LastActive,
+		Status:           SessionActive,
+		MessagesSent:     record.MessagesSent,
+		MessagesReceived: record.MessagesReceived,
+		Ratchet:          record.Ratchet,
+		Resumed:          true,
+		Metadata:         make(map[string]interface{}),
+	}
+}
+
 // List returns all active sessions.
 func (sm *SessionManager) List() []*Session {
 	sm.mu.RLock()
@@ -18,8 +18,12 @@
 package sage
 
 import (
+	"context"
 	"testing"
 	"time"
+
+	"github.com/sage-x-project/sage-adk/adapters/sage/sessionstore"
+	"github.com/sage-x-project/sage-adk/pkg/errors"
 )
 
 func TestNewSessionManager(t *testing.T) {
@@ -288,6 +292,54 @@ func TestSessionManager_ReuseActiveSession(t *testing.T) {
 	}
 }
 
+func TestSessionManager_Store(t *testing.T) {
+	sm := NewSessionManager(time.Hour, time.Minute)
+	defer sm.Stop()
+
+	store := sessionstore.NewMemory()
+	sm.SetStore("did:sage:alice", store)
+
+	session, err := sm.Create("did:sage:alice", "did:sage:bob")
+	if err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+
+	// Sessions are persisted once established.
+	if _, err := store.Load(context.Background(), session.ID); !errors.Is(err, errors.ErrNotFound) {
+		t.Fatalf("establishing session should not be persisted, Load() error = %v", err)
+	}
+	session.SessionKey = make([]byte, 32)
+	if err := activateSession(session); err != nil {
+		t.Fatalf("activateSession failed: %v", err)
+	}
+	session.MessagesSent = 3
+	if err := sm.Update(session); err != nil {
+		t.Fatalf("Update failed: %v", err)
+	}
+
+	// After a restart, the session is restored from the store.
+	sm.Forget()
+	restored, err := sm.GetByDID("did:sage:bob")
+	if err != nil {
+		t.Fatalf("GetByDID after Forget failed: %v", err)
+	}
+	if restored.ID != session.ID || !restored.Resumed || !restored.IsActive() || restored.MessagesSent != 3 || restored.Ratchet == nil {
+		t.Errorf("restored session = %+v", restored)
+	}
+	if _, err := sm.Get(session.ID); err != nil {
+		t.Errorf("Get after restore failed: %v", err)
+	}
+
+	// Delete removes the persisted session too.
+	if err := sm.Delete(session.ID); err != nil {
+		t.Fatalf("Delete failed: %v", err)
+	}
+	sm.Forget()
+	if _, err := sm.Get(session.ID); !errors.Is(err, errors.ErrNotFound) {
+		t.Errorf("Get after Delete error = %v, want ErrNotFound", err)
+	}
+}
+
 func TestSession_IsActive(t *testing.T) {
 	now := time.Now()
 
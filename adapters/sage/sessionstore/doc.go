@@ -0,0 +1,33 @@
+// Copyright (C) 2025 sage-x-project
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with this program. If not, see <https://www.gnu.org/licenses/>.
+
+// SPDX-License-Identifier: LGPL-3.0-or-later
+// Package sessionstore persists established SAGE sessions.
+//
+// A Store keeps the session key, expiry and counters of each session, so
+// an agent can continue its sessions after a restart instead of making
+// every peer handshake again. Memory keeps them in-process; StorageStore
+// keeps them in any storage.Storage backend, sealed with XChaCha20-Poly1305
+// under a local key-encryption key:
+//
+//	kek, err := sessionstore.LoadOrCreateKEK("/var/lib/agent/session.kek")
+//	store, err := sessionstore.NewStorageStore(redisStorage, kek)
+//
+// Replicas that share the backend and KEK share their sessions too.
+//
+// Before trusting a restored session, peers exchange resumption proofs
+// (see Proof): HMACs over fresh nonces keyed from the session key, which
+// show that both sides still hold the same key.
+package sessionstore
@@ -0,0 +1,55 @@
+// Copyright (C) 2025 sage-x-project
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with this program. If not, see <https://www.gnu.org/licenses/>.
+
+// SPDX-License-Identifier: LGPL-3.0-or-later
+
+package sessionstore
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/binary"
+)
+
+// Labels of resumption proofs. The initiator proves it holds the session
+// key with LabelResume; the peer answers with LabelResumed.
+const (
+	LabelResume  = "sage-session-resume"
+	LabelResumed = "sage-session-resumed"
+)
+
+// Proof returns an HMAC-SHA256 proof of possession of sessionKey over
+// label and fields. The MAC key is derived from the session key, so the
+// proof reveals nothing about the key that encrypts messages.
+func Proof(sessionKey []byte, label string, fields ...string) []byte {
+	derive := hmac.New(sha256.New, sessionKey)
+	derive.Write([]byte("sage-resumption-key"))
+	mac := hmac.New(sha256.New, derive.Sum(nil))
+
+	// Length-prefix every field so their boundaries are unambiguous
+	var length [4]byte
+	for _, field := range append([]string{label}, fields...) {
+		binary.BigEndian.PutUint32(length[:], uint32(len(field)))
+		mac.Write(length[:])
+		mac.Write([]byte(field))
+	}
+	return mac.Sum(nil)
+}
+
+// VerifyProof reports whether proof is Proof(sessionKey, label, fields...),
+// in constant time.
+func VerifyProof(sessionKey, proof []byte, label string, fields ...string) bool {
+	return hmac.Equal(proof, Proof(sessionKey, label, fields...))
+}
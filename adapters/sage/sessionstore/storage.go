@@ -0,0 +1,353 @@
+// Copyright (C) 2025 sage-x-project
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with this program. If not, see <https://www.gnu.org/licenses/>.
+
+// SPDX-License-Identifier: LGPL-3.0-or-later
+
+package sessionstore
+
+import (
+	"context"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/crypto/chacha20poly1305"
+
+	"github.com/sage-x-project/sage-adk/pkg/errors"
+	"github.com/sage-x-project/sage-adk/storage"
+)
+
+// DefaultNamespace is the storage namespace of sealed sessions. Their DID
+// index lives in DefaultNamespace + "_index".
+const DefaultNamespace = "sage_sessions"
+
+// KEKSize is the size of a key-encryption key.
+const KEKSize = chacha20poly1305.KeySize
+
+// sealedVersion is the format version of sealed records.
+const sealedVersion = 1
+
+// sealedRecord is a Record as kept in storage. Only the IDs needed to find
+// it are in the clear; the session key and counters are sealed.
+type sealedRecord struct {
+	Version    int    `json:"version"`
+	ID         string `json:"id"`
+	LocalDID   string `json:"local_did"`
+	RemoteDID  string `json:"remote_did"`
+	Nonce      []byte `json:"nonce"`
+	Ciphertext []byte `json:"ciphertext"`
+}
+
+// StorageStore keeps sessions in a storage.Storage backend, such as Redis
+// or PostgreSQL, with each record encrypted under a local key-encryption
+// key (KEK). Anyone with access to the backend but not the KEK learns which
+// peers have sessions, but not their keys.
+type StorageStore struct {
+	backend   storage.Storage
+	aead      cipher.AEAD
+	namespace string
+	mu        sync.Mutex
+}
+
+// NewStorageStore creates a session store on backend, sealing records with
+// kek, which must be KEKSize bytes.
+func NewStorageStore(backend storage.Storage, kek []byte) (*StorageStore, error) {
+	if backend == nil {
+		return nil, errors.ErrInvalidInput.WithMessage("storage backend is required")
+	}
+	if len(kek) != KEKSize {
+		return nil, errors.ErrInvalidInput.
+			WithMessage("invalid key-encryption key size").
+			WithDetail("size", len(kek))
+	}
+
+	aead, err := chacha20poly1305.NewX(kek)
+	if err != nil {
+		return nil, errors.ErrOperationFailed.
+			WithMessage("failed to initialize cipher").
+			WithDetail("error", err.Error())
+	}
+
+	return &StorageStore{
+		backend:   backend,
+		aead:      aead,
+		namespace: DefaultNamespace,
+	}, nil
+}
+
+// Save implements Store.
+func (s *StorageStore) Save(ctx context.Context, record *Record) error {
+	if err := validate(record); err != nil {
+		return err
+	}
+
+	plaintext, err := json.Marshal(record)
+	if err != nil {
+		return errors.ErrOperationFailed.
+			WithMessage("failed to encode session").
+			WithDetail("error", err.Error())
+	}
+
+	sealed := sealedRecord{
+		Version:   sealedVersion,
+		ID:        record.ID,
+		LocalDID:  record.LocalDID,
+		RemoteDID: record.RemoteDID,
+		Nonce:     make([]byte, s.aead.NonceSize()),
+	}
+	if _, err := rand.Read(sealed.Nonce); err != nil {
+		return errors.ErrOperationFailed.WithMessage("failed to generate nonce")
+	}
+	sealed.Ciphertext = s.aead.Seal(nil, sealed.Nonce, plaintext, sealed.additionalData())
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	key := pairKey(record.LocalDID, record.RemoteDID)
+	if old, err := s.indexed(ctx, key); err == nil && old != record.ID {
+		s.backend.Delete(ctx, s.namespace, old)
+	}
+
+	if err := s.backend.Store(ctx, s.namespace, record.ID, sealed); err != nil {
+		return errors.ErrStorageConnection.
+			WithMessage("failed to store session").
+			WithDetail("error", err.Error())
+	}
+	if err := s.backend.Store(ctx, s.indexNamespace(), key, record.ID); err != nil {
+		return errors.ErrStorageConnection.
+			WithMessage("failed to index session").
+			WithDetail("error", err.Error())
+	}
+	return nil
+}
+
+// Load implements Store. Expired sessions are removed.
+func (s *StorageStore) Load(ctx context.Context, id string) (*Record, error) {
+	item, err := s.backend.Get(ctx, s.namespace, id)
+	if isNotFound(err) {
+		return nil, notFound(id)
+	}
+	if err != nil {
+		return nil, errors.ErrStorageConnection.
+			WithMessage("failed to load session").
+			WithDetail("error", err.Error())
+	}
+
+	sealed, err := decodeSealed(item)
+	if err != nil {
+		return nil, err
+	}
+	if sealed.ID != id {
+		return nil, errors.ErrInvalidFormat.
+			WithMessage("stored session does not match its key").
+			WithDetail("session_id", id)
+	}
+
+	record, err := s.open(sealed)
+	if err != nil {
+		return nil, err
+	}
+	if record.Expired(time.Now()) {
+		s.Delete(ctx, id)
+		return nil, notFound(id)
+	}
+	return record, nil
+}
+
+// LoadByDID implements Store.
+func (s *StorageStore) LoadByDID(ctx context.Context, localDID, remoteDID string) (*Record, error) {
+	id, err := s.indexed(ctx, pairKey(localDID, remoteDID))
+	if err != nil {
+		return nil, err
+	}
+
+	record, err := s.Load(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if record.LocalDID != localDID || record.RemoteDID != remoteDID {
+		return nil, errors.ErrNotFound.
+			WithMessage("no stored session for DID").
+			WithDetail("remote_did", remoteDID)
+	}
+	return record, nil
+}
+
+// Delete implements Store.
+func (s *StorageStore) Delete(ctx context.Context, id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	item, err := s.backend.Get(ctx, s.namespace, id)
+	if isNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		return errors.ErrStorageConnection.
+			WithMessage("failed to delete session").
+			WithDetail("error", err.Error())
+	}
+
+	if sealed, err := decodeSealed(item); err == nil {
+		key := pairKey(sealed.LocalDID, sealed.RemoteDID)
+		if indexed, err := s.indexed(ctx, key); err == nil && indexed == id {
+			s.backend.Delete(ctx, s.indexNamespace(), key)
+		}
+	}
+
+	if err := s.backend.Delete(ctx, s.namespace, id); err != nil && !isNotFound(err) {
+		return errors.ErrStorageConnection.
+			WithMessage("failed to delete session").
+			WithDetail("error", err.Error())
+	}
+	return nil
+}
+
+// open decrypts a sealed record.
+func (s *StorageStore) open(sealed *sealedRecord) (*Record, error) {
+	if sealed.Version != sealedVersion {
+		return nil, errors.ErrInvalidFormat.
+			WithMessage("unsupported session record version").
+			WithDetail("version", sealed.Version)
+	}
+	if len(sealed.Nonce) != s.aead.NonceSize() {
+		return nil, errors.ErrInvalidFormat.WithMessage("invalid session record nonce")
+	}
+
+	plaintext, err := s.aead.Open(nil, sealed.Nonce, sealed.Ciphertext, sealed.additionalData())
+	if err != nil {
+		return nil, errors.ErrInvalidCredentials.
+			WithMessage("failed to decrypt session; wrong key-encryption key or tampered record").
+			WithDetail("session_id", sealed.ID)
+	}
+
+	var record Record
+	if err := json.Unmarshal(plaintext, &record); err != nil {
+		return nil, errors.ErrInvalidFormat.
+			WithMessage("invalid session record").
+			WithDetail("error", err.Error())
+	}
+	return &record, nil
+}
+
+// indexed returns the ID of the session indexed under key.
+func (s *StorageStore) indexed(ctx context.Context, key string) (string, error) {
+	item, err := s.backend.Get(ctx, s.indexNamespace(), key)
+	if isNotFound(err) {
+		return "", errors.ErrNotFound.WithMessage("no stored session for DID")
+	}
+	if err != nil {
+		return "", errors.ErrStorageConnection.
+			WithMessage("failed to load session index").
+			WithDetail("error", err.Error())
+	}
+
+	id, ok := item.(string)
+	if !ok {
+		return "", errors.ErrInvalidFormat.WithMessage("invalid session index entry")
+	}
+	return id, nil
+}
+
+func (s *StorageStore) indexNamespace() string {
+	return s.namespace + "_index"
+}
+
+// additionalData binds the ciphertext to the session and its peers, so a
+// sealed record cannot be moved to another key.
+func (r *sealedRecord) additionalData() []byte {
+	return []byte(strings.Join([]string{"sage-session", r.ID, r.LocalDID, r.RemoteDID}, "\x00"))
+}
+
+// decodeSealed converts a stored item back into a sealedRecord. Backends
+// that serialize values return generic JSON data rather than the struct.
+func decodeSealed(item interface{}) (*sealedRecord, error) {
+	if sealed, ok := item.(sealedRecord); ok {
+		return &sealed, nil
+	}
+
+	data, err := json.Marshal(item)
+	if err != nil {
+		return nil, errors.ErrInvalidFormat.WithMessage("invalid session record")
+	}
+	var sealed sealedRecord
+	if err := json.Unmarshal(data, &sealed); err != nil {
+		return nil, errors.ErrInvalidFormat.
+			WithMessage("invalid session record").
+			WithDetail("error", err.Error())
+	}
+	return &sealed, nil
+}
+
+// isNotFound reports whether err means a missing storage key.
+func isNotFound(err error) bool {
+	return err == storage.ErrNotFound || errors.Is(err, storage.ErrNotFound) || errors.Is(err, errors.ErrNotFound)
+}
+
+// LoadKEK reads a hex-encoded key-encryption key from path.
+func LoadKEK(path string) ([]byte, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	kek, err := hex.DecodeString(strings.TrimSpace(string(data)))
+	if err != nil || len(kek) != KEKSize {
+		return nil, errors.ErrInvalidFormat.
+			WithMessage("key-encryption key must be 32 hex-encoded bytes").
+			WithDetail("path", path)
+	}
+	return kek, nil
+}
+
+// LoadOrCreateKEK reads the key-encryption key at path, generating one
+// readable only by its owner if the file does not exist.
+func LoadOrCreateKEK(path string) ([]byte, error) {
+	kek, err := LoadKEK(path)
+	if !os.IsNotExist(err) {
+		return kek, err
+	}
+
+	kek = make([]byte, KEKSize)
+	if _, err := rand.Read(kek); err != nil {
+		return nil, errors.ErrOperationFailed.WithMessage("failed to generate key-encryption key")
+	}
+
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o600)
+	if os.IsExist(err) {
+		// Another process created it first
+		return LoadKEK(path)
+	}
+	if err != nil {
+		return nil, errors.ErrStorageConnection.
+			WithMessage("failed to create key-encryption key").
+			WithDetail("path", path).
+			WithDetail("error", err.Error())
+	}
+	defer file.Close()
+
+	if _, err := file.WriteString(hex.EncodeToString(kek) + "\n"); err != nil {
+		return nil, errors.ErrStorageConnection.
+			WithMessage("failed to write key-encryption key").
+			WithDetail("path", path).
+			WithDetail("error", err.Error())
+	}
+	return kek, nil
+}
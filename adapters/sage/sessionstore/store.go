@@ -0,0 +1,181 @@
+// Copyright (C) 2025 sage-x-project
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with this program. If not, see <https://www.gnu.org/licenses/>.
+
+// SPDX-License-Identifier: LGPL-3.0-or-later
+
+package sessionstore
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/sage-x-project/sage-adk/adapters/sage/ratchet"
+	"github.com/sage-x-project/sage-adk/pkg/errors"
+)
+
+// Record is the persisted state of an established SAGE session. SessionKey
+// and Ratchet are secret.
+type Record struct {
+	ID         string    `json:"id"`
+	LocalDID   string    `json:"local_did"`
+	RemoteDID  string    `json:"remote_did"`
+	SessionKey []byte    `json:"session_key"`
+	CreatedAt  time.Time `json:"created_at"`
+	ExpiresAt  time.Time `json:"expires_at"`
+	LastActive time.Time `json:"last_active"`
+
+	MessagesSent     int64 `json:"messages_sent"`
+	MessagesReceived int64 `json:"messages_received"`
+
+	// Ratchet is the session's message key schedule.
+	Ratchet *ratchet.State `json:"ratchet,omitempty"`
+}
+
+// Expired returns true if the session has expired at now.
+func (r *Record) Expired(now time.Time) bool {
+	return !now.Before(r.ExpiresAt)
+}
+
+// Store persists established sessions so they survive restarts.
+//
+// Load and LoadByDID return ErrNotFound for unknown and expired sessions.
+// Implementations must be safe for concurrent use.
+type Store interface {
+	// Save stores a session, replacing any other session between the same
+	// two DIDs.
+	Save(ctx context.Context, record *Record) error
+
+	// Load returns the session with the given ID.
+	Load(ctx context.Context, id string) (*Record, error)
+
+	// LoadByDID returns the session between localDID and remoteDID.
+	LoadByDID(ctx context.Context, localDID, remoteDID string) (*Record, error)
+
+	// Delete removes a session. Deleting an unknown session is not an error.
+	Delete(ctx context.Context, id string) error
+}
+
+// Memory is an in-process Store, mainly for tests and for sharing sessions
+// between transports in one process.
+type Memory struct {
+	mu      sync.RWMutex
+	records map[string]Record // id -> record
+	index   map[string]string // pair -> id
+}
+
+// NewMemory creates an empty in-memory store.
+func NewMemory() *Memory {
+	return &Memory{
+		records: make(map[string]Record),
+		index:   make(map[string]string),
+	}
+}
+
+// Save implements Store.
+func (m *Memory) Save(ctx context.Context, record *Record) error {
+	if err := validate(record); err != nil {
+		return err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	key := pairKey(record.LocalDID, record.RemoteDID)
+	if old, ok := m.index[key]; ok && old != record.ID {
+		delete(m.records, old)
+	}
+	m.records[record.ID] = copyRecord(record)
+	m.index[key] = record.ID
+	return nil
+}
+
+// Load implements Store.
+func (m *Memory) Load(ctx context.Context, id string) (*Record, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	record, ok := m.records[id]
+	if !ok || record.Expired(time.Now()) {
+		return nil, notFound(id)
+	}
+	copy := copyRecord(&record)
+	return &copy, nil
+}
+
+// LoadByDID implements Store.
+func (m *Memory) LoadByDID(ctx context.Context, localDID, remoteDID string) (*Record, error) {
+	m.mu.RLock()
+	id, ok := m.index[pairKey(localDID, remoteDID)]
+	m.mu.RUnlock()
+
+	if !ok {
+		return nil, errors.ErrNotFound.
+			WithMessage("no stored session for DID").
+			WithDetail("remote_did", remoteDID)
+	}
+	return m.Load(ctx, id)
+}
+
+// Delete implements Store.
+func (m *Memory) Delete(ctx context.Context, id string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	record, ok := m.records[id]
+	if !ok {
+		return nil
+	}
+	delete(m.records, id)
+	key := pairKey(record.LocalDID, record.RemoteDID)
+	if m.index[key] == id {
+		delete(m.index, key)
+	}
+	return nil
+}
+
+// pairKey indexes sessions by the DIDs at both ends.
+func pairKey(localDID, remoteDID string) string {
+	return localDID + "|" + remoteDID
+}
+
+func copyRecord(record *Record) Record {
+	copy := *record
+	copy.SessionKey = append([]byte(nil), record.SessionKey...)
+	if record.Ratchet != nil {
+		copy.Ratchet = record.Ratchet.Clone()
+	}
+	return copy
+}
+
+func validate(record *Record) error {
+	switch {
+	case record == nil:
+		return errors.ErrInvalidInput.WithMessage("session record is nil")
+	case record.ID == "":
+		return errors.ErrMissingField.WithDetail("field", "id")
+	case record.LocalDID == "" || record.RemoteDID == "":
+		return errors.ErrMissingField.WithDetail("field", "did")
+	case len(record.SessionKey) == 0:
+		return errors.ErrMissingField.WithDetail("field", "session_key")
+	}
+	return nil
+}
+
+func notFound(id string) error {
+	return errors.ErrNotFound.
+		WithMessage("session not found").
+		WithDetail("session_id", id)
+}
@@ -0,0 +1,232 @@
+// Copyright (C) 2025 sage-x-project
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with this program. If not, see <https://www.gnu.org/licenses/>.
+
+// SPDX-License-Identifier: LGPL-3.0-or-later
+
+package sessionstore
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/sage-x-project/sage-adk/adapters/sage/ratchet"
+	"github.com/sage-x-project/sage-adk/pkg/errors"
+	"github.com/sage-x-project/sage-adk/storage"
+)
+
+// jsonStorage round-trips values through JSON, like the Redis and
+// PostgreSQL backends.
+type jsonStorage struct {
+	*storage.MemoryStorage
+}
+
+func (s jsonStorage) Store(ctx context.Context, namespace, key string, value interface{}) error {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	var decoded interface{}
+	json.Unmarshal(data, &decoded)
+	return s.MemoryStorage.Store(ctx, namespace, key, decoded)
+}
+
+func newRecord(id, remoteDID string) *Record {
+	now := time.Now()
+	state, _ := ratchet.New(bytes.Repeat([]byte{7}, 32), "did:sage:local:alice", remoteDID, now)
+	return &Record{
+		ID:           id,
+		LocalDID:     "did:sage:local:alice",
+		RemoteDID:    remoteDID,
+		SessionKey:   bytes.Repeat([]byte{7}, 32),
+		CreatedAt:    now,
+		ExpiresAt:    now.Add(time.Hour),
+		LastActive:   now,
+		MessagesSent: 3,
+		Ratchet:      state,
+	}
+}
+
+func testKEK() []byte {
+	return bytes.Repeat([]byte{1}, KEKSize)
+}
+
+func stores(t *testing.T) map[string]Store {
+	t.Helper()
+	sealed, err := NewStorageStore(storage.NewMemoryStorage(), testKEK())
+	if err != nil {
+		t.Fatal(err)
+	}
+	serialized, _ := NewStorageStore(jsonStorage{storage.NewMemoryStorage()}, testKEK())
+	return map[string]Store{
+		"memory":     NewMemory(),
+		"storage":    sealed,
+		"serialized": serialized,
+	}
+}
+
+func TestStore(t *testing.T) {
+	ctx := context.Background()
+
+	for name, store := range stores(t) {
+		t.Run(name, func(t *testing.T) {
+			record := newRecord("sess_1", "did:sage:local:bob")
+			if err := store.Save(ctx, record); err != nil {
+				t.Fatalf("Save() error = %v", err)
+			}
+
+			loaded, err := store.Load(ctx, "sess_1")
+			if err != nil {
+				t.Fatalf("Load() error = %v", err)
+			}
+			if !bytes.Equal(loaded.SessionKey, record.SessionKey) || loaded.MessagesSent != 3 ||
+				loaded.Ratchet == nil || !bytes.Equal(loaded.Ratchet.SendChain, record.Ratchet.SendChain) {
+				t.Errorf("Load() = %+v, want saved record", loaded)
+			}
+
+			byDID, err := store.LoadByDID(ctx, record.LocalDID, record.RemoteDID)
+			if err != nil || byDID.ID != "sess_1" {
+				t.Fatalf("LoadByDID() = %v, %v", byDID, err)
+			}
+
+			// A new session with the same peer replaces the old one
+			if err := store.Save(ctx, newRecord("sess_2", "did:sage:local:bob")); err != nil {
+				t.Fatal(err)
+			}
+			if _, err := store.Load(ctx, "sess_1"); !errors.Is(err, errors.ErrNotFound) {
+				t.Errorf("Load() replaced session error = %v, want ErrNotFound", err)
+			}
+			if byDID, _ := store.LoadByDID(ctx, record.LocalDID, record.RemoteDID); byDID == nil || byDID.ID != "sess_2" {
+				t.Errorf("LoadByDID() after replace = %v, want sess_2", byDID)
+			}
+
+			if err := store.Delete(ctx, "sess_2"); err != nil {
+				t.Fatalf("Delete() error = %v", err)
+			}
+			if _, err := store.LoadByDID(ctx, record.LocalDID, record.RemoteDID); !errors.Is(err, errors.ErrNotFound) {
+				t.Errorf("LoadByDID() after delete error = %v, want ErrNotFound", err)
+			}
+			if err := store.Delete(ctx, "sess_2"); err != nil {
+				t.Errorf("Delete() unknown session error = %v", err)
+			}
+		})
+	}
+}
+
+func TestStore_Expired(t *testing.T) {
+	ctx := context.Background()
+
+	for name, store := range stores(t) {
+		t.Run(name, func(t *testing.T) {
+			record := newRecord("sess_old", "did:sage:local:bob")
+			record.ExpiresAt = time.Now().Add(-time.Second)
+			store.Save(ctx, record)
+
+			if _, err := store.Load(ctx, "sess_old"); !errors.Is(err, errors.ErrNotFound) {
+				t.Errorf("Load() expired error = %v, want ErrNotFound", err)
+			}
+		})
+	}
+}
+
+func TestStore_Invalid(t *testing.T) {
+	store := NewMemory()
+	record := newRecord("sess_1", "did:sage:local:bob")
+	record.SessionKey = nil
+
+	if err := store.Save(context.Background(), record); !errors.Is(err, errors.ErrMissingField) {
+		t.Errorf("Save() without key error = %v, want ErrMissingField", err)
+	}
+}
+
+func TestStorageStore_EncryptedAtRest(t *testing.T) {
+	ctx := context.Background()
+	backend := storage.NewMemoryStorage()
+	store, _ := NewStorageStore(backend, testKEK())
+
+	record := newRecord("sess_1", "did:sage:local:bob")
+	store.Save(ctx, record)
+
+	item, _ := backend.Get(ctx, DefaultNamespace, "sess_1")
+	data, _ := json.Marshal(item)
+	if bytes.Contains(data, []byte(hex.EncodeToString(record.SessionKey))) ||
+		bytes.Contains(data, []byte(`"session_key"`)) {
+		t.Error("session key is stored in the clear")
+	}
+
+	// A different KEK cannot open the record
+	other, _ := NewStorageStore(backend, bytes.Repeat([]byte{2}, KEKSize))
+	if _, err := other.Load(ctx, "sess_1"); !errors.Is(err, errors.ErrInvalidCredentials) {
+		t.Errorf("Load() wrong KEK error = %v, want ErrInvalidCredentials", err)
+	}
+
+	// A sealed record moved to another session ID is rejected
+	sealed := item.(sealedRecord)
+	sealed.ID = "sess_evil"
+	backend.Store(ctx, DefaultNamespace, "sess_evil", sealed)
+	if _, err := store.Load(ctx, "sess_evil"); err == nil {
+		t.Error("Load() of a moved record should fail")
+	}
+
+	if _, err := NewStorageStore(backend, []byte("short")); !errors.Is(err, errors.ErrInvalidInput) {
+		t.Errorf("NewStorageStore() short KEK error = %v, want ErrInvalidInput", err)
+	}
+}
+
+func TestLoadOrCreateKEK(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "session.kek")
+
+	kek, err := LoadOrCreateKEK(path)
+	if err != nil || len(kek) != KEKSize {
+		t.Fatalf("LoadOrCreateKEK() = %d bytes, %v", len(kek), err)
+	}
+	info, _ := os.Stat(path)
+	if info.Mode().Perm() != 0o600 {
+		t.Errorf("KEK file mode = %v, want 0600", info.Mode().Perm())
+	}
+
+	again, err := LoadOrCreateKEK(path)
+	if err != nil || !bytes.Equal(again, kek) {
+		t.Errorf("LoadOrCreateKEK() second call = %x, %v, want the same key", again, err)
+	}
+
+	os.WriteFile(path, []byte("not hex"), 0o600)
+	if _, err := LoadKEK(path); !errors.Is(err, errors.ErrInvalidFormat) {
+		t.Errorf("LoadKEK() invalid error = %v, want ErrInvalidFormat", err)
+	}
+}
+
+func TestProof(t *testing.T) {
+	key := bytes.Repeat([]byte{7}, 32)
+	proof := Proof(key, LabelResume, "sess_1", "nonce")
+
+	if !VerifyProof(key, proof, LabelResume, "sess_1", "nonce") {
+		t.Error("VerifyProof() = false for a valid proof")
+	}
+	if VerifyProof(key, proof, LabelResumed, "sess_1", "nonce") {
+		t.Error("VerifyProof() accepted a proof for another label")
+	}
+	if VerifyProof(key, proof, LabelResume, "sess_1n", "once") {
+		t.Error("VerifyProof() accepted shifted field boundaries")
+	}
+	if VerifyProof(bytes.Repeat([]byte{8}, 32), proof, LabelResume, "sess_1", "nonce") {
+		t.Error("VerifyProof() accepted a proof under another key")
+	}
+}
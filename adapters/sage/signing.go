@@ -141,6 +141,14 @@ func (sm *SigningManager) createSignatureBase(message interface{}) (string, erro
 		copy := *v
 		copy.Signature = SignatureEnvelope{}
 		messageToSign = copy
+	case *SessionResume:
+		copy := *v
+		copy.Signature = SignatureEnvelope{}
+		messageToSign = copy
+	case *SessionResumed:
+		copy := *v
+		copy.Signature = SignatureEnvelope{}
+		messageToSign = copy
 	case HandshakeRequest:
 		v.Signature = SignatureEnvelope{}
 		messageToSign = v
@@ -190,6 +198,10 @@ func (sm *SigningManager) ValidateTimestamp(timestamp time.Time, maxClockSkew ti
 }
 
 // NonceCache provides replay protection by tracking used nonces.
+//
+// Deprecated: NonceCache evicts the oldest nonce when full, so a flood of
+// messages can make it forget nonces that are still valid, and it only
+// protects one process. Use a replay.Guard instead.
 type NonceCache struct {
 	nonces map[string]time.Time
 	maxSize int
@@ -0,0 +1,298 @@
+// Copyright (C) 2025 sage-x-project
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with this program. If not, see <https://www.gnu.org/licenses/>.
+
+// SPDX-License-Identifier: LGPL-3.0-or-later
+
+package sage
+
+import (
+	"bufio"
+	"crypto/ed25519"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"io"
+	"net/http"
+	"sync"
+
+	"lukechampine.com/blake3"
+
+	"github.com/sage-x-project/sage-adk/pkg/errors"
+)
+
+// ContentTypeStream is the media type of SAGE streaming responses: one
+// JSON-encoded StreamChunk per line.
+const ContentTypeStream = "application/x-ndjson"
+
+// StreamChunk is one frame of a SAGE streaming response.
+//
+// Chunks form a hash chain: each Digest covers the previous digest, the
+// stream ID, the sequence number and the chunk content, and the chain is
+// seeded from the request nonce. Dropped, reordered, altered or replayed
+// chunks therefore break verification. When the sender has a signing key,
+// each digest is also signed with Ed25519.
+type StreamChunk struct {
+	StreamID  string `json:"stream_id"`
+	Sequence  uint64 `json:"sequence"`
+	Data      string `json:"data,omitempty"`
+	Final     bool   `json:"final,omitempty"`
+	Error     string `json:"error,omitempty"`
+	Digest    string `json:"digest"`
+	KeyID     string `json:"key_id,omitempty"`
+	Signature string `json:"signature,omitempty"`
+}
+
+// streamChain computes the digest chain shared by writer and reader.
+type streamChain struct {
+	streamID string
+	prev     []byte
+	next     uint64
+}
+
+func newStreamChain(streamID, nonce string) *streamChain {
+	seed := blake3.Sum256([]byte("sage-stream\x00" + streamID + "\x00" + nonce))
+	return &streamChain{streamID: streamID, prev: seed[:]}
+}
+
+// digest returns the digest of chunk chained to the previous one.
+func (c *streamChain) digest(chunk *StreamChunk) []byte {
+	h := blake3.New(32, nil)
+	h.Write(c.prev)
+	h.Write([]byte(chunk.StreamID))
+
+	var seq [8]byte
+	binary.BigEndian.PutUint64(seq[:], chunk.Sequence)
+	h.Write(seq[:])
+
+	if chunk.Final {
+		h.Write([]byte{1})
+	} else {
+		h.Write([]byte{0})
+	}
+	writeField(h, chunk.Error)
+	writeField(h, chunk.Data)
+	return h.Sum(nil)
+}
+
+// writeField writes a length-prefixed field so fields cannot run together.
+func writeField(w io.Writer, s string) {
+	var n [8]byte
+	binary.BigEndian.PutUint64(n[:], uint64(len(s)))
+	w.Write(n[:])
+	io.WriteString(w, s)
+}
+
+// StreamWriter writes an integrity-protected chunk stream.
+// It is safe for concurrent use.
+type StreamWriter struct {
+	mu         sync.Mutex
+	w          io.Writer
+	flusher    http.Flusher
+	chain      *streamChain
+	privateKey ed25519.PrivateKey
+	keyID      string
+	closed     bool
+}
+
+// NewStreamWriter creates a stream writer for the response to a request
+// with the given stream ID and nonce. privateKey may be nil, in which case
+// chunks carry digests but no signatures.
+func NewStreamWriter(w io.Writer, streamID, nonce string, privateKey ed25519.PrivateKey, keyID string) *StreamWriter {
+	flusher, _ := w.(http.Flusher)
+	return &StreamWriter{
+		w:          w,
+		flusher:    flusher,
+		chain:      newStreamChain(streamID, nonce),
+		privateKey: privateKey,
+		keyID:      keyID,
+	}
+}
+
+// Write sends a data chunk.
+func (sw *StreamWriter) Write(data string) error {
+	return sw.emit(&StreamChunk{Data: data})
+}
+
+// Close sends the final chunk, carrying the error message if err is not nil.
+// Closing twice is a no-op.
+func (sw *StreamWriter) Close(err error) error {
+	chunk := &StreamChunk{Final: true}
+	if err != nil {
+		chunk.Error = err.Error()
+	}
+	return sw.emit(chunk)
+}
+
+func (sw *StreamWriter) emit(chunk *StreamChunk) error {
+	sw.mu.Lock()
+	defer sw.mu.Unlock()
+
+	if sw.closed {
+		if chunk.Final {
+			return nil
+		}
+		return errors.ErrOperationFailed.WithMessage("stream already closed")
+	}
+
+	chunk.StreamID = sw.chain.streamID
+	chunk.Sequence = sw.chain.next
+	digest := sw.chain.digest(chunk)
+	chunk.Digest = base64.StdEncoding.EncodeToString(digest)
+	if sw.privateKey != nil {
+		chunk.KeyID = sw.keyID
+		chunk.Signature = base64.StdEncoding.EncodeToString(ed25519.Sign(sw.privateKey, digest))
+	}
+
+	line, err := json.Marshal(chunk)
+	if err != nil {
+		return errors.ErrOperationFailed.
+			WithMessage("failed to marshal stream chunk").
+			WithDetail("error", err.Error())
+	}
+	if _, err := sw.w.Write(append(line, '\n')); err != nil {
+		return errors.ErrNetworkUnavailable.
+			WithMessage("failed to write stream chunk").
+			WithDetail("error", err.Error())
+	}
+	if sw.flusher != nil {
+		sw.flusher.Flush()
+	}
+
+	sw.chain.prev = digest
+	sw.chain.next++
+	sw.closed = chunk.Final
+	return nil
+}
+
+// KeyResolverFunc returns the Ed25519 public key for a key ID.
+type KeyResolverFunc func(keyID string) (ed25519.PublicKey, error)
+
+// StreamReader reads and verifies a chunk stream written by StreamWriter.
+type StreamReader struct {
+	scanner    *bufio.Scanner
+	chain      *streamChain
+	resolveKey KeyResolverFunc
+	keys       map[string]ed25519.PublicKey
+	done       bool
+}
+
+// NewStreamReader creates a reader for the response to a request with the
+// given stream ID and nonce. If resolveKey is set, every chunk must carry a
+// valid signature from the key named by its key ID.
+func NewStreamReader(r io.Reader, streamID, nonce string, resolveKey KeyResolverFunc) *StreamReader {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+	return &StreamReader{
+		scanner:    scanner,
+		chain:      newStreamChain(streamID, nonce),
+		resolveKey: resolveKey,
+		keys:       make(map[string]ed25519.PublicKey),
+	}
+}
+
+// Next returns the next verified chunk. It returns io.EOF after the final
+// chunk and io.ErrUnexpectedEOF if the stream ends without one.
+func (sr *StreamReader) Next() (*StreamChunk, error) {
+	if sr.done {
+		return nil, io.EOF
+	}
+
+	for sr.scanner.Scan() {
+		line := sr.scanner.Bytes()
+		if len(line) == 0 {
+			continue
+		}
+
+		var chunk StreamChunk
+		if err := json.Unmarshal(line, &chunk); err != nil {
+			return nil, errors.ErrMessageParsing.
+				WithMessage("invalid stream chunk").
+				WithDetail("error", err.Error())
+		}
+		if err := sr.verify(&chunk); err != nil {
+			return nil, err
+		}
+
+		sr.done = chunk.Final
+		return &chunk, nil
+	}
+
+	if err := sr.scanner.Err(); err != nil {
+		return nil, errors.ErrNetworkUnavailable.
+			WithMessage("failed to read stream").
+			WithDetail("error", err.Error())
+	}
+	return nil, io.ErrUnexpectedEOF
+}
+
+// verify checks the chunk's position, digest and signature and advances
+// the chain.
+func (sr *StreamReader) verify(chunk *StreamChunk) error {
+	if chunk.StreamID != sr.chain.streamID {
+		return errors.ErrSignatureInvalid.
+			WithMessage("stream chunk belongs to another stream").
+			WithDetail("stream_id", chunk.StreamID)
+	}
+	if chunk.Sequence != sr.chain.next {
+		return errors.ErrSignatureInvalid.
+			WithMessage("stream chunk out of sequence").
+			WithDetail("expected", sr.chain.next).
+			WithDetail("got", chunk.Sequence)
+	}
+
+	digest := sr.chain.digest(chunk)
+	if base64.StdEncoding.EncodeToString(digest) != chunk.Digest {
+		return errors.ErrSignatureInvalid.
+			WithMessage("stream chunk digest mismatch").
+			WithDetail("sequence", chunk.Sequence)
+	}
+
+	if sr.resolveKey != nil {
+		publicKey, err := sr.key(chunk.KeyID)
+		if err != nil {
+			return err
+		}
+		signature, err := base64.StdEncoding.DecodeString(chunk.Signature)
+		if err != nil || !ed25519.Verify(publicKey, digest, signature) {
+			return errors.ErrSignatureInvalid.
+				WithMessage("stream chunk signature invalid").
+				WithDetail("sequence", chunk.Sequence)
+		}
+	}
+
+	sr.chain.prev = digest
+	sr.chain.next++
+	return nil
+}
+
+// key resolves and caches the public key for a chunk key ID.
+func (sr *StreamReader) key(keyID string) (ed25519.PublicKey, error) {
+	if keyID == "" {
+		return nil, errors.ErrSignatureInvalid.WithMessage("stream chunk is not signed")
+	}
+	if key, ok := sr.keys[keyID]; ok {
+		return key, nil
+	}
+
+	key, err := sr.resolveKey(keyID)
+	if err != nil {
+		return nil, errors.ErrSignatureInvalid.
+			WithMessage("cannot resolve stream signing key").
+			WithDetail("key_id", keyID).
+			WithDetail("error", err.Error())
+	}
+	sr.keys[keyID] = key
+	return key, nil
+}
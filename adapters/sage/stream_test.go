@@ -0,0 +1,200 @@
+// Copyright (C) 2025 sage-x-project
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with this program. If not, see <https://www.gnu.org/licenses/>.
+
+// SPDX-License-Identifier: LGPL-3.0-or-later
+
+package sage
+
+import (
+	"bytes"
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sage-x-project/sage-adk/core/protocol"
+	"github.com/sage-x-project/sage-adk/pkg/errors"
+	"github.com/sage-x-project/sage-adk/pkg/types"
+)
+
+func writeTestStream(t *testing.T, key ed25519.PrivateKey, chunks ...string) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+	w := NewStreamWriter(&buf, "msg-1", "nonce-1", key, "did:sage:test#key-1")
+	for _, c := range chunks {
+		if err := w.Write(c); err != nil {
+			t.Fatalf("Write() error = %v", err)
+		}
+	}
+	if err := w.Close(nil); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+	return buf.Bytes()
+}
+
+func readTestStream(data []byte, resolveKey KeyResolverFunc) ([]string, error) {
+	r := NewStreamReader(bytes.NewReader(data), "msg-1", "nonce-1", resolveKey)
+	var out []string
+	for {
+		chunk, err := r.Next()
+		if err == io.EOF {
+			return out, nil
+		}
+		if err != nil {
+			return out, err
+		}
+		if !chunk.Final {
+			out = append(out, chunk.Data)
+		}
+	}
+}
+
+func TestStream_RoundTrip(t *testing.T) {
+	pub, priv, _ := ed25519.GenerateKey(rand.Reader)
+	resolveKey := func(keyID string) (ed25519.PublicKey, error) { return pub, nil }
+
+	data := writeTestStream(t, priv, "hello", " ", "world")
+	got, err := readTestStream(data, resolveKey)
+	if err != nil {
+		t.Fatalf("read error = %v", err)
+	}
+	if strings.Join(got, "") != "hello world" {
+		t.Errorf("chunks = %q", got)
+	}
+}
+
+func TestStream_DetectsTampering(t *testing.T) {
+	pub, priv, _ := ed25519.GenerateKey(rand.Reader)
+	otherPub, _, _ := ed25519.GenerateKey(rand.Reader)
+	data := writeTestStream(t, priv, "a", "b", "c")
+	lines := strings.SplitAfter(string(data), "\n")
+
+	tests := []struct {
+		name string
+		data string
+		key  ed25519.PublicKey
+	}{
+		{"dropped chunk", lines[0] + lines[2] + lines[3], pub},
+		{"reordered chunks", lines[1] + lines[0] + lines[2] + lines[3], pub},
+		{"altered data", strings.Replace(string(data), `"data":"b"`, `"data":"x"`, 1), pub},
+		{"truncated", lines[0] + lines[1], pub},
+		{"wrong signer", string(data), otherPub},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			key := tt.key
+			_, err := readTestStream([]byte(tt.data), func(string) (ed25519.PublicKey, error) { return key, nil })
+			if err == nil {
+				t.Error("tampered stream should fail verification")
+			}
+		})
+	}
+
+	// A different request nonce yields a different chain.
+	r := NewStreamReader(bytes.NewReader(data), "msg-1", "other-nonce", nil)
+	if _, err := r.Next(); !errors.Is(err, errors.ErrSignatureInvalid) {
+		t.Errorf("replayed stream error = %v, want ErrSignatureInvalid", err)
+	}
+}
+
+func TestStream_UnsignedRejectedWhenKeyExpected(t *testing.T) {
+	data := writeTestStream(t, nil, "a")
+
+	if _, err := readTestStream(data, nil); err != nil {
+		t.Errorf("digest-only read error = %v", err)
+	}
+	if _, err := readTestStream(data, func(string) (ed25519.PublicKey, error) { return nil, nil }); err == nil {
+		t.Error("unsigned stream should fail when signatures are required")
+	}
+}
+
+func TestNetworkServer_Stream(t *testing.T) {
+	pub, priv, _ := ed25519.GenerateKey(rand.Reader)
+
+	server := NewNetworkServer(":0", nil)
+	server.SetStreamSigner(priv, "did:sage:server#key-1")
+	server.SetStreamHandler(func(ctx context.Context, msg *types.Message, emit protocol.StreamFunc) error {
+		for i := 0; i < 3; i++ {
+			if err := emit(fmt.Sprintf("chunk-%d;", i)); err != nil {
+				return err
+			}
+		}
+		if msg.Metadata["fail"] == true {
+			return fmt.Errorf("handler failed")
+		}
+		return nil
+	})
+
+	ts := httptest.NewServer(server.httpServer.Handler)
+	defer ts.Close()
+
+	newMsg := func() *types.Message {
+		msg := types.NewMessage(types.MessageRoleUser, []types.Part{types.NewTextPart("stream")})
+		msg.Security = &types.SecurityMetadata{
+			Mode:      types.ProtocolModeSAGE,
+			AgentDID:  "did:sage:client",
+			Nonce:     "nonce-" + msg.MessageID,
+			Timestamp: time.Now(),
+		}
+		return msg
+	}
+
+	client := NewNetworkClient(nil)
+	resolveKey := func(keyID string) (ed25519.PublicKey, error) {
+		if keyID != "did:sage:server#key-1" {
+			return nil, fmt.Errorf("unknown key %s", keyID)
+		}
+		return pub, nil
+	}
+
+	var got strings.Builder
+	err := client.StreamMessage(context.Background(), ts.URL+"/sage/stream", newMsg(), resolveKey, func(chunk string) error {
+		got.WriteString(chunk)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("StreamMessage() error = %v", err)
+	}
+	if got.String() != "chunk-0;chunk-1;chunk-2;" {
+		t.Errorf("streamed = %q", got.String())
+	}
+
+	// A failure after the first chunk ends the stream with an error chunk.
+	failing := newMsg()
+	failing.Metadata["fail"] = true
+	err = client.StreamMessage(context.Background(), ts.URL+"/sage/stream", failing, resolveKey, func(string) error { return nil })
+	if err == nil || !strings.Contains(fmt.Sprint(err), "handler failed") {
+		t.Errorf("StreamMessage() error = %v, want handler failure", err)
+	}
+}
+
+func TestStreamChunk_JSON(t *testing.T) {
+	data := writeTestStream(t, nil, "x")
+	var chunk StreamChunk
+	if err := json.Unmarshal(bytes.SplitN(data, []byte("\n"), 2)[0], &chunk); err != nil {
+		t.Fatal(err)
+	}
+	if chunk.StreamID != "msg-1" || chunk.Sequence != 0 || chunk.Digest == "" || chunk.Signature != "" {
+		t.Errorf("chunk = %+v", chunk)
+	}
+}
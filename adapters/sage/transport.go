@@ -25,6 +25,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/sage-x-project/sage-adk/adapters/sage/ratchet"
 	"github.com/sage-x-project/sage-adk/pkg/errors"
 )
 
@@ -50,6 +51,10 @@ type TransportManager struct {
 	// Active handshakes tracking
 	activeHandshakes map[string]*HandshakeState
 	mu               sync.RWMutex
+
+	// Message key schedule
+	rekey        ratchet.Config
+	ratchetLocks map[string]*sync.Mutex
 }
 
 // HandshakeState tracks the state of an ongoing handshake.
@@ -88,6 +93,11 @@ func NewTransportManager(
 		config,
 	)
 
+	rekey := config.Rekey
+	if rekey == (ratchet.Config{}) {
+		rekey = ratchet.DefaultConfig()
+	}
+
 	return &TransportManager{
 		handshakeManager:  handshakeManager,
 		sessionManager:    sessionManager,
@@ -98,6 +108,8 @@ func NewTransportManager(
 		publicKey:         privateKey.Public().(ed25519.PublicKey),
 		config:            config,
 		activeHandshakes:  make(map[string]*HandshakeState),
+		rekey:             rekey,
+		ratchetLocks:      make(map[string]*sync.Mutex),
 	}
 }
 
@@ -137,8 +149,8 @@ func NewTransportManagerFromConfig(cfg *Config, keyManager *KeyManager) (*Transp
 
 	// Create transport config from SAGE config
 	transportConfig := &TransportConfig{
-		SessionTTL:      cfg.DID.CacheTTL, // Reuse DID cache TTL for sessions
-		MaxClockSkew:    5 * time.Minute,
+		SessionTTL:       cfg.DID.CacheTTL, // Reuse DID cache TTL for sessions
+		MaxClockSkew:     5 * time.Minute,
 		HandshakeTimeout: 30 * time.Second,
 	}
 
@@ -150,6 +162,13 @@ func (tm *TransportManager) SetMessageHandler(handler MessageHandler) {
 	tm.messageHandler = handler
 }
 
+// SetSigningKeyFunc makes handshake and application messages follow the
+// key returned by fn, for example the active key of a rotating key ring.
+// It must be called before the transport is used.
+func (tm *TransportManager) SetSigningKeyFunc(fn SigningKeyFunc) {
+	tm.handshakeManager.SetSigningKeyFunc(fn)
+}
+
 // Connect initiates a connection to a remote agent.
 // This starts the handshake process (Phase 1: Invitation).
 func (tm *TransportManager) Connect(ctx context.Context, remoteDID string) (*HandshakeInvitation, error) {
@@ -313,7 +332,13 @@ func (tm *TransportManager) HandleComplete(ctx context.Context, complete *Handsh
 
 // SendMessage sends an encrypted message to a remote agent.
 // The session must be established first via Connect/Handle* methods.
+// Each message is numbered and encrypted with its own key; the session is
+// rekeyed as configured by TransportConfig.Rekey.
 func (tm *TransportManager) SendMessage(ctx context.Context, remoteDID string, payload interface{}) (*ApplicationMessage, error) {
+	lock := tm.ratchetLock(remoteDID)
+	lock.Lock()
+	defer lock.Unlock()
+
 	// Get active session
 	session, err := tm.sessionManager.GetByDID(remoteDID)
 	if err != nil {
@@ -330,8 +355,28 @@ func (tm *TransportManager) SendMessage(ctx context.Context, remoteDID string, p
 			WithDetail("status", session.Status)
 	}
 
-	// Encrypt payload with session key
-	encryptedPayload, err := tm.encryptionManager.EncryptWithSessionKey(payload, session.SessionKey)
+	if session.Ratchet == nil {
+		return nil, errors.ErrSessionOutOfSync.
+			WithMessage("session has no message keys").
+			WithDetail("remote_did", remoteDID)
+	}
+
+	plaintext, err := json.Marshal(payload)
+	if err != nil {
+		return nil, errors.ErrOperationFailed.
+			WithMessage("failed to marshal data").
+			WithDetail("error", err.Error())
+	}
+
+	// Derive the message key, rekeying if due
+	now := time.Now()
+	header, messageKey, nextRatchet, err := session.Ratchet.Send(tm.rekey, len(plaintext), now)
+	if err != nil {
+		return nil, err
+	}
+
+	// Encrypt payload with message key
+	encryptedPayload, err := tm.encryptionManager.EncryptWithSessionKey(json.RawMessage(plaintext), messageKey)
 	if err != nil {
 		return nil, err
 	}
@@ -341,73 +386,135 @@ func (tm *TransportManager) SendMessage(ctx context.Context, remoteDID string, p
 		FromDID:          tm.localDID,
 		ToDID:            remoteDID,
 		SessionID:        session.ID,
+		Header:           header,
 		EncryptedPayload: *encryptedPayload,
-		Timestamp:        time.Now(),
+		Timestamp:        now,
 	}
 
 	// Sign message
-	keyID := tm.localDID + "#key-1"
-	signature, err := tm.signingManager.SignMessage(message, tm.privateKey, keyID)
+	privateKey, keyID, err := tm.handshakeManager.currentKey()
+	if err != nil {
+		return nil, err
+	}
+	signature, err := tm.signingManager.SignMessage(message, privateKey, keyID)
 	if err != nil {
 		return nil, err
 	}
 	message.Signature = *signature
 
+	// Commit the key schedule before the message leaves
+	updated := *session
+	updated.Ratchet = nextRatchet
+	updated.MessagesSent++
+	if err := tm.sessionManager.Update(&updated); err != nil {
+		return nil, err
+	}
+
 	return message, nil
 }
 
 // ReceiveMessage processes an incoming encrypted message.
 func (tm *TransportManager) ReceiveMessage(ctx context.Context, message *ApplicationMessage, senderPublicKey ed25519.PublicKey) error {
+	payload, err := tm.OpenMessage(ctx, message, senderPublicKey)
+	if err != nil {
+		return err
+	}
+
+	// Call message handler if set
+	if tm.messageHandler != nil {
+		return tm.messageHandler(ctx, message.FromDID, payload)
+	}
+
+	return nil
+}
+
+// OpenMessage verifies and decrypts an incoming encrypted message and
+// returns its JSON payload. Messages of another session, or whose sequence
+// number does not exceed that of the last opened message, fail with
+// ErrReplayDetected, and messages whose keys this side cannot derive with
+// ErrSessionOutOfSync.
+func (tm *TransportManager) OpenMessage(ctx context.Context, message *ApplicationMessage, senderPublicKey ed25519.PublicKey) ([]byte, error) {
 	// Verify signature
 	if err := tm.signingManager.VerifySignature(message, &message.Signature, senderPublicKey); err != nil {
-		return errors.ErrSignatureInvalid.
+		return nil, errors.ErrSignatureInvalid.
 			WithMessage("message signature verification failed").
 			WithDetail("error", err.Error())
 	}
 
 	// Validate timestamp
 	if err := tm.signingManager.ValidateTimestamp(message.Timestamp, tm.config.MaxClockSkew); err != nil {
-		return err
+		return nil, err
 	}
 
+	lock := tm.ratchetLock(message.FromDID)
+	lock.Lock()
+	defer lock.Unlock()
+
 	// Get session
 	session, err := tm.sessionManager.GetByDID(message.FromDID)
 	if err != nil {
-		return errors.ErrOperationFailed.
+		return nil, errors.ErrOperationFailed.
 			WithMessage("no active session found").
 			WithDetail("from_did", message.FromDID).
 			WithDetail("error", err.Error())
 	}
 
 	if !session.IsActive() {
-		return errors.ErrOperationFailed.
+		return nil, errors.ErrOperationFailed.
 			WithMessage("session is not active").
 			WithDetail("from_did", message.FromDID).
 			WithDetail("status", session.Status)
 	}
 
-	// Decrypt payload
-	var payload map[string]interface{}
-	if err := tm.encryptionManager.DecryptWithSessionKey(&message.EncryptedPayload, session.SessionKey, &payload); err != nil {
-		return errors.ErrOperationFailed.
-			WithMessage("failed to decrypt message").
-			WithDetail("error", err.Error())
+	// A message of an earlier session is a replay; it must not be taken
+	// for a desynchronized key schedule, which ends the live session
+	if message.SessionID != session.ID {
+		return nil, errors.ErrReplayDetected.
+			WithMessage("message belongs to another session").
+			WithDetail("from_did", message.FromDID).
+			WithDetail("session_id", message.SessionID)
 	}
 
-	// Convert payload to JSON bytes for handler
-	payloadBytes, err := json.Marshal(payload)
+	if session.Ratchet == nil {
+		return nil, errors.ErrSessionOutOfSync.
+			WithMessage("session has no message keys").
+			WithDetail("from_did", message.FromDID)
+	}
+
+	// Derive the message key; this rejects replayed and reordered messages
+	messageKey, nextRatchet, err := session.Ratchet.Receive(message.Header)
 	if err != nil {
-		return errors.ErrOperationFailed.
-			WithMessage("failed to marshal payload").
+		return nil, err
+	}
+
+	// Decrypt payload. The signature is valid, so a message that does not
+	// decrypt was keyed differently by the peer.
+	var payload json.RawMessage
+	if err := tm.encryptionManager.DecryptWithSessionKey(&message.EncryptedPayload, messageKey, &payload); err != nil {
+		return nil, errors.ErrSessionOutOfSync.
+			WithMessage("failed to decrypt message").
 			WithDetail("error", err.Error())
 	}
 
-	// Call message handler if set
-	if tm.messageHandler != nil {
-		return tm.messageHandler(ctx, message.FromDID, payloadBytes)
+	updated := *session
+	updated.Ratchet = nextRatchet
+	updated.MessagesReceived++
+	if err := tm.sessionManager.Update(&updated); err != nil {
+		return nil, err
 	}
 
-	return nil
+	return payload, nil
+}
+
+// HasSession reports whether an active session with the remote DID exists.
+func (tm *TransportManager) HasSession(remoteDID string) bool {
+	session, err := tm.sessionManager.GetByDID(remoteDID)
+	return err == nil && session.IsActive()
+}
+
+// LocalDID returns the DID this transport manager acts as.
+func (tm *TransportManager) LocalDID() string {
+	return tm.localDID
 }
 
 // Disconnect closes a connection to a remote agent.
@@ -439,24 +546,38 @@ func (tm *TransportManager) ListSessions() []*Session {
 	return tm.sessionManager.List()
 }
 
-// Close shuts down the transport manager.
+// Close shuts down the transport manager. Sessions persisted in a session
+// store are kept, so they can be resumed after a restart.
 func (tm *TransportManager) Close() error {
-	// Close all active sessions
-	sessions := tm.sessionManager.List()
-	for _, session := range sessions {
-		tm.sessionManager.Delete(session.ID)
-	}
+	tm.sessionManager.Forget()
 	return nil
 }
 
-// ApplicationMessage represents an encrypted application-level message.
+// ratchetLock returns the lock serializing message key use with a remote
+// DID, so that each sequence number is sent and accepted once.
+func (tm *TransportManager) ratchetLock(remoteDID string) *sync.Mutex {
+	tm.mu.Lock()
+	defer tm.mu.Unlock()
+
+	lock, ok := tm.ratchetLocks[remoteDID]
+	if !ok {
+		lock = &sync.Mutex{}
+		tm.ratchetLocks[remoteDID] = lock
+	}
+	return lock
+}
+
+// ApplicationMessage represents an encrypted application-level message. The
+// embedded ratchet header carries the message's sequence number and epoch.
 type ApplicationMessage struct {
-	FromDID          string             `json:"from_did"`
-	ToDID            string             `json:"to_did"`
-	SessionID        string             `json:"session_id"`
-	EncryptedPayload EncryptedPayload   `json:"encrypted_payload"`
-	Signature        SignatureEnvelope  `json:"signature"`
-	Timestamp        time.Time          `json:"timestamp"`
+	FromDID          string            `json:"from_did"`
+	ToDID            string            `json:"to_did"`
+	SessionID        string            `json:"session_id"`
+	EncryptedPayload EncryptedPayload  `json:"encrypted_payload"`
+	Signature        SignatureEnvelope `json:"signature"`
+	Timestamp        time.Time         `json:"timestamp"`
+
+	ratchet.Header
 }
 
 // MessageEnvelope wraps any SAGE protocol message for transport.
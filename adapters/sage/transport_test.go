@@ -18,6 +18,7 @@
 package sage
 
 import (
+	"bytes"
 	"context"
 	"crypto/ed25519"
 	"crypto/rand"
@@ -25,7 +26,10 @@ import (
 	"testing"
 	"time"
 
+	"github.com/sage-x-project/sage-adk/adapters/sage/ratchet"
+	"github.com/sage-x-project/sage-adk/adapters/sage/sessionstore"
 	adkconfig "github.com/sage-x-project/sage-adk/config"
+	"github.com/sage-x-project/sage-adk/pkg/errors"
 )
 
 func TestNewTransportManagerFromConfig(t *testing.T) {
@@ -248,6 +252,103 @@ func TestTransportManager_FullHandshakeFlow(t *testing.T) {
 	}
 }
 
+func TestTransportManager_ResumeSession(t *testing.T) {
+	alicePublicKey, alicePrivateKey, _ := ed25519.GenerateKey(rand.Reader)
+	alice := NewTransportManager("did:sage:alice", alicePrivateKey, nil)
+	alice.SetSessionStore(sessionstore.NewMemory())
+
+	bobPublicKey, bobPrivateKey, _ := ed25519.GenerateKey(rand.Reader)
+	bob := NewTransportManager("did:sage:bob", bobPrivateKey, nil)
+	bob.SetSessionStore(sessionstore.NewMemory())
+
+	ctx := context.Background()
+	invitation, _ := alice.Connect(ctx, "did:sage:bob")
+	request, _ := bob.HandleInvitation(ctx, invitation)
+	response, _ := alice.HandleRequest(ctx, request, bobPublicKey)
+	complete, _ := bob.HandleResponse(ctx, response, alicePublicKey)
+	if err := alice.HandleComplete(ctx, complete, bobPublicKey); err != nil {
+		t.Fatalf("handshake failed: %v", err)
+	}
+
+	// Restart both sides.
+	alice.Close()
+	bob.Close()
+	if !alice.NeedsResume("did:sage:bob") {
+		t.Fatal("restored session should need resumption")
+	}
+
+	resume, err := alice.ResumeSession(ctx, "did:sage:bob")
+	if err != nil {
+		t.Fatalf("ResumeSession failed: %v", err)
+	}
+
+	// A resumption signed by another key is rejected.
+	_, otherKey, _ := ed25519.GenerateKey(rand.Reader)
+	if _, err := bob.HandleResume(ctx, resume, otherKey.Public().(ed25519.PublicKey)); err == nil {
+		t.Error("HandleResume should reject a resumption from the wrong key")
+	}
+
+	resumed, err := bob.HandleResume(ctx, resume, alicePublicKey)
+	if err != nil {
+		t.Fatalf("HandleResume failed: %v", err)
+	}
+	if err := alice.CompleteResume(ctx, resume, resumed, bobPublicKey); err != nil {
+		t.Fatalf("CompleteResume failed: %v", err)
+	}
+	if alice.NeedsResume("did:sage:bob") || bob.NeedsResume("did:sage:alice") {
+		t.Error("confirmed session should not need resumption")
+	}
+
+	message, err := alice.SendMessage(ctx, "did:sage:bob", map[string]string{"text": "hello"})
+	if err != nil {
+		t.Fatalf("SendMessage failed: %v", err)
+	}
+	if _, err := bob.OpenMessage(ctx, message, alicePublicKey); err != nil {
+		t.Errorf("OpenMessage after resumption failed: %v", err)
+	}
+
+	// A peer without the session answers with ErrNotFound.
+	carol := NewTransportManager("did:sage:bob", bobPrivateKey, nil)
+	resume, _ = alice.ResumeSession(ctx, "did:sage:bob")
+	if _, err := carol.HandleResume(ctx, resume, alicePublicKey); !errors.Is(err, errors.ErrNotFound) {
+		t.Errorf("HandleResume without session error = %v, want ErrNotFound", err)
+	}
+}
+
+func TestTransportManager_SigningKeyFunc(t *testing.T) {
+	_, alicePrivateKey, _ := ed25519.GenerateKey(rand.Reader)
+	alice := NewTransportManager("did:sage:alice", alicePrivateKey, nil)
+
+	bobOldPublicKey, bobOldPrivateKey, _ := ed25519.GenerateKey(rand.Reader)
+	bobNewPublicKey, bobNewPrivateKey, _ := ed25519.GenerateKey(rand.Reader)
+	bob := NewTransportManager("did:sage:bob", bobOldPrivateKey, nil)
+
+	// Bob has rotated to a new key since the transport was created
+	bob.SetSigningKeyFunc(func() (ed25519.PrivateKey, string, error) {
+		return bobNewPrivateKey, "did:sage:bob#key-2", nil
+	})
+
+	ctx := context.Background()
+	invitation, err := alice.Connect(ctx, "did:sage:bob")
+	if err != nil {
+		t.Fatalf("Connect() error = %v", err)
+	}
+	request, err := bob.HandleInvitation(ctx, invitation)
+	if err != nil {
+		t.Fatalf("HandleInvitation() error = %v", err)
+	}
+
+	if request.Signature.KeyID != "did:sage:bob#key-2" {
+		t.Errorf("request key ID = %s, want did:sage:bob#key-2", request.Signature.KeyID)
+	}
+	if _, err := alice.HandleRequest(ctx, request, bobOldPublicKey); err == nil {
+		t.Error("HandleRequest() should reject the request under Bob's old key")
+	}
+	if _, err := alice.HandleRequest(ctx, request, bobNewPublicKey); err != nil {
+		t.Errorf("HandleRequest() with Bob's new key error = %v", err)
+	}
+}
+
 func TestTransportManager_SendReceiveMessage(t *testing.T) {
 	// Setup Alice and Bob with established session
 	alicePublicKey, alicePrivateKey, _ := ed25519.GenerateKey(rand.Reader)
@@ -323,6 +424,155 @@ func TestTransportManager_SendReceiveMessage(t *testing.T) {
 	}
 }
 
+func TestTransportManager_MessageSequence(t *testing.T) {
+	ctx := context.Background()
+	config := DefaultTransportConfig()
+	config.Rekey = ratchet.Config{Messages: 2, ExchangeEvery: 2}
+	alice, bob, alicePublicKey, bobPublicKey := connectedTransports(t, config)
+
+	// Keys are rekeyed and exchanged in both directions.
+	var lastEpoch uint32
+	for i := 0; i < 10; i++ {
+		message, err := alice.SendMessage(ctx, "did:sage:bob", map[string]int{"n": i})
+		if err != nil {
+			t.Fatalf("SendMessage failed: %v", err)
+		}
+		if message.Sequence != uint64(i+1) {
+			t.Errorf("Sequence = %d, want %d", message.Sequence, i+1)
+		}
+		lastEpoch = message.Epoch
+		if _, err := bob.OpenMessage(ctx, message, alicePublicKey); err != nil {
+			t.Fatalf("OpenMessage %d failed: %v", i, err)
+		}
+
+		reply, err := bob.SendMessage(ctx, "did:sage:alice", map[string]int{"n": i})
+		if err != nil {
+			t.Fatalf("SendMessage failed: %v", err)
+		}
+		if _, err := alice.OpenMessage(ctx, reply, bobPublicKey); err != nil {
+			t.Fatalf("OpenMessage reply %d failed: %v", i, err)
+		}
+	}
+	if lastEpoch == 0 {
+		t.Error("session was never rekeyed")
+	}
+
+	session, _ := alice.GetSession("did:sage:bob")
+	if session.MessagesSent != 10 || session.MessagesReceived != 10 {
+		t.Errorf("MessagesSent = %d, MessagesReceived = %d, want 10 and 10", session.MessagesSent, session.MessagesReceived)
+	}
+
+	// Replayed and reordered messages are rejected.
+	first, _ := alice.SendMessage(ctx, "did:sage:bob", "first")
+	second, _ := alice.SendMessage(ctx, "did:sage:bob", "second")
+	if _, err := bob.OpenMessage(ctx, second, alicePublicKey); err != nil {
+		t.Fatalf("OpenMessage failed: %v", err)
+	}
+	if _, err := bob.OpenMessage(ctx, second, alicePublicKey); !errors.Is(err, errors.ErrReplayDetected) {
+		t.Errorf("replayed message error = %v, want ErrReplayDetected", err)
+	}
+	if _, err := bob.OpenMessage(ctx, first, alicePublicKey); !errors.Is(err, errors.ErrReplayDetected) {
+		t.Errorf("reordered message error = %v, want ErrReplayDetected", err)
+	}
+
+	// A renumbered message fails its signature.
+	third, _ := alice.SendMessage(ctx, "did:sage:bob", "third")
+	third.Sequence++
+	if _, err := bob.OpenMessage(ctx, third, alicePublicKey); !errors.Is(err, errors.ErrSignatureInvalid) {
+		t.Errorf("renumbered message error = %v, want ErrSignatureInvalid", err)
+	}
+}
+
+func TestTransportManager_CrossSessionReplay(t *testing.T) {
+	ctx := context.Background()
+	alice, bob, alicePublicKey, bobPublicKey := connectedTransports(t, nil)
+
+	old, err := alice.SendMessage(ctx, "did:sage:bob", "first session")
+	if err != nil {
+		t.Fatalf("SendMessage failed: %v", err)
+	}
+
+	// Start a new session
+	alice.Disconnect(ctx, "did:sage:bob")
+	bob.Disconnect(ctx, "did:sage:alice")
+	handshake(t, alice, bob, alicePublicKey, bobPublicKey)
+
+	// A message of the earlier session is a replay and leaves the new
+	// session intact
+	if _, err := bob.OpenMessage(ctx, old, alicePublicKey); !errors.Is(err, errors.ErrReplayDetected) {
+		t.Errorf("cross-session replay error = %v, want ErrReplayDetected", err)
+	}
+	if !bob.HasSession("did:sage:alice") {
+		t.Fatal("replay ended the live session")
+	}
+
+	message, err := alice.SendMessage(ctx, "did:sage:bob", "second session")
+	if err != nil {
+		t.Fatalf("SendMessage failed: %v", err)
+	}
+	if _, err := bob.OpenMessage(ctx, message, alicePublicKey); err != nil {
+		t.Errorf("OpenMessage in new session failed: %v", err)
+	}
+}
+
+func TestTransportManager_SessionKeysDiscarded(t *testing.T) {
+	alice, bob, _, _ := connectedTransports(t, nil)
+
+	aliceSession, _ := alice.GetSession("did:sage:bob")
+	bobSession, _ := bob.GetSession("did:sage:alice")
+	for _, session := range []*Session{aliceSession, bobSession} {
+		if session.Ratchet == nil {
+			t.Fatal("active session has no message keys")
+		}
+		if session.SharedSecret != nil || session.EphemeralKey != nil {
+			t.Error("active session keeps handshake secrets")
+		}
+	}
+	if !bytes.Equal(aliceSession.SessionKey, bobSession.SessionKey) {
+		t.Error("resumption keys differ")
+	}
+}
+
+// connectedTransports returns transports for alice and bob with an active
+// session between them.
+func connectedTransports(t *testing.T, config *TransportConfig) (alice, bob *TransportManager, alicePublicKey, bobPublicKey ed25519.PublicKey) {
+	t.Helper()
+
+	alicePublicKey, alicePrivateKey, _ := ed25519.GenerateKey(rand.Reader)
+	alice = NewTransportManager("did:sage:alice", alicePrivateKey, config)
+	bobPublicKey, bobPrivateKey, _ := ed25519.GenerateKey(rand.Reader)
+	bob = NewTransportManager("did:sage:bob", bobPrivateKey, config)
+
+	handshake(t, alice, bob, alicePublicKey, bobPublicKey)
+	return alice, bob, alicePublicKey, bobPublicKey
+}
+
+// handshake establishes a session between alice and bob.
+func handshake(t *testing.T, alice, bob *TransportManager, alicePublicKey, bobPublicKey ed25519.PublicKey) {
+	t.Helper()
+
+	ctx := context.Background()
+	invitation, err := alice.Connect(ctx, "did:sage:bob")
+	if err != nil {
+		t.Fatalf("Connect failed: %v", err)
+	}
+	request, err := bob.HandleInvitation(ctx, invitation)
+	if err != nil {
+		t.Fatalf("HandleInvitation failed: %v", err)
+	}
+	response, err := alice.HandleRequest(ctx, request, bobPublicKey)
+	if err != nil {
+		t.Fatalf("HandleRequest failed: %v", err)
+	}
+	complete, err := bob.HandleResponse(ctx, response, alicePublicKey)
+	if err != nil {
+		t.Fatalf("HandleResponse failed: %v", err)
+	}
+	if err := alice.HandleComplete(ctx, complete, bobPublicKey); err != nil {
+		t.Fatalf("HandleComplete failed: %v", err)
+	}
+}
+
 func TestTransportManager_SendMessage_NoSession(t *testing.T) {
 	_, privateKey, _ := ed25519.GenerateKey(rand.Reader)
 	tm := NewTransportManager("did:sage:alice", privateKey, nil)
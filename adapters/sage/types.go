@@ -19,6 +19,8 @@ package sage
 
 import (
 	"time"
+
+	"github.com/sage-x-project/sage-adk/adapters/sage/ratchet"
 )
 
 // HandshakePhase represents the current phase of the SAGE handshake.
@@ -36,6 +38,12 @@ const (
 
 	// PhaseComplete is the final phase where Bob acknowledges session establishment.
 	PhaseComplete HandshakePhase = "complete"
+
+	// PhaseResume asks the peer to continue a session restored after a restart.
+	PhaseResume HandshakePhase = "resume"
+
+	// PhaseResumed confirms that the peer still holds the resumed session.
+	PhaseResumed HandshakePhase = "resumed"
 )
 
 // SessionStatus represents the current status of a SAGE session.
@@ -84,9 +92,12 @@ type Session struct {
 	RemoteDID string
 
 	// Cryptographic material
-	SessionKey   []byte // ChaCha20-Poly1305 key (32 bytes)
+	SessionKey   []byte // ChaCha20-Poly1305 key (32 bytes); a resumption key once active
 	SharedSecret []byte // HPKE shared secret
 
+	// Ratchet derives message keys once the session is active
+	Ratchet *ratchet.State
+
 	// Timing
 	CreatedAt  time.Time
 	ExpiresAt  time.Time
@@ -105,6 +116,10 @@ type Session struct {
 	MessagesSent     int64
 	MessagesReceived int64
 
+	// Resumed is set on sessions restored from a session store until the
+	// peer proves it still holds the session key.
+	Resumed bool
+
 	// Metadata
 	Metadata map[string]interface{}
 }
@@ -241,6 +256,10 @@ type TransportConfig struct {
 	// Security
 	MaxClockSkew     time.Duration
 	NonceCache      int // Number of nonces to cache for replay protection
+
+	// Rekey sets when session message keys are rekeyed. The zero value
+	// uses ratchet.DefaultConfig.
+	Rekey ratchet.Config
 }
 
 // DefaultTransportConfig returns default configuration values.
@@ -252,5 +271,6 @@ func DefaultTransportConfig() *TransportConfig {
 		MessageTimeout:   10 * time.Second,
 		MaxClockSkew:     5 * time.Minute,
 		NonceCache:       1000,
+		Rekey:            ratchet.DefaultConfig(),
 	}
 }
@@ -19,14 +19,27 @@ package builder
 
 import (
 	"context"
+	"net/http"
+	"os"
 
 	"github.com/sage-x-project/sage-adk/adapters/a2a"
 	"github.com/sage-x-project/sage-adk/adapters/llm"
 	sageadapter "github.com/sage-x-project/sage-adk/adapters/sage"
+	"github.com/sage-x-project/sage-adk/adapters/sage/httpsig"
+	"github.com/sage-x-project/sage-adk/adapters/sage/replay"
 	"github.com/sage-x-project/sage-adk/config"
 	"github.com/sage-x-project/sage-adk/core/agent"
+	"github.com/sage-x-project/sage-adk/core/authn"
+	"github.com/sage-x-project/sage-adk/core/authz"
+	"github.com/sage-x-project/sage-adk/core/middleware"
 	"github.com/sage-x-project/sage-adk/core/protocol"
+	"github.com/sage-x-project/sage-adk/core/task"
+	"github.com/sage-x-project/sage-adk/core/tools"
+	"github.com/sage-x-project/sage-adk/observability/logging"
 	"github.com/sage-x-project/sage-adk/pkg/errors"
+	"github.com/sage-x-project/sage-adk/pkg/mtls"
+	"github.com/sage-x-project/sage-adk/pkg/types"
+	grpcserver "github.com/sage-x-project/sage-adk/server/grpc"
 	"github.com/sage-x-project/sage-adk/storage"
 	sagecrypto "github.com/sage-x-project/sage/crypto"
 )
@@ -49,12 +62,22 @@ type Builder struct {
 	a2aConfig    *config.A2AConfig
 	sageConfig   *config.SAGEConfig
 
+	// Signed A2A mode: resolves the keys of senders the A2A server accepts
+	a2aSenderKeys httpsig.KeyResolver
+
 	// LLM provider
 	llmProvider llm.Provider
 
+	// Tools and advertised skills
+	toolRegistry *tools.Registry
+	skills       []types.AgentSkill
+
 	// Storage backend
 	storageBackend storage.Storage
 
+	// Task store shared by the agent's servers
+	taskStore task.Store
+
 	// Message handler
 	messageHandler agent.MessageHandler
 
@@ -62,6 +85,13 @@ type Builder struct {
 	keyManager *sageadapter.KeyManager
 	keyPair    sagecrypto.KeyPair
 
+	// SAGE adapter of the built agent, if any
+	sageAdapter *sageadapter.Adapter
+
+	// Server authentication and authorization of the built agent
+	authenticator *authn.Authenticator
+	authorizer    *authz.Authorizer
+
 	// Lifecycle hooks
 	beforeStart func(context.Context) error
 	afterStop   func(context.Context) error
@@ -100,6 +130,49 @@ func (b *Builder) WithLLM(provider llm.Provider) *Builder {
 	return b
 }
 
+// WithNamedLLM sets the LLM provider declared under name in the builder's
+// configuration. An empty name selects the configured default provider.
+//
+// Example:
+//
+//	builder.WithConfig(cfg).WithNamedLLM("fast")
+func (b *Builder) WithNamedLLM(name string) *Builder {
+	provider, err := llm.NewProviderFromConfig(&b.config.LLM, name)
+	if err != nil {
+		b.errors = append(b.errors, err)
+		return b
+	}
+
+	b.llmProvider = provider
+	return b
+}
+
+// WithTools sets the tool registry available to the agent.
+//
+// Example:
+//
+//	registry := tools.NewRegistry()
+//	tools.RegisterBuiltinTools(registry)
+//	builder.WithTools(registry)
+func (b *Builder) WithTools(registry *tools.Registry) *Builder {
+	b.toolRegistry = registry
+	return b
+}
+
+// WithSkill advertises a skill in the agent card.
+//
+// Example:
+//
+//	builder.WithSkill(types.AgentSkill{
+//	    ID:          "summarize",
+//	    Name:        "Summarize",
+//	    Description: "Summarizes long documents",
+//	})
+func (b *Builder) WithSkill(skill types.AgentSkill) *Builder {
+	b.skills = append(b.skills, skill)
+	return b
+}
+
 // WithProtocol sets the protocol mode for agent communication.
 //
 // Available modes:
@@ -132,6 +205,23 @@ func (b *Builder) WithA2AConfig(cfg *config.A2AConfig) *Builder {
 	return b
 }
 
+// WithA2ASignatures makes the A2A server require RFC 9421 HTTP message
+// signatures, resolving senders' keys with resolve (e.g. httpsig.StaticKeys
+// or a SAGE DIDResolver's HTTPKeyResolver). Handlers get the verified
+// sender DID from a2a.Sender(ctx). Signatures must carry a nonce, and a
+// nonce seen before is rejected as a replay; the nonces are kept in the
+// SAGE configuration's nonce store, in memory by default.
+//
+// Example:
+//
+//	builder.WithA2ASignatures(httpsig.StaticKeys(map[string]ed25519.PublicKey{
+//	    "did:sage:local:agent-b": agentBKey,
+//	}))
+func (b *Builder) WithA2ASignatures(resolve httpsig.KeyResolver) *Builder {
+	b.a2aSenderKeys = resolve
+	return b
+}
+
 // WithSAGEConfig sets custom SAGE protocol configuration.
 //
 // Required when using ProtocolSAGE mode.
@@ -198,6 +288,21 @@ func (b *Builder) WithStorage(backend storage.Storage) *Builder {
 	return b
 }
 
+// WithTaskStore sets the store recording the agent's tasks.
+//
+// Pass the same store to grpc.ServerConfig.Tasks to let gRPC clients get,
+// list, cancel and follow the tasks of A2A requests. If not called, an
+// in-memory store is used.
+//
+// Example:
+//
+//	tasks := task.NewMemoryStore(nil)
+//	builder.WithTaskStore(tasks)
+func (b *Builder) WithTaskStore(store task.Store) *Builder {
+	b.taskStore = store
+	return b
+}
+
 // OnMessage sets the message handler for the agent.
 //
 // This is the core business logic that processes incoming messages.
@@ -245,6 +350,19 @@ func (b *Builder) AfterStop(hook func(context.Context) error) *Builder {
 	return b
 }
 
+// WithAuthorizer enforces an authorization policy on the agent: inbound
+// messages are authorized before they are handled, and tool calls before
+// they run. It takes precedence over config.Server.AuthzPolicy.
+//
+// Example:
+//
+//	authorizer, err := authz.Open("policy.yaml", nil)
+//	builder.WithAuthorizer(authorizer)
+func (b *Builder) WithAuthorizer(authorizer *authz.Authorizer) *Builder {
+	b.authorizer = authorizer
+	return b
+}
+
 // WithConfig sets custom global configuration.
 //
 // This overrides all default configuration.
@@ -401,7 +519,7 @@ func (b *Builder) validate() error {
 		return nil
 	}
 
-	v := &validator{builder: b}
+	v := &validator{builder: b, errors: append([]error(nil), b.errors...)}
 	v.validateName()
 	v.validateProtocol()
 	v.validateLLM()
@@ -422,11 +540,35 @@ func (b *Builder) validate() error {
 func (b *Builder) buildAgent() (*agent.AgentImpl, error) {
 	// Create server based on protocol mode
 	var srv agent.Server
+	var sageAdapter *sageadapter.Adapter
 	var err error
 
+	authenticator, err := b.serverAuthenticator()
+	if err != nil {
+		return nil, errors.ErrConfigurationError.
+			WithMessage("failed to create authenticator").
+			WithDetail("error", err.Error())
+	}
+
+	if b.protocolMode == protocol.ProtocolSAGE {
+		sageAdapter, err = sageadapter.NewAdapter(b.sageConfig)
+		if err != nil {
+			return nil, errors.ErrOperationFailed.
+				WithMessage("failed to create SAGE adapter").
+				WithDetail("error", err.Error())
+		}
+	}
+
+	authorizer, watchPolicy, err := b.serverAuthorizer(sageAdapter)
+	if err != nil {
+		return nil, errors.ErrConfigurationError.
+			WithMessage("failed to load authorization policy").
+			WithDetail("error", err.Error())
+	}
+
 	switch b.protocolMode {
 	case protocol.ProtocolA2A:
-		srv, err = b.createA2AServer()
+		srv, err = b.createA2AServer(authenticator, authorizer)
 		if err != nil {
 			return nil, errors.ErrOperationFailed.
 				WithMessage("failed to create A2A server").
@@ -434,8 +576,12 @@ func (b *Builder) buildAgent() (*agent.AgentImpl, error) {
 		}
 
 	case protocol.ProtocolSAGE:
-		// TODO: Implement SAGE server creation
-		return nil, errors.ErrNotImplemented.WithMessage("SAGE protocol server not yet implemented")
+		sageServer := sageadapter.NewAgentServer(sageAdapter)
+		sageServer.SetTLS(b.serverTLS())
+		if authenticator != nil {
+			sageServer.SetAuthenticator(authenticator)
+		}
+		srv = sageServer
 
 	case protocol.ProtocolAuto:
 		// TODO: Implement auto-detection server
@@ -455,28 +601,144 @@ func (b *Builder) buildAgent() (*agent.AgentImpl, error) {
 		A2AConfig:      b.a2aConfig,
 		SAGEConfig:     b.sageConfig,
 		LLMProvider:    b.llmProvider,
+		Tools:          b.toolRegistry,
+		Skills:         b.skills,
 		Storage:        b.storageBackend,
 		MessageHandler: b.messageHandler,
 		BeforeStart:    b.beforeStart,
 		AfterStop:      b.afterStop,
 	}
 
+	if authorizer != nil {
+		// Tool calls are authorized for the caller of the message
+		executorConfig := tools.DefaultExecutorConfig()
+		executorConfig.Authorizer = authorizer
+		opts.ToolExecutor = executorConfig
+	}
+
+	// Follow changes to the policy file until the agent stops
+	stopWatch := func() {}
+	if watchPolicy != "" {
+		ctx, cancel := context.WithCancel(context.Background())
+		stopWatch = cancel
+		go authorizer.Watch(ctx, watchPolicy, 0)
+
+		afterStop := b.afterStop
+		opts.AfterStop = func(ctx context.Context) error {
+			stopWatch()
+			if afterStop != nil {
+				return afterStop(ctx)
+			}
+			return nil
+		}
+	}
+
 	// Create agent using agent package
 	ag, err := agent.NewAgentWithOptions(opts)
 	if err != nil {
+		stopWatch()
 		return nil, err
 	}
 
+	// Deliver verified SAGE messages to the agent's handler, once they
+	// are authorized
+	if sageAdapter != nil {
+		handler := middleware.Handler(ag.Process)
+		if authorizer != nil {
+			handler = authorizer.Middleware()(handler)
+		}
+		sageAdapter.SetMessageHandler(sageadapter.MessageHandlerFunc(handler))
+		b.sageAdapter = sageAdapter
+	}
+	b.authenticator = authenticator
+	b.authorizer = authorizer
+
 	// Inject server into agent
 	if err := ag.SetServer(srv); err != nil {
+		stopWatch()
 		return nil, err
 	}
 
 	return ag, nil
 }
 
+// ReloadKeys re-reads the key ring the built agent signs SAGE messages
+// with, for example after "adk keys rotate". It does nothing if the agent
+// has no SAGE adapter or signs without a key ring.
+func (b *Builder) ReloadKeys() error {
+	if b.sageAdapter == nil || b.sageAdapter.KeyRing() == nil {
+		return nil
+	}
+	return b.sageAdapter.ReloadKeyRing()
+}
+
+// serverTLS returns the server TLS settings of the configuration, or nil
+// to serve plain HTTP.
+func (b *Builder) serverTLS() *mtls.Config {
+	if b.config == nil || !b.config.Server.TLS.Enabled() {
+		return nil
+	}
+	return &b.config.Server.TLS
+}
+
+// serverAuthenticator creates the authenticator configured in
+// config.Server.Auth, looking API keys up in the agent's storage when a key
+// namespace is set. It returns nil when authentication is not configured.
+func (b *Builder) serverAuthenticator() (*authn.Authenticator, error) {
+	if b.config == nil {
+		return nil, nil
+	}
+	return authn.NewServerAuthenticator(&b.config.Server.Auth, b.storageBackend)
+}
+
+// serverAuthorizer returns the authorizer set with WithAuthorizer, or
+// else one enforcing config.Server.AuthzPolicy, together with the policy
+// file to watch for changes. Capabilities are resolved with the SAGE
+// adapter, if any. It returns nil when no policy is configured.
+func (b *Builder) serverAuthorizer(sageAdapter *sageadapter.Adapter) (*authz.Authorizer, string, error) {
+	if b.authorizer != nil {
+		return b.authorizer, "", nil
+	}
+	if b.config == nil || b.config.Server.AuthzPolicy == "" {
+		return nil, "", nil
+	}
+
+	// Denials are logged to stderr, which stays free when MCP uses stdio
+	authzConfig := &authz.Config{
+		Logger: logging.NewStructuredLoggerWithOutput(logging.LevelInfo, os.Stderr),
+	}
+	if sageAdapter != nil {
+		authzConfig.Capabilities = sageAdapter
+	}
+
+	policyFile := b.config.Server.AuthzPolicy
+	authorizer, err := authz.Open(policyFile, authzConfig)
+	if err != nil {
+		return nil, "", err
+	}
+	return authorizer, policyFile, nil
+}
+
+// GRPCServerConfig returns the configuration of a gRPC server for the
+// built agent, with the agent's authentication, authorization and TLS
+// settings, so that its RPCs are authenticated and authorized like the
+// agent's own server. Call it after Build.
+//
+// Example:
+//
+//	ag, err := b.Build()
+//	server := grpc.NewServer(ag, b.GRPCServerConfig())
+func (b *Builder) GRPCServerConfig() grpcserver.ServerConfig {
+	config := grpcserver.DefaultServerConfig()
+	config.Authenticator = b.authenticator
+	config.Authorizer = b.authorizer
+	config.TLS = b.serverTLS()
+	config.Tasks = b.taskStore
+	return config
+}
+
 // createA2AServer creates an A2A server with the builder's configuration.
-func (b *Builder) createA2AServer() (agent.Server, error) {
+func (b *Builder) createA2AServer(authenticator *authn.Authenticator, authorizer *authz.Authorizer) (agent.Server, error) {
 	// Build agent URL from config
 	agentURL := b.a2aConfig.ServerURL
 	if agentURL == "" {
@@ -489,8 +751,68 @@ func (b *Builder) createA2AServer() (agent.Server, error) {
 		AgentURL:       agentURL,
 		Description:    "", // TODO: Add description to builder
 		MessageHandler: b.messageHandler,
+		Authenticator:  authenticator,
+		Authorizer:     authorizer,
+		TLS:            b.serverTLS(),
+		Tasks:          b.taskStore,
+	}
+
+	// Enforce the peer protocol policies on inbound requests
+	negotiatorConfig := &protocol.NegotiatorConfig{}
+	if b.config != nil {
+		var err error
+		negotiatorConfig, err = protocol.NegotiatorConfigFrom(&b.config.Protocol)
+		if err != nil {
+			return nil, err
+		}
+	}
+	negotiatorConfig.AgentID = b.name
+	middleware := protocol.Middleware(protocol.NewNegotiator(negotiatorConfig))
+
+	// Require HTTP message signatures on A2A requests. Verification runs
+	// first, so that detection only counts verified requests as SAGE.
+	var verify func(http.Handler) http.Handler
+	switch {
+	case b.a2aSenderKeys != nil:
+		verifierConfig, err := b.a2aVerifierConfig()
+		if err != nil {
+			return nil, err
+		}
+		verify = a2a.VerifySignatures(b.a2aSenderKeys, verifierConfig)
+	case b.sageConfig != nil && b.sageConfig.RequireHTTPSignatures:
+		sageAdapter, err := sageadapter.NewAdapter(b.sageConfig)
+		if err != nil {
+			return nil, err
+		}
+		verify = sageAdapter.HTTPVerifier().Middleware
+	}
+	serverConfig.Middleware = middleware
+	if verify != nil {
+		serverConfig.Middleware = func(next http.Handler) http.Handler {
+			return verify(middleware(next))
+		}
 	}
 
 	// Create server
 	return a2a.NewServer(serverConfig)
 }
+
+// a2aVerifierConfig returns the signature verification settings of the
+// A2A server: a replay guard in the configured nonce store, remembering
+// nonces for as long as signatures are accepted.
+func (b *Builder) a2aVerifierConfig() (*httpsig.VerifierConfig, error) {
+	var nonceStore string
+	maxAge := replay.DefaultMaxClockSkew
+	if b.sageConfig != nil {
+		nonceStore = b.sageConfig.NonceStore
+		if b.sageConfig.MaxClockSkew > 0 {
+			maxAge = b.sageConfig.MaxClockSkew
+		}
+	}
+
+	guard, err := replay.Open(nonceStore, replay.Window(maxAge))
+	if err != nil {
+		return nil, err
+	}
+	return &httpsig.VerifierConfig{MaxAge: maxAge, Guard: guard}, nil
+}
@@ -19,6 +19,8 @@ package builder
 
 import (
 	"context"
+	"os"
+	"path/filepath"
 	"sync"
 	"testing"
 	"time"
@@ -27,6 +29,7 @@ import (
 	"github.com/sage-x-project/sage-adk/config"
 	"github.com/sage-x-project/sage-adk/core/agent"
 	"github.com/sage-x-project/sage-adk/core/protocol"
+	"github.com/sage-x-project/sage-adk/core/tools"
 	"github.com/sage-x-project/sage-adk/pkg/types"
 	"github.com/sage-x-project/sage-adk/storage"
 )
@@ -137,8 +140,6 @@ func TestBuilder_WithSAGE_NoConfig_Error(t *testing.T) {
 }
 
 func TestBuilder_WithSAGE_WithConfig_Success(t *testing.T) {
-	t.Skip("SAGE protocol server not yet implemented")
-
 	sageConfig := &config.SAGEConfig{
 		DID:         "did:sage:ethereum:0x123",
 		Network:     "ethereum",
@@ -485,3 +486,43 @@ func TestBuilder_Idempotent(t *testing.T) {
 		t.Error("Multiple builds produced different agents")
 	}
 }
+
+func TestBuilder_AuthzPolicy(t *testing.T) {
+	policyFile := filepath.Join(t.TempDir(), "policy.yaml")
+	if err := os.WriteFile(policyFile, []byte("default: deny\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg := config.NewConfig()
+	cfg.Server.AuthzPolicy = policyFile
+	registry := tools.NewRegistry()
+	if err := tools.RegisterBuiltinTools(registry); err != nil {
+		t.Fatal(err)
+	}
+	b := NewAgent("authz-agent").WithConfig(cfg).WithTools(registry)
+	ag, err := b.Build()
+	if err != nil {
+		t.Fatalf("Build() error = %v", err)
+	}
+	defer ag.Stop(context.Background())
+
+	if b.authorizer == nil {
+		t.Fatal("Build() did not load the authorization policy")
+	}
+	if got := b.GRPCServerConfig().Authorizer; got != b.authorizer {
+		t.Error("GRPCServerConfig() does not install the authorizer")
+	}
+	call := &llm.ToolCall{ID: "call-1", Type: llm.ToolTypeFunction, Function: &llm.FunctionCall{
+		Name:      "calculator",
+		Arguments: `{"operation":"add","a":1,"b":2}`,
+	}}
+	if res := ag.Executor().Execute(context.Background(), call); res.Status != tools.StatusDenied {
+		t.Errorf("executor status = %q, want %q under a deny-all policy", res.Status, tools.StatusDenied)
+	}
+
+	cfg = config.NewConfig()
+	cfg.Server.AuthzPolicy = filepath.Join(t.TempDir(), "missing.yaml")
+	if _, err := NewAgent("authz-agent").WithConfig(cfg).Build(); err == nil {
+		t.Error("Build() should fail when the policy cannot be loaded")
+	}
+}
@@ -28,6 +28,8 @@ import (
 	"strings"
 	"time"
 
+	"github.com/sage-x-project/sage-adk/adapters/sage/httpsig"
+	"github.com/sage-x-project/sage-adk/core/authn"
 	"github.com/sage-x-project/sage-adk/core/protocol"
 	"github.com/sage-x-project/sage-adk/pkg/errors"
 	"github.com/sage-x-project/sage-adk/pkg/types"
@@ -56,6 +58,12 @@ type Client struct {
 
 	// headers are custom HTTP headers to include in requests
 	headers map[string]string
+
+	// signer adds HTTP message signatures to requests, if set
+	signer *httpsig.Signer
+
+	// tokenSource supplies bearer tokens for requests, if set
+	tokenSource func(ctx context.Context) (string, error)
 }
 
 // NewClient creates a new SAGE ADK client.
@@ -170,6 +178,10 @@ func (c *Client) sendMessageOnce(ctx context.Context, msg *types.Message) (*type
 		req.Header.Set(k, v)
 	}
 
+	if err := c.signRequest(req); err != nil {
+		return nil, err
+	}
+
 	// Send request
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
@@ -197,6 +209,26 @@ func (c *Client) sendMessageOnce(ctx context.Context, msg *types.Message) (*type
 	return &response, nil
 }
 
+// signRequest adds the bearer token and signs req with the configured
+// signer. Signing happens after all headers are set, so custom headers can
+// be covered.
+func (c *Client) signRequest(req *http.Request) error {
+	if c.tokenSource != nil {
+		token, err := c.tokenSource(req.Context())
+		if err != nil {
+			return errors.ErrUnauthorized.
+				WithMessage("failed to obtain bearer token").
+				WithDetail("error", err.Error())
+		}
+		req.Header.Set(authn.HeaderAuthorization, "Bearer "+token)
+	}
+
+	if c.signer == nil {
+		return nil
+	}
+	return c.signer.Sign(req)
+}
+
 // handleErrorResponse converts HTTP error responses to appropriate errors.
 func (c *Client) handleErrorResponse(statusCode int, body []byte) error {
 	// Try to parse error response
@@ -19,6 +19,7 @@ package client
 
 import (
 	"context"
+	"crypto/ed25519"
 	"encoding/json"
 	"fmt"
 	"net/http"
@@ -27,6 +28,8 @@ import (
 	"testing"
 	"time"
 
+	"github.com/sage-x-project/sage-adk/adapters/sage/httpsig"
+	"github.com/sage-x-project/sage-adk/core/authn"
 	"github.com/sage-x-project/sage-adk/core/protocol"
 	"github.com/sage-x-project/sage-adk/pkg/errors"
 	"github.com/sage-x-project/sage-adk/pkg/types"
@@ -158,6 +161,108 @@ func TestClient_SendMessage(t *testing.T) {
 	}
 }
 
+func TestClient_SendMessage_Signed(t *testing.T) {
+	publicKey, privateKey, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		t.Fatalf("GenerateKey() error = %v", err)
+	}
+
+	verifier := httpsig.NewVerifier(httpsig.StaticKeys(map[string]ed25519.PublicKey{
+		"did:sage:local:alice": publicKey,
+	}), nil)
+	server := httptest.NewServer(verifier.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		sender := httpsig.FromContext(r.Context())
+		if sender == nil || sender.DID() != "did:sage:local:alice" {
+			t.Errorf("verified sender = %+v, want did:sage:local:alice", sender)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(types.Message{
+			MessageID: "resp-123",
+			Role:      types.MessageRoleAgent,
+			Kind:      "message",
+			Parts:     []types.Part{&types.TextPart{Kind: "text", Text: "Hello from agent"}},
+		})
+	})))
+	defer server.Close()
+
+	msg := &types.Message{
+		MessageID: "msg-123",
+		Role:      types.MessageRoleUser,
+		Kind:      "message",
+		Parts:     []types.Part{&types.TextPart{Kind: "text", Text: "Hello"}},
+	}
+
+	client, err := NewClient(server.URL, WithSigningKey(privateKey, "did:sage:local:alice#key-1"))
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	if _, err := client.SendMessage(context.Background(), msg); err != nil {
+		t.Fatalf("SendMessage() error = %v", err)
+	}
+
+	// Without a signing key the server rejects the request
+	unsigned, err := NewClient(server.URL, WithRetry(0, 0, 0))
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	if _, err := unsigned.SendMessage(context.Background(), msg); !errors.IsUnauthorized(err) {
+		t.Errorf("unsigned SendMessage() error = %v, want unauthorized", err)
+	}
+}
+
+func TestClient_SendMessage_Authenticated(t *testing.T) {
+	authenticator, err := authn.NewAuthenticator(&authn.Config{
+		APIKeys: []authn.APIKey{{ID: "ci", Key: "ci-secret"}},
+	})
+	if err != nil {
+		t.Fatalf("NewAuthenticator() error = %v", err)
+	}
+
+	server := httptest.NewServer(authenticator.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if principal, ok := authn.PrincipalFromContext(r.Context()); !ok || principal.KeyID != "ci" {
+			t.Errorf("principal = %+v, want key ci", principal)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(types.Message{
+			MessageID: "resp-123",
+			Role:      types.MessageRoleAgent,
+			Kind:      "message",
+			Parts:     []types.Part{&types.TextPart{Kind: "text", Text: "Hello from agent"}},
+		})
+	})))
+	defer server.Close()
+
+	msg := &types.Message{
+		MessageID: "msg-123",
+		Role:      types.MessageRoleUser,
+		Kind:      "message",
+		Parts:     []types.Part{&types.TextPart{Kind: "text", Text: "Hello"}},
+	}
+
+	for name, opt := range map[string]Option{
+		"api key":      WithAPIKey("ci-secret"),
+		"bearer token": WithBearerToken("ci-secret"),
+	} {
+		client, err := NewClient(server.URL, opt)
+		if err != nil {
+			t.Fatalf("failed to create client: %v", err)
+		}
+		if _, err := client.SendMessage(context.Background(), msg); err != nil {
+			t.Errorf("%s: SendMessage() error = %v", name, err)
+		}
+	}
+
+	anonymous, err := NewClient(server.URL, WithRetry(0, 0, 0))
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	if _, err := anonymous.SendMessage(context.Background(), msg); !errors.IsUnauthorized(err) {
+		t.Errorf("anonymous SendMessage() error = %v, want unauthorized", err)
+	}
+}
+
 func TestClient_SendMessage_Retry(t *testing.T) {
 	attempts := 0
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -168,6 +168,32 @@ Use a custom HTTP client for advanced configuration:
 	    client.WithHTTPClient(httpClient),
 	)
 
+# Request Signing
+
+Requests can be signed with the agent's Ed25519 key, so that servers
+verifying RFC 9421 HTTP message signatures know who sent them:
+
+	client, err := client.NewClient(
+	    baseURL,
+	    client.WithSigningKey(privateKey, "did:sage:local:alice#key-1"),
+	)
+
+The signature covers the method, URL, authority and body (through a
+Content-Digest header). Use WithSigner for other components or a rotating
+key; see the httpsig package.
+
+# Authentication
+
+Servers requiring authentication accept an API key or a bearer token:
+
+	client, err := client.NewClient(
+	    baseURL,
+	    client.WithAPIKey(os.Getenv("AGENT_API_KEY")),
+	)
+
+WithBearerToken sends a fixed token; WithTokenSource asks for a token on
+every request, so short-lived JWTs can be refreshed. See core/authn.
+
 # Thread Safety
 
 The Client is safe for concurrent use by multiple goroutines.
@@ -7,12 +7,16 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"strings"
 	"time"
 
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/credentials/insecure"
 	"google.golang.org/grpc/keepalive"
 
+	"github.com/sage-x-project/sage-adk/core/authn"
+	"github.com/sage-x-project/sage-adk/core/task"
 	"github.com/sage-x-project/sage-adk/pkg/types"
 	pb "github.com/sage-x-project/sage-adk/proto/pb"
 	grpcserver "github.com/sage-x-project/sage-adk/server/grpc"
@@ -32,9 +36,18 @@ func NewGRPCClient(target string, opts ...ClientOption) (*GRPCClient, error) {
 		opt(&config)
 	}
 
+	creds := insecure.NewCredentials()
+	if config.TLS.Enabled() {
+		tlsConfig, err := config.TLS.ClientTLS()
+		if err != nil {
+			return nil, fmt.Errorf("invalid TLS configuration: %w", err)
+		}
+		creds = credentials.NewTLS(tlsConfig)
+	}
+
 	// Set up gRPC connection options
 	dialOpts := []grpc.DialOption{
-		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithTransportCredentials(creds),
 		grpc.WithKeepaliveParams(keepalive.ClientParameters{
 			Time:                10 * time.Second,
 			Timeout:             3 * time.Second,
@@ -46,6 +59,15 @@ func NewGRPCClient(target string, opts ...ClientOption) (*GRPCClient, error) {
 		),
 	}
 
+	// Send credentials with every call
+	if config.APIKey != "" || config.TokenSource != nil {
+		dialOpts = append(dialOpts, grpc.WithPerRPCCredentials(&rpcCredentials{
+			apiKey:      config.APIKey,
+			tokenSource: config.TokenSource,
+			requireTLS:  config.TLS.Enabled(),
+		}))
+	}
+
 	// Connect to gRPC server
 	conn, err := grpc.Dial(target, dialOpts...)
 	if err != nil {
@@ -59,6 +81,36 @@ func NewGRPCClient(target string, opts ...ClientOption) (*GRPCClient, error) {
 	}, nil
 }
 
+// rpcCredentials sends an API key or bearer token as call metadata, under
+// the header names the server's authentication interceptors read.
+type rpcCredentials struct {
+	apiKey      string
+	tokenSource func(ctx context.Context) (string, error)
+	requireTLS  bool
+}
+
+// GetRequestMetadata implements credentials.PerRPCCredentials.
+func (c *rpcCredentials) GetRequestMetadata(ctx context.Context, uri ...string) (map[string]string, error) {
+	md := make(map[string]string, 2)
+	if c.apiKey != "" {
+		md[strings.ToLower(authn.HeaderAPIKey)] = c.apiKey
+	}
+	if c.tokenSource != nil {
+		token, err := c.tokenSource(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to obtain bearer token: %w", err)
+		}
+		md[strings.ToLower(authn.HeaderAuthorization)] = "Bearer " + token
+	}
+	return md, nil
+}
+
+// RequireTransportSecurity implements credentials.PerRPCCredentials.
+// Credentials may travel in plaintext only when TLS is not configured.
+func (c *rpcCredentials) RequireTransportSecurity() bool {
+	return c.requireTLS
+}
+
 // SendMessage sends a message via gRPC
 func (c *GRPCClient) SendMessage(ctx context.Context, message *types.Message) (*types.Message, error) {
 	// Apply timeout
@@ -108,6 +160,98 @@ func (c *GRPCClient) SendMessageStream(ctx context.Context) (MessageStream, erro
 	}, nil
 }
 
+// GetTask retrieves a task, with up to historyLength of its most recent
+// messages (0 for all)
+func (c *GRPCClient) GetTask(ctx context.Context, taskID string, historyLength int) (*types.Task, error) {
+	if c.config.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, c.config.Timeout)
+		defer cancel()
+	}
+
+	var response *pb.Task
+	err := c.executeWithRetry(ctx, func() error {
+		var err error
+		response, err = c.client.GetTask(ctx, &pb.GetTaskRequest{
+			TaskId:        taskID,
+			HistoryLength: int32(historyLength),
+		})
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return grpcserver.TaskFromProto(response)
+}
+
+// ListTasks lists the agent's tasks, newest first
+func (c *GRPCClient) ListTasks(ctx context.Context, query *task.Query) (*task.Page, error) {
+	if query == nil {
+		query = &task.Query{}
+	}
+	if c.config.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, c.config.Timeout)
+		defer cancel()
+	}
+
+	req := &pb.ListTasksRequest{
+		ContextId: query.ContextID,
+		State:     grpcserver.TaskStateToProto(query.State),
+		PageSize:  int32(query.PageSize),
+		PageToken: query.PageToken,
+	}
+
+	var response *pb.ListTasksResponse
+	err := c.executeWithRetry(ctx, func() error {
+		var err error
+		response, err = c.client.ListTasks(ctx, req)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	page := &task.Page{NextPageToken: response.NextPageToken}
+	for _, pbTask := range response.Tasks {
+		t, err := grpcserver.TaskFromProto(pbTask)
+		if err != nil {
+			return nil, err
+		}
+		page.Tasks = append(page.Tasks, t)
+	}
+	return page, nil
+}
+
+// CancelTask cancels a task that has not finished
+func (c *GRPCClient) CancelTask(ctx context.Context, taskID string) (*types.Task, error) {
+	if c.config.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, c.config.Timeout)
+		defer cancel()
+	}
+
+	// Not retried: a retry after a lost response would fail as finished
+	response, err := c.client.CancelTask(ctx, &pb.CancelTaskRequest{TaskId: taskID})
+	if err != nil {
+		return nil, err
+	}
+
+	return grpcserver.TaskFromProto(response)
+}
+
+// SubscribeTask streams the updates of a task, starting with its current
+// status. Recv returns io.EOF after the task finishes.
+func (c *GRPCClient) SubscribeTask(ctx context.Context, taskID string) (*TaskSubscription, error) {
+	stream, err := c.client.SubscribeTask(ctx, &pb.SubscribeTaskRequest{TaskId: taskID})
+	if err != nil {
+		return nil, fmt.Errorf("failed to subscribe to task: %w", err)
+	}
+
+	return &TaskSubscription{stream: stream}, nil
+}
+
 // Close closes the gRPC connection
 func (c *GRPCClient) Close() error {
 	if c.conn != nil {
@@ -213,6 +357,21 @@ func (s *grpcMessageStream) Close() error {
 	return s.stream.CloseSend()
 }
 
+// TaskSubscription receives the updates of a task from SubscribeTask
+type TaskSubscription struct {
+	stream pb.AgentService_SubscribeTaskClient
+}
+
+// Recv returns the next update of the task
+func (s *TaskSubscription) Recv() (task.Event, error) {
+	pbEvent, err := s.stream.Recv()
+	if err != nil {
+		return task.Event{}, err
+	}
+
+	return grpcserver.TaskEventFromProto(pbEvent)
+}
+
 // isRetryableError determines if an error is retryable
 func isRetryableError(err error) bool {
 	// gRPC-specific error checking would go here
@@ -18,9 +18,14 @@
 package client
 
 import (
+	"context"
+	"crypto/ed25519"
+	"crypto/tls"
 	"net/http"
 	"time"
 
+	"github.com/sage-x-project/sage-adk/adapters/sage/httpsig"
+	"github.com/sage-x-project/sage-adk/core/authn"
 	"github.com/sage-x-project/sage-adk/core/protocol"
 )
 
@@ -103,3 +108,64 @@ func WithMaxIdleConns(n int) Option {
 		}
 	}
 }
+
+// WithTLSConfig sets the TLS configuration of the connection pool, e.g.
+// one built with mtls.Config.ClientTLS to present a client certificate.
+func WithTLSConfig(config *tls.Config) Option {
+	return func(c *Client) {
+		if c.httpClient == nil {
+			c.httpClient = &http.Client{}
+		}
+		if transport, ok := c.httpClient.Transport.(*http.Transport); ok {
+			transport.TLSClientConfig = config
+		} else {
+			c.httpClient.Transport = &http.Transport{
+				TLSClientConfig: config,
+				IdleConnTimeout: 90 * time.Second,
+			}
+		}
+	}
+}
+
+// WithAPIKey authenticates every request with an API key in the
+// X-API-Key header.
+func WithAPIKey(key string) Option {
+	return func(c *Client) {
+		if c.headers == nil {
+			c.headers = make(map[string]string)
+		}
+		c.headers[authn.HeaderAPIKey] = key
+	}
+}
+
+// WithBearerToken authenticates every request with a static bearer token,
+// such as a JWT or an API key.
+func WithBearerToken(token string) Option {
+	return WithTokenSource(func(context.Context) (string, error) {
+		return token, nil
+	})
+}
+
+// WithTokenSource authenticates every request with a bearer token obtained
+// from source, so that short-lived JWTs can be refreshed.
+func WithTokenSource(source func(ctx context.Context) (string, error)) Option {
+	return func(c *Client) {
+		c.tokenSource = source
+	}
+}
+
+// WithSigningKey signs every request with an RFC 9421 HTTP message
+// signature made with the agent's Ed25519 key. keyID identifies the key to
+// the server, e.g. "did:sage:local:alice#key-1". The request's method, URL,
+// authority and body are covered.
+func WithSigningKey(key ed25519.PrivateKey, keyID string) Option {
+	return WithSigner(httpsig.NewSigner(httpsig.StaticKey(key, keyID), nil))
+}
+
+// WithSigner signs every request with signer, for control over the covered
+// components or to follow a rotating key.
+func WithSigner(signer *httpsig.Signer) Option {
+	return func(c *Client) {
+		c.signer = signer
+	}
+}
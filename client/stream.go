@@ -102,6 +102,10 @@ func (c *Client) StreamMessage(ctx context.Context, msg *types.Message) (<-chan
 		req.Header.Set(k, v)
 	}
 
+	if err := c.signRequest(req); err != nil {
+		return nil, err
+	}
+
 	// Send request
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
@@ -0,0 +1,222 @@
+// Copyright (C) 2025 sage-x-project
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with this program. If not, see <https://www.gnu.org/licenses/>.
+
+// SPDX-License-Identifier: LGPL-3.0-or-later
+
+package main
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/sage-x-project/sage-adk/adapters/sage"
+	"github.com/sage-x-project/sage-adk/adapters/sage/keyring"
+	"github.com/sage-x-project/sage-adk/adapters/sage/keystore"
+	"github.com/sage-x-project/sage-adk/adapters/sage/registry"
+	"github.com/spf13/cobra"
+)
+
+var keysCmd = &cobra.Command{
+	Use:   "keys",
+	Short: "Manage rotating SAGE signing keys",
+	Long: `Manage the key ring a SAGE agent signs with.
+
+A key ring holds an active key that signs messages, a next key that is
+published ahead of time, and retired keys that keep verifying signatures
+during an overlap window after a rotation. Agents use a key ring by setting
+sage.key_ring_path in their configuration.
+
+With --registry, the keys verifiers should accept are published to the
+DID's entry in a local registry after every change.
+
+Private keys in the key ring are encrypted with a passphrase, read from
+SAGE_ADK_KEY_PASSPHRASE, then --passphrase-file, then prompted for on the
+terminal. Agents read it the same way, using sage.passphrase_file.
+
+Example:
+  adk keys init --did did:sage:local:alice
+  adk keys rotate --overlap 48h --registry registry.yaml
+  adk keys list
+  adk keys encrypt agent.pem --out agent.key.json`,
+}
+
+var keysInitCmd = &cobra.Command{
+	Use:   "init",
+	Short: "Create a key ring with an active and a next key",
+	Args:  cobra.NoArgs,
+	RunE:  runKeysInit,
+}
+
+var keysRotateCmd = &cobra.Command{
+	Use:   "rotate",
+	Short: "Promote the next key and retire the active one",
+	Args:  cobra.NoArgs,
+	RunE:  runKeysRotate,
+}
+
+var keysListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List the keys in the key ring",
+	Args:  cobra.NoArgs,
+	RunE:  runKeysList,
+}
+
+var keysEncryptCmd = &cobra.Command{
+	Use:   "encrypt <key-file>",
+	Short: "Encrypt a PEM or JWK private key with a passphrase",
+	Long: `Encrypt a PEM or JWK Ed25519 private key into a passphrase-protected
+keystore file that sage.private_key_path can point to. The passphrase is
+read like the key ring's.`,
+	Args: cobra.ExactArgs(1),
+	RunE: runKeysEncrypt,
+}
+
+var (
+	keysRingPath       string
+	keysRegistry       string
+	keysDID            string
+	keysOverlap        time.Duration
+	keysOut            string
+	keysPassphraseFile string
+	keysKDF            string
+)
+
+func init() {
+	keysCmd.PersistentFlags().StringVar(&keysRingPath, "ring", "keys.json", "Key ring file")
+	keysCmd.PersistentFlags().StringVar(&keysRegistry, "registry", "", "Local DID registry file or URL to publish keys to")
+	keysCmd.PersistentFlags().StringVar(&keysPassphraseFile, "passphrase-file", "", "File containing the key passphrase")
+
+	keysInitCmd.Flags().StringVar(&keysDID, "did", "", "Agent DID (required)")
+	keysInitCmd.MarkFlagRequired("did")
+
+	keysRotateCmd.Flags().DurationVar(&keysOverlap, "overlap", keyring.DefaultOverlap, "How long the retired key keeps verifying signatures")
+
+	keysEncryptCmd.Flags().StringVarP(&keysOut, "out", "o", "", "Encrypted key file (required)")
+	keysEncryptCmd.Flags().StringVar(&keysKDF, "kdf", keystore.KDFArgon2id, "Key derivation function (argon2id, scrypt)")
+	keysEncryptCmd.MarkFlagRequired("out")
+
+	keysCmd.AddCommand(keysInitCmd)
+	keysCmd.AddCommand(keysRotateCmd)
+	keysCmd.AddCommand(keysListCmd)
+	keysCmd.AddCommand(keysEncryptCmd)
+}
+
+func runKeysInit(cmd *cobra.Command, args []string) error {
+	if _, err := os.Stat(keysRingPath); err == nil {
+		return fmt.Errorf("key ring %s already exists", keysRingPath)
+	}
+
+	ring, err := keyring.New(keysDID, time.Now())
+	if err != nil {
+		return err
+	}
+	if err := ring.Save(keysRingPath, keysPassphrase(), nil); err != nil {
+		return err
+	}
+	fmt.Printf("Created key ring %s for %s\n", keysRingPath, keysDID)
+
+	return publishKeys(cmd, ring)
+}
+
+func runKeysRotate(cmd *cobra.Command, args []string) error {
+	// Ask once: loading decrypts the ring, saving encrypts the new key
+	secret, err := keysPassphrase()()
+	if err != nil {
+		return err
+	}
+	passphrase := keystore.StaticPassphrase(secret)
+	ring, err := keyring.Load(keysRingPath, passphrase)
+	if err != nil {
+		return err
+	}
+
+	active, err := ring.Rotate(time.Now(), keysOverlap)
+	if err != nil {
+		return err
+	}
+	if err := ring.Save(keysRingPath, passphrase, nil); err != nil {
+		return err
+	}
+	fmt.Printf("Active key is now %s; the previous key verifies for %s\n", ring.KeyID(active), keysOverlap)
+	fmt.Println("Send SIGHUP to running agents (adk serve) to sign with the new key.")
+
+	return publishKeys(cmd, ring)
+}
+
+func runKeysList(cmd *cobra.Command, args []string) error {
+	// Listing needs the public keys only
+	ring, err := keyring.Load(keysRingPath, nil)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("%s\n", ring.DID())
+	for _, key := range ring.Keys() {
+		expires := "-"
+		if key.NotAfter != nil {
+			expires = key.NotAfter.Format(time.RFC3339)
+		}
+		fmt.Printf("  %s\t%s\tfrom %s\tuntil %s\n", key.ID, key.Status, key.NotBefore.Format(time.RFC3339), expires)
+	}
+	return nil
+}
+
+func runKeysEncrypt(cmd *cobra.Command, args []string) error {
+	if _, err := os.Stat(keysOut); err == nil {
+		return fmt.Errorf("%s already exists", keysOut)
+	}
+
+	km := sage.NewKeyManager()
+	privateKey, err := km.LoadPrivateKey(args[0], nil)
+	if err != nil {
+		return err
+	}
+
+	passphrase, err := keysPassphrase()()
+	if err != nil {
+		return err
+	}
+
+	opts := keystore.DefaultOptions()
+	opts.KDF = keysKDF
+	if err := km.SaveEncrypted(privateKey, keysOut, passphrase, opts); err != nil {
+		return err
+	}
+	fmt.Printf("Wrote encrypted key %s; delete %s once agents use it\n", keysOut, args[0])
+	return nil
+}
+
+// keysPassphrase returns the passphrase source selected by the flags.
+func keysPassphrase() keystore.PassphraseFunc {
+	return keystore.DefaultPassphrase(keysPassphraseFile)
+}
+
+// publishKeys publishes the ring's keys to --registry, if set.
+func publishKeys(cmd *cobra.Command, ring *keyring.Ring) error {
+	if keysRegistry == "" {
+		return nil
+	}
+
+	reg, err := registry.Open(keysRegistry)
+	if err != nil {
+		return err
+	}
+	if err := sage.PublishKeys(cmd.Context(), reg, ring); err != nil {
+		return fmt.Errorf("failed to publish keys: %w", err)
+	}
+	fmt.Printf("Published keys of %s to %s\n", ring.DID(), keysRegistry)
+	return nil
+}
@@ -0,0 +1,206 @@
+// Copyright (C) 2025 sage-x-project
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with this program. If not, see <https://www.gnu.org/licenses/>.
+
+// SPDX-License-Identifier: LGPL-3.0-or-later
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/sage-x-project/sage-adk/adapters/sage/registry"
+	"github.com/spf13/cobra"
+)
+
+var registryCmd = &cobra.Command{
+	Use:   "registry",
+	Short: "Manage a local DID registry",
+	Long: `Manage an offline DID registry for development and tests.
+
+The registry is a JSON or YAML file (or, with an http:// location, a registry
+served by "adk registry serve"). Agents use it instead of a blockchain by
+setting sage.registry in their configuration.
+
+Example:
+  adk registry register --did did:sage:local:alice --public-key <base64>
+  adk registry list
+  adk registry deactivate did:sage:local:alice
+  adk registry serve --addr :8090 --token <secret>
+
+Writes to a served registry need the shared token given to "serve", passed
+with --token or SAGE_ADK_REGISTRY_TOKEN. Without a token the served registry
+is read-only.`,
+}
+
+var registryServeCmd = &cobra.Command{
+	Use:   "serve",
+	Short: "Share the registry file with other agents over HTTP",
+	Args:  cobra.NoArgs,
+	RunE:  runRegistryServe,
+}
+
+var registryRegisterCmd = &cobra.Command{
+	Use:   "register",
+	Short: "Register a DID",
+	Args:  cobra.NoArgs,
+	RunE:  runRegistryRegister,
+}
+
+var registryDeactivateCmd = &cobra.Command{
+	Use:   "deactivate <did>",
+	Short: "Deactivate a DID",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		reg, err := openRegistry()
+		if err != nil {
+			return err
+		}
+		if err := reg.Deactivate(cmd.Context(), args[0]); err != nil {
+			return err
+		}
+		fmt.Printf("Deactivated %s\n", args[0])
+		return nil
+	},
+}
+
+var registryListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List registered DIDs",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		reg, err := openRegistry()
+		if err != nil {
+			return err
+		}
+		records, err := reg.List(cmd.Context())
+		if err != nil {
+			return err
+		}
+		for _, record := range records {
+			status := "active"
+			if !record.IsActive() {
+				status = "deactivated"
+			}
+			fmt.Printf("%s\t%s\t%s\t%s\n", record.DID, status, record.Name, record.Endpoint)
+		}
+		return nil
+	},
+}
+
+// registryTokenEnv is read when --token is not given.
+const registryTokenEnv = "SAGE_ADK_REGISTRY_TOKEN"
+
+var (
+	registryLocation string
+	registryAddr     string
+	registryToken    string
+	registryRecord   registry.Record
+)
+
+func init() {
+	registryCmd.PersistentFlags().StringVarP(&registryLocation, "registry", "r", "registry.yaml", "Registry file or URL")
+
+	registryCmd.PersistentFlags().StringVar(&registryToken, "token", "", "Shared token for registry writes (default $"+registryTokenEnv+")")
+
+	registryServeCmd.Flags().StringVar(&registryAddr, "addr", ":8090", "Listen address")
+
+	registryRegisterCmd.Flags().StringVar(&registryRecord.DID, "did", "", "Agent DID (required)")
+	registryRegisterCmd.Flags().StringVar(&registryRecord.PublicKey, "public-key", "", "Base64 Ed25519 public key (required)")
+	registryRegisterCmd.Flags().StringVar(&registryRecord.KEMKey, "kem-key", "", "Base64 X25519 public key")
+	registryRegisterCmd.Flags().StringVar(&registryRecord.Name, "name", "", "Agent name")
+	registryRegisterCmd.Flags().StringVar(&registryRecord.Endpoint, "endpoint", "", "Agent endpoint")
+	registryRegisterCmd.Flags().StringVar(&registryRecord.Owner, "owner", "", "Owner address")
+	registryRegisterCmd.MarkFlagRequired("did")
+	registryRegisterCmd.MarkFlagRequired("public-key")
+
+	registryCmd.AddCommand(registryServeCmd)
+	registryCmd.AddCommand(registryRegisterCmd)
+	registryCmd.AddCommand(registryDeactivateCmd)
+	registryCmd.AddCommand(registryListCmd)
+}
+
+// sharedToken returns the registry token from --token or registryTokenEnv.
+func sharedToken() string {
+	if registryToken != "" {
+		return registryToken
+	}
+	return os.Getenv(registryTokenEnv)
+}
+
+// openRegistry opens the registry location, sending the shared token to a
+// served registry.
+func openRegistry() (registry.Registry, error) {
+	reg, err := registry.Open(registryLocation)
+	if err != nil {
+		return nil, err
+	}
+	if client, ok := reg.(*registry.Client); ok {
+		client.SetToken(sharedToken())
+	}
+	return reg, nil
+}
+
+func runRegistryRegister(cmd *cobra.Command, args []string) error {
+	reg, err := openRegistry()
+	if err != nil {
+		return err
+	}
+	if err := reg.Register(cmd.Context(), &registryRecord); err != nil {
+		return err
+	}
+	fmt.Printf("Registered %s\n", registryRecord.DID)
+	return nil
+}
+
+func runRegistryServe(cmd *cobra.Command, args []string) error {
+	token := sharedToken()
+	srv := &http.Server{
+		Addr: registryAddr,
+		Handler: registry.Handler(registry.NewFileRegistry(registryLocation), &registry.HandlerConfig{
+			Token: token,
+		}),
+	}
+	if token == "" {
+		log.Printf("⚠️  No --token set; the registry is served read-only")
+	}
+
+	errChan := make(chan error, 1)
+	go func() {
+		log.Printf("📒 DID registry %s on http://%s", registryLocation, registryAddr)
+		if err := srv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			errChan <- fmt.Errorf("server error: %w", err)
+		}
+	}()
+
+	sigChan := make(chan os.Signal, 1)
+	signal.Notify(sigChan, syscall.SIGINT, syscall.SIGTERM)
+
+	select {
+	case <-sigChan:
+	case err := <-errChan:
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	return srv.Shutdown(ctx)
+}
@@ -41,5 +41,8 @@ func init() {
 	rootCmd.AddCommand(initCmd)
 	rootCmd.AddCommand(generateCmd)
 	rootCmd.AddCommand(serveCmd)
+	rootCmd.AddCommand(keysCmd)
+	rootCmd.AddCommand(registryCmd)
+	rootCmd.AddCommand(auditCmd)
 	rootCmd.AddCommand(versionCmd)
 }
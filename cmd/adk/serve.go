@@ -27,10 +27,14 @@ import (
 	"time"
 
 	"github.com/sage-x-project/sage-adk/adapters/llm"
+	"github.com/sage-x-project/sage-adk/adapters/mcp"
 	"github.com/sage-x-project/sage-adk/builder"
 	"github.com/sage-x-project/sage-adk/config"
 	"github.com/sage-x-project/sage-adk/core/agent"
+	"github.com/sage-x-project/sage-adk/core/authn"
 	"github.com/sage-x-project/sage-adk/core/protocol"
+	"github.com/sage-x-project/sage-adk/core/tools"
+	"github.com/sage-x-project/sage-adk/pkg/mtls"
 	"github.com/sage-x-project/sage-adk/storage"
 	"github.com/spf13/cobra"
 )
@@ -49,23 +53,40 @@ Configuration can be provided via:
   - Environment variables
   - Command-line flags (highest priority)
 
+With --mcp, the agent's tools and skills are served over the Model Context
+Protocol instead: "stdio" talks MCP on standard input and output (for IDEs
+and other MCP hosts that launch the agent), "http" serves the streamable
+HTTP transport at /mcp.
+
+With --authz-policy (or server.authzpolicy in the configuration), inbound
+messages and tool calls are checked against an authorization policy file,
+which is reloaded whenever it changes.
+
+Send SIGHUP to reload the SAGE key ring after "adk keys rotate".
+
 Example:
   adk serve
   adk serve --config my-config.yaml
-  adk serve --port 9000 --host 0.0.0.0`,
+  adk serve --port 9000 --host 0.0.0.0
+  adk serve --authz-policy policy.yaml
+  adk serve --mcp stdio`,
 	RunE: runServe,
 }
 
 var (
-	serveConfig string
-	servePort   int
-	serveHost   string
+	serveConfig      string
+	servePort        int
+	serveHost        string
+	serveMCP         string
+	serveAuthzPolicy string
 )
 
 func init() {
 	serveCmd.Flags().StringVarP(&serveConfig, "config", "c", "config.yaml", "Path to configuration file")
 	serveCmd.Flags().IntVarP(&servePort, "port", "p", 8080, "Server port")
 	serveCmd.Flags().StringVar(&serveHost, "host", "0.0.0.0", "Server host")
+	serveCmd.Flags().StringVar(&serveMCP, "mcp", "", "Serve tools and skills over MCP (stdio, http)")
+	serveCmd.Flags().StringVar(&serveAuthzPolicy, "authz-policy", "", "Authorization policy file (overrides the configuration)")
 }
 
 func runServe(cmd *cobra.Command, args []string) error {
@@ -79,9 +100,15 @@ func runServe(cmd *cobra.Command, args []string) error {
 	if err != nil {
 		return fmt.Errorf("failed to load config: %w", err)
 	}
+	if serveAuthzPolicy != "" {
+		cfg.Server.AuthzPolicy = serveAuthzPolicy
+	}
+	if cfg.Server.AuthzPolicy != "" {
+		log.Printf("🛡️  Authorization policy: %s", cfg.Server.AuthzPolicy)
+	}
 
 	// 2. Initialize agent from configuration
-	agentInstance, err := createAgent(cfg, serveHost, servePort)
+	agentInstance, agentBuilder, err := createAgent(cfg, serveHost, servePort)
 	if err != nil {
 		return fmt.Errorf("failed to create agent: %w", err)
 	}
@@ -90,6 +117,36 @@ func runServe(cmd *cobra.Command, args []string) error {
 	sigChan := make(chan os.Signal, 1)
 	signal.Notify(sigChan, syscall.SIGINT, syscall.SIGTERM)
 
+	// SIGHUP reloads the signing key ring, e.g. after "adk keys rotate"
+	hupChan := make(chan os.Signal, 1)
+	signal.Notify(hupChan, syscall.SIGHUP)
+	defer signal.Stop(hupChan)
+	go reloadKeysOnHangup(agentBuilder, hupChan)
+
+	// Select the MCP transport if requested
+	switch serveMCP {
+	case "":
+	case "stdio":
+		return serveMCPStdio(agentInstance, cfg, sigChan)
+	case "http":
+		// The MCP server replaces the protocol server, so it applies the
+		// same authentication and TLS settings
+		mcpServer, err := newMCPServer(agentInstance, cfg)
+		if err != nil {
+			return err
+		}
+		if err := agentInstance.SetServer(mcpServer); err != nil {
+			return err
+		}
+		scheme := "http"
+		if cfg.Server.TLS.Enabled() {
+			scheme = "https"
+		}
+		log.Printf("🔌 MCP: %s://%s:%d/mcp", scheme, serveHost, servePort)
+	default:
+		return fmt.Errorf("unsupported MCP transport: %s", serveMCP)
+	}
+
 	// 4. Start HTTP server in goroutine
 	errChan := make(chan error, 1)
 	go func() {
@@ -119,6 +176,73 @@ func runServe(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+// reloadKeysOnHangup reloads the agent's signing key ring whenever a
+// signal arrives on hup, so rotated keys are used without a restart.
+func reloadKeysOnHangup(b *builder.Builder, hup <-chan os.Signal) {
+	for range hup {
+		if err := b.ReloadKeys(); err != nil {
+			log.Printf("⚠️  Failed to reload signing keys: %v", err)
+			continue
+		}
+		log.Println("🔑 Signing keys reloaded")
+	}
+}
+
+// newMCPServer exposes the agent's tools and skills over MCP. Tool calls go
+// through the agent's executor, so tools needing approval wait for it, and
+// HTTP requests are authenticated and encrypted as configured for the
+// agent's server.
+func newMCPServer(ag *agent.AgentImpl, cfg *config.Config) (*mcp.Server, error) {
+	authenticator, err := authn.NewServerAuthenticator(&cfg.Server.Auth, ag.Storage())
+	if err != nil {
+		return nil, fmt.Errorf("failed to create authenticator: %w", err)
+	}
+
+	var tlsConfig *mtls.Config
+	if cfg.Server.TLS.Enabled() {
+		tlsConfig = &cfg.Server.TLS
+	}
+
+	return mcp.NewServer(&mcp.ServerConfig{
+		Name:          ag.Name(),
+		Version:       ag.Card().Version,
+		Instructions:  ag.Description(),
+		Tools:         ag.Tools(),
+		Executor:      ag.Executor(),
+		Skills:        ag.Card().Skills,
+		Authenticator: authenticator,
+		TLS:           tlsConfig,
+	}), nil
+}
+
+// serveMCPStdio serves MCP on stdin/stdout until the input closes or a
+// signal arrives. Logs go to stderr and do not disturb the protocol.
+func serveMCPStdio(ag *agent.AgentImpl, cfg *config.Config, sigChan <-chan os.Signal) error {
+	server, err := newMCPServer(ag, cfg)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	go func() {
+		select {
+		case <-sigChan:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	log.Println("🔌 MCP: serving on stdio")
+	if err := server.ServeStdio(ctx, os.Stdin, os.Stdout); err != nil && err != context.Canceled {
+		return fmt.Errorf("MCP server error: %w", err)
+	}
+
+	log.Println("✅ MCP server stopped")
+	return nil
+}
+
 // loadConfig loads configuration from the specified file
 func loadConfig(path string) (*config.Config, error) {
 	// Check if config file exists
@@ -137,21 +261,30 @@ func loadConfig(path string) (*config.Config, error) {
 	return cfg, nil
 }
 
-// createAgent creates and configures an agent from the configuration
-func createAgent(cfg *config.Config, host string, port int) (*agent.AgentImpl, error) {
+// createAgent creates and configures an agent from the configuration. It
+// also returns the builder, which reloads the agent's keys.
+func createAgent(cfg *config.Config, host string, port int) (*agent.AgentImpl, *builder.Builder, error) {
 	log.Println("🔧 Initializing agent...")
 
-	// Create agent builder
-	b := builder.NewAgent(cfg.Agent.Name)
+	// Create agent builder. The configuration carries the server's TLS and
+	// authentication settings.
+	b := builder.NewAgent(cfg.Agent.Name).WithConfig(cfg)
 
 	// Configure LLM provider
 	if err := configureLLM(b, cfg); err != nil {
-		return nil, fmt.Errorf("failed to configure LLM: %w", err)
+		return nil, nil, fmt.Errorf("failed to configure LLM: %w", err)
+	}
+
+	// Register built-in tools
+	registry := tools.NewRegistry()
+	if err := tools.RegisterBuiltinTools(registry); err != nil {
+		return nil, nil, fmt.Errorf("failed to register tools: %w", err)
 	}
+	b.WithTools(registry)
 
 	// Configure storage
 	if err := configureStorage(b, cfg); err != nil {
-		return nil, fmt.Errorf("failed to configure storage: %w", err)
+		return nil, nil, fmt.Errorf("failed to configure storage: %w", err)
 	}
 
 	// Configure protocol
@@ -198,11 +331,11 @@ func createAgent(cfg *config.Config, host string, port int) (*agent.AgentImpl, e
 	// Build agent
 	agentInstance, err := b.Build()
 	if err != nil {
-		return nil, fmt.Errorf("failed to build agent: %w", err)
+		return nil, nil, fmt.Errorf("failed to build agent: %w", err)
 	}
 
 	log.Println("✅ Agent initialized successfully")
-	return agentInstance, nil
+	return agentInstance, b, nil
 }
 
 // configureLLM configures the LLM provider from config
@@ -212,60 +345,14 @@ func configureLLM(b *builder.Builder, cfg *config.Config) error {
 		return nil
 	}
 
-	var provider llm.Provider
-	var err error
-
-	switch cfg.LLM.Provider {
-	case "openai":
-		apiKey := os.Getenv("OPENAI_API_KEY")
-		if cfg.LLM.APIKey != "" {
-			apiKey = cfg.LLM.APIKey
-		}
-		if apiKey == "" {
-			return fmt.Errorf("OpenAI API key required (set OPENAI_API_KEY)")
-		}
-		provider = llm.OpenAI(&llm.OpenAIConfig{
-			APIKey: apiKey,
-			Model:  cfg.LLM.Model,
-		})
-		log.Printf("✅ LLM: OpenAI (%s)", cfg.LLM.Model)
-
-	case "anthropic":
-		apiKey := os.Getenv("ANTHROPIC_API_KEY")
-		if cfg.LLM.APIKey != "" {
-			apiKey = cfg.LLM.APIKey
-		}
-		if apiKey == "" {
-			return fmt.Errorf("Anthropic API key required (set ANTHROPIC_API_KEY)")
-		}
-		provider = llm.Anthropic(&llm.AnthropicConfig{
-			APIKey: apiKey,
-			Model:  cfg.LLM.Model,
-		})
-		log.Printf("✅ LLM: Anthropic (%s)", cfg.LLM.Model)
-
-	case "gemini":
-		apiKey := os.Getenv("GEMINI_API_KEY")
-		if cfg.LLM.APIKey != "" {
-			apiKey = cfg.LLM.APIKey
-		}
-		if apiKey == "" {
-			return fmt.Errorf("Gemini API key required (set GEMINI_API_KEY)")
-		}
-		provider = llm.Gemini(&llm.GeminiConfig{
-			APIKey: apiKey,
-			Model:  cfg.LLM.Model,
-		})
-		log.Printf("✅ LLM: Gemini (%s)", cfg.LLM.Model)
-
-	default:
-		return fmt.Errorf("unsupported LLM provider: %s", cfg.LLM.Provider)
-	}
-
+	provider, err := llm.NewProviderFromConfig(&cfg.LLM, cfg.LLM.Provider)
 	if err != nil {
-		return err
+		return fmt.Errorf("failed to create LLM provider %q: %w", cfg.LLM.Provider, err)
 	}
 
+	p, _ := cfg.LLM.Lookup(cfg.LLM.Provider)
+	log.Printf("✅ LLM: %s (type: %s, model: %s)", cfg.LLM.Provider, p.Type, p.Model)
+
 	b.WithLLM(provider)
 	return nil
 }
@@ -0,0 +1,128 @@
+// Copyright (C) 2025 sage-x-project
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with this program. If not, see <https://www.gnu.org/licenses/>.
+
+// SPDX-License-Identifier: LGPL-3.0-or-later
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sage-x-project/sage-adk/config"
+	"github.com/sage-x-project/sage-adk/core/authn"
+)
+
+const testAPIKey = "serve-test-key"
+
+// authConfig returns a configuration of an A2A agent requiring an API key.
+func authConfig() *config.Config {
+	cfg := config.DefaultConfig()
+	cfg.Agent.Name = "serve-test"
+	cfg.Protocol.Mode = "a2a"
+	cfg.A2A.Enabled = true
+	cfg.Server.Auth.APIKeys = []authn.APIKey{{ID: "test", Key: testAPIKey}}
+	return cfg
+}
+
+// freePort returns a local TCP port nothing listens on.
+func freePort(t *testing.T) int {
+	t.Helper()
+
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer l.Close()
+	return l.Addr().(*net.TCPAddr).Port
+}
+
+// post sends a JSON-RPC request to url, with an API key if key is set.
+func post(t *testing.T, url, key string) int {
+	t.Helper()
+
+	body := `{"jsonrpc":"2.0","id":1,"method":"initialize","params":{"protocolVersion":"2024-11-05"}}`
+	req, _ := http.NewRequest(http.MethodPost, url, strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	if key != "" {
+		req.Header.Set(authn.HeaderAPIKey, key)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	return resp.StatusCode
+}
+
+func TestServe_RequiresAuthentication(t *testing.T) {
+	cfg := authConfig()
+	port := freePort(t)
+
+	ag, _, err := createAgent(cfg, "127.0.0.1", port)
+	if err != nil {
+		t.Fatalf("createAgent() error = %v", err)
+	}
+
+	go ag.Start(fmt.Sprintf("127.0.0.1:%d", port))
+	defer ag.Stop(context.Background())
+
+	url := fmt.Sprintf("http://127.0.0.1:%d/", port)
+	for deadline := time.Now().Add(5 * time.Second); ; time.Sleep(10 * time.Millisecond) {
+		if conn, err := net.Dial("tcp", fmt.Sprintf("127.0.0.1:%d", port)); err == nil {
+			conn.Close()
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("server did not start")
+		}
+	}
+
+	if status := post(t, url, ""); status != http.StatusUnauthorized {
+		t.Errorf("unauthenticated status = %d, want 401", status)
+	}
+	if status := post(t, url, testAPIKey); status == http.StatusUnauthorized {
+		t.Errorf("authenticated status = %d", status)
+	}
+}
+
+func TestServe_MCPRequiresAuthentication(t *testing.T) {
+	cfg := authConfig()
+
+	ag, _, err := createAgent(cfg, "127.0.0.1", 8080)
+	if err != nil {
+		t.Fatalf("createAgent() error = %v", err)
+	}
+	server, err := newMCPServer(ag, cfg)
+	if err != nil {
+		t.Fatalf("newMCPServer() error = %v", err)
+	}
+
+	ts := httptest.NewServer(server.Handler())
+	defer ts.Close()
+
+	if status := post(t, ts.URL, ""); status != http.StatusUnauthorized {
+		t.Errorf("unauthenticated status = %d, want 401", status)
+	}
+	if status := post(t, ts.URL, testAPIKey); status != http.StatusOK {
+		t.Errorf("authenticated status = %d, want 200", status)
+	}
+}
@@ -19,6 +19,9 @@ package config
 
 import (
 	"time"
+
+	"github.com/sage-x-project/sage-adk/core/authn"
+	"github.com/sage-x-project/sage-adk/pkg/mtls"
 )
 
 // Config represents the complete configuration for SAGE ADK.
@@ -49,6 +52,19 @@ type ServerConfig struct {
 	ReadTimeout     time.Duration
 	WriteTimeout    time.Duration
 	ShutdownTimeout time.Duration
+
+	// TLS serves the HTTP and gRPC endpoints over TLS, or mutual TLS when
+	// a client CA bundle is set.
+	TLS mtls.Config
+
+	// Auth requires API keys or JWT bearer tokens on the HTTP and gRPC
+	// endpoints when any credential source is configured.
+	Auth authn.Config
+
+	// AuthzPolicy is the authorization policy file (see core/authz).
+	// When set, inbound messages and tool calls are checked against it,
+	// and it is reloaded when it changes.
+	AuthzPolicy string
 }
 
 // ProtocolConfig contains protocol mode and selection settings.
@@ -56,6 +72,13 @@ type ProtocolConfig struct {
 	Mode            string // "a2a", "sage", "auto"
 	AutoDetect      bool
 	DefaultProtocol string // "a2a", "sage"
+
+	// PeerPolicy is the negotiation policy for peers not listed in Peers:
+	// "prefer_sage" (default, downgrade allowed), "require_sage" or "a2a_only".
+	PeerPolicy string
+
+	// Peers overrides PeerPolicy per peer, keyed by DID or agent ID.
+	Peers map[string]string
 }
 
 // A2AConfig contains A2A protocol settings.
@@ -70,24 +93,113 @@ type A2AConfig struct {
 
 // SAGEConfig contains SAGE protocol and security settings.
 type SAGEConfig struct {
-	Enabled         bool          `json:"enabled" yaml:"enabled"`
-	Network         string        `json:"network" yaml:"network"`                   // "ethereum", "kaia", "sepolia", etc.
-	DID             string        `json:"did" yaml:"did"`
-	RPCEndpoint     string        `json:"rpc_endpoint" yaml:"rpc_endpoint"`         // RPC endpoint URL
-	ContractAddress string        `json:"contract_address" yaml:"contract_address"` // Smart contract address
-	PrivateKeyPath  string        `json:"private_key_path" yaml:"private_key_path"`
-	CacheEnabled    bool          `json:"cache_enabled" yaml:"cache_enabled"`
-	CacheTTL        time.Duration `json:"cache_ttl" yaml:"cache_ttl"`
+	Enabled                 bool          `json:"enabled" yaml:"enabled"`
+	Network                 string        `json:"network" yaml:"network"` // "ethereum", "kaia", "sepolia", etc.
+	DID                     string        `json:"did" yaml:"did"`
+	RPCEndpoint             string        `json:"rpc_endpoint" yaml:"rpc_endpoint"`         // RPC endpoint URL
+	ContractAddress         string        `json:"contract_address" yaml:"contract_address"` // Smart contract address
+	PrivateKeyPath          string        `json:"private_key_path" yaml:"private_key_path"`
+	PassphraseFile          string        `json:"passphrase_file,omitempty" yaml:"passphrase_file,omitempty"`                     // Passphrase file of the keystore and key ring
+	KeyRingPath             string        `json:"key_ring_path,omitempty" yaml:"key_ring_path,omitempty"`                         // Rotating signing key ring
+	Registry                string        `json:"registry,omitempty" yaml:"registry,omitempty"`                                   // Local DID registry file or URL
+	NonceStore              string        `json:"nonce_store,omitempty" yaml:"nonce_store,omitempty"`                             // Replay protection: "memory" or a redis:// URL shared by replicas
+	MaxClockSkew            time.Duration `json:"max_clock_skew,omitempty" yaml:"max_clock_skew,omitempty"`                       // Accepted timestamp skew (default 5m)
+	RekeyMessages           int64         `json:"rekey_messages,omitempty" yaml:"rekey_messages,omitempty"`                       // Rekey encrypted sessions after this many messages (default 1000)
+	RekeyBytes              int64         `json:"rekey_bytes,omitempty" yaml:"rekey_bytes,omitempty"`                             // ... or this many bytes (default 64MB)
+	RekeyInterval           time.Duration `json:"rekey_interval,omitempty" yaml:"rekey_interval,omitempty"`                       // ... or this long (default 10m)
+	HTTPSignatureComponents []string      `json:"http_signature_components,omitempty" yaml:"http_signature_components,omitempty"` // RFC 9421 components signed on SAGE HTTP requests (default @method, @target-uri, @authority)
+	HTTPSignatureHeaders    []string      `json:"http_signature_headers,omitempty" yaml:"http_signature_headers,omitempty"`       // Header fields signed as well, e.g. content-type
+	RequireHTTPSignatures   bool          `json:"require_http_signatures,omitempty" yaml:"require_http_signatures,omitempty"`     // Reject SAGE HTTP requests without a valid signature
+	MaxBodyBytes            int64         `json:"max_body_bytes,omitempty" yaml:"max_body_bytes,omitempty"`                       // Largest SAGE HTTP request body accepted (default 10MB)
+	CacheEnabled            bool          `json:"cache_enabled" yaml:"cache_enabled"`
+	CacheTTL                time.Duration `json:"cache_ttl" yaml:"cache_ttl"`
 }
 
 // LLMConfig contains LLM provider configuration.
+//
+// The top-level fields describe a single provider. Several providers can be
+// declared under Providers and selected by name; Provider then names the
+// default one.
 type LLMConfig struct {
-	Provider    string        `json:"provider" yaml:"provider"`       // "openai", "anthropic", "gemini"
-	APIKey      string        `json:"api_key" yaml:"api_key"`
-	Model       string        `json:"model" yaml:"model"`
-	MaxTokens   int           `json:"max_tokens" yaml:"max_tokens"`
-	Temperature float64       `json:"temperature" yaml:"temperature"`
-	Timeout     time.Duration `json:"timeout" yaml:"timeout"`
+	Provider     string                       `json:"provider" yaml:"provider"` // "openai", "anthropic", "gemini" or a key of Providers
+	APIKey       string                       `json:"api_key" yaml:"api_key"`
+	Model        string                       `json:"model" yaml:"model"`
+	BaseURL      string                       `json:"base_url,omitempty" yaml:"base_url,omitempty"`
+	Organization string                       `json:"organization,omitempty" yaml:"organization,omitempty"`
+	ProxyURL     string                       `json:"proxy_url,omitempty" yaml:"proxy_url,omitempty"`
+	MaxTokens    int                          `json:"max_tokens" yaml:"max_tokens"`
+	Temperature  float64                      `json:"temperature" yaml:"temperature"`
+	TopP         float64                      `json:"top_p,omitempty" yaml:"top_p,omitempty"`
+	Timeout      time.Duration                `json:"timeout" yaml:"timeout"`
+	Providers    map[string]LLMProviderConfig `json:"providers,omitempty" yaml:"providers,omitempty"`
+}
+
+// LLMProviderConfig declares a named LLM provider.
+// Zero-valued generation parameters, timeout and proxy inherit the
+// top-level LLMConfig values.
+type LLMProviderConfig struct {
+	Type         string            `json:"type" yaml:"type"` // registered provider type; defaults to the provider name
+	APIKey       string            `json:"api_key" yaml:"api_key"`
+	Model        string            `json:"model" yaml:"model"`
+	BaseURL      string            `json:"base_url,omitempty" yaml:"base_url,omitempty"`
+	Organization string            `json:"organization,omitempty" yaml:"organization,omitempty"`
+	ProxyURL     string            `json:"proxy_url,omitempty" yaml:"proxy_url,omitempty"`
+	MaxTokens    int               `json:"max_tokens,omitempty" yaml:"max_tokens,omitempty"`
+	Temperature  float64           `json:"temperature,omitempty" yaml:"temperature,omitempty"`
+	TopP         float64           `json:"top_p,omitempty" yaml:"top_p,omitempty"`
+	Timeout      time.Duration     `json:"timeout,omitempty" yaml:"timeout,omitempty"`
+	Options      map[string]string `json:"options,omitempty" yaml:"options,omitempty"` // provider-specific settings
+}
+
+// Lookup returns the provider declared under name. An empty name selects
+// Provider. A name that is not a key of Providers but equals Provider
+// resolves to the provider described by the top-level fields.
+func (c *LLMConfig) Lookup(name string) (LLMProviderConfig, bool) {
+	if name == "" {
+		name = c.Provider
+	}
+	if name == "" {
+		return LLMProviderConfig{}, false
+	}
+
+	if p, ok := c.Providers[name]; ok {
+		if p.Type == "" {
+			p.Type = name
+		}
+		if p.ProxyURL == "" {
+			p.ProxyURL = c.ProxyURL
+		}
+		if p.MaxTokens == 0 {
+			p.MaxTokens = c.MaxTokens
+		}
+		if p.Temperature == 0 {
+			p.Temperature = c.Temperature
+		}
+		if p.TopP == 0 {
+			p.TopP = c.TopP
+		}
+		if p.Timeout == 0 {
+			p.Timeout = c.Timeout
+		}
+		return p, true
+	}
+
+	if name != c.Provider {
+		return LLMProviderConfig{}, false
+	}
+
+	return LLMProviderConfig{
+		Type:         c.Provider,
+		APIKey:       c.APIKey,
+		Model:        c.Model,
+		BaseURL:      c.BaseURL,
+		Organization: c.Organization,
+		ProxyURL:     c.ProxyURL,
+		MaxTokens:    c.MaxTokens,
+		Temperature:  c.Temperature,
+		TopP:         c.TopP,
+		Timeout:      c.Timeout,
+	}, true
 }
 
 // StorageConfig contains storage backend configuration.
@@ -272,6 +272,63 @@ func TestConfig_Validate_LLM(t *testing.T) {
 	}
 }
 
+func TestLLMConfig_Lookup(t *testing.T) {
+	cfg := LLMConfig{
+		Provider:    "openai",
+		APIKey:      "sk-top",
+		Model:       "gpt-4",
+		MaxTokens:   2000,
+		Temperature: 0.7,
+		Timeout:     30 * time.Second,
+		Providers: map[string]LLMProviderConfig{
+			"claude": {Type: "anthropic", Model: "claude-3", Temperature: 0.2},
+			"gemini": {},
+		},
+	}
+
+	top, ok := cfg.Lookup("")
+	if !ok || top.Type != "openai" || top.APIKey != "sk-top" {
+		t.Errorf("Lookup(\"\") = %+v, %v", top, ok)
+	}
+
+	claude, ok := cfg.Lookup("claude")
+	if !ok {
+		t.Fatal("Lookup(claude) should succeed")
+	}
+	if claude.Type != "anthropic" || claude.Temperature != 0.2 {
+		t.Errorf("Lookup(claude) = %+v", claude)
+	}
+	if claude.MaxTokens != 2000 || claude.Timeout != 30*time.Second {
+		t.Errorf("Lookup(claude) should inherit defaults, got %+v", claude)
+	}
+
+	gemini, ok := cfg.Lookup("gemini")
+	if !ok || gemini.Type != "gemini" {
+		t.Errorf("Lookup(gemini) = %+v, %v; want type defaulting to name", gemini, ok)
+	}
+
+	if _, ok := cfg.Lookup("missing"); ok {
+		t.Error("Lookup(missing) should fail")
+	}
+}
+
+func TestConfig_Validate_NamedLLMProviders(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.LLM.Provider = "local"
+	cfg.LLM.Providers = map[string]LLMProviderConfig{
+		"local": {Type: "ollama", BaseURL: "http://localhost:11434"},
+	}
+
+	if err := cfg.Validate(); err != nil {
+		t.Errorf("Validate() error = %v, want nil for named provider", err)
+	}
+
+	cfg.LLM.Providers["local"] = LLMProviderConfig{Type: "ollama", MaxTokens: -1}
+	if err := cfg.Validate(); err == nil {
+		t.Error("Validate() should reject negative max tokens in named provider")
+	}
+}
+
 func TestConfig_Validate_Storage(t *testing.T) {
 	tests := []struct {
 		name    string
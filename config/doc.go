@@ -27,7 +27,8 @@
 //
 // The configuration is organized into sections:
 //   - Agent: Agent identity and metadata
-//   - Server: HTTP server settings
+//   - Server: HTTP server settings, including TLS, mutual TLS and API key
+//     or JWT authentication
 //   - Protocol: Protocol mode and selection
 //   - A2A: A2A protocol settings
 //   - SAGE: SAGE protocol and security settings
@@ -65,6 +66,8 @@
 // All configuration is validated before use. Validation rules include:
 //   - Agent name must not be empty
 //   - Server port must be between 1 and 65535
+//   - Server TLS needs a certificate and key, and a CA bundle to verify
+//     client certificates
 //   - Protocol mode must be "a2a", "sage", or "auto"
 //   - LLM provider must be "openai", "anthropic", or "gemini"
 //   - Storage type must be "memory", "redis", or "postgres"
@@ -22,9 +22,14 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
+	"time"
 
 	"gopkg.in/yaml.v3"
+
+	"github.com/sage-x-project/sage-adk/core/authn"
+	"github.com/sage-x-project/sage-adk/pkg/mtls"
 )
 
 // LoadFromFile loads configuration from a file (YAML or JSON).
@@ -91,6 +96,33 @@ func (c *Config) LoadEnv() error {
 			c.Server.Port = port
 		}
 	}
+	if v := os.Getenv("SAGE_ADK_SERVER_TLS_CERT_FILE"); v != "" {
+		c.Server.TLS.CertFile = v
+	}
+	if v := os.Getenv("SAGE_ADK_SERVER_TLS_KEY_FILE"); v != "" {
+		c.Server.TLS.KeyFile = v
+	}
+	if v := os.Getenv("SAGE_ADK_SERVER_TLS_CA_FILE"); v != "" {
+		c.Server.TLS.CAFile = v
+	}
+	if v := os.Getenv("SAGE_ADK_SERVER_TLS_CLIENT_AUTH"); v != "" {
+		c.Server.TLS.ClientAuth = mtls.ClientAuth(v)
+	}
+	if v := os.Getenv("SAGE_ADK_SERVER_AUTH_JWKS_URL"); v != "" {
+		if c.Server.Auth.JWT == nil {
+			c.Server.Auth.JWT = &authn.JWTConfig{}
+		}
+		c.Server.Auth.JWT.JWKSURL = v
+	}
+	if v := os.Getenv("SAGE_ADK_SERVER_AUTH_JWT_ISSUER"); v != "" && c.Server.Auth.JWT != nil {
+		c.Server.Auth.JWT.Issuer = v
+	}
+	if v := os.Getenv("SAGE_ADK_SERVER_AUTH_JWT_AUDIENCE"); v != "" && c.Server.Auth.JWT != nil {
+		c.Server.Auth.JWT.Audience = strings.Split(v, ",")
+	}
+	if v := os.Getenv("SAGE_ADK_SERVER_AUTHZ_POLICY"); v != "" {
+		c.Server.AuthzPolicy = v
+	}
 
 	// SAGE config
 	if v := os.Getenv("SAGE_ADK_SAGE_DID"); v != "" {
@@ -99,6 +131,12 @@ func (c *Config) LoadEnv() error {
 	if v := os.Getenv("SAGE_ADK_SAGE_PRIVATE_KEY_PATH"); v != "" {
 		c.SAGE.PrivateKeyPath = v
 	}
+	if v := os.Getenv("SAGE_ADK_SAGE_PASSPHRASE_FILE"); v != "" {
+		c.SAGE.PassphraseFile = v
+	}
+	if v := os.Getenv("SAGE_ADK_SAGE_KEY_RING_PATH"); v != "" {
+		c.SAGE.KeyRingPath = v
+	}
 	if v := os.Getenv("SAGE_ADK_SAGE_NETWORK"); v != "" {
 		c.SAGE.Network = v
 	}
@@ -108,6 +146,46 @@ func (c *Config) LoadEnv() error {
 	if v := os.Getenv("SAGE_ADK_SAGE_CONTRACT_ADDRESS"); v != "" {
 		c.SAGE.ContractAddress = v
 	}
+	if v := os.Getenv("SAGE_ADK_SAGE_REGISTRY"); v != "" {
+		c.SAGE.Registry = v
+	}
+	if v := os.Getenv("SAGE_ADK_SAGE_NONCE_STORE"); v != "" {
+		c.SAGE.NonceStore = v
+	}
+	if v := os.Getenv("SAGE_ADK_SAGE_MAX_CLOCK_SKEW"); v != "" {
+		if d, err := time.ParseDuration(v); err == nil {
+			c.SAGE.MaxClockSkew = d
+		}
+	}
+	if v := os.Getenv("SAGE_ADK_SAGE_REKEY_MESSAGES"); v != "" {
+		if n, err := strconv.ParseInt(v, 10, 64); err == nil {
+			c.SAGE.RekeyMessages = n
+		}
+	}
+	if v := os.Getenv("SAGE_ADK_SAGE_REKEY_BYTES"); v != "" {
+		if n, err := strconv.ParseInt(v, 10, 64); err == nil {
+			c.SAGE.RekeyBytes = n
+		}
+	}
+	if v := os.Getenv("SAGE_ADK_SAGE_REKEY_INTERVAL"); v != "" {
+		if d, err := time.ParseDuration(v); err == nil {
+			c.SAGE.RekeyInterval = d
+		}
+	}
+	if v := os.Getenv("SAGE_ADK_SAGE_HTTP_SIGNATURE_COMPONENTS"); v != "" {
+		c.SAGE.HTTPSignatureComponents = splitList(v)
+	}
+	if v := os.Getenv("SAGE_ADK_SAGE_HTTP_SIGNATURE_HEADERS"); v != "" {
+		c.SAGE.HTTPSignatureHeaders = splitList(v)
+	}
+	if v := os.Getenv("SAGE_ADK_SAGE_REQUIRE_HTTP_SIGNATURES"); v != "" {
+		c.SAGE.RequireHTTPSignatures = v == "true" || v == "1"
+	}
+	if v := os.Getenv("SAGE_ADK_SAGE_MAX_BODY_BYTES"); v != "" {
+		if n, err := strconv.ParseInt(v, 10, 64); err == nil {
+			c.SAGE.MaxBodyBytes = n
+		}
+	}
 	if v := os.Getenv("SAGE_ADK_SAGE_ENABLED"); v != "" {
 		c.SAGE.Enabled = v == "true" || v == "1"
 	}
@@ -157,10 +235,22 @@ func (c *Config) ValidateSAGE() error {
 		if c.SAGE.Network == "" {
 			return fmt.Errorf("SAGE.Network is required when SAGE is enabled")
 		}
-		if c.SAGE.RPCEndpoint == "" {
-			return fmt.Errorf("SAGE.RPCEndpoint is required when SAGE is enabled")
+		if c.SAGE.RPCEndpoint == "" && c.SAGE.Registry == "" {
+			return fmt.Errorf("SAGE.RPCEndpoint or SAGE.Registry is required when SAGE is enabled")
 		}
 	}
 
 	return nil
 }
+
+// splitList splits a comma-separated environment variable, dropping empty
+// entries.
+func splitList(v string) []string {
+	var list []string
+	for _, item := range strings.Split(v, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			list = append(list, item)
+		}
+	}
+	return list
+}
@@ -20,6 +20,7 @@ package config
 import (
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 )
@@ -169,19 +170,29 @@ func TestLoadFromFile_UnsupportedExtension(t *testing.T) {
 func TestLoadEnv(t *testing.T) {
 	// Set environment variables
 	testEnv := map[string]string{
-		"SAGE_ADK_AGENT_ID":                "env-agent-id",
-		"SAGE_ADK_AGENT_NAME":              "env-agent",
-		"SAGE_ADK_SERVER_HOST":             "env-host",
-		"SAGE_ADK_SERVER_PORT":             "9090",
-		"SAGE_ADK_SAGE_DID":                "did:sage:env:789",
-		"SAGE_ADK_SAGE_PRIVATE_KEY_PATH":   "/env/key",
-		"SAGE_ADK_SAGE_NETWORK":            "sepolia",
-		"SAGE_ADK_SAGE_RPC_ENDPOINT":       "http://sepolia:8545",
-		"SAGE_ADK_SAGE_CONTRACT_ADDRESS":   "0xENV123",
-		"SAGE_ADK_SAGE_ENABLED":            "true",
-		"SAGE_ADK_LLM_PROVIDER":            "anthropic",
-		"SAGE_ADK_LLM_API_KEY":             "sk-env-key",
-		"SAGE_ADK_LLM_MODEL":               "claude-3",
+		"SAGE_ADK_AGENT_ID":                       "env-agent-id",
+		"SAGE_ADK_AGENT_NAME":                     "env-agent",
+		"SAGE_ADK_SERVER_HOST":                    "env-host",
+		"SAGE_ADK_SERVER_PORT":                    "9090",
+		"SAGE_ADK_SERVER_AUTHZ_POLICY":            "/env/policy.yaml",
+		"SAGE_ADK_SAGE_DID":                       "did:sage:env:789",
+		"SAGE_ADK_SAGE_PRIVATE_KEY_PATH":          "/env/key",
+		"SAGE_ADK_SAGE_NETWORK":                   "sepolia",
+		"SAGE_ADK_SAGE_RPC_ENDPOINT":              "http://sepolia:8545",
+		"SAGE_ADK_SAGE_CONTRACT_ADDRESS":          "0xENV123",
+		"SAGE_ADK_SAGE_ENABLED":                   "true",
+		"SAGE_ADK_SAGE_NONCE_STORE":               "redis://redis:6379/0",
+		"SAGE_ADK_SAGE_MAX_CLOCK_SKEW":            "2m",
+		"SAGE_ADK_SAGE_REKEY_MESSAGES":            "500",
+		"SAGE_ADK_SAGE_REKEY_BYTES":               "1048576",
+		"SAGE_ADK_SAGE_REKEY_INTERVAL":            "30m",
+		"SAGE_ADK_SAGE_HTTP_SIGNATURE_COMPONENTS": "@method, @target-uri,,@authority",
+		"SAGE_ADK_SAGE_HTTP_SIGNATURE_HEADERS":    "content-type",
+		"SAGE_ADK_SAGE_REQUIRE_HTTP_SIGNATURES":   "true",
+		"SAGE_ADK_SAGE_MAX_BODY_BYTES":            "65536",
+		"SAGE_ADK_LLM_PROVIDER":                   "anthropic",
+		"SAGE_ADK_LLM_API_KEY":                    "sk-env-key",
+		"SAGE_ADK_LLM_MODEL":                      "claude-3",
 	}
 
 	for k, v := range testEnv {
@@ -203,12 +214,22 @@ func TestLoadEnv(t *testing.T) {
 		{"Agent.Name", cfg.Agent.Name, "env-agent"},
 		{"Server.Host", cfg.Server.Host, "env-host"},
 		{"Server.Port", cfg.Server.Port, 9090},
+		{"Server.AuthzPolicy", cfg.Server.AuthzPolicy, "/env/policy.yaml"},
 		{"SAGE.DID", cfg.SAGE.DID, "did:sage:env:789"},
 		{"SAGE.PrivateKeyPath", cfg.SAGE.PrivateKeyPath, "/env/key"},
 		{"SAGE.Network", cfg.SAGE.Network, "sepolia"},
 		{"SAGE.RPCEndpoint", cfg.SAGE.RPCEndpoint, "http://sepolia:8545"},
 		{"SAGE.ContractAddress", cfg.SAGE.ContractAddress, "0xENV123"},
 		{"SAGE.Enabled", cfg.SAGE.Enabled, true},
+		{"SAGE.NonceStore", cfg.SAGE.NonceStore, "redis://redis:6379/0"},
+		{"SAGE.MaxClockSkew", cfg.SAGE.MaxClockSkew, 2 * time.Minute},
+		{"SAGE.RekeyMessages", cfg.SAGE.RekeyMessages, int64(500)},
+		{"SAGE.RekeyBytes", cfg.SAGE.RekeyBytes, int64(1048576)},
+		{"SAGE.RekeyInterval", cfg.SAGE.RekeyInterval, 30 * time.Minute},
+		{"SAGE.HTTPSignatureComponents", strings.Join(cfg.SAGE.HTTPSignatureComponents, " "), "@method @target-uri @authority"},
+		{"SAGE.HTTPSignatureHeaders", strings.Join(cfg.SAGE.HTTPSignatureHeaders, " "), "content-type"},
+		{"SAGE.RequireHTTPSignatures", cfg.SAGE.RequireHTTPSignatures, true},
+		{"SAGE.MaxBodyBytes", cfg.SAGE.MaxBodyBytes, int64(65536)},
 		{"LLM.Provider", cfg.LLM.Provider, "anthropic"},
 		{"LLM.APIKey", cfg.LLM.APIKey, "sk-env-key"},
 		{"LLM.Model", cfg.LLM.Model, "claude-3"},
@@ -339,6 +360,19 @@ func TestValidateSAGE(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "local registry instead of RPCEndpoint",
+			config: &Config{
+				SAGE: SAGEConfig{
+					Enabled:        true,
+					DID:            "did:sage:test:123",
+					PrivateKeyPath: "/path/to/key",
+					Network:        "local",
+					Registry:       "registry.yaml",
+				},
+			},
+			wantErr: false,
+		},
 		{
 			name: "SAGE disabled - no validation",
 			config: &Config{
@@ -69,6 +69,21 @@ func (c *Config) validateServer() error {
 		return fmt.Errorf("server write timeout must be positive")
 	}
 
+	if c.Server.TLS.Enabled() {
+		if c.Server.TLS.CertFile == "" {
+			return fmt.Errorf("server TLS requires cert_file and key_file")
+		}
+		if err := c.Server.TLS.Validate(); err != nil {
+			return fmt.Errorf("invalid server TLS configuration: %w", err)
+		}
+	}
+
+	if c.Server.Auth.Enabled() {
+		if err := c.Server.Auth.Validate(); err != nil {
+			return fmt.Errorf("invalid server auth configuration: %w", err)
+		}
+	}
+
 	return nil
 }
 
@@ -93,16 +108,48 @@ func (c *Config) validateProtocol() error {
 		return fmt.Errorf("default protocol must be one of: a2a, sage")
 	}
 
+	validPeerPolicies := map[string]bool{
+		"":             true,
+		"prefer_sage":  true,
+		"require_sage": true,
+		"a2a_only":     true,
+	}
+
+	if !validPeerPolicies[c.Protocol.PeerPolicy] {
+		return fmt.Errorf("peer policy must be one of: prefer_sage, require_sage, a2a_only")
+	}
+
+	for peer, policy := range c.Protocol.Peers {
+		if policy == "" || !validPeerPolicies[policy] {
+			return fmt.Errorf("peer %q policy must be one of: prefer_sage, require_sage, a2a_only", peer)
+		}
+	}
+
 	return nil
 }
 
 // validateLLM validates LLM configuration.
 func (c *Config) validateLLM() error {
+	for name, p := range c.LLM.Providers {
+		if p.MaxTokens < 0 {
+			return fmt.Errorf("LLM provider %q: max tokens must not be negative", name)
+		}
+		if p.Temperature < 0 || p.Temperature > 2 {
+			return fmt.Errorf("LLM provider %q: temperature must be between 0 and 2", name)
+		}
+	}
+
 	// If provider is empty, skip validation (LLM is optional)
 	if c.LLM.Provider == "" {
 		return nil
 	}
 
+	// Named providers may use custom types and environment API keys,
+	// which are checked when the provider is created.
+	if _, ok := c.LLM.Providers[c.LLM.Provider]; ok {
+		return nil
+	}
+
 	validProviders := map[string]bool{
 		"openai":    true,
 		"anthropic": true,
@@ -110,7 +157,7 @@ func (c *Config) validateLLM() error {
 	}
 
 	if !validProviders[c.LLM.Provider] {
-		return fmt.Errorf("LLM provider must be one of: openai, anthropic, gemini")
+		return fmt.Errorf("LLM provider must be one of: openai, anthropic, gemini, or a key of LLM.Providers")
 	}
 
 	if c.LLM.APIKey == "" {
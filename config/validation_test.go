@@ -20,6 +20,9 @@ package config
 import (
 	"testing"
 	"time"
+
+	"github.com/sage-x-project/sage-adk/core/authn"
+	"github.com/sage-x-project/sage-adk/pkg/mtls"
 )
 
 func TestConfig_Validate_ServerTimeouts(t *testing.T) {
@@ -209,3 +212,91 @@ func TestConfig_Validate_Postgres(t *testing.T) {
 		})
 	}
 }
+
+func TestConfig_Validate_PeerPolicy(t *testing.T) {
+	tests := []struct {
+		name       string
+		peerPolicy string
+		peers      map[string]string
+		wantErr    bool
+	}{
+		{name: "default", wantErr: false},
+		{name: "require sage", peerPolicy: "require_sage", wantErr: false},
+		{name: "unknown policy", peerPolicy: "sage_please", wantErr: true},
+		{
+			name:    "peer overrides",
+			peers:   map[string]string{"did:sage:eth:0x1": "require_sage", "legacy": "a2a_only"},
+			wantErr: false,
+		},
+		{name: "empty peer policy", peers: map[string]string{"legacy": ""}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := DefaultConfig()
+			cfg.Protocol.PeerPolicy = tt.peerPolicy
+			cfg.Protocol.Peers = tt.peers
+
+			err := cfg.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestConfig_Validate_ServerTLS(t *testing.T) {
+	tests := []struct {
+		name    string
+		tls     mtls.Config
+		wantErr bool
+	}{
+		{name: "disabled", wantErr: false},
+		{name: "server certificate", tls: mtls.Config{CertFile: "s.crt", KeyFile: "s.key"}, wantErr: false},
+		{
+			name:    "mutual TLS",
+			tls:     mtls.Config{CertFile: "s.crt", KeyFile: "s.key", CAFile: "ca.pem", ClientAuth: mtls.ClientAuthRequireAndVerify},
+			wantErr: false,
+		},
+		{name: "CA without certificate", tls: mtls.Config{CAFile: "ca.pem"}, wantErr: true},
+		{name: "unknown client auth", tls: mtls.Config{CertFile: "s.crt", KeyFile: "s.key", ClientAuth: "maybe"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := DefaultConfig()
+			cfg.Server.TLS = tt.tls
+
+			err := cfg.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestConfig_Validate_ServerAuth(t *testing.T) {
+	tests := []struct {
+		name    string
+		auth    authn.Config
+		wantErr bool
+	}{
+		{name: "disabled", wantErr: false},
+		{name: "hashed API key", auth: authn.Config{APIKeys: []authn.APIKey{{ID: "ops", Hash: authn.HashAPIKey("secret")}}}, wantErr: false},
+		{name: "JWKS URL", auth: authn.Config{JWT: &authn.JWTConfig{JWKSURL: "https://issuer/jwks.json"}}, wantErr: false},
+		{name: "API key without id", auth: authn.Config{APIKeys: []authn.APIKey{{Key: "secret"}}}, wantErr: true},
+		{name: "symmetric JWT algorithm", auth: authn.Config{JWT: &authn.JWTConfig{JWKSFile: "jwks.json", Algorithms: []string{"HS256"}}}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := DefaultConfig()
+			cfg.Server.Auth = tt.auth
+
+			err := cfg.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,114 @@
+// Copyright (C) 2025 sage-x-project
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with this program. If not, see <https://www.gnu.org/licenses/>.
+
+// SPDX-License-Identifier: LGPL-3.0-or-later
+
+package agent
+
+import (
+	"context"
+	"errors"
+
+	"github.com/sage-x-project/sage-adk/core/tools"
+	"github.com/sage-x-project/sage-adk/pkg/types"
+)
+
+// Process processes a message and returns a response.
+//
+// With tools configured, the handler finds the agent's executor with
+// tools.ExecutorFromContext. When tool calls of the task are waiting for
+// approval after the handler returns, the response is an approval request
+// and the task waits for input (see tools.ApprovalRequestMessage). The
+// user's next message in the task decides an approval; the handler then
+// runs with it in tools.ApprovalFromContext, to re-execute its call. A
+// reply that does not decide an approval repeats the request without
+// calling the handler.
+func (a *AgentImpl) Process(ctx context.Context, msg *types.Message) (*types.Message, error) {
+	if a.executor == nil || msg.TaskID == nil || *msg.TaskID == "" {
+		if a.executor != nil {
+			ctx = tools.WithExecutor(ctx, a.executor)
+		}
+		return a.agentImpl.Process(ctx, msg)
+	}
+
+	taskID := *msg.TaskID
+	ctx = tools.WithTaskID(tools.WithExecutor(ctx, a.executor), taskID)
+
+	pending, err := a.pendingApprovals(ctx, taskID)
+	if err != nil {
+		return nil, err
+	}
+	if len(pending) > 0 {
+		approval, err := a.approvals.HandleReply(ctx, taskID, messageText(msg))
+		if errors.Is(err, tools.ErrApprovalReplyUnclear) || errors.Is(err, tools.ErrApprovalAmbiguous) {
+			return approvalRequest(msg, pending), nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		ctx = tools.WithApproval(ctx, approval)
+	}
+
+	response, err := a.agentImpl.Process(ctx, msg)
+	if err != nil {
+		return nil, err
+	}
+
+	pending, err = a.pendingApprovals(ctx, taskID)
+	if err != nil {
+		return nil, err
+	}
+	if len(pending) > 0 {
+		return approvalRequest(msg, pending), nil
+	}
+	return response, nil
+}
+
+// pendingApprovals returns the approvals of the task waiting for a
+// decision by the caller recorded in ctx.
+func (a *AgentImpl) pendingApprovals(ctx context.Context, taskID string) ([]*tools.Approval, error) {
+	all, err := a.approvals.Pending(ctx, taskID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Only the requesting caller may decide, or see, its approvals
+	var pending []*tools.Approval
+	for _, approval := range all {
+		if approval.DecidableBy(ctx) {
+			pending = append(pending, approval)
+		}
+	}
+	return pending, nil
+}
+
+// approvalRequest returns the request for the pending approvals, in reply
+// to msg.
+func approvalRequest(msg *types.Message, pending []*tools.Approval) *types.Message {
+	request := tools.ApprovalRequestMessage(pending)
+	request.TaskID = msg.TaskID
+	request.ContextID = msg.ContextID
+	return request
+}
+
+// messageText returns the first text part of msg.
+func messageText(msg *types.Message) string {
+	for _, part := range msg.Parts {
+		if textPart, ok := part.(*types.TextPart); ok {
+			return textPart.Text
+		}
+	}
+	return ""
+}
@@ -0,0 +1,104 @@
+// Copyright (C) 2025 sage-x-project
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with this program. If not, see <https://www.gnu.org/licenses/>.
+
+// SPDX-License-Identifier: LGPL-3.0-or-later
+
+package agent
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+
+	"github.com/sage-x-project/sage-adk/adapters/llm"
+	"github.com/sage-x-project/sage-adk/core/authn"
+	"github.com/sage-x-project/sage-adk/core/task"
+	"github.com/sage-x-project/sage-adk/core/tools"
+	"github.com/sage-x-project/sage-adk/pkg/types"
+	"github.com/sage-x-project/sage-adk/storage"
+)
+
+func TestAgentImpl_Process_Approval(t *testing.T) {
+	var transfers int32
+	registry := tools.NewRegistry()
+	registry.Register(tools.RequireApproval(tools.NewFunctionTool("transfer", "moves money", nil,
+		func(ctx context.Context, params map[string]interface{}) (*tools.Result, error) {
+			atomic.AddInt32(&transfers, 1)
+			return tools.SuccessResult("sent"), nil
+		})))
+
+	ag, err := NewAgentWithOptions(&Options{
+		Name:    "bank",
+		Tools:   registry,
+		Storage: storage.NewMemoryStorage(),
+		MessageHandler: func(ctx context.Context, msg MessageContext) error {
+			executor, ok := tools.ExecutorFromContext(ctx)
+			if !ok {
+				t.Fatal("no executor in context")
+			}
+			call := &llm.ToolCall{ID: "call-1", Type: llm.ToolTypeFunction,
+				Function: &llm.FunctionCall{Name: "transfer", Arguments: `{"amount":10}`}}
+			if approval, ok := tools.ApprovalFromContext(ctx); ok {
+				call = approval.ToolCall()
+			}
+			return msg.Reply(executor.Execute(ctx, call).Status)
+		},
+	})
+	if err != nil {
+		t.Fatalf("NewAgentWithOptions() error = %v", err)
+	}
+
+	taskID := "task-1"
+	sendAs := func(principal *authn.Principal, text string) *types.Message {
+		msg := types.NewMessageWithContext(types.MessageRoleUser, []types.Part{types.NewTextPart(text)}, &taskID, nil)
+		reply, err := ag.Process(authn.WithPrincipal(context.Background(), principal), msg)
+		if err != nil {
+			t.Fatalf("Process(%q) error = %v", text, err)
+		}
+		return reply
+	}
+	alice := &authn.Principal{Subject: "alice", Tenant: "tenant-a"}
+	send := func(text string) *types.Message {
+		return sendAs(alice, text)
+	}
+
+	// The call pauses the task for approval
+	if reply := send("send 10 to bob"); !task.IsInputRequired(reply) {
+		t.Fatalf("reply = %+v, want an approval request", reply)
+	}
+	if atomic.LoadInt32(&transfers) != 0 {
+		t.Fatal("tool ran before approval")
+	}
+
+	// Another caller who knows the task ID cannot approve the call
+	sendAs(&authn.Principal{Subject: "mallory", Tenant: "tenant-a"}, "approve")
+	if atomic.LoadInt32(&transfers) != 0 {
+		t.Fatal("another caller approved the call")
+	}
+
+	// An unclear reply repeats the request
+	if reply := send("what?"); !task.IsInputRequired(reply) {
+		t.Errorf("reply to unclear answer = %+v, want the request again", reply)
+	}
+
+	// Approving runs the call
+	reply := send("approve")
+	if task.IsInputRequired(reply) || reply.Parts[0].(*types.TextPart).Text != tools.StatusSuccess {
+		t.Errorf("reply after approval = %+v", reply)
+	}
+	if atomic.LoadInt32(&transfers) != 1 {
+		t.Errorf("transfers = %d, want 1", transfers)
+	}
+}
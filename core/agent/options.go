@@ -23,6 +23,7 @@ import (
 	"github.com/sage-x-project/sage-adk/adapters/llm"
 	"github.com/sage-x-project/sage-adk/config"
 	"github.com/sage-x-project/sage-adk/core/protocol"
+	"github.com/sage-x-project/sage-adk/core/tools"
 	"github.com/sage-x-project/sage-adk/pkg/errors"
 	"github.com/sage-x-project/sage-adk/pkg/types"
 	"github.com/sage-x-project/sage-adk/storage"
@@ -59,6 +60,16 @@ type Options struct {
 	// LLM provider (optional, for AI capabilities)
 	LLMProvider llm.Provider
 
+	// Tools available to the agent (optional)
+	Tools *tools.Registry
+
+	// ToolExecutor configures how tool calls run (optional). Approvals
+	// default to an approval store in Storage.
+	ToolExecutor *tools.ExecutorConfig
+
+	// Skills advertised in the agent card (optional)
+	Skills []types.AgentSkill
+
 	// Storage backend (required)
 	Storage storage.Storage
 
@@ -90,6 +101,11 @@ type AgentImpl struct {
 	// LLM provider
 	llmProvider llm.Provider
 
+	// Tool registry, and the executor running its tools with approvals
+	tools     *tools.Registry
+	executor  *tools.Executor
+	approvals *tools.ApprovalStore
+
 	// Storage backend
 	storage storage.Storage
 
@@ -127,6 +143,7 @@ func NewAgentWithOptions(opts *Options) (*AgentImpl, error) {
 		Name:        opts.Name,
 		Description: opts.Description,
 		Version:     opts.Version,
+		Skills:      opts.Skills,
 	}
 
 	// Create core agent
@@ -143,6 +160,15 @@ func NewAgentWithOptions(opts *Options) (*AgentImpl, error) {
 	selector := protocol.NewSelector()
 	selector.SetMode(opts.ProtocolMode)
 
+	if opts.Config != nil {
+		negotiatorConfig, err := protocol.NegotiatorConfigFrom(&opts.Config.Protocol)
+		if err != nil {
+			return nil, err
+		}
+		negotiatorConfig.AgentID = opts.Config.Agent.ID
+		selector.SetNegotiator(protocol.NewNegotiator(negotiatorConfig))
+	}
+
 	// TODO: Register protocol adapters based on config
 	// This will be implemented when we have working A2A and SAGE transports
 
@@ -152,6 +178,7 @@ func NewAgentWithOptions(opts *Options) (*AgentImpl, error) {
 		protocolMode:     opts.ProtocolMode,
 		protocolSelector: selector,
 		llmProvider:      opts.LLMProvider,
+		tools:            opts.Tools,
 		storage:          opts.Storage,
 		beforeStart:      opts.BeforeStart,
 		afterStop:        opts.AfterStop,
@@ -159,6 +186,19 @@ func NewAgentWithOptions(opts *Options) (*AgentImpl, error) {
 		sageConfig:       opts.SAGEConfig,
 	}
 
+	if opts.Tools != nil {
+		executorConfig := tools.DefaultExecutorConfig()
+		if opts.ToolExecutor != nil {
+			copied := *opts.ToolExecutor
+			executorConfig = &copied
+		}
+		if executorConfig.Approvals == nil {
+			executorConfig.Approvals = tools.NewApprovalStore(opts.Storage)
+		}
+		agent.approvals = executorConfig.Approvals
+		agent.executor = tools.NewExecutor(opts.Tools, executorConfig)
+	}
+
 	return agent, nil
 }
 
@@ -225,6 +265,21 @@ func (a *AgentImpl) LLMProvider() llm.Provider {
 	return a.llmProvider
 }
 
+// Tools returns the agent's tool registry.
+//
+// Returns nil if no tools are configured.
+func (a *AgentImpl) Tools() *tools.Registry {
+	return a.tools
+}
+
+// Executor returns the executor running the agent's tools, which asks for
+// approval of tools that need it.
+//
+// Returns nil if no tools are configured.
+func (a *AgentImpl) Executor() *tools.Executor {
+	return a.executor
+}
+
 // Storage returns the agent's storage backend.
 func (a *AgentImpl) Storage() storage.Storage {
 	return a.storage
@@ -0,0 +1,215 @@
+// Copyright (C) 2025 sage-x-project
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with this program. If not, see <https://www.gnu.org/licenses/>.
+
+// SPDX-License-Identifier: LGPL-3.0-or-later
+
+package authn
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/sage-x-project/sage-adk/pkg/errors"
+	"github.com/sage-x-project/sage-adk/storage"
+)
+
+const hashPrefix = "sha256:"
+
+// APIKey describes an API key and the principal it authenticates.
+type APIKey struct {
+	// ID names the key in logs and authorization policies. It is never
+	// the secret.
+	ID string `json:"id" yaml:"id"`
+
+	// Key is the secret in plain text. Prefer Hash outside development.
+	Key string `json:"key,omitempty" yaml:"key,omitempty"`
+
+	// Hash is the secret's HashAPIKey digest, "sha256:<hex>".
+	Hash string `json:"hash,omitempty" yaml:"hash,omitempty"`
+
+	// Subject identifies the caller (default: the key ID).
+	Subject string `json:"subject,omitempty" yaml:"subject,omitempty"`
+
+	// Tenant is the caller's tenant.
+	Tenant string `json:"tenant,omitempty" yaml:"tenant,omitempty"`
+
+	// Scopes are granted to the caller.
+	Scopes []string `json:"scopes,omitempty" yaml:"scopes,omitempty"`
+
+	// ExpiresAt, if set, is when the key stops being accepted.
+	ExpiresAt time.Time `json:"expires_at,omitempty" yaml:"expires_at,omitempty"`
+}
+
+// HashAPIKey returns the digest under which an API key secret is stored.
+// API keys are random and long, so a fast hash is sufficient.
+func HashAPIKey(secret string) string {
+	sum := sha256.Sum256([]byte(secret))
+	return hashPrefix + hex.EncodeToString(sum[:])
+}
+
+// digest returns the hash configured for the key, computing it from a
+// plain-text key.
+func (k *APIKey) digest() (string, error) {
+	switch {
+	case k.ID == "":
+		return "", errors.ErrMissingField.WithMessage("API key id is required")
+	case (k.Key == "") == (k.Hash == ""):
+		return "", errors.ErrInvalidInput.
+			WithMessage("API key needs exactly one of key or hash").
+			WithDetail("id", k.ID)
+	case k.Key != "":
+		return HashAPIKey(k.Key), nil
+	}
+
+	hash := strings.ToLower(k.Hash)
+	digest, ok := strings.CutPrefix(hash, hashPrefix)
+	if raw, err := hex.DecodeString(digest); !ok || err != nil || len(raw) != sha256.Size {
+		return "", errors.ErrInvalidFormat.
+			WithMessage("API key hash must be sha256:<64 hex digits>").
+			WithDetail("id", k.ID)
+	}
+	return hash, nil
+}
+
+// principal returns the principal the key authenticates.
+func (k *APIKey) principal() *Principal {
+	subject := k.Subject
+	if subject == "" {
+		subject = k.ID
+	}
+	return &Principal{
+		Subject:   subject,
+		Method:    MethodAPIKey,
+		KeyID:     k.ID,
+		Tenant:    k.Tenant,
+		Scopes:    k.Scopes,
+		ExpiresAt: k.ExpiresAt,
+	}
+}
+
+// KeyStore looks up API keys by the HashAPIKey digest of their secret.
+type KeyStore interface {
+	// LookupAPIKey returns the key with the given hash, or nil if there
+	// is none.
+	LookupAPIKey(ctx context.Context, hash string) (*APIKey, error)
+}
+
+// StaticKeys is a fixed set of API keys, typically from the configuration.
+type StaticKeys struct {
+	keys map[string]*APIKey
+}
+
+// NewStaticKeys indexes keys by hash. Plain-text secrets are not retained.
+func NewStaticKeys(keys []APIKey) (*StaticKeys, error) {
+	s := &StaticKeys{keys: make(map[string]*APIKey, len(keys))}
+	for i := range keys {
+		hash, err := keys[i].digest()
+		if err != nil {
+			return nil, err
+		}
+		if _, dup := s.keys[hash]; dup {
+			return nil, errors.ErrAlreadyExists.
+				WithMessage("duplicate API key").
+				WithDetail("id", keys[i].ID)
+		}
+
+		key := keys[i]
+		key.Key = ""
+		key.Hash = hash
+		s.keys[hash] = &key
+	}
+	return s, nil
+}
+
+// LookupAPIKey implements KeyStore.
+func (s *StaticKeys) LookupAPIKey(ctx context.Context, hash string) (*APIKey, error) {
+	return s.keys[hash], nil
+}
+
+// DefaultKeyNamespace is the storage namespace of API keys.
+const DefaultKeyNamespace = "api_keys"
+
+// StorageKeys keeps API keys in a storage backend, so they can be issued
+// and revoked at runtime and shared between instances.
+type StorageKeys struct {
+	store     storage.Storage
+	namespace string
+}
+
+// NewStorageKeys returns a key store on store, in namespace (default
+// DefaultKeyNamespace).
+func NewStorageKeys(store storage.Storage, namespace string) *StorageKeys {
+	if namespace == "" {
+		namespace = DefaultKeyNamespace
+	}
+	return &StorageKeys{store: store, namespace: namespace}
+}
+
+// Add stores a key. Only its hash is persisted.
+func (s *StorageKeys) Add(ctx context.Context, key APIKey) error {
+	hash, err := key.digest()
+	if err != nil {
+		return err
+	}
+	key.Key = ""
+	key.Hash = hash
+	return s.store.Store(ctx, s.namespace, storageKey(hash), &key)
+}
+
+// Remove revokes the key with the given secret hash.
+func (s *StorageKeys) Remove(ctx context.Context, hash string) error {
+	return s.store.Delete(ctx, s.namespace, storageKey(strings.ToLower(hash)))
+}
+
+// LookupAPIKey implements KeyStore.
+func (s *StorageKeys) LookupAPIKey(ctx context.Context, hash string) (*APIKey, error) {
+	value, err := s.store.Get(ctx, s.namespace, storageKey(hash))
+	if errors.Is(err, storage.ErrNotFound) || errors.IsNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	switch v := value.(type) {
+	case *APIKey:
+		return v, nil
+	case APIKey:
+		return &v, nil
+	}
+
+	// Persistent backends return decoded JSON
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	var key APIKey
+	if err := json.Unmarshal(data, &key); err != nil {
+		return nil, errors.ErrInvalidFormat.
+			WithMessage("stored API key is malformed").
+			WithDetail("error", err.Error())
+	}
+	return &key, nil
+}
+
+// storageKey strips the algorithm prefix, which backends may treat as a
+// separator.
+func storageKey(hash string) string {
+	return strings.TrimPrefix(hash, hashPrefix)
+}
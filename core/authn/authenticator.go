@@ -0,0 +1,262 @@
+// Copyright (C) 2025 sage-x-project
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with this program. If not, see <https://www.gnu.org/licenses/>.
+
+// SPDX-License-Identifier: LGPL-3.0-or-later
+
+package authn
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/sage-x-project/sage-adk/pkg/errors"
+	"github.com/sage-x-project/sage-adk/storage"
+)
+
+// Credential headers. gRPC metadata uses the same names in lower case.
+const (
+	HeaderAPIKey        = "X-API-Key"
+	HeaderAuthorization = "Authorization"
+)
+
+// DefaultPublicPaths are the HTTP paths served without credentials when
+// Config.PublicPaths is nil: health checks and agent card discovery.
+var DefaultPublicPaths = []string{
+	"/health",
+	"/.well-known/agent.json",
+	"/.well-known/agent-card.json",
+}
+
+// Config configures an Authenticator.
+type Config struct {
+	// APIKeys are accepted API keys.
+	APIKeys []APIKey `json:"api_keys,omitempty" yaml:"api_keys,omitempty"`
+
+	// KeyNamespace, if set, also looks API keys up in the storage
+	// backend under this namespace (see StorageKeys).
+	KeyNamespace string `json:"key_namespace,omitempty" yaml:"key_namespace,omitempty"`
+
+	// JWT validates bearer tokens that are JWTs.
+	JWT *JWTConfig `json:"jwt,omitempty" yaml:"jwt,omitempty"`
+
+	// Optional lets requests without credentials through anonymously.
+	// Invalid credentials are still rejected.
+	Optional bool `json:"optional,omitempty" yaml:"optional,omitempty"`
+
+	// PublicPaths are HTTP paths served without authentication (default
+	// DefaultPublicPaths).
+	PublicPaths []string `json:"public_paths,omitempty" yaml:"public_paths,omitempty"`
+
+	// KeyStores are additional API key sources, e.g. StorageKeys.
+	KeyStores []KeyStore `json:"-" yaml:"-"`
+}
+
+// Enabled reports whether any credential source is configured.
+func (c *Config) Enabled() bool {
+	return c != nil && (len(c.APIKeys) > 0 || c.KeyNamespace != "" || len(c.KeyStores) > 0 || c.JWT.Enabled())
+}
+
+// Validate checks the settings without loading any key.
+func (c *Config) Validate() error {
+	if _, err := NewStaticKeys(c.APIKeys); err != nil {
+		return err
+	}
+	if c.JWT != nil {
+		return c.JWT.Validate()
+	}
+	return nil
+}
+
+// Credentials are what a caller presented.
+type Credentials struct {
+	// APIKey is the X-API-Key value.
+	APIKey string
+
+	// Bearer is the token of an "Authorization: Bearer" header.
+	Bearer string
+}
+
+// Empty reports whether no credential was presented.
+func (c Credentials) Empty() bool {
+	return c.APIKey == "" && c.Bearer == ""
+}
+
+// CredentialsFromRequest extracts the credentials of an HTTP request.
+func CredentialsFromRequest(r *http.Request) Credentials {
+	return Credentials{
+		APIKey: r.Header.Get(HeaderAPIKey),
+		Bearer: BearerToken(r.Header.Get(HeaderAuthorization)),
+	}
+}
+
+// BearerToken returns the token of an Authorization header value, or ""
+// if it is not a bearer credential.
+func BearerToken(authorization string) string {
+	scheme, token, ok := strings.Cut(strings.TrimSpace(authorization), " ")
+	if !ok || !strings.EqualFold(scheme, "Bearer") {
+		return ""
+	}
+	return strings.TrimSpace(token)
+}
+
+// Authenticator verifies API keys and JWT bearer tokens. It is safe for
+// concurrent use.
+type Authenticator struct {
+	stores   []KeyStore
+	jwt      *JWTValidator
+	optional bool
+	public   map[string]bool
+	now      func() time.Time
+}
+
+// NewAuthenticator creates an authenticator. KeyNamespace requires a
+// StorageKeys in config.KeyStores; NewServerAuthenticator adds one for the
+// agent's storage backend.
+func NewAuthenticator(config *Config) (*Authenticator, error) {
+	if !config.Enabled() {
+		return nil, errors.ErrConfigurationError.WithMessage("authentication requires API keys or JWT settings")
+	}
+
+	a := &Authenticator{optional: config.Optional, public: make(map[string]bool), now: time.Now}
+
+	if len(config.APIKeys) > 0 {
+		static, err := NewStaticKeys(config.APIKeys)
+		if err != nil {
+			return nil, err
+		}
+		a.stores = append(a.stores, static)
+	}
+	a.stores = append(a.stores, config.KeyStores...)
+
+	if config.JWT != nil {
+		jwt, err := NewJWTValidator(config.JWT)
+		if err != nil {
+			return nil, err
+		}
+		a.jwt = jwt
+	}
+
+	paths := config.PublicPaths
+	if paths == nil {
+		paths = DefaultPublicPaths
+	}
+	for _, path := range paths {
+		a.public[path] = true
+	}
+	return a, nil
+}
+
+// NewServerAuthenticator creates the authenticator of an agent server,
+// looking API keys up in store when config.KeyNamespace is set. It returns
+// nil when config does not enable authentication.
+func NewServerAuthenticator(config *Config, store storage.Storage) (*Authenticator, error) {
+	if !config.Enabled() {
+		return nil, nil
+	}
+
+	copied := *config
+	if copied.KeyNamespace != "" && store != nil {
+		storageKeys := NewStorageKeys(store, copied.KeyNamespace)
+		copied.KeyStores = append([]KeyStore{storageKeys}, copied.KeyStores...)
+	}
+	return NewAuthenticator(&copied)
+}
+
+// Authenticate verifies the credentials. Without credentials it returns
+// nil and no error if authentication is optional. A bearer token is
+// validated as a JWT when it looks like one, and otherwise looked up as an
+// API key.
+func (a *Authenticator) Authenticate(ctx context.Context, creds Credentials) (*Principal, error) {
+	switch {
+	case creds.APIKey != "":
+		return a.authenticateAPIKey(ctx, creds.APIKey)
+	case creds.Bearer != "" && a.jwt != nil && strings.Count(creds.Bearer, ".") == 2:
+		return a.jwt.Validate(ctx, creds.Bearer)
+	case creds.Bearer != "":
+		return a.authenticateAPIKey(ctx, creds.Bearer)
+	case a.optional:
+		return nil, nil
+	}
+	return nil, errors.ErrUnauthorized.WithMessage("authentication required")
+}
+
+func (a *Authenticator) authenticateAPIKey(ctx context.Context, secret string) (*Principal, error) {
+	hash := HashAPIKey(secret)
+	for _, store := range a.stores {
+		key, err := store.LookupAPIKey(ctx, hash)
+		if err != nil {
+			return nil, errors.ErrOperationFailed.
+				WithMessage("API key lookup failed").
+				WithDetail("error", err.Error())
+		}
+		if key == nil {
+			continue
+		}
+		if !key.ExpiresAt.IsZero() && a.now().After(key.ExpiresAt) {
+			return nil, errors.ErrInvalidCredentials.
+				WithMessage("API key expired").
+				WithDetail("id", key.ID)
+		}
+		return key.principal(), nil
+	}
+	return nil, errors.ErrInvalidCredentials.WithMessage("unknown API key")
+}
+
+// Public reports whether an HTTP path is served without authentication.
+func (a *Authenticator) Public(path string) bool {
+	return a.public[path]
+}
+
+// Middleware authenticates HTTP requests and records the principal in the
+// request context. Failures are answered with 401 Unauthorized.
+func (a *Authenticator) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if a.Public(r.URL.Path) {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		principal, err := a.Authenticate(r.Context(), CredentialsFromRequest(r))
+		if err != nil {
+			writeUnauthorized(w, err)
+			return
+		}
+		if principal != nil {
+			r = r.WithContext(WithPrincipal(r.Context(), principal))
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// writeUnauthorized answers a failed authentication. Lookup failures are
+// server errors, not bad credentials.
+func writeUnauthorized(w http.ResponseWriter, err error) {
+	status := http.StatusUnauthorized
+	challenge := `Bearer realm="sage-adk"`
+	switch {
+	case errors.Is(err, errors.ErrInvalidCredentials):
+		challenge += `, error="invalid_token"`
+	case !errors.IsUnauthorized(err):
+		status = http.StatusServiceUnavailable
+	}
+
+	w.Header().Set("WWW-Authenticate", challenge)
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
+}
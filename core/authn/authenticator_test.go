@@ -0,0 +1,175 @@
+// Copyright (C) 2025 sage-x-project
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with this program. If not, see <https://www.gnu.org/licenses/>.
+
+// SPDX-License-Identifier: LGPL-3.0-or-later
+
+package authn
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/sage-x-project/sage-adk/observability/logging"
+	"github.com/sage-x-project/sage-adk/pkg/errors"
+	"github.com/sage-x-project/sage-adk/storage"
+)
+
+func TestAuthenticator_APIKeys(t *testing.T) {
+	store := NewStorageKeys(storage.NewMemoryStorage(), "")
+	if err := store.Add(context.Background(), APIKey{ID: "runtime", Key: "runtime-secret", Tenant: "acme"}); err != nil {
+		t.Fatal(err)
+	}
+
+	a, err := NewAuthenticator(&Config{
+		APIKeys: []APIKey{
+			{ID: "dev", Key: "dev-secret", Scopes: []string{"chat"}},
+			{ID: "ops", Hash: HashAPIKey("ops-secret"), Subject: "ops-team"},
+			{ID: "old", Key: "old-secret", ExpiresAt: time.Now().Add(-time.Hour)},
+		},
+		KeyStores: []KeyStore{store},
+	})
+	if err != nil {
+		t.Fatalf("NewAuthenticator() error = %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		creds   Credentials
+		subject string
+		wantErr *errors.Error
+	}{
+		{name: "plain key", creds: Credentials{APIKey: "dev-secret"}, subject: "dev"},
+		{name: "hashed key", creds: Credentials{APIKey: "ops-secret"}, subject: "ops-team"},
+		{name: "key as bearer", creds: Credentials{Bearer: "ops-secret"}, subject: "ops-team"},
+		{name: "stored key", creds: Credentials{APIKey: "runtime-secret"}, subject: "runtime"},
+		{name: "unknown key", creds: Credentials{APIKey: "guess"}, wantErr: errors.ErrInvalidCredentials},
+		{name: "expired key", creds: Credentials{APIKey: "old-secret"}, wantErr: errors.ErrInvalidCredentials},
+		{name: "no credentials", wantErr: errors.ErrUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			principal, err := a.Authenticate(context.Background(), tt.creds)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("Authenticate() error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Authenticate() error = %v", err)
+			}
+			if principal.Subject != tt.subject || principal.Method != MethodAPIKey {
+				t.Errorf("principal = %+v, want subject %q", principal, tt.subject)
+			}
+		})
+	}
+
+	// Revoked keys are rejected
+	if err := store.Remove(context.Background(), HashAPIKey("runtime-secret")); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := a.Authenticate(context.Background(), Credentials{APIKey: "runtime-secret"}); err == nil {
+		t.Error("revoked key accepted")
+	}
+}
+
+func TestNewServerAuthenticator(t *testing.T) {
+	if a, err := NewServerAuthenticator(&Config{}, nil); a != nil || err != nil {
+		t.Errorf("NewServerAuthenticator() without settings = %v, %v, want nil", a, err)
+	}
+
+	backend := storage.NewMemoryStorage()
+	keys := NewStorageKeys(backend, "keys")
+	if err := keys.Add(context.Background(), APIKey{ID: "stored", Key: "stored-secret"}); err != nil {
+		t.Fatal(err)
+	}
+
+	a, err := NewServerAuthenticator(&Config{KeyNamespace: "keys"}, backend)
+	if err != nil {
+		t.Fatalf("NewServerAuthenticator() error = %v", err)
+	}
+	if _, err := a.Authenticate(context.Background(), Credentials{APIKey: "stored-secret"}); err != nil {
+		t.Errorf("Authenticate() with a stored key error = %v", err)
+	}
+}
+
+func TestAuthenticator_Middleware(t *testing.T) {
+	a, err := NewAuthenticator(&Config{APIKeys: []APIKey{{ID: "dev", Key: "dev-secret", Tenant: "acme"}}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var principal *Principal
+	var userID string
+	handler := a.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		principal, _ = PrincipalFromContext(r.Context())
+		userID = logging.GetUserID(r.Context())
+	}))
+
+	serve := func(path string, header http.Header) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, path, nil)
+		for k, v := range header {
+			req.Header[k] = v
+		}
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec
+	}
+
+	rec := serve("/", http.Header{"Authorization": {"Bearer dev-secret"}})
+	if rec.Code != http.StatusOK || principal == nil || principal.Tenant != "acme" || userID != "dev" {
+		t.Fatalf("authenticated request: code %d, principal %+v, user %q", rec.Code, principal, userID)
+	}
+
+	rec = serve("/", http.Header{"X-Api-Key": {"wrong"}})
+	if rec.Code != http.StatusUnauthorized || rec.Header().Get("WWW-Authenticate") == "" {
+		t.Errorf("bad key: code %d, challenge %q", rec.Code, rec.Header().Get("WWW-Authenticate"))
+	}
+
+	if rec = serve("/", nil); rec.Code != http.StatusUnauthorized {
+		t.Errorf("anonymous request: code %d", rec.Code)
+	}
+
+	principal = nil
+	if rec = serve("/.well-known/agent.json", nil); rec.Code != http.StatusOK || principal != nil {
+		t.Errorf("public path: code %d, principal %+v", rec.Code, principal)
+	}
+}
+
+func TestConfig_Validate(t *testing.T) {
+	tests := []struct {
+		name   string
+		config Config
+	}{
+		{"key without id", Config{APIKeys: []APIKey{{Key: "secret"}}}},
+		{"key and hash", Config{APIKeys: []APIKey{{ID: "a", Key: "secret", Hash: HashAPIKey("secret")}}}},
+		{"bad hash", Config{APIKeys: []APIKey{{ID: "a", Hash: "md5:abc"}}}},
+		{"duplicate key", Config{APIKeys: []APIKey{{ID: "a", Key: "s"}, {ID: "b", Key: "s"}}}},
+		{"JWT without keys", Config{JWT: &JWTConfig{Issuer: "https://issuer"}}},
+		{"JWT with HS256", Config{JWT: &JWTConfig{JWKSFile: "jwks.json", Algorithms: []string{"HS256"}}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.config.Validate(); err == nil {
+				t.Error("Validate() error = nil")
+			}
+		})
+	}
+}
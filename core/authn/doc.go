@@ -0,0 +1,51 @@
+// Copyright (C) 2025 sage-x-project
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with this program. If not, see <https://www.gnu.org/licenses/>.
+
+// SPDX-License-Identifier: LGPL-3.0-or-later
+
+// Package authn authenticates callers of the HTTP and gRPC endpoints with
+// API keys and JWT bearer tokens.
+//
+// An Authenticator establishes who the caller is; core/authz then decides
+// what the caller may do. API keys are listed in the configuration, in
+// plain text or as SHA-256 hashes, or kept in a storage backend. JWTs are
+// verified against a JSON Web Key Set read from a local file or fetched
+// from a URL and cached, and their issuer, audience and expiry are
+// checked:
+//
+//	auth:
+//	  api_keys:
+//	    - id: ops
+//	      hash: sha256:9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08
+//	      subject: ops-team
+//	      tenant: internal
+//	  jwt:
+//	    jwks_url: https://auth.example.com/.well-known/jwks.json
+//	    issuer: https://auth.example.com/
+//	    audience: ["sage-agent"]
+//
+// Callers send an API key in the X-API-Key header, or either credential as
+// "Authorization: Bearer <token>"; gRPC callers use the same names as
+// metadata keys. Middleware protects HTTP servers and the interceptors in
+// server/grpc protect gRPC servers:
+//
+//	authenticator, err := authn.NewAuthenticator(&cfg.Server.Auth)
+//	handler = authenticator.Middleware(handler)
+//
+// The authenticated Principal, with the JWT claims, is recorded in the
+// request context for handlers (PrincipalFromContext), for authorization
+// policies, for rate limiting (ratelimit.PerPrincipalKeyFunc) and for
+// logging, which reports the principal's subject as user_id.
+package authn
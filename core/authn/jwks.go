@@ -0,0 +1,330 @@
+// Copyright (C) 2025 sage-x-project
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with this program. If not, see <https://www.gnu.org/licenses/>.
+
+// SPDX-License-Identifier: LGPL-3.0-or-later
+
+package authn
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/sage-x-project/sage-adk/pkg/errors"
+)
+
+// maxJWKSSize bounds the size of a fetched key set.
+const maxJWKSSize = 1 << 20
+
+// jwk is a JSON Web Key (RFC 7517) as it appears in a key set.
+type jwk struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	Alg string `json:"alg"`
+	Crv string `json:"crv"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+}
+
+// publicKey is a verification key from a key set.
+type publicKey struct {
+	id  string
+	alg string
+	key crypto.PublicKey
+}
+
+// parseJWKS parses a key set, skipping encryption keys and key types it
+// cannot verify with.
+func parseJWKS(data []byte) ([]publicKey, error) {
+	var set struct {
+		Keys []jwk `json:"keys"`
+	}
+	if err := json.Unmarshal(data, &set); err != nil {
+		return nil, errors.ErrInvalidFormat.
+			WithMessage("invalid JWKS document").
+			WithDetail("error", err.Error())
+	}
+
+	var keys []publicKey
+	for _, k := range set.Keys {
+		if k.Use != "" && k.Use != "sig" {
+			continue
+		}
+		key, err := k.publicKey()
+		if err != nil {
+			return nil, errors.ErrInvalidFormat.
+				WithMessage("invalid JWKS key").
+				WithDetail("kid", k.Kid).
+				WithDetail("error", err.Error())
+		}
+		if key != nil {
+			keys = append(keys, publicKey{id: k.Kid, alg: k.Alg, key: key})
+		}
+	}
+	if len(keys) == 0 {
+		return nil, errors.ErrInvalidInput.WithMessage("JWKS contains no signature keys")
+	}
+	return keys, nil
+}
+
+// publicKey decodes the key; it returns nil for unsupported key types.
+func (k *jwk) publicKey() (crypto.PublicKey, error) {
+	switch k.Kty {
+	case "RSA":
+		n, err := decodeInt(k.N)
+		if err != nil {
+			return nil, err
+		}
+		e, err := decodeInt(k.E)
+		if err != nil {
+			return nil, err
+		}
+		if !e.IsInt64() || e.Int64() > 1<<31-1 {
+			return nil, fmt.Errorf("RSA exponent too large")
+		}
+		return &rsa.PublicKey{N: n, E: int(e.Int64())}, nil
+
+	case "EC":
+		var curve elliptic.Curve
+		switch k.Crv {
+		case "P-256":
+			curve = elliptic.P256()
+		case "P-384":
+			curve = elliptic.P384()
+		case "P-521":
+			curve = elliptic.P521()
+		default:
+			return nil, nil
+		}
+		x, err := decodeInt(k.X)
+		if err != nil {
+			return nil, err
+		}
+		y, err := decodeInt(k.Y)
+		if err != nil {
+			return nil, err
+		}
+		if !curve.IsOnCurve(x, y) {
+			return nil, fmt.Errorf("point is not on curve %s", k.Crv)
+		}
+		return &ecdsa.PublicKey{Curve: curve, X: x, Y: y}, nil
+
+	case "OKP":
+		if k.Crv != "Ed25519" {
+			return nil, nil
+		}
+		x, err := base64.RawURLEncoding.DecodeString(k.X)
+		if err != nil {
+			return nil, err
+		}
+		if len(x) != ed25519.PublicKeySize {
+			return nil, fmt.Errorf("invalid Ed25519 key size %d", len(x))
+		}
+		return ed25519.PublicKey(x), nil
+	}
+	return nil, nil
+}
+
+func decodeInt(s string) (*big.Int, error) {
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, err
+	}
+	if len(b) == 0 {
+		return nil, fmt.Errorf("empty key parameter")
+	}
+	return new(big.Int).SetBytes(b), nil
+}
+
+// keySet caches the keys of a JWKS file or URL. Keys are reloaded once
+// they are older than ttl, or early when a token names an unknown key ID.
+// A failed reload keeps the previous keys. Fetches run outside mu and
+// concurrent lookups share a single in-flight reload.
+type keySet struct {
+	fetch      func(ctx context.Context) ([]byte, error)
+	ttl        time.Duration
+	minRefresh time.Duration
+	now        func() time.Time
+
+	mu       sync.Mutex
+	keys     []publicKey
+	fetched  time.Time
+	err      error
+	inflight *keyFetch
+}
+
+// keyFetch is a reload in progress; done is closed once err is set.
+type keyFetch struct {
+	done chan struct{}
+	err  error
+}
+
+// minRefreshInterval limits refetches triggered by unknown key IDs.
+const minRefreshInterval = 10 * time.Second
+
+func newKeySet(fetch func(ctx context.Context) ([]byte, error), ttl time.Duration) *keySet {
+	minRefresh := minRefreshInterval
+	if ttl < minRefresh {
+		minRefresh = ttl
+	}
+	return &keySet{fetch: fetch, ttl: ttl, minRefresh: minRefresh, now: time.Now}
+}
+
+// fileFetcher reads a JWKS file.
+func fileFetcher(file string) func(context.Context) ([]byte, error) {
+	return func(context.Context) ([]byte, error) {
+		return os.ReadFile(file)
+	}
+}
+
+// urlFetcher downloads a JWKS document.
+func urlFetcher(url string, client *http.Client) func(context.Context) ([]byte, error) {
+	return func(ctx context.Context) ([]byte, error) {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("Accept", "application/json")
+
+		resp, err := client.Do(req)
+		if err != nil {
+			return nil, err
+		}
+		defer resp.Body.Close()
+
+		if resp.StatusCode != http.StatusOK {
+			return nil, fmt.Errorf("JWKS endpoint returned %s", resp.Status)
+		}
+		return io.ReadAll(io.LimitReader(resp.Body, maxJWKSSize))
+	}
+}
+
+// lookup returns the keys that may have signed a token with the given key
+// ID and algorithm.
+func (s *keySet) lookup(ctx context.Context, kid, alg string) ([]publicKey, error) {
+	s.mu.Lock()
+	age := s.now().Sub(s.fetched)
+	stale := s.keys == nil || age >= s.ttl
+	if stale && s.keys == nil && s.err != nil && age < s.minRefresh {
+		err := s.err
+		s.mu.Unlock()
+		return nil, err
+	}
+	s.mu.Unlock()
+
+	if stale {
+		if err := s.refresh(ctx); err != nil {
+			s.mu.Lock()
+			empty := s.keys == nil
+			s.mu.Unlock()
+			if empty {
+				return nil, err
+			}
+		}
+	}
+
+	s.mu.Lock()
+	matches := s.match(kid, alg)
+	rotate := len(matches) == 0 && kid != "" && s.now().Sub(s.fetched) >= s.minRefresh
+	s.mu.Unlock()
+
+	if rotate {
+		// The issuer may have rotated its keys
+		if err := s.refresh(ctx); err == nil {
+			s.mu.Lock()
+			matches = s.match(kid, alg)
+			s.mu.Unlock()
+		}
+	}
+	return matches, nil
+}
+
+// refresh reloads the keys, or waits for a reload already in progress. It
+// must be called without mu held; the lock is only taken to publish the
+// result.
+func (s *keySet) refresh(ctx context.Context) error {
+	s.mu.Lock()
+	if f := s.inflight; f != nil {
+		s.mu.Unlock()
+		select {
+		case <-f.done:
+			return f.err
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	f := &keyFetch{done: make(chan struct{})}
+	s.inflight = f
+	// Back off from a failing source too
+	s.fetched = s.now()
+	s.mu.Unlock()
+
+	keys, err := s.load(ctx)
+
+	s.mu.Lock()
+	if err != nil {
+		s.err = err
+	} else {
+		s.keys, s.err = keys, nil
+	}
+	s.inflight = nil
+	s.mu.Unlock()
+
+	f.err = err
+	close(f.done)
+	return err
+}
+
+// load fetches and parses the key set.
+func (s *keySet) load(ctx context.Context) ([]publicKey, error) {
+	data, err := s.fetch(ctx)
+	if err != nil {
+		return nil, errors.ErrNetworkUnavailable.
+			WithMessage("failed to load JWKS").
+			WithDetail("error", err.Error())
+	}
+	return parseJWKS(data)
+}
+
+// match returns the cached keys for kid and alg; it must be called with mu
+// held.
+func (s *keySet) match(kid, alg string) []publicKey {
+	var matches []publicKey
+	for _, k := range s.keys {
+		if kid != "" && k.id != kid {
+			continue
+		}
+		if k.alg != "" && k.alg != alg {
+			continue
+		}
+		matches = append(matches, k)
+	}
+	return matches
+}
@@ -0,0 +1,370 @@
+// Copyright (C) 2025 sage-x-project
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with this program. If not, see <https://www.gnu.org/licenses/>.
+
+// SPDX-License-Identifier: LGPL-3.0-or-later
+
+package authn
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	_ "crypto/sha256" // register hash functions for crypto.Hash
+	_ "crypto/sha512"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math"
+	"math/big"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/sage-x-project/sage-adk/pkg/errors"
+)
+
+// Defaults for JWTConfig.
+const (
+	DefaultJWKSCacheTTL = 10 * time.Minute
+	DefaultJWTLeeway    = time.Minute
+)
+
+// DefaultJWTAlgorithms are the signature algorithms accepted by default.
+// Symmetric algorithms are not supported: keys come from a public JWKS.
+var DefaultJWTAlgorithms = []string{
+	"RS256", "RS384", "RS512",
+	"PS256", "PS384", "PS512",
+	"ES256", "ES384", "ES512",
+	"EdDSA",
+}
+
+// JWTConfig configures JWT bearer token validation.
+type JWTConfig struct {
+	// JWKSFile is a local JSON Web Key Set with the issuer's keys.
+	JWKSFile string `json:"jwks_file,omitempty" yaml:"jwks_file,omitempty"`
+
+	// JWKSURL is fetched for the issuer's keys when JWKSFile is not set.
+	JWKSURL string `json:"jwks_url,omitempty" yaml:"jwks_url,omitempty"`
+
+	// CacheTTL is how long keys are cached before they are reloaded
+	// (default DefaultJWKSCacheTTL). Tokens naming an unknown key ID
+	// trigger an early reload.
+	CacheTTL time.Duration `json:"cache_ttl,omitempty" yaml:"cache_ttl,omitempty"`
+
+	// Issuer, if set, must equal the "iss" claim.
+	Issuer string `json:"issuer,omitempty" yaml:"issuer,omitempty"`
+
+	// Audience, if set, must contain one of the "aud" claim values.
+	Audience []string `json:"audience,omitempty" yaml:"audience,omitempty"`
+
+	// Algorithms restricts the accepted algorithms (default
+	// DefaultJWTAlgorithms).
+	Algorithms []string `json:"algorithms,omitempty" yaml:"algorithms,omitempty"`
+
+	// Leeway tolerates clock skew in the exp and nbf checks (default
+	// DefaultJWTLeeway).
+	Leeway time.Duration `json:"leeway,omitempty" yaml:"leeway,omitempty"`
+
+	// TenantClaim names the claim holding the caller's tenant (optional).
+	TenantClaim string `json:"tenant_claim,omitempty" yaml:"tenant_claim,omitempty"`
+
+	// ScopeClaim names the claim holding the granted scopes, a
+	// space-separated string or a list (default "scope").
+	ScopeClaim string `json:"scope_claim,omitempty" yaml:"scope_claim,omitempty"`
+
+	// HTTPClient fetches JWKSURL (default: a client with a 10 second
+	// timeout).
+	HTTPClient *http.Client `json:"-" yaml:"-"`
+}
+
+// Enabled reports whether a key source is configured.
+func (c *JWTConfig) Enabled() bool {
+	return c != nil && (c.JWKSFile != "" || c.JWKSURL != "")
+}
+
+// Validate checks the settings without loading any key.
+func (c *JWTConfig) Validate() error {
+	if !c.Enabled() {
+		return errors.ErrMissingField.WithMessage("JWT validation requires jwks_file or jwks_url")
+	}
+	for _, alg := range c.Algorithms {
+		if _, err := algorithmFor(alg); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// JWTValidator validates JWT bearer tokens. It is safe for concurrent use.
+type JWTValidator struct {
+	config     JWTConfig
+	keys       *keySet
+	algorithms map[string]bool
+	now        func() time.Time
+}
+
+// NewJWTValidator creates a validator. Keys are loaded on first use.
+func NewJWTValidator(config *JWTConfig) (*JWTValidator, error) {
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+
+	v := &JWTValidator{config: *config, now: time.Now, algorithms: make(map[string]bool)}
+	if v.config.CacheTTL <= 0 {
+		v.config.CacheTTL = DefaultJWKSCacheTTL
+	}
+	if v.config.Leeway <= 0 {
+		v.config.Leeway = DefaultJWTLeeway
+	}
+	if v.config.ScopeClaim == "" {
+		v.config.ScopeClaim = "scope"
+	}
+
+	algorithms := v.config.Algorithms
+	if len(algorithms) == 0 {
+		algorithms = DefaultJWTAlgorithms
+	}
+	for _, alg := range algorithms {
+		v.algorithms[alg] = true
+	}
+
+	fetch := fileFetcher(v.config.JWKSFile)
+	if v.config.JWKSFile == "" {
+		client := v.config.HTTPClient
+		if client == nil {
+			client = &http.Client{Timeout: 10 * time.Second}
+		}
+		fetch = urlFetcher(v.config.JWKSURL, client)
+	}
+	v.keys = newKeySet(fetch, v.config.CacheTTL)
+	return v, nil
+}
+
+// Validate verifies the token's signature and claims and returns the
+// principal it authenticates.
+func (v *JWTValidator) Validate(ctx context.Context, token string) (*Principal, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, invalidToken("malformed token")
+	}
+
+	var header struct {
+		Alg string `json:"alg"`
+		Kid string `json:"kid"`
+	}
+	if err := decodeSegment(parts[0], &header); err != nil {
+		return nil, invalidToken("malformed header")
+	}
+	if !v.algorithms[header.Alg] {
+		return nil, invalidToken("algorithm not accepted").WithDetail("alg", header.Alg)
+	}
+	alg, err := algorithmFor(header.Alg)
+	if err != nil {
+		return nil, invalidToken("algorithm not accepted").WithDetail("alg", header.Alg)
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, invalidToken("malformed signature")
+	}
+
+	keys, err := v.keys.lookup(ctx, header.Kid, header.Alg)
+	if err != nil {
+		return nil, err
+	}
+	signed := []byte(parts[0] + "." + parts[1])
+	verified := false
+	for _, key := range keys {
+		if alg.verify(key.key, signed, signature) {
+			verified = true
+			break
+		}
+	}
+	if !verified {
+		return nil, invalidToken("signature verification failed").WithDetail("kid", header.Kid)
+	}
+
+	var claims map[string]interface{}
+	if err := decodeSegment(parts[1], &claims); err != nil {
+		return nil, invalidToken("malformed claims")
+	}
+	return v.principal(claims)
+}
+
+// principal checks the registered claims and builds the principal.
+func (v *JWTValidator) principal(claims map[string]interface{}) (*Principal, error) {
+	now := v.now()
+
+	exp, ok := numericDate(claims["exp"])
+	if !ok {
+		return nil, invalidToken("token has no expiry")
+	}
+	if now.After(exp.Add(v.config.Leeway)) {
+		return nil, invalidToken("token expired").WithDetail("exp", exp.Format(time.RFC3339))
+	}
+	if nbf, ok := numericDate(claims["nbf"]); ok && now.Add(v.config.Leeway).Before(nbf) {
+		return nil, invalidToken("token not yet valid").WithDetail("nbf", nbf.Format(time.RFC3339))
+	}
+
+	if v.config.Issuer != "" && claims["iss"] != v.config.Issuer {
+		return nil, invalidToken("unexpected issuer").WithDetail("iss", fmt.Sprint(claims["iss"]))
+	}
+	if len(v.config.Audience) > 0 && !containsAny(stringList(claims["aud"]), v.config.Audience) {
+		return nil, invalidToken("unexpected audience")
+	}
+
+	subject, _ := claims["sub"].(string)
+	if subject == "" {
+		return nil, invalidToken("token has no subject")
+	}
+
+	principal := &Principal{
+		Subject:   subject,
+		Method:    MethodJWT,
+		Scopes:    stringList(claims[v.config.ScopeClaim]),
+		Claims:    claims,
+		ExpiresAt: exp,
+	}
+	if v.config.TenantClaim != "" {
+		principal.Tenant, _ = claims[v.config.TenantClaim].(string)
+	}
+	return principal, nil
+}
+
+func invalidToken(reason string) *errors.Error {
+	return errors.ErrInvalidCredentials.WithMessage("invalid bearer token: " + reason)
+}
+
+func decodeSegment(segment string, v interface{}) error {
+	data, err := base64.RawURLEncoding.DecodeString(segment)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, v)
+}
+
+// numericDate converts a JWT NumericDate claim.
+func numericDate(v interface{}) (time.Time, bool) {
+	f, ok := v.(float64)
+	if !ok || math.IsNaN(f) || math.IsInf(f, 0) {
+		return time.Time{}, false
+	}
+	sec, frac := math.Modf(f)
+	return time.Unix(int64(sec), int64(frac*1e9)), true
+}
+
+// stringList reads a claim that is either a space-separated string or a
+// list of strings.
+func stringList(v interface{}) []string {
+	switch v := v.(type) {
+	case string:
+		return strings.Fields(v)
+	case []interface{}:
+		list := make([]string, 0, len(v))
+		for _, item := range v {
+			if s, ok := item.(string); ok {
+				list = append(list, s)
+			}
+		}
+		return list
+	}
+	return nil
+}
+
+func containsAny(list, want []string) bool {
+	for _, s := range list {
+		for _, w := range want {
+			if s == w {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// curveBits is the curve size ES256, ES384 and ES512 require.
+var curveBits = map[crypto.Hash]int{crypto.SHA256: 256, crypto.SHA384: 384, crypto.SHA512: 521}
+
+// algorithm verifies JWS signatures of one algorithm.
+type algorithm struct {
+	hash crypto.Hash
+	kind string // "rsa", "pss", "ecdsa" or "eddsa"
+}
+
+func algorithmFor(name string) (algorithm, error) {
+	switch name {
+	case "RS256":
+		return algorithm{crypto.SHA256, "rsa"}, nil
+	case "RS384":
+		return algorithm{crypto.SHA384, "rsa"}, nil
+	case "RS512":
+		return algorithm{crypto.SHA512, "rsa"}, nil
+	case "PS256":
+		return algorithm{crypto.SHA256, "pss"}, nil
+	case "PS384":
+		return algorithm{crypto.SHA384, "pss"}, nil
+	case "PS512":
+		return algorithm{crypto.SHA512, "pss"}, nil
+	case "ES256":
+		return algorithm{crypto.SHA256, "ecdsa"}, nil
+	case "ES384":
+		return algorithm{crypto.SHA384, "ecdsa"}, nil
+	case "ES512":
+		return algorithm{crypto.SHA512, "ecdsa"}, nil
+	case "EdDSA":
+		return algorithm{0, "eddsa"}, nil
+	}
+	return algorithm{}, errors.ErrInvalidValue.
+		WithMessage("unsupported JWT algorithm").
+		WithDetail("alg", name)
+}
+
+// verify reports whether signature is valid for signed under key. Keys of
+// the wrong type never verify.
+func (a algorithm) verify(key crypto.PublicKey, signed, signature []byte) bool {
+	if a.kind == "eddsa" {
+		k, ok := key.(ed25519.PublicKey)
+		return ok && ed25519.Verify(k, signed, signature)
+	}
+
+	h := a.hash.New()
+	h.Write(signed)
+	digest := h.Sum(nil)
+
+	switch a.kind {
+	case "rsa":
+		k, ok := key.(*rsa.PublicKey)
+		return ok && rsa.VerifyPKCS1v15(k, a.hash, digest, signature) == nil
+	case "pss":
+		k, ok := key.(*rsa.PublicKey)
+		return ok && rsa.VerifyPSS(k, a.hash, digest, signature, nil) == nil
+	case "ecdsa":
+		k, ok := key.(*ecdsa.PublicKey)
+		if !ok || k.Curve.Params().BitSize != curveBits[a.hash] {
+			return false
+		}
+		// JWS encodes the signature as fixed-size r || s
+		size := (k.Curve.Params().BitSize + 7) / 8
+		if len(signature) != 2*size {
+			return false
+		}
+		r := new(big.Int).SetBytes(signature[:size])
+		s := new(big.Int).SetBytes(signature[size:])
+		return ecdsa.Verify(k, digest, r, s)
+	}
+	return false
+}
@@ -0,0 +1,321 @@
+// Copyright (C) 2025 sage-x-project
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with this program. If not, see <https://www.gnu.org/licenses/>.
+
+// SPDX-License-Identifier: LGPL-3.0-or-later
+
+package authn
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/sage-x-project/sage-adk/pkg/errors"
+)
+
+var b64 = base64.RawURLEncoding
+
+// testSigner signs tokens with one key and describes it as a JWK.
+type testSigner struct {
+	kid string
+	alg string
+	key crypto.Signer
+}
+
+func (s *testSigner) jwk() map[string]string {
+	switch pub := s.key.Public().(type) {
+	case *rsa.PublicKey:
+		return map[string]string{"kty": "RSA", "kid": s.kid, "n": b64.EncodeToString(pub.N.Bytes()), "e": b64.EncodeToString(big.NewInt(int64(pub.E)).Bytes())}
+	case *ecdsa.PublicKey:
+		return map[string]string{"kty": "EC", "kid": s.kid, "crv": "P-256", "x": b64.EncodeToString(pub.X.FillBytes(make([]byte, 32))), "y": b64.EncodeToString(pub.Y.FillBytes(make([]byte, 32)))}
+	case ed25519.PublicKey:
+		return map[string]string{"kty": "OKP", "kid": s.kid, "crv": "Ed25519", "x": b64.EncodeToString(pub)}
+	}
+	return nil
+}
+
+func (s *testSigner) sign(t *testing.T, claims map[string]interface{}) string {
+	t.Helper()
+
+	header, _ := json.Marshal(map[string]string{"alg": s.alg, "kid": s.kid, "typ": "JWT"})
+	payload, _ := json.Marshal(claims)
+	signed := b64.EncodeToString(header) + "." + b64.EncodeToString(payload)
+
+	var sig []byte
+	var err error
+	switch key := s.key.(type) {
+	case ed25519.PrivateKey:
+		sig = ed25519.Sign(key, []byte(signed))
+	case *ecdsa.PrivateKey:
+		digest := sha256.Sum256([]byte(signed))
+		r, s, e := ecdsa.Sign(rand.Reader, key, digest[:])
+		err = e
+		sig = append(r.FillBytes(make([]byte, 32)), s.FillBytes(make([]byte, 32))...)
+	case *rsa.PrivateKey:
+		digest := sha256.Sum256([]byte(signed))
+		sig, err = rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, digest[:])
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+	return signed + "." + b64.EncodeToString(sig)
+}
+
+func jwksOf(signers ...*testSigner) []byte {
+	var keys []map[string]string
+	for _, s := range signers {
+		keys = append(keys, s.jwk())
+	}
+	data, _ := json.Marshal(map[string]interface{}{"keys": keys})
+	return data
+}
+
+func TestJWTValidator(t *testing.T) {
+	rsaKey, _ := rsa.GenerateKey(rand.Reader, 2048)
+	ecKey, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	_, edKey, _ := ed25519.GenerateKey(rand.Reader)
+	_, otherKey, _ := ed25519.GenerateKey(rand.Reader)
+
+	rs := &testSigner{kid: "rsa", alg: "RS256", key: rsaKey}
+	es := &testSigner{kid: "ec", alg: "ES256", key: ecKey}
+	ed := &testSigner{kid: "ed", alg: "EdDSA", key: edKey}
+	forged := &testSigner{kid: "ed", alg: "EdDSA", key: otherKey}
+
+	file := filepath.Join(t.TempDir(), "jwks.json")
+	if err := os.WriteFile(file, jwksOf(rs, es, ed), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	v, err := NewJWTValidator(&JWTConfig{
+		JWKSFile:    file,
+		Issuer:      "https://issuer.example",
+		Audience:    []string{"sage-agent"},
+		TenantClaim: "tenant",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	now := time.Now()
+	claims := func(overrides map[string]interface{}) map[string]interface{} {
+		c := map[string]interface{}{
+			"iss":    "https://issuer.example",
+			"aud":    []string{"other", "sage-agent"},
+			"sub":    "alice",
+			"exp":    now.Add(time.Hour).Unix(),
+			"scope":  "chat tools",
+			"tenant": "acme",
+		}
+		for k, val := range overrides {
+			if val == nil {
+				delete(c, k)
+			} else {
+				c[k] = val
+			}
+		}
+		return c
+	}
+
+	for _, signer := range []*testSigner{rs, es, ed} {
+		principal, err := v.Validate(context.Background(), signer.sign(t, claims(nil)))
+		if err != nil {
+			t.Fatalf("%s: Validate() error = %v", signer.alg, err)
+		}
+		if principal.Subject != "alice" || principal.Tenant != "acme" || !principal.HasScope("tools") || principal.Method != MethodJWT {
+			t.Errorf("%s: principal = %+v", signer.alg, principal)
+		}
+	}
+
+	rejected := []struct {
+		name  string
+		token string
+	}{
+		{"expired", ed.sign(t, claims(map[string]interface{}{"exp": now.Add(-time.Hour).Unix()}))},
+		{"no expiry", ed.sign(t, claims(map[string]interface{}{"exp": nil}))},
+		{"not yet valid", ed.sign(t, claims(map[string]interface{}{"nbf": now.Add(time.Hour).Unix()}))},
+		{"wrong issuer", ed.sign(t, claims(map[string]interface{}{"iss": "https://evil.example"}))},
+		{"wrong audience", ed.sign(t, claims(map[string]interface{}{"aud": "other"}))},
+		{"forged signature", forged.sign(t, claims(nil))},
+		{"alg none", b64.EncodeToString([]byte(`{"alg":"none"}`)) + "." + b64.EncodeToString([]byte(`{"sub":"alice"}`)) + "."},
+		{"malformed", "not-a-jwt"},
+	}
+	for _, tt := range rejected {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := v.Validate(context.Background(), tt.token); !errors.Is(err, errors.ErrInvalidCredentials) {
+				t.Errorf("Validate() error = %v, want ErrInvalidCredentials", err)
+			}
+		})
+	}
+}
+
+func TestJWTValidator_JWKSURLCaching(t *testing.T) {
+	_, key1, _ := ed25519.GenerateKey(rand.Reader)
+	_, key2, _ := ed25519.GenerateKey(rand.Reader)
+	first := &testSigner{kid: "k1", alg: "EdDSA", key: key1}
+	second := &testSigner{kid: "k2", alg: "EdDSA", key: key2}
+
+	var fetches atomic.Int32
+	var rotated atomic.Bool
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fetches.Add(1)
+		if rotated.Load() {
+			w.Write(jwksOf(second))
+			return
+		}
+		w.Write(jwksOf(first))
+	}))
+	defer server.Close()
+
+	v, err := NewJWTValidator(&JWTConfig{JWKSURL: server.URL, CacheTTL: time.Hour})
+	if err != nil {
+		t.Fatal(err)
+	}
+	clock := time.Now()
+	v.keys.now = func() time.Time { return clock }
+
+	claims := map[string]interface{}{"sub": "alice", "exp": clock.Add(time.Hour).Unix()}
+	for i := 0; i < 3; i++ {
+		if _, err := v.Validate(context.Background(), first.sign(t, claims)); err != nil {
+			t.Fatalf("Validate() error = %v", err)
+		}
+	}
+	if n := fetches.Load(); n != 1 {
+		t.Errorf("fetches = %d, want 1 (cached)", n)
+	}
+
+	// After rotation, an unknown key ID refetches once the cooldown passed
+	rotated.Store(true)
+	clock = clock.Add(minRefreshInterval)
+	if _, err := v.Validate(context.Background(), second.sign(t, claims)); err != nil {
+		t.Fatalf("Validate() after rotation error = %v", err)
+	}
+	if n := fetches.Load(); n != 2 {
+		t.Errorf("fetches = %d, want 2", n)
+	}
+}
+
+func TestKeySet_ConcurrentRefresh(t *testing.T) {
+	_, key1, _ := ed25519.GenerateKey(rand.Reader)
+	_, key2, _ := ed25519.GenerateKey(rand.Reader)
+	first := &testSigner{kid: "k1", alg: "EdDSA", key: key1}
+	second := &testSigner{kid: "k2", alg: "EdDSA", key: key2}
+
+	var fetches atomic.Int32
+	started := make(chan struct{}, 2)
+	release := make(chan struct{})
+	s := newKeySet(func(context.Context) ([]byte, error) {
+		n := fetches.Add(1)
+		started <- struct{}{}
+		<-release
+		if n > 1 {
+			return jwksOf(first, second), nil
+		}
+		return jwksOf(first), nil
+	}, time.Hour)
+	clock := time.Now()
+	s.now = func() time.Time { return clock }
+
+	// Concurrent lookups on an empty set share one fetch
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if keys, err := s.lookup(context.Background(), "k1", "EdDSA"); err != nil || len(keys) != 1 {
+				t.Errorf("lookup() = %d keys, %v", len(keys), err)
+			}
+		}()
+	}
+	<-started
+	release <- struct{}{}
+	wg.Wait()
+	if n := fetches.Load(); n != 1 {
+		t.Errorf("fetches = %d, want 1", n)
+	}
+
+	// A slow rotation fetch does not block lookups of cached keys
+	clock = clock.Add(minRefreshInterval)
+	done := make(chan []publicKey)
+	go func() {
+		keys, _ := s.lookup(context.Background(), "k2", "EdDSA")
+		done <- keys
+	}()
+	<-started
+
+	cached := make(chan error)
+	go func() {
+		_, err := s.lookup(context.Background(), "k1", "EdDSA")
+		cached <- err
+	}()
+	select {
+	case err := <-cached:
+		if err != nil {
+			t.Errorf("lookup() during refresh error = %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("lookup() blocked on an in-flight fetch")
+	}
+
+	release <- struct{}{}
+	if keys := <-done; len(keys) != 1 {
+		t.Errorf("lookup() after rotation = %d keys, want 1", len(keys))
+	}
+	if n := fetches.Load(); n != 2 {
+		t.Errorf("fetches = %d, want 2", n)
+	}
+}
+
+func TestAuthenticator_JWTBearer(t *testing.T) {
+	_, key, _ := ed25519.GenerateKey(rand.Reader)
+	signer := &testSigner{kid: "k1", alg: "EdDSA", key: key}
+	file := filepath.Join(t.TempDir(), "jwks.json")
+	if err := os.WriteFile(file, jwksOf(signer), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	a, err := NewAuthenticator(&Config{
+		APIKeys: []APIKey{{ID: "dev", Key: "dev-secret"}},
+		JWT:     &JWTConfig{JWKSFile: file},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	token := signer.sign(t, map[string]interface{}{"sub": "svc-1", "exp": time.Now().Add(time.Hour).Unix()})
+	principal, err := a.Authenticate(context.Background(), Credentials{Bearer: token})
+	if err != nil || principal.Subject != "svc-1" || principal.Claims["sub"] != "svc-1" {
+		t.Fatalf("Authenticate(JWT) = %+v, %v", principal, err)
+	}
+
+	if principal, err := a.Authenticate(context.Background(), Credentials{Bearer: "dev-secret"}); err != nil || principal.KeyID != "dev" {
+		t.Errorf("Authenticate(API key bearer) = %+v, %v", principal, err)
+	}
+}
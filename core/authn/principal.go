@@ -0,0 +1,86 @@
+// Copyright (C) 2025 sage-x-project
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with this program. If not, see <https://www.gnu.org/licenses/>.
+
+// SPDX-License-Identifier: LGPL-3.0-or-later
+
+package authn
+
+import (
+	"context"
+	"time"
+
+	"github.com/sage-x-project/sage-adk/observability/logging"
+)
+
+// Authentication methods.
+const (
+	MethodAPIKey = "api_key"
+	MethodJWT    = "jwt"
+)
+
+// Principal is an authenticated caller.
+type Principal struct {
+	// Subject identifies the caller: the API key's subject or the JWT
+	// "sub" claim.
+	Subject string
+
+	// Method is how the caller authenticated, MethodAPIKey or MethodJWT.
+	Method string
+
+	// KeyID is the ID of the API key used, if any. It is safe to log.
+	KeyID string
+
+	// Tenant is the caller's tenant, if known.
+	Tenant string
+
+	// Scopes are the scopes granted to the caller.
+	Scopes []string
+
+	// Claims are the JWT claims, if any.
+	Claims map[string]interface{}
+
+	// ExpiresAt is when the credential expires; zero if it does not.
+	ExpiresAt time.Time
+}
+
+// HasScope reports whether the principal was granted scope.
+func (p *Principal) HasScope(scope string) bool {
+	for _, s := range p.Scopes {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
+
+type contextKey string
+
+const principalKey contextKey = "authn_principal"
+
+// WithPrincipal records the authenticated caller in ctx, and its subject
+// as the logging user ID.
+func WithPrincipal(ctx context.Context, principal *Principal) context.Context {
+	ctx = context.WithValue(ctx, principalKey, principal)
+	if principal.Subject != "" {
+		ctx = logging.WithUserID(ctx, principal.Subject)
+	}
+	return ctx
+}
+
+// PrincipalFromContext returns the caller recorded with WithPrincipal.
+func PrincipalFromContext(ctx context.Context) (*Principal, bool) {
+	principal, ok := ctx.Value(principalKey).(*Principal)
+	return principal, ok && principal != nil
+}
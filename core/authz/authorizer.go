@@ -0,0 +1,215 @@
+// Copyright (C) 2025 sage-x-project
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with this program. If not, see <https://www.gnu.org/licenses/>.
+
+// SPDX-License-Identifier: LGPL-3.0-or-later
+
+package authz
+
+import (
+	"context"
+	"strings"
+	"sync"
+
+	"github.com/sage-x-project/sage-adk/core/middleware"
+	"github.com/sage-x-project/sage-adk/observability/logging"
+	"github.com/sage-x-project/sage-adk/pkg/errors"
+	"github.com/sage-x-project/sage-adk/pkg/types"
+)
+
+// CapabilityResolver looks up the capabilities a DID advertises.
+// The SAGE adapter's DIDResolver implements it.
+type CapabilityResolver interface {
+	GetAgentCapabilities(ctx context.Context, agentDID string) (map[string]interface{}, error)
+}
+
+// Config configures an Authorizer.
+type Config struct {
+	// Capabilities resolves DID capabilities for rules that check them
+	// (optional; without it such rules never match).
+	Capabilities CapabilityResolver
+
+	// DryRun logs denials without enforcing them, regardless of the
+	// policy's own dry_run setting.
+	DryRun bool
+
+	// Logger logs denials and policy reloads (optional).
+	Logger logging.Logger
+}
+
+// Authorizer enforces a Policy. The policy can be replaced at any time,
+// for example by Watch. Authorizer is safe for concurrent use.
+type Authorizer struct {
+	mu     sync.RWMutex
+	policy *Policy
+	config Config
+}
+
+// NewAuthorizer creates an authorizer for policy.
+func NewAuthorizer(policy *Policy, config *Config) (*Authorizer, error) {
+	if policy == nil {
+		return nil, errors.ErrConfigurationError.WithMessage("authorization policy is nil")
+	}
+	if err := policy.Validate(); err != nil {
+		return nil, err
+	}
+
+	a := &Authorizer{policy: policy}
+	if config != nil {
+		a.config = *config
+	}
+	return a, nil
+}
+
+// Open loads a policy file and creates an authorizer for it.
+func Open(file string, config *Config) (*Authorizer, error) {
+	policy, err := LoadPolicy(file)
+	if err != nil {
+		return nil, err
+	}
+	return NewAuthorizer(policy, config)
+}
+
+// Policy returns the policy in force.
+func (a *Authorizer) Policy() *Policy {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	return a.policy
+}
+
+// SetPolicy replaces the policy in force.
+func (a *Authorizer) SetPolicy(policy *Policy) error {
+	if policy == nil {
+		return errors.ErrInvalidInput.WithMessage("authorization policy is nil")
+	}
+	if err := policy.Validate(); err != nil {
+		return err
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	a.policy = policy
+	return nil
+}
+
+// Authorize decides req. It returns errors.ErrAccessDenied, carrying the
+// rule and reason as details, when the request is denied and the denial
+// is enforced. Dry-run denials are logged and return a nil error.
+func (a *Authorizer) Authorize(ctx context.Context, req *Request) (Decision, error) {
+	policy := a.Policy()
+
+	if policy.needsCapabilities() && req.Caller.Capabilities == nil && req.Caller.DID != "" &&
+		a.config.Capabilities != nil {
+		capabilities, err := a.config.Capabilities.GetAgentCapabilities(ctx, req.Caller.DID)
+		if err != nil {
+			// Fail closed: a deny rule conditioned on a capability must
+			// not be bypassed by an unavailable resolver.
+			decision := Decision{
+				Reason: "capability lookup failed for " + req.Caller.DID + ": " + err.Error(),
+				DryRun: policy.DryRun || a.config.DryRun,
+			}
+			return decision, a.deny(ctx, req, decision)
+		}
+		if capabilities == nil {
+			capabilities = map[string]interface{}{}
+		}
+		req.Caller.Capabilities = capabilities
+	}
+
+	decision := policy.Evaluate(req)
+	decision.DryRun = decision.DryRun || a.config.DryRun
+	if decision.Allowed {
+		return decision, nil
+	}
+	return decision, a.deny(ctx, req, decision)
+}
+
+// deny logs a denial and returns the error to enforce it, if any.
+func (a *Authorizer) deny(ctx context.Context, req *Request, decision Decision) error {
+	if a.config.Logger != nil {
+		fields := []logging.Field{
+			logging.String("caller", req.Caller.String()),
+			logging.String("skill", req.Skill),
+			logging.String("tool", req.Tool),
+			logging.String("rule", decision.Rule),
+			logging.String("reason", decision.Reason),
+			logging.Bool("dry_run", decision.DryRun),
+		}
+		if len(decision.Trace) > 0 {
+			fields = append(fields, logging.String("trace", strings.Join(decision.Trace, "; ")))
+		}
+		a.config.Logger.Warn(ctx, "authorization denied", fields...)
+	}
+
+	if !decision.Enforced() {
+		return nil
+	}
+
+	err := errors.ErrAccessDenied.
+		WithMessage(decision.Reason).
+		WithDetail("caller", req.Caller.String())
+	if decision.Rule != "" {
+		err = err.WithDetail("rule", decision.Rule)
+	}
+	if len(decision.Trace) > 0 {
+		err = err.WithDetail("trace", decision.Trace)
+	}
+	return err
+}
+
+// Middleware authorizes every message before it reaches the handler. The
+// handler's context carries the caller, with resolved capabilities, and
+// the message's skill, so AuthorizeTool can decide tool calls made while
+// handling the message.
+func (a *Authorizer) Middleware() middleware.Middleware {
+	return func(next middleware.Handler) middleware.Handler {
+		return func(ctx context.Context, msg *types.Message) (*types.Message, error) {
+			ctx, err := a.AuthorizeMessage(ctx, msg)
+			if err != nil {
+				return nil, err
+			}
+			return next(ctx, msg)
+		}
+	}
+}
+
+// AuthorizeMessage authorizes an inbound message and returns the context
+// to handle it with. It backs Middleware and the gRPC interceptors.
+func (a *Authorizer) AuthorizeMessage(ctx context.Context, msg *types.Message) (context.Context, error) {
+	req := &Request{
+		Caller:  CallerFromContext(ctx, msg),
+		Skill:   SkillOf(msg),
+		Message: msg,
+	}
+	if _, err := a.Authorize(ctx, req); err != nil {
+		return ctx, err
+	}
+
+	ctx = WithIdentity(ctx, req.Caller)
+	return withSkill(ctx, req.Skill), nil
+}
+
+// AuthorizeTool decides whether the caller in ctx may call tool. It
+// implements tools.Authorizer.
+func (a *Authorizer) AuthorizeTool(ctx context.Context, tool string) error {
+	req := &Request{
+		Caller: CallerFromContext(ctx, nil),
+		Skill:  skillFromContext(ctx),
+		Tool:   tool,
+	}
+	_, err := a.Authorize(ctx, req)
+	return err
+}
@@ -0,0 +1,184 @@
+// Copyright (C) 2025 sage-x-project
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with this program. If not, see <https://www.gnu.org/licenses/>.
+
+// SPDX-License-Identifier: LGPL-3.0-or-later
+
+package authz
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/sage-x-project/sage-adk/core/authn"
+	"github.com/sage-x-project/sage-adk/core/tools"
+	"github.com/sage-x-project/sage-adk/pkg/errors"
+	"github.com/sage-x-project/sage-adk/pkg/mtls"
+	"github.com/sage-x-project/sage-adk/pkg/types"
+)
+
+type capabilityFunc func(ctx context.Context, did string) (map[string]interface{}, error)
+
+func (f capabilityFunc) GetAgentCapabilities(ctx context.Context, did string) (map[string]interface{}, error) {
+	return f(ctx, did)
+}
+
+func newTestAuthorizer(t *testing.T, config *Config) *Authorizer {
+	t.Helper()
+
+	policy, err := ParsePolicy([]byte(testPolicy), "yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	a, err := NewAuthorizer(policy, config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return a
+}
+
+func chatMessage() *types.Message {
+	msg := types.NewMessage(types.MessageRoleUser, []types.Part{types.NewTextPart("hi")})
+	msg.Metadata = map[string]interface{}{MetadataSkill: "chat"}
+	return msg
+}
+
+func TestAuthorizer_Middleware(t *testing.T) {
+	lookups := 0
+	a := newTestAuthorizer(t, &Config{
+		Capabilities: capabilityFunc(func(ctx context.Context, did string) (map[string]interface{}, error) {
+			lookups++
+			if did == "did:sage:ethereum:0xgold" {
+				return map[string]interface{}{"chat": true, "tier": "gold"}, nil
+			}
+			return map[string]interface{}{"chat": true}, nil
+		}),
+	})
+
+	var handled context.Context
+	handler := a.Middleware()(func(ctx context.Context, msg *types.Message) (*types.Message, error) {
+		handled = ctx
+		return msg, nil
+	})
+
+	ctx := WithIdentity(context.Background(), Identity{DID: "did:sage:ethereum:0xgold"})
+	if _, err := handler(ctx, chatMessage()); err != nil {
+		t.Fatalf("handler() error = %v", err)
+	}
+	id, _ := IdentityFromContext(handled)
+	if id.Capabilities["tier"] != "gold" || skillFromContext(handled) != "chat" || lookups != 1 {
+		t.Errorf("identity = %+v, skill = %q, lookups = %d", id, skillFromContext(handled), lookups)
+	}
+
+	ctx = WithIdentity(context.Background(), Identity{DID: "did:sage:ethereum:0xother"})
+	_, err := handler(ctx, chatMessage())
+	if !errors.Is(err, errors.ErrAccessDenied) {
+		t.Fatalf("handler() error = %v, want ErrAccessDenied", err)
+	}
+}
+
+func TestAuthorizer_CapabilityLookupFailsClosed(t *testing.T) {
+	a := newTestAuthorizer(t, &Config{
+		Capabilities: capabilityFunc(func(ctx context.Context, did string) (map[string]interface{}, error) {
+			return nil, errors.ErrNetworkUnavailable
+		}),
+	})
+
+	req := &Request{Caller: Identity{DID: "did:sage:ethereum:0x01"}, Skill: "chat", Message: chatMessage()}
+	decision, err := a.Authorize(context.Background(), req)
+	if !errors.Is(err, errors.ErrAccessDenied) || decision.Allowed {
+		t.Errorf("Authorize() = %+v, %v, want denial", decision, err)
+	}
+}
+
+func TestAuthorizer_DryRun(t *testing.T) {
+	a := newTestAuthorizer(t, &Config{DryRun: true})
+
+	decision, err := a.Authorize(context.Background(), &Request{Skill: "chat"})
+	if err != nil {
+		t.Fatalf("Authorize() error = %v, want nil in dry-run", err)
+	}
+	if decision.Allowed || !decision.DryRun || decision.Enforced() {
+		t.Errorf("decision = %+v", decision)
+	}
+}
+
+func TestAuthorizer_AuthorizeTool(t *testing.T) {
+	a := newTestAuthorizer(t, nil)
+
+	var _ tools.Authorizer = a
+
+	ctx := tools.WithTenantID(context.Background(), "internal")
+	if err := a.AuthorizeTool(ctx, "search_web"); err != nil {
+		t.Errorf("AuthorizeTool(search_web) error = %v", err)
+	}
+	if err := a.AuthorizeTool(ctx, "shell"); !errors.Is(err, errors.ErrAccessDenied) {
+		t.Errorf("AuthorizeTool(shell) error = %v, want ErrAccessDenied", err)
+	}
+
+	// Only verified client certificates identify the caller
+	peer := &mtls.PeerIdentity{SPIFFEID: "spiffe://example.org/internal/indexer", URIs: []string{"spiffe://example.org/internal/indexer"}}
+	if err := a.AuthorizeTool(mtls.WithPeer(context.Background(), peer), "search_web"); !errors.Is(err, errors.ErrAccessDenied) {
+		t.Errorf("AuthorizeTool(unverified peer) error = %v, want ErrAccessDenied", err)
+	}
+	peer.Verified = true
+	if err := a.AuthorizeTool(mtls.WithPeer(context.Background(), peer), "search_web"); err != nil {
+		t.Errorf("AuthorizeTool(verified peer) error = %v", err)
+	}
+
+	// The tenant of an authenticated principal applies
+	ctx = authn.WithPrincipal(context.Background(), &authn.Principal{Subject: "svc", KeyID: "k1", Tenant: "internal"})
+	if err := a.AuthorizeTool(ctx, "search_web"); err != nil {
+		t.Errorf("AuthorizeTool(principal) error = %v", err)
+	}
+}
+
+func TestAuthorizer_Watch(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "policy.yaml")
+	if err := os.WriteFile(file, []byte("default: deny\nrules: []\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	a, err := Open(file, nil)
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go a.Watch(ctx, file, 10*time.Millisecond)
+
+	// An invalid policy is ignored.
+	if err := os.WriteFile(file, []byte("default: maybe\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	time.Sleep(50 * time.Millisecond)
+	if a.Policy().Default != Deny {
+		t.Fatalf("Default = %q after invalid reload", a.Policy().Default)
+	}
+
+	if err := os.WriteFile(file, []byte("default: allow\nrules: []\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	deadline := time.Now().Add(2 * time.Second)
+	for a.Policy().Default != Allow {
+		if time.Now().After(deadline) {
+			t.Fatal("policy was not reloaded")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
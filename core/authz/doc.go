@@ -0,0 +1,76 @@
+// Copyright (C) 2025 sage-x-project
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with this program. If not, see <https://www.gnu.org/licenses/>.
+
+// SPDX-License-Identifier: LGPL-3.0-or-later
+// Package authz decides which callers may use which skills and tools.
+//
+// Verifying a signature or an API key establishes who the caller is;
+// authz decides what that caller may do. A declarative Policy, loaded from
+// YAML or JSON, lists allow and deny rules over the caller's DID, subject,
+// API key, tenant and verified client certificate, the capabilities its DID advertises, the requested skill or
+// tool and attributes of the message:
+//
+//	default: deny
+//	rules:
+//	  - name: block-revoked
+//	    effect: deny
+//	    callers:
+//	      dids: ["did:sage:ethereum:0xdead*"]
+//	  - name: partners-chat
+//	    effect: allow
+//	    capabilities: ["chat", "tier=gold"]
+//	    skills: ["chat", "summarize"]
+//	    message:
+//	      role: user
+//	  - name: internal-tools
+//	    effect: allow
+//	    callers:
+//	      tenants: ["internal"]
+//	      peers: ["spiffe://example.org/ns/internal/*"]
+//	    tools: ["search_*"]
+//
+// All conditions of a rule must match; values within a condition are
+// alternatives and may use path.Match globs. A matching deny rule wins over
+// any allow rule, and requests matching no rule get the default effect.
+// Every Decision explains itself: the deciding rule, or for the default a
+// trace of why each rule did not match.
+//
+// An Authorizer enforces the policy as core/middleware and, through
+// AuthorizeMessage, as gRPC interceptors in server/grpc. Its AuthorizeTool
+// method plugs into tools.ExecutorConfig.Authorizer:
+//
+//	authorizer, err := authz.Open("policy.yaml", &authz.Config{
+//	    Capabilities: didResolver, // *sage.DIDResolver
+//	    Logger:       logger,
+//	})
+//	go authorizer.Watch(ctx, "policy.yaml", 0)
+//
+//	chain := middleware.NewChain(authorizer.Middleware())
+//
+// The builder package does this for agents whose configuration names a
+// policy file in Server.AuthzPolicy (adk serve --authz-policy): it
+// authorizes messages on the A2A and SAGE servers, tool calls through the
+// executor, and RPCs of the gRPC server configured by GRPCServerConfig,
+// and watches the file for changes.
+//
+// The caller comes from WithIdentity, or else from a verified HTTP
+// signature or signed SAGE message, the principal authenticated by
+// core/authn, and the peer names from a client certificate verified by
+// pkg/mtls; the skill
+// comes from the "skill" message metadata. In dry-run mode, set in the
+// policy or in Config, denials are logged but not enforced, so a new policy
+// can be tried against live traffic. Watch reloads the policy file when it
+// changes and keeps the previous policy if the new one is invalid.
+package authz
@@ -0,0 +1,342 @@
+// Copyright (C) 2025 sage-x-project
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with this program. If not, see <https://www.gnu.org/licenses/>.
+
+// SPDX-License-Identifier: LGPL-3.0-or-later
+
+package authz
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/sage-x-project/sage-adk/pkg/errors"
+	"gopkg.in/yaml.v3"
+)
+
+// Effect is the outcome of a rule or of the policy default.
+type Effect string
+
+const (
+	// Allow permits the request.
+	Allow Effect = "allow"
+
+	// Deny rejects the request.
+	Deny Effect = "deny"
+)
+
+// Policy is a declarative set of authorization rules.
+//
+// A rule applies when all of its conditions match; values within one
+// condition are alternatives and may use path.Match glob patterns. A
+// matching deny rule always wins over matching allow rules. Requests that
+// match no rule get the Default effect.
+type Policy struct {
+	// Default is the effect for requests no rule matches (default deny).
+	Default Effect `json:"default,omitempty" yaml:"default,omitempty"`
+
+	// DryRun logs denials without enforcing them.
+	DryRun bool `json:"dry_run,omitempty" yaml:"dry_run,omitempty"`
+
+	// Rules are evaluated in order; the first matching rule of the winning
+	// effect is reported in the decision.
+	Rules []Rule `json:"rules" yaml:"rules"`
+}
+
+// Rule allows or denies the requests matching its conditions.
+// Empty conditions match every request.
+type Rule struct {
+	// Name identifies the rule in decisions and logs.
+	Name string `json:"name" yaml:"name"`
+
+	// Effect is allow or deny.
+	Effect Effect `json:"effect" yaml:"effect"`
+
+	// Callers restricts the caller identity.
+	Callers Callers `json:"callers,omitempty" yaml:"callers,omitempty"`
+
+	// Capabilities lists capabilities the caller's DID must all advertise,
+	// either as a name ("chat") or as name=value ("tier=gold").
+	Capabilities []string `json:"capabilities,omitempty" yaml:"capabilities,omitempty"`
+
+	// Skills restricts the requested skill.
+	Skills []string `json:"skills,omitempty" yaml:"skills,omitempty"`
+
+	// Tools restricts the requested tool.
+	Tools []string `json:"tools,omitempty" yaml:"tools,omitempty"`
+
+	// Message restricts message attributes: role, kind, context_id,
+	// task_id or metadata.<key>.
+	Message map[string]string `json:"message,omitempty" yaml:"message,omitempty"`
+}
+
+// Callers restricts who may match a rule. A caller matches if any listed
+// identity matches.
+type Callers struct {
+	DIDs     []string `json:"dids,omitempty" yaml:"dids,omitempty"`
+	Subjects []string `json:"subjects,omitempty" yaml:"subjects,omitempty"`
+	APIKeys  []string `json:"api_keys,omitempty" yaml:"api_keys,omitempty"`
+	Tenants  []string `json:"tenants,omitempty" yaml:"tenants,omitempty"`
+
+	// Peers match any name of the caller's verified client certificate:
+	// SPIFFE ID, URI, DNS or email SAN, or subject common name.
+	Peers []string `json:"peers,omitempty" yaml:"peers,omitempty"`
+}
+
+// empty reports whether no caller restriction is set.
+func (c Callers) empty() bool {
+	return len(c.DIDs) == 0 && len(c.Subjects) == 0 && len(c.APIKeys) == 0 && len(c.Tenants) == 0 && len(c.Peers) == 0
+}
+
+// LoadPolicy reads a policy file. Files ending in .json are parsed as JSON,
+// everything else as YAML.
+func LoadPolicy(file string) (*Policy, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, errors.ErrConfigurationError.
+			WithMessage("failed to read policy file").
+			WithDetail("path", file).
+			WithDetail("error", err.Error())
+	}
+
+	format := "yaml"
+	if strings.EqualFold(filepath.Ext(file), ".json") {
+		format = "json"
+	}
+
+	policy, err := ParsePolicy(data, format)
+	if err != nil {
+		return nil, err
+	}
+	return policy, nil
+}
+
+// ParsePolicy parses and validates a policy in the given format, "yaml" or
+// "json".
+func ParsePolicy(data []byte, format string) (*Policy, error) {
+	var policy Policy
+
+	var err error
+	switch format {
+	case "json":
+		err = json.Unmarshal(data, &policy)
+	case "yaml", "yml", "":
+		err = yaml.Unmarshal(data, &policy)
+	default:
+		return nil, errors.ErrInvalidValue.
+			WithMessage("unsupported policy format").
+			WithDetail("format", format)
+	}
+	if err != nil {
+		return nil, errors.ErrConfigurationError.
+			WithMessage("failed to parse policy").
+			WithDetail("error", err.Error())
+	}
+
+	if err := policy.Validate(); err != nil {
+		return nil, err
+	}
+	return &policy, nil
+}
+
+// Validate checks effects, patterns and message attributes and fills in
+// the default effect.
+func (p *Policy) Validate() error {
+	switch p.Default {
+	case "":
+		p.Default = Deny
+	case Allow, Deny:
+	default:
+		return errors.ErrInvalidValue.
+			WithMessage("policy default must be allow or deny").
+			WithDetail("default", string(p.Default))
+	}
+
+	for i := range p.Rules {
+		rule := &p.Rules[i]
+		if rule.Name == "" {
+			rule.Name = fmt.Sprintf("rule-%d", i+1)
+		}
+		if rule.Effect != Allow && rule.Effect != Deny {
+			return errors.ErrInvalidValue.
+				WithMessage("rule effect must be allow or deny").
+				WithDetail("rule", rule.Name).
+				WithDetail("effect", string(rule.Effect))
+		}
+
+		patterns := [][]string{rule.Callers.DIDs, rule.Callers.Subjects, rule.Callers.APIKeys, rule.Callers.Tenants, rule.Callers.Peers, rule.Skills, rule.Tools}
+		for _, list := range patterns {
+			for _, pattern := range list {
+				if _, err := path.Match(pattern, ""); err != nil {
+					return invalidPattern(rule.Name, pattern)
+				}
+			}
+		}
+
+		for attr, pattern := range rule.Message {
+			if !knownAttribute(attr) {
+				return errors.ErrInvalidValue.
+					WithMessage("unknown message attribute").
+					WithDetail("rule", rule.Name).
+					WithDetail("attribute", attr)
+			}
+			if _, err := path.Match(pattern, ""); err != nil {
+				return invalidPattern(rule.Name, pattern)
+			}
+		}
+	}
+	return nil
+}
+
+// needsCapabilities reports whether any rule checks DID capabilities.
+func (p *Policy) needsCapabilities() bool {
+	for _, rule := range p.Rules {
+		if len(rule.Capabilities) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// Evaluate decides a request against the policy. Capabilities must
+// already be resolved into req.Caller.
+func (p *Policy) Evaluate(req *Request) Decision {
+	var allowedBy string
+	var trace []string
+
+	for _, rule := range p.Rules {
+		ok, why := rule.match(req)
+		if !ok {
+			trace = append(trace, fmt.Sprintf("rule %q: %s", rule.Name, why))
+			continue
+		}
+		if rule.Effect == Deny {
+			return Decision{
+				Allowed: false,
+				Rule:    rule.Name,
+				Reason:  fmt.Sprintf("denied by rule %q", rule.Name),
+				DryRun:  p.DryRun,
+			}
+		}
+		if allowedBy == "" {
+			allowedBy = rule.Name
+		}
+	}
+
+	if allowedBy != "" {
+		return Decision{
+			Allowed: true,
+			Rule:    allowedBy,
+			Reason:  fmt.Sprintf("allowed by rule %q", allowedBy),
+			DryRun:  p.DryRun,
+		}
+	}
+
+	decision := Decision{
+		Allowed: p.Default == Allow,
+		DryRun:  p.DryRun,
+		Trace:   trace,
+	}
+	if decision.Allowed {
+		decision.Reason = "no rule matched; default is allow"
+	} else {
+		decision.Reason = "no rule matched; default is deny"
+	}
+	return decision
+}
+
+// match reports whether the rule applies to req and, if not, why.
+func (r Rule) match(req *Request) (bool, string) {
+	caller := req.Caller
+	if !r.Callers.empty() &&
+		!matchAny(r.Callers.DIDs, caller.DID) &&
+		!matchAny(r.Callers.Subjects, caller.Subject) &&
+		!matchAny(r.Callers.APIKeys, caller.APIKey) &&
+		!matchAny(r.Callers.Tenants, caller.Tenant) &&
+		!matchAnyOf(r.Callers.Peers, caller.Peers) {
+		return false, fmt.Sprintf("caller %s is not listed", caller)
+	}
+
+	for _, capability := range r.Capabilities {
+		if !caller.hasCapability(capability) {
+			return false, fmt.Sprintf("caller lacks capability %q", capability)
+		}
+	}
+
+	if len(r.Skills) > 0 && !matchAny(r.Skills, req.Skill) {
+		return false, fmt.Sprintf("skill %q does not match %v", req.Skill, r.Skills)
+	}
+
+	if len(r.Tools) > 0 && !matchAny(r.Tools, req.Tool) {
+		return false, fmt.Sprintf("tool %q does not match %v", req.Tool, r.Tools)
+	}
+
+	attrs := make([]string, 0, len(r.Message))
+	for attr := range r.Message {
+		attrs = append(attrs, attr)
+	}
+	sort.Strings(attrs)
+	for _, attr := range attrs {
+		value := req.attribute(attr)
+		if !matchAny([]string{r.Message[attr]}, value) {
+			return false, fmt.Sprintf("message %s %q does not match %q", attr, value, r.Message[attr])
+		}
+	}
+
+	return true, ""
+}
+
+// matchAny reports whether value is non-empty and matches one of patterns.
+func matchAny(patterns []string, value string) bool {
+	if value == "" {
+		return false
+	}
+	for _, pattern := range patterns {
+		if ok, _ := path.Match(pattern, value); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// matchAnyOf reports whether any value matches any pattern.
+func matchAnyOf(patterns, values []string) bool {
+	for _, value := range values {
+		if matchAny(patterns, value) {
+			return true
+		}
+	}
+	return false
+}
+
+// knownAttribute reports whether attr names a message attribute.
+func knownAttribute(attr string) bool {
+	switch attr {
+	case "role", "kind", "context_id", "task_id":
+		return true
+	}
+	return strings.HasPrefix(attr, "metadata.") && len(attr) > len("metadata.")
+}
+
+func invalidPattern(rule, pattern string) error {
+	return errors.ErrInvalidValue.
+		WithMessage("invalid pattern in policy").
+		WithDetail("rule", rule).
+		WithDetail("pattern", pattern)
+}
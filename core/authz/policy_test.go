@@ -0,0 +1,220 @@
+// Copyright (C) 2025 sage-x-project
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with this program. If not, see <https://www.gnu.org/licenses/>.
+
+// SPDX-License-Identifier: LGPL-3.0-or-later
+
+package authz
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/sage-x-project/sage-adk/pkg/errors"
+	"github.com/sage-x-project/sage-adk/pkg/types"
+)
+
+const testPolicy = `
+default: deny
+rules:
+  - name: block-revoked
+    effect: deny
+    callers:
+      dids: ["did:sage:ethereum:0xdead*"]
+  - name: partners-chat
+    effect: allow
+    capabilities: ["chat", "tier=gold"]
+    skills: ["chat", "summarize"]
+    message:
+      role: user
+  - name: internal-tools
+    effect: allow
+    callers:
+      tenants: ["internal"]
+      peers: ["spiffe://example.org/internal/*"]
+    tools: ["search_*"]
+  - name: ops-key
+    effect: allow
+    callers:
+      api_keys: ["ops-*"]
+    message:
+      metadata.priority: high
+`
+
+func TestParsePolicy(t *testing.T) {
+	policy, err := ParsePolicy([]byte(testPolicy), "yaml")
+	if err != nil {
+		t.Fatalf("ParsePolicy() error = %v", err)
+	}
+	if policy.Default != Deny || len(policy.Rules) != 4 {
+		t.Fatalf("policy = %+v", policy)
+	}
+	if policy.Rules[2].Callers.Tenants[0] != "internal" {
+		t.Errorf("tenants = %v", policy.Rules[2].Callers.Tenants)
+	}
+
+	jsonPolicy, err := ParsePolicy([]byte(`{"rules":[{"effect":"allow","skills":["chat"]}]}`), "json")
+	if err != nil {
+		t.Fatalf("ParsePolicy(json) error = %v", err)
+	}
+	if jsonPolicy.Default != Deny || jsonPolicy.Rules[0].Name != "rule-1" {
+		t.Errorf("policy = %+v", jsonPolicy)
+	}
+}
+
+func TestParsePolicy_Invalid(t *testing.T) {
+	tests := []struct {
+		name   string
+		policy string
+	}{
+		{"bad default", "default: maybe"},
+		{"bad effect", "rules: [{name: a, effect: permit}]"},
+		{"bad pattern", "rules: [{name: a, effect: allow, skills: ['[']}]"},
+		{"unknown attribute", "rules: [{name: a, effect: allow, message: {sender: x}}]"},
+		{"not yaml", "rules: ["},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := ParsePolicy([]byte(tt.policy), "yaml"); err == nil {
+				t.Error("ParsePolicy() error = nil")
+			}
+		})
+	}
+
+	if _, err := ParsePolicy([]byte("{}"), "toml"); !errors.Is(err, errors.ErrInvalidValue) {
+		t.Errorf("ParsePolicy(toml) error = %v, want ErrInvalidValue", err)
+	}
+}
+
+func TestPolicy_Evaluate(t *testing.T) {
+	policy, err := ParsePolicy([]byte(testPolicy), "yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	gold := map[string]interface{}{"chat": true, "tier": "gold"}
+	silver := map[string]interface{}{"chat": true, "tier": "silver"}
+	noChat := map[string]interface{}{"chat": false, "tier": "gold"}
+
+	userMsg := &types.Message{Role: types.MessageRoleUser, Metadata: map[string]interface{}{"priority": "high"}}
+	agentMsg := &types.Message{Role: types.MessageRoleAgent}
+
+	tests := []struct {
+		name    string
+		req     Request
+		allowed bool
+		rule    string
+	}{
+		{
+			name:    "gold partner chats",
+			req:     Request{Caller: Identity{DID: "did:sage:ethereum:0x01", Capabilities: gold}, Skill: "chat", Message: userMsg},
+			allowed: true,
+			rule:    "partners-chat",
+		},
+		{
+			name: "silver partner denied",
+			req:  Request{Caller: Identity{DID: "did:sage:ethereum:0x01", Capabilities: silver}, Skill: "chat", Message: userMsg},
+		},
+		{
+			name: "false capability",
+			req:  Request{Caller: Identity{DID: "did:sage:ethereum:0x01", Capabilities: noChat}, Skill: "chat", Message: userMsg},
+		},
+		{
+			name: "wrong role",
+			req:  Request{Caller: Identity{DID: "did:sage:ethereum:0x01", Capabilities: gold}, Skill: "chat", Message: agentMsg},
+		},
+		{
+			name: "revoked DID denied despite capabilities",
+			req:  Request{Caller: Identity{DID: "did:sage:ethereum:0xdeadbeef", Capabilities: gold}, Skill: "chat", Message: userMsg},
+			rule: "block-revoked",
+		},
+		{
+			name:    "internal tenant uses tool",
+			req:     Request{Caller: Identity{Tenant: "internal"}, Tool: "search_web"},
+			allowed: true,
+			rule:    "internal-tools",
+		},
+		{
+			name:    "internal workload uses tool",
+			req:     Request{Caller: Identity{Peers: []string{"spiffe://example.org/internal/indexer"}}, Tool: "search_web"},
+			allowed: true,
+			rule:    "internal-tools",
+		},
+		{
+			name: "external workload denied",
+			req:  Request{Caller: Identity{Peers: []string{"spiffe://example.org/external/crawler"}}, Tool: "search_web"},
+		},
+		{
+			name: "internal tenant other tool",
+			req:  Request{Caller: Identity{Tenant: "internal"}, Tool: "shell"},
+		},
+		{
+			name:    "ops key with high priority",
+			req:     Request{Caller: Identity{APIKey: "ops-1"}, Message: userMsg},
+			allowed: true,
+			rule:    "ops-key",
+		},
+		{
+			name: "anonymous",
+			req:  Request{Skill: "chat", Message: userMsg},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := tt.req
+			decision := policy.Evaluate(&req)
+			if decision.Allowed != tt.allowed || decision.Rule != tt.rule {
+				t.Errorf("Evaluate() = %+v, want allowed %v by %q", decision, tt.allowed, tt.rule)
+			}
+			if decision.Reason == "" {
+				t.Error("Reason is empty")
+			}
+		})
+	}
+}
+
+func TestPolicy_EvaluateExplainsDefault(t *testing.T) {
+	policy, err := ParsePolicy([]byte(testPolicy), "yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	decision := policy.Evaluate(&Request{
+		Caller: Identity{DID: "did:sage:ethereum:0x01", Capabilities: map[string]interface{}{"chat": true}},
+		Skill:  "translate",
+	})
+	if decision.Allowed || decision.Rule != "" || len(decision.Trace) != 4 {
+		t.Fatalf("Evaluate() = %+v", decision)
+	}
+
+	want := []string{
+		`rule "block-revoked": caller (did=did:sage:ethereum:0x01) is not listed`,
+		`rule "partners-chat": caller lacks capability "tier=gold"`,
+	}
+	for i, w := range want {
+		if decision.Trace[i] != w {
+			t.Errorf("Trace[%d] = %q, want %q", i, decision.Trace[i], w)
+		}
+	}
+	if !strings.Contains(decision.Reason, "default is deny") {
+		t.Errorf("Reason = %q", decision.Reason)
+	}
+
+	policy.Default = Allow
+	if decision := policy.Evaluate(&Request{Skill: "translate"}); !decision.Allowed {
+		t.Errorf("Evaluate() with default allow = %+v", decision)
+	}
+}
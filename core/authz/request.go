@@ -0,0 +1,240 @@
+// Copyright (C) 2025 sage-x-project
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with this program. If not, see <https://www.gnu.org/licenses/>.
+
+// SPDX-License-Identifier: LGPL-3.0-or-later
+
+package authz
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/sage-x-project/sage-adk/adapters/sage/httpsig"
+	"github.com/sage-x-project/sage-adk/core/authn"
+	"github.com/sage-x-project/sage-adk/core/tools"
+	"github.com/sage-x-project/sage-adk/pkg/mtls"
+	"github.com/sage-x-project/sage-adk/pkg/types"
+)
+
+// MetadataSkill is the message metadata key naming the requested skill.
+const MetadataSkill = "skill"
+
+// Identity describes an authenticated caller.
+type Identity struct {
+	// DID is the verified DID of the calling agent.
+	DID string
+
+	// Subject is the authenticated subject of an API key or bearer token.
+	Subject string
+
+	// APIKey identifies the API key the caller presented. It should be a
+	// key ID, never the secret itself.
+	APIKey string
+
+	// Tenant is the caller's tenant.
+	Tenant string
+
+	// Peers are the names of the caller's verified client certificate.
+	Peers []string
+
+	// Capabilities are the capabilities advertised by DID. They are
+	// resolved on demand when left nil.
+	Capabilities map[string]interface{}
+}
+
+// String describes the identity for deny reasons.
+func (id Identity) String() string {
+	var parts []string
+	if id.DID != "" {
+		parts = append(parts, "did="+id.DID)
+	}
+	if id.Subject != "" {
+		parts = append(parts, "subject="+id.Subject)
+	}
+	if id.APIKey != "" {
+		parts = append(parts, "api_key="+id.APIKey)
+	}
+	if id.Tenant != "" {
+		parts = append(parts, "tenant="+id.Tenant)
+	}
+	if len(id.Peers) > 0 {
+		parts = append(parts, "peer="+id.Peers[0])
+	}
+	if len(parts) == 0 {
+		return "(anonymous)"
+	}
+	return "(" + strings.Join(parts, " ") + ")"
+}
+
+// hasCapability reports whether the identity advertises capability, given
+// as "name" (present and not false) or "name=value".
+func (id Identity) hasCapability(capability string) bool {
+	name, want, withValue := strings.Cut(capability, "=")
+	value, ok := id.Capabilities[name]
+	if !ok {
+		return false
+	}
+	if withValue {
+		return fmt.Sprint(value) == want
+	}
+	b, isBool := value.(bool)
+	return !isBool || b
+}
+
+// Request is what a policy decides about.
+type Request struct {
+	// Caller is the authenticated caller.
+	Caller Identity
+
+	// Skill is the requested skill, if any.
+	Skill string
+
+	// Tool is the requested tool, if any.
+	Tool string
+
+	// Message is the inbound message, if any.
+	Message *types.Message
+}
+
+// attribute returns a message attribute named as in Rule.Message.
+func (r *Request) attribute(attr string) string {
+	msg := r.Message
+	if msg == nil {
+		return ""
+	}
+	switch attr {
+	case "role":
+		return string(msg.Role)
+	case "kind":
+		return msg.Kind
+	case "context_id":
+		if msg.ContextID != nil {
+			return *msg.ContextID
+		}
+	case "task_id":
+		if msg.TaskID != nil {
+			return *msg.TaskID
+		}
+	default:
+		if v, ok := msg.Metadata[strings.TrimPrefix(attr, "metadata.")]; ok && v != nil {
+			return fmt.Sprint(v)
+		}
+	}
+	return ""
+}
+
+// Decision is the explained outcome of an authorization check.
+type Decision struct {
+	// Allowed reports whether the request is permitted by the policy.
+	Allowed bool
+
+	// Rule names the deciding rule; empty if the default applied.
+	Rule string
+
+	// Reason explains the decision.
+	Reason string
+
+	// DryRun reports that a denial is logged but not enforced.
+	DryRun bool
+
+	// Trace explains why each rule did not match when the default applied.
+	Trace []string
+}
+
+// Enforced reports whether the request must be rejected.
+func (d Decision) Enforced() bool {
+	return !d.Allowed && !d.DryRun
+}
+
+type contextKey string
+
+const (
+	identityKey contextKey = "authz_identity"
+	skillKey    contextKey = "authz_skill"
+)
+
+// WithIdentity records the authenticated caller, typically set by the
+// authentication layer in front of the authorizer.
+func WithIdentity(ctx context.Context, id Identity) context.Context {
+	return context.WithValue(ctx, identityKey, id)
+}
+
+// IdentityFromContext returns the caller recorded with WithIdentity.
+func IdentityFromContext(ctx context.Context) (Identity, bool) {
+	id, ok := ctx.Value(identityKey).(Identity)
+	return id, ok
+}
+
+// withSkill records the skill of the message being handled, so that tool
+// checks made while handling it see the same skill.
+func withSkill(ctx context.Context, skill string) context.Context {
+	return context.WithValue(ctx, skillKey, skill)
+}
+
+func skillFromContext(ctx context.Context) string {
+	skill, _ := ctx.Value(skillKey).(string)
+	return skill
+}
+
+// CallerFromContext assembles the caller identity from the context and,
+// if given, the inbound message. Fields set with WithIdentity take
+// precedence; otherwise the DID comes from a verified HTTP signature or the
+// message's SAGE security metadata, the subject, API key and tenant from
+// an authn principal, the peers from a verified mtls client certificate,
+// and otherwise the tenant from tools.WithTenantID.
+// Message metadata is never trusted as an identity source.
+func CallerFromContext(ctx context.Context, msg *types.Message) Identity {
+	id, _ := IdentityFromContext(ctx)
+
+	if id.DID == "" {
+		if result := httpsig.FromContext(ctx); result != nil {
+			id.DID = result.DID()
+		}
+	}
+	if id.DID == "" && msg != nil && msg.Security != nil && msg.Security.Signature != nil {
+		id.DID = msg.Security.AgentDID
+	}
+	if principal, ok := authn.PrincipalFromContext(ctx); ok {
+		if id.Subject == "" {
+			id.Subject = principal.Subject
+		}
+		if id.APIKey == "" {
+			id.APIKey = principal.KeyID
+		}
+		if id.Tenant == "" {
+			id.Tenant = principal.Tenant
+		}
+	}
+	if len(id.Peers) == 0 {
+		// Unverified certificates are not identities
+		if peer, ok := mtls.PeerFromContext(ctx); ok && peer.Verified {
+			id.Peers = peer.Names()
+		}
+	}
+	if id.Tenant == "" {
+		id.Tenant = tools.GetTenantID(ctx)
+	}
+	return id
+}
+
+// SkillOf returns the skill named in the message metadata.
+func SkillOf(msg *types.Message) string {
+	if msg == nil {
+		return ""
+	}
+	skill, _ := msg.Metadata[MetadataSkill].(string)
+	return skill
+}
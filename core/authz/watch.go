@@ -0,0 +1,87 @@
+// Copyright (C) 2025 sage-x-project
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with this program. If not, see <https://www.gnu.org/licenses/>.
+
+// SPDX-License-Identifier: LGPL-3.0-or-later
+
+package authz
+
+import (
+	"context"
+	"os"
+	"time"
+
+	"github.com/sage-x-project/sage-adk/observability/logging"
+)
+
+// DefaultWatchInterval is how often Watch checks the policy file.
+const DefaultWatchInterval = 5 * time.Second
+
+// Reload loads file and installs it as the policy in force. On error the
+// current policy is kept.
+func (a *Authorizer) Reload(file string) error {
+	policy, err := LoadPolicy(file)
+	if err != nil {
+		return err
+	}
+	return a.SetPolicy(policy)
+}
+
+// Watch reloads the policy whenever file's modification time or size
+// changes, until ctx is done. A policy that fails to load or validate is
+// logged and the current policy stays in force. A zero interval uses
+// DefaultWatchInterval. Watch blocks; run it in its own goroutine.
+func (a *Authorizer) Watch(ctx context.Context, file string, interval time.Duration) {
+	if interval <= 0 {
+		interval = DefaultWatchInterval
+	}
+
+	last, _ := os.Stat(file)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		info, err := os.Stat(file)
+		if err != nil || !changed(last, info) {
+			continue
+		}
+		last = info
+
+		if err := a.Reload(file); err != nil {
+			if a.config.Logger != nil {
+				a.config.Logger.Error(ctx, "authorization policy reload failed",
+					logging.String("path", file), logging.Error(err))
+			}
+			continue
+		}
+		if a.config.Logger != nil {
+			a.config.Logger.Info(ctx, "authorization policy reloaded", logging.String("path", file))
+		}
+	}
+}
+
+// changed reports whether a file differs from its previous state.
+func changed(prev, cur os.FileInfo) bool {
+	if prev == nil {
+		return true
+	}
+	return !prev.ModTime().Equal(cur.ModTime()) || prev.Size() != cur.Size()
+}
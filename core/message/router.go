@@ -42,6 +42,9 @@ type Router struct {
 	// Default handler for processing messages
 	handler middleware.Handler
 
+	// Negotiator chooses the protocol per peer in auto mode
+	negotiator *protocol.Negotiator
+
 	// Mutex for thread-safe access
 	mu sync.RWMutex
 }
@@ -49,9 +52,10 @@ type Router struct {
 // NewRouter creates a new message router.
 func NewRouter(mode protocol.ProtocolMode) *Router {
 	return &Router{
-		adapters: make(map[string]protocol.ProtocolAdapter),
-		mode:     mode,
-		chain:    middleware.NewChain(),
+		adapters:   make(map[string]protocol.ProtocolAdapter),
+		mode:       mode,
+		chain:      middleware.NewChain(),
+		negotiator: protocol.NewNegotiator(nil),
 	}
 }
 
@@ -152,6 +156,44 @@ func (r *Router) Send(ctx context.Context, msg *types.Message) error {
 	return adapter.SendMessage(ctx, msg)
 }
 
+// SetNegotiator sets the negotiator used by SendTo.
+func (r *Router) SetNegotiator(negotiator *protocol.Negotiator) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if negotiator == nil {
+		negotiator = protocol.NewNegotiator(nil)
+	}
+	r.negotiator = negotiator
+}
+
+// SendTo sends a message to a peer. In auto mode the protocol is
+// negotiated from the peer's agent card and DID document; otherwise the
+// fixed mode is used, subject to the peer's policy.
+func (r *Router) SendTo(ctx context.Context, peer *protocol.Peer, msg *types.Message) error {
+	if msg == nil {
+		return errors.ErrInvalidInput.WithMessage("message is nil")
+	}
+
+	r.mu.RLock()
+	mode := r.mode
+	negotiator := r.negotiator
+	_, hasSAGE := r.adapters["sage"]
+	r.mu.RUnlock()
+
+	mode, err := negotiator.Resolve(ctx, peer, mode, hasSAGE)
+	if err != nil {
+		return err
+	}
+
+	adapter, err := r.selectAdapter(msg, mode)
+	if err != nil {
+		return err
+	}
+
+	return adapter.SendMessage(ctx, msg)
+}
+
 // Receive receives a message using the specified protocol adapter.
 func (r *Router) Receive(ctx context.Context, adapterName string) (*types.Message, error) {
 	adapter, err := r.GetAdapter(adapterName)
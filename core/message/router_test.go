@@ -246,6 +246,42 @@ func TestRouter_Send(t *testing.T) {
 	}
 }
 
+func TestRouter_SendTo(t *testing.T) {
+	router := NewRouter(protocol.ProtocolAuto)
+	a2aAdapter := protocol.NewMockAdapter("a2a")
+	sageAdapter := protocol.NewMockAdapter("sage")
+	router.RegisterAdapter(a2aAdapter)
+	router.RegisterAdapter(sageAdapter)
+
+	msg := types.NewMessage(types.MessageRoleUser, []types.Part{
+		types.NewTextPart("test"),
+	})
+
+	sagePeer := &protocol.Peer{DIDDocument: &protocol.DIDDocument{
+		ID:                 "did:sage:eth:0x1",
+		VerificationMethod: []protocol.VerificationMethod{{ID: "did:sage:eth:0x1#key-1"}},
+		Service:            []protocol.DIDService{{Type: protocol.ServiceTypeSAGE}},
+	}}
+	legacyPeer := &protocol.Peer{ID: "legacy"}
+
+	if err := router.SendTo(context.Background(), sagePeer, msg); err != nil {
+		t.Fatalf("SendTo() error = %v", err)
+	}
+	if err := router.SendTo(context.Background(), legacyPeer, msg); err != nil {
+		t.Fatalf("SendTo() error = %v", err)
+	}
+	if len(sageAdapter.SentMessages) != 1 || len(a2aAdapter.SentMessages) != 1 {
+		t.Errorf("sent sage = %d, a2a = %d, want 1 and 1", len(sageAdapter.SentMessages), len(a2aAdapter.SentMessages))
+	}
+
+	negotiator := protocol.NewNegotiator(nil)
+	negotiator.SetPeerPolicy("legacy", protocol.PolicyRequireSAGE)
+	router.SetNegotiator(negotiator)
+	if err := router.SendTo(context.Background(), legacyPeer, msg); err == nil {
+		t.Error("SendTo() should refuse a peer that requires SAGE")
+	}
+}
+
 func TestRouter_Receive(t *testing.T) {
 	router := NewRouter(protocol.ProtocolA2A)
 	adapter := protocol.NewMockAdapter("a2a")
@@ -76,10 +76,25 @@ type ProtocolAdapter interface {
 	SupportsStreaming() bool
 
 	// Stream sends a message and streams the response through the callback.
+	// The message is taken from the context (see WithStreamMessage).
 	// Returns an error if streaming is not supported.
 	Stream(ctx context.Context, fn StreamFunc) error
 }
 
+const streamMessageKey contextKey = "protocol_stream_message"
+
+// WithStreamMessage returns a context carrying the message that an
+// adapter's Stream call sends.
+func WithStreamMessage(ctx context.Context, msg *types.Message) context.Context {
+	return context.WithValue(ctx, streamMessageKey, msg)
+}
+
+// StreamMessageFromContext retrieves the message to stream from the context.
+func StreamMessageFromContext(ctx context.Context) (*types.Message, bool) {
+	msg, ok := ctx.Value(streamMessageKey).(*types.Message)
+	return msg, ok && msg != nil
+}
+
 // DetectProtocol automatically detects the protocol from a message.
 // It checks the message's security metadata to determine the protocol.
 func DetectProtocol(msg *types.Message) ProtocolMode {
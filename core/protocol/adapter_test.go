@@ -207,3 +207,15 @@ func TestMockAdapter_Stream_NotSupported(t *testing.T) {
 		t.Error("Stream() should return error when streaming not supported")
 	}
 }
+
+func TestStreamMessageFromContext(t *testing.T) {
+	if _, ok := StreamMessageFromContext(context.Background()); ok {
+		t.Error("StreamMessageFromContext() should report no message")
+	}
+
+	msg := types.NewMessage(types.MessageRoleUser, []types.Part{types.NewTextPart("hi")})
+	got, ok := StreamMessageFromContext(WithStreamMessage(context.Background(), msg))
+	if !ok || got != msg {
+		t.Errorf("StreamMessageFromContext() = %v, %v", got, ok)
+	}
+}
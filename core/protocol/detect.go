@@ -0,0 +1,166 @@
+// Copyright (C) 2025 sage-x-project
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with this program. If not, see <https://www.gnu.org/licenses/>.
+
+// SPDX-License-Identifier: LGPL-3.0-or-later
+
+package protocol
+
+import (
+	"context"
+	"encoding/json"
+	"mime"
+	"net/http"
+
+	"github.com/sage-x-project/sage-adk/adapters/sage/httpsig"
+	"github.com/sage-x-project/sage-adk/pkg/types"
+)
+
+// HTTP headers and content types that identify SAGE traffic.
+const (
+	// HeaderSignatureInput is the RFC 9421 Signature-Input header.
+	HeaderSignatureInput = "Signature-Input"
+
+	// HeaderSignature is the RFC 9421 Signature header.
+	HeaderSignature = "Signature"
+
+	// HeaderProtocolMode carries the sender's protocol mode.
+	HeaderProtocolMode = "X-SAGE-Protocol-Mode"
+
+	// HeaderAgentDID carries the sender's DID.
+	HeaderAgentDID = "X-SAGE-Agent-DID"
+
+	// ContentTypeSAGE is the media type of SAGE message payloads.
+	ContentTypeSAGE = "application/sage+json"
+)
+
+// Detection describes how the protocol of an inbound message was detected.
+type Detection struct {
+	// Mode is the detected protocol (ProtocolA2A or ProtocolSAGE).
+	Mode ProtocolMode
+
+	// PeerDID is the sender DID: the signer's for verified SAGE requests,
+	// otherwise the DID the request declared, if any.
+	PeerDID string
+
+	// Reason names the evidence used for detection.
+	Reason string
+}
+
+// DetectRequest detects the protocol of an inbound HTTP request without
+// reading the body.
+//
+// A request is SAGE only if its RFC 9421 signature was verified by
+// httpsig.Verifier.Middleware before detection; the peer DID is then the
+// signer's. Signature headers, the SAGE media type and X-SAGE-Protocol-Mode
+// can be set by anyone, so requests carrying them without a verified
+// signature are A2A with reason "unverified". Everything else is A2A.
+func DetectRequest(r *http.Request) Detection {
+	if result := httpsig.FromContext(r.Context()); result != nil {
+		return Detection{Mode: ProtocolSAGE, PeerDID: result.DID(), Reason: "http_signature"}
+	}
+
+	d := Detection{Mode: ProtocolA2A, PeerDID: r.Header.Get(HeaderAgentDID), Reason: "default"}
+	if r.Header.Get(HeaderSignatureInput) != "" ||
+		isSAGEContentType(r.Header.Get("Content-Type")) ||
+		r.Header.Get(HeaderProtocolMode) == string(types.ProtocolModeSAGE) {
+		d.Reason = "unverified"
+	}
+	return d
+}
+
+// DetectPayload detects the protocol of a JSON payload. It recognizes a
+// message with SAGE security metadata, either bare or as the message
+// parameter of a JSON-RPC request, and SAGE handshake messages. Nothing is
+// verified, so the result tells how to parse the payload and must not
+// satisfy a peer policy.
+func DetectPayload(body []byte) Detection {
+	var probe struct {
+		Security *types.SecurityMetadata `json:"security"`
+		Phase    string                  `json:"phase"`
+		FromDID  string                  `json:"from_did"`
+		Params   struct {
+			Message struct {
+				Security *types.SecurityMetadata `json:"security"`
+			} `json:"message"`
+		} `json:"params"`
+	}
+
+	d := Detection{Mode: ProtocolA2A, Reason: "default"}
+	if err := json.Unmarshal(body, &probe); err != nil {
+		return d
+	}
+
+	security := probe.Security
+	if security == nil {
+		security = probe.Params.Message.Security
+	}
+
+	switch {
+	case security != nil && security.Mode == types.ProtocolModeSAGE:
+		d.Mode, d.PeerDID, d.Reason = ProtocolSAGE, security.AgentDID, "security_metadata"
+	case probe.Phase != "" && probe.FromDID != "":
+		d.Mode, d.PeerDID, d.Reason = ProtocolSAGE, probe.FromDID, "handshake"
+	}
+	return d
+}
+
+// isSAGEContentType reports whether contentType is the SAGE media type.
+func isSAGEContentType(contentType string) bool {
+	if contentType == "" {
+		return false
+	}
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	return err == nil && mediaType == ContentTypeSAGE
+}
+
+type contextKey string
+
+const detectionKey contextKey = "protocol_detection"
+
+// WithDetection returns a context carrying the inbound protocol detection.
+func WithDetection(ctx context.Context, d Detection) context.Context {
+	return context.WithValue(ctx, detectionKey, d)
+}
+
+// GetDetection retrieves the inbound protocol detection from the context.
+func GetDetection(ctx context.Context) (Detection, bool) {
+	d, ok := ctx.Value(detectionKey).(Detection)
+	return d, ok
+}
+
+// Middleware detects the protocol of inbound HTTP requests, rejects A2A
+// requests from peers whose policy requires SAGE with 403 Forbidden, and
+// records the detection in the request context for handlers. It must run
+// behind httpsig.Verifier.Middleware for any request to count as SAGE.
+func Middleware(negotiator *Negotiator) func(http.Handler) http.Handler {
+	if negotiator == nil {
+		negotiator = NewNegotiator(nil)
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			d := DetectRequest(r)
+
+			if err := negotiator.CheckInbound(r.Context(), d.PeerDID, d); err != nil {
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(http.StatusForbidden)
+				json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
+				return
+			}
+
+			next.ServeHTTP(w, r.WithContext(WithDetection(r.Context(), d)))
+		})
+	}
+}
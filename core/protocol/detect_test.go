@@ -0,0 +1,143 @@
+// Copyright (C) 2025 sage-x-project
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with this program. If not, see <https://www.gnu.org/licenses/>.
+
+// SPDX-License-Identifier: LGPL-3.0-or-later
+
+package protocol
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/sage-x-project/sage-adk/adapters/sage/httpsig"
+)
+
+// verified marks r as carrying a signature verified for the key ID.
+func verified(r *http.Request, keyID string) *http.Request {
+	return r.WithContext(httpsig.WithResult(r.Context(), &httpsig.Result{KeyID: keyID}))
+}
+
+func TestDetectRequest(t *testing.T) {
+	tests := []struct {
+		name     string
+		headers  map[string]string
+		verified bool
+		want     ProtocolMode
+		reason   string
+	}{
+		{"plain JSON", map[string]string{"Content-Type": "application/json"}, false, ProtocolA2A, "default"},
+		{
+			"verified signature",
+			map[string]string{
+				HeaderSignatureInput: `sig1=("@method" "@target-uri");keyid="did:sage:eth:0x1#key-1"`,
+				HeaderSignature:      "sig1=:AAAA:",
+			},
+			true, ProtocolSAGE, "http_signature",
+		},
+		{
+			"unverified signature",
+			map[string]string{
+				HeaderSignatureInput: `sig1=("@method" "@target-uri");keyid="did:sage:eth:0x1#key-1"`,
+				HeaderSignature:      "sig1=:AAAA:",
+			},
+			false, ProtocolA2A, "unverified",
+		},
+		{"SAGE content type", map[string]string{"Content-Type": "application/sage+json; charset=utf-8"}, false, ProtocolA2A, "unverified"},
+		{"mode header", map[string]string{HeaderProtocolMode: "sage"}, false, ProtocolA2A, "unverified"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodPost, "/", nil)
+			for k, v := range tt.headers {
+				r.Header.Set(k, v)
+			}
+			if tt.verified {
+				r = verified(r, "did:sage:eth:0x1#key-1")
+			}
+			d := DetectRequest(r)
+			if d.Mode != tt.want || d.Reason != tt.reason {
+				t.Errorf("DetectRequest() = %v (%s), want %v (%s)", d.Mode, d.Reason, tt.want, tt.reason)
+			}
+			if tt.verified && d.PeerDID != "did:sage:eth:0x1" {
+				t.Errorf("PeerDID = %q, want the signer's DID", d.PeerDID)
+			}
+		})
+	}
+}
+
+func TestDetectPayload(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+		want ProtocolMode
+		did  string
+	}{
+		{"A2A message", `{"messageId":"m1","role":"user","parts":[]}`, ProtocolA2A, ""},
+		{"SAGE message", `{"security":{"mode":"sage","agentDid":"did:sage:eth:0x1"}}`, ProtocolSAGE, "did:sage:eth:0x1"},
+		{"JSON-RPC", `{"jsonrpc":"2.0","method":"message/send","params":{"message":{"security":{"mode":"sage","agentDid":"did:x"}}}}`, ProtocolSAGE, "did:x"},
+		{"handshake", `{"phase":"invitation","from_did":"did:y"}`, ProtocolSAGE, "did:y"},
+		{"not JSON", `hello`, ProtocolA2A, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := DetectPayload([]byte(tt.body))
+			if d.Mode != tt.want || d.PeerDID != tt.did {
+				t.Errorf("DetectPayload() = %v, %q, want %v, %q", d.Mode, d.PeerDID, tt.want, tt.did)
+			}
+		})
+	}
+}
+
+func TestMiddleware(t *testing.T) {
+	negotiator := NewNegotiator(nil)
+	negotiator.SetPeerPolicy("did:sage:eth:0xbank", PolicyRequireSAGE)
+
+	var seen Detection
+	handler := Middleware(negotiator)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		seen, _ = GetDetection(r.Context())
+	}))
+
+	// A verified signature satisfies the policy; the signer is the peer
+	r := httptest.NewRequest(http.MethodPost, "/", strings.NewReader("{}"))
+	r.Header.Set(HeaderAgentDID, "did:sage:eth:0xother")
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, verified(r, "did:sage:eth:0xbank#key-1"))
+	if w.Code != http.StatusOK || seen.Mode != ProtocolSAGE || seen.PeerDID != "did:sage:eth:0xbank" {
+		t.Errorf("SAGE request = %d, %+v", w.Code, seen)
+	}
+
+	// Headers claiming SAGE without a verified signature do not
+	r = httptest.NewRequest(http.MethodPost, "/", strings.NewReader("{}"))
+	r.Header.Set(HeaderProtocolMode, "sage")
+	r.Header.Set("Content-Type", ContentTypeSAGE)
+	r.Header.Set(HeaderAgentDID, "did:sage:eth:0xbank")
+	w = httptest.NewRecorder()
+	handler.ServeHTTP(w, r)
+	if w.Code != http.StatusForbidden {
+		t.Errorf("unverified SAGE request from SAGE-only peer = %d, want 403", w.Code)
+	}
+
+	r = httptest.NewRequest(http.MethodPost, "/", strings.NewReader("{}"))
+	r.Header.Set(HeaderAgentDID, "did:sage:eth:0xbank")
+	w = httptest.NewRecorder()
+	handler.ServeHTTP(w, r)
+	if w.Code != http.StatusForbidden {
+		t.Errorf("A2A request from SAGE-only peer = %d, want 403", w.Code)
+	}
+}
@@ -52,6 +52,36 @@
 //	selector.SetMode(protocol.ProtocolSAGE)
 //	adapter := selector.Select(msg) // Always returns SAGE adapter
 //
+// # Peer Negotiation
+//
+// SelectForPeer negotiates the protocol with a specific peer from its agent
+// card ("sage" capability) and DID document (a SAGEAgent service and
+// verification keys). A Negotiator applies per-peer policy: prefer SAGE and
+// allow downgrade (default), require SAGE, or A2A only. Every downgrade is
+// logged and recorded by reason in sage_agent_protocol_downgrades_total:
+//
+//	negotiator := protocol.NewNegotiator(&protocol.NegotiatorConfig{
+//	    AgentID: "agent-1",
+//	    Peers:   map[string]protocol.PeerPolicy{"did:sage:eth:0xbank": protocol.PolicyRequireSAGE},
+//	    Logger:  logger,
+//	    Metrics: metrics.NewAgentMetrics(collector),
+//	})
+//	selector.SetNegotiator(negotiator)
+//
+//	adapter, err := selector.SelectForPeer(ctx, &protocol.Peer{Card: card, DIDDocument: doc})
+//
+// # Inbound Detection
+//
+// DetectRequest classifies an inbound HTTP request as SAGE only if its
+// RFC 9421 signature was verified, and takes the peer DID from the signer.
+// SAGE headers and the application/sage+json content type alone are
+// claims, not evidence; DetectPayload inspects the JSON body the same
+// way. Middleware applies detection and peer policy to an HTTP server and
+// stores the Detection in the request context. It runs behind the
+// signature verifier; the builder mounts both on the A2A server:
+//
+//	handler = verifier.Middleware(protocol.Middleware(negotiator)(handler))
+//
 // # Custom Adapters
 //
 // Implement the ProtocolAdapter interface to support new protocols:
@@ -0,0 +1,369 @@
+// Copyright (C) 2025 sage-x-project
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with this program. If not, see <https://www.gnu.org/licenses/>.
+
+// SPDX-License-Identifier: LGPL-3.0-or-later
+
+package protocol
+
+import (
+	"context"
+	"strings"
+	"sync"
+
+	"github.com/sage-x-project/sage-adk/config"
+	"github.com/sage-x-project/sage-adk/observability/logging"
+	"github.com/sage-x-project/sage-adk/observability/metrics"
+	"github.com/sage-x-project/sage-adk/pkg/errors"
+	"github.com/sage-x-project/sage-adk/pkg/types"
+)
+
+const (
+	// CapabilitySAGE is the agent card capability advertising SAGE support.
+	CapabilitySAGE = "sage"
+
+	// ServiceTypeSAGE is the DID document service type of a SAGE endpoint.
+	ServiceTypeSAGE = "SAGEAgent"
+)
+
+// Downgrade and rejection reasons reported to logs and metrics.
+const (
+	ReasonNoPeerInfo      = "no_peer_info"
+	ReasonPeerUnsupported = "peer_unsupported"
+	ReasonNoVerification  = "no_verification_key"
+	ReasonNoAdapter       = "no_sage_adapter"
+	ReasonSAGERequired    = "sage_required"
+)
+
+// VerificationMethod is a public key entry of a DID document.
+type VerificationMethod struct {
+	ID                 string `json:"id"`
+	Type               string `json:"type"`
+	Controller         string `json:"controller,omitempty"`
+	PublicKeyMultibase string `json:"publicKeyMultibase,omitempty"`
+}
+
+// DIDService is a service endpoint entry of a DID document.
+type DIDService struct {
+	ID              string `json:"id"`
+	Type            string `json:"type"`
+	ServiceEndpoint string `json:"serviceEndpoint"`
+}
+
+// DIDDocument is the part of a W3C DID document used for negotiation.
+type DIDDocument struct {
+	ID                 string               `json:"id"`
+	VerificationMethod []VerificationMethod `json:"verificationMethod,omitempty"`
+	Service            []DIDService         `json:"service,omitempty"`
+}
+
+// Peer describes a remote agent for protocol negotiation.
+type Peer struct {
+	// ID identifies the peer when it has no DID.
+	ID string
+
+	// Card is the peer's agent card, if known.
+	Card *types.AgentCard
+
+	// DIDDocument is the peer's resolved DID document, if any.
+	DIDDocument *DIDDocument
+}
+
+// Identity returns the key used to look up the peer's policy: the DID if
+// known, then the agent card ID, then ID.
+func (p *Peer) Identity() string {
+	if p == nil {
+		return ""
+	}
+	if p.DIDDocument != nil && p.DIDDocument.ID != "" {
+		return p.DIDDocument.ID
+	}
+	if p.Card != nil && p.Card.ID != "" {
+		return p.Card.ID
+	}
+	return p.ID
+}
+
+// SupportsSAGE reports whether the peer can speak SAGE. The agent card must
+// advertise the "sage" capability (or list it in metadata "protocols"), or
+// the DID document must declare a SAGE service. A DID document without
+// verification keys cannot verify signatures and disqualifies the peer.
+// The returned reason explains a negative answer.
+func (p *Peer) SupportsSAGE() (bool, string) {
+	if p == nil || (p.Card == nil && p.DIDDocument == nil) {
+		return false, ReasonNoPeerInfo
+	}
+
+	if p.DIDDocument != nil && len(p.DIDDocument.VerificationMethod) == 0 {
+		return false, ReasonNoVerification
+	}
+
+	if p.Card != nil && cardAdvertisesSAGE(p.Card) {
+		return true, ""
+	}
+	if p.DIDDocument != nil {
+		for _, svc := range p.DIDDocument.Service {
+			if svc.Type == ServiceTypeSAGE {
+				return true, ""
+			}
+		}
+	}
+	return false, ReasonPeerUnsupported
+}
+
+// cardAdvertisesSAGE checks the card capabilities and "protocols" metadata.
+func cardAdvertisesSAGE(card *types.AgentCard) bool {
+	for _, c := range card.Capabilities {
+		if strings.EqualFold(c, CapabilitySAGE) {
+			return true
+		}
+	}
+
+	switch protocols := card.Metadata["protocols"].(type) {
+	case []string:
+		for _, p := range protocols {
+			if strings.EqualFold(p, CapabilitySAGE) {
+				return true
+			}
+		}
+	case []interface{}:
+		for _, p := range protocols {
+			if s, ok := p.(string); ok && strings.EqualFold(s, CapabilitySAGE) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// PeerPolicy decides which protocols are acceptable with a peer.
+type PeerPolicy int
+
+const (
+	// PolicyPreferSAGE uses SAGE when the peer supports it and downgrades
+	// to A2A otherwise.
+	PolicyPreferSAGE PeerPolicy = iota
+
+	// PolicyRequireSAGE refuses to talk to the peer without SAGE.
+	PolicyRequireSAGE
+
+	// PolicyA2AOnly always uses A2A.
+	PolicyA2AOnly
+)
+
+// String returns the string representation of the policy.
+func (p PeerPolicy) String() string {
+	switch p {
+	case PolicyPreferSAGE:
+		return "prefer_sage"
+	case PolicyRequireSAGE:
+		return "require_sage"
+	case PolicyA2AOnly:
+		return "a2a_only"
+	default:
+		return "unknown"
+	}
+}
+
+// ParsePeerPolicy parses a policy name. An empty name is PolicyPreferSAGE.
+func ParsePeerPolicy(s string) (PeerPolicy, error) {
+	switch s {
+	case "", "prefer_sage":
+		return PolicyPreferSAGE, nil
+	case "require_sage":
+		return PolicyRequireSAGE, nil
+	case "a2a_only":
+		return PolicyA2AOnly, nil
+	default:
+		return 0, errors.ErrInvalidValue.
+			WithMessage("unknown peer policy").
+			WithDetail("policy", s)
+	}
+}
+
+// NegotiatorConfig configures a Negotiator.
+type NegotiatorConfig struct {
+	// AgentID labels log entries and metrics of the local agent.
+	AgentID string
+
+	// DefaultPolicy applies to peers without an entry in Peers.
+	DefaultPolicy PeerPolicy
+
+	// Peers overrides the policy per peer, keyed by Peer.Identity.
+	Peers map[string]PeerPolicy
+
+	// Logger logs downgrades and rejections (optional).
+	Logger logging.Logger
+
+	// Metrics records downgrades and rejections (optional).
+	Metrics *metrics.AgentMetrics
+}
+
+// NegotiatorConfigFrom builds a negotiator configuration from the protocol
+// section of the agent configuration.
+func NegotiatorConfigFrom(cfg *config.ProtocolConfig) (*NegotiatorConfig, error) {
+	defaultPolicy, err := ParsePeerPolicy(cfg.PeerPolicy)
+	if err != nil {
+		return nil, err
+	}
+
+	peers := make(map[string]PeerPolicy, len(cfg.Peers))
+	for peer, name := range cfg.Peers {
+		policy, err := ParsePeerPolicy(name)
+		if err != nil {
+			return nil, errors.ErrInvalidValue.
+				WithMessage("unknown peer policy").
+				WithDetail("peer", peer).
+				WithDetail("policy", name)
+		}
+		peers[peer] = policy
+	}
+
+	return &NegotiatorConfig{
+		DefaultPolicy: defaultPolicy,
+		Peers:         peers,
+	}, nil
+}
+
+// Negotiator chooses the protocol for a peer from its agent card and DID
+// document and enforces per-peer protocol policy.
+type Negotiator struct {
+	mu     sync.RWMutex
+	config NegotiatorConfig
+	peers  map[string]PeerPolicy
+}
+
+// NewNegotiator creates a negotiator. A nil config prefers SAGE for all peers.
+func NewNegotiator(config *NegotiatorConfig) *Negotiator {
+	n := &Negotiator{peers: make(map[string]PeerPolicy)}
+	if config != nil {
+		n.config = *config
+		for peer, policy := range config.Peers {
+			n.peers[peer] = policy
+		}
+	}
+	return n
+}
+
+// SetPeerPolicy sets the policy for a peer identity.
+func (n *Negotiator) SetPeerPolicy(peer string, policy PeerPolicy) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.peers[peer] = policy
+}
+
+// PolicyFor returns the policy that applies to a peer identity.
+func (n *Negotiator) PolicyFor(peer string) PeerPolicy {
+	n.mu.RLock()
+	defer n.mu.RUnlock()
+
+	if policy, ok := n.peers[peer]; ok {
+		return policy
+	}
+	return n.config.DefaultPolicy
+}
+
+// Negotiate chooses the protocol for an outbound exchange with peer.
+// It returns ErrProtocolMismatch if the peer's policy requires SAGE and the
+// peer does not support it. Every downgrade is logged and counted.
+func (n *Negotiator) Negotiate(ctx context.Context, peer *Peer) (ProtocolMode, error) {
+	return n.negotiate(ctx, peer, true)
+}
+
+// Resolve returns the protocol for an exchange with peer under a selection
+// mode. Auto mode negotiates, treating the peer as unable to use SAGE when
+// sageAvailable is false; a fixed A2A mode is refused for peers that
+// require SAGE; a fixed SAGE mode is used as is.
+func (n *Negotiator) Resolve(ctx context.Context, peer *Peer, mode ProtocolMode, sageAvailable bool) (ProtocolMode, error) {
+	switch mode {
+	case ProtocolAuto:
+		return n.negotiate(ctx, peer, sageAvailable)
+	case ProtocolA2A:
+		if identity := peer.Identity(); n.PolicyFor(identity) == PolicyRequireSAGE {
+			return ProtocolA2A, n.reject(ctx, identity, ReasonSAGERequired)
+		}
+	}
+	return mode, nil
+}
+
+// negotiate implements Negotiate; sageAvailable is false when no SAGE
+// adapter can carry the exchange.
+func (n *Negotiator) negotiate(ctx context.Context, peer *Peer, sageAvailable bool) (ProtocolMode, error) {
+	identity := peer.Identity()
+	policy := n.PolicyFor(identity)
+
+	if policy == PolicyA2AOnly {
+		return ProtocolA2A, nil
+	}
+
+	supported, reason := peer.SupportsSAGE()
+	if supported && !sageAvailable {
+		supported, reason = false, ReasonNoAdapter
+	}
+	if supported {
+		return ProtocolSAGE, nil
+	}
+
+	if policy == PolicyRequireSAGE {
+		return ProtocolA2A, n.reject(ctx, identity, reason)
+	}
+
+	n.downgrade(ctx, identity, reason)
+	return ProtocolA2A, nil
+}
+
+// CheckInbound enforces the policy of the sender on an inbound message
+// received with the detected protocol. A2A traffic from a peer that
+// requires SAGE is rejected with ErrProtocolMismatch.
+func (n *Negotiator) CheckInbound(ctx context.Context, peer string, detected Detection) error {
+	if detected.Mode == ProtocolSAGE {
+		return nil
+	}
+	if n.PolicyFor(peer) == PolicyRequireSAGE {
+		return n.reject(ctx, peer, ReasonSAGERequired)
+	}
+	return nil
+}
+
+// downgrade logs and counts a fallback from SAGE to A2A.
+func (n *Negotiator) downgrade(ctx context.Context, peer, reason string) {
+	if n.config.Logger != nil {
+		n.config.Logger.Warn(ctx, "protocol downgraded to A2A",
+			logging.String("agent_id", n.config.AgentID),
+			logging.String("peer", peer),
+			logging.String("reason", reason),
+		)
+	}
+	if n.config.Metrics != nil {
+		n.config.Metrics.RecordProtocolDowngrade(n.config.AgentID, reason)
+	}
+}
+
+// reject logs and counts a refused exchange and returns the error.
+func (n *Negotiator) reject(ctx context.Context, peer, reason string) error {
+	if n.config.Logger != nil {
+		n.config.Logger.Warn(ctx, "peer rejected: SAGE required",
+			logging.String("agent_id", n.config.AgentID),
+			logging.String("peer", peer),
+			logging.String("reason", reason),
+		)
+	}
+	if n.config.Metrics != nil {
+		n.config.Metrics.RecordProtocolError(n.config.AgentID, "sage", reason)
+	}
+	return errors.ErrProtocolMismatch.
+		WithMessage("peer requires SAGE but it cannot be used").
+		WithDetail("peer", peer).
+		WithDetail("reason", reason)
+}
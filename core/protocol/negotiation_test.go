@@ -0,0 +1,182 @@
+// Copyright (C) 2025 sage-x-project
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with this program. If not, see <https://www.gnu.org/licenses/>.
+
+// SPDX-License-Identifier: LGPL-3.0-or-later
+
+package protocol
+
+import (
+	"bytes"
+	"context"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/sage-x-project/sage-adk/config"
+	"github.com/sage-x-project/sage-adk/observability/logging"
+	"github.com/sage-x-project/sage-adk/observability/metrics"
+	"github.com/sage-x-project/sage-adk/pkg/errors"
+	"github.com/sage-x-project/sage-adk/pkg/types"
+)
+
+func sagePeer(did string) *Peer {
+	return &Peer{DIDDocument: &DIDDocument{
+		ID:                 did,
+		VerificationMethod: []VerificationMethod{{ID: did + "#key-1", Type: "Ed25519VerificationKey2020"}},
+		Service:            []DIDService{{ID: did + "#sage", Type: ServiceTypeSAGE, ServiceEndpoint: "https://peer/sage"}},
+	}}
+}
+
+func a2aPeer(id string) *Peer {
+	card := types.NewAgentCard(id, "", "1.0")
+	card.ID = id
+	return &Peer{Card: card}
+}
+
+func TestPeer_SupportsSAGE(t *testing.T) {
+	cardWithMetadata := a2aPeer("meta")
+	cardWithMetadata.Card.Metadata["protocols"] = []interface{}{"a2a", "sage"}
+
+	cardWithCapability := a2aPeer("cap")
+	cardWithCapability.Card.Capabilities = []string{"SAGE"}
+
+	noKeys := sagePeer("did:sage:eth:0x3")
+	noKeys.DIDDocument.VerificationMethod = nil
+
+	tests := []struct {
+		name   string
+		peer   *Peer
+		want   bool
+		reason string
+	}{
+		{"nil peer", nil, false, ReasonNoPeerInfo},
+		{"DID service", sagePeer("did:sage:eth:0x1"), true, ""},
+		{"card capability", cardWithCapability, true, ""},
+		{"card metadata", cardWithMetadata, true, ""},
+		{"plain A2A card", a2aPeer("plain"), false, ReasonPeerUnsupported},
+		{"DID without keys", noKeys, false, ReasonNoVerification},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, reason := tt.peer.SupportsSAGE()
+			if got != tt.want || reason != tt.reason {
+				t.Errorf("SupportsSAGE() = %v, %q, want %v, %q", got, reason, tt.want, tt.reason)
+			}
+		})
+	}
+}
+
+func TestNegotiator_Negotiate(t *testing.T) {
+	var logs bytes.Buffer
+	collector := metrics.NewPrometheusCollector()
+
+	negotiator := NewNegotiator(&NegotiatorConfig{
+		AgentID: "agent-1",
+		Peers: map[string]PeerPolicy{
+			"did:sage:eth:0xbank": PolicyRequireSAGE,
+			"did:sage:eth:0xold":  PolicyA2AOnly,
+		},
+		Logger:  logging.NewStructuredLoggerWithOutput(logging.LevelInfo, &logs),
+		Metrics: metrics.NewAgentMetrics(collector),
+	})
+	ctx := context.Background()
+
+	if mode, err := negotiator.Negotiate(ctx, sagePeer("did:sage:eth:0x1")); err != nil || mode != ProtocolSAGE {
+		t.Errorf("capable peer = %v, %v, want sage", mode, err)
+	}
+	if mode, err := negotiator.Negotiate(ctx, sagePeer("did:sage:eth:0xold")); err != nil || mode != ProtocolA2A {
+		t.Errorf("a2a-only peer = %v, %v, want a2a", mode, err)
+	}
+
+	mode, err := negotiator.Negotiate(ctx, a2aPeer("legacy"))
+	if err != nil || mode != ProtocolA2A {
+		t.Errorf("legacy peer = %v, %v, want a2a", mode, err)
+	}
+	if !strings.Contains(logs.String(), "protocol downgraded") || !strings.Contains(logs.String(), ReasonPeerUnsupported) {
+		t.Errorf("downgrade not logged: %s", logs.String())
+	}
+
+	bank := sagePeer("did:sage:eth:0xbank")
+	bank.DIDDocument.Service = nil
+	if _, err := negotiator.Negotiate(ctx, bank); !errors.Is(err, errors.ErrProtocolMismatch) {
+		t.Errorf("required peer error = %v, want ErrProtocolMismatch", err)
+	}
+
+	w := httptest.NewRecorder()
+	collector.Handler().ServeHTTP(w, httptest.NewRequest("GET", "/metrics", nil))
+	if !strings.Contains(w.Body.String(), `sage_agent_protocol_downgrades_total{agent_id="agent-1",reason="peer_unsupported"} 1`) {
+		t.Error("downgrade metric not recorded")
+	}
+	if !strings.Contains(w.Body.String(), `sage_agent_protocol_errors_total{agent_id="agent-1",protocol="sage",type="peer_unsupported"} 1`) {
+		t.Error("rejection metric not recorded with its reason")
+	}
+}
+
+func TestParsePeerPolicy(t *testing.T) {
+	for _, policy := range []PeerPolicy{PolicyPreferSAGE, PolicyRequireSAGE, PolicyA2AOnly} {
+		got, err := ParsePeerPolicy(policy.String())
+		if err != nil || got != policy {
+			t.Errorf("ParsePeerPolicy(%q) = %v, %v", policy.String(), got, err)
+		}
+	}
+	if _, err := ParsePeerPolicy("sometimes"); err == nil {
+		t.Error("ParsePeerPolicy() should reject unknown policies")
+	}
+}
+
+func TestSelector_SelectForPeer(t *testing.T) {
+	selector := NewSelector()
+	selector.Register(ProtocolA2A, NewMockAdapter("a2a"))
+	ctx := context.Background()
+
+	// Without a SAGE adapter a capable peer is downgraded.
+	adapter, err := selector.SelectForPeer(ctx, sagePeer("did:sage:eth:0x1"))
+	if err != nil || adapter.Name() != "a2a" {
+		t.Fatalf("SelectForPeer() = %v, %v, want a2a", adapter, err)
+	}
+
+	selector.Register(ProtocolSAGE, NewMockAdapter("sage"))
+	adapter, err = selector.SelectForPeer(ctx, sagePeer("did:sage:eth:0x1"))
+	if err != nil || adapter.Name() != "sage" {
+		t.Fatalf("SelectForPeer() = %v, %v, want sage", adapter, err)
+	}
+
+	// A fixed A2A mode refuses peers that require SAGE.
+	negotiator := NewNegotiator(nil)
+	negotiator.SetPeerPolicy("did:sage:eth:0x1", PolicyRequireSAGE)
+	selector.SetNegotiator(negotiator)
+	selector.SetMode(ProtocolA2A)
+	if _, err := selector.SelectForPeer(ctx, sagePeer("did:sage:eth:0x1")); !errors.Is(err, errors.ErrProtocolMismatch) {
+		t.Errorf("SelectForPeer() error = %v, want ErrProtocolMismatch", err)
+	}
+}
+
+func TestNegotiatorConfigFrom(t *testing.T) {
+	cfg, err := NegotiatorConfigFrom(&config.ProtocolConfig{
+		PeerPolicy: "require_sage",
+		Peers:      map[string]string{"legacy": "prefer_sage"},
+	})
+	if err != nil {
+		t.Fatalf("NegotiatorConfigFrom() error = %v", err)
+	}
+	if cfg.DefaultPolicy != PolicyRequireSAGE || cfg.Peers["legacy"] != PolicyPreferSAGE {
+		t.Errorf("config = %+v", cfg)
+	}
+
+	if _, err := NegotiatorConfigFrom(&config.ProtocolConfig{Peers: map[string]string{"x": "bad"}}); err == nil {
+		t.Error("NegotiatorConfigFrom() should reject unknown policies")
+	}
+}
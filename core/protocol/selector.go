@@ -18,8 +18,10 @@
 package protocol
 
 import (
+	"context"
 	"sync"
 
+	"github.com/sage-x-project/sage-adk/pkg/errors"
 	"github.com/sage-x-project/sage-adk/pkg/types"
 )
 
@@ -29,6 +31,14 @@ type ProtocolSelector interface {
 	// Returns nil if no adapter is registered for the selected protocol.
 	Select(msg *types.Message) ProtocolAdapter
 
+	// SelectForPeer returns the protocol adapter for an exchange with peer.
+	// In auto mode the protocol is negotiated from the peer's agent card
+	// and DID document under the negotiator's policy.
+	SelectForPeer(ctx context.Context, peer *Peer) (ProtocolAdapter, error)
+
+	// SetNegotiator sets the negotiator used by SelectForPeer.
+	SetNegotiator(negotiator *Negotiator)
+
 	// Register registers a protocol adapter for a protocol mode.
 	Register(mode ProtocolMode, adapter ProtocolAdapter)
 
@@ -41,16 +51,18 @@ type ProtocolSelector interface {
 
 // selector implements ProtocolSelector.
 type selector struct {
-	mu       sync.RWMutex
-	mode     ProtocolMode
-	adapters map[ProtocolMode]ProtocolAdapter
+	mu         sync.RWMutex
+	mode       ProtocolMode
+	adapters   map[ProtocolMode]ProtocolAdapter
+	negotiator *Negotiator
 }
 
 // NewSelector creates a new protocol selector with auto-detection enabled.
 func NewSelector() ProtocolSelector {
 	return &selector{
-		mode:     ProtocolAuto,
-		adapters: make(map[ProtocolMode]ProtocolAdapter),
+		mode:       ProtocolAuto,
+		adapters:   make(map[ProtocolMode]ProtocolAdapter),
+		negotiator: NewNegotiator(nil),
 	}
 }
 
@@ -72,6 +84,42 @@ func (s *selector) Select(msg *types.Message) ProtocolAdapter {
 	return s.adapters[mode]
 }
 
+// SelectForPeer returns the protocol adapter negotiated for peer.
+func (s *selector) SelectForPeer(ctx context.Context, peer *Peer) (ProtocolAdapter, error) {
+	s.mu.RLock()
+	mode := s.mode
+	negotiator := s.negotiator
+	_, hasSAGE := s.adapters[ProtocolSAGE]
+	s.mu.RUnlock()
+
+	mode, err := negotiator.Resolve(ctx, peer, mode, hasSAGE)
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.RLock()
+	adapter := s.adapters[mode]
+	s.mu.RUnlock()
+
+	if adapter == nil {
+		return nil, errors.ErrNotFound.
+			WithMessage("protocol adapter not registered").
+			WithDetail("mode", mode.String())
+	}
+	return adapter, nil
+}
+
+// SetNegotiator sets the negotiator used by SelectForPeer.
+func (s *selector) SetNegotiator(negotiator *Negotiator) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if negotiator == nil {
+		negotiator = NewNegotiator(nil)
+	}
+	s.negotiator = negotiator
+}
+
 // Register registers a protocol adapter for a protocol mode.
 func (s *selector) Register(mode ProtocolMode, adapter ProtocolAdapter) {
 	s.mu.Lock()
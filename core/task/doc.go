@@ -0,0 +1,51 @@
+// Copyright (C) 2025 sage-x-project
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with this program. If not, see <https://www.gnu.org/licenses/>.
+
+// SPDX-License-Identifier: LGPL-3.0-or-later
+
+// Package task keeps track of the tasks an agent works on.
+//
+// Every message an agent server processes becomes a task (see
+// types.Task) with a lifecycle state, a history and artifacts. A Store
+// records tasks and notifies subscribers of their status and artifact
+// updates, so clients can poll, list, cancel and follow long-running work.
+// The A2A and gRPC servers share one store: a task started over either
+// protocol can be inspected over the other.
+//
+// Run records the authenticated caller that creates a task as its Owner.
+// Only the owner may continue it, and servers check Authorize before
+// exposing a task, so knowing a task ID does not grant access to it.
+//
+// Example:
+//
+//	store := task.NewMemoryStore(nil)
+//
+//	// Record and run a message as a task; the context is canceled when
+//	// the task is canceled through the store
+//	t, reply, err := task.Run(ctx, store, msg, func(ctx context.Context, msg *types.Message) (*types.Message, error) {
+//	    return agent.Process(ctx, msg)
+//	})
+//
+//	// Follow a task until it finishes
+//	events, err := store.Subscribe(ctx, t.ID)
+//	for event := range events {
+//	    if event.Status != nil {
+//	        fmt.Println(event.Status.Status.State)
+//	    }
+//	}
+//
+//	// Cancel it
+//	canceled, err := store.Cancel(ctx, t.ID)
+package task
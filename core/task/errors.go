@@ -0,0 +1,43 @@
+// Copyright (C) 2025 sage-x-project
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with this program. If not, see <https://www.gnu.org/licenses/>.
+
+// SPDX-License-Identifier: LGPL-3.0-or-later
+
+package task
+
+import "errors"
+
+var (
+	// ErrTaskNotFound is returned when a task is not found.
+	ErrTaskNotFound = errors.New("task not found")
+
+	// ErrTaskExists is returned when trying to create a task that already exists.
+	ErrTaskExists = errors.New("task already exists")
+
+	// ErrTaskFinished is returned when updating or canceling a task in a
+	// terminal state.
+	ErrTaskFinished = errors.New("task already finished")
+
+	// ErrTaskCanceled is returned by Run when the task was canceled while
+	// it ran.
+	ErrTaskCanceled = errors.New("task canceled")
+
+	// ErrInvalidTaskID is returned when the task ID is empty.
+	ErrInvalidTaskID = errors.New("invalid task ID")
+
+	// ErrInvalidPageToken is returned when a list page token is not one
+	// the store issued.
+	ErrInvalidPageToken = errors.New("invalid page token")
+)
@@ -0,0 +1,335 @@
+// Copyright (C) 2025 sage-x-project
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with this program. If not, see <https://www.gnu.org/licenses/>.
+
+// SPDX-License-Identifier: LGPL-3.0-or-later
+
+package task
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/sage-x-project/sage-adk/pkg/types"
+)
+
+// Event kinds, as in the A2A protocol.
+const (
+	kindStatusUpdate   = "status-update"
+	kindArtifactUpdate = "artifact-update"
+)
+
+// MemoryStore is an in-memory implementation of the Store interface.
+type MemoryStore struct {
+	mu          sync.Mutex
+	config      *Config
+	tasks       map[string]*types.Task
+	order       []string // task IDs, oldest first
+	subscribers map[string]map[*subscriber]struct{}
+}
+
+// subscriber is one Subscribe call.
+type subscriber struct {
+	events chan Event
+	done   chan struct{}
+}
+
+// NewMemoryStore creates a new in-memory task store.
+func NewMemoryStore(config *Config) *MemoryStore {
+	if config == nil {
+		config = DefaultConfig()
+	}
+
+	return &MemoryStore{
+		config:      config,
+		tasks:       make(map[string]*types.Task),
+		subscribers: make(map[string]map[*subscriber]struct{}),
+	}
+}
+
+// Create records a new task. A task without a status starts submitted.
+func (m *MemoryStore) Create(ctx context.Context, task *types.Task) error {
+	if task == nil || task.ID == "" {
+		return ErrInvalidTaskID
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, exists := m.tasks[task.ID]; exists {
+		return ErrTaskExists
+	}
+
+	now := time.Now()
+	task = copyTask(task)
+	if task.Kind == "" {
+		task.Kind = "task"
+	}
+	if task.Status.State == "" {
+		task.Status.State = types.TaskStateSubmitted
+	}
+	if task.Status.Timestamp.IsZero() {
+		task.Status.Timestamp = now
+	}
+	if task.CreatedAt.IsZero() {
+		task.CreatedAt = now
+	}
+	task.UpdatedAt = now
+
+	m.tasks[task.ID] = task
+	m.order = append(m.order, task.ID)
+	m.evict()
+	return nil
+}
+
+// Get retrieves a task by ID.
+func (m *MemoryStore) Get(ctx context.Context, taskID string) (*types.Task, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	task, exists := m.tasks[taskID]
+	if !exists {
+		return nil, ErrTaskNotFound
+	}
+	return copyTask(task), nil
+}
+
+// List lists tasks, newest first. The page token is the ID of the last
+// task of the previous page.
+func (m *MemoryStore) List(ctx context.Context, query *Query) (*Page, error) {
+	if query == nil {
+		query = &Query{}
+	}
+	size := query.pageSize()
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	page := &Page{}
+	found := query.PageToken == ""
+	for i := len(m.order) - 1; i >= 0; i-- {
+		id := m.order[i]
+		if !found {
+			found = id == query.PageToken
+			continue
+		}
+
+		task := m.tasks[id]
+		if query.ContextID != "" && task.ContextID != query.ContextID {
+			continue
+		}
+		if query.State != "" && task.Status.State != query.State {
+			continue
+		}
+		if query.Owner != nil && OwnerOf(task) != *query.Owner {
+			continue
+		}
+		if len(page.Tasks) == size {
+			page.NextPageToken = page.Tasks[size-1].ID
+			break
+		}
+		page.Tasks = append(page.Tasks, copyTask(task))
+	}
+
+	if !found {
+		return nil, ErrInvalidPageToken
+	}
+	return page, nil
+}
+
+// UpdateStatus moves a task to a new state and notifies subscribers.
+func (m *MemoryStore) UpdateStatus(ctx context.Context, taskID string, state types.TaskState, message *types.Message) (*types.Task, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	task, err := m.unfinished(taskID)
+	if err != nil {
+		return nil, err
+	}
+
+	task.UpdateState(state, message)
+	if message != nil {
+		task.AddHistoryMessage(*message)
+	}
+
+	m.publish(task.ID, Event{Status: statusEvent(task)})
+	return copyTask(task), nil
+}
+
+// AddArtifact adds an artifact to a task and notifies subscribers.
+func (m *MemoryStore) AddArtifact(ctx context.Context, taskID string, artifact types.Artifact) (*types.Task, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	task, err := m.unfinished(taskID)
+	if err != nil {
+		return nil, err
+	}
+
+	if artifact.CreatedAt.IsZero() {
+		artifact.CreatedAt = time.Now()
+	}
+	task.AddArtifact(artifact)
+
+	m.publish(task.ID, Event{Artifact: &types.TaskArtifactUpdateEvent{
+		ContextID: task.ContextID,
+		TaskID:    task.ID,
+		Kind:      kindArtifactUpdate,
+		Artifact:  artifact,
+	}})
+	return copyTask(task), nil
+}
+
+// Cancel moves a task that has not finished to the canceled state.
+func (m *MemoryStore) Cancel(ctx context.Context, taskID string) (*types.Task, error) {
+	return m.UpdateStatus(ctx, taskID, types.TaskStateCanceled, nil)
+}
+
+// Subscribe returns the updates of a task, starting with its current
+// status. For a finished task, the channel carries only the final status.
+func (m *MemoryStore) Subscribe(ctx context.Context, taskID string) (<-chan Event, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	task, exists := m.tasks[taskID]
+	if !exists {
+		return nil, ErrTaskNotFound
+	}
+
+	buffer := m.config.SubscriberBuffer
+	if buffer < 1 {
+		buffer = 1
+	}
+	sub := &subscriber{events: make(chan Event, buffer), done: make(chan struct{})}
+	sub.events <- Event{Status: statusEvent(task)}
+	if task.Status.State.IsTerminal() {
+		close(sub.events)
+		return sub.events, nil
+	}
+
+	if m.subscribers[taskID] == nil {
+		m.subscribers[taskID] = make(map[*subscriber]struct{})
+	}
+	m.subscribers[taskID][sub] = struct{}{}
+
+	go func() {
+		select {
+		case <-ctx.Done():
+			m.mu.Lock()
+			m.unsubscribe(taskID, sub)
+			m.mu.Unlock()
+		case <-sub.done:
+		}
+	}()
+
+	return sub.events, nil
+}
+
+// Count returns the number of tasks kept.
+func (m *MemoryStore) Count() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return len(m.tasks)
+}
+
+// unfinished returns the stored task if it has not reached a terminal
+// state. Callers must hold m.mu.
+func (m *MemoryStore) unfinished(taskID string) (*types.Task, error) {
+	task, exists := m.tasks[taskID]
+	if !exists {
+		return nil, ErrTaskNotFound
+	}
+	if task.Status.State.IsTerminal() {
+		return nil, ErrTaskFinished
+	}
+	return task, nil
+}
+
+// publish delivers an event to the task's subscribers without blocking.
+// Subscribers whose buffer is full are dropped, and all are closed after
+// a final event. Callers must hold m.mu.
+func (m *MemoryStore) publish(taskID string, event Event) {
+	for sub := range m.subscribers[taskID] {
+		select {
+		case sub.events <- event:
+			if event.Final() {
+				m.unsubscribe(taskID, sub)
+			}
+		default:
+			m.unsubscribe(taskID, sub)
+		}
+	}
+}
+
+// unsubscribe removes a subscriber and closes its channel. Callers must
+// hold m.mu.
+func (m *MemoryStore) unsubscribe(taskID string, sub *subscriber) {
+	subs := m.subscribers[taskID]
+	if _, ok := subs[sub]; !ok {
+		return
+	}
+	delete(subs, sub)
+	if len(subs) == 0 {
+		delete(m.subscribers, taskID)
+	}
+	close(sub.events)
+	close(sub.done)
+}
+
+// evict drops the oldest finished tasks beyond MaxTasks. Callers must hold
+// m.mu.
+func (m *MemoryStore) evict() {
+	excess := len(m.tasks) - m.config.MaxTasks
+	if m.config.MaxTasks <= 0 || excess <= 0 {
+		return
+	}
+
+	kept := m.order[:0]
+	for _, id := range m.order {
+		if excess > 0 && m.tasks[id].Status.State.IsTerminal() {
+			delete(m.tasks, id)
+			excess--
+			continue
+		}
+		kept = append(kept, id)
+	}
+	m.order = kept
+}
+
+// statusEvent returns the status update event for a task's current status.
+func statusEvent(task *types.Task) *types.TaskStatusUpdateEvent {
+	return &types.TaskStatusUpdateEvent{
+		ContextID: task.ContextID,
+		TaskID:    task.ID,
+		Kind:      kindStatusUpdate,
+		Status:    task.Status,
+		Final:     task.Status.State.IsTerminal(),
+	}
+}
+
+// copyTask returns a copy of a task that can be modified without affecting
+// the original. Messages and parts are shared.
+func copyTask(task *types.Task) *types.Task {
+	copied := *task
+	copied.Artifacts = append([]types.Artifact(nil), task.Artifacts...)
+	copied.History = append([]types.Message(nil), task.History...)
+	if task.Metadata != nil {
+		copied.Metadata = make(map[string]interface{}, len(task.Metadata))
+		for k, v := range task.Metadata {
+			copied.Metadata[k] = v
+		}
+	}
+	return &copied
+}
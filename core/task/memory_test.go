@@ -0,0 +1,208 @@
+// Copyright (C) 2025 sage-x-project
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with this program. If not, see <https://www.gnu.org/licenses/>.
+
+// SPDX-License-Identifier: LGPL-3.0-or-later
+
+package task
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/sage-x-project/sage-adk/pkg/types"
+)
+
+func TestMemoryStore_CreateGet(t *testing.T) {
+	store := NewMemoryStore(nil)
+	ctx := context.Background()
+
+	if err := store.Create(ctx, types.NewTask("task-1", "ctx-1")); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	if err := store.Create(ctx, types.NewTask("task-1", "ctx-1")); !errors.Is(err, ErrTaskExists) {
+		t.Errorf("Create() duplicate error = %v, want ErrTaskExists", err)
+	}
+	if err := store.Create(ctx, &types.Task{}); !errors.Is(err, ErrInvalidTaskID) {
+		t.Errorf("Create() without ID error = %v, want ErrInvalidTaskID", err)
+	}
+
+	task, err := store.Get(ctx, "task-1")
+	if err != nil || task.Status.State != types.TaskStateSubmitted {
+		t.Fatalf("Get() = %+v, %v", task, err)
+	}
+
+	// Returned tasks are copies
+	task.Metadata["changed"] = true
+	if again, _ := store.Get(ctx, "task-1"); again.Metadata["changed"] != nil {
+		t.Error("Get() returned the stored task")
+	}
+
+	if _, err := store.Get(ctx, "missing"); !errors.Is(err, ErrTaskNotFound) {
+		t.Errorf("Get() missing error = %v, want ErrTaskNotFound", err)
+	}
+}
+
+func TestMemoryStore_UpdateAndCancel(t *testing.T) {
+	store := NewMemoryStore(nil)
+	ctx := context.Background()
+	store.Create(ctx, types.NewTask("task-1", "ctx-1"))
+
+	reply := types.NewMessage(types.MessageRoleAgent, []types.Part{types.NewTextPart("working on it")})
+	task, err := store.UpdateStatus(ctx, "task-1", types.TaskStateWorking, reply)
+	if err != nil || task.Status.State != types.TaskStateWorking || len(task.History) != 1 {
+		t.Fatalf("UpdateStatus() = %+v, %v", task, err)
+	}
+
+	artifact := types.NewArtifact("report", "", []types.Part{types.NewTextPart("done")})
+	if task, err = store.AddArtifact(ctx, "task-1", *artifact); err != nil || len(task.Artifacts) != 1 {
+		t.Fatalf("AddArtifact() = %+v, %v", task, err)
+	}
+
+	if task, err = store.Cancel(ctx, "task-1"); err != nil || task.Status.State != types.TaskStateCanceled {
+		t.Fatalf("Cancel() = %+v, %v", task, err)
+	}
+	if _, err := store.Cancel(ctx, "task-1"); !errors.Is(err, ErrTaskFinished) {
+		t.Errorf("Cancel() finished task error = %v, want ErrTaskFinished", err)
+	}
+	if _, err := store.UpdateStatus(ctx, "task-1", types.TaskStateCompleted, nil); !errors.Is(err, ErrTaskFinished) {
+		t.Errorf("UpdateStatus() finished task error = %v, want ErrTaskFinished", err)
+	}
+}
+
+func TestMemoryStore_List(t *testing.T) {
+	store := NewMemoryStore(nil)
+	ctx := context.Background()
+	for i := 0; i < 5; i++ {
+		contextID := "ctx-even"
+		if i%2 == 1 {
+			contextID = "ctx-odd"
+		}
+		store.Create(ctx, types.NewTask(fmt.Sprintf("task-%d", i), contextID))
+	}
+	store.Cancel(ctx, "task-4")
+
+	// Newest first, in pages
+	var ids []string
+	query := &Query{PageSize: 2}
+	for {
+		page, err := store.List(ctx, query)
+		if err != nil {
+			t.Fatalf("List() error = %v", err)
+		}
+		for _, task := range page.Tasks {
+			ids = append(ids, task.ID)
+		}
+		if page.NextPageToken == "" {
+			break
+		}
+		query.PageToken = page.NextPageToken
+	}
+	if fmt.Sprint(ids) != "[task-4 task-3 task-2 task-1 task-0]" {
+		t.Errorf("List() pages = %v", ids)
+	}
+
+	page, _ := store.List(ctx, &Query{ContextID: "ctx-even", State: types.TaskStateSubmitted})
+	if len(page.Tasks) != 2 || page.Tasks[0].ID != "task-2" || page.Tasks[1].ID != "task-0" {
+		t.Errorf("List() filtered = %d tasks", len(page.Tasks))
+	}
+
+	if _, err := store.List(ctx, &Query{PageToken: "bogus"}); !errors.Is(err, ErrInvalidPageToken) {
+		t.Errorf("List() bad token error = %v, want ErrInvalidPageToken", err)
+	}
+}
+
+func TestMemoryStore_Evict(t *testing.T) {
+	store := NewMemoryStore(&Config{MaxTasks: 2, SubscriberBuffer: 1})
+	ctx := context.Background()
+
+	store.Create(ctx, types.NewTask("running", "ctx"))
+	store.Create(ctx, types.NewTask("finished", "ctx"))
+	store.Cancel(ctx, "finished")
+	store.Create(ctx, types.NewTask("new", "ctx"))
+
+	if _, err := store.Get(ctx, "finished"); !errors.Is(err, ErrTaskNotFound) {
+		t.Error("oldest finished task was kept")
+	}
+	if _, err := store.Get(ctx, "running"); err != nil {
+		t.Error("running task was evicted")
+	}
+	if store.Count() != 2 {
+		t.Errorf("Count() = %d, want 2", store.Count())
+	}
+}
+
+func TestMemoryStore_Subscribe(t *testing.T) {
+	store := NewMemoryStore(nil)
+	ctx := context.Background()
+	store.Create(ctx, types.NewTask("task-1", "ctx-1"))
+
+	events, err := store.Subscribe(ctx, "task-1")
+	if err != nil {
+		t.Fatalf("Subscribe() error = %v", err)
+	}
+
+	store.UpdateStatus(ctx, "task-1", types.TaskStateWorking, nil)
+	store.AddArtifact(ctx, "task-1", *types.NewArtifact("out", "", []types.Part{types.NewTextPart("result")}))
+	store.UpdateStatus(ctx, "task-1", types.TaskStateCompleted, nil)
+
+	var got []string
+	timeout := time.After(time.Second)
+	for done := false; !done; {
+		select {
+		case event, ok := <-events:
+			if !ok {
+				done = true
+				break
+			}
+			if event.Status != nil {
+				got = append(got, string(event.Status.Status.State))
+			} else {
+				got = append(got, event.Artifact.Artifact.Name)
+			}
+		case <-timeout:
+			t.Fatal("subscription was not closed after the final event")
+		}
+	}
+	if fmt.Sprint(got) != "[submitted working out completed]" {
+		t.Errorf("events = %v", got)
+	}
+
+	// A finished task yields its final status only
+	events, _ = store.Subscribe(ctx, "task-1")
+	if event := <-events; !event.Final() {
+		t.Errorf("event = %+v, want final status", event)
+	}
+	if _, ok := <-events; ok {
+		t.Error("subscription to a finished task stayed open")
+	}
+
+	// Canceling the context ends the subscription
+	store.Create(ctx, types.NewTask("task-2", "ctx-1"))
+	subCtx, cancel := context.WithCancel(ctx)
+	events, _ = store.Subscribe(subCtx, "task-2")
+	<-events
+	cancel()
+	select {
+	case _, ok := <-events:
+		if ok {
+			t.Error("unexpected event after cancel")
+		}
+	case <-time.After(time.Second):
+		t.Error("subscription was not closed after cancel")
+	}
+}
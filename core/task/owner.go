@@ -0,0 +1,79 @@
+// Copyright (C) 2025 sage-x-project
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with this program. If not, see <https://www.gnu.org/licenses/>.
+
+// SPDX-License-Identifier: LGPL-3.0-or-later
+package task
+
+import (
+	"context"
+
+	"github.com/sage-x-project/sage-adk/core/authn"
+	"github.com/sage-x-project/sage-adk/pkg/types"
+)
+
+// Task metadata keys recording the owner.
+const (
+	ownerSubjectKey = "owner_subject"
+	ownerTenantKey  = "owner_tenant"
+)
+
+// Owner is the caller that created a task. Only the same caller may
+// continue, read, cancel or subscribe to it.
+type Owner struct {
+	// Subject is the subject of the authenticated principal.
+	Subject string
+
+	// Tenant is the principal's tenant.
+	Tenant string
+}
+
+// OwnerFromContext returns the caller recorded in ctx by the authn
+// middleware. Unauthenticated callers are the zero Owner.
+func OwnerFromContext(ctx context.Context) Owner {
+	principal, ok := authn.PrincipalFromContext(ctx)
+	if !ok {
+		return Owner{}
+	}
+	return Owner{Subject: principal.Subject, Tenant: principal.Tenant}
+}
+
+// OwnerOf returns the owner recorded on t with SetOwner.
+func OwnerOf(t *types.Task) Owner {
+	subject, _ := t.Metadata[ownerSubjectKey].(string)
+	tenant, _ := t.Metadata[ownerTenantKey].(string)
+	return Owner{Subject: subject, Tenant: tenant}
+}
+
+// SetOwner records owner on t.
+func SetOwner(t *types.Task, owner Owner) {
+	if owner == (Owner{}) {
+		return
+	}
+	if t.Metadata == nil {
+		t.Metadata = make(map[string]interface{})
+	}
+	t.Metadata[ownerSubjectKey] = owner.Subject
+	t.Metadata[ownerTenantKey] = owner.Tenant
+}
+
+// Authorize returns ErrTaskNotFound unless t belongs to the caller in ctx.
+// Tasks of other callers are reported as missing, so their IDs cannot be
+// probed.
+func Authorize(ctx context.Context, t *types.Task) error {
+	if OwnerOf(t) != OwnerFromContext(ctx) {
+		return ErrTaskNotFound
+	}
+	return nil
+}
@@ -0,0 +1,171 @@
+// Copyright (C) 2025 sage-x-project
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with this program. If not, see <https://www.gnu.org/licenses/>.
+
+// SPDX-License-Identifier: LGPL-3.0-or-later
+
+package task
+
+import (
+	"context"
+	"errors"
+
+	"github.com/sage-x-project/sage-adk/core/middleware"
+	"github.com/sage-x-project/sage-adk/pkg/types"
+)
+
+// Run records msg as a task in store and processes it with handler.
+//
+// A message with a task ID continues that task, e.g. one waiting for
+// input; otherwise a new task is created, owned by the caller in ctx (see
+// OwnerFromContext). Only the owner may continue a task: the task of
+// another caller is reported as ErrTaskNotFound. The message passed to handler
+// and the reply carry the task and context IDs. The task ends completed
+// with the reply as its status message, or failed if handler returns an
+// error. A reply marked with InputRequired leaves the task waiting for the
+// user's next message instead. handler's context is canceled when the task is canceled through
+// the store, in which case Run returns ErrTaskCanceled.
+func Run(ctx context.Context, store Store, msg *types.Message, handler middleware.Handler) (*types.Task, *types.Message, error) {
+	task, msg, err := start(ctx, store, msg)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	runCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	events, err := store.Subscribe(runCtx, task.ID)
+	if err != nil {
+		return nil, nil, err
+	}
+	go func() {
+		for event := range events {
+			if event.Status != nil && event.Status.Status.State == types.TaskStateCanceled {
+				cancel()
+			}
+		}
+	}()
+
+	reply, err := handler(runCtx, msg)
+	if err != nil {
+		failed, updateErr := store.UpdateStatus(ctx, task.ID, types.TaskStateFailed, failureMessage(task, err))
+		if errors.Is(updateErr, ErrTaskFinished) {
+			return canceled(ctx, store, task)
+		}
+		if updateErr == nil {
+			task = failed
+		}
+		return task, nil, err
+	}
+
+	if reply != nil {
+		replied := *reply
+		replied.TaskID = &task.ID
+		replied.ContextID = &task.ContextID
+		reply = &replied
+	}
+	state := types.TaskStateCompleted
+	if IsInputRequired(reply) {
+		state = types.TaskStateInputRequired
+	}
+	done, err := store.UpdateStatus(ctx, task.ID, state, reply)
+	if errors.Is(err, ErrTaskFinished) {
+		return canceled(ctx, store, task)
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	return done, reply, nil
+}
+
+// inputRequiredKey is the reply metadata key set by InputRequired.
+const inputRequiredKey = "input_required"
+
+// InputRequired marks msg as a reply that asks the user for input, e.g.
+// to approve a tool call, and returns it. Run then leaves the task in the
+// input-required state; the user's reply continues it.
+func InputRequired(msg *types.Message) *types.Message {
+	if msg.Metadata == nil {
+		msg.Metadata = make(map[string]interface{})
+	}
+	msg.Metadata[inputRequiredKey] = true
+	return msg
+}
+
+// IsInputRequired reports whether msg was marked with InputRequired.
+func IsInputRequired(msg *types.Message) bool {
+	if msg == nil {
+		return false
+	}
+	required, _ := msg.Metadata[inputRequiredKey].(bool)
+	return required
+}
+
+// start creates the task of msg, or resumes the task it refers to, and
+// returns it working along with msg carrying its IDs.
+func start(ctx context.Context, store Store, msg *types.Message) (*types.Task, *types.Message, error) {
+	withIDs := *msg
+	msg = &withIDs
+
+	if msg.TaskID != nil && *msg.TaskID != "" {
+		existing, err := store.Get(ctx, *msg.TaskID)
+		if err == nil {
+			if err := Authorize(ctx, existing); err != nil {
+				return nil, nil, err
+			}
+			msg.ContextID = &existing.ContextID
+			task, err := store.UpdateStatus(ctx, existing.ID, types.TaskStateWorking, msg)
+			return task, msg, err
+		}
+		if !errors.Is(err, ErrTaskNotFound) {
+			return nil, nil, err
+		}
+	} else {
+		taskID := types.GenerateTaskID()
+		msg.TaskID = &taskID
+	}
+
+	if msg.ContextID == nil || *msg.ContextID == "" {
+		contextID := types.GenerateContextID()
+		msg.ContextID = &contextID
+	}
+
+	task := types.NewTask(*msg.TaskID, *msg.ContextID)
+	task.Status.State = types.TaskStateWorking
+	SetOwner(task, OwnerFromContext(ctx))
+	task.History = append(task.History, *msg)
+	if err := store.Create(ctx, task); err != nil {
+		return nil, nil, err
+	}
+	return task, msg, nil
+}
+
+// canceled returns the outcome of a task that another caller finished
+// while it ran, normally by canceling it.
+func canceled(ctx context.Context, store Store, task *types.Task) (*types.Task, *types.Message, error) {
+	current, err := store.Get(ctx, task.ID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if current.Status.State != types.TaskStateCanceled {
+		return current, nil, ErrTaskFinished
+	}
+	return current, nil, ErrTaskCanceled
+}
+
+// failureMessage is the status message of a failed task.
+func failureMessage(task *types.Task, err error) *types.Message {
+	return types.NewMessageWithContext(types.MessageRoleAgent,
+		[]types.Part{types.NewTextPart(err.Error())}, &task.ID, &task.ContextID)
+}
@@ -0,0 +1,155 @@
+// Copyright (C) 2025 sage-x-project
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with this program. If not, see <https://www.gnu.org/licenses/>.
+
+// SPDX-License-Identifier: LGPL-3.0-or-later
+
+package task
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/sage-x-project/sage-adk/core/authn"
+	"github.com/sage-x-project/sage-adk/pkg/types"
+)
+
+func TestRun(t *testing.T) {
+	store := NewMemoryStore(nil)
+	ctx := context.Background()
+	msg := types.NewMessage(types.MessageRoleUser, []types.Part{types.NewTextPart("hello")})
+
+	var seen *types.Message
+	task, reply, err := Run(ctx, store, msg, func(ctx context.Context, msg *types.Message) (*types.Message, error) {
+		seen = msg
+		return types.NewMessage(types.MessageRoleAgent, []types.Part{types.NewTextPart("hi")}), nil
+	})
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	if task.Status.State != types.TaskStateCompleted || len(task.History) != 2 {
+		t.Errorf("task = %+v", task)
+	}
+	if seen.TaskID == nil || *seen.TaskID != task.ID || *reply.TaskID != task.ID || *reply.ContextID != task.ContextID {
+		t.Errorf("IDs not propagated: message %v, reply %v, task %s", seen.TaskID, reply.TaskID, task.ID)
+	}
+	if msg.TaskID != nil {
+		t.Error("Run() modified the caller's message")
+	}
+
+	// A failing handler fails the task
+	failure := errors.New("boom")
+	task, _, err = Run(ctx, store, msg, func(ctx context.Context, msg *types.Message) (*types.Message, error) {
+		return nil, failure
+	})
+	if !errors.Is(err, failure) || task.Status.State != types.TaskStateFailed {
+		t.Errorf("Run() = %+v, %v", task, err)
+	}
+
+	// A message for a finished task is rejected
+	_, _, err = Run(ctx, store, seen, func(ctx context.Context, msg *types.Message) (*types.Message, error) {
+		return nil, nil
+	})
+	if !errors.Is(err, ErrTaskFinished) {
+		t.Errorf("Run() on finished task error = %v, want ErrTaskFinished", err)
+	}
+}
+
+func TestRun_Cancel(t *testing.T) {
+	store := NewMemoryStore(nil)
+	ctx := context.Background()
+	taskID := "task-long"
+	msg := types.NewMessageWithContext(types.MessageRoleUser, []types.Part{types.NewTextPart("work")}, &taskID, nil)
+
+	started := make(chan struct{})
+	go func() {
+		<-started
+		if _, err := store.Cancel(ctx, taskID); err != nil {
+			t.Errorf("Cancel() error = %v", err)
+		}
+	}()
+
+	task, _, err := Run(ctx, store, msg, func(ctx context.Context, msg *types.Message) (*types.Message, error) {
+		close(started)
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(5 * time.Second):
+			return nil, errors.New("handler was not canceled")
+		}
+	})
+	if !errors.Is(err, ErrTaskCanceled) || task.Status.State != types.TaskStateCanceled {
+		t.Errorf("Run() = %+v, %v, want canceled", task, err)
+	}
+}
+
+func TestRun_InputRequired(t *testing.T) {
+	store := NewMemoryStore(nil)
+	ctx := context.Background()
+	msg := types.NewMessage(types.MessageRoleUser, []types.Part{types.NewTextPart("delete it")})
+
+	task, reply, err := Run(ctx, store, msg, func(ctx context.Context, msg *types.Message) (*types.Message, error) {
+		return InputRequired(types.NewMessage(types.MessageRoleAgent, []types.Part{types.NewTextPart("are you sure?")})), nil
+	})
+	if err != nil || task.Status.State != types.TaskStateInputRequired {
+		t.Fatalf("Run() = %+v, %v, want input-required", task, err)
+	}
+
+	// The user's answer continues the task
+	answer := types.NewMessageWithContext(types.MessageRoleUser, []types.Part{types.NewTextPart("yes")}, reply.TaskID, nil)
+	task, _, err = Run(ctx, store, answer, func(ctx context.Context, msg *types.Message) (*types.Message, error) {
+		return types.NewMessage(types.MessageRoleAgent, []types.Part{types.NewTextPart("deleted")}), nil
+	})
+	if err != nil || task.Status.State != types.TaskStateCompleted || len(task.History) != 4 {
+		t.Errorf("Run() = %+v, %v, want completed with 4 messages", task, err)
+	}
+}
+
+func TestRun_Owner(t *testing.T) {
+	store := NewMemoryStore(nil)
+	alice := authn.WithPrincipal(context.Background(), &authn.Principal{Subject: "alice", Tenant: "tenant-a"})
+	mallory := authn.WithPrincipal(context.Background(), &authn.Principal{Subject: "mallory", Tenant: "tenant-a"})
+	askAgain := func(ctx context.Context, msg *types.Message) (*types.Message, error) {
+		return InputRequired(types.NewMessage(types.MessageRoleAgent, []types.Part{types.NewTextPart("which account?")})), nil
+	}
+
+	msg := types.NewMessage(types.MessageRoleUser, []types.Part{types.NewTextPart("transfer")})
+	task, _, err := Run(alice, store, msg, askAgain)
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	if owner := OwnerOf(task); owner != (Owner{Subject: "alice", Tenant: "tenant-a"}) {
+		t.Errorf("OwnerOf() = %+v, want alice", owner)
+	}
+
+	// Another caller cannot continue the task
+	next := types.NewMessageWithContext(types.MessageRoleUser, []types.Part{types.NewTextPart("approve")}, &task.ID, nil)
+	if _, _, err := Run(mallory, store, next, askAgain); !errors.Is(err, ErrTaskNotFound) {
+		t.Errorf("Run() by another caller error = %v, want ErrTaskNotFound", err)
+	}
+	if _, _, err := Run(alice, store, next, askAgain); err != nil {
+		t.Errorf("Run() by the owner error = %v", err)
+	}
+
+	// Listing filters by owner
+	for ctx, want := range map[context.Context]int{alice: 1, mallory: 0} {
+		owner := OwnerFromContext(ctx)
+		page, err := store.List(ctx, &Query{Owner: &owner})
+		if err != nil || len(page.Tasks) != want {
+			t.Errorf("List() for %s = %v, %v; want %d tasks", owner.Subject, page, err, want)
+		}
+	}
+}
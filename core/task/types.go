@@ -0,0 +1,132 @@
+// Copyright (C) 2025 sage-x-project
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with this program. If not, see <https://www.gnu.org/licenses/>.
+
+// SPDX-License-Identifier: LGPL-3.0-or-later
+
+package task
+
+import (
+	"context"
+
+	"github.com/sage-x-project/sage-adk/pkg/types"
+)
+
+// Store is the interface for task storage. Implementations must be safe
+// for concurrent use and return copies, so callers may modify the tasks
+// they get.
+type Store interface {
+	// Create records a new task.
+	Create(ctx context.Context, task *types.Task) error
+
+	// Get retrieves a task by ID.
+	Get(ctx context.Context, taskID string) (*types.Task, error)
+
+	// List lists tasks, newest first.
+	List(ctx context.Context, query *Query) (*Page, error)
+
+	// UpdateStatus moves a task to a new state. A non-nil message becomes
+	// the status message and is added to the history.
+	UpdateStatus(ctx context.Context, taskID string, state types.TaskState, message *types.Message) (*types.Task, error)
+
+	// AddArtifact adds an artifact to a task.
+	AddArtifact(ctx context.Context, taskID string, artifact types.Artifact) (*types.Task, error)
+
+	// Cancel moves a task that has not finished to the canceled state.
+	Cancel(ctx context.Context, taskID string) (*types.Task, error)
+
+	// Subscribe returns the updates of a task, starting with its current
+	// status. The channel is closed after the final status update, or
+	// when ctx is done.
+	Subscribe(ctx context.Context, taskID string) (<-chan Event, error)
+}
+
+// Query represents filtering and paging options for listing tasks.
+type Query struct {
+	// ContextID filters by context ID.
+	ContextID string
+
+	// State filters by task state.
+	State types.TaskState
+
+	// Owner, if set, filters by the caller that created the task.
+	Owner *Owner
+
+	// PageSize limits the number of tasks per page (default
+	// DefaultPageSize, at most MaxPageSize).
+	PageSize int
+
+	// PageToken is the NextPageToken of the previous page.
+	PageToken string
+}
+
+// Page sizes for List.
+const (
+	DefaultPageSize = 50
+	MaxPageSize     = 1000
+)
+
+// pageSize returns the effective page size of a query.
+func (q *Query) pageSize() int {
+	switch {
+	case q == nil || q.PageSize <= 0:
+		return DefaultPageSize
+	case q.PageSize > MaxPageSize:
+		return MaxPageSize
+	}
+	return q.PageSize
+}
+
+// Page is one page of List results.
+type Page struct {
+	// Tasks are the tasks of the page.
+	Tasks []*types.Task
+
+	// NextPageToken fetches the next page; it is empty on the last page.
+	NextPageToken string
+}
+
+// Event is a task update. Exactly one of Status and Artifact is set.
+type Event struct {
+	// Status is set when the task's state changed.
+	Status *types.TaskStatusUpdateEvent
+
+	// Artifact is set when an artifact was added.
+	Artifact *types.TaskArtifactUpdateEvent
+}
+
+// Final reports whether the event is the last one of its task.
+func (e Event) Final() bool {
+	return e.Status != nil && e.Status.Final
+}
+
+// Config represents task store configuration.
+type Config struct {
+	// MaxTasks is the number of tasks kept. Beyond it, the oldest finished
+	// tasks are dropped; tasks still running are always kept.
+	MaxTasks int
+
+	// SubscriberBuffer is the number of events buffered per subscriber.
+	// A subscriber that falls further behind is dropped: its channel is
+	// closed and it must Get the task to catch up.
+	SubscriberBuffer int
+}
+
+// DefaultConfig returns the default task store configuration.
+func DefaultConfig() *Config {
+	return &Config{
+		MaxTasks:         10000,
+		SubscriberBuffer: 64,
+	}
+}
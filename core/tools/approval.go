@@ -0,0 +1,476 @@
+// Copyright (C) 2025 sage-x-project
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with this program. If not, see <https://www.gnu.org/licenses/>.
+
+// SPDX-License-Identifier: LGPL-3.0-or-later
+
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/sage-x-project/sage-adk/adapters/llm"
+	"github.com/sage-x-project/sage-adk/core/authn"
+	"github.com/sage-x-project/sage-adk/core/task"
+	"github.com/sage-x-project/sage-adk/pkg/errors"
+	"github.com/sage-x-project/sage-adk/pkg/types"
+	"github.com/sage-x-project/sage-adk/storage"
+)
+
+// ApprovalRequirer is implemented by tools whose calls need human approval.
+type ApprovalRequirer interface {
+	// RequiresApproval returns true if calls must be approved before they run.
+	RequiresApproval() bool
+}
+
+// RequireApproval marks tool as requiring approval before each call.
+func RequireApproval(tool Tool) Tool {
+	return &approvalTool{Tool: tool}
+}
+
+// NeedsApproval returns true if tool is marked as requiring approval.
+func NeedsApproval(tool Tool) bool {
+	r, ok := tool.(ApprovalRequirer)
+	return ok && r.RequiresApproval()
+}
+
+type approvalTool struct {
+	Tool
+}
+
+func (t *approvalTool) RequiresApproval() bool {
+	return true
+}
+
+// ApprovalStatus is the state of an approval request.
+type ApprovalStatus string
+
+const (
+	// ApprovalPending is waiting for a decision.
+	ApprovalPending ApprovalStatus = "pending"
+
+	// ApprovalApproved allows the call to run.
+	ApprovalApproved ApprovalStatus = "approved"
+
+	// ApprovalRejected prevents the call from running.
+	ApprovalRejected ApprovalStatus = "rejected"
+)
+
+// Approval is a request for a human to approve a tool call.
+type Approval struct {
+	// ID identifies the approval.
+	ID string `json:"id"`
+
+	// TaskID is the task the call belongs to.
+	TaskID string `json:"task_id,omitempty"`
+
+	// CallID is the ID of the tool call.
+	CallID string `json:"call_id"`
+
+	// ToolName is the name of the called tool.
+	ToolName string `json:"tool_name"`
+
+	// Arguments are the call arguments shown to the approver.
+	// An approved call runs with exactly these arguments.
+	Arguments map[string]interface{} `json:"arguments"`
+
+	// Status is the approval status.
+	Status ApprovalStatus `json:"status"`
+
+	// Reason optionally explains the decision.
+	Reason string `json:"reason,omitempty"`
+
+	// AgentID and TenantID identify the caller.
+	AgentID  string `json:"agent_id,omitempty"`
+	TenantID string `json:"tenant_id,omitempty"`
+
+	// Requester is the subject of the authenticated principal whose call
+	// needs approval. Only the same subject of the same tenant may decide
+	// it.
+	Requester string `json:"requester,omitempty"`
+
+	// CreatedAt is when approval was requested.
+	CreatedAt time.Time `json:"created_at"`
+
+	// DecidedAt is when the approval was decided.
+	DecidedAt *time.Time `json:"decided_at,omitempty"`
+}
+
+// DecidableBy reports whether the caller recorded in ctx requested the
+// approval: the authenticated subject and the tenant must both match.
+func (a *Approval) DecidableBy(ctx context.Context) bool {
+	return a.Requester == requester(ctx) && a.TenantID == GetTenantID(ctx)
+}
+
+// requester returns the subject of the authenticated principal in ctx.
+func requester(ctx context.Context) string {
+	if principal, ok := authn.PrincipalFromContext(ctx); ok {
+		return principal.Subject
+	}
+	return ""
+}
+
+// ToolCall rebuilds the tool call awaiting approval, so it can be executed
+// again after a restart.
+func (a *Approval) ToolCall() *llm.ToolCall {
+	args, _ := json.Marshal(a.Arguments)
+	return &llm.ToolCall{
+		ID:   a.CallID,
+		Type: llm.ToolTypeFunction,
+		Function: &llm.FunctionCall{
+			Name:      a.ToolName,
+			Arguments: string(args),
+		},
+	}
+}
+
+// DefaultApprovalNamespace is the storage namespace of approvals.
+const DefaultApprovalNamespace = "approvals"
+
+// ApprovalStore persists approval requests in a storage.Storage, so pending
+// approvals survive restarts. It is safe for concurrent use.
+type ApprovalStore struct {
+	storage   storage.Storage
+	namespace string
+	mu        sync.Mutex
+}
+
+// NewApprovalStore creates an approval store in DefaultApprovalNamespace.
+func NewApprovalStore(store storage.Storage) *ApprovalStore {
+	return &ApprovalStore{
+		storage:   store,
+		namespace: DefaultApprovalNamespace,
+	}
+}
+
+// approvalID derives the approval ID of a call within a task.
+func approvalID(taskID, callID string) string {
+	if taskID == "" {
+		return callID
+	}
+	return taskID + ":" + callID
+}
+
+// Request records a pending approval for call. If an approval for the call
+// already exists it is returned unchanged; an approval requested by
+// another caller fails with ErrApprovalRequired rather than letting this
+// caller run the call with it.
+func (s *ApprovalStore) Request(ctx context.Context, call *llm.ToolCall, args map[string]interface{}) (*Approval, error) {
+	if call == nil || call.Function == nil {
+		return nil, fmt.Errorf("%w: missing function call", ErrInvalidParameters)
+	}
+
+	callID := call.ID
+	if callID == "" {
+		callID = uuid.NewString()
+	}
+
+	taskID := GetTaskID(ctx)
+	id := approvalID(taskID, callID)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if existing, err := s.get(ctx, id); err == nil {
+		if !existing.DecidableBy(ctx) {
+			return nil, fmt.Errorf("%w: approval %s belongs to another caller", ErrApprovalRequired, id)
+		}
+		return existing, nil
+	} else if err != ErrApprovalNotFound {
+		return nil, err
+	}
+
+	approval := &Approval{
+		ID:        id,
+		TaskID:    taskID,
+		CallID:    callID,
+		ToolName:  call.Function.Name,
+		Arguments: args,
+		Status:    ApprovalPending,
+		AgentID:   GetAgentID(ctx),
+		TenantID:  GetTenantID(ctx),
+		Requester: requester(ctx),
+		CreatedAt: time.Now(),
+	}
+	if err := s.storage.Store(ctx, s.namespace, id, *approval); err != nil {
+		return nil, err
+	}
+	return approval, nil
+}
+
+// Get returns the approval with id.
+func (s *ApprovalStore) Get(ctx context.Context, id string) (*Approval, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.get(ctx, id)
+}
+
+// Lookup returns the approval of call within the task recorded in ctx.
+// Approvals requested by another caller are reported as missing.
+func (s *ApprovalStore) Lookup(ctx context.Context, call *llm.ToolCall) (*Approval, error) {
+	if call == nil || call.ID == "" {
+		return nil, ErrApprovalNotFound
+	}
+	approval, err := s.Get(ctx, approvalID(GetTaskID(ctx), call.ID))
+	if err == nil && !approval.DecidableBy(ctx) {
+		return nil, ErrApprovalNotFound
+	}
+	return approval, err
+}
+
+// Approve approves a pending approval.
+func (s *ApprovalStore) Approve(ctx context.Context, id, reason string) (*Approval, error) {
+	return s.decide(ctx, id, ApprovalApproved, reason)
+}
+
+// Reject rejects a pending approval.
+func (s *ApprovalStore) Reject(ctx context.Context, id, reason string) (*Approval, error) {
+	return s.decide(ctx, id, ApprovalRejected, reason)
+}
+
+// Pending returns the pending approvals of taskID, oldest first.
+// An empty taskID returns the pending approvals of all tasks.
+func (s *ApprovalStore) Pending(ctx context.Context, taskID string) ([]*Approval, error) {
+	items, err := s.storage.List(ctx, s.namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	var pending []*Approval
+	for _, item := range items {
+		approval, err := decodeApproval(item)
+		if err != nil {
+			return nil, err
+		}
+		if approval.Status == ApprovalPending && (taskID == "" || approval.TaskID == taskID) {
+			pending = append(pending, approval)
+		}
+	}
+
+	sort.Slice(pending, func(i, j int) bool {
+		return pending[i].CreatedAt.Before(pending[j].CreatedAt)
+	})
+	return pending, nil
+}
+
+// Delete removes an approval.
+func (s *ApprovalStore) Delete(ctx context.Context, id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.storage.Delete(ctx, s.namespace, id); err != nil {
+		if isStorageNotFound(err) {
+			return ErrApprovalNotFound
+		}
+		return err
+	}
+	return nil
+}
+
+// HandleReply decides one pending approval of taskID from a user reply.
+//
+// Replies starting with "approve", "yes", "ok" or "confirm" approve; replies
+// starting with "reject", "no", "deny" or "cancel" reject. The rest of the
+// reply becomes the reason. Other replies return ErrApprovalReplyUnclear.
+//
+// Only approvals requested by the caller recorded in ctx are considered
+// (see Approval.DecidableBy). When the
+// task has several pending approvals, the reply must name one by ID after
+// the decision ("approve task-1:call-2"); otherwise it returns
+// ErrApprovalAmbiguous.
+func (s *ApprovalStore) HandleReply(ctx context.Context, taskID, reply string) (*Approval, error) {
+	if taskID == "" {
+		return nil, fmt.Errorf("%w: task id is required", ErrInvalidParameters)
+	}
+
+	status, reason, ok := ParseApprovalReply(reply)
+	if !ok {
+		return nil, ErrApprovalReplyUnclear
+	}
+
+	pending, err := s.Pending(ctx, taskID)
+	if err != nil {
+		return nil, err
+	}
+
+	var candidates []*Approval
+	for _, approval := range pending {
+		if approval.DecidableBy(ctx) {
+			candidates = append(candidates, approval)
+		}
+	}
+
+	var target *Approval
+	if fields := strings.Fields(reason); len(fields) > 0 {
+		for _, approval := range candidates {
+			if fields[0] == approval.ID || fields[0] == approval.CallID {
+				target = approval
+				reason = strings.TrimSpace(strings.TrimPrefix(reason, fields[0]))
+				break
+			}
+		}
+	}
+	if target == nil {
+		switch len(candidates) {
+		case 0:
+			return nil, ErrApprovalNotFound
+		case 1:
+			target = candidates[0]
+		default:
+			return nil, ErrApprovalAmbiguous
+		}
+	}
+
+	return s.decide(ctx, target.ID, status, reason)
+}
+
+// ParseApprovalReply interprets a user reply to an approval request.
+func ParseApprovalReply(reply string) (status ApprovalStatus, reason string, ok bool) {
+	fields := strings.Fields(reply)
+	if len(fields) == 0 {
+		return "", "", false
+	}
+
+	word := strings.ToLower(strings.Trim(fields[0], ".,!:;"))
+	reason = strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(reply), fields[0]))
+
+	switch word {
+	case "approve", "approved", "yes", "y", "ok", "okay", "confirm", "confirmed":
+		return ApprovalApproved, reason, true
+	case "reject", "rejected", "no", "n", "deny", "denied", "cancel":
+		return ApprovalRejected, reason, true
+	}
+	return "", "", false
+}
+
+func (s *ApprovalStore) decide(ctx context.Context, id string, status ApprovalStatus, reason string) (*Approval, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	approval, err := s.get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if approval.Status != ApprovalPending {
+		return nil, ErrApprovalDecided
+	}
+
+	now := time.Now()
+	approval.Status = status
+	approval.Reason = reason
+	approval.DecidedAt = &now
+
+	if err := s.storage.Store(ctx, s.namespace, id, *approval); err != nil {
+		return nil, err
+	}
+	return approval, nil
+}
+
+func (s *ApprovalStore) get(ctx context.Context, id string) (*Approval, error) {
+	item, err := s.storage.Get(ctx, s.namespace, id)
+	if err != nil {
+		if isStorageNotFound(err) {
+			return nil, ErrApprovalNotFound
+		}
+		return nil, err
+	}
+	return decodeApproval(item)
+}
+
+// isStorageNotFound reports whether err means a missing storage key.
+func isStorageNotFound(err error) bool {
+	return err == storage.ErrNotFound || errors.Is(err, errors.ErrNotFound)
+}
+
+// decodeApproval converts a stored item back into an Approval. Backends
+// that serialize values return generic JSON data rather than the struct.
+func decodeApproval(item interface{}) (*Approval, error) {
+	if a, ok := item.(Approval); ok {
+		return &a, nil
+	}
+
+	data, err := json.Marshal(item)
+	if err != nil {
+		return nil, err
+	}
+	var approval Approval
+	if err := json.Unmarshal(data, &approval); err != nil {
+		return nil, err
+	}
+	return &approval, nil
+}
+
+// PendingApprovals returns the approvals that the results are waiting for.
+func PendingApprovals(results []*CallResult) []*Approval {
+	var pending []*Approval
+	for _, r := range results {
+		if r.Status == StatusPendingApproval && r.Approval != nil {
+			pending = append(pending, r.Approval)
+		}
+	}
+	return pending
+}
+
+// ApprovalRequestMessage builds the agent message asking the user to
+// approve the given calls. The text shows each tool and its arguments; a
+// data part carries the approvals for clients that render them. The
+// message is marked with task.InputRequired, so the task waits for the
+// user's reply.
+func ApprovalRequestMessage(approvals []*Approval) *types.Message {
+	var b strings.Builder
+	b.WriteString("The following actions need your approval:\n")
+	for i, a := range approvals {
+		args, _ := json.MarshalIndent(a.Arguments, "  ", "  ")
+		fmt.Fprintf(&b, "\n%d. %s (%s)\n  %s\n", i+1, a.ToolName, a.ID, args)
+	}
+	if len(approvals) == 1 {
+		b.WriteString("\nReply \"approve\" or \"reject\", optionally followed by a reason.")
+	} else {
+		b.WriteString("\nReply \"approve <id>\" or \"reject <id>\" for each action, optionally followed by a reason.")
+	}
+
+	ids := make([]string, len(approvals))
+	for i, a := range approvals {
+		ids[i] = a.ID
+	}
+
+	msg := types.NewMessage(types.MessageRoleAgent, []types.Part{
+		types.NewTextPart(b.String()),
+		types.NewDataPart(map[string]interface{}{"approvals": approvals}),
+	})
+	msg.Metadata["approval_ids"] = ids
+	return task.InputRequired(msg)
+}
+
+// PauseForApproval moves t to the input-required state with an approval
+// request for the given calls and returns the request message.
+func PauseForApproval(t *types.Task, approvals []*Approval) *types.Message {
+	msg := ApprovalRequestMessage(approvals)
+	if t != nil {
+		msg.TaskID = &t.ID
+		if t.ContextID != "" {
+			msg.ContextID = &t.ContextID
+		}
+		t.UpdateState(types.TaskStateInputRequired, msg)
+	}
+	return msg
+}
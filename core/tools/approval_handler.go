@@ -0,0 +1,126 @@
+// Copyright (C) 2025 sage-x-project
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with this program. If not, see <https://www.gnu.org/licenses/>.
+
+// SPDX-License-Identifier: LGPL-3.0-or-later
+
+package tools
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/sage-x-project/sage-adk/core/authn"
+)
+
+// ApprovalScope is the scope a caller needs to use ApprovalHandler.
+const ApprovalScope = "tools:approve"
+
+// ApprovalDecision is the request body of ApprovalHandler.
+type ApprovalDecision struct {
+	// ID is the approval to decide.
+	ID string `json:"id"`
+
+	// Approved approves the call when true and rejects it otherwise.
+	Approved bool `json:"approved"`
+
+	// Reason optionally explains the decision.
+	Reason string `json:"reason,omitempty"`
+}
+
+// ApprovalHandler creates an HTTP handler for deciding approvals.
+//
+// GET lists pending approvals, optionally filtered by the task_id query
+// parameter. POST decides an approval from an ApprovalDecision body.
+//
+// Mount it behind authn.Authenticator.Middleware: callers must be
+// authenticated with ApprovalScope, and only see and decide the approvals
+// they requested themselves (see Approval.DecidableBy).
+func ApprovalHandler(store *ApprovalStore) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		principal, ok := authn.PrincipalFromContext(ctx)
+		if !ok {
+			writeApprovalError(w, http.StatusUnauthorized, errors.New("authentication required"))
+			return
+		}
+		if !principal.HasScope(ApprovalScope) {
+			writeApprovalError(w, http.StatusForbidden, errors.New("missing scope "+ApprovalScope))
+			return
+		}
+
+		switch r.Method {
+		case http.MethodGet:
+			pending, err := store.Pending(ctx, r.URL.Query().Get("task_id"))
+			if err != nil {
+				writeApprovalError(w, http.StatusInternalServerError, err)
+				return
+			}
+			visible := []*Approval{}
+			for _, approval := range pending {
+				if approval.DecidableBy(ctx) {
+					visible = append(visible, approval)
+				}
+			}
+			writeApprovalJSON(w, http.StatusOK, visible)
+
+		case http.MethodPost:
+			var decision ApprovalDecision
+			if err := json.NewDecoder(r.Body).Decode(&decision); err != nil || decision.ID == "" {
+				writeApprovalError(w, http.StatusBadRequest, errors.New("body must contain an approval id"))
+				return
+			}
+
+			// Approvals of other callers are reported as missing
+			approval, err := store.Get(ctx, decision.ID)
+			if err == nil && !approval.DecidableBy(ctx) {
+				err = ErrApprovalNotFound
+			}
+			if err == nil {
+				decide := store.Reject
+				if decision.Approved {
+					decide = store.Approve
+				}
+				approval, err = decide(ctx, decision.ID, decision.Reason)
+			}
+
+			switch {
+			case errors.Is(err, ErrApprovalNotFound):
+				writeApprovalError(w, http.StatusNotFound, err)
+			case errors.Is(err, ErrApprovalDecided):
+				writeApprovalError(w, http.StatusConflict, err)
+			case err != nil:
+				writeApprovalError(w, http.StatusInternalServerError, err)
+			default:
+				writeApprovalJSON(w, http.StatusOK, approval)
+			}
+
+		default:
+			w.Header().Set("Allow", "GET, POST")
+			writeApprovalError(w, http.StatusMethodNotAllowed, errors.New("method not allowed"))
+		}
+	}
+}
+
+func writeApprovalJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+func writeApprovalError(w http.ResponseWriter, status int, err error) {
+	writeApprovalJSON(w, status, map[string]string{"error": err.Error()})
+}
@@ -0,0 +1,342 @@
+// Copyright (C) 2025 sage-x-project
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with this program. If not, see <https://www.gnu.org/licenses/>.
+
+// SPDX-License-Identifier: LGPL-3.0-or-later
+
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/sage-x-project/sage-adk/core/authn"
+	"github.com/sage-x-project/sage-adk/core/task"
+	"github.com/sage-x-project/sage-adk/pkg/types"
+	"github.com/sage-x-project/sage-adk/storage"
+)
+
+func transferTool(calls *int32, got *map[string]interface{}) Tool {
+	return RequireApproval(NewFunctionTool("transfer", "moves money", &ParameterSchema{Type: "object"},
+		func(ctx context.Context, params map[string]interface{}) (*Result, error) {
+			atomic.AddInt32(calls, 1)
+			*got = params
+			return SuccessResult("done"), nil
+		}))
+}
+
+func TestNeedsApproval(t *testing.T) {
+	plain := NewFunctionTool("plain", "", &ParameterSchema{Type: "object"}, nil)
+	if NeedsApproval(plain) {
+		t.Error("plain tool should not need approval")
+	}
+	if !NeedsApproval(RequireApproval(plain)) {
+		t.Error("wrapped tool should need approval")
+	}
+}
+
+func TestExecutor_Approval_ApproveAfterRestart(t *testing.T) {
+	backend := storage.NewMemoryStorage()
+	ctx := WithTaskID(context.Background(), "task-1")
+
+	var calls int32
+	var got map[string]interface{}
+	registry := NewRegistry()
+	registry.Register(transferTool(&calls, &got))
+
+	executor := NewExecutor(registry, &ExecutorConfig{Approvals: NewApprovalStore(backend)})
+	res := executor.Execute(ctx, newCall("c1", "transfer", `{"amount":100}`))
+	if res.Status != StatusPendingApproval {
+		t.Fatalf("Status = %s, want %s", res.Status, StatusPendingApproval)
+	}
+	if res.Approval == nil || res.Approval.ID != "task-1:c1" {
+		t.Fatalf("Approval = %+v", res.Approval)
+	}
+	if atomic.LoadInt32(&calls) != 0 {
+		t.Fatal("tool must not run before approval")
+	}
+
+	paused := types.NewTask("task-1", "ctx-1")
+	msg := PauseForApproval(paused, PendingApprovals([]*CallResult{res}))
+	if paused.Status.State != types.TaskStateInputRequired || !task.IsInputRequired(msg) {
+		t.Errorf("task state = %s, want input-required", paused.Status.State)
+	}
+	text := msg.Parts[0].(*types.TextPart).Text
+	if !strings.Contains(text, "transfer") || !strings.Contains(text, `"amount": 100`) {
+		t.Errorf("request message does not show the call: %q", text)
+	}
+
+	// A new store and executor over the same storage simulate a restart.
+	store := NewApprovalStore(backend)
+	decided, err := store.HandleReply(context.Background(), "task-1", "yes, go ahead")
+	if err != nil {
+		t.Fatalf("HandleReply() error = %v", err)
+	}
+	if decided.Status != ApprovalApproved || decided.Reason != "go ahead" {
+		t.Errorf("decided = %+v", decided)
+	}
+
+	executor = NewExecutor(registry, &ExecutorConfig{Approvals: store})
+	pending, _ := store.Pending(context.Background(), "task-1")
+	if len(pending) != 0 {
+		t.Errorf("pending after reply = %d", len(pending))
+	}
+	res = executor.Execute(ctx, decided.ToolCall())
+	if res.Status != StatusSuccess {
+		t.Fatalf("Status = %s, err = %v", res.Status, res.Err)
+	}
+	if atomic.LoadInt32(&calls) != 1 || got["amount"] != float64(100) {
+		t.Errorf("calls = %d, params = %v", calls, got)
+	}
+	if _, err := store.Get(context.Background(), "task-1:c1"); !errors.Is(err, ErrApprovalNotFound) {
+		t.Errorf("approval should be consumed, got %v", err)
+	}
+}
+
+func TestExecutor_Approval_Rejected(t *testing.T) {
+	store := NewApprovalStore(storage.NewMemoryStorage())
+	ctx := WithTaskID(context.Background(), "task-1")
+
+	var calls int32
+	var got map[string]interface{}
+	registry := NewRegistry()
+	registry.Register(transferTool(&calls, &got))
+	executor := NewExecutor(registry, &ExecutorConfig{Approvals: store})
+
+	call := newCall("c1", "transfer", `{"amount":100}`)
+	executor.Execute(ctx, call)
+	if _, err := store.Reject(ctx, "task-1:c1", "too much"); err != nil {
+		t.Fatalf("Reject() error = %v", err)
+	}
+	if _, err := store.Approve(ctx, "task-1:c1", ""); !errors.Is(err, ErrApprovalDecided) {
+		t.Errorf("Approve() after reject error = %v, want ErrApprovalDecided", err)
+	}
+
+	res := executor.Execute(ctx, call)
+	if res.Status != StatusNotApproved {
+		t.Fatalf("Status = %s, want %s", res.Status, StatusNotApproved)
+	}
+	if res.Result.Success || !strings.Contains(res.Result.Error, "too much") {
+		t.Errorf("Result = %+v", res.Result)
+	}
+	if atomic.LoadInt32(&calls) != 0 {
+		t.Error("rejected tool must not run")
+	}
+}
+
+func TestExecutor_Approval_ToolConfigAndNoStore(t *testing.T) {
+	registry := NewRegistry()
+	registry.Register(NewFunctionTool("delete", "", &ParameterSchema{Type: "object"},
+		func(ctx context.Context, params map[string]interface{}) (*Result, error) {
+			return SuccessResult(nil), nil
+		}))
+
+	executor := NewExecutor(registry, &ExecutorConfig{
+		Tools: map[string]*ToolConfig{"delete": {RequireApproval: true}},
+	})
+	res := executor.Execute(context.Background(), newCall("c1", "delete", ""))
+	if res.Status != StatusDenied || !errors.Is(res.Err, ErrApprovalRequired) {
+		t.Errorf("Status = %s, err = %v", res.Status, res.Err)
+	}
+}
+
+func TestApprovalStore_HandleReply(t *testing.T) {
+	store := NewApprovalStore(storage.NewMemoryStorage())
+	ctx := WithTaskID(context.Background(), "task-1")
+	store.Request(ctx, newCall("c1", "transfer", ""), nil)
+	store.Request(ctx, newCall("c2", "transfer", ""), nil)
+	store.Request(WithTaskID(context.Background(), "task-2"), newCall("c3", "transfer", ""), nil)
+	store.Request(WithTenantID(WithTaskID(context.Background(), "task-3"), "tenant-b"), newCall("c4", "transfer", ""), nil)
+
+	if _, err := store.HandleReply(ctx, "", "approve"); !errors.Is(err, ErrInvalidParameters) {
+		t.Errorf("HandleReply() without task error = %v, want ErrInvalidParameters", err)
+	}
+	if _, err := store.HandleReply(ctx, "task-1", "approve"); !errors.Is(err, ErrApprovalAmbiguous) {
+		t.Errorf("HandleReply() with two pending error = %v, want ErrApprovalAmbiguous", err)
+	}
+
+	decided, err := store.HandleReply(ctx, "task-1", "reject task-1:c2 not now")
+	if err != nil || decided.ID != "task-1:c2" || decided.Status != ApprovalRejected || decided.Reason != "not now" {
+		t.Fatalf("HandleReply() = %+v, %v", decided, err)
+	}
+	if a, _ := store.Get(ctx, "task-1:c1"); a.Status != ApprovalPending {
+		t.Error("reply decided an approval it did not name")
+	}
+	if a, _ := store.Get(ctx, "task-2:c3"); a.Status != ApprovalPending {
+		t.Error("reply decided an approval of another task")
+	}
+
+	// Approvals of another tenant are out of reach
+	if _, err := store.HandleReply(context.Background(), "task-3", "approve"); !errors.Is(err, ErrApprovalNotFound) {
+		t.Errorf("HandleReply() across tenants error = %v, want ErrApprovalNotFound", err)
+	}
+}
+
+func TestApprovalStore_HandleReply_RequiresRequester(t *testing.T) {
+	store := NewApprovalStore(storage.NewMemoryStorage())
+	alice := &authn.Principal{Subject: "alice", Tenant: "tenant-a"}
+	ctx := WithTaskID(authn.WithPrincipal(context.Background(), alice), "task-1")
+	approval, err := store.Request(ctx, newCall("c1", "transfer", ""), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if approval.Requester != "alice" || approval.TenantID != "tenant-a" {
+		t.Fatalf("Request() recorded %q of %q, want the principal", approval.Requester, approval.TenantID)
+	}
+
+	// Knowing the task ID is not enough to decide the approval
+	for _, other := range []*authn.Principal{
+		{Subject: "mallory", Tenant: "tenant-a"},
+		{Subject: "alice", Tenant: "tenant-b"},
+	} {
+		otherCtx := authn.WithPrincipal(context.Background(), other)
+		if _, err := store.HandleReply(otherCtx, "task-1", "approve"); !errors.Is(err, ErrApprovalNotFound) {
+			t.Errorf("HandleReply() by %s of %s error = %v, want ErrApprovalNotFound", other.Subject, other.Tenant, err)
+		}
+	}
+	if _, err := store.HandleReply(context.Background(), "task-1", "approve"); !errors.Is(err, ErrApprovalNotFound) {
+		t.Errorf("unauthenticated HandleReply() error = %v, want ErrApprovalNotFound", err)
+	}
+
+	decided, err := store.HandleReply(ctx, "task-1", "approve")
+	if err != nil || decided.Status != ApprovalApproved {
+		t.Errorf("HandleReply() by the requester = %+v, %v", decided, err)
+	}
+}
+
+func TestRegistry_Execute_RequiresApproval(t *testing.T) {
+	var calls int32
+	var got map[string]interface{}
+	registry := NewRegistry()
+	registry.Register(transferTool(&calls, &got))
+
+	if _, err := registry.Execute(context.Background(), "transfer", nil); !errors.Is(err, ErrApprovalRequired) {
+		t.Errorf("Execute() error = %v, want ErrApprovalRequired", err)
+	}
+	if atomic.LoadInt32(&calls) != 0 {
+		t.Error("tool needing approval ran without it")
+	}
+}
+
+func TestApprovalStore_DecodesSerializedValues(t *testing.T) {
+	backend := storage.NewMemoryStorage()
+	ctx := context.Background()
+
+	// Serializing backends return generic JSON values.
+	var generic map[string]interface{}
+	data, _ := json.Marshal(Approval{ID: "a1", ToolName: "transfer", Status: ApprovalPending})
+	json.Unmarshal(data, &generic)
+	backend.Store(ctx, DefaultApprovalNamespace, "a1", generic)
+
+	approval, err := NewApprovalStore(backend).Get(ctx, "a1")
+	if err != nil || approval.ToolName != "transfer" || approval.Status != ApprovalPending {
+		t.Errorf("Get() = %+v, %v", approval, err)
+	}
+}
+
+func TestParseApprovalReply(t *testing.T) {
+	tests := []struct {
+		reply  string
+		status ApprovalStatus
+		reason string
+		ok     bool
+	}{
+		{"approve", ApprovalApproved, "", true},
+		{"Yes! looks fine", ApprovalApproved, "looks fine", true},
+		{"no, wrong account", ApprovalRejected, "wrong account", true},
+		{"Reject", ApprovalRejected, "", true},
+		{"maybe later", "", "", false},
+		{"  ", "", "", false},
+	}
+	for _, tt := range tests {
+		status, reason, ok := ParseApprovalReply(tt.reply)
+		if status != tt.status || reason != tt.reason || ok != tt.ok {
+			t.Errorf("ParseApprovalReply(%q) = %q, %q, %v", tt.reply, status, reason, ok)
+		}
+	}
+}
+
+func TestApprovalHandler(t *testing.T) {
+	store := NewApprovalStore(storage.NewMemoryStorage())
+	operator := &authn.Principal{Subject: "operator", Tenant: "tenant-a", Scopes: []string{ApprovalScope}}
+	ctx := WithTaskID(authn.WithPrincipal(context.Background(), operator), "task-1")
+	if _, err := store.Request(ctx, newCall("c1", "transfer", ""), map[string]interface{}{"amount": 5}); err != nil {
+		t.Fatal(err)
+	}
+	handler := ApprovalHandler(store)
+	request := func(method, target, body string, principal *authn.Principal) *httptest.ResponseRecorder {
+		r := httptest.NewRequest(method, target, strings.NewReader(body))
+		if principal != nil {
+			r = r.WithContext(authn.WithPrincipal(r.Context(), principal))
+		}
+		rec := httptest.NewRecorder()
+		handler(rec, r)
+		return rec
+	}
+
+	if rec := request(http.MethodGet, "/approvals", "", nil); rec.Code != http.StatusUnauthorized {
+		t.Errorf("unauthenticated GET = %d, want 401", rec.Code)
+	}
+	if rec := request(http.MethodGet, "/approvals", "", &authn.Principal{Subject: "viewer"}); rec.Code != http.StatusForbidden {
+		t.Errorf("GET without scope = %d, want 403", rec.Code)
+	}
+
+	// Another tenant neither sees nor decides the approval
+	other := &authn.Principal{Subject: "other", Tenant: "tenant-b", Scopes: []string{ApprovalScope}}
+	rec := request(http.MethodGet, "/approvals", "", other)
+	if rec.Body.String() != "[]\n" {
+		t.Errorf("other tenant GET = %s, want []", rec.Body.String())
+	}
+	if rec := request(http.MethodPost, "/approvals", `{"id":"task-1:c1","approved":true}`, other); rec.Code != http.StatusNotFound {
+		t.Errorf("other tenant POST = %d, want 404", rec.Code)
+	}
+
+	// So does another subject of the same tenant
+	peer := &authn.Principal{Subject: "peer", Tenant: "tenant-a", Scopes: []string{ApprovalScope}}
+	if rec := request(http.MethodGet, "/approvals", "", peer); rec.Body.String() != "[]\n" {
+		t.Errorf("other subject GET = %s, want []", rec.Body.String())
+	}
+	if rec := request(http.MethodPost, "/approvals", `{"id":"task-1:c1","approved":true}`, peer); rec.Code != http.StatusNotFound {
+		t.Errorf("other subject POST = %d, want 404", rec.Code)
+	}
+
+	rec = request(http.MethodGet, "/approvals?task_id=task-1", "", operator)
+	var pending []*Approval
+	json.NewDecoder(rec.Body).Decode(&pending)
+	if rec.Code != http.StatusOK || len(pending) != 1 {
+		t.Fatalf("GET = %d, %d approvals", rec.Code, len(pending))
+	}
+
+	post := func(body string) int {
+		return request(http.MethodPost, "/approvals", body, operator).Code
+	}
+	if code := post(`{"id":"task-1:c1","approved":true}`); code != http.StatusOK {
+		t.Errorf("approve = %d", code)
+	}
+	if code := post(`{"id":"task-1:c1","approved":false}`); code != http.StatusConflict {
+		t.Errorf("second decision = %d, want 409", code)
+	}
+	if code := post(`{"id":"missing"}`); code != http.StatusNotFound {
+		t.Errorf("missing = %d, want 404", code)
+	}
+	if code := post(`{`); code != http.StatusBadRequest {
+		t.Errorf("bad body = %d, want 400", code)
+	}
+}
@@ -34,7 +34,7 @@ func CalculatorTool() Tool {
 				"operation": {
 					Type:        "string",
 					Description: "The arithmetic operation to perform",
-					Enum:        []string{"add", "subtract", "multiply", "divide"},
+					Enum:        []interface{}{"add", "subtract", "multiply", "divide"},
 				},
 				"a": {
 					Type:        "number",
@@ -96,7 +96,7 @@ func CurrentTimeTool() Tool {
 				"format": {
 					Type:        "string",
 					Description: "The time format (RFC3339, Unix, or Human)",
-					Enum:        []string{"RFC3339", "Unix", "Human"},
+					Enum:        []interface{}{"RFC3339", "Unix", "Human"},
 					Default:     "RFC3339",
 				},
 				"timezone": {
@@ -0,0 +1,103 @@
+// Copyright (C) 2025 sage-x-project
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with this program. If not, see <https://www.gnu.org/licenses/>.
+
+// SPDX-License-Identifier: LGPL-3.0-or-later
+
+package tools
+
+import (
+	"context"
+
+	"github.com/sage-x-project/sage-adk/core/authn"
+)
+
+type contextKey string
+
+const (
+	agentIDKey  contextKey = "tools_agent_id"
+	tenantIDKey contextKey = "tools_tenant_id"
+	taskIDKey   contextKey = "tools_task_id"
+	executorKey contextKey = "tools_executor"
+	approvalKey contextKey = "tools_approval"
+)
+
+// WithAgentID records the calling agent for access policy checks.
+func WithAgentID(ctx context.Context, agentID string) context.Context {
+	return context.WithValue(ctx, agentIDKey, agentID)
+}
+
+// GetAgentID retrieves the calling agent from the context.
+func GetAgentID(ctx context.Context) string {
+	if v, ok := ctx.Value(agentIDKey).(string); ok {
+		return v
+	}
+	return ""
+}
+
+// WithTenantID records the calling tenant for access policy checks.
+func WithTenantID(ctx context.Context, tenantID string) context.Context {
+	return context.WithValue(ctx, tenantIDKey, tenantID)
+}
+
+// GetTenantID retrieves the calling tenant from the context: the tenant
+// recorded with WithTenantID or, failing that, the tenant of the principal
+// recorded by the authn middleware.
+func GetTenantID(ctx context.Context) string {
+	if v, ok := ctx.Value(tenantIDKey).(string); ok {
+		return v
+	}
+	if principal, ok := authn.PrincipalFromContext(ctx); ok {
+		return principal.Tenant
+	}
+	return ""
+}
+
+// WithTaskID records the task that tool calls belong to, which scopes
+// approval requests.
+func WithTaskID(ctx context.Context, taskID string) context.Context {
+	return context.WithValue(ctx, taskIDKey, taskID)
+}
+
+// GetTaskID retrieves the task from the context.
+func GetTaskID(ctx context.Context) string {
+	if v, ok := ctx.Value(taskIDKey).(string); ok {
+		return v
+	}
+	return ""
+}
+
+// WithExecutor records the executor that message handlers should run tool
+// calls with.
+func WithExecutor(ctx context.Context, executor *Executor) context.Context {
+	return context.WithValue(ctx, executorKey, executor)
+}
+
+// ExecutorFromContext retrieves the executor recorded with WithExecutor.
+func ExecutorFromContext(ctx context.Context) (*Executor, bool) {
+	executor, ok := ctx.Value(executorKey).(*Executor)
+	return executor, ok && executor != nil
+}
+
+// WithApproval records the approval decided by the message being handled,
+// so the handler can re-execute its call.
+func WithApproval(ctx context.Context, approval *Approval) context.Context {
+	return context.WithValue(ctx, approvalKey, approval)
+}
+
+// ApprovalFromContext retrieves the approval recorded with WithApproval.
+func ApprovalFromContext(ctx context.Context) (*Approval, bool) {
+	approval, ok := ctx.Value(approvalKey).(*Approval)
+	return approval, ok && approval != nil
+}
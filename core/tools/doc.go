@@ -36,7 +36,7 @@
 //	            "operation": {
 //	                Type:        "string",
 //	                Description: "The operation to perform",
-//	                Enum:        []string{"add", "subtract", "multiply", "divide"},
+//	                Enum:        []interface{}{"add", "subtract", "multiply", "divide"},
 //	            },
 //	            "a": {Type: "number", Description: "First number"},
 //	            "b": {Type: "number", Description: "Second number"},
@@ -77,4 +77,80 @@
 //	    "a":         5.0,
 //	    "b":         3.0,
 //	})
+//
+// # Typed Tools
+//
+// NewTypedTool derives the parameter schema from a Go struct, validates the
+// model's arguments against it and decodes them before calling the function.
+// Invalid arguments come back as a failed Result the model can act on:
+//
+//	type WeatherInput struct {
+//	    City string `json:"city" description:"City name" required:"true"`
+//	    Unit string `json:"unit,omitempty" enum:"celsius,fahrenheit"`
+//	    Days int    `json:"days,omitempty" min:"1" max:"7"`
+//	}
+//
+//	weather := tools.MustTypedTool("weather", "Get the weather forecast",
+//	    func(ctx context.Context, in WeatherInput) (*Forecast, error) {
+//	        return forecast(ctx, in.City, in.Unit, in.Days)
+//	    })
+//
+// ParameterSchema is the same type as llm.FunctionParameters, so
+// Registry.ToLLMTools passes tool schemas to providers unchanged.
+//
+// # Executor
+//
+// An Executor runs the tool calls of one model turn in parallel under a
+// bulkhead, with per-tool timeouts and retries and an allow/deny Policy
+// checked against the agent and tenant recorded in the context:
+//
+//	policy := tools.NewPolicy(tools.Permissions{Deny: []string{"shell"}})
+//	policy.SetTenant("tenant-a", tools.Permissions{Allow: []string{"calculator"}})
+//
+//	executor := tools.NewExecutor(registry, &tools.ExecutorConfig{
+//	    MaxConcurrent: 4,
+//	    Timeout:       10 * time.Second,
+//	    Tools:         map[string]*tools.ToolConfig{"search": {Timeout: time.Minute}},
+//	    Policy:        policy,
+//	    Metrics:       metrics.NewToolMetrics(collector),
+//	})
+//
+//	ctx = tools.WithTenantID(tools.WithAgentID(ctx, "agent-1"), "tenant-a")
+//	for _, res := range executor.ExecuteAll(ctx, resp.ToolCalls) {
+//	    messages = append(messages, res.ToLLMResult())
+//	}
+//
+// # Approvals
+//
+// Tools wrapped with RequireApproval, or configured with
+// ToolConfig.RequireApproval, do not run until a human approves the call.
+// With an ApprovalStore the executor persists a pending Approval and reports
+// StatusPendingApproval; the task is then paused for user input:
+//
+//	executor := tools.NewExecutor(registry, &tools.ExecutorConfig{
+//	    Approvals: tools.NewApprovalStore(store),
+//	})
+//
+//	ctx = tools.WithTaskID(ctx, task.ID)
+//	results := executor.ExecuteAll(ctx, resp.ToolCalls)
+//	if pending := tools.PendingApprovals(results); len(pending) > 0 {
+//	    return tools.PauseForApproval(task, pending)
+//	}
+//
+// An approval records the authenticated principal (authn.PrincipalFromContext)
+// and tenant that requested it, and only the same principal decides it:
+// with its next message in the task, or through ApprovalHandler with
+// ApprovalScope. Without WithTenantID, the tenant is the principal's.
+// Re-executing
+// the call then runs it with the approved arguments or reports the
+// rejection to the model:
+//
+//	approval, err := approvalStore.HandleReply(ctx, task.ID, userText)
+//	messages = append(messages, executor.Execute(ctx, approval.ToolCall()).ToLLMResult())
+//
+// Agents built with tools do this themselves: their handlers get an
+// approval-aware executor from ExecutorFromContext, the agent pauses the
+// task while approvals are pending, and a reply deciding one is handed to
+// the handler through ApprovalFromContext. Registry.Execute refuses tools
+// that need approval.
 package tools
@@ -37,4 +37,27 @@ var (
 
 	// ErrExecutionFailed is returned when tool execution fails.
 	ErrExecutionFailed = errors.New("tool execution failed")
+
+	// ErrToolAccessDenied is returned when the access policy denies a tool call.
+	ErrToolAccessDenied = errors.New("tool access denied")
+
+	// ErrToolTimeout is returned when a tool call exceeds its timeout.
+	ErrToolTimeout = errors.New("tool execution timed out")
+
+	// ErrApprovalRequired is returned when a tool needs approval but no
+	// approval store is configured.
+	ErrApprovalRequired = errors.New("tool call requires approval")
+
+	// ErrApprovalNotFound is returned when an approval does not exist.
+	ErrApprovalNotFound = errors.New("approval not found")
+
+	// ErrApprovalDecided is returned when deciding an approval twice.
+	ErrApprovalDecided = errors.New("approval already decided")
+
+	// ErrApprovalReplyUnclear is returned when a reply neither approves nor rejects.
+	ErrApprovalReplyUnclear = errors.New("reply does not approve or reject")
+
+	// ErrApprovalAmbiguous is returned when a reply does not say which of
+	// several pending approvals it decides.
+	ErrApprovalAmbiguous = errors.New("reply does not name the approval to decide")
 )
@@ -0,0 +1,450 @@
+// Copyright (C) 2025 sage-x-project
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with this program. If not, see <https://www.gnu.org/licenses/>.
+
+// SPDX-License-Identifier: LGPL-3.0-or-later
+
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/sage-x-project/sage-adk/adapters/llm"
+	"github.com/sage-x-project/sage-adk/core/resilience"
+	"github.com/sage-x-project/sage-adk/observability/metrics"
+)
+
+// Execution status values reported to metrics and spans.
+const (
+	StatusSuccess  = "success"
+	StatusFailed   = "failed"
+	StatusError    = "error"
+	StatusTimeout  = "timeout"
+	StatusDenied   = "denied"
+	StatusRejected = "rejected"
+
+	// StatusPendingApproval means the call is waiting for human approval.
+	StatusPendingApproval = "pending_approval"
+
+	// StatusNotApproved means a human rejected the call.
+	StatusNotApproved = "not_approved"
+)
+
+// Span is a tracing span around a single tool execution.
+type Span interface {
+	// SetAttribute sets a span attribute.
+	SetAttribute(key string, value interface{})
+
+	// RecordError records an error on the span.
+	RecordError(err error)
+
+	// End finishes the span.
+	End()
+}
+
+// Tracer starts spans for tool executions.
+type Tracer interface {
+	// StartSpan starts a span and returns a context carrying it.
+	StartSpan(ctx context.Context, name string) (context.Context, Span)
+}
+
+// Auditor records the outcome of every tool call, for example in a
+// tamper-evident audit log.
+type Auditor interface {
+	// RecordToolCall records a completed call.
+	RecordToolCall(ctx context.Context, result *CallResult)
+}
+
+// ToolConfig overrides executor settings for a single tool.
+type ToolConfig struct {
+	// Timeout bounds each attempt (0 = use the executor default).
+	Timeout time.Duration
+
+	// Retry configures retries (nil = use the executor default).
+	Retry *resilience.RetryConfig
+
+	// RequireApproval requires human approval before each call, in
+	// addition to tools marked with RequireApproval.
+	RequireApproval bool
+}
+
+// ExecutorConfig configures an Executor.
+type ExecutorConfig struct {
+	// MaxConcurrent is the maximum number of tools running at once.
+	MaxConcurrent int
+
+	// MaxQueueDepth is the maximum number of calls waiting for a slot (0 = no limit).
+	MaxQueueDepth int
+
+	// AcquireTimeout is the maximum time to wait for a slot (0 = wait for the context).
+	AcquireTimeout time.Duration
+
+	// Timeout bounds each attempt of a tool call (0 = no timeout).
+	Timeout time.Duration
+
+	// Retry configures retries of failed calls (nil = single attempt).
+	Retry *resilience.RetryConfig
+
+	// Tools holds per-tool overrides keyed by tool name.
+	Tools map[string]*ToolConfig
+
+	// Policy checks tool access by agent and tenant (nil = allow all).
+	Policy *Policy
+
+	// Authorizer checks tool calls against an authorization policy (optional).
+	Authorizer Authorizer
+
+	// Metrics records execution metrics (optional).
+	Metrics *metrics.ToolMetrics
+
+	// Tracer creates execution spans (optional).
+	Tracer Tracer
+
+	// Auditor records every call and its outcome (optional).
+	Auditor Auditor
+
+	// Approvals persists approval requests for tools that require them.
+	// Without it, such tools are denied.
+	Approvals *ApprovalStore
+}
+
+// DefaultExecutorConfig returns a default executor configuration.
+func DefaultExecutorConfig() *ExecutorConfig {
+	return &ExecutorConfig{
+		MaxConcurrent:  10,
+		MaxQueueDepth:  0,
+		AcquireTimeout: 0,
+		Timeout:        30 * time.Second,
+		Retry:          nil,
+	}
+}
+
+// CallResult is the outcome of one tool call.
+type CallResult struct {
+	// Call is the executed tool call.
+	Call *llm.ToolCall
+
+	// Result is the tool result, nil if Err is set.
+	Result *Result
+
+	// Err is the execution error, if any.
+	Err error
+
+	// Status is the execution status (StatusSuccess, StatusTimeout, ...).
+	Status string
+
+	// Attempts is the number of attempts made.
+	Attempts int
+
+	// Duration is the total execution time including retries.
+	Duration time.Duration
+
+	// Approval is the approval request when the call required approval.
+	Approval *Approval
+}
+
+// ToLLMResult converts the outcome into a tool message for the model.
+// Errors are reported as failed results so the model can react to them.
+func (r *CallResult) ToLLMResult() *llm.ToolCallResult {
+	result := r.Result
+	if r.Status == StatusPendingApproval {
+		result = ErrorResultWithMessage("awaiting user approval")
+	}
+	if r.Err != nil {
+		result = ErrorResult(r.Err)
+	}
+
+	content, err := json.Marshal(result)
+	if err != nil {
+		content = []byte(fmt.Sprintf(`{"success":false,"error":%q}`, err.Error()))
+	}
+
+	out := &llm.ToolCallResult{
+		Role:    "tool",
+		Content: string(content),
+	}
+	if r.Call != nil {
+		out.ToolCallID = r.Call.ID
+		if r.Call.Function != nil {
+			out.Name = r.Call.Function.Name
+		}
+	}
+	return out
+}
+
+// Executor runs tool calls from a registry with concurrency limits,
+// timeouts, retries and access control.
+type Executor struct {
+	registry *Registry
+	config   *ExecutorConfig
+	bulkhead *resilience.Bulkhead
+	inFlight int64
+}
+
+// NewExecutor creates a new executor for the registry.
+func NewExecutor(registry *Registry, config *ExecutorConfig) *Executor {
+	if config == nil {
+		config = DefaultExecutorConfig()
+	}
+
+	maxConcurrent := config.MaxConcurrent
+	if maxConcurrent <= 0 {
+		maxConcurrent = DefaultExecutorConfig().MaxConcurrent
+	}
+
+	return &Executor{
+		registry: registry,
+		config:   config,
+		bulkhead: resilience.NewBulkhead(&resilience.BulkheadConfig{
+			MaxConcurrent: maxConcurrent,
+			MaxQueueDepth: config.MaxQueueDepth,
+			Timeout:       config.AcquireTimeout,
+		}),
+	}
+}
+
+// ExecuteAll runs the tool calls of one model turn in parallel.
+// Results are returned in the order of calls.
+func (e *Executor) ExecuteAll(ctx context.Context, calls []*llm.ToolCall) []*CallResult {
+	results := make([]*CallResult, len(calls))
+
+	var wg sync.WaitGroup
+	for i, call := range calls {
+		wg.Add(1)
+		go func(i int, call *llm.ToolCall) {
+			defer wg.Done()
+			results[i] = e.Execute(ctx, call)
+		}(i, call)
+	}
+	wg.Wait()
+
+	return results
+}
+
+// Execute runs a single tool call.
+func (e *Executor) Execute(ctx context.Context, call *llm.ToolCall) *CallResult {
+	start := time.Now()
+	res := &CallResult{Call: call}
+
+	name := ""
+	if call != nil && call.Function != nil {
+		name = call.Function.Name
+	}
+
+	var span Span
+	if e.config.Tracer != nil {
+		ctx, span = e.config.Tracer.StartSpan(ctx, "tool.execute")
+		span.SetAttribute("tool.name", name)
+		if call != nil {
+			span.SetAttribute("tool.call_id", call.ID)
+		}
+		defer span.End()
+	}
+
+	res.Result, res.Status, res.Err = e.execute(ctx, call, name, res)
+	res.Duration = time.Since(start)
+
+	if span != nil {
+		span.SetAttribute("tool.status", res.Status)
+		span.SetAttribute("tool.attempts", res.Attempts)
+		if res.Err != nil {
+			span.RecordError(res.Err)
+		}
+	}
+	if e.config.Metrics != nil {
+		e.config.Metrics.RecordExecution(name, res.Status, res.Duration.Seconds())
+	}
+	if e.config.Auditor != nil {
+		e.config.Auditor.RecordToolCall(ctx, res)
+	}
+
+	return res
+}
+
+// execute performs the lookup, policy and approval checks and guarded execution.
+func (e *Executor) execute(ctx context.Context, call *llm.ToolCall, name string, res *CallResult) (*Result, string, error) {
+	if call == nil || call.Function == nil {
+		return nil, StatusError, fmt.Errorf("%w: missing function call", ErrInvalidParameters)
+	}
+
+	tool, err := e.registry.Get(name)
+	if err != nil {
+		return nil, StatusError, fmt.Errorf("%w: %s", err, name)
+	}
+
+	if e.config.Policy != nil {
+		agentID, tenantID := GetAgentID(ctx), GetTenantID(ctx)
+		if err := e.config.Policy.Check(agentID, tenantID, name); err != nil {
+			if e.config.Metrics != nil {
+				e.config.Metrics.RecordDenied(name, agentID, tenantID)
+			}
+			return nil, StatusDenied, err
+		}
+	}
+
+	if e.config.Authorizer != nil {
+		if err := e.config.Authorizer.AuthorizeTool(ctx, name); err != nil {
+			if e.config.Metrics != nil {
+				e.config.Metrics.RecordDenied(name, GetAgentID(ctx), GetTenantID(ctx))
+			}
+			return nil, StatusDenied, fmt.Errorf("%w: %s: %v", ErrToolAccessDenied, name, err)
+		}
+	}
+
+	params := map[string]interface{}{}
+	if call.Function.Arguments != "" {
+		params, err = call.Function.ParsedArguments()
+		if err != nil {
+			return nil, StatusError, fmt.Errorf("%w: %v", ErrInvalidParameters, err)
+		}
+	}
+
+	// Reject arguments that don't match the schema before asking for
+	// approval or running the tool; the model sees what was wrong.
+	if err := ValidateParams(tool, params); err != nil {
+		return ErrorResult(err), StatusFailed, nil
+	}
+
+	if e.requiresApproval(tool) {
+		if e.config.Approvals == nil {
+			return nil, StatusDenied, fmt.Errorf("%w: %s", ErrApprovalRequired, name)
+		}
+
+		approval, err := e.config.Approvals.Request(ctx, call, params)
+		if err != nil {
+			return nil, StatusError, err
+		}
+		res.Approval = approval
+
+		switch approval.Status {
+		case ApprovalPending:
+			return nil, StatusPendingApproval, nil
+		case ApprovalRejected:
+			_ = e.config.Approvals.Delete(ctx, approval.ID)
+			msg := "tool call rejected by user"
+			if approval.Reason != "" {
+				msg += ": " + approval.Reason
+			}
+			return ErrorResultWithMessage(msg), StatusNotApproved, nil
+		}
+
+		// Run exactly what the user approved and consume the approval.
+		params = approval.Arguments
+		if params == nil {
+			params = map[string]interface{}{}
+		}
+		_ = e.config.Approvals.Delete(ctx, approval.ID)
+	}
+
+	attempts := &res.Attempts
+	timeout, retry := e.settings(name)
+
+	var result *Result
+	var lastErr error
+	attempt := func(ctx context.Context) error {
+		*attempts++
+		if *attempts > 1 && e.config.Metrics != nil {
+			e.config.Metrics.RecordRetry(name)
+		}
+
+		lastErr = e.runAttempt(ctx, tool, params, timeout, &result)
+		return lastErr
+	}
+
+	err = e.bulkhead.Execute(ctx, func(ctx context.Context) error {
+		e.trackInFlight(1)
+		defer e.trackInFlight(-1)
+
+		if retry == nil {
+			return attempt(ctx)
+		}
+		return resilience.Retry(ctx, retry, attempt)
+	})
+
+	switch {
+	case err == nil:
+		if result != nil && !result.Success {
+			return result, StatusFailed, nil
+		}
+		return result, StatusSuccess, nil
+	case errors.Is(err, resilience.ErrBulkheadFull):
+		return nil, StatusRejected, err
+	case errors.Is(lastErr, ErrToolTimeout):
+		return nil, StatusTimeout, lastErr
+	default:
+		return nil, StatusError, err
+	}
+}
+
+// runAttempt executes the tool once, bounded by timeout.
+func (e *Executor) runAttempt(ctx context.Context, tool Tool, params map[string]interface{}, timeout time.Duration, out **Result) error {
+	if timeout <= 0 {
+		result, err := tool.Execute(ctx, params)
+		if err == nil {
+			*out = result
+		}
+		return err
+	}
+
+	var result *Result
+	err := resilience.WithTimeout(ctx, &resilience.TimeoutConfig{Duration: timeout}, func(ctx context.Context) error {
+		r, err := tool.Execute(ctx, params)
+		result = r
+		return err
+	})
+	if errors.Is(err, resilience.ErrTimeout) {
+		return fmt.Errorf("%w: %s after %s", ErrToolTimeout, tool.Name(), timeout)
+	}
+	if err == nil {
+		*out = result
+	}
+	return err
+}
+
+// requiresApproval reports whether calls of tool need human approval.
+func (e *Executor) requiresApproval(tool Tool) bool {
+	if NeedsApproval(tool) {
+		return true
+	}
+	tc, ok := e.config.Tools[tool.Name()]
+	return ok && tc != nil && tc.RequireApproval
+}
+
+// settings returns the effective timeout and retry configuration for a tool.
+func (e *Executor) settings(name string) (time.Duration, *resilience.RetryConfig) {
+	timeout, retry := e.config.Timeout, e.config.Retry
+	if tc, ok := e.config.Tools[name]; ok && tc != nil {
+		if tc.Timeout > 0 {
+			timeout = tc.Timeout
+		}
+		if tc.Retry != nil {
+			retry = tc.Retry
+		}
+	}
+	return timeout, retry
+}
+
+// trackInFlight updates the in-flight gauge.
+func (e *Executor) trackInFlight(delta int64) {
+	n := atomic.AddInt64(&e.inFlight, delta)
+	if e.config.Metrics != nil {
+		e.config.Metrics.SetInFlight(float64(n))
+	}
+}
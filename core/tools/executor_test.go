@@ -0,0 +1,334 @@
+// Copyright (C) 2025 sage-x-project
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with this program. If not, see <https://www.gnu.org/licenses/>.
+
+// SPDX-License-Identifier: LGPL-3.0-or-later
+
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/sage-x-project/sage-adk/adapters/llm"
+	"github.com/sage-x-project/sage-adk/core/resilience"
+)
+
+func newCall(id, name, args string) *llm.ToolCall {
+	return &llm.ToolCall{
+		ID:       id,
+		Type:     llm.ToolTypeFunction,
+		Function: &llm.FunctionCall{Name: name, Arguments: args},
+	}
+}
+
+func sleepTool(name string, d time.Duration, running, peak *int32) Tool {
+	return NewFunctionTool(name, "sleeps", &ParameterSchema{Type: "object"},
+		func(ctx context.Context, params map[string]interface{}) (*Result, error) {
+			n := atomic.AddInt32(running, 1)
+			defer atomic.AddInt32(running, -1)
+			for {
+				p := atomic.LoadInt32(peak)
+				if n <= p || atomic.CompareAndSwapInt32(peak, p, n) {
+					break
+				}
+			}
+			select {
+			case <-time.After(d):
+				return SuccessResult(name), nil
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			}
+		})
+}
+
+func TestExecutor_ExecuteAll_ParallelAndOrdered(t *testing.T) {
+	var running, peak int32
+	registry := NewRegistry()
+	for _, name := range []string{"a", "b", "c", "d"} {
+		if err := registry.Register(sleepTool(name, 30*time.Millisecond, &running, &peak)); err != nil {
+			t.Fatalf("Register() error = %v", err)
+		}
+	}
+
+	config := DefaultExecutorConfig()
+	config.MaxConcurrent = 2
+	executor := NewExecutor(registry, config)
+
+	calls := []*llm.ToolCall{
+		newCall("1", "a", ""),
+		newCall("2", "b", "{}"),
+		newCall("3", "c", ""),
+		newCall("4", "d", ""),
+	}
+
+	results := executor.ExecuteAll(context.Background(), calls)
+
+	if len(results) != len(calls) {
+		t.Fatalf("len(results) = %d, want %d", len(results), len(calls))
+	}
+	for i, res := range results {
+		if res.Err != nil {
+			t.Fatalf("result %d error = %v", i, res.Err)
+		}
+		if res.Call != calls[i] {
+			t.Errorf("result %d out of order", i)
+		}
+		if res.Status != StatusSuccess {
+			t.Errorf("result %d status = %v, want success", i, res.Status)
+		}
+	}
+	if got := atomic.LoadInt32(&peak); got != 2 {
+		t.Errorf("peak concurrency = %d, want 2", got)
+	}
+}
+
+func TestExecutor_PerToolTimeout(t *testing.T) {
+	var running, peak int32
+	registry := NewRegistry()
+	_ = registry.Register(sleepTool("slow", time.Second, &running, &peak))
+
+	config := DefaultExecutorConfig()
+	config.Tools = map[string]*ToolConfig{"slow": {Timeout: 20 * time.Millisecond}}
+	executor := NewExecutor(registry, config)
+
+	res := executor.Execute(context.Background(), newCall("1", "slow", ""))
+	if !errors.Is(res.Err, ErrToolTimeout) {
+		t.Errorf("Err = %v, want ErrToolTimeout", res.Err)
+	}
+	if res.Status != StatusTimeout {
+		t.Errorf("Status = %v, want timeout", res.Status)
+	}
+}
+
+func TestExecutor_Retry(t *testing.T) {
+	var calls int32
+	registry := NewRegistry()
+	_ = registry.Register(NewFunctionTool("flaky", "fails twice", nil,
+		func(ctx context.Context, params map[string]interface{}) (*Result, error) {
+			if atomic.AddInt32(&calls, 1) < 3 {
+				return nil, errors.New("temporary")
+			}
+			return SuccessResult("ok"), nil
+		}))
+
+	config := DefaultExecutorConfig()
+	config.Retry = &resilience.RetryConfig{
+		MaxAttempts: 3,
+		Backoff:     resilience.ConstantBackoff(time.Millisecond),
+		ShouldRetry: resilience.DefaultShouldRetry,
+	}
+	executor := NewExecutor(registry, config)
+
+	res := executor.Execute(context.Background(), newCall("1", "flaky", ""))
+	if res.Err != nil {
+		t.Fatalf("Err = %v", res.Err)
+	}
+	if res.Attempts != 3 {
+		t.Errorf("Attempts = %d, want 3", res.Attempts)
+	}
+}
+
+func TestExecutor_PolicyDenied(t *testing.T) {
+	registry := NewRegistry()
+	_ = RegisterBuiltinTools(registry)
+
+	policy := NewPolicy(Permissions{Deny: []string{"current_time"}})
+	policy.SetTenant("tenant-a", Permissions{Allow: []string{"echo"}})
+
+	config := DefaultExecutorConfig()
+	config.Policy = policy
+	executor := NewExecutor(registry, config)
+
+	ctx := WithTenantID(WithAgentID(context.Background(), "agent-1"), "tenant-a")
+
+	tests := []struct {
+		tool   string
+		ctx    context.Context
+		denied bool
+	}{
+		{"current_time", context.Background(), true},
+		{"calculator", context.Background(), false},
+		{"calculator", ctx, true},
+		{"echo", ctx, false},
+	}
+
+	for _, tt := range tests {
+		res := executor.Execute(tt.ctx, newCall("1", tt.tool, `{"message":"hi","operation":"add","a":1,"b":2}`))
+		if got := errors.Is(res.Err, ErrToolAccessDenied); got != tt.denied {
+			t.Errorf("%s denied = %v, want %v (err = %v)", tt.tool, got, tt.denied, res.Err)
+		}
+	}
+}
+
+type authorizerFunc func(ctx context.Context, tool string) error
+
+func (f authorizerFunc) AuthorizeTool(ctx context.Context, tool string) error {
+	return f(ctx, tool)
+}
+
+func TestExecutor_AuthorizerDenied(t *testing.T) {
+	registry := NewRegistry()
+	_ = RegisterBuiltinTools(registry)
+
+	config := DefaultExecutorConfig()
+	config.Authorizer = authorizerFunc(func(ctx context.Context, tool string) error {
+		if tool == "calculator" && GetTenantID(ctx) != "tenant-a" {
+			return errors.New("denied by rule \"calc\"")
+		}
+		return nil
+	})
+	executor := NewExecutor(registry, config)
+
+	args := `{"operation":"add","a":1,"b":2}`
+
+	res := executor.Execute(context.Background(), newCall("1", "calculator", args))
+	if !errors.Is(res.Err, ErrToolAccessDenied) || res.Status != StatusDenied {
+		t.Errorf("Err = %v, Status = %s, want ErrToolAccessDenied", res.Err, res.Status)
+	}
+
+	res = executor.Execute(WithTenantID(context.Background(), "tenant-a"), newCall("2", "calculator", args))
+	if res.Err != nil {
+		t.Errorf("Err = %v, want nil", res.Err)
+	}
+}
+
+func TestExecutor_UnknownToolAndBadArguments(t *testing.T) {
+	registry := NewRegistry()
+	_ = registry.Register(EchoTool())
+	executor := NewExecutor(registry, nil)
+
+	res := executor.Execute(context.Background(), newCall("1", "missing", ""))
+	if !errors.Is(res.Err, ErrToolNotFound) {
+		t.Errorf("Err = %v, want ErrToolNotFound", res.Err)
+	}
+
+	res = executor.Execute(context.Background(), newCall("2", "echo", "{not json"))
+	if !errors.Is(res.Err, ErrInvalidParameters) {
+		t.Errorf("Err = %v, want ErrInvalidParameters", res.Err)
+	}
+}
+
+func TestExecutor_ValidatesArguments(t *testing.T) {
+	var ran int32
+	registry := NewRegistry()
+	schema := llm.NewFunctionParameters().AddProperty("count", "integer", "how many", true)
+	_ = registry.Register(NewFunctionTool("count", "counts", schema,
+		func(ctx context.Context, params map[string]interface{}) (*Result, error) {
+			atomic.AddInt32(&ran, 1)
+			return SuccessResult(params["count"]), nil
+		}))
+	executor := NewExecutor(registry, nil)
+
+	res := executor.Execute(context.Background(), newCall("1", "count", `{"count":"three"}`))
+	if res.Status != StatusFailed || res.Result == nil || res.Result.Success {
+		t.Errorf("Execute() = %+v, want a failed result", res)
+	}
+	if atomic.LoadInt32(&ran) != 0 {
+		t.Error("tool ran with invalid arguments")
+	}
+
+	res = executor.Execute(context.Background(), newCall("2", "count", `{"count":3}`))
+	if res.Status != StatusSuccess || atomic.LoadInt32(&ran) != 1 {
+		t.Errorf("Execute() = %+v, want success", res)
+	}
+}
+
+type recordingSpan struct {
+	mu    sync.Mutex
+	attrs map[string]interface{}
+	ended bool
+}
+
+func (s *recordingSpan) SetAttribute(key string, value interface{}) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.attrs[key] = value
+}
+
+func (s *recordingSpan) RecordError(err error) {}
+
+func (s *recordingSpan) End() { s.ended = true }
+
+type recordingTracer struct {
+	spans []*recordingSpan
+}
+
+func (t *recordingTracer) StartSpan(ctx context.Context, name string) (context.Context, Span) {
+	span := &recordingSpan{attrs: make(map[string]interface{})}
+	t.spans = append(t.spans, span)
+	return ctx, span
+}
+
+func TestExecutor_TracingAndLLMResult(t *testing.T) {
+	registry := NewRegistry()
+	_ = registry.Register(EchoTool())
+
+	tracer := &recordingTracer{}
+	config := DefaultExecutorConfig()
+	config.Tracer = tracer
+	executor := NewExecutor(registry, config)
+
+	res := executor.Execute(context.Background(), newCall("call-1", "echo", `{"message":"hi"}`))
+	if res.Err != nil {
+		t.Fatalf("Err = %v", res.Err)
+	}
+
+	if len(tracer.spans) != 1 || !tracer.spans[0].ended {
+		t.Fatal("expected one ended span")
+	}
+	if tracer.spans[0].attrs["tool.name"] != "echo" {
+		t.Errorf("tool.name = %v, want echo", tracer.spans[0].attrs["tool.name"])
+	}
+
+	msg := res.ToLLMResult()
+	if msg.ToolCallID != "call-1" || msg.Name != "echo" {
+		t.Errorf("ToLLMResult() = %+v", msg)
+	}
+	var decoded Result
+	if err := json.Unmarshal([]byte(msg.Content), &decoded); err != nil {
+		t.Fatalf("content is not JSON: %v", err)
+	}
+	if !decoded.Success {
+		t.Error("decoded result should be successful")
+	}
+}
+
+func TestRegistry_ConcurrentAccess(t *testing.T) {
+	registry := NewRegistry()
+	executor := NewExecutor(registry, nil)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(2)
+		go func(i int) {
+			defer wg.Done()
+			_ = registry.Register(NewFunctionTool(string(rune('a'+i)), "", nil,
+				func(ctx context.Context, params map[string]interface{}) (*Result, error) {
+					return SuccessResult(nil), nil
+				}))
+		}(i)
+		go func(i int) {
+			defer wg.Done()
+			executor.Execute(context.Background(), newCall("1", string(rune('a'+i)), ""))
+			_ = registry.List()
+		}(i)
+	}
+	wg.Wait()
+}
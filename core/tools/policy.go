@@ -0,0 +1,122 @@
+// Copyright (C) 2025 sage-x-project
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with this program. If not, see <https://www.gnu.org/licenses/>.
+
+// SPDX-License-Identifier: LGPL-3.0-or-later
+
+package tools
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+// Wildcard matches every tool name in a Permissions list.
+const Wildcard = "*"
+
+// Permissions lists the tools allowed or denied within one scope.
+// An empty Allow list places no restriction; Deny always wins.
+type Permissions struct {
+	// Allow lists permitted tool names. Use Wildcard to allow all tools.
+	Allow []string
+
+	// Deny lists forbidden tool names. Use Wildcard to deny all tools.
+	Deny []string
+}
+
+// allows reports whether the scope permits tool.
+func (p Permissions) allows(tool string) bool {
+	if contains(p.Deny, tool) {
+		return false
+	}
+	if len(p.Allow) == 0 {
+		return true
+	}
+	return contains(p.Allow, tool)
+}
+
+// Policy decides which tools an agent or tenant may call.
+//
+// A call is checked against the default scope, the agent scope and the
+// tenant scope. It is permitted only if every applicable scope allows it.
+// Policy is safe for concurrent use.
+type Policy struct {
+	mu       sync.RWMutex
+	defaults Permissions
+	agents   map[string]Permissions
+	tenants  map[string]Permissions
+}
+
+// NewPolicy creates a policy with the given default permissions.
+func NewPolicy(defaults Permissions) *Policy {
+	return &Policy{
+		defaults: defaults,
+		agents:   make(map[string]Permissions),
+		tenants:  make(map[string]Permissions),
+	}
+}
+
+// SetAgent sets the permissions of an agent.
+func (p *Policy) SetAgent(agentID string, perms Permissions) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.agents[agentID] = perms
+}
+
+// SetTenant sets the permissions of a tenant.
+func (p *Policy) SetTenant(tenantID string, perms Permissions) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.tenants[tenantID] = perms
+}
+
+// Check returns ErrToolAccessDenied if the agent or tenant may not call tool.
+// Empty agent or tenant IDs skip the corresponding scope.
+func (p *Policy) Check(agentID, tenantID, tool string) error {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	if !p.defaults.allows(tool) {
+		return fmt.Errorf("%w: %s", ErrToolAccessDenied, tool)
+	}
+
+	if perms, ok := p.agents[agentID]; ok && agentID != "" && !perms.allows(tool) {
+		return fmt.Errorf("%w: %s for agent %s", ErrToolAccessDenied, tool, agentID)
+	}
+
+	if perms, ok := p.tenants[tenantID]; ok && tenantID != "" && !perms.allows(tool) {
+		return fmt.Errorf("%w: %s for tenant %s", ErrToolAccessDenied, tool, tenantID)
+	}
+
+	return nil
+}
+
+// Authorizer decides whether the caller recorded in ctx may call a tool,
+// beyond the static Policy. The authz package provides one.
+type Authorizer interface {
+	AuthorizeTool(ctx context.Context, tool string) error
+}
+
+// contains reports whether names contains name or the wildcard.
+func contains(names []string, name string) bool {
+	for _, n := range names {
+		if n == name || n == Wildcard {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,104 @@
+// Copyright (C) 2025 sage-x-project
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with this program. If not, see <https://www.gnu.org/licenses/>.
+
+// SPDX-License-Identifier: LGPL-3.0-or-later
+
+package tools
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/sage-x-project/sage-adk/adapters/llm"
+)
+
+// TypedFunc is the function run by a TypedTool.
+type TypedFunc[In, Out any] func(ctx context.Context, input In) (Out, error)
+
+// TypedTool is a tool whose parameters are decoded into a Go struct.
+// The parameter schema is generated from In with llm.SchemaFor, and
+// arguments are validated against it before the function runs.
+type TypedTool[In, Out any] struct {
+	name        string
+	description string
+	parameters  *ParameterSchema
+	fn          TypedFunc[In, Out]
+}
+
+// NewTypedTool creates a tool from a typed function.
+// In must be a struct type; see llm.SchemaFor for the supported struct tags.
+func NewTypedTool[In, Out any](name, description string, fn TypedFunc[In, Out]) (*TypedTool[In, Out], error) {
+	if name == "" {
+		return nil, ErrEmptyToolName
+	}
+
+	parameters, err := llm.SchemaFor[In]()
+	if err != nil {
+		return nil, err
+	}
+
+	return &TypedTool[In, Out]{
+		name:        name,
+		description: description,
+		parameters:  parameters,
+		fn:          fn,
+	}, nil
+}
+
+// MustTypedTool is like NewTypedTool but panics on error.
+func MustTypedTool[In, Out any](name, description string, fn TypedFunc[In, Out]) *TypedTool[In, Out] {
+	tool, err := NewTypedTool(name, description, fn)
+	if err != nil {
+		panic(err)
+	}
+	return tool
+}
+
+// Name returns the tool name.
+func (t *TypedTool[In, Out]) Name() string {
+	return t.name
+}
+
+// Description returns the tool description.
+func (t *TypedTool[In, Out]) Description() string {
+	return t.description
+}
+
+// Parameters returns the generated parameter schema.
+func (t *TypedTool[In, Out]) Parameters() *ParameterSchema {
+	return t.parameters
+}
+
+// Execute validates and decodes params, then runs the function.
+//
+// Invalid arguments are reported as a failed Result rather than an error,
+// so the model can see what was wrong and correct its call.
+func (t *TypedTool[In, Out]) Execute(ctx context.Context, params map[string]interface{}) (*Result, error) {
+	if err := t.parameters.Validate(params); err != nil {
+		return ErrorResult(err), nil
+	}
+
+	var input In
+	if err := UnmarshalParams(params, &input); err != nil {
+		return ErrorResultWithMessage(fmt.Sprintf("invalid arguments: %v", err)), nil
+	}
+
+	output, err := t.fn(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+
+	return SuccessResult(output), nil
+}
@@ -0,0 +1,125 @@
+// Copyright (C) 2025 sage-x-project
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with this program. If not, see <https://www.gnu.org/licenses/>.
+
+// SPDX-License-Identifier: LGPL-3.0-or-later
+
+package tools
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+)
+
+type weatherInput struct {
+	City string `json:"city" description:"City name" required:"true"`
+	Unit string `json:"unit,omitempty" enum:"celsius,fahrenheit"`
+	Days int    `json:"days,omitempty" min:"1" max:"7"`
+}
+
+type weatherOutput struct {
+	Summary string `json:"summary"`
+}
+
+func newWeatherTool(t *testing.T) *TypedTool[weatherInput, weatherOutput] {
+	t.Helper()
+	tool, err := NewTypedTool("weather", "Get the weather",
+		func(ctx context.Context, in weatherInput) (weatherOutput, error) {
+			if in.City == "Atlantis" {
+				return weatherOutput{}, errors.New("service unavailable")
+			}
+			return weatherOutput{Summary: in.City + " " + in.Unit}, nil
+		})
+	if err != nil {
+		t.Fatalf("NewTypedTool() error = %v", err)
+	}
+	return tool
+}
+
+func TestNewTypedTool(t *testing.T) {
+	tool := newWeatherTool(t)
+
+	if tool.Name() != "weather" || tool.Description() != "Get the weather" {
+		t.Errorf("tool = %s: %s", tool.Name(), tool.Description())
+	}
+
+	params := tool.Parameters()
+	if params.Properties["city"].Description != "City name" {
+		t.Errorf("city.Description = %q", params.Properties["city"].Description)
+	}
+	if len(params.Required) != 1 || params.Required[0] != "city" {
+		t.Errorf("Required = %v, want [city]", params.Required)
+	}
+
+	if _, err := NewTypedTool("", "", func(ctx context.Context, in weatherInput) (string, error) {
+		return "", nil
+	}); err != ErrEmptyToolName {
+		t.Errorf("NewTypedTool(\"\") error = %v, want ErrEmptyToolName", err)
+	}
+	if _, err := NewTypedTool("bad", "", func(ctx context.Context, in int) (string, error) {
+		return "", nil
+	}); err == nil {
+		t.Error("NewTypedTool() should reject non-struct input")
+	}
+}
+
+func TestTypedTool_Execute(t *testing.T) {
+	tool := newWeatherTool(t)
+	ctx := context.Background()
+
+	result, err := tool.Execute(ctx, map[string]interface{}{"city": "Seoul", "unit": "celsius"})
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	if !result.Success {
+		t.Fatalf("Execute() failed: %s", result.Error)
+	}
+	if out := result.Output.(weatherOutput); out.Summary != "Seoul celsius" {
+		t.Errorf("Output = %+v", out)
+	}
+
+	result, err = tool.Execute(ctx, map[string]interface{}{"unit": "kelvin", "days": 10.0})
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	if result.Success {
+		t.Fatal("Execute() should reject invalid arguments")
+	}
+	for _, want := range []string{"city: is required", "unit: must be one of", "days: must be <= 7"} {
+		if !strings.Contains(result.Error, want) {
+			t.Errorf("Error = %q, want it to contain %q", result.Error, want)
+		}
+	}
+
+	if _, err := tool.Execute(ctx, map[string]interface{}{"city": "Atlantis"}); err == nil {
+		t.Error("Execute() should return the function error")
+	}
+}
+
+func TestRegistry_ToLLMTools(t *testing.T) {
+	registry := NewRegistry()
+	if err := registry.Register(newWeatherTool(t)); err != nil {
+		t.Fatalf("Register() error = %v", err)
+	}
+
+	llmTools := registry.ToLLMTools()
+	if len(llmTools) != 1 {
+		t.Fatalf("len(ToLLMTools()) = %d, want 1", len(llmTools))
+	}
+	if fn := llmTools[0].Function; fn.Name != "weather" || fn.Parameters.Properties["days"].Type != "integer" {
+		t.Errorf("Function = %+v", fn)
+	}
+}
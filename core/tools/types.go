@@ -20,6 +20,10 @@ package tools
 import (
 	"context"
 	"encoding/json"
+	"fmt"
+	"sync"
+
+	"github.com/sage-x-project/sage-adk/adapters/llm"
 )
 
 // Tool represents a callable function/tool that an agent can use.
@@ -38,19 +42,11 @@ type Tool interface {
 }
 
 // ParameterSchema defines the JSON schema for tool parameters.
-type ParameterSchema struct {
-	Type       string                      `json:"type"`
-	Properties map[string]*PropertySchema  `json:"properties,omitempty"`
-	Required   []string                    `json:"required,omitempty"`
-}
+// It is the same type LLM providers receive as function parameters.
+type ParameterSchema = llm.FunctionParameters
 
 // PropertySchema defines a single parameter property.
-type PropertySchema struct {
-	Type        string   `json:"type"`
-	Description string   `json:"description,omitempty"`
-	Enum        []string `json:"enum,omitempty"`
-	Default     interface{} `json:"default,omitempty"`
-}
+type PropertySchema = llm.PropertySchema
 
 // Result represents the result of a tool execution.
 type Result struct {
@@ -109,7 +105,9 @@ func (t *FunctionTool) Execute(ctx context.Context, params map[string]interface{
 }
 
 // Registry manages a collection of tools.
+// It is safe for concurrent use.
 type Registry struct {
+	mu    sync.RWMutex
 	tools map[string]Tool
 }
 
@@ -128,6 +126,10 @@ func (r *Registry) Register(tool Tool) error {
 	if tool.Name() == "" {
 		return ErrEmptyToolName
 	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
 	if _, exists := r.tools[tool.Name()]; exists {
 		return ErrToolAlreadyExists
 	}
@@ -137,6 +139,9 @@ func (r *Registry) Register(tool Tool) error {
 
 // Unregister removes a tool from the registry.
 func (r *Registry) Unregister(name string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
 	if _, exists := r.tools[name]; !exists {
 		return ErrToolNotFound
 	}
@@ -146,6 +151,9 @@ func (r *Registry) Unregister(name string) error {
 
 // Get retrieves a tool by name.
 func (r *Registry) Get(name string) (Tool, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
 	tool, exists := r.tools[name]
 	if !exists {
 		return nil, ErrToolNotFound
@@ -155,6 +163,9 @@ func (r *Registry) Get(name string) (Tool, error) {
 
 // List returns all registered tools.
 func (r *Registry) List() []Tool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
 	tools := make([]Tool, 0, len(r.tools))
 	for _, tool := range r.tools {
 		tools = append(tools, tool)
@@ -164,24 +175,47 @@ func (r *Registry) List() []Tool {
 
 // Has checks if a tool is registered.
 func (r *Registry) Has(name string) bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
 	_, exists := r.tools[name]
 	return exists
 }
 
 // Count returns the number of registered tools.
 func (r *Registry) Count() int {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
 	return len(r.tools)
 }
 
 // Execute runs a tool with the given parameters.
+//
+// Parameters that don't match the tool's schema are reported as a failed
+// Result without running the tool. Tools that need approval are refused
+// with ErrApprovalRequired; run them through an Executor with an
+// ApprovalStore.
 func (r *Registry) Execute(ctx context.Context, name string, params map[string]interface{}) (*Result, error) {
 	tool, err := r.Get(name)
 	if err != nil {
 		return nil, err
 	}
+	if NeedsApproval(tool) {
+		return nil, fmt.Errorf("%w: %s", ErrApprovalRequired, name)
+	}
+	if err := ValidateParams(tool, params); err != nil {
+		return ErrorResult(err), nil
+	}
 	return tool.Execute(ctx, params)
 }
 
+// ValidateParams checks params against the tool's parameter schema.
+// It returns nil for tools without a schema.
+func ValidateParams(tool Tool, params map[string]interface{}) error {
+	return tool.Parameters().Validate(params)
+}
+
 // ToLLMFormat converts tools to LLM function calling format.
 func (r *Registry) ToLLMFormat() []map[string]interface{} {
 	tools := r.List()
@@ -201,6 +235,18 @@ func (r *Registry) ToLLMFormat() []map[string]interface{} {
 	return result
 }
 
+// ToLLMTools converts tools to LLM tool definitions.
+func (r *Registry) ToLLMTools() []*llm.Tool {
+	tools := r.List()
+	result := make([]*llm.Tool, len(tools))
+
+	for i, tool := range tools {
+		result[i] = llm.NewTool(llm.NewFunction(tool.Name(), tool.Description(), tool.Parameters()))
+	}
+
+	return result
+}
+
 // SuccessResult creates a successful result.
 func SuccessResult(output interface{}) *Result {
 	return &Result{
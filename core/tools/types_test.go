@@ -21,6 +21,8 @@ import (
 	"context"
 	"errors"
 	"testing"
+
+	"github.com/sage-x-project/sage-adk/adapters/llm"
 )
 
 func TestNewFunctionTool(t *testing.T) {
@@ -247,6 +249,45 @@ func TestRegistry_Execute(t *testing.T) {
 	}
 }
 
+func TestRegistry_Execute_InvalidParameters(t *testing.T) {
+	registry := NewRegistry()
+	schema := llm.NewFunctionParameters().AddProperty("message", "string", "text to echo", true)
+	registry.Register(NewFunctionTool("echo", "Echoes input", schema,
+		func(ctx context.Context, params map[string]interface{}) (*Result, error) {
+			t.Error("tool ran with invalid parameters")
+			return SuccessResult(params["message"]), nil
+		},
+	))
+
+	result, err := registry.Execute(context.Background(), "echo", map[string]interface{}{})
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	if result.Success {
+		t.Error("Result.Success = true, want false for a missing required parameter")
+	}
+}
+
+func TestRegistry_Execute_GoValues(t *testing.T) {
+	registry := NewRegistry()
+	schema := llm.NewFunctionParameters().AddProperty("tags", "array", "tags to join", true)
+	registry.Register(NewFunctionTool("join", "Joins tags", schema,
+		func(ctx context.Context, params map[string]interface{}) (*Result, error) {
+			return SuccessResult(params["tags"]), nil
+		},
+	))
+
+	result, err := registry.Execute(context.Background(), "join", map[string]interface{}{
+		"tags": []string{"a", "b"},
+	})
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	if !result.Success {
+		t.Errorf("Result = %+v, want a []string accepted as an array", result)
+	}
+}
+
 func TestRegistry_Execute_NotFound(t *testing.T) {
 	registry := NewRegistry()
 
@@ -0,0 +1,52 @@
+// Copyright (C) 2025 sage-x-project
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with this program. If not, see <https://www.gnu.org/licenses/>.
+
+// SPDX-License-Identifier: LGPL-3.0-or-later
+
+// Package audit keeps a tamper-evident log of what an agent received and
+// sent.
+//
+// Every inbound and outbound message, tool call and LLM call is appended to
+// a hash chain: each Entry carries the SHA-256 hash of the previous entry
+// and its own hash over its contents. Periodically, and after every
+// BatchSize entries, the log appends a checkpoint entry signing the chain
+// head with the agent's SAGE key. Editing, inserting or removing an entry
+// breaks the chain; rewriting the whole chain requires the signing key.
+//
+//	sink, err := audit.OpenFile("audit.log")
+//	log, err := audit.New(ctx, sink, &audit.Config{
+//	    Agent:  "did:sage:ethereum:0xabc",
+//	    Key:    sageAdapter.SigningKey,
+//	    Redact: []string{"message.parts.text", "tool.arguments.password"},
+//	})
+//	go log.Run(ctx)
+//	defer log.Close(ctx)
+//
+//	chain := middleware.NewChain(log.Middleware())
+//	executorConfig.Auditor = log
+//	provider = log.WrapProvider(provider)
+//
+// Entries are written to a Sink: a JSON-lines file (OpenFile) or any
+// storage.Storage backend (NewStorageSink). Configured fields are redacted
+// before hashing, so the log proves that a message was exchanged without
+// keeping its confidential parts.
+//
+// Verify and VerifySink check a log and report gaps, broken links, altered
+// entries, invalid signatures and entries not yet covered by a checkpoint.
+// With keys, checkpoints must be signed by the entries' agent, and a log
+// without valid checkpoints, or with entries left unsigned for longer than
+// the batch interval, fails verification. The adk audit verify command
+// does the same for log files.
+package audit
@@ -0,0 +1,178 @@
+// Copyright (C) 2025 sage-x-project
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with this program. If not, see <https://www.gnu.org/licenses/>.
+
+// SPDX-License-Identifier: LGPL-3.0-or-later
+
+package audit
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Kind classifies audit entries.
+type Kind string
+
+const (
+	// KindMessageIn records a message the agent received.
+	KindMessageIn Kind = "message.in"
+
+	// KindMessageOut records a message the agent sent.
+	KindMessageOut Kind = "message.out"
+
+	// KindToolCall records a tool call and its outcome.
+	KindToolCall Kind = "tool.call"
+
+	// KindLLMCall records an LLM request and its response.
+	KindLLMCall Kind = "llm.call"
+
+	// KindCheckpoint records a signature over the chain head.
+	KindCheckpoint Kind = "checkpoint"
+)
+
+// GenesisHash is the previous hash of the first entry.
+var GenesisHash = strings.Repeat("0", sha256.Size*2)
+
+// signatureContext prefixes the data checkpoint signatures cover.
+const signatureContext = "sage-adk-audit-v1"
+
+// Entry is one link of the audit chain.
+type Entry struct {
+	// Seq numbers entries from 1 without gaps.
+	Seq uint64 `json:"seq"`
+
+	// Time is when the entry was recorded.
+	Time time.Time `json:"time"`
+
+	// Agent identifies the recording agent, typically its DID.
+	Agent string `json:"agent,omitempty"`
+
+	// Kind classifies the entry.
+	Kind Kind `json:"kind"`
+
+	// Data holds the recorded event, after redaction.
+	Data map[string]interface{} `json:"data,omitempty"`
+
+	// PrevHash is the Hash of the previous entry, or GenesisHash.
+	PrevHash string `json:"prev_hash"`
+
+	// Hash is the hex SHA-256 hash of the entry's other fields.
+	Hash string `json:"hash"`
+}
+
+// Checkpoint is the data of a KindCheckpoint entry.
+type Checkpoint struct {
+	// Covers is the sequence number of the signed chain head, the entry
+	// right before the checkpoint.
+	Covers uint64 `json:"covers"`
+
+	// KeyID identifies the signing key ("did#key-2").
+	KeyID string `json:"key_id"`
+
+	// Signature is the Ed25519 signature over SignedData.
+	Signature []byte `json:"signature"`
+}
+
+// ComputeHash returns the hash an entry should carry. It covers every field
+// except Hash, in a canonical JSON encoding, so entries can be re-encoded by
+// storage backends without changing their hash.
+func (e *Entry) ComputeHash() (string, error) {
+	body := struct {
+		Seq      uint64                 `json:"seq"`
+		Time     time.Time              `json:"time"`
+		Agent    string                 `json:"agent,omitempty"`
+		Kind     Kind                   `json:"kind"`
+		Data     map[string]interface{} `json:"data,omitempty"`
+		PrevHash string                 `json:"prev_hash"`
+	}{e.Seq, e.Time, e.Agent, e.Kind, e.Data, e.PrevHash}
+
+	data, err := canonicalJSON(body)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// Checkpoint decodes the data of a checkpoint entry.
+func (e *Entry) Checkpoint() (*Checkpoint, error) {
+	if e.Kind != KindCheckpoint {
+		return nil, fmt.Errorf("entry %d is a %s entry, not a checkpoint", e.Seq, e.Kind)
+	}
+
+	var cp Checkpoint
+	if err := convert(e.Data, &cp); err != nil {
+		return nil, err
+	}
+	return &cp, nil
+}
+
+// SignedData returns the bytes a checkpoint signs: the agent, the sequence
+// number and hash of the chain head.
+func SignedData(agent string, covers uint64, head string) []byte {
+	return []byte(fmt.Sprintf("%s\n%s\n%d\n%s", signatureContext, agent, covers, head))
+}
+
+// canonicalJSON encodes v as JSON with sorted object keys and numbers
+// exactly as decoded.
+func canonicalJSON(v interface{}) ([]byte, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	var generic interface{}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	if err := decoder.Decode(&generic); err != nil {
+		return nil, err
+	}
+	return json.Marshal(generic)
+}
+
+// toData converts an event into the generic form entries hold, the same
+// form it has after a round trip through storage.
+func toData(v interface{}) (map[string]interface{}, error) {
+	if v == nil {
+		return nil, nil
+	}
+
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	var out map[string]interface{}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	if err := decoder.Decode(&out); err != nil {
+		return nil, fmt.Errorf("audit data must be a JSON object: %w", err)
+	}
+	return out, nil
+}
+
+// convert re-decodes generic data into a typed value.
+func convert(in, out interface{}) error {
+	data, err := json.Marshal(in)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, out)
+}
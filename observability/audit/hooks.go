@@ -0,0 +1,170 @@
+// Copyright (C) 2025 sage-x-project
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with this program. If not, see <https://www.gnu.org/licenses/>.
+
+// SPDX-License-Identifier: LGPL-3.0-or-later
+
+package audit
+
+import (
+	"context"
+	"strings"
+
+	"github.com/sage-x-project/sage-adk/adapters/llm"
+	"github.com/sage-x-project/sage-adk/core/middleware"
+	"github.com/sage-x-project/sage-adk/core/tools"
+	"github.com/sage-x-project/sage-adk/pkg/types"
+)
+
+// Middleware records every inbound message before it reaches the handler,
+// and the handler's response or error. A message that cannot be recorded
+// is rejected, so nothing is processed without an audit trail.
+func (l *Log) Middleware() middleware.Middleware {
+	return func(next middleware.Handler) middleware.Handler {
+		return func(ctx context.Context, msg *types.Message) (*types.Message, error) {
+			if _, err := l.Record(ctx, KindMessageIn, map[string]interface{}{"message": msg}); err != nil {
+				return nil, err
+			}
+
+			response, err := next(ctx, msg)
+
+			data := map[string]interface{}{}
+			if msg != nil {
+				data["in_reply_to"] = msg.MessageID
+			}
+			if response != nil {
+				data["message"] = response
+			}
+			if err != nil {
+				data["error"] = err.Error()
+			}
+			if _, recordErr := l.Record(ctx, KindMessageOut, data); recordErr != nil {
+				l.logError(ctx, "failed to record outbound message", recordErr)
+			}
+			return response, err
+		}
+	}
+}
+
+// RecordToolCall records a tool call and its outcome. It implements
+// tools.Auditor.
+func (l *Log) RecordToolCall(ctx context.Context, result *tools.CallResult) {
+	tool := map[string]interface{}{
+		"status":      result.Status,
+		"attempts":    result.Attempts,
+		"duration_ms": result.Duration.Milliseconds(),
+	}
+	if call := result.Call; call != nil {
+		tool["call_id"] = call.ID
+		if call.Function != nil {
+			tool["name"] = call.Function.Name
+			if args, err := call.Function.ParsedArguments(); err == nil {
+				tool["arguments"] = args
+			} else {
+				tool["arguments"] = call.Function.Arguments
+			}
+		}
+	}
+	if result.Result != nil {
+		tool["result"] = result.Result
+	}
+	if result.Err != nil {
+		tool["error"] = result.Err.Error()
+	}
+
+	if _, err := l.Record(ctx, KindToolCall, map[string]interface{}{"tool": tool}); err != nil {
+		l.logError(ctx, "failed to record tool call", err)
+	}
+}
+
+// WrapProvider returns a provider that records every completion request
+// made through provider and its response. Streamed completions are
+// recorded once the stream ends, with the concatenated chunks. Advanced
+// providers stay advanced, and tool-calling completions are recorded too.
+func (l *Log) WrapProvider(provider llm.Provider) llm.Provider {
+	p := &auditedProvider{Provider: provider, log: l}
+	if advanced, ok := provider.(llm.AdvancedProvider); ok {
+		return &auditedAdvancedProvider{auditedProvider: p, advanced: advanced}
+	}
+	return p
+}
+
+// auditedProvider records the calls of a provider.
+type auditedProvider struct {
+	llm.Provider
+	log *Log
+}
+
+// Complete implements llm.Provider.
+func (p *auditedProvider) Complete(ctx context.Context, req *llm.CompletionRequest) (*llm.CompletionResponse, error) {
+	resp, err := p.Provider.Complete(ctx, req)
+	p.record(ctx, req, resp, err)
+	return resp, err
+}
+
+// Stream implements llm.Provider.
+func (p *auditedProvider) Stream(ctx context.Context, req *llm.CompletionRequest, fn llm.StreamFunc) error {
+	var content strings.Builder
+	err := p.Provider.Stream(ctx, req, func(chunk string) error {
+		content.WriteString(chunk)
+		return fn(chunk)
+	})
+	p.record(ctx, req, map[string]interface{}{"content": content.String()}, err)
+	return err
+}
+
+// record records one LLM call.
+func (p *auditedProvider) record(ctx context.Context, req, resp interface{}, callErr error) {
+	call := map[string]interface{}{
+		"provider": p.Provider.Name(),
+		"request":  req,
+	}
+	if callErr != nil {
+		call["error"] = callErr.Error()
+	} else {
+		call["response"] = resp
+	}
+
+	if _, err := p.log.Record(ctx, KindLLMCall, map[string]interface{}{"llm": call}); err != nil {
+		p.log.logError(ctx, "failed to record LLM call", err)
+	}
+}
+
+// auditedAdvancedProvider records the calls of an advanced provider.
+type auditedAdvancedProvider struct {
+	*auditedProvider
+	advanced llm.AdvancedProvider
+}
+
+// SupportsFunctionCalling implements llm.AdvancedProvider.
+func (p *auditedAdvancedProvider) SupportsFunctionCalling() bool {
+	return p.advanced.SupportsFunctionCalling()
+}
+
+// CompleteWithTools implements llm.AdvancedProvider.
+func (p *auditedAdvancedProvider) CompleteWithTools(ctx context.Context, req *llm.CompletionRequestWithTools) (*llm.CompletionResponseWithTools, error) {
+	resp, err := p.advanced.CompleteWithTools(ctx, req)
+	p.record(ctx, req, resp, err)
+	return resp, err
+}
+
+// CountTokens implements llm.AdvancedProvider.
+func (p *auditedAdvancedProvider) CountTokens(text string) int {
+	return p.advanced.CountTokens(text)
+}
+
+// GetTokenLimit implements llm.AdvancedProvider.
+func (p *auditedAdvancedProvider) GetTokenLimit(model string) int {
+	return p.advanced.GetTokenLimit(model)
+}
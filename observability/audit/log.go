@@ -0,0 +1,288 @@
+// Copyright (C) 2025 sage-x-project
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with this program. If not, see <https://www.gnu.org/licenses/>.
+
+// SPDX-License-Identifier: LGPL-3.0-or-later
+
+package audit
+
+import (
+	"context"
+	"crypto/ed25519"
+	"sync"
+	"time"
+
+	"github.com/sage-x-project/sage-adk/observability/logging"
+	"github.com/sage-x-project/sage-adk/pkg/errors"
+)
+
+// Defaults for Config.
+const (
+	DefaultBatchSize     = 100
+	DefaultBatchInterval = time.Minute
+)
+
+// KeyFunc returns the private key to sign checkpoints with and its key ID.
+// It is called for every checkpoint, so a rotating key ring can be
+// followed. The SAGE adapter's SigningKey method is a KeyFunc.
+type KeyFunc func() (ed25519.PrivateKey, string, error)
+
+// Config configures a Log.
+type Config struct {
+	// Agent identifies the recording agent in every entry, typically its
+	// DID.
+	Agent string
+
+	// Key signs checkpoints (optional; without it the log is hash-chained
+	// but never signed).
+	Key KeyFunc
+
+	// BatchSize is the number of entries after which a checkpoint is
+	// signed (default DefaultBatchSize).
+	BatchSize int
+
+	// BatchInterval is how often Run signs pending entries (default
+	// DefaultBatchInterval).
+	BatchInterval time.Duration
+
+	// Redact lists data fields to redact before entries are hashed, as
+	// dotted paths such as "message.parts.text" or "tool.arguments.*".
+	Redact []string
+
+	// Logger logs audit failures that cannot be returned to a caller
+	// (optional).
+	Logger logging.Logger
+}
+
+// Log appends events to a hash-chained audit log. It is safe for
+// concurrent use.
+type Log struct {
+	sink     Sink
+	config   Config
+	redactor redactor
+	now      func() time.Time
+
+	mu      sync.Mutex
+	head    *Entry
+	pending int
+	closed  bool
+}
+
+// New creates a log writing to sink. If the sink already holds entries,
+// the chain continues from the last one.
+func New(ctx context.Context, sink Sink, config *Config) (*Log, error) {
+	if sink == nil {
+		return nil, errors.ErrInvalidInput.WithMessage("audit sink is required")
+	}
+
+	l := &Log{sink: sink, now: time.Now}
+	if config != nil {
+		l.config = *config
+	}
+	if l.config.BatchSize <= 0 {
+		l.config.BatchSize = DefaultBatchSize
+	}
+	if l.config.BatchInterval <= 0 {
+		l.config.BatchInterval = DefaultBatchInterval
+	}
+
+	r, err := newRedactor(l.config.Redact)
+	if err != nil {
+		return nil, err
+	}
+	l.redactor = r
+
+	entries, err := sink.Entries(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if len(entries) > 0 {
+		l.head = entries[len(entries)-1]
+		for i := len(entries) - 1; i >= 0 && entries[i].Kind != KindCheckpoint; i-- {
+			l.pending++
+		}
+	}
+	return l, nil
+}
+
+// Record appends an event of the given kind. Data must encode to a JSON
+// object; configured fields are redacted before the entry is hashed. A
+// checkpoint is signed once BatchSize entries are pending.
+func (l *Log) Record(ctx context.Context, kind Kind, data interface{}) (*Entry, error) {
+	if kind == KindCheckpoint {
+		return nil, errors.ErrInvalidInput.WithMessage("checkpoints are recorded by the log itself")
+	}
+
+	fields, err := toData(data)
+	if err != nil {
+		return nil, errors.ErrInvalidInput.
+			WithMessage("invalid audit data").
+			WithDetail("error", err.Error())
+	}
+	l.redactor.apply(fields)
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	entry, err := l.appendLocked(ctx, kind, fields)
+	if err != nil {
+		return nil, err
+	}
+
+	l.pending++
+	if l.config.Key != nil && l.pending >= l.config.BatchSize {
+		if err := l.checkpointLocked(ctx); err != nil {
+			return entry, err
+		}
+	}
+	return entry, nil
+}
+
+// Checkpoint signs the chain head if entries are pending. It requires
+// Config.Key.
+func (l *Log) Checkpoint(ctx context.Context) error {
+	if l.config.Key == nil {
+		return errors.ErrConfigurationError.WithMessage("audit log has no signing key")
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	return l.checkpointLocked(ctx)
+}
+
+// Run signs pending entries every BatchInterval until ctx is done. It
+// blocks; run it in its own goroutine.
+func (l *Log) Run(ctx context.Context) {
+	if l.config.Key == nil {
+		return
+	}
+
+	ticker := time.NewTicker(l.config.BatchInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		if err := l.Checkpoint(ctx); err != nil {
+			l.logError(ctx, "audit checkpoint failed", err)
+		}
+	}
+}
+
+// Close signs pending entries, if a key is configured, and closes the
+// sink. Later records fail.
+func (l *Log) Close(ctx context.Context) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.closed {
+		return nil
+	}
+
+	var err error
+	if l.config.Key != nil {
+		err = l.checkpointLocked(ctx)
+	}
+	l.closed = true
+	if closeErr := l.sink.Close(); err == nil {
+		err = closeErr
+	}
+	return err
+}
+
+// Head returns the last entry, or nil if the log is empty.
+func (l *Log) Head() *Entry {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	return l.head
+}
+
+// checkpointLocked appends a checkpoint signing the current head.
+func (l *Log) checkpointLocked(ctx context.Context) error {
+	if l.pending == 0 || l.head == nil {
+		return nil
+	}
+
+	key, keyID, err := l.config.Key()
+	if err != nil {
+		return errors.ErrOperationFailed.
+			WithMessage("failed to get audit signing key").
+			WithDetail("error", err.Error())
+	}
+	if len(key) != ed25519.PrivateKeySize {
+		return errors.ErrConfigurationError.WithMessage("invalid audit signing key")
+	}
+
+	cp := Checkpoint{
+		Covers:    l.head.Seq,
+		KeyID:     keyID,
+		Signature: ed25519.Sign(key, SignedData(l.config.Agent, l.head.Seq, l.head.Hash)),
+	}
+	fields, err := toData(cp)
+	if err != nil {
+		return err
+	}
+
+	if _, err := l.appendLocked(ctx, KindCheckpoint, fields); err != nil {
+		return err
+	}
+	l.pending = 0
+	return nil
+}
+
+// appendLocked links, hashes and writes an entry.
+func (l *Log) appendLocked(ctx context.Context, kind Kind, data map[string]interface{}) (*Entry, error) {
+	if l.closed {
+		return nil, errors.ErrOperationFailed.WithMessage("audit log is closed")
+	}
+
+	entry := &Entry{
+		Seq:      1,
+		Time:     l.now().UTC().Round(0),
+		Agent:    l.config.Agent,
+		Kind:     kind,
+		Data:     data,
+		PrevHash: GenesisHash,
+	}
+	if l.head != nil {
+		entry.Seq = l.head.Seq + 1
+		entry.PrevHash = l.head.Hash
+	}
+
+	hash, err := entry.ComputeHash()
+	if err != nil {
+		return nil, errors.ErrOperationFailed.
+			WithMessage("failed to hash audit entry").
+			WithDetail("error", err.Error())
+	}
+	entry.Hash = hash
+
+	if err := l.sink.Append(ctx, entry); err != nil {
+		return nil, err
+	}
+	l.head = entry
+	return entry, nil
+}
+
+func (l *Log) logError(ctx context.Context, msg string, err error) {
+	if l.config.Logger != nil {
+		l.config.Logger.Error(ctx, msg, logging.Error(err))
+	}
+}
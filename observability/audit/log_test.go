@@ -0,0 +1,291 @@
+// Copyright (C) 2025 sage-x-project
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with this program. If not, see <https://www.gnu.org/licenses/>.
+
+// SPDX-License-Identifier: LGPL-3.0-or-later
+
+package audit
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/json"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sage-x-project/sage-adk/adapters/llm"
+	"github.com/sage-x-project/sage-adk/core/tools"
+	"github.com/sage-x-project/sage-adk/pkg/types"
+	"github.com/sage-x-project/sage-adk/storage"
+)
+
+const testAgent = "did:sage:local:alice"
+
+func newKey(t *testing.T) (ed25519.PublicKey, KeyFunc) {
+	t.Helper()
+
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return pub, func() (ed25519.PrivateKey, string, error) {
+		return priv, testAgent + "#key-1", nil
+	}
+}
+
+func TestLog_FileChainAndVerify(t *testing.T) {
+	ctx := context.Background()
+	file := filepath.Join(t.TempDir(), "audit.log")
+	pub, key := newKey(t)
+
+	sink, err := OpenFile(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	log, err := New(ctx, sink, &Config{Agent: testAgent, Key: key, BatchSize: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for i := 0; i < 3; i++ {
+		if _, err := log.Record(ctx, KindMessageIn, map[string]interface{}{"n": i, "big": uint64(1) << 60}); err != nil {
+			t.Fatalf("Record() error = %v", err)
+		}
+	}
+
+	// Entries 1-2, checkpoint 3, entry 4 pending.
+	report, err := VerifySink(ctx, sink, StaticKey(pub), 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !report.OK() || report.Entries != 4 || report.Checkpoints != 1 || report.SignedThrough != 3 || report.Unsigned != 1 {
+		t.Fatalf("report = %+v", report)
+	}
+
+	if err := log.Close(ctx); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+
+	// Reopening continues the chain.
+	sink, err = OpenFile(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	log, err = New(ctx, sink, &Config{Agent: testAgent, Key: key})
+	if err != nil {
+		t.Fatal(err)
+	}
+	entry, err := log.Record(ctx, KindMessageOut, map[string]interface{}{"ok": true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if entry.Seq != 6 {
+		t.Errorf("Seq = %d, want 6", entry.Seq)
+	}
+	log.Close(ctx)
+
+	entries, err := ReadFile(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if report := Verify(entries, StaticKey(pub), 0); !report.OK() || report.Unsigned != 0 {
+		t.Fatalf("report = %+v", report)
+	}
+}
+
+func TestVerify_DetectsTampering(t *testing.T) {
+	ctx := context.Background()
+	pub, key := newKey(t)
+	_, otherKey := newKey(t)
+
+	build := func(key KeyFunc) []*Entry {
+		sink := NewStorageSink(storage.NewMemoryStorage(), "")
+		log, err := New(ctx, sink, &Config{Agent: testAgent, Key: key, BatchSize: 3})
+		if err != nil {
+			t.Fatal(err)
+		}
+		for i := 0; i < 3; i++ {
+			if _, err := log.Record(ctx, KindToolCall, map[string]interface{}{"n": i}); err != nil {
+				t.Fatal(err)
+			}
+		}
+		entries, err := sink.Entries(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return entries
+	}
+
+	tests := []struct {
+		name   string
+		key    KeyFunc
+		tamper func([]*Entry) []*Entry
+		want   ProblemType
+	}{
+		{"edited data", key, func(e []*Entry) []*Entry {
+			e[1].Data["n"] = json.Number("7")
+			return e
+		}, ProblemHash},
+		{"removed entry", key, func(e []*Entry) []*Entry {
+			return append(e[:1], e[2:]...)
+		}, ProblemGap},
+		{"rewritten entry", key, func(e []*Entry) []*Entry {
+			e[1].Data["n"] = json.Number("7")
+			e[1].Hash, _ = e[1].ComputeHash()
+			return e
+		}, ProblemChain},
+		{"foreign signature", otherKey, func(e []*Entry) []*Entry {
+			return e
+		}, ProblemSignature},
+		{"removed checkpoint", key, func(e []*Entry) []*Entry {
+			return e[:3]
+		}, ProblemUnsigned},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			report := Verify(tt.tamper(build(tt.key)), StaticKey(pub), 0)
+			if report.OK() || report.Problems[0].Type != tt.want {
+				t.Errorf("Problems = %v, want %s", report.Problems, tt.want)
+			}
+		})
+	}
+}
+
+func TestVerify_RequiresAgentKey(t *testing.T) {
+	ctx := context.Background()
+	malloryPub, malloryPriv, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// A log rewritten and signed with another registered DID's key
+	sink := NewStorageSink(storage.NewMemoryStorage(), "")
+	log, err := New(ctx, sink, &Config{Agent: testAgent, BatchSize: 1,
+		Key: func() (ed25519.PrivateKey, string, error) {
+			return malloryPriv, "did:sage:local:mallory#key-1", nil
+		}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := log.Record(ctx, KindToolCall, map[string]interface{}{"n": 1}); err != nil {
+		t.Fatal(err)
+	}
+
+	entries, _ := sink.Entries(ctx)
+	report := Verify(entries, StaticKey(malloryPub), 0)
+	if report.OK() || report.Problems[0].Type != ProblemSignature {
+		t.Errorf("Problems = %v, want %s", report.Problems, ProblemSignature)
+	}
+}
+
+func TestVerify_StaleUnsignedEntries(t *testing.T) {
+	ctx := context.Background()
+	pub, key := newKey(t)
+
+	sink := NewStorageSink(storage.NewMemoryStorage(), "")
+	log, err := New(ctx, sink, &Config{Agent: testAgent, Key: key, BatchSize: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	log.now = func() time.Time { return time.Now().Add(-time.Hour) }
+	for i := 0; i < 3; i++ {
+		if _, err := log.Record(ctx, KindToolCall, map[string]interface{}{"n": i}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// Entry 4 should have been signed within the batch interval
+	entries, _ := sink.Entries(ctx)
+	report := Verify(entries, StaticKey(pub), time.Minute)
+	if report.OK() || report.Problems[0].Type != ProblemUnsigned || report.Problems[0].Seq != 4 {
+		t.Errorf("Problems = %v, want entry 4 %s", report.Problems, ProblemUnsigned)
+	}
+	if report := Verify(entries, StaticKey(pub), 2*time.Hour); !report.OK() {
+		t.Errorf("Problems within the interval = %v", report.Problems)
+	}
+
+	// Without keys, signing is not checked
+	if report := Verify(entries, nil, time.Minute); !report.OK() {
+		t.Errorf("Problems without keys = %v", report.Problems)
+	}
+}
+
+func TestLog_Redact(t *testing.T) {
+	ctx := context.Background()
+	sink := NewStorageSink(storage.NewMemoryStorage(), "")
+	log, err := New(ctx, sink, &Config{Redact: []string{"message.parts.text", "tool.arguments.*word"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	msg := types.NewMessage(types.MessageRoleUser, []types.Part{types.NewTextPart("secret plan")})
+	handler := log.Middleware()(func(ctx context.Context, msg *types.Message) (*types.Message, error) {
+		return msg, nil
+	})
+	if _, err := handler(ctx, msg); err != nil {
+		t.Fatal(err)
+	}
+
+	log.RecordToolCall(ctx, &tools.CallResult{
+		Call: &llm.ToolCall{ID: "1", Type: "function", Function: &llm.FunctionCall{
+			Name: "login", Arguments: `{"user":"bob","password":"hunter2"}`,
+		}},
+		Status: tools.StatusSuccess,
+	})
+
+	entries, _ := sink.Entries(ctx)
+	if len(entries) != 3 {
+		t.Fatalf("len(entries) = %d, want 3", len(entries))
+	}
+	data, _ := json.Marshal(entries)
+	if strings.Contains(string(data), "secret plan") || strings.Contains(string(data), "hunter2") {
+		t.Errorf("entries not redacted: %s", data)
+	}
+	if !strings.Contains(string(data), `"user":"bob"`) {
+		t.Errorf("entries over-redacted: %s", data)
+	}
+	if report := Verify(entries, nil, 0); !report.OK() {
+		t.Errorf("Problems = %v", report.Problems)
+	}
+
+	if _, err := New(ctx, sink, &Config{Redact: []string{"a..b"}}); err == nil {
+		t.Error("New() with invalid redaction field error = nil")
+	}
+}
+
+func TestLog_WrapProvider(t *testing.T) {
+	ctx := context.Background()
+	sink := NewStorageSink(storage.NewMemoryStorage(), "")
+	log, err := New(ctx, sink, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	provider := log.WrapProvider(llm.NewMockProvider("mock", []string{"hello"}))
+	if _, ok := provider.(llm.AdvancedProvider); !ok {
+		t.Error("wrapped provider is not advanced")
+	}
+	if _, err := provider.Complete(ctx, &llm.CompletionRequest{Model: "m"}); err != nil {
+		t.Fatal(err)
+	}
+
+	entries, _ := sink.Entries(ctx)
+	if len(entries) != 1 || entries[0].Kind != KindLLMCall {
+		t.Fatalf("entries = %+v", entries)
+	}
+}
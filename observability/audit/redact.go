@@ -0,0 +1,79 @@
+// Copyright (C) 2025 sage-x-project
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with this program. If not, see <https://www.gnu.org/licenses/>.
+
+// SPDX-License-Identifier: LGPL-3.0-or-later
+
+package audit
+
+import (
+	"path"
+	"strings"
+
+	"github.com/sage-x-project/sage-adk/pkg/errors"
+)
+
+// Redacted replaces the value of redacted fields.
+const Redacted = "[REDACTED]"
+
+// redactor removes configured fields from entry data.
+//
+// Fields are dotted paths into the data ("message.parts.text"); each
+// segment may be a path.Match pattern ("*.password"). Arrays are traversed
+// transparently, so "message.parts.text" reaches the text of every part.
+type redactor [][]string
+
+// newRedactor compiles field paths.
+func newRedactor(fields []string) (redactor, error) {
+	r := make(redactor, 0, len(fields))
+	for _, field := range fields {
+		segments := strings.Split(field, ".")
+		for _, segment := range segments {
+			if _, err := path.Match(segment, ""); err != nil || segment == "" {
+				return nil, errors.ErrInvalidValue.
+					WithMessage("invalid redaction field").
+					WithDetail("field", field)
+			}
+		}
+		r = append(r, segments)
+	}
+	return r, nil
+}
+
+// apply redacts data in place.
+func (r redactor) apply(data map[string]interface{}) {
+	for _, segments := range r {
+		redactValue(data, segments)
+	}
+}
+
+func redactValue(value interface{}, segments []string) {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, child := range v {
+			if ok, _ := path.Match(segments[0], key); !ok {
+				continue
+			}
+			if len(segments) == 1 {
+				v[key] = Redacted
+				continue
+			}
+			redactValue(child, segments[1:])
+		}
+	case []interface{}:
+		for _, item := range v {
+			redactValue(item, segments)
+		}
+	}
+}
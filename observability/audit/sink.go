@@ -0,0 +1,225 @@
+// Copyright (C) 2025 sage-x-project
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with this program. If not, see <https://www.gnu.org/licenses/>.
+
+// SPDX-License-Identifier: LGPL-3.0-or-later
+
+package audit
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"sync"
+
+	"github.com/sage-x-project/sage-adk/pkg/errors"
+	"github.com/sage-x-project/sage-adk/storage"
+)
+
+// Sink persists audit entries.
+type Sink interface {
+	// Append writes an entry after all previous ones.
+	Append(ctx context.Context, entry *Entry) error
+
+	// Entries returns all entries in sequence order.
+	Entries(ctx context.Context) ([]*Entry, error)
+
+	// Close releases the sink.
+	Close() error
+}
+
+// FileSink appends entries to a file, one JSON object per line. Every
+// entry is synced to disk before Append returns.
+type FileSink struct {
+	path string
+	file *os.File
+	mu   sync.Mutex
+}
+
+// OpenFile opens or creates an audit log file for appending.
+func OpenFile(path string) (*FileSink, error) {
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o600)
+	if err != nil {
+		return nil, errors.ErrOperationFailed.
+			WithMessage("failed to open audit log").
+			WithDetail("path", path).
+			WithDetail("error", err.Error())
+	}
+	return &FileSink{path: path, file: file}, nil
+}
+
+// Append implements Sink.
+func (s *FileSink) Append(ctx context.Context, entry *Entry) error {
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return errors.ErrOperationFailed.
+			WithMessage("failed to encode audit entry").
+			WithDetail("error", err.Error())
+	}
+	data = append(data, '\n')
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, err := s.file.Write(data); err != nil {
+		return errors.ErrOperationFailed.
+			WithMessage("failed to write audit entry").
+			WithDetail("path", s.path).
+			WithDetail("error", err.Error())
+	}
+	if err := s.file.Sync(); err != nil {
+		return errors.ErrOperationFailed.
+			WithMessage("failed to sync audit log").
+			WithDetail("path", s.path).
+			WithDetail("error", err.Error())
+	}
+	return nil
+}
+
+// Entries implements Sink.
+func (s *FileSink) Entries(ctx context.Context) ([]*Entry, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return ReadFile(s.path)
+}
+
+// Close implements Sink.
+func (s *FileSink) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.file.Close()
+}
+
+// ReadFile reads the entries of an audit log file in file order.
+func ReadFile(path string) ([]*Entry, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, errors.ErrOperationFailed.
+			WithMessage("failed to open audit log").
+			WithDetail("path", path).
+			WithDetail("error", err.Error())
+	}
+	defer file.Close()
+
+	var entries []*Entry
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 64*1024), 64*1024*1024)
+	for line := 1; scanner.Scan(); line++ {
+		if len(bytes.TrimSpace(scanner.Bytes())) == 0 {
+			continue
+		}
+		entry, err := decodeEntry(scanner.Bytes())
+		if err != nil {
+			return nil, errors.ErrInvalidFormat.
+				WithMessage("malformed audit entry").
+				WithDetail("path", path).
+				WithDetail("line", line).
+				WithDetail("error", err.Error())
+		}
+		entries = append(entries, entry)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, errors.ErrOperationFailed.
+			WithMessage("failed to read audit log").
+			WithDetail("path", path).
+			WithDetail("error", err.Error())
+	}
+	return entries, nil
+}
+
+// DefaultNamespace is the storage namespace used by NewStorageSink.
+const DefaultNamespace = "audit_log"
+
+// StorageSink keeps entries in a storage.Storage namespace, keyed by
+// zero-padded sequence number.
+type StorageSink struct {
+	storage   storage.Storage
+	namespace string
+}
+
+// NewStorageSink creates a sink on store. An empty namespace uses
+// DefaultNamespace.
+func NewStorageSink(store storage.Storage, namespace string) *StorageSink {
+	if namespace == "" {
+		namespace = DefaultNamespace
+	}
+	return &StorageSink{storage: store, namespace: namespace}
+}
+
+// Append implements Sink.
+func (s *StorageSink) Append(ctx context.Context, entry *Entry) error {
+	return s.storage.Store(ctx, s.namespace, fmt.Sprintf("%020d", entry.Seq), *entry)
+}
+
+// Entries implements Sink.
+func (s *StorageSink) Entries(ctx context.Context) ([]*Entry, error) {
+	items, err := s.storage.List(ctx, s.namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make([]*Entry, 0, len(items))
+	for _, item := range items {
+		entry, err := decodeItem(item)
+		if err != nil {
+			return nil, errors.ErrInvalidFormat.
+				WithMessage("malformed audit entry").
+				WithDetail("error", err.Error())
+		}
+		entries = append(entries, entry)
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Seq < entries[j].Seq })
+	return entries, nil
+}
+
+// Close implements Sink. The storage backend is left open.
+func (s *StorageSink) Close() error {
+	return nil
+}
+
+// decodeEntry decodes an entry, keeping numbers in its data exact.
+func decodeEntry(data []byte) (*Entry, error) {
+	var entry Entry
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	if err := decoder.Decode(&entry); err != nil {
+		return nil, err
+	}
+	return &entry, nil
+}
+
+// decodeItem converts a stored item back into an Entry. Backends that
+// serialize values return generic JSON data rather than the struct.
+func decodeItem(item interface{}) (*Entry, error) {
+	switch v := item.(type) {
+	case Entry:
+		return &v, nil
+	case []byte:
+		return decodeEntry(v)
+	case string:
+		return decodeEntry([]byte(v))
+	}
+
+	data, err := json.Marshal(item)
+	if err != nil {
+		return nil, err
+	}
+	return decodeEntry(data)
+}
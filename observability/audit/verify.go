@@ -0,0 +1,220 @@
+// Copyright (C) 2025 sage-x-project
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with this program. If not, see <https://www.gnu.org/licenses/>.
+
+// SPDX-License-Identifier: LGPL-3.0-or-later
+
+package audit
+
+import (
+	"context"
+	"crypto/ed25519"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// KeyResolver returns the public key for a checkpoint's key ID, as valid
+// when the checkpoint was recorded.
+type KeyResolver func(keyID string, at time.Time) (ed25519.PublicKey, error)
+
+// StaticKey returns a KeyResolver that verifies every checkpoint with key.
+func StaticKey(key ed25519.PublicKey) KeyResolver {
+	return func(string, time.Time) (ed25519.PublicKey, error) {
+		return key, nil
+	}
+}
+
+// ProblemType classifies verification problems.
+type ProblemType string
+
+const (
+	// ProblemGap means sequence numbers are missing or out of order.
+	ProblemGap ProblemType = "gap"
+
+	// ProblemChain means an entry does not link to its predecessor.
+	ProblemChain ProblemType = "chain"
+
+	// ProblemHash means an entry's contents do not match its hash.
+	ProblemHash ProblemType = "hash"
+
+	// ProblemSignature means a checkpoint is malformed, signed by a key
+	// of another agent, or its signature does not verify.
+	ProblemSignature ProblemType = "signature"
+
+	// ProblemUnsigned means the log has no valid checkpoint, or entries
+	// have been left unsigned for longer than the batch interval.
+	ProblemUnsigned ProblemType = "unsigned"
+)
+
+// Problem is an integrity violation found by Verify.
+type Problem struct {
+	// Seq is the sequence number of the offending entry.
+	Seq uint64
+
+	// Type classifies the problem.
+	Type ProblemType
+
+	// Detail explains the problem.
+	Detail string
+}
+
+// String formats the problem for display.
+func (p Problem) String() string {
+	return fmt.Sprintf("entry %d: %s: %s", p.Seq, p.Type, p.Detail)
+}
+
+// Report is the result of verifying an audit log.
+type Report struct {
+	// Entries is the number of entries checked.
+	Entries int
+
+	// Checkpoints is the number of valid signed checkpoints.
+	Checkpoints int
+
+	// SignedThrough is the sequence number of the last entry covered by
+	// a valid checkpoint.
+	SignedThrough uint64
+
+	// Unsigned is the number of entries after the last valid checkpoint.
+	Unsigned int
+
+	// Problems lists every integrity violation found.
+	Problems []Problem
+}
+
+// OK reports whether no problems were found. Recent unsigned trailing
+// entries are not a problem: they are awaiting the next checkpoint.
+func (r *Report) OK() bool {
+	return len(r.Problems) == 0
+}
+
+// Verify checks the chain links, hashes and sequence numbers of entries
+// and, with keys, the signatures of checkpoints. Without keys, checkpoints
+// are not counted as signed.
+//
+// With keys, checkpoints must be signed with a key of the entry's agent,
+// and the log must be signed: a log without a valid checkpoint, or with
+// entries unsigned for longer than batchInterval (the log's
+// Config.BatchInterval, default DefaultBatchInterval), fails with
+// ProblemUnsigned.
+func Verify(entries []*Entry, keys KeyResolver, batchInterval time.Duration) *Report {
+	report := &Report{Entries: len(entries)}
+
+	var prev *Entry
+	for _, entry := range entries {
+		report.check(prev, entry, keys)
+		prev = entry
+	}
+
+	var oldestUnsigned *Entry
+	for _, entry := range entries {
+		if entry.Seq > report.SignedThrough {
+			report.Unsigned++
+			if oldestUnsigned == nil {
+				oldestUnsigned = entry
+			}
+		}
+	}
+
+	if keys == nil || len(entries) == 0 {
+		return report
+	}
+	if batchInterval <= 0 {
+		batchInterval = DefaultBatchInterval
+	}
+	switch {
+	case report.Checkpoints == 0:
+		report.problem(entries[len(entries)-1].Seq, ProblemUnsigned, "log has no valid checkpoint")
+	case oldestUnsigned != nil && time.Since(oldestUnsigned.Time) > batchInterval:
+		report.problem(oldestUnsigned.Seq, ProblemUnsigned,
+			fmt.Sprintf("entry is unsigned after %s", time.Since(oldestUnsigned.Time).Round(time.Second)))
+	}
+	return report
+}
+
+// VerifySink verifies all entries of a sink.
+func VerifySink(ctx context.Context, sink Sink, keys KeyResolver, batchInterval time.Duration) (*Report, error) {
+	entries, err := sink.Entries(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return Verify(entries, keys, batchInterval), nil
+}
+
+// check verifies entry against its predecessor.
+func (r *Report) check(prev, entry *Entry, keys KeyResolver) {
+	wantSeq, wantPrev := uint64(1), GenesisHash
+	if prev != nil {
+		wantSeq, wantPrev = prev.Seq+1, prev.Hash
+	}
+
+	if entry.Seq != wantSeq {
+		r.problem(entry.Seq, ProblemGap, fmt.Sprintf("expected entry %d", wantSeq))
+	}
+	if entry.PrevHash != wantPrev {
+		r.problem(entry.Seq, ProblemChain, "previous hash does not match the preceding entry")
+	}
+
+	hash, err := entry.ComputeHash()
+	if err != nil {
+		r.problem(entry.Seq, ProblemHash, err.Error())
+		return
+	}
+	if hash != entry.Hash {
+		r.problem(entry.Seq, ProblemHash, "contents do not match the entry hash")
+		return
+	}
+
+	if entry.Kind != KindCheckpoint || keys == nil {
+		return
+	}
+
+	cp, err := entry.Checkpoint()
+	if err != nil {
+		r.problem(entry.Seq, ProblemSignature, err.Error())
+		return
+	}
+	if prev == nil || cp.Covers != prev.Seq {
+		r.problem(entry.Seq, ProblemSignature, fmt.Sprintf("checkpoint covers entry %d, not its predecessor", cp.Covers))
+		return
+	}
+
+	// A key of another DID, even a registered one, does not sign for the
+	// agent
+	if did, _, _ := strings.Cut(cp.KeyID, "#"); did != entry.Agent {
+		r.problem(entry.Seq, ProblemSignature, fmt.Sprintf("key %s does not belong to agent %s", cp.KeyID, entry.Agent))
+		return
+	}
+
+	key, err := keys(cp.KeyID, entry.Time)
+	if err == nil && len(key) != ed25519.PublicKeySize {
+		err = fmt.Errorf("invalid public key size %d", len(key))
+	}
+	if err != nil {
+		r.problem(entry.Seq, ProblemSignature, fmt.Sprintf("key %s: %v", cp.KeyID, err))
+		return
+	}
+	if !ed25519.Verify(key, SignedData(entry.Agent, cp.Covers, prev.Hash), cp.Signature) {
+		r.problem(entry.Seq, ProblemSignature, fmt.Sprintf("invalid signature by %s", cp.KeyID))
+		return
+	}
+
+	r.Checkpoints++
+	r.SignedThrough = entry.Seq
+}
+
+func (r *Report) problem(seq uint64, typ ProblemType, detail string) {
+	r.Problems = append(r.Problems, Problem{Seq: seq, Type: typ, Detail: detail})
+}
@@ -74,6 +74,14 @@
 //	http.Handle("/health/live", health.Handler(liveness))
 //	http.Handle("/health/ready", health.Handler(readiness))
 //
+// # Audit Log
+//
+// Tamper-evident, hash-chained record of messages, tool calls and LLM
+// calls, with checkpoints signed by the agent's SAGE key:
+//
+//	auditLog, _ := audit.New(ctx, sink, &audit.Config{Key: adapter.SigningKey})
+//	chain := middleware.NewChain(auditLog.Middleware())
+//
 // # Integration with Agent
 //
 //	agent := builder.NewAgent("monitored-agent").
@@ -34,11 +34,12 @@ const (
 	MetricCPUUsage         = "sage_agent_cpu_usage_percent"
 
 	// Protocol metrics
-	MetricProtocolRequests  = "sage_agent_protocol_requests_total"
-	MetricProtocolErrors    = "sage_agent_protocol_errors_total"
-	MetricHandshakeTime     = "sage_agent_handshake_duration_seconds"
-	MetricSigningTime       = "sage_agent_signing_duration_seconds"
-	MetricVerificationTime  = "sage_agent_verification_duration_seconds"
+	MetricProtocolRequests   = "sage_agent_protocol_requests_total"
+	MetricProtocolErrors     = "sage_agent_protocol_errors_total"
+	MetricHandshakeTime      = "sage_agent_handshake_duration_seconds"
+	MetricSigningTime        = "sage_agent_signing_duration_seconds"
+	MetricVerificationTime   = "sage_agent_verification_duration_seconds"
+	MetricProtocolDowngrades = "sage_agent_protocol_downgrades_total"
 )
 
 // AgentMetrics provides agent-specific metrics.
@@ -126,6 +127,16 @@ func (m *AgentMetrics) RecordProtocolError(agentID, protocol, errorType string)
 	m.collector.IncrementCounter(MetricProtocolErrors, labels)
 }
 
+// RecordProtocolDowngrade records a fallback from SAGE to A2A. Peers are
+// not labeled, to keep the number of series bounded.
+func (m *AgentMetrics) RecordProtocolDowngrade(agentID, reason string) {
+	labels := NewLabels(
+		"agent_id", agentID,
+		"reason", reason,
+	)
+	m.collector.IncrementCounter(MetricProtocolDowngrades, labels)
+}
+
 // RecordHandshakeTime records SAGE handshake duration.
 func (m *AgentMetrics) RecordHandshakeTime(agentID string, duration float64) {
 	labels := NewLabels("agent_id", agentID)
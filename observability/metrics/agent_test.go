@@ -173,6 +173,7 @@ func TestProtocolMetrics(t *testing.T) {
 	agentMetrics.RecordHandshakeTime("agent-1", 0.123)
 	agentMetrics.RecordSigningTime("agent-1", 0.005)
 	agentMetrics.RecordVerificationTime("agent-1", 0.003)
+	agentMetrics.RecordProtocolDowngrade("agent-1", "peer_unsupported")
 
 	// Verify metrics
 	req := httptest.NewRequest("GET", "/metrics", nil)
@@ -201,6 +202,10 @@ func TestProtocolMetrics(t *testing.T) {
 		t.Error("sage_agent_verification_duration_seconds metric not found")
 	}
 
+	if !strings.Contains(body, "sage_agent_protocol_downgrades_total") {
+		t.Error("sage_agent_protocol_downgrades_total metric not found")
+	}
+
 	if !strings.Contains(body, `operation="handshake"`) {
 		t.Error("operation label not found")
 	}
@@ -0,0 +1,67 @@
+// Copyright (C) 2025 sage-x-project
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with this program. If not, see <https://www.gnu.org/licenses/>.
+
+// SPDX-License-Identifier: LGPL-3.0-or-later
+
+package metrics
+
+const (
+	// Tool execution metrics
+	MetricToolExecutions       = "sage_tool_executions_total"
+	MetricToolExecutionLatency = "sage_tool_execution_latency_seconds"
+	MetricToolRetries          = "sage_tool_retries_total"
+	MetricToolDenied           = "sage_tool_denied_total"
+	MetricToolInFlight         = "sage_tool_in_flight"
+)
+
+// ToolMetrics provides tool execution metrics.
+type ToolMetrics struct {
+	collector Collector
+}
+
+// NewToolMetrics creates a new tool metrics collector.
+func NewToolMetrics(collector Collector) *ToolMetrics {
+	return &ToolMetrics{
+		collector: collector,
+	}
+}
+
+// RecordExecution records a finished tool execution with its status
+// (e.g. "success", "error", "timeout") and latency in seconds.
+func (m *ToolMetrics) RecordExecution(tool, status string, latency float64) {
+	labels := NewLabels("tool", tool, "status", status)
+	m.collector.IncrementCounter(MetricToolExecutions, labels)
+	m.collector.ObserveHistogram(MetricToolExecutionLatency, latency, NewLabels("tool", tool))
+}
+
+// RecordRetry records a retried tool execution attempt.
+func (m *ToolMetrics) RecordRetry(tool string) {
+	m.collector.IncrementCounter(MetricToolRetries, NewLabels("tool", tool))
+}
+
+// RecordDenied records a tool call rejected by the access policy.
+func (m *ToolMetrics) RecordDenied(tool, agentID, tenantID string) {
+	labels := NewLabels(
+		"tool", tool,
+		"agent_id", agentID,
+		"tenant_id", tenantID,
+	)
+	m.collector.IncrementCounter(MetricToolDenied, labels)
+}
+
+// SetInFlight sets the number of tool executions currently running.
+func (m *ToolMetrics) SetInFlight(count float64) {
+	m.collector.SetGauge(MetricToolInFlight, count, NoLabels())
+}
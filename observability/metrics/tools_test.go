@@ -0,0 +1,56 @@
+// Copyright (C) 2025 sage-x-project
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with this program. If not, see <https://www.gnu.org/licenses/>.
+
+// SPDX-License-Identifier: LGPL-3.0-or-later
+
+package metrics
+
+import (
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestToolMetrics(t *testing.T) {
+	collector := NewPrometheusCollector()
+	toolMetrics := NewToolMetrics(collector)
+
+	toolMetrics.RecordExecution("calculator", "success", 0.012)
+	toolMetrics.RecordRetry("calculator")
+	toolMetrics.RecordDenied("shell", "agent-1", "tenant-a")
+	toolMetrics.SetInFlight(2)
+
+	req := httptest.NewRequest("GET", "/metrics", nil)
+	w := httptest.NewRecorder()
+	collector.Handler().ServeHTTP(w, req)
+
+	body := w.Body.String()
+
+	for _, name := range []string{
+		MetricToolExecutions,
+		MetricToolExecutionLatency,
+		MetricToolRetries,
+		MetricToolDenied,
+		MetricToolInFlight,
+	} {
+		if !strings.Contains(body, name) {
+			t.Errorf("%s metric not found", name)
+		}
+	}
+
+	if !strings.Contains(body, `status="success"`) {
+		t.Error("status label not found")
+	}
+}
@@ -80,7 +80,10 @@ func TestPredefinedErrors_Security(t *testing.T) {
 		{"ErrSignatureInvalid", ErrSignatureInvalid},
 		{"ErrDIDNotFound", ErrDIDNotFound},
 		{"ErrAgentInactive", ErrAgentInactive},
+		{"ErrReplayDetected", ErrReplayDetected},
+		{"ErrSessionOutOfSync", ErrSessionOutOfSync},
 		{"ErrUnauthorized", ErrUnauthorized},
+		{"ErrAccessDenied", ErrAccessDenied},
 		{"ErrInvalidCredentials", ErrInvalidCredentials},
 	}
 
@@ -40,6 +40,21 @@ var (
 		Message:  "agent is deactivated",
 	}
 
+	// ErrReplayDetected indicates a nonce was used before.
+	ErrReplayDetected = &Error{
+		Category: CategorySecurity,
+		Code:     "REPLAY_DETECTED",
+		Message:  "nonce replay detected",
+	}
+
+	// ErrSessionOutOfSync indicates the peers of an encrypted session no
+	// longer derive the same keys, so the session must be re-established.
+	ErrSessionOutOfSync = &Error{
+		Category: CategorySecurity,
+		Code:     "SESSION_OUT_OF_SYNC",
+		Message:  "session keys out of sync",
+	}
+
 	// ErrUnauthorized indicates unauthorized access.
 	ErrUnauthorized = &Error{
 		Category: CategoryUnauthorized,
@@ -47,6 +62,13 @@ var (
 		Message:  "unauthorized access",
 	}
 
+	// ErrAccessDenied indicates an authorization policy denied the request.
+	ErrAccessDenied = &Error{
+		Category: CategoryUnauthorized,
+		Code:     "ACCESS_DENIED",
+		Message:  "access denied by policy",
+	}
+
 	// ErrInvalidCredentials indicates invalid credentials.
 	ErrInvalidCredentials = &Error{
 		Category: CategoryUnauthorized,
@@ -0,0 +1,290 @@
+// Copyright (C) 2025 sage-x-project
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with this program. If not, see <https://www.gnu.org/licenses/>.
+
+// SPDX-License-Identifier: LGPL-3.0-or-later
+
+package mtls
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/sage-x-project/sage-adk/pkg/errors"
+)
+
+// ClientAuth is how a server requests and verifies client certificates.
+type ClientAuth string
+
+const (
+	// ClientAuthNone does not request client certificates.
+	ClientAuthNone ClientAuth = "none"
+
+	// ClientAuthRequest requests a certificate but does not require or
+	// verify it.
+	ClientAuthRequest ClientAuth = "request"
+
+	// ClientAuthRequireAny requires a certificate but does not verify it.
+	ClientAuthRequireAny ClientAuth = "require_any"
+
+	// ClientAuthVerifyIfGiven verifies a certificate if one is presented.
+	ClientAuthVerifyIfGiven ClientAuth = "verify_if_given"
+
+	// ClientAuthRequireAndVerify requires a certificate signed by CAFile:
+	// mutual TLS.
+	ClientAuthRequireAndVerify ClientAuth = "require_and_verify"
+)
+
+// DefaultReloadInterval is how often certificate files are checked for
+// changes.
+const DefaultReloadInterval = 30 * time.Second
+
+// Config configures TLS for a server or a client.
+type Config struct {
+	// CertFile and KeyFile hold the PEM certificate chain and private key
+	// presented to peers. Servers require them; clients present them for
+	// mutual TLS.
+	CertFile string `json:"cert_file,omitempty" yaml:"cert_file,omitempty"`
+	KeyFile  string `json:"key_file,omitempty" yaml:"key_file,omitempty"`
+
+	// CAFile is a PEM bundle of trusted CAs: on servers the CAs client
+	// certificates must chain to, on clients the CAs server certificates
+	// must chain to (default: system roots).
+	CAFile string `json:"ca_file,omitempty" yaml:"ca_file,omitempty"`
+
+	// ClientAuth is how servers verify client certificates (default
+	// require_and_verify with a CAFile, none without).
+	ClientAuth ClientAuth `json:"client_auth,omitempty" yaml:"client_auth,omitempty"`
+
+	// ServerName overrides the name clients verify in the server
+	// certificate.
+	ServerName string `json:"server_name,omitempty" yaml:"server_name,omitempty"`
+
+	// InsecureSkipVerify disables server certificate verification on
+	// clients. For development only.
+	InsecureSkipVerify bool `json:"insecure_skip_verify,omitempty" yaml:"insecure_skip_verify,omitempty"`
+
+	// MinVersion is the minimum TLS version, "1.2" (default) or "1.3".
+	MinVersion string `json:"min_version,omitempty" yaml:"min_version,omitempty"`
+
+	// ReloadInterval is how often certificate files are checked for
+	// changes during handshakes (default DefaultReloadInterval).
+	ReloadInterval time.Duration `json:"reload_interval,omitempty" yaml:"reload_interval,omitempty"`
+}
+
+// Enabled reports whether any TLS setting is present.
+func (c *Config) Enabled() bool {
+	return c != nil && (c.CertFile != "" || c.KeyFile != "" || c.CAFile != "" || c.InsecureSkipVerify)
+}
+
+// Validate checks the settings without reading any file.
+func (c *Config) Validate() error {
+	if (c.CertFile == "") != (c.KeyFile == "") {
+		return errors.ErrInvalidInput.WithMessage("TLS cert_file and key_file must be set together")
+	}
+
+	switch c.ClientAuth {
+	case "", ClientAuthNone, ClientAuthRequest, ClientAuthRequireAny:
+	case ClientAuthVerifyIfGiven, ClientAuthRequireAndVerify:
+		if c.CAFile == "" {
+			return errors.ErrInvalidInput.
+				WithMessage("TLS client_auth requires ca_file").
+				WithDetail("client_auth", string(c.ClientAuth))
+		}
+	default:
+		return errors.ErrInvalidValue.
+			WithMessage("TLS client_auth must be none, request, require_any, verify_if_given or require_and_verify").
+			WithDetail("client_auth", string(c.ClientAuth))
+	}
+
+	if _, err := c.minVersion(); err != nil {
+		return err
+	}
+	return nil
+}
+
+// ServerTLS returns a server TLS configuration that reloads the
+// certificate and client CA bundle when their files change.
+func (c *Config) ServerTLS() (*tls.Config, error) {
+	if err := c.Validate(); err != nil {
+		return nil, err
+	}
+	if c.CertFile == "" {
+		return nil, errors.ErrInvalidInput.WithMessage("TLS servers require cert_file and key_file")
+	}
+
+	version, _ := c.minVersion()
+	certs, err := c.certificates()
+	if err != nil {
+		return nil, err
+	}
+
+	base := &tls.Config{
+		MinVersion: version,
+		ClientAuth: c.clientAuth(),
+		GetCertificate: func(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+			return certs.get(), nil
+		},
+	}
+	if c.CAFile == "" {
+		return base, nil
+	}
+
+	pool, err := c.pool()
+	if err != nil {
+		return nil, err
+	}
+	base.ClientCAs = pool.get()
+
+	server := base.Clone()
+	server.GetConfigForClient = func(*tls.ClientHelloInfo) (*tls.Config, error) {
+		cfg := base.Clone()
+		cfg.ClientCAs = pool.get()
+		return cfg, nil
+	}
+	return server, nil
+}
+
+// ClientTLS returns a client TLS configuration that presents CertFile, if
+// set, and verifies servers against CAFile, reloading both when their
+// files change.
+func (c *Config) ClientTLS() (*tls.Config, error) {
+	if err := c.Validate(); err != nil {
+		return nil, err
+	}
+
+	version, _ := c.minVersion()
+	cfg := &tls.Config{
+		MinVersion:         version,
+		ServerName:         c.ServerName,
+		InsecureSkipVerify: c.InsecureSkipVerify,
+	}
+
+	if c.CertFile != "" {
+		certs, err := c.certificates()
+		if err != nil {
+			return nil, err
+		}
+		cfg.GetClientCertificate = func(*tls.CertificateRequestInfo) (*tls.Certificate, error) {
+			return certs.get(), nil
+		}
+	}
+
+	if c.CAFile != "" && !c.InsecureSkipVerify {
+		pool, err := c.pool()
+		if err != nil {
+			return nil, err
+		}
+
+		// Verify with the current roots ourselves; RootCAs would pin the
+		// bundle loaded at startup.
+		cfg.InsecureSkipVerify = true
+		cfg.VerifyConnection = func(cs tls.ConnectionState) error {
+			return verifyServer(cs, pool.get())
+		}
+	}
+	return cfg, nil
+}
+
+// verifyServer verifies a server certificate chain and name against roots.
+func verifyServer(cs tls.ConnectionState, roots *x509.CertPool) error {
+	if len(cs.PeerCertificates) == 0 {
+		return fmt.Errorf("server presented no certificate")
+	}
+
+	intermediates := x509.NewCertPool()
+	for _, cert := range cs.PeerCertificates[1:] {
+		intermediates.AddCert(cert)
+	}
+	_, err := cs.PeerCertificates[0].Verify(x509.VerifyOptions{
+		DNSName:       cs.ServerName,
+		Roots:         roots,
+		Intermediates: intermediates,
+	})
+	return err
+}
+
+func (c *Config) clientAuth() tls.ClientAuthType {
+	switch c.ClientAuth {
+	case ClientAuthRequest:
+		return tls.RequestClientCert
+	case ClientAuthRequireAny:
+		return tls.RequireAnyClientCert
+	case ClientAuthVerifyIfGiven:
+		return tls.VerifyClientCertIfGiven
+	case ClientAuthRequireAndVerify:
+		return tls.RequireAndVerifyClientCert
+	case "":
+		if c.CAFile != "" {
+			return tls.RequireAndVerifyClientCert
+		}
+	}
+	return tls.NoClientCert
+}
+
+func (c *Config) minVersion() (uint16, error) {
+	switch c.MinVersion {
+	case "", "1.2":
+		return tls.VersionTLS12, nil
+	case "1.3":
+		return tls.VersionTLS13, nil
+	}
+	return 0, errors.ErrInvalidValue.
+		WithMessage("TLS min_version must be 1.2 or 1.3").
+		WithDetail("min_version", c.MinVersion)
+}
+
+func (c *Config) interval() time.Duration {
+	if c.ReloadInterval > 0 {
+		return c.ReloadInterval
+	}
+	return DefaultReloadInterval
+}
+
+// certificates watches the certificate and key files.
+func (c *Config) certificates() (*reloading[*tls.Certificate], error) {
+	return newReloading(c.interval(), []string{c.CertFile, c.KeyFile}, func() (*tls.Certificate, error) {
+		cert, err := tls.LoadX509KeyPair(c.CertFile, c.KeyFile)
+		if err != nil {
+			return nil, errors.ErrConfigurationError.
+				WithMessage("failed to load TLS certificate").
+				WithDetail("cert_file", c.CertFile).
+				WithDetail("error", err.Error())
+		}
+		return &cert, nil
+	})
+}
+
+// pool watches the CA bundle file.
+func (c *Config) pool() (*reloading[*x509.CertPool], error) {
+	return newReloading(c.interval(), []string{c.CAFile}, func() (*x509.CertPool, error) {
+		data, err := os.ReadFile(c.CAFile)
+		if err != nil {
+			return nil, errors.ErrConfigurationError.
+				WithMessage("failed to read TLS CA bundle").
+				WithDetail("ca_file", c.CAFile).
+				WithDetail("error", err.Error())
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(data) {
+			return nil, errors.ErrConfigurationError.
+				WithMessage("TLS CA bundle contains no certificates").
+				WithDetail("ca_file", c.CAFile)
+		}
+		return pool, nil
+	})
+}
@@ -0,0 +1,46 @@
+// Copyright (C) 2025 sage-x-project
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with this program. If not, see <https://www.gnu.org/licenses/>.
+
+// SPDX-License-Identifier: LGPL-3.0-or-later
+
+// Package mtls builds TLS and mutual TLS configurations for agent servers
+// and clients.
+//
+// A Config names PEM certificate, key and CA bundle files and how peer
+// certificates are verified. It is the tls section of config.ServerConfig
+// and is shared by the gRPC server and client, the A2A server and the SAGE
+// NetworkServer:
+//
+//	server:
+//	  tls:
+//	    cert_file: /etc/agent/tls.crt
+//	    key_file: /etc/agent/tls.key
+//	    ca_file: /etc/agent/clients-ca.pem
+//	    client_auth: require_and_verify
+//
+// Certificates and CA bundles are re-read when their files change, checked
+// at most every ReloadInterval during handshakes, so rotating the files
+// (for example by cert-manager or a SPIFFE agent) needs no restart. A file
+// that fails to load leaves the previous certificate in use.
+//
+// The verified peer certificate is summarized as a PeerIdentity, carrying
+// its SPIFFE ID and subject alternative names. Middleware puts it in the
+// request context for HTTP handlers; the gRPC server does the same, and
+// authz.CallerFromContext uses it as the caller's peer identity:
+//
+//	if peer, ok := mtls.PeerFromContext(ctx); ok {
+//	    log.Printf("called by %s", peer.SPIFFEID)
+//	}
+package mtls
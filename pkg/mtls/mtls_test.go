@@ -0,0 +1,210 @@
+// Copyright (C) 2025 sage-x-project
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with this program. If not, see <https://www.gnu.org/licenses/>.
+
+// SPDX-License-Identifier: LGPL-3.0-or-later
+
+package mtls
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+type testCA struct {
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+	pem  []byte
+}
+
+var serial int64
+
+func newTestCA(t *testing.T) *testCA {
+	t.Helper()
+
+	key, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	serial++
+	tmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(serial),
+		Subject:               pkix.Name{CommonName: "test CA"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert, _ := x509.ParseCertificate(der)
+	return &testCA{cert: cert, key: key, pem: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})}
+}
+
+// issue writes a leaf certificate and key to dir and returns their paths.
+func (ca *testCA) issue(t *testing.T, dir, name string, tmpl *x509.Certificate) (string, string) {
+	t.Helper()
+
+	key, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	serial++
+	tmpl.SerialNumber = big.NewInt(serial)
+	tmpl.Subject = pkix.Name{CommonName: name}
+	tmpl.NotBefore = time.Now().Add(-time.Hour)
+	tmpl.NotAfter = time.Now().Add(time.Hour)
+	tmpl.ExtKeyUsage = []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth}
+
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, ca.cert, &key.PublicKey, ca.key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyDER, _ := x509.MarshalECPrivateKey(key)
+
+	certFile := filepath.Join(dir, name+".crt")
+	keyFile := filepath.Join(dir, name+".key")
+	writeFile(t, certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}))
+	writeFile(t, keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}))
+	return certFile, keyFile
+}
+
+func writeFile(t *testing.T, file string, data []byte) {
+	t.Helper()
+	if err := os.WriteFile(file, data, 0o600); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestMutualTLS(t *testing.T) {
+	dir := t.TempDir()
+	ca := newTestCA(t)
+	caFile := filepath.Join(dir, "ca.pem")
+	writeFile(t, caFile, ca.pem)
+
+	serverCert, serverKey := ca.issue(t, dir, "server", &x509.Certificate{
+		DNSNames:    []string{"localhost"},
+		IPAddresses: []net.IP{net.ParseIP("127.0.0.1")},
+	})
+	spiffe, _ := url.Parse("spiffe://example.org/agent/alice")
+	clientCert, clientKey := ca.issue(t, dir, "client", &x509.Certificate{URIs: []*url.URL{spiffe}})
+
+	serverTLS, err := (&Config{CertFile: serverCert, KeyFile: serverKey, CAFile: caFile}).ServerTLS()
+	if err != nil {
+		t.Fatalf("ServerTLS() error = %v", err)
+	}
+
+	var peer *PeerIdentity
+	server := httptest.NewUnstartedServer(Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		peer, _ = PeerFromContext(r.Context())
+	})))
+	server.TLS = serverTLS
+	server.StartTLS()
+	defer server.Close()
+
+	newClient := func(c *Config) *http.Client {
+		clientTLS, err := c.ClientTLS()
+		if err != nil {
+			t.Fatalf("ClientTLS() error = %v", err)
+		}
+		return &http.Client{Transport: &http.Transport{TLSClientConfig: clientTLS}}
+	}
+
+	resp, err := newClient(&Config{CertFile: clientCert, KeyFile: clientKey, CAFile: caFile, ServerName: "localhost"}).Get(server.URL)
+	if err != nil {
+		t.Fatalf("mutual TLS request error = %v", err)
+	}
+	resp.Body.Close()
+
+	if peer == nil || peer.SPIFFEID != spiffe.String() || !peer.Verified || peer.Name() != spiffe.String() {
+		t.Fatalf("peer = %+v", peer)
+	}
+
+	// Without a client certificate the handshake fails.
+	if _, err := newClient(&Config{CAFile: caFile, ServerName: "localhost"}).Get(server.URL); err == nil {
+		t.Error("request without client certificate succeeded")
+	}
+
+	// A server signed by another CA is rejected.
+	otherCA := filepath.Join(dir, "other.pem")
+	writeFile(t, otherCA, newTestCA(t).pem)
+	if _, err := newClient(&Config{CertFile: clientCert, KeyFile: clientKey, CAFile: otherCA}).Get(server.URL); err == nil {
+		t.Error("request to untrusted server succeeded")
+	}
+}
+
+func TestReloadOnRotation(t *testing.T) {
+	dir := t.TempDir()
+	ca := newTestCA(t)
+	certFile, keyFile := ca.issue(t, dir, "server", &x509.Certificate{DNSNames: []string{"localhost"}})
+
+	serverTLS, err := (&Config{CertFile: certFile, KeyFile: keyFile, ReloadInterval: time.Nanosecond}).ServerTLS()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	first, _ := serverTLS.GetCertificate(&tls.ClientHelloInfo{})
+
+	// Rotate the files in place.
+	rotatedCert, rotatedKey := ca.issue(t, dir, "rotated", &x509.Certificate{DNSNames: []string{"localhost"}})
+	for src, dst := range map[string]string{rotatedCert: certFile, rotatedKey: keyFile} {
+		data, _ := os.ReadFile(src)
+		writeFile(t, dst, data)
+		future := time.Now().Add(time.Minute)
+		os.Chtimes(dst, future, future)
+	}
+
+	second, _ := serverTLS.GetCertificate(&tls.ClientHelloInfo{})
+	if string(first.Certificate[0]) == string(second.Certificate[0]) {
+		t.Error("certificate was not reloaded")
+	}
+
+	// A broken file keeps the previous certificate.
+	writeFile(t, certFile, []byte("garbage"))
+	third, _ := serverTLS.GetCertificate(&tls.ClientHelloInfo{})
+	if string(third.Certificate[0]) != string(second.Certificate[0]) {
+		t.Error("broken certificate replaced the previous one")
+	}
+}
+
+func TestConfig_Validate(t *testing.T) {
+	tests := []struct {
+		name   string
+		config Config
+	}{
+		{"cert without key", Config{CertFile: "a.crt"}},
+		{"verify without CA", Config{CertFile: "a.crt", KeyFile: "a.key", ClientAuth: ClientAuthRequireAndVerify}},
+		{"unknown client auth", Config{ClientAuth: "always"}},
+		{"bad version", Config{MinVersion: "1.1"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.config.Validate(); err == nil {
+				t.Error("Validate() error = nil")
+			}
+		})
+	}
+}
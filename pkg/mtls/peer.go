@@ -0,0 +1,122 @@
+// Copyright (C) 2025 sage-x-project
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with this program. If not, see <https://www.gnu.org/licenses/>.
+
+// SPDX-License-Identifier: LGPL-3.0-or-later
+
+package mtls
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"net/http"
+)
+
+// PeerIdentity describes the certificate a peer presented.
+type PeerIdentity struct {
+	// SPIFFEID is the peer's spiffe:// URI SAN, if any.
+	SPIFFEID string
+
+	// DNSNames, URIs and EmailAddresses are the certificate's subject
+	// alternative names.
+	DNSNames       []string
+	URIs           []string
+	EmailAddresses []string
+
+	// Subject is the certificate subject's common name.
+	Subject string
+
+	// Verified reports whether the certificate was verified against the
+	// server's client CAs. Unverified certificates, accepted with the
+	// request or require_any modes, must not be trusted as identities.
+	Verified bool
+
+	// Certificate is the peer's leaf certificate.
+	Certificate *x509.Certificate
+}
+
+// Name returns the most specific name of the peer: its SPIFFE ID, else its
+// first DNS name, else its subject common name.
+func (p *PeerIdentity) Name() string {
+	switch {
+	case p.SPIFFEID != "":
+		return p.SPIFFEID
+	case len(p.DNSNames) > 0:
+		return p.DNSNames[0]
+	}
+	return p.Subject
+}
+
+// Names returns every name of the peer, for matching against policies.
+func (p *PeerIdentity) Names() []string {
+	names := append([]string{}, p.URIs...)
+	names = append(names, p.DNSNames...)
+	names = append(names, p.EmailAddresses...)
+	if p.Subject != "" {
+		names = append(names, p.Subject)
+	}
+	return names
+}
+
+// IdentityFromCertificate summarizes a peer certificate.
+func IdentityFromCertificate(cert *x509.Certificate, verified bool) *PeerIdentity {
+	id := &PeerIdentity{
+		DNSNames:       cert.DNSNames,
+		EmailAddresses: cert.EmailAddresses,
+		Subject:        cert.Subject.CommonName,
+		Verified:       verified,
+		Certificate:    cert,
+	}
+	for _, uri := range cert.URIs {
+		id.URIs = append(id.URIs, uri.String())
+		if uri.Scheme == "spiffe" && id.SPIFFEID == "" {
+			id.SPIFFEID = uri.String()
+		}
+	}
+	return id
+}
+
+// IdentityFromConnectionState returns the identity of the peer of a TLS
+// connection, or nil if it presented no certificate.
+func IdentityFromConnectionState(cs *tls.ConnectionState) *PeerIdentity {
+	if cs == nil || len(cs.PeerCertificates) == 0 {
+		return nil
+	}
+	return IdentityFromCertificate(cs.PeerCertificates[0], len(cs.VerifiedChains) > 0)
+}
+
+type contextKey struct{}
+
+// WithPeer records the peer identity in ctx.
+func WithPeer(ctx context.Context, peer *PeerIdentity) context.Context {
+	return context.WithValue(ctx, contextKey{}, peer)
+}
+
+// PeerFromContext returns the peer identity recorded with WithPeer.
+func PeerFromContext(ctx context.Context) (*PeerIdentity, bool) {
+	peer, ok := ctx.Value(contextKey{}).(*PeerIdentity)
+	return peer, ok && peer != nil
+}
+
+// Middleware records the identity of the TLS client certificate, if any,
+// in the request context.
+func Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if peer := IdentityFromConnectionState(r.TLS); peer != nil {
+			r = r.WithContext(WithPeer(r.Context(), peer))
+		}
+		next.ServeHTTP(w, r)
+	})
+}
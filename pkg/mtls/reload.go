@@ -0,0 +1,102 @@
+// Copyright (C) 2025 sage-x-project
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with this program. If not, see <https://www.gnu.org/licenses/>.
+
+// SPDX-License-Identifier: LGPL-3.0-or-later
+
+package mtls
+
+import (
+	"os"
+	"sync"
+	"time"
+)
+
+// reloading holds a value loaded from files and reloads it when any of the
+// files changes. Changes are checked lazily, at most once per interval.
+type reloading[T any] struct {
+	files    []string
+	interval time.Duration
+	load     func() (T, error)
+	now      func() time.Time
+
+	mu      sync.Mutex
+	value   T
+	stamps  []fileStamp
+	checked time.Time
+}
+
+// fileStamp identifies a version of a file.
+type fileStamp struct {
+	modTime time.Time
+	size    int64
+}
+
+// newReloading loads the initial value, which must succeed.
+func newReloading[T any](interval time.Duration, files []string, load func() (T, error)) (*reloading[T], error) {
+	r := &reloading[T]{files: files, interval: interval, load: load, now: time.Now}
+
+	value, err := load()
+	if err != nil {
+		return nil, err
+	}
+	r.value = value
+	r.stamps = r.stat()
+	r.checked = r.now()
+	return r, nil
+}
+
+// get returns the current value, reloading it first if the files changed
+// since the last check. A failed reload keeps the previous value.
+func (r *reloading[T]) get() T {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := r.now()
+	if now.Sub(r.checked) < r.interval {
+		return r.value
+	}
+	r.checked = now
+
+	stamps := r.stat()
+	if equalStamps(stamps, r.stamps) {
+		return r.value
+	}
+
+	// Retry on the next check if the files are mid-rotation
+	if value, err := r.load(); err == nil {
+		r.value = value
+		r.stamps = stamps
+	}
+	return r.value
+}
+
+func (r *reloading[T]) stat() []fileStamp {
+	stamps := make([]fileStamp, len(r.files))
+	for i, file := range r.files {
+		if info, err := os.Stat(file); err == nil {
+			stamps[i] = fileStamp{modTime: info.ModTime(), size: info.Size()}
+		}
+	}
+	return stamps
+}
+
+func equalStamps(a, b []fileStamp) bool {
+	for i := range a {
+		if !a[i].modTime.Equal(b[i].modTime) || a[i].size != b[i].size {
+			return false
+		}
+	}
+	return true
+}
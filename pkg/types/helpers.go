@@ -137,9 +137,28 @@ type AgentCard struct {
 	Description  string
 	Version      string
 	Capabilities []string
+	Skills       []AgentSkill
 	Metadata     map[string]interface{}
 }
 
+// AgentSkill describes a task an agent is able to perform.
+type AgentSkill struct {
+	// ID uniquely identifies the skill within the agent.
+	ID string
+
+	// Name is a human-readable skill name.
+	Name string
+
+	// Description explains what the skill does.
+	Description string
+
+	// Tags categorize the skill.
+	Tags []string
+
+	// Examples are sample requests the skill handles.
+	Examples []string
+}
+
 // NewAgentCard creates a new AgentCard with generated ID.
 func NewAgentCard(name, description, version string) *AgentCard {
 	return &AgentCard{
@@ -340,6 +340,12 @@ func (m *Message) UnmarshalJSON(data []byte) error {
 	return nil
 }
 
+// UnmarshalPart decodes a single part, choosing the concrete type by its
+// kind.
+func UnmarshalPart(data []byte) (Part, error) {
+	return unmarshalPart(data)
+}
+
 // unmarshalPart determines the concrete type of a Part from raw JSON.
 func unmarshalPart(rawPart json.RawMessage) (Part, error) {
 	var typeDetect struct {
@@ -7,6 +7,7 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/sage-x-project/sage-adk/core/authn"
 	"github.com/sage-x-project/sage-adk/pkg/types"
 )
 
@@ -124,6 +125,18 @@ func PerUserKeyFunc(ctx context.Context, msg *types.Message) string {
 	return "anonymous"
 }
 
+// PerPrincipalKeyFunc generates a key based on the authenticated caller,
+// falling back to the anonymous key for unauthenticated requests
+func PerPrincipalKeyFunc(ctx context.Context, msg *types.Message) string {
+	if principal, ok := authn.PrincipalFromContext(ctx); ok {
+		if principal.Tenant != "" {
+			return fmt.Sprintf("principal:%s:%s", principal.Tenant, principal.Subject)
+		}
+		return fmt.Sprintf("principal:%s", principal.Subject)
+	}
+	return "anonymous"
+}
+
 // PerContextKeyFunc generates a key based on context ID
 func PerContextKeyFunc(ctx context.Context, msg *types.Message) string {
 	if msg.ContextID != nil {
@@ -0,0 +1,106 @@
+// Copyright (C) 2025 sage-x-project
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with this program. If not, see <https://www.gnu.org/licenses/>.
+
+// SPDX-License-Identifier: LGPL-3.0-or-later
+
+package grpc
+
+import (
+	"context"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/sage-x-project/sage-adk/core/authn"
+	"github.com/sage-x-project/sage-adk/pkg/errors"
+)
+
+// publicMethods are served without authentication, like the HTTP health
+// and agent card endpoints.
+var publicMethods = map[string]bool{
+	"/sage.adk.v1.AgentService/HealthCheck":  true,
+	"/sage.adk.v1.AgentService/GetAgentInfo": true,
+}
+
+// UnaryAuthnInterceptor authenticates unary calls with the API key or
+// bearer token in the call metadata and records the principal in the
+// handler context.
+func UnaryAuthnInterceptor(authenticator *authn.Authenticator) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if isPublicMethod(info.FullMethod) {
+			return handler(ctx, req)
+		}
+		ctx, err := authenticate(ctx, authenticator)
+		if err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// StreamAuthnInterceptor authenticates streams when they are opened and
+// records the principal in the stream context.
+func StreamAuthnInterceptor(authenticator *authn.Authenticator) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if isPublicMethod(info.FullMethod) {
+			return handler(srv, ss)
+		}
+		ctx, err := authenticate(ss.Context(), authenticator)
+		if err != nil {
+			return err
+		}
+		return handler(srv, &peerStream{ServerStream: ss, ctx: ctx})
+	}
+}
+
+func isPublicMethod(method string) bool {
+	return publicMethods[method] || strings.HasPrefix(method, "/grpc.health.v1.Health/")
+}
+
+// authenticate verifies the credentials in the incoming metadata.
+func authenticate(ctx context.Context, authenticator *authn.Authenticator) (context.Context, error) {
+	var creds authn.Credentials
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		creds.APIKey = first(md.Get(strings.ToLower(authn.HeaderAPIKey)))
+		creds.Bearer = authn.BearerToken(first(md.Get(strings.ToLower(authn.HeaderAuthorization))))
+	}
+
+	principal, err := authenticator.Authenticate(ctx, creds)
+	if err != nil {
+		return nil, authnStatus(err)
+	}
+	if principal != nil {
+		ctx = authn.WithPrincipal(ctx, principal)
+	}
+	return ctx, nil
+}
+
+func first(values []string) string {
+	if len(values) == 0 {
+		return ""
+	}
+	return values[0]
+}
+
+// authnStatus converts an authentication error into a gRPC status.
+func authnStatus(err error) error {
+	if errors.IsUnauthorized(err) {
+		return status.Error(codes.Unauthenticated, err.Error())
+	}
+	return status.Error(codes.Unavailable, err.Error())
+}
@@ -0,0 +1,101 @@
+// Copyright (C) 2025 sage-x-project
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with this program. If not, see <https://www.gnu.org/licenses/>.
+
+// SPDX-License-Identifier: LGPL-3.0-or-later
+
+package grpc
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/sage-x-project/sage-adk/core/authz"
+	"github.com/sage-x-project/sage-adk/pkg/errors"
+	pb "github.com/sage-x-project/sage-adk/proto/pb"
+)
+
+// UnaryAuthzInterceptor authorizes SendMessage calls with authorizer.
+// Other unary RPCs pass through; the task RPCs only reach the tasks the
+// caller created (see task.Authorize).
+func UnaryAuthzInterceptor(authorizer *authz.Authorizer) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		send, ok := req.(*pb.SendMessageRequest)
+		if !ok || send.Message == nil {
+			return handler(ctx, req)
+		}
+
+		msg, err := ProtoToMessage(send.Message)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid message: %v", err)
+		}
+
+		ctx, err = authorizer.AuthorizeMessage(ctx, msg)
+		if err != nil {
+			return nil, authzStatus(err)
+		}
+		return handler(ctx, req)
+	}
+}
+
+// StreamAuthzInterceptor authorizes every message received on a
+// StreamMessages stream. A denied message fails the stream with
+// PermissionDenied; control messages are not checked.
+func StreamAuthzInterceptor(authorizer *authz.Authorizer) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		return handler(srv, &authzStream{ServerStream: ss, authorizer: authorizer})
+	}
+}
+
+// authzStream authorizes inbound stream messages as they are received.
+type authzStream struct {
+	grpc.ServerStream
+	authorizer *authz.Authorizer
+}
+
+// RecvMsg receives a message and authorizes it before handing it on.
+func (s *authzStream) RecvMsg(m interface{}) error {
+	if err := s.ServerStream.RecvMsg(m); err != nil {
+		return err
+	}
+
+	req, ok := m.(*pb.StreamMessageRequest)
+	if !ok {
+		return nil
+	}
+	r, ok := req.Request.(*pb.StreamMessageRequest_Message)
+	if !ok {
+		return nil
+	}
+
+	msg, err := ProtoToMessage(r.Message)
+	if err != nil {
+		return status.Errorf(codes.InvalidArgument, "invalid message: %v", err)
+	}
+	if _, err := s.authorizer.AuthorizeMessage(s.Context(), msg); err != nil {
+		return authzStatus(err)
+	}
+	return nil
+}
+
+// authzStatus converts an authorization error into a gRPC status.
+func authzStatus(err error) error {
+	if errors.Is(err, errors.ErrAccessDenied) {
+		return status.Error(codes.PermissionDenied, err.Error())
+	}
+	return status.Error(codes.Internal, err.Error())
+}
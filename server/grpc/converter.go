@@ -6,8 +6,10 @@ package grpc
 import (
 	"fmt"
 
+	"google.golang.org/protobuf/types/known/structpb"
 	"google.golang.org/protobuf/types/known/timestamppb"
 
+	"github.com/sage-x-project/sage-adk/core/task"
 	"github.com/sage-x-project/sage-adk/pkg/types"
 	pb "github.com/sage-x-project/sage-adk/proto/pb"
 )
@@ -209,3 +211,313 @@ func MessageRoleToProto(role types.MessageRole) pb.MessageRole {
 		return pb.MessageRole_MESSAGE_ROLE_UNSPECIFIED
 	}
 }
+
+// taskStates maps internal task states to protobuf task states
+var taskStates = map[types.TaskState]pb.TaskState{
+	types.TaskStateSubmitted:     pb.TaskState_TASK_STATE_SUBMITTED,
+	types.TaskStateWorking:       pb.TaskState_TASK_STATE_WORKING,
+	types.TaskStateInputRequired: pb.TaskState_TASK_STATE_INPUT_REQUIRED,
+	types.TaskStateCompleted:     pb.TaskState_TASK_STATE_COMPLETED,
+	types.TaskStateCanceled:      pb.TaskState_TASK_STATE_CANCELED,
+	types.TaskStateFailed:        pb.TaskState_TASK_STATE_FAILED,
+	types.TaskStateRejected:      pb.TaskState_TASK_STATE_REJECTED,
+	types.TaskStateAuthRequired:  pb.TaskState_TASK_STATE_AUTH_REQUIRED,
+	types.TaskStateUnknown:       pb.TaskState_TASK_STATE_UNKNOWN,
+}
+
+// TaskStateToProto converts internal TaskState to protobuf TaskState
+func TaskStateToProto(state types.TaskState) pb.TaskState {
+	if pbState, ok := taskStates[state]; ok {
+		return pbState
+	}
+	return pb.TaskState_TASK_STATE_UNSPECIFIED
+}
+
+// TaskStateFromProto converts protobuf TaskState to internal TaskState.
+// TASK_STATE_UNSPECIFIED converts to an empty state.
+func TaskStateFromProto(state pb.TaskState) types.TaskState {
+	for internal, pbState := range taskStates {
+		if pbState == state {
+			return internal
+		}
+	}
+	return ""
+}
+
+// TaskToProto converts internal Task to protobuf Task. A positive
+// historyLength keeps only the most recent history messages.
+func TaskToProto(t *types.Task, historyLength int) (*pb.Task, error) {
+	if t == nil {
+		return nil, fmt.Errorf("nil task")
+	}
+
+	status, err := TaskStatusToProto(t.Status)
+	if err != nil {
+		return nil, err
+	}
+
+	pbTask := &pb.Task{
+		Id:        t.ID,
+		ContextId: t.ContextID,
+		Status:    status,
+		CreatedAt: timestamppb.New(t.CreatedAt),
+		UpdatedAt: timestamppb.New(t.UpdatedAt),
+	}
+
+	for i := range t.Artifacts {
+		artifact, err := ArtifactToProto(&t.Artifacts[i])
+		if err != nil {
+			return nil, err
+		}
+		pbTask.Artifacts = append(pbTask.Artifacts, artifact)
+	}
+
+	history := t.History
+	if historyLength > 0 && len(history) > historyLength {
+		history = history[len(history)-historyLength:]
+	}
+	for i := range history {
+		msg, err := MessageToProto(&history[i])
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert history: %w", err)
+		}
+		pbTask.History = append(pbTask.History, msg)
+	}
+
+	if len(t.Metadata) > 0 {
+		if pbTask.Metadata, err = structpb.NewStruct(t.Metadata); err != nil {
+			return nil, fmt.Errorf("failed to convert task metadata: %w", err)
+		}
+	}
+
+	return pbTask, nil
+}
+
+// TaskFromProto converts protobuf Task to internal Task
+func TaskFromProto(pbTask *pb.Task) (*types.Task, error) {
+	if pbTask == nil {
+		return nil, fmt.Errorf("nil protobuf task")
+	}
+
+	status, err := TaskStatusFromProto(pbTask.Status)
+	if err != nil {
+		return nil, err
+	}
+
+	t := &types.Task{
+		ID:        pbTask.Id,
+		ContextID: pbTask.ContextId,
+		Kind:      "task",
+		Status:    status,
+		CreatedAt: pbTask.CreatedAt.AsTime(),
+		UpdatedAt: pbTask.UpdatedAt.AsTime(),
+	}
+
+	for _, pbArtifact := range pbTask.Artifacts {
+		artifact, err := ArtifactFromProto(pbArtifact)
+		if err != nil {
+			return nil, err
+		}
+		t.Artifacts = append(t.Artifacts, *artifact)
+	}
+
+	for _, pbMsg := range pbTask.History {
+		msg, err := ProtoToMessage(pbMsg)
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert history: %w", err)
+		}
+		t.History = append(t.History, *msg)
+	}
+
+	if pbTask.Metadata != nil {
+		t.Metadata = pbTask.Metadata.AsMap()
+	}
+
+	return t, nil
+}
+
+// TaskStatusToProto converts internal TaskStatus to protobuf TaskStatus
+func TaskStatusToProto(status types.TaskStatus) (*pb.TaskStatus, error) {
+	pbStatus := &pb.TaskStatus{
+		State:     TaskStateToProto(status.State),
+		Timestamp: timestamppb.New(status.Timestamp),
+	}
+
+	if status.Message != nil {
+		msg, err := MessageToProto(status.Message)
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert status message: %w", err)
+		}
+		pbStatus.Message = msg
+	}
+
+	return pbStatus, nil
+}
+
+// TaskStatusFromProto converts protobuf TaskStatus to internal TaskStatus
+func TaskStatusFromProto(pbStatus *pb.TaskStatus) (types.TaskStatus, error) {
+	if pbStatus == nil {
+		return types.TaskStatus{}, fmt.Errorf("nil protobuf task status")
+	}
+
+	status := types.TaskStatus{
+		State:     TaskStateFromProto(pbStatus.State),
+		Timestamp: pbStatus.Timestamp.AsTime(),
+	}
+
+	if pbStatus.Message != nil {
+		msg, err := ProtoToMessage(pbStatus.Message)
+		if err != nil {
+			return types.TaskStatus{}, fmt.Errorf("failed to convert status message: %w", err)
+		}
+		status.Message = msg
+	}
+
+	return status, nil
+}
+
+// ArtifactToProto converts internal Artifact to protobuf Artifact
+func ArtifactToProto(artifact *types.Artifact) (*pb.Artifact, error) {
+	if artifact == nil {
+		return nil, fmt.Errorf("nil artifact")
+	}
+
+	pbArtifact := &pb.Artifact{
+		ArtifactId:  artifact.ArtifactID,
+		Name:        artifact.Name,
+		Description: artifact.Description,
+		Extensions:  artifact.Extensions,
+		CreatedAt:   timestamppb.New(artifact.CreatedAt),
+	}
+
+	for _, part := range artifact.Parts {
+		pbPart, err := PartToProto(part)
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert artifact part: %w", err)
+		}
+		pbArtifact.Parts = append(pbArtifact.Parts, pbPart)
+	}
+
+	if len(artifact.Metadata) > 0 {
+		metadata, err := structpb.NewStruct(artifact.Metadata)
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert artifact metadata: %w", err)
+		}
+		pbArtifact.Metadata = metadata
+	}
+
+	return pbArtifact, nil
+}
+
+// ArtifactFromProto converts protobuf Artifact to internal Artifact
+func ArtifactFromProto(pbArtifact *pb.Artifact) (*types.Artifact, error) {
+	if pbArtifact == nil {
+		return nil, fmt.Errorf("nil protobuf artifact")
+	}
+
+	artifact := &types.Artifact{
+		ArtifactID:  pbArtifact.ArtifactId,
+		Name:        pbArtifact.Name,
+		Description: pbArtifact.Description,
+		Extensions:  pbArtifact.Extensions,
+		CreatedAt:   pbArtifact.CreatedAt.AsTime(),
+	}
+
+	for _, pbPart := range pbArtifact.Parts {
+		part, err := PartFromProto(pbPart)
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert artifact part: %w", err)
+		}
+		artifact.Parts = append(artifact.Parts, part)
+	}
+
+	if pbArtifact.Metadata != nil {
+		artifact.Metadata = pbArtifact.Metadata.AsMap()
+	}
+
+	return artifact, nil
+}
+
+// TaskEventToProto converts a task store event to protobuf TaskEvent
+func TaskEventToProto(event task.Event) (*pb.TaskEvent, error) {
+	switch {
+	case event.Status != nil:
+		status, err := TaskStatusToProto(event.Status.Status)
+		if err != nil {
+			return nil, err
+		}
+		return &pb.TaskEvent{
+			Event: &pb.TaskEvent_StatusUpdate{
+				StatusUpdate: &pb.TaskStatusUpdateEvent{
+					TaskId:    event.Status.TaskID,
+					ContextId: event.Status.ContextID,
+					Status:    status,
+					Final:     event.Status.Final,
+				},
+			},
+		}, nil
+
+	case event.Artifact != nil:
+		artifact, err := ArtifactToProto(&event.Artifact.Artifact)
+		if err != nil {
+			return nil, err
+		}
+		update := &pb.TaskArtifactUpdateEvent{
+			TaskId:    event.Artifact.TaskID,
+			ContextId: event.Artifact.ContextID,
+			Artifact:  artifact,
+		}
+		if event.Artifact.Append != nil {
+			update.Append = *event.Artifact.Append
+		}
+		if event.Artifact.LastChunk != nil {
+			update.LastChunk = *event.Artifact.LastChunk
+		}
+		return &pb.TaskEvent{
+			Event: &pb.TaskEvent_ArtifactUpdate{ArtifactUpdate: update},
+		}, nil
+
+	default:
+		return nil, fmt.Errorf("empty task event")
+	}
+}
+
+// TaskEventFromProto converts protobuf TaskEvent to a task store event
+func TaskEventFromProto(pbEvent *pb.TaskEvent) (task.Event, error) {
+	if pbEvent == nil {
+		return task.Event{}, fmt.Errorf("nil protobuf task event")
+	}
+
+	switch e := pbEvent.Event.(type) {
+	case *pb.TaskEvent_StatusUpdate:
+		status, err := TaskStatusFromProto(e.StatusUpdate.Status)
+		if err != nil {
+			return task.Event{}, err
+		}
+		return task.Event{Status: &types.TaskStatusUpdateEvent{
+			ContextID: e.StatusUpdate.ContextId,
+			TaskID:    e.StatusUpdate.TaskId,
+			Kind:      "status-update",
+			Status:    status,
+			Final:     e.StatusUpdate.Final,
+		}}, nil
+
+	case *pb.TaskEvent_ArtifactUpdate:
+		artifact, err := ArtifactFromProto(e.ArtifactUpdate.Artifact)
+		if err != nil {
+			return task.Event{}, err
+		}
+		appendChunk, lastChunk := e.ArtifactUpdate.Append, e.ArtifactUpdate.LastChunk
+		return task.Event{Artifact: &types.TaskArtifactUpdateEvent{
+			ContextID: e.ArtifactUpdate.ContextId,
+			TaskID:    e.ArtifactUpdate.TaskId,
+			Kind:      "artifact-update",
+			Artifact:  *artifact,
+			Append:    &appendChunk,
+			LastChunk: &lastChunk,
+		}}, nil
+
+	default:
+		return task.Event{}, fmt.Errorf("unknown task event type")
+	}
+}
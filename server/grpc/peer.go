@@ -0,0 +1,72 @@
+// Copyright (C) 2025 sage-x-project
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with this program. If not, see <https://www.gnu.org/licenses/>.
+
+// SPDX-License-Identifier: LGPL-3.0-or-later
+
+package grpc
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+
+	"github.com/sage-x-project/sage-adk/pkg/mtls"
+)
+
+// UnaryPeerInterceptor records the client certificate identity of TLS
+// connections in the handler context, for mtls.PeerFromContext.
+func UnaryPeerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		return handler(withPeerIdentity(ctx), req)
+	}
+}
+
+// StreamPeerInterceptor records the client certificate identity of TLS
+// connections in the stream context, for mtls.PeerFromContext.
+func StreamPeerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		return handler(srv, &peerStream{ServerStream: ss, ctx: withPeerIdentity(ss.Context())})
+	}
+}
+
+// peerStream overrides the context of a server stream.
+type peerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+// Context returns the context carrying the peer identity.
+func (s *peerStream) Context() context.Context {
+	return s.ctx
+}
+
+// withPeerIdentity adds the identity of the TLS client certificate, if
+// any, to ctx.
+func withPeerIdentity(ctx context.Context) context.Context {
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return ctx
+	}
+	info, ok := p.AuthInfo.(credentials.TLSInfo)
+	if !ok {
+		return ctx
+	}
+	if id := mtls.IdentityFromConnectionState(&info.State); id != nil {
+		return mtls.WithPeer(ctx, id)
+	}
+	return ctx
+}
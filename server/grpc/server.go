@@ -25,7 +25,11 @@ Features:
   - Unary RPC for single message exchange
   - Bidirectional streaming for real-time communication
   - Agent metadata and health check endpoints
+  - Task polling, listing, cancellation and subscription (core/task)
   - Automatic protocol conversion (gRPC ↔ internal types)
+  - Optional API key and JWT bearer authentication (core/authn)
+  - Optional policy-based authorization of inbound messages (core/authz)
+  - Optional TLS and mutual TLS with certificate hot-reload (pkg/mtls)
   - Connection management and graceful shutdown
 
 Example:
@@ -65,6 +69,7 @@ import (
 
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/credentials/insecure"
 	"google.golang.org/grpc/health"
 	healthpb "google.golang.org/grpc/health/grpc_health_v1"
@@ -74,6 +79,10 @@ import (
 	"google.golang.org/protobuf/types/known/timestamppb"
 
 	"github.com/sage-x-project/sage-adk/core/agent"
+	"github.com/sage-x-project/sage-adk/core/authn"
+	"github.com/sage-x-project/sage-adk/core/authz"
+	"github.com/sage-x-project/sage-adk/core/task"
+	"github.com/sage-x-project/sage-adk/pkg/mtls"
 	pb "github.com/sage-x-project/sage-adk/proto/pb"
 )
 
@@ -82,6 +91,7 @@ type Server struct {
 	pb.UnimplementedAgentServiceServer
 
 	agent      agent.Agent
+	tasks      task.Store
 	grpcServer *grpc.Server
 	config     ServerConfig
 	listener   net.Listener
@@ -89,6 +99,9 @@ type Server struct {
 	// Health checker
 	healthServer *health.Server
 
+	// TLS setup error, reported by Start
+	tlsErr error
+
 	// Active streams tracking
 	mu      sync.RWMutex
 	streams map[string]context.CancelFunc
@@ -108,6 +121,22 @@ type ServerConfig struct {
 
 	// Maximum message size
 	MaxMessageSize int
+
+	// Authenticator authenticates callers with API keys or bearer tokens
+	// (optional; all callers are accepted without it)
+	Authenticator *authn.Authenticator
+
+	// Authorizer authorizes inbound messages (optional)
+	Authorizer *authz.Authorizer
+
+	// TLS serves over TLS, or mutual TLS with a client CA (optional;
+	// plaintext without it)
+	TLS *mtls.Config
+
+	// Tasks records the tasks of processed messages. Share the A2A
+	// server's store so tasks can be followed over either protocol
+	// (optional; an in-memory store is used without it)
+	Tasks task.Store
 }
 
 // DefaultServerConfig returns default server configuration
@@ -136,7 +165,38 @@ func NewServer(ag agent.Agent, config ServerConfig) *Server {
 		}),
 		grpc.MaxRecvMsgSize(config.MaxMessageSize),
 		grpc.MaxSendMsgSize(config.MaxMessageSize),
-		grpc.Creds(insecure.NewCredentials()),
+	}
+
+	// Transport credentials; the peer and authentication interceptors
+	// run before authorization so policies can match their identities
+	var tlsErr error
+	creds := insecure.NewCredentials()
+	if config.TLS.Enabled() {
+		tlsConfig, err := config.TLS.ServerTLS()
+		if err != nil {
+			tlsErr = err
+		} else {
+			creds = credentials.NewTLS(tlsConfig)
+			opts = append(opts,
+				grpc.ChainUnaryInterceptor(UnaryPeerInterceptor()),
+				grpc.ChainStreamInterceptor(StreamPeerInterceptor()),
+			)
+		}
+	}
+	opts = append(opts, grpc.Creds(creds))
+
+	if config.Authenticator != nil {
+		opts = append(opts,
+			grpc.ChainUnaryInterceptor(UnaryAuthnInterceptor(config.Authenticator)),
+			grpc.ChainStreamInterceptor(StreamAuthnInterceptor(config.Authenticator)),
+		)
+	}
+
+	if config.Authorizer != nil {
+		opts = append(opts,
+			grpc.ChainUnaryInterceptor(UnaryAuthzInterceptor(config.Authorizer)),
+			grpc.ChainStreamInterceptor(StreamAuthzInterceptor(config.Authorizer)),
+		)
 	}
 
 	grpcServer := grpc.NewServer(opts...)
@@ -146,11 +206,18 @@ func NewServer(ag agent.Agent, config ServerConfig) *Server {
 	healthServer.SetServingStatus("", healthpb.HealthCheckResponse_SERVING)
 	healthServer.SetServingStatus("sage.adk.v1.AgentService", healthpb.HealthCheckResponse_SERVING)
 
+	tasks := config.Tasks
+	if tasks == nil {
+		tasks = task.NewMemoryStore(nil)
+	}
+
 	s := &Server{
 		agent:        ag,
+		tasks:        tasks,
 		grpcServer:   grpcServer,
 		config:       config,
 		healthServer: healthServer,
+		tlsErr:       tlsErr,
 		streams:      make(map[string]context.CancelFunc),
 	}
 
@@ -166,6 +233,10 @@ func NewServer(ag agent.Agent, config ServerConfig) *Server {
 
 // Start starts the gRPC server
 func (s *Server) Start() error {
+	if s.tlsErr != nil {
+		return fmt.Errorf("invalid TLS configuration: %w", s.tlsErr)
+	}
+
 	addr := fmt.Sprintf(":%d", s.config.Port)
 	listener, err := net.Listen("tcp", addr)
 	if err != nil {
@@ -230,10 +301,10 @@ func (s *Server) SendMessage(ctx context.Context, req *pb.SendMessageRequest) (*
 		return nil, status.Errorf(codes.InvalidArgument, "invalid message: %v", err)
 	}
 
-	// Process message through agent
-	response, err := s.agent.Process(ctx, msg)
+	// Process message through agent, recorded as a task
+	_, response, err := task.Run(ctx, s.tasks, msg, s.agent.Process)
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "agent processing failed: %v", err)
+		return nil, processStatus(err)
 	}
 
 	// Convert response back to protobuf
@@ -282,6 +353,10 @@ func (s *Server) StreamMessages(stream pb.AgentService_StreamMessagesServer) err
 		if err == io.EOF {
 			return nil
 		}
+		if _, ok := status.FromError(err); ok && err != nil {
+			// Already a status, e.g. PermissionDenied from the authorizer
+			return err
+		}
 		if err != nil {
 			return status.Errorf(codes.Internal, "stream receive error: %v", err)
 		}
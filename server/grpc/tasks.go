@@ -0,0 +1,177 @@
+// Copyright (C) 2025 sage-x-project
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as
+// published by the Free Software Foundation, either version 3 of the
+// License, or (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with this program. If not, see <https://www.gnu.org/licenses/>.
+
+// SPDX-License-Identifier: LGPL-3.0-or-later
+
+package grpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/sage-x-project/sage-adk/core/task"
+	"github.com/sage-x-project/sage-adk/pkg/types"
+	pb "github.com/sage-x-project/sage-adk/proto/pb"
+)
+
+// GetTask returns one of the caller's tasks from the task store. Tasks
+// created by another principal are reported as not found.
+func (s *Server) GetTask(ctx context.Context, req *pb.GetTaskRequest) (*pb.Task, error) {
+	if req.TaskId == "" {
+		return nil, status.Error(codes.InvalidArgument, "task_id is required")
+	}
+
+	t, err := s.ownTask(ctx, req.TaskId)
+	if err != nil {
+		return nil, taskStatus(err)
+	}
+
+	pbTask, err := TaskToProto(t, int(req.HistoryLength))
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "task conversion failed: %v", err)
+	}
+	return pbTask, nil
+}
+
+// ListTasks lists the caller's tasks from the task store, newest first
+func (s *Server) ListTasks(ctx context.Context, req *pb.ListTasksRequest) (*pb.ListTasksResponse, error) {
+	owner := task.OwnerFromContext(ctx)
+	page, err := s.tasks.List(ctx, &task.Query{
+		ContextID: req.ContextId,
+		State:     TaskStateFromProto(req.State),
+		Owner:     &owner,
+		PageSize:  int(req.PageSize),
+		PageToken: req.PageToken,
+	})
+	if err != nil {
+		return nil, taskStatus(err)
+	}
+
+	resp := &pb.ListTasksResponse{NextPageToken: page.NextPageToken}
+	for _, t := range page.Tasks {
+		pbTask, err := TaskToProto(t, 0)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "task conversion failed: %v", err)
+		}
+		resp.Tasks = append(resp.Tasks, pbTask)
+	}
+	return resp, nil
+}
+
+// CancelTask cancels one of the caller's tasks that has not finished. The
+// agent's processing of the task is canceled too.
+func (s *Server) CancelTask(ctx context.Context, req *pb.CancelTaskRequest) (*pb.Task, error) {
+	if req.TaskId == "" {
+		return nil, status.Error(codes.InvalidArgument, "task_id is required")
+	}
+
+	if _, err := s.ownTask(ctx, req.TaskId); err != nil {
+		return nil, taskStatus(err)
+	}
+	t, err := s.tasks.Cancel(ctx, req.TaskId)
+	if err != nil {
+		return nil, taskStatus(err)
+	}
+
+	pbTask, err := TaskToProto(t, 0)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "task conversion failed: %v", err)
+	}
+	return pbTask, nil
+}
+
+// SubscribeTask streams the updates of one of the caller's tasks until it
+// finishes
+func (s *Server) SubscribeTask(req *pb.SubscribeTaskRequest, stream pb.AgentService_SubscribeTaskServer) error {
+	if req.TaskId == "" {
+		return status.Error(codes.InvalidArgument, "task_id is required")
+	}
+
+	if _, err := s.ownTask(stream.Context(), req.TaskId); err != nil {
+		return taskStatus(err)
+	}
+
+	// Track the subscription like a message stream, so Stop ends it
+	streamID := fmt.Sprintf("task-%s-%d", req.TaskId, time.Now().UnixNano())
+	ctx, cancel := context.WithCancel(stream.Context())
+	defer cancel()
+
+	s.mu.Lock()
+	s.streams[streamID] = cancel
+	s.mu.Unlock()
+
+	defer func() {
+		s.mu.Lock()
+		delete(s.streams, streamID)
+		s.mu.Unlock()
+	}()
+
+	events, err := s.tasks.Subscribe(ctx, req.TaskId)
+	if err != nil {
+		return taskStatus(err)
+	}
+
+	for event := range events {
+		pbEvent, err := TaskEventToProto(event)
+		if err != nil {
+			return status.Errorf(codes.Internal, "event conversion failed: %v", err)
+		}
+		if err := stream.Send(pbEvent); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ownTask returns the task with taskID if the caller in ctx created it.
+func (s *Server) ownTask(ctx context.Context, taskID string) (*types.Task, error) {
+	t, err := s.tasks.Get(ctx, taskID)
+	if err != nil {
+		return nil, err
+	}
+	if err := task.Authorize(ctx, t); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+// taskStatus converts a task store error into a gRPC status.
+func taskStatus(err error) error {
+	switch {
+	case errors.Is(err, task.ErrTaskNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, task.ErrTaskFinished):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	case errors.Is(err, task.ErrTaskCanceled):
+		return status.Error(codes.Canceled, err.Error())
+	case errors.Is(err, task.ErrInvalidTaskID), errors.Is(err, task.ErrInvalidPageToken):
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	return status.Error(codes.Internal, err.Error())
+}
+
+// processStatus converts an error of processing a message as a task into
+// a gRPC status.
+func processStatus(err error) error {
+	if errors.Is(err, task.ErrTaskCanceled) || errors.Is(err, task.ErrTaskFinished) || errors.Is(err, task.ErrTaskNotFound) {
+		return taskStatus(err)
+	}
+	return status.Errorf(codes.Internal, "agent processing failed: %v", err)
+}